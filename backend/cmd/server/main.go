@@ -46,7 +46,7 @@ func main() {
 	cache := redis.NewCacheClient(config.REDIS_ADDRESS, config.REDIS_PASSWORD, 1)
 
 	repo := mysql.NewMySQLRepo(store)
-	paymentService := payments.NewPaymentService(repo, store)
+	paymentService := payments.NewPaymentService(repo, store, config)
 
 	sender := pkg.NewGmailSender(config.EMAIL_SENDER_NAME, config.EMAIL_SENDER_ADDRESS, config.EMAIL_SENDER_PASSWORD)
 
@@ -56,7 +56,13 @@ func main() {
 		DB: 0,
 	}
 
-	worker := workers.NewWorkerService(redisConfig, sender, repo, *maker)
+	scheduleConfig := services.ScheduleConfig{
+		LoanDefaultCron:      config.LOAN_DEFAULT_CRON,
+		LoanDefaultGraceDays: config.LOAN_DEFAULT_GRACE_DAYS,
+		LoanPenaltyCron:      config.LOAN_PENALTY_CRON,
+	}
+
+	worker := workers.NewWorkerService(redisConfig, scheduleConfig, sender, repo, *maker)
 
 	err = worker.StartProcessor()
 	if err != nil {
@@ -0,0 +1,248 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/services"
+	"github.com/EmilioCliff/kokomed-fin/backend/pkg"
+	"github.com/gin-gonic/gin"
+)
+
+type createAssignmentRuleRequest struct {
+	Name              string  `json:"name"              binding:"required"`
+	RuleType          string  `json:"ruleType"          binding:"required,oneof=ID_NUMBER LOAN_PREFIX NAME_AMOUNT"`
+	Priority          uint32  `json:"priority"`
+	Active            *bool   `json:"active"`
+	Prefix            string  `json:"prefix"`
+	DaysWindow        uint32  `json:"daysWindow"`
+	MinNameSimilarity float64 `json:"minNameSimilarity"`
+}
+
+func (s *Server) createAssignmentRule(ctx *gin.Context) {
+	var req createAssignmentRuleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, err.Error())))
+
+		return
+	}
+
+	payload, ok := ctx.Get(authorizationPayloadKey)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "missing token"})
+
+		return
+	}
+
+	payloadData, ok := payload.(*pkg.Payload)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "incorrect token"})
+
+		return
+	}
+
+	if strings.ToLower(payloadData.Role) != "admin" {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "not authorized"})
+
+		return
+	}
+
+	ruleData := services.AssignmentRuleData{
+		Name:              req.Name,
+		RuleType:          req.RuleType,
+		Priority:          req.Priority,
+		Active:            true,
+		Prefix:            req.Prefix,
+		DaysWindow:        req.DaysWindow,
+		MinNameSimilarity: req.MinNameSimilarity,
+		CreatedBy:         payloadData.UserID,
+	}
+
+	if req.Active != nil {
+		ruleData.Active = *req.Active
+	}
+
+	rule, err := s.payments.CreateAssignmentRule(ctx, &ruleData)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": rule})
+}
+
+func (s *Server) listAssignmentRules(ctx *gin.Context) {
+	payload, ok := ctx.Get(authorizationPayloadKey)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "missing token"})
+
+		return
+	}
+
+	payloadData, ok := payload.(*pkg.Payload)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "incorrect token"})
+
+		return
+	}
+
+	if strings.ToLower(payloadData.Role) != "admin" {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "not authorized"})
+
+		return
+	}
+
+	rules, err := s.payments.ListAssignmentRules(ctx)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": rules})
+}
+
+type updateAssignmentRuleRequest struct {
+	Name              *string  `json:"name"`
+	Priority          *uint32  `json:"priority"`
+	Active            *bool    `json:"active"`
+	Prefix            *string  `json:"prefix"`
+	DaysWindow        *uint32  `json:"daysWindow"`
+	MinNameSimilarity *float64 `json:"minNameSimilarity"`
+}
+
+func (s *Server) updateAssignmentRule(ctx *gin.Context) {
+	var req updateAssignmentRuleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, err.Error())))
+
+		return
+	}
+
+	id, err := pkg.StringToUint32(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+
+		return
+	}
+
+	payload, ok := ctx.Get(authorizationPayloadKey)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "missing token"})
+
+		return
+	}
+
+	payloadData, ok := payload.(*pkg.Payload)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "incorrect token"})
+
+		return
+	}
+
+	if strings.ToLower(payloadData.Role) != "admin" {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "not authorized"})
+
+		return
+	}
+
+	rule, err := s.payments.UpdateAssignmentRule(ctx, id, &services.UpdateAssignmentRuleData{
+		Name:              req.Name,
+		Priority:          req.Priority,
+		Active:            req.Active,
+		Prefix:            req.Prefix,
+		DaysWindow:        req.DaysWindow,
+		MinNameSimilarity: req.MinNameSimilarity,
+	})
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": rule})
+}
+
+func (s *Server) deleteAssignmentRule(ctx *gin.Context) {
+	id, err := pkg.StringToUint32(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+
+		return
+	}
+
+	payload, ok := ctx.Get(authorizationPayloadKey)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "missing token"})
+
+		return
+	}
+
+	payloadData, ok := payload.(*pkg.Payload)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "incorrect token"})
+
+		return
+	}
+
+	if strings.ToLower(payloadData.Role) != "admin" {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "not authorized"})
+
+		return
+	}
+
+	if err := s.payments.DeleteAssignmentRule(ctx, id); err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "assignment rule deleted"})
+}
+
+type dryRunAssignmentRulesRequest struct {
+	RuleIDs []uint32 `json:"ruleIds"`
+	Limit   int      `json:"limit"`
+}
+
+func (s *Server) dryRunAssignmentRules(ctx *gin.Context) {
+	// the body is optional, without rule ids every active rule is evaluated
+	var req dryRunAssignmentRulesRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, err.Error())))
+
+		return
+	}
+
+	payload, ok := ctx.Get(authorizationPayloadKey)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "missing token"})
+
+		return
+	}
+
+	payloadData, ok := payload.(*pkg.Payload)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "incorrect token"})
+
+		return
+	}
+
+	if strings.ToLower(payloadData.Role) != "admin" {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "not authorized"})
+
+		return
+	}
+
+	results, err := s.payments.DryRunAssignmentRules(ctx, req.RuleIDs, req.Limit)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": results})
+}
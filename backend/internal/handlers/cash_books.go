@@ -0,0 +1,232 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/repository"
+	"github.com/EmilioCliff/kokomed-fin/backend/pkg"
+	"github.com/gin-gonic/gin"
+)
+
+func (s *Server) getCashBook(ctx *gin.Context) {
+	branchID, err := pkg.StringToUint32(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+
+		return
+	}
+
+	date := time.Now()
+
+	if value := ctx.Query("date"); value != "" {
+		date, err = time.Parse("2006-01-02", value)
+		if err != nil {
+			ctx.JSON(
+				http.StatusBadRequest,
+				errorResponse(pkg.Errorf(pkg.INVALID_ERROR, "date must be YYYY-MM-DD")),
+			)
+
+			return
+		}
+	}
+
+	if _, err := s.repo.Branches.GetBranchByID(ctx, branchID); err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+
+		return
+	}
+
+	cashBook, err := s.repo.CashBooks.GetCashBook(ctx, branchID, date)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": cashBook})
+}
+
+type createBranchExpenseRequest struct {
+	Amount      float64 `binding:"required,gt=0" json:"amount"`
+	Description string  `binding:"required"      json:"description"`
+	Date        string  `                        json:"date"`
+}
+
+func (s *Server) createBranchExpense(ctx *gin.Context) {
+	var req createBranchExpenseRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, err.Error())))
+
+		return
+	}
+
+	branchID, err := pkg.StringToUint32(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+
+		return
+	}
+
+	payload, ok := ctx.Get(authorizationPayloadKey)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "missing token"})
+
+		return
+	}
+
+	payloadData, ok := payload.(*pkg.Payload)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "incorrect token"})
+
+		return
+	}
+
+	date := time.Now()
+
+	if req.Date != "" {
+		date, err = time.Parse("2006-01-02", req.Date)
+		if err != nil {
+			ctx.JSON(
+				http.StatusBadRequest,
+				errorResponse(pkg.Errorf(pkg.INVALID_ERROR, "date must be YYYY-MM-DD")),
+			)
+
+			return
+		}
+	}
+
+	if _, err := s.repo.Branches.GetBranchByID(ctx, branchID); err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+
+		return
+	}
+
+	expense, err := s.repo.CashBooks.CreateExpense(ctx, &repository.BranchExpense{
+		BranchID:    branchID,
+		ExpenseDate: date,
+		Amount:      pkg.MoneyFromFloat(req.Amount),
+		Description: req.Description,
+		CreatedBy:   payloadData.UserID,
+	})
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": expense})
+}
+
+type closeCashBookRequest struct {
+	Date        string   `binding:"required"          json:"date"`
+	CountedCash *float64 `binding:"required,gte=0"    json:"countedCash"`
+	Note        string   `                            json:"note"`
+}
+
+func (s *Server) closeCashBook(ctx *gin.Context) {
+	var req closeCashBookRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, err.Error())))
+
+		return
+	}
+
+	branchID, err := pkg.StringToUint32(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+
+		return
+	}
+
+	date, err := time.Parse("2006-01-02", req.Date)
+	if err != nil {
+		ctx.JSON(
+			http.StatusBadRequest,
+			errorResponse(pkg.Errorf(pkg.INVALID_ERROR, "date must be YYYY-MM-DD")),
+		)
+
+		return
+	}
+
+	payload, ok := ctx.Get(authorizationPayloadKey)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "missing token"})
+
+		return
+	}
+
+	payloadData, ok := payload.(*pkg.Payload)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "incorrect token"})
+
+		return
+	}
+
+	if strings.ToLower(payloadData.Role) != "admin" {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "not authorized"})
+
+		return
+	}
+
+	if _, err := s.repo.Branches.GetBranchByID(ctx, branchID); err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+
+		return
+	}
+
+	cashBook, err := s.repo.CashBooks.CloseCashBook(ctx, &repository.CloseCashBook{
+		BranchID:    branchID,
+		Date:        date,
+		CountedCash: pkg.MoneyFromFloat(*req.CountedCash),
+		Note:        strings.TrimSpace(req.Note),
+		ClosedBy:    payloadData.UserID,
+	})
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": cashBook})
+}
+
+func (s *Server) listCashVariances(ctx *gin.Context) {
+	branchID, err := pkg.StringToUint32(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+
+		return
+	}
+
+	pageNo, err := pkg.StringToUint32(ctx.DefaultQuery("page", "1"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, err.Error())))
+
+		return
+	}
+
+	pageSize, err := pkg.StringToUint32(ctx.DefaultQuery("limit", "10"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, err.Error())))
+
+		return
+	}
+
+	variances, metadata, err := s.repo.CashBooks.ListCashVariances(
+		ctx,
+		branchID,
+		&pkg.PaginationMetadata{CurrentPage: pageNo, PageSize: pageSize},
+	)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"metadata": metadata,
+		"data":     variances,
+	})
+}
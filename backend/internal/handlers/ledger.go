@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/EmilioCliff/kokomed-fin/backend/pkg"
+	"github.com/gin-gonic/gin"
+)
+
+func (s *Server) listLedgerAccounts(ctx *gin.Context) {
+	accounts, err := s.repo.Ledger.ListAccounts(ctx)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": accounts})
+}
+
+func (s *Server) getTrialBalance(ctx *gin.Context) {
+	date := time.Now()
+
+	if value := ctx.Query("date"); value != "" {
+		var err error
+
+		date, err = time.Parse("2006-01-02", value)
+		if err != nil {
+			ctx.JSON(
+				http.StatusBadRequest,
+				errorResponse(pkg.Errorf(pkg.INVALID_ERROR, "date must be YYYY-MM-DD")),
+			)
+
+			return
+		}
+	}
+
+	// the balance includes everything posted on the day
+	asOf := startOfNextDay(date)
+
+	trialBalance, err := s.repo.Ledger.GetTrialBalance(ctx, asOf)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": trialBalance})
+}
+
+func (s *Server) getAccountLedger(ctx *gin.Context) {
+	pageNo, err := pkg.StringToUint32(ctx.DefaultQuery("page", "1"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, err.Error())))
+
+		return
+	}
+
+	pageSize, err := pkg.StringToUint32(ctx.DefaultQuery("limit", "10"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, err.Error())))
+
+		return
+	}
+
+	today := time.Now()
+	from := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, time.UTC)
+	to := today
+
+	if value := ctx.Query("from"); value != "" {
+		from, err = time.Parse("2006-01-02", value)
+		if err != nil {
+			ctx.JSON(
+				http.StatusBadRequest,
+				errorResponse(pkg.Errorf(pkg.INVALID_ERROR, "from must be YYYY-MM-DD")),
+			)
+
+			return
+		}
+	}
+
+	if value := ctx.Query("to"); value != "" {
+		to, err = time.Parse("2006-01-02", value)
+		if err != nil {
+			ctx.JSON(
+				http.StatusBadRequest,
+				errorResponse(pkg.Errorf(pkg.INVALID_ERROR, "to must be YYYY-MM-DD")),
+			)
+
+			return
+		}
+	}
+
+	if to.Before(from) {
+		ctx.JSON(
+			http.StatusBadRequest,
+			errorResponse(pkg.Errorf(pkg.INVALID_ERROR, "to must not be before from")),
+		)
+
+		return
+	}
+
+	entries, metadata, err := s.repo.Ledger.GetAccountLedger(
+		ctx,
+		ctx.Param("code"),
+		from,
+		startOfNextDay(to),
+		&pkg.PaginationMetadata{CurrentPage: pageNo, PageSize: pageSize},
+	)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"metadata": metadata,
+		"data":     entries,
+	})
+}
+
+// startOfNextDay is the exclusive upper bound that takes in everything posted on date.
+func startOfNextDay(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location()).AddDate(0, 0, 1)
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/EmilioCliff/kokomed-fin/backend/internal/repository"
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/services"
 	"github.com/EmilioCliff/kokomed-fin/backend/pkg"
 	"github.com/gin-gonic/gin"
 )
@@ -44,6 +45,7 @@ type createLoanRequest struct {
 	InstallmentsPeriod uint32  `binding:"required" json:"installmentsPeriod"`
 	ProcessingFee      float64 `binding:"required" json:"processingFee"`
 	ProcessingFeePaid  bool    `				   json:"processingFeePaid"`
+	UseOverpayment     bool    `                   json:"useOverpayment"`
 }
 
 func (s *Server) createLoan(ctx *gin.Context) {
@@ -75,7 +77,7 @@ func (s *Server) createLoan(ctx *gin.Context) {
 		ApprovedBy:         payloadData.UserID,
 		TotalInstallments:  req.Installments,
 		InstallmentsPeriod: req.InstallmentsPeriod,
-		ProcessingFee:      req.ProcessingFee,
+		ProcessingFee:      pkg.MoneyFromFloat(req.ProcessingFee),
 		FeePaid:            false,
 		CreatedBy:          payloadData.UserID,
 		Status:             "INACTIVE",
@@ -105,6 +107,7 @@ func (s *Server) createLoan(ctx *gin.Context) {
 		params.DueDate = pkg.TimePtr(
 			disburseDate.AddDate(0, 0, int(req.Installments)*int(req.InstallmentsPeriod)),
 		)
+		params.UseOverpayment = req.UseOverpayment
 	}
 
 	if req.LoanPurpose != "" {
@@ -135,9 +138,11 @@ func (s *Server) createLoan(ctx *gin.Context) {
 
 // binding:"oneof=ACTIVE DEFAULTED"
 type disburseLoanRequest struct {
-	Status       string `json:"status"`
-	DisburseDate string `json:"disburseDate"`
-	FeePaid      bool   `json:"feePaid"`
+	Status         string `json:"status"`
+	DisburseDate   string `json:"disburseDate"`
+	FeePaid        bool   `json:"feePaid"`
+	MpesaPayout    bool   `json:"mpesaPayout"`
+	UseOverpayment bool   `json:"useOverpayment"`
 }
 
 func (s *Server) disburseLoan(ctx *gin.Context) {
@@ -169,6 +174,35 @@ func (s *Server) disburseLoan(ctx *gin.Context) {
 		return
 	}
 
+	// the loan is activated by the b2c result callback once the payout goes through
+	if req.Status == "ACTIVE" && req.MpesaPayout {
+		if req.UseOverpayment {
+			ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(
+				pkg.INVALID_ERROR,
+				"overpayment cannot be applied to an mpesa payout, disburse without mpesaPayout",
+			)))
+
+			return
+		}
+
+		rslt, err := s.payments.InitiateB2CDisbursement(ctx, &services.B2CDisbursementData{
+			LoanID:      id,
+			InitiatedBy: payloadData.UserID,
+		})
+		if err != nil {
+			ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+
+			return
+		}
+
+		s.cache.Del(ctx, fmt.Sprintf("loan:%d", id))
+		s.cache.DelAll(ctx, "loan:limit=*")
+
+		ctx.JSON(http.StatusOK, gin.H{"success": "Loan disbursement initiated", "data": rslt})
+
+		return
+	}
+
 	params := repository.DisburseLoan{
 		ID:          id,
 		DisbursedBy: payloadData.UserID,
@@ -188,6 +222,7 @@ func (s *Server) disburseLoan(ctx *gin.Context) {
 		}
 
 		params.DisbursedOn = pkg.TimePtr(disbursedDate)
+		params.UseOverpayment = req.UseOverpayment
 	}
 
 	if req.Status != "" {
@@ -214,6 +249,166 @@ func (s *Server) disburseLoan(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, gin.H{"success": "Loan disbursed successfully"})
 }
 
+type restructureLoanRequest struct {
+	Installments       uint32 `binding:"required,gt=0" json:"installments"`
+	InstallmentsPeriod uint32 `binding:"required,gt=0" json:"installmentsPeriod"`
+	Reason             string `binding:"required" json:"reason"`
+}
+
+func (s *Server) restructureLoan(ctx *gin.Context) {
+	var req restructureLoanRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, err.Error())))
+
+		return
+	}
+
+	id, err := pkg.StringToUint32(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+
+		return
+	}
+
+	payload, ok := ctx.Get(authorizationPayloadKey)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "missing token"})
+
+		return
+	}
+
+	payloadData, ok := payload.(*pkg.Payload)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "incorrect token"})
+
+		return
+	}
+
+	if strings.ToLower(payloadData.Role) != "admin" {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "not authorized"})
+
+		return
+	}
+
+	restructure, err := s.repo.Loans.RestructureLoan(ctx, &repository.RestructureLoan{
+		LoanID:             id,
+		TotalInstallments:  req.Installments,
+		InstallmentsPeriod: req.InstallmentsPeriod,
+		Reason:             req.Reason,
+		ApprovedBy:         payloadData.UserID,
+	})
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+
+		return
+	}
+
+	s.cache.Del(ctx, fmt.Sprintf("loan:%d", id))
+	s.cache.DelAll(ctx, "loan:limit=*")
+
+	ctx.JSON(http.StatusOK, restructure)
+}
+
+type topUpLoanRequest struct {
+	ProductID          uint32  `binding:"required"       json:"productId"`
+	LoanOfficerID      uint32  `binding:"required"       json:"loanOfficerId"`
+	LoanPurpose        string  `                         json:"loanPurpose"`
+	DisburseOn         string  `                         json:"disburseOn"`
+	Installments       uint32  `binding:"required,gt=0"  json:"installments"`
+	InstallmentsPeriod uint32  `binding:"required,gt=0"  json:"installmentsPeriod"`
+	ProcessingFee      float64 `binding:"required"       json:"processingFee"`
+	ProcessingFeePaid  bool    `                         json:"processingFeePaid"`
+}
+
+func (s *Server) topUpLoan(ctx *gin.Context) {
+	var req topUpLoanRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, err.Error())))
+
+		return
+	}
+
+	id, err := pkg.StringToUint32(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+
+		return
+	}
+
+	payload, ok := ctx.Get(authorizationPayloadKey)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "missing token"})
+
+		return
+	}
+
+	payloadData, ok := payload.(*pkg.Payload)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "incorrect token"})
+
+		return
+	}
+
+	if strings.ToLower(payloadData.Role) != "admin" {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "not authorized"})
+
+		return
+	}
+
+	disburseDate := time.Now()
+	if req.DisburseOn != "" {
+		disburseDate, err = time.Parse("2006-01-02", req.DisburseOn)
+		if err != nil {
+			ctx.JSON(
+				http.StatusBadRequest,
+				errorResponse(pkg.Errorf(pkg.INVALID_ERROR, "invalid disburse_on date format")),
+			)
+
+			return
+		}
+	}
+
+	newLoan := repository.Loan{
+		ProductID:          req.ProductID,
+		LoanOfficerID:      req.LoanOfficerID,
+		ApprovedBy:         payloadData.UserID,
+		TotalInstallments:  req.Installments,
+		InstallmentsPeriod: req.InstallmentsPeriod,
+		ProcessingFee:      pkg.MoneyFromFloat(req.ProcessingFee),
+		FeePaid:            req.ProcessingFeePaid,
+		CreatedBy:          payloadData.UserID,
+		DisbursedOn:        pkg.TimePtr(disburseDate),
+		DisbursedBy:        pkg.Uint32Ptr(payloadData.UserID),
+		DueDate: pkg.TimePtr(
+			disburseDate.AddDate(0, 0, int(req.Installments)*int(req.InstallmentsPeriod)),
+		),
+	}
+
+	if req.LoanPurpose != "" {
+		newLoan.LoanPurpose = pkg.StringPtr(req.LoanPurpose)
+	}
+
+	topUp, err := s.repo.Loans.TopUpLoan(ctx, &repository.TopUpLoan{
+		LoanID:  id,
+		NewLoan: newLoan,
+	})
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+
+		return
+	}
+
+	s.cache.Del(ctx, fmt.Sprintf("loan:%d", id))
+	s.cache.DelAll(ctx, "loan:limit=*")
+
+	if topUp.Loan != nil {
+		s.cache.DelAll(ctx, "client:limit=*")
+		s.cache.Del(ctx, fmt.Sprintf("client:%v", topUp.Loan.Client.ID))
+	}
+
+	ctx.JSON(http.StatusOK, topUp)
+}
+
 func (s *Server) getLoanInstallments(ctx *gin.Context) {
 	id, err := pkg.StringToUint32(ctx.Param("id"))
 	if err != nil {
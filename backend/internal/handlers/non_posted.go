@@ -221,6 +221,60 @@ func (s *Server) getNonPosted(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, response)
 }
 
+type duplicateNonPostedResponse struct {
+	TransactionNumber string              `json:"transactionNumber"`
+	TransactionSource string              `json:"transactionSource"`
+	Payments          []nonPostedResponse `json:"payments"`
+}
+
+func (s *Server) listDuplicateNonPosted(ctx *gin.Context) {
+	payload, ok := ctx.Get(authorizationPayloadKey)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "missing token"})
+
+		return
+	}
+
+	payloadData, ok := payload.(*pkg.Payload)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "incorrect token"})
+
+		return
+	}
+
+	if strings.ToLower(payloadData.Role) != "admin" {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "not authorized"})
+
+		return
+	}
+
+	payments, err := s.repo.NonPosted.ListDuplicateNonPosted(ctx)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+
+		return
+	}
+
+	// payments are ordered by transaction number so duplicates are adjacent
+	rsp := []duplicateNonPostedResponse{}
+
+	for idx := range payments {
+		last := len(rsp) - 1
+		if last < 0 || rsp[last].TransactionNumber != payments[idx].TransactionNumber ||
+			rsp[last].TransactionSource != payments[idx].TransactionSource {
+			rsp = append(rsp, duplicateNonPostedResponse{
+				TransactionNumber: payments[idx].TransactionNumber,
+				TransactionSource: payments[idx].TransactionSource,
+			})
+			last++
+		}
+
+		rsp[last].Payments = append(rsp[last].Payments, structureNonPosted(&payments[idx]))
+	}
+
+	ctx.JSON(http.StatusOK, rsp)
+}
+
 func structureNonPosted(p *repository.NonPosted) nonPostedResponse {
 	rsp := nonPostedResponse{
 		ID:                p.ID,
@@ -229,7 +283,7 @@ func structureNonPosted(p *repository.NonPosted) nonPostedResponse {
 		AccountNumber:     p.AccountNumber,
 		PhoneNumber:       p.PhoneNumber,
 		PayingName:        p.PayingName,
-		Amount:            p.Amount,
+		Amount:            p.Amount.Float64(),
 		PaidDate:          p.PaidDate,
 		AssignedBy:        p.AssignedBy,
 	}
@@ -240,10 +294,55 @@ func structureNonPosted(p *repository.NonPosted) nonPostedResponse {
 			ID:          p.AssignedClient.ID,
 			FullName:    p.AssignedClient.FullName,
 			PhoneNumber: p.AssignedClient.PhoneNumber,
-			Overpayment: p.AssignedClient.Overpayment,
+			Overpayment: p.AssignedClient.Overpayment.Float64(),
 			BranchName:  p.AssignedClient.BranchName,
 		}
 	}
 
 	return rsp
 }
+
+func (s *Server) suggestNonPostedAssignments(ctx *gin.Context) {
+	id, err := pkg.StringToUint32(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+
+		return
+	}
+
+	payload, ok := ctx.Get(authorizationPayloadKey)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "missing token"})
+
+		return
+	}
+
+	payloadData, ok := payload.(*pkg.Payload)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "incorrect token"})
+
+		return
+	}
+
+	if strings.ToLower(payloadData.Role) != "admin" {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "not authorized"})
+
+		return
+	}
+
+	limit, err := pkg.StringToUint32(ctx.DefaultQuery("limit", "5"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+
+		return
+	}
+
+	suggestions, err := s.payments.SuggestAssignments(ctx, id, int(limit))
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": suggestions})
+}
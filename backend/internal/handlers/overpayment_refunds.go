@@ -0,0 +1,280 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/services"
+	"github.com/EmilioCliff/kokomed-fin/backend/pkg"
+	"github.com/gin-gonic/gin"
+)
+
+type overpaymentRefundRequest struct {
+	Amount       float64 `json:"amount"       binding:"required,gt=0"`
+	Reason       string  `json:"reason"       binding:"required"`
+	PayoutMethod string  `json:"payoutMethod"`
+	PhoneNumber  string  `json:"phoneNumber"`
+}
+
+func (s *Server) requestOverpaymentRefund(ctx *gin.Context) {
+	var req overpaymentRefundRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, err.Error())))
+
+		return
+	}
+
+	id, err := pkg.StringToUint32(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+
+		return
+	}
+
+	payload, ok := ctx.Get(authorizationPayloadKey)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "missing token"})
+
+		return
+	}
+
+	payloadData, ok := payload.(*pkg.Payload)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "incorrect token"})
+
+		return
+	}
+
+	refund, err := s.payments.RequestOverpaymentRefund(ctx, &services.OverpaymentRefundData{
+		ClientID:     id,
+		Amount:       pkg.MoneyFromFloat(req.Amount),
+		Reason:       req.Reason,
+		PayoutMethod: req.PayoutMethod,
+		PhoneNumber:  req.PhoneNumber,
+		RequestedBy:  payloadData.UserID,
+	})
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": refund})
+}
+
+func (s *Server) listOverpaymentRefunds(ctx *gin.Context) {
+	payload, ok := ctx.Get(authorizationPayloadKey)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "missing token"})
+
+		return
+	}
+
+	payloadData, ok := payload.(*pkg.Payload)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "incorrect token"})
+
+		return
+	}
+
+	if strings.ToLower(payloadData.Role) != "admin" {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "not authorized"})
+
+		return
+	}
+
+	pageNo, err := pkg.StringToUint32(ctx.DefaultQuery("page", "1"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, err.Error())))
+
+		return
+	}
+
+	pageSize, err := pkg.StringToUint32(ctx.DefaultQuery("limit", "10"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, err.Error())))
+
+		return
+	}
+
+	var status *string
+	if ctx.Query("status") != "" {
+		status = pkg.StringPtr(ctx.Query("status"))
+	}
+
+	refunds, metadata, err := s.payments.ListOverpaymentRefunds(
+		ctx,
+		status,
+		&pkg.PaginationMetadata{CurrentPage: pageNo, PageSize: pageSize},
+	)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"metadata": metadata,
+		"data":     refunds,
+	})
+}
+
+func (s *Server) getOverpaymentRefund(ctx *gin.Context) {
+	id, err := pkg.StringToUint32(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+
+		return
+	}
+
+	refund, err := s.payments.GetOverpaymentRefund(ctx, id)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": refund})
+}
+
+type reviewOverpaymentRefundRequest struct {
+	Note string `json:"note"`
+}
+
+func (s *Server) approveOverpaymentRefund(ctx *gin.Context) {
+	var req reviewOverpaymentRefundRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, err.Error())))
+
+		return
+	}
+
+	id, err := pkg.StringToUint32(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+
+		return
+	}
+
+	payload, ok := ctx.Get(authorizationPayloadKey)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "missing token"})
+
+		return
+	}
+
+	payloadData, ok := payload.(*pkg.Payload)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "incorrect token"})
+
+		return
+	}
+
+	if strings.ToLower(payloadData.Role) != "admin" {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "not authorized"})
+
+		return
+	}
+
+	refund, err := s.payments.ApproveOverpaymentRefund(ctx, id, &services.ReviewOverpaymentRefundData{
+		ReviewedBy:    payloadData.UserID,
+		ReviewerEmail: payloadData.Email,
+		Note:          req.Note,
+	})
+	if err != nil {
+		// a failed payout has already given the client back the balance
+		if failed, lookupErr := s.payments.GetOverpaymentRefund(ctx, id); lookupErr == nil {
+			s.cache.Del(ctx, fmt.Sprintf("client:%v", failed.ClientID))
+		}
+
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+
+		return
+	}
+
+	s.cache.Del(ctx, fmt.Sprintf("client:%v", refund.ClientID))
+	s.cache.DelAll(ctx, "client:limit=*")
+
+	ctx.JSON(http.StatusOK, gin.H{"data": refund})
+}
+
+func (s *Server) rejectOverpaymentRefund(ctx *gin.Context) {
+	var req reviewOverpaymentRefundRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, err.Error())))
+
+		return
+	}
+
+	id, err := pkg.StringToUint32(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+
+		return
+	}
+
+	payload, ok := ctx.Get(authorizationPayloadKey)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "missing token"})
+
+		return
+	}
+
+	payloadData, ok := payload.(*pkg.Payload)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "incorrect token"})
+
+		return
+	}
+
+	if strings.ToLower(payloadData.Role) != "admin" {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "not authorized"})
+
+		return
+	}
+
+	refund, err := s.payments.RejectOverpaymentRefund(ctx, id, &services.ReviewOverpaymentRefundData{
+		ReviewedBy:    payloadData.UserID,
+		ReviewerEmail: payloadData.Email,
+		Note:          req.Note,
+	})
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": refund})
+}
+
+func (s *Server) downloadRefundVoucher(ctx *gin.Context) {
+	id, err := pkg.StringToUint32(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+
+		return
+	}
+
+	refund, err := s.payments.GetOverpaymentRefund(ctx, id)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+
+		return
+	}
+
+	voucher, err := s.report.GenerateRefundVoucher(ctx, refund)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+
+		return
+	}
+
+	fileName := fmt.Sprintf("refund_voucher_RF-%06d.pdf", refund.ID)
+
+	ctx.Header("Content-Type", "application/pdf")
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, fileName))
+	ctx.Data(http.StatusOK, "application/pdf", voucher)
+}
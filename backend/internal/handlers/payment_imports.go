@@ -0,0 +1,226 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/services"
+	"github.com/EmilioCliff/kokomed-fin/backend/pkg"
+	"github.com/gin-gonic/gin"
+)
+
+func (s *Server) previewPaymentImport(ctx *gin.Context) {
+	payload, ok := ctx.Get(authorizationPayloadKey)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "missing token"})
+
+		return
+	}
+
+	payloadData, ok := payload.(*pkg.Payload)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "incorrect token"})
+
+		return
+	}
+
+	if strings.ToLower(payloadData.Role) != "admin" {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "not authorized"})
+
+		return
+	}
+
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, err.Error())))
+
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, err.Error())))
+
+		return
+	}
+	defer file.Close()
+
+	lines, err := pkg.ParsePaymentImport(fileHeader.Filename, file)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+
+		return
+	}
+
+	batch, err := s.payments.PreviewPaymentImport(ctx, &services.PaymentImportData{
+		FileName:   fileHeader.Filename,
+		UploadedBy: payloadData.UserID,
+		Lines:      lines,
+	})
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": batch})
+}
+
+func (s *Server) listPaymentImports(ctx *gin.Context) {
+	pageNo, err := pkg.StringToUint32(ctx.DefaultQuery("page", "1"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, err.Error())))
+
+		return
+	}
+
+	pageSize, err := pkg.StringToUint32(ctx.DefaultQuery("limit", "10"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, err.Error())))
+
+		return
+	}
+
+	batches, metadata, err := s.payments.ListPaymentImports(
+		ctx,
+		&pkg.PaginationMetadata{CurrentPage: pageNo, PageSize: pageSize},
+	)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"metadata": metadata,
+		"data":     batches,
+	})
+}
+
+func (s *Server) getPaymentImport(ctx *gin.Context) {
+	id, err := pkg.StringToUint32(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+
+		return
+	}
+
+	batch, err := s.payments.GetPaymentImport(ctx, id)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": batch})
+}
+
+func (s *Server) postPaymentImport(ctx *gin.Context) {
+	id, err := pkg.StringToUint32(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+
+		return
+	}
+
+	payload, ok := ctx.Get(authorizationPayloadKey)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "missing token"})
+
+		return
+	}
+
+	payloadData, ok := payload.(*pkg.Payload)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "incorrect token"})
+
+		return
+	}
+
+	if strings.ToLower(payloadData.Role) != "admin" {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "not authorized"})
+
+		return
+	}
+
+	batch, err := s.payments.PostPaymentImport(ctx, id, payloadData.UserID, payloadData.Email)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+
+		return
+	}
+
+	s.invalidatePaymentImportCache(ctx, batch)
+
+	ctx.JSON(http.StatusOK, gin.H{"data": batch})
+}
+
+type rollbackPaymentImportRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+func (s *Server) rollbackPaymentImport(ctx *gin.Context) {
+	var req rollbackPaymentImportRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, err.Error())))
+
+		return
+	}
+
+	id, err := pkg.StringToUint32(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+
+		return
+	}
+
+	payload, ok := ctx.Get(authorizationPayloadKey)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "missing token"})
+
+		return
+	}
+
+	payloadData, ok := payload.(*pkg.Payload)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "incorrect token"})
+
+		return
+	}
+
+	if strings.ToLower(payloadData.Role) != "admin" {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "not authorized"})
+
+		return
+	}
+
+	batch, err := s.payments.RollbackPaymentImport(ctx, id, &services.RollbackPaymentImportData{
+		RolledBackBy: payloadData.UserID,
+		AssignedBy:   payloadData.Email,
+		Reason:       req.Reason,
+	})
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+
+		return
+	}
+
+	s.invalidatePaymentImportCache(ctx, batch)
+
+	ctx.JSON(http.StatusOK, gin.H{"data": batch})
+}
+
+func (s *Server) invalidatePaymentImportCache(ctx context.Context, batch services.PaymentImportBatch) {
+	for _, row := range batch.Rows {
+		if row.ClientID != nil && row.NonPostedID != nil {
+			s.cache.Del(ctx, fmt.Sprintf("client:%v", *row.ClientID))
+			s.cache.Del(ctx, fmt.Sprintf("non-posted:%d", *row.NonPostedID))
+		}
+	}
+
+	s.cache.DelAll(ctx, "non-posted/all:limit=*")
+	s.cache.DelAll(ctx, "loan:limit=*")
+	s.cache.DelAll(ctx, "client:limit=*")
+}
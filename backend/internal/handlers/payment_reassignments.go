@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/services"
+	"github.com/EmilioCliff/kokomed-fin/backend/pkg"
+	"github.com/gin-gonic/gin"
+)
+
+type reassignPaymentRequest struct {
+	ClientID uint32 `json:"clientId" binding:"required"`
+	Reason   string `json:"reason"   binding:"required"`
+}
+
+func (s *Server) reassignPayment(ctx *gin.Context) {
+	var req reassignPaymentRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, err.Error())))
+
+		return
+	}
+
+	id, err := pkg.StringToUint32(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+
+		return
+	}
+
+	payload, ok := ctx.Get(authorizationPayloadKey)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "missing token"})
+
+		return
+	}
+
+	payloadData, ok := payload.(*pkg.Payload)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "incorrect token"})
+
+		return
+	}
+
+	if strings.ToLower(payloadData.Role) != "admin" {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "not authorized"})
+
+		return
+	}
+
+	reassignment, err := s.payments.ReassignPayment(ctx, id, &services.ReassignPaymentData{
+		ClientID:     req.ClientID,
+		ReassignedBy: payloadData.UserID,
+		AssignedBy:   payloadData.Email,
+		Reason:       req.Reason,
+	})
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+
+		return
+	}
+
+	if reassignment.FromLoanID != nil {
+		s.cache.Del(ctx, fmt.Sprintf("loan:%d", *reassignment.FromLoanID))
+	}
+
+	if reassignment.ToLoanID != nil {
+		s.cache.Del(ctx, fmt.Sprintf("loan:%d", *reassignment.ToLoanID))
+	}
+
+	s.cache.Del(ctx, fmt.Sprintf("client:%v", reassignment.FromClientID))
+	s.cache.Del(ctx, fmt.Sprintf("client:%v", reassignment.ToClientID))
+	s.cache.Del(ctx, fmt.Sprintf("non-posted:%d", reassignment.NonPostedID))
+	s.cache.DelAll(ctx, "non-posted/all:limit=*")
+	s.cache.DelAll(ctx, "loan:limit=*")
+	s.cache.DelAll(ctx, "client:limit=*")
+
+	ctx.JSON(http.StatusOK, gin.H{"data": reassignment})
+}
+
+func (s *Server) listPaymentReassignments(ctx *gin.Context) {
+	id, err := pkg.StringToUint32(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+
+		return
+	}
+
+	reassignments, err := s.payments.ListPaymentReassignments(ctx, id)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": reassignments})
+}
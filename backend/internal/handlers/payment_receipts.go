@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/services"
+	"github.com/EmilioCliff/kokomed-fin/backend/pkg"
+	"github.com/gin-gonic/gin"
+)
+
+func (s *Server) downloadPaymentReceipt(ctx *gin.Context) {
+	id, err := pkg.StringToUint32(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+
+		return
+	}
+
+	receipt, err := s.report.GeneratePaymentReceipt(ctx, id)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+
+		return
+	}
+
+	fileName := fmt.Sprintf("receipt_%s.pdf", pkg.PaymentReceiptNumber(id))
+
+	ctx.Header("Content-Type", "application/pdf")
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, fileName))
+	ctx.Data(http.StatusOK, "application/pdf", receipt)
+}
+
+type sendPaymentReceiptRequest struct {
+	Channel   string `json:"channel"   binding:"required,oneof=email sms"`
+	Recipient string `json:"recipient"`
+}
+
+func (s *Server) sendPaymentReceipt(ctx *gin.Context) {
+	var req sendPaymentReceiptRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, err.Error())))
+
+		return
+	}
+
+	id, err := pkg.StringToUint32(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+
+		return
+	}
+
+	recipient := strings.TrimSpace(req.Recipient)
+	if req.Channel == services.ReceiptChannelEmail && recipient == "" {
+		ctx.JSON(
+			http.StatusBadRequest,
+			errorResponse(pkg.Errorf(pkg.INVALID_ERROR, "an email address is required")),
+		)
+
+		return
+	}
+
+	// fail early instead of queueing a receipt that can never be generated
+	receipt, err := s.repo.NonPosted.GetPaymentReceiptData(ctx, id)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+
+		return
+	}
+
+	err = s.worker.DistributeTaskSendPaymentReceipt(ctx, services.SendPaymentReceiptPayload{
+		NonPostedID: id,
+		Channel:     req.Channel,
+		Recipient:   recipient,
+	})
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": gin.H{"receiptNumber": receipt.ReceiptNumber}})
+}
@@ -0,0 +1,181 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/services"
+	"github.com/EmilioCliff/kokomed-fin/backend/pkg"
+	"github.com/gin-gonic/gin"
+)
+
+type splitPortionRequest struct {
+	ClientID uint32  `json:"clientId"`
+	LoanID   *uint32 `json:"loanId"`
+	Amount   float64 `json:"amount"   binding:"required,gt=0"`
+}
+
+type splitPaymentRequest struct {
+	Portions []splitPortionRequest `json:"portions" binding:"required,min=2,dive"`
+}
+
+func (s *Server) splitPayment(ctx *gin.Context) {
+	var req splitPaymentRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, err.Error())))
+
+		return
+	}
+
+	id, err := pkg.StringToUint32(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+
+		return
+	}
+
+	payload, ok := ctx.Get(authorizationPayloadKey)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "missing token"})
+
+		return
+	}
+
+	payloadData, ok := payload.(*pkg.Payload)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "incorrect token"})
+
+		return
+	}
+
+	if strings.ToLower(payloadData.Role) != "admin" {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "not authorized"})
+
+		return
+	}
+
+	splitData := services.PaymentSplitData{
+		NonPostedID: id,
+		SplitBy:     payloadData.UserID,
+		AssignedBy:  payloadData.Email,
+		Portions:    make([]services.PaymentSplitPortionData, len(req.Portions)),
+	}
+
+	for i, portion := range req.Portions {
+		if portion.ClientID == 0 && portion.LoanID == nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(
+				pkg.INVALID_ERROR,
+				"portion %d needs a clientId or a loanId",
+				i+1,
+			)))
+
+			return
+		}
+
+		splitData.Portions[i] = services.PaymentSplitPortionData{
+			ClientID: portion.ClientID,
+			LoanID:   portion.LoanID,
+			Amount:   pkg.MoneyFromFloat(portion.Amount),
+		}
+	}
+
+	split, err := s.payments.SplitPayment(ctx, &splitData)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+
+		return
+	}
+
+	s.clearPaymentSplitCache(ctx, split)
+
+	ctx.JSON(http.StatusOK, gin.H{"data": split})
+}
+
+func (s *Server) getPaymentSplit(ctx *gin.Context) {
+	id, err := pkg.StringToUint32(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+
+		return
+	}
+
+	split, err := s.payments.GetPaymentSplit(ctx, id)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": split})
+}
+
+type reversePaymentSplitRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+func (s *Server) reversePaymentSplit(ctx *gin.Context) {
+	var req reversePaymentSplitRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, err.Error())))
+
+		return
+	}
+
+	id, err := pkg.StringToUint32(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+
+		return
+	}
+
+	payload, ok := ctx.Get(authorizationPayloadKey)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "missing token"})
+
+		return
+	}
+
+	payloadData, ok := payload.(*pkg.Payload)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "incorrect token"})
+
+		return
+	}
+
+	if strings.ToLower(payloadData.Role) != "admin" {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "not authorized"})
+
+		return
+	}
+
+	split, err := s.payments.ReversePaymentSplit(ctx, id, &services.ReversePaymentSplitData{
+		ReversedBy: payloadData.UserID,
+		AssignedBy: payloadData.Email,
+		Reason:     req.Reason,
+	})
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+
+		return
+	}
+
+	s.clearPaymentSplitCache(ctx, split)
+
+	ctx.JSON(http.StatusOK, gin.H{"data": split})
+}
+
+func (s *Server) clearPaymentSplitCache(ctx *gin.Context, split services.PaymentSplit) {
+	for _, portion := range split.Portions {
+		if portion.LoanID != nil {
+			s.cache.Del(ctx, fmt.Sprintf("loan:%d", *portion.LoanID))
+		}
+
+		s.cache.Del(ctx, fmt.Sprintf("client:%v", portion.ClientID))
+	}
+
+	s.cache.Del(ctx, fmt.Sprintf("non-posted:%d", split.NonPostedID))
+	s.cache.DelAll(ctx, "non-posted/all:limit=*")
+	s.cache.DelAll(ctx, "loan:limit=*")
+	s.cache.DelAll(ctx, "client:limit=*")
+}
@@ -1,20 +1,25 @@
 package handlers
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"log"
 	"net/http"
-	"strconv"
 	"strings"
 	"time"
 
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/payments"
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/repository"
 	"github.com/EmilioCliff/kokomed-fin/backend/internal/services"
 	"github.com/EmilioCliff/kokomed-fin/backend/pkg"
 	"github.com/gin-gonic/gin"
 )
 
 func (s *Server) paymentCallback(ctx *gin.Context) {
-	var rq any
-	if err := ctx.ShouldBindJSON(&rq); err != nil {
+	rawPayload, err := ctx.GetRawData()
+	if err != nil {
 		ctx.JSON(http.StatusOK, gin.H{
 			"ResultCode": 400,
 			"ResultDesc": "Rejected",
@@ -23,10 +28,10 @@ func (s *Server) paymentCallback(ctx *gin.Context) {
 		return
 	}
 
-	req, _ := rq.(map[string]interface{})
+	var req pkg.C2BCallback
+	if err := json.Unmarshal(rawPayload, &req); err != nil {
+		s.saveCallbackPayload(ctx, "", "CONFIRMATION", rawPayload)
 
-	amountFlt, err := strconv.ParseFloat(req["TransAmount"].(string), 64)
-	if err != nil {
 		ctx.JSON(http.StatusOK, gin.H{
 			"ResultCode": 400,
 			"ResultDesc": "Rejected",
@@ -35,32 +40,109 @@ func (s *Server) paymentCallback(ctx *gin.Context) {
 		return
 	}
 
+	s.saveCallbackPayload(ctx, req.TransID, "CONFIRMATION", rawPayload)
+
+	if err := req.Validate(); err != nil {
+		ctx.JSON(http.StatusOK, gin.H{
+			"ResultCode": 400,
+			"ResultDesc": "Rejected",
+		})
+
+		return
+	}
+
+	// Validate has already checked the amount and transaction time
+	amount, _ := req.Amount()
+	paidDate, _ := req.PaidDate()
+
+	phoneNumber := req.MSISDN
+	if phoneNumber == "" {
+		phoneNumber = "***" // safaricom hidden number
+	}
+
 	callbackData := services.MpesaCallbackData{
-		TransactionID: req["TransID"].(string),
-		AccountNumber: req["BillRefNumber"].(string),
-		PhoneNumber:   "***", // safaricom hidden number
-		PayingName:    req["FirstName"].(string),
-		Amount:        amountFlt,
-		AssignedBy:    "APP",
-		AssignedTo:    nil,
+		TransactionSource: "MPESA",
+		TransactionID:     req.TransID,
+		AccountNumber:     strings.TrimSpace(req.BillRefNumber),
+		PhoneNumber:       phoneNumber,
+		PayingName:        req.PayingName(),
+		Amount:            amount,
+		AssignedBy:        "APP",
+		AssignedTo:        nil,
+		PaidDate:          pkg.TimePtr(paidDate),
+	}
+
+	if err := s.postPayment(ctx, &callbackData); err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+
+		return
 	}
 
-	if app, ok := req["App"].(string); ok && app != "" {
-		callbackData.TransactionSource = "INTERNAL"
+	ctx.JSON(http.StatusOK, gin.H{
+		"ResultCode": 0,
+		"ResultDesc": "Accepted",
+	})
+}
 
-		if email, ok := req["Email"].(string); ok && email != "" {
-			callbackData.AssignedBy = email
-		}
-	} else {
-		callbackData.TransactionSource = "MPESA"
+type internalPaymentRequest struct {
+	TransAmount   string `binding:"required" json:"TransAmount"`
+	TransID       string `binding:"required" json:"TransID"`
+	BillRefNumber string `binding:"required" json:"BillRefNumber"`
+	MSISDN        string `                   json:"MSISDN"`
+	FirstName     string `binding:"required" json:"FirstName"`
+	DatePaid      string `                   json:"DatePaid"`
+}
+
+func (s *Server) internalPayment(ctx *gin.Context) {
+	var req internalPaymentRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, err.Error())))
+
+		return
 	}
 
-	if paidDate, ok := req["DatePaid"].(string); ok && paidDate != "" {
-		paidDateT, err := time.Parse("2006-01-02", paidDate)
+	payload, ok := ctx.Get(authorizationPayloadKey)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "missing token"})
+
+		return
+	}
+
+	payloadData, ok := payload.(*pkg.Payload)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "incorrect token"})
+
+		return
+	}
+
+	amountFlt, err := pkg.StringToFloat64(req.TransAmount)
+	if err != nil || amountFlt <= 0 {
+		ctx.JSON(
+			http.StatusBadRequest,
+			errorResponse(pkg.Errorf(pkg.INVALID_ERROR, "invalid amount: %s", req.TransAmount)),
+		)
+
+		return
+	}
+
+	callbackData := services.MpesaCallbackData{
+		TransactionSource: "INTERNAL",
+		TransactionID:     req.TransID,
+		AccountNumber:     strings.TrimSpace(req.BillRefNumber),
+		PhoneNumber:       req.MSISDN,
+		PayingName:        req.FirstName,
+		Amount:            amountFlt,
+		AssignedBy:        payloadData.Email,
+		AssignedTo:        nil,
+		CashReceipt:       true,
+	}
+
+	if req.DatePaid != "" {
+		paidDateT, err := time.ParseInLocation("2006-01-02", req.DatePaid, pkg.NairobiLocation())
 		if err != nil {
 			ctx.JSON(
 				http.StatusBadRequest,
-				errorResponse(pkg.Errorf(pkg.INVALID_ERROR, "invalid disburse_on date format")),
+				errorResponse(pkg.Errorf(pkg.INVALID_ERROR, "invalid paid date format")),
 			)
 
 			return
@@ -69,19 +151,192 @@ func (s *Server) paymentCallback(ctx *gin.Context) {
 		callbackData.PaidDate = pkg.TimePtr(paidDateT)
 	}
 
-	clientID, err := s.repo.Clients.GetClientIDByPhoneNumber(ctx, callbackData.AccountNumber)
-	if err != nil && pkg.ErrorCode(err) != pkg.NOT_FOUND_ERROR {
+	if err := s.postPayment(ctx, &callbackData); err != nil {
 		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
 
 		return
 	}
 
+	ctx.JSON(http.StatusOK, gin.H{
+		"ResultCode": 0,
+		"ResultDesc": "Accepted",
+	})
+}
+
+// postPayment assigns the payment to the client owning the account number and processes it.
+func (s *Server) postPayment(ctx *gin.Context, callbackData *services.MpesaCallbackData) error {
+	clientID, err := s.repo.Clients.GetClientIDByPhoneNumber(ctx, callbackData.AccountNumber)
+	if err != nil && pkg.ErrorCode(err) != pkg.NOT_FOUND_ERROR {
+		return err
+	}
+
 	if clientID != 0 {
 		callbackData.AssignedTo = pkg.Uint32Ptr(clientID)
 		s.cache.Del(ctx, fmt.Sprintf("client:%v", clientID))
 	}
 
-	loanId, err := s.payments.ProcessCallback(ctx, &callbackData)
+	loanId, err := s.payments.ProcessCallback(ctx, callbackData)
+	if err != nil {
+		return err
+	}
+
+	s.cache.Del(ctx, fmt.Sprintf("loan:%d", loanId))
+	s.cache.DelAll(ctx, "non-posted/all:limit=*")
+
+	s.cache.DelAll(ctx, "loan:limit=*")
+	s.cache.DelAll(ctx, "client:limit=*")
+
+	return nil
+}
+
+func (s *Server) saveCallbackPayload(
+	ctx *gin.Context,
+	transactionNumber string,
+	callbackType string,
+	rawPayload []byte,
+) {
+	if err := s.repo.NonPosted.CreateCallbackPayload(ctx, &repository.CallbackPayload{
+		TransactionNumber: transactionNumber,
+		CallbackType:      callbackType,
+		Payload:           string(rawPayload),
+	}); err != nil {
+		log.Printf("failed to save callback payload: %v", err)
+	}
+}
+
+func (s *Server) listCallbackPayloads(ctx *gin.Context) {
+	payload, ok := ctx.Get(authorizationPayloadKey)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "missing token"})
+
+		return
+	}
+
+	payloadData, ok := payload.(*pkg.Payload)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "incorrect token"})
+
+		return
+	}
+
+	if strings.ToLower(payloadData.Role) != "admin" {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "not authorized"})
+
+		return
+	}
+
+	payloads, err := s.repo.NonPosted.ListCallbackPayloads(ctx, ctx.Param("transactionNumber"))
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": payloads})
+}
+
+type stkPushRequest struct {
+	Amount float64 `binding:"omitempty,gt=0" json:"amount"`
+}
+
+func (s *Server) stkPush(ctx *gin.Context) {
+	// the body is optional, without an amount the next unpaid installment is requested
+	var req stkPushRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, err.Error())))
+
+		return
+	}
+
+	id, err := pkg.StringToUint32(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+
+		return
+	}
+
+	payload, ok := ctx.Get(authorizationPayloadKey)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "missing token"})
+
+		return
+	}
+
+	payloadData, ok := payload.(*pkg.Payload)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "incorrect token"})
+
+		return
+	}
+
+	pushData := services.STKPushData{
+		LoanID:      id,
+		InitiatedBy: payloadData.UserID,
+	}
+
+	if req.Amount > 0 {
+		pushData.Amount = pkg.Float64Ptr(req.Amount)
+	}
+
+	rslt, err := s.payments.InitiateSTKPush(ctx, &pushData)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": rslt})
+}
+
+func (s *Server) stkCallback(ctx *gin.Context) {
+	rawPayload, err := ctx.GetRawData()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, err.Error())))
+
+		return
+	}
+
+	var req pkg.STKCallback
+	if err := json.Unmarshal(rawPayload, &req); err != nil {
+		s.saveCallbackPayload(ctx, "", "STK", rawPayload)
+
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, err.Error())))
+
+		return
+	}
+
+	result := req.Body.StkCallback
+	s.saveCallbackPayload(ctx, result.CheckoutRequestID, "STK", rawPayload)
+
+	callbackData := services.STKCallbackData{
+		CheckoutRequestID: result.CheckoutRequestID,
+		ResultCode:        result.ResultCode,
+		ResultDesc:        result.ResultDesc,
+		ReceiptNumber:     result.Item("MpesaReceiptNumber"),
+		PhoneNumber:       result.Item("PhoneNumber"),
+	}
+
+	if amount := result.Item("Amount"); amount != "" {
+		callbackData.Amount, err = pkg.StringToFloat64(amount)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+
+			return
+		}
+	}
+
+	if transactionDate := result.Item("TransactionDate"); transactionDate != "" {
+		paidDate, err := time.ParseInLocation(
+			pkg.MpesaTimeFormat,
+			transactionDate,
+			pkg.NairobiLocation(),
+		)
+		if err == nil {
+			callbackData.PaidDate = pkg.TimePtr(paidDate)
+		}
+	}
+
+	loanId, err := s.payments.ProcessSTKCallback(ctx, &callbackData)
 	if err != nil {
 		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
 
@@ -100,6 +355,80 @@ func (s *Server) paymentCallback(ctx *gin.Context) {
 	})
 }
 
+func (s *Server) b2cResultCallback(ctx *gin.Context) {
+	rawPayload, err := ctx.GetRawData()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, err.Error())))
+
+		return
+	}
+
+	var req pkg.B2CResult
+	if err := json.Unmarshal(rawPayload, &req); err != nil {
+		s.saveCallbackPayload(ctx, "", "B2C", rawPayload)
+
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, err.Error())))
+
+		return
+	}
+
+	s.saveCallbackPayload(ctx, req.Result.ConversationID, "B2C", rawPayload)
+
+	resultData := services.B2CResultData{
+		ConversationID: req.Result.ConversationID,
+		ResultCode:     req.Result.ResultCode,
+		ResultDesc:     req.Result.ResultDesc,
+		TransactionID:  req.Result.TransactionID,
+	}
+
+	if completedAt := req.Parameter("TransactionCompletedDateTime"); completedAt != "" {
+		disbursedOn, err := time.ParseInLocation(
+			pkg.MpesaB2CTimeFormat,
+			completedAt,
+			pkg.NairobiLocation(),
+		)
+		if err == nil {
+			resultData.CompletedAt = pkg.TimePtr(disbursedOn)
+		}
+	}
+
+	loanId, err := s.payments.ProcessB2CResult(ctx, &resultData)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+
+		return
+	}
+
+	s.cache.Del(ctx, fmt.Sprintf("loan:%d", loanId))
+	s.cache.DelAll(ctx, "loan:limit=*")
+	s.cache.DelAll(ctx, "client:limit=*")
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"ResultCode": 0,
+		"ResultDesc": "Accepted",
+	})
+}
+
+// a timeout does not mean the payout failed, the loan is left DISBURSING until it is confirmed.
+func (s *Server) b2cTimeoutCallback(ctx *gin.Context) {
+	rawPayload, err := ctx.GetRawData()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, err.Error())))
+
+		return
+	}
+
+	var req pkg.B2CResult
+	_ = json.Unmarshal(rawPayload, &req)
+
+	s.saveCallbackPayload(ctx, req.Result.ConversationID, "B2C_TIMEOUT", rawPayload)
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"ResultCode": 0,
+		"ResultDesc": "Accepted",
+	})
+}
+
 type paymentByAdminRequest struct {
 	ClientID uint32 `binding:"required" json:"clientId"`
 }
@@ -437,6 +766,7 @@ func (s *Server) getMPESAAccesToken(ctx *gin.Context) {
 	}
 
 	accessToken, err := pkg.GenerateAccessToken(
+		s.config.MPESA_BASE_URL,
 		s.config.MPESA_CONSUMER_KEY,
 		s.config.MPESA_CONSUMER_SECRET,
 	)
@@ -450,5 +780,100 @@ func (s *Server) getMPESAAccesToken(ctx *gin.Context) {
 }
 
 func (s *Server) validationCallback(ctx *gin.Context) {
-	// No validation for my darajaApp
+	var req pkg.C2BCallback
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusOK, gin.H{
+			"ResultCode": payments.ValidationOtherError,
+			"ResultDesc": "Rejected",
+		})
+
+		return
+	}
+
+	// an invalid amount is rejected by the validation rules
+	amountFlt, _ := req.Amount()
+
+	result, err := s.payments.ValidateCallback(ctx, &services.MpesaValidationData{
+		TransactionID: req.TransID,
+		AccountNumber: req.BillRefNumber,
+		PhoneNumber:   req.MSISDN,
+		PayingName:    req.PayingName(),
+		Amount:        amountFlt,
+	})
+	if err != nil {
+		// Daraja only reads a result code, the failure is kept in the logs
+		log.Printf("failed to validate callback %s: %v", req.TransID, err)
+
+		ctx.JSON(http.StatusOK, gin.H{
+			"ResultCode": payments.ValidationOtherError,
+			"ResultDesc": "Rejected",
+		})
+
+		return
+	}
+
+	if !result.Accepted {
+		ctx.JSON(http.StatusOK, gin.H{
+			"ResultCode": result.ResultCode,
+			"ResultDesc": "Rejected",
+		})
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"ResultCode": result.ResultCode,
+		"ResultDesc": "Accepted",
+	})
+}
+
+func (s *Server) listPaymentValidationLogs(ctx *gin.Context) {
+	payload, ok := ctx.Get(authorizationPayloadKey)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "missing token"})
+
+		return
+	}
+
+	payloadData, ok := payload.(*pkg.Payload)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "incorrect token"})
+
+		return
+	}
+
+	if strings.ToLower(payloadData.Role) != "admin" {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "not authorized"})
+
+		return
+	}
+
+	pageNo, err := pkg.StringToUint32(ctx.DefaultQuery("page", "1"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, err.Error())))
+
+		return
+	}
+
+	pageSize, err := pkg.StringToUint32(ctx.DefaultQuery("limit", "10"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, err.Error())))
+
+		return
+	}
+
+	logs, metadata, err := s.repo.NonPosted.ListPaymentValidationLogs(
+		ctx,
+		&pkg.PaginationMetadata{CurrentPage: pageNo, PageSize: pageSize},
+	)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"metadata": metadata,
+		"data":     logs,
+	})
 }
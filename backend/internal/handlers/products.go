@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/EmilioCliff/kokomed-fin/backend/internal/repository"
@@ -18,9 +19,10 @@ type productResponse struct {
 }
 
 type createProductRequest struct {
-	BranchID    uint32  `binding:"required" json:"branchId"`
-	LoanAmount  float64 `binding:"required" json:"loanAmount"`
-	RepayAmount float64 `binding:"required" json:"repayAmount"`
+	BranchID           uint32  `binding:"required" json:"branchId"`
+	LoanAmount         float64 `binding:"required" json:"loanAmount"`
+	RepayAmount        float64 `binding:"required" json:"repayAmount"`
+	AllocationStrategy string  `binding:"omitempty" json:"allocationStrategy"`
 }
 
 func (s *Server) createProduct(ctx *gin.Context) {
@@ -46,11 +48,12 @@ func (s *Server) createProduct(ctx *gin.Context) {
 	}
 
 	product, err := s.repo.Products.CreateProduct(ctx, &repository.Product{
-		BranchID:       req.BranchID,
-		LoanAmount:     req.LoanAmount,
-		RepayAmount:    req.RepayAmount,
-		UpdatedBy:      payloadData.UserID,
-		InterestAmount: req.RepayAmount - req.LoanAmount,
+		BranchID:           req.BranchID,
+		LoanAmount:         pkg.MoneyFromFloat(req.LoanAmount),
+		RepayAmount:        pkg.MoneyFromFloat(req.RepayAmount),
+		UpdatedBy:          payloadData.UserID,
+		InterestAmount:     pkg.MoneyFromFloat(req.RepayAmount) - pkg.MoneyFromFloat(req.LoanAmount),
+		AllocationStrategy: req.AllocationStrategy,
 	})
 	if err != nil {
 		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
@@ -95,6 +98,188 @@ func (s *Server) getProduct(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, product)
 }
 
+type updateProductAllocationStrategyRequest struct {
+	Strategy string `binding:"required,oneof=OLDEST_FIRST CURRENT_FIRST SPREAD_OVERDUE WATERFALL" json:"strategy"`
+}
+
+func (s *Server) updateProductAllocationStrategy(ctx *gin.Context) {
+	var req updateProductAllocationStrategyRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, err.Error())))
+
+		return
+	}
+
+	id, err := pkg.StringToUint32(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+
+		return
+	}
+
+	payload, ok := ctx.Get(authorizationPayloadKey)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "missing token"})
+
+		return
+	}
+
+	payloadData, ok := payload.(*pkg.Payload)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "incorrect token"})
+
+		return
+	}
+
+	if strings.ToLower(payloadData.Role) != "admin" {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "not authorized"})
+
+		return
+	}
+
+	product, err := s.repo.Products.UpdateProductAllocationStrategy(
+		ctx,
+		id,
+		req.Strategy,
+		payloadData.UserID,
+	)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+
+		return
+	}
+
+	s.cache.DelAll(ctx, "product:limit=*")
+
+	ctx.JSON(http.StatusOK, product)
+}
+
+type updateProductDefaultThresholdRequest struct {
+	// GraceDays left out clears the product threshold
+	GraceDays *uint32 `binding:"omitempty,lte=3650" json:"graceDays"`
+}
+
+func (s *Server) updateProductDefaultThreshold(ctx *gin.Context) {
+	var req updateProductDefaultThresholdRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, err.Error())))
+
+		return
+	}
+
+	id, err := pkg.StringToUint32(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+
+		return
+	}
+
+	payload, ok := ctx.Get(authorizationPayloadKey)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "missing token"})
+
+		return
+	}
+
+	payloadData, ok := payload.(*pkg.Payload)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "incorrect token"})
+
+		return
+	}
+
+	if strings.ToLower(payloadData.Role) != "admin" {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "not authorized"})
+
+		return
+	}
+
+	product, err := s.repo.Products.UpdateProductDefaultThreshold(
+		ctx,
+		id,
+		req.GraceDays,
+		payloadData.UserID,
+	)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+
+		return
+	}
+
+	s.cache.DelAll(ctx, "product:limit=*")
+
+	ctx.JSON(http.StatusOK, product)
+}
+
+type updateProductPenaltyRuleRequest struct {
+	// PenaltyType left out clears the product penalty rule
+	PenaltyType string   `binding:"omitempty,oneof=FLAT PERCENTAGE" json:"penaltyType"`
+	Rate        float64  `binding:"required_with=PenaltyType" json:"rate"`
+	Frequency   string   `binding:"required_with=PenaltyType,omitempty,oneof=DAILY WEEKLY" json:"frequency"`
+	Cap         *float64 `binding:"omitempty" json:"cap"`
+}
+
+func (s *Server) updateProductPenaltyRule(ctx *gin.Context) {
+	var req updateProductPenaltyRuleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, err.Error())))
+
+		return
+	}
+
+	id, err := pkg.StringToUint32(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+
+		return
+	}
+
+	payload, ok := ctx.Get(authorizationPayloadKey)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "missing token"})
+
+		return
+	}
+
+	payloadData, ok := payload.(*pkg.Payload)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "incorrect token"})
+
+		return
+	}
+
+	if strings.ToLower(payloadData.Role) != "admin" {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "not authorized"})
+
+		return
+	}
+
+	var rule *repository.PenaltyRule
+	if req.PenaltyType != "" {
+		rule = &repository.PenaltyRule{
+			PenaltyType: req.PenaltyType,
+			Rate:        req.Rate,
+			Frequency:   req.Frequency,
+		}
+
+		if req.Cap != nil {
+			capAmount := pkg.MoneyFromFloat(*req.Cap)
+			rule.Cap = &capAmount
+		}
+	}
+
+	product, err := s.repo.Products.UpdateProductPenaltyRule(ctx, id, rule, payloadData.UserID)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+
+		return
+	}
+
+	s.cache.DelAll(ctx, "product:limit=*")
+
+	ctx.JSON(http.StatusOK, product)
+}
+
 func (s *Server) listProducts(ctx *gin.Context) {
 	pageNoStr := ctx.DefaultQuery("page", "1")
 	pageNo, err := pkg.StringToUint32(pageNoStr)
@@ -0,0 +1,196 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/services"
+	"github.com/EmilioCliff/kokomed-fin/backend/pkg"
+	"github.com/gin-gonic/gin"
+)
+
+func (s *Server) reconcileStatement(ctx *gin.Context) {
+	payload, ok := ctx.Get(authorizationPayloadKey)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "missing token"})
+
+		return
+	}
+
+	payloadData, ok := payload.(*pkg.Payload)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "incorrect token"})
+
+		return
+	}
+
+	if strings.ToLower(payloadData.Role) != "admin" {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "not authorized"})
+
+		return
+	}
+
+	fileHeader, err := ctx.FormFile("statement")
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, err.Error())))
+
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, err.Error())))
+
+		return
+	}
+	defer file.Close()
+
+	lines, err := pkg.ParseMpesaStatement(fileHeader.Filename, file)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+
+		return
+	}
+
+	statementData := services.StatementData{
+		FileName:   fileHeader.Filename,
+		UploadedBy: payloadData.UserID,
+		Lines:      make([]services.StatementLine, len(lines)),
+	}
+
+	if date := ctx.PostForm("statementDate"); date != "" {
+		statementDate, err := time.ParseInLocation("2006-01-02", date, pkg.NairobiLocation())
+		if err != nil {
+			ctx.JSON(
+				http.StatusBadRequest,
+				errorResponse(pkg.Errorf(pkg.INVALID_ERROR, err.Error())),
+			)
+
+			return
+		}
+
+		statementData.StatementDate = pkg.TimePtr(statementDate)
+	}
+
+	for i, line := range lines {
+		statementData.Lines[i] = services.StatementLine{
+			TransactionNumber: line.ReceiptNo,
+			AccountNumber:     line.AccountNumber,
+			PhoneNumber:       line.PhoneNumber(),
+			PayingName:        line.PayingName(),
+			Amount:            line.PaidIn,
+			PaidDate:          line.CompletionTime,
+		}
+	}
+
+	report, err := s.payments.ReconcileStatement(ctx, &statementData)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": report})
+}
+
+func (s *Server) getReconciliation(ctx *gin.Context) {
+	id, err := pkg.StringToUint32(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+
+		return
+	}
+
+	payload, ok := ctx.Get(authorizationPayloadKey)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "missing token"})
+
+		return
+	}
+
+	payloadData, ok := payload.(*pkg.Payload)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "incorrect token"})
+
+		return
+	}
+
+	if strings.ToLower(payloadData.Role) != "admin" {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "not authorized"})
+
+		return
+	}
+
+	report, err := s.payments.GetReconciliation(ctx, id)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": report})
+}
+
+type importMissingCallbacksRequest struct {
+	ItemIDs []uint32 `json:"itemIds"`
+}
+
+func (s *Server) importMissingCallbacks(ctx *gin.Context) {
+	// the body is optional, without item ids every missing callback is imported
+	var req importMissingCallbacksRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, err.Error())))
+
+		return
+	}
+
+	id, err := pkg.StringToUint32(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+
+		return
+	}
+
+	payload, ok := ctx.Get(authorizationPayloadKey)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "missing token"})
+
+		return
+	}
+
+	payloadData, ok := payload.(*pkg.Payload)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "incorrect token"})
+
+		return
+	}
+
+	if strings.ToLower(payloadData.Role) != "admin" {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "not authorized"})
+
+		return
+	}
+
+	results, err := s.payments.ImportMissingCallbacks(ctx, id, req.ItemIDs, payloadData.Email)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+
+		return
+	}
+
+	for _, result := range results {
+		if result.LoanID != 0 {
+			s.cache.Del(ctx, fmt.Sprintf("loan:%d", result.LoanID))
+		}
+	}
+
+	s.cache.DelAll(ctx, "non-posted/all:limit=*")
+	s.cache.DelAll(ctx, "loan:limit=*")
+	s.cache.DelAll(ctx, "client:limit=*")
+
+	ctx.JSON(http.StatusOK, gin.H{"data": results})
+}
@@ -95,15 +95,23 @@ func (s *Server) setUpRoutes() {
 	cachedRoutes.GET("/client", s.listClients)
 	authRoute.GET("/client/:id", s.getClient)
 	authRoute.PATCH("/client/:id", s.updateClient)
+	authRoute.POST("/client/:id/blacklist", s.blacklistClient)
+	authRoute.DELETE("/client/:id/blacklist", s.removeBlacklistedClient)
+	authRoute.POST("/client/:id/overpayment-refunds", s.requestOverpaymentRefund)
 
 	// product routes
 	authRoute.POST("/product", s.createProduct)
 	cachedRoutes.GET("/product", s.listProducts)
 	authRoute.GET("/product/:id", s.getProduct)
+	authRoute.PATCH("/product/:id/allocation-strategy", s.updateProductAllocationStrategy)
+	authRoute.PATCH("/product/:id/default-threshold", s.updateProductDefaultThreshold)
+	authRoute.PATCH("/product/:id/penalty-rule", s.updateProductPenaltyRule)
 
 	// non-posted routes
 	cachedRoutes.GET("/non-posted/all", s.listAllNonPostedPayments)
 	authRoute.POST("/non-posted/clients", s.listClientsNonPosted)
+	authRoute.GET("/non-posted/duplicates", s.listDuplicateNonPosted)
+	authRoute.GET("/non-posted/:id/suggestions", s.suggestNonPostedAssignments)
 	cachedRoutes.GET("/non-posted/:id", s.getNonPosted)
 
 	// branches routes
@@ -115,6 +123,11 @@ func (s *Server) setUpRoutes() {
 	// loans routes
 	authRoute.POST("/loan", s.createLoan)
 	authRoute.PATCH("/loan/:id/disburse", s.disburseLoan)
+	authRoute.POST("/loan/:id/stk-push", s.stkPush)
+	authRoute.POST("/loan/:id/restructure", s.restructureLoan)
+	authRoute.POST("/loan/:id/top-up", s.topUpLoan)
+	authRoute.POST("/loan/:id/settlement-quotes", s.createSettlementQuote)
+	authRoute.GET("/loan/:id/settlement-quotes", s.listSettlementQuotes)
 	authRoute.GET("/loan/:id/installments", s.getLoanInstallments)
 	cachedRoutes.GET("/loan", s.listLoansByCategory)
 	cachedRoutes.GET("/loan/:id", s.getLoan)
@@ -125,12 +138,49 @@ func (s *Server) setUpRoutes() {
 	// payments routes
 	v1.POST("/payment/callback", s.paymentCallback)
 	v1.POST("/payment/validation", s.validationCallback)
+	v1.POST("/payment/stk/callback", s.stkCallback)
+	v1.POST("/payment/b2c/result", s.b2cResultCallback)
+	v1.POST("/payment/b2c/timeout", s.b2cTimeoutCallback)
+	authRoute.GET("/payment/validation-logs", s.listPaymentValidationLogs)
+	authRoute.POST("/payment/internal", s.internalPayment)
+	authRoute.GET("/payment/payloads/:transactionNumber", s.listCallbackPayloads)
+	authRoute.POST("/payment/reconciliations", s.reconcileStatement)
+	authRoute.GET("/payment/reconciliations/:id", s.getReconciliation)
+	authRoute.POST("/payment/reconciliations/:id/import", s.importMissingCallbacks)
+	authRoute.GET("/payment/imports", s.listPaymentImports)
+	authRoute.POST("/payment/imports", s.previewPaymentImport)
+	authRoute.GET("/payment/imports/:id", s.getPaymentImport)
+	authRoute.POST("/payment/imports/:id/post", s.postPaymentImport)
+	authRoute.POST("/payment/imports/:id/rollback", s.rollbackPaymentImport)
+	authRoute.GET("/payment/assignment-rules", s.listAssignmentRules)
+	authRoute.POST("/payment/assignment-rules", s.createAssignmentRule)
+	authRoute.POST("/payment/assignment-rules/dry-run", s.dryRunAssignmentRules)
+	authRoute.PATCH("/payment/assignment-rules/:id", s.updateAssignmentRule)
+	authRoute.DELETE("/payment/assignment-rules/:id", s.deleteAssignmentRule)
+	authRoute.GET("/payment/splits/:id", s.getPaymentSplit)
+	authRoute.POST("/payment/splits/:id/reverse", s.reversePaymentSplit)
 	authRoute.PATCH("/payment/:id/assign", s.paymentByAdmin)
+	authRoute.POST("/payment/:id/split", s.splitPayment)
+	authRoute.GET("/payment/:id/receipt", s.downloadPaymentReceipt)
+	authRoute.POST("/payment/:id/receipt/send", s.sendPaymentReceipt)
+	authRoute.POST("/payment/:id/reassign", s.reassignPayment)
+	authRoute.GET("/payment/:id/reassignments", s.listPaymentReassignments)
 	authRoute.POST("/payment/:id/update", s.updatePayment)
 	authRoute.POST("/payment/:id/simulate-update", s.simulateUpdatePayment)
 	authRoute.POST("/payment/:id/delete", s.deleteLoan)
 	authRoute.GET("/payment/:id/simulate-delete", s.simulateDeletePayment)
 
+	// overpayment refunds routes
+	authRoute.GET("/overpayment-refunds", s.listOverpaymentRefunds)
+	authRoute.GET("/overpayment-refunds/:id", s.getOverpaymentRefund)
+	authRoute.GET("/overpayment-refunds/:id/voucher", s.downloadRefundVoucher)
+	authRoute.POST("/overpayment-refunds/:id/approve", s.approveOverpaymentRefund)
+	authRoute.POST("/overpayment-refunds/:id/reject", s.rejectOverpaymentRefund)
+
+	// early settlement routes
+	authRoute.GET("/settlement-quotes/:id", s.getSettlementQuote)
+	authRoute.POST("/settlement-quotes/:id/settle", s.settleLoan)
+
 	// helper routes
 	authRoute.GET("/helper/dashboard", s.getDashboardData)
 	authRoute.GET("/helper/formData", s.getLoanFormData)
@@ -141,6 +191,17 @@ func (s *Server) setUpRoutes() {
 	// reports routes
 	authRoute.POST("/report", s.generateReport)
 
+	// cash book routes
+	authRoute.GET("/branch/:id/cash-book", s.getCashBook)
+	authRoute.POST("/branch/:id/cash-book/close", s.closeCashBook)
+	authRoute.GET("/branch/:id/cash-book/variances", s.listCashVariances)
+	authRoute.POST("/branch/:id/expenses", s.createBranchExpense)
+
+	// ledger routes
+	authRoute.GET("/ledger/accounts", s.listLedgerAccounts)
+	authRoute.GET("/ledger/accounts/:code/entries", s.getAccountLedger)
+	authRoute.GET("/ledger/trial-balance", s.getTrialBalance)
+
 	s.srv = &http.Server{
 		Addr:         s.config.HTTP_PORT,
 		Handler:      s.router.Handler(),
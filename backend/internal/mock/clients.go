@@ -13,12 +13,15 @@ var _ repository.ClientRepository = (*MockClientRepository)(nil)
 type MockClientRepository struct {
 	mockCreateClientFunc               func(ctx context.Context, client *repository.Client) (repository.ClientFullData, error)
 	mockUpdateClientFunc               func(ctx context.Context, client *repository.UpdateClient) error
-	mockUpdateClientOverpaymentFunc    func(ctx context.Context, phoneNumber string, overpayment float64) error
+	mockUpdateClientOverpaymentFunc    func(ctx context.Context, phoneNumber string, overpayment pkg.Money) error
 	mockListClientsFunc                func(ctx context.Context, category *repository.ClientCategorySearch, pgData *pkg.PaginationMetadata) ([]repository.ClientFullData, pkg.PaginationMetadata, error)
 	mockGetClientFullDataFunc          func(ctx context.Context, clientID uint32) (repository.ClientFullData, error)
 	mockGetClientIDByPhoneNumberFunc   func(ctx context.Context, phoneNumber string) (uint32, error)
 	mockListClientsByBranchFunc        func(ctx context.Context, branchID uint32, pgData *pkg.PaginationMetadata) ([]repository.Client, error)
 	mockListClientsByActiveStatusFunc  func(ctx context.Context, active bool, pgData *pkg.PaginationMetadata) ([]repository.Client, error)
+	mockBlacklistClientFunc            func(ctx context.Context, blacklist *repository.BlacklistedClient) (repository.BlacklistedClient, error)
+	mockGetBlacklistedClientFunc       func(ctx context.Context, clientID uint32) (repository.BlacklistedClient, error)
+	mockRemoveBlacklistedClientFunc    func(ctx context.Context, clientID uint32) error
 	mockGetReportClientAdminDataFunc   func(ctx context.Context, filters services.ReportFilters) ([]services.ClientAdminsReportData, services.ClientSummary, error)
 	mockGetReportClientClientsDataFunc func(ctx context.Context, id uint32, filters services.ReportFilters) (services.ClientClientsReportData, error)
 }
@@ -40,43 +43,78 @@ func (m *MockClientRepository) UpdateClient(
 func (m *MockClientRepository) UpdateClientOverpayment(
 	ctx context.Context,
 	phoneNumber string,
-	overpayment float64,
+	overpayment pkg.Money,
 ) error {
 	return m.mockUpdateClientOverpaymentFunc(ctx, phoneNumber, overpayment)
 }
+
 func (m *MockClientRepository) ListClients(
 	ctx context.Context,
 	category *repository.ClientCategorySearch,
 	pgData *pkg.PaginationMetadata,
-) ([]repository.ClientFullData, pkg.PaginationMetadata, error)
+) ([]repository.ClientFullData, pkg.PaginationMetadata, error) {
+	return m.mockListClientsFunc(ctx, category, pgData)
+}
 
-// GetClient(ctx context.Context, clientID uint32) (ClientFullData, error)
 func (m *MockClientRepository) GetClientFullData(
 	ctx context.Context,
 	clientID uint32,
-) (repository.ClientFullData, error)
+) (repository.ClientFullData, error) {
+	return m.mockGetClientFullDataFunc(ctx, clientID)
+}
 
 func (m *MockClientRepository) GetClientIDByPhoneNumber(
 	ctx context.Context,
 	phoneNumber string,
-) (uint32, error)
+) (uint32, error) {
+	return m.mockGetClientIDByPhoneNumberFunc(ctx, phoneNumber)
+}
+
 func (m *MockClientRepository) ListClientsByBranch(
 	ctx context.Context,
 	branchID uint32,
 	pgData *pkg.PaginationMetadata,
-) ([]repository.Client, error)
+) ([]repository.Client, error) {
+	return m.mockListClientsByBranchFunc(ctx, branchID, pgData)
+}
+
 func (m *MockClientRepository) ListClientsByActiveStatus(
 	ctx context.Context,
 	active bool,
 	pgData *pkg.PaginationMetadata,
-) ([]repository.Client, error)
+) ([]repository.Client, error) {
+	return m.mockListClientsByActiveStatusFunc(ctx, active, pgData)
+}
 
 func (m *MockClientRepository) GetReportClientAdminData(
 	ctx context.Context,
 	filters services.ReportFilters,
-) ([]services.ClientAdminsReportData, services.ClientSummary, error)
+) ([]services.ClientAdminsReportData, services.ClientSummary, error) {
+	return m.mockGetReportClientAdminDataFunc(ctx, filters)
+}
+
 func (m *MockClientRepository) GetReportClientClientsData(
 	ctx context.Context,
 	id uint32,
 	filters services.ReportFilters,
-) (services.ClientClientsReportData, error)
+) (services.ClientClientsReportData, error) {
+	return m.mockGetReportClientClientsDataFunc(ctx, id, filters)
+}
+
+func (m *MockClientRepository) BlacklistClient(
+	ctx context.Context,
+	blacklist *repository.BlacklistedClient,
+) (repository.BlacklistedClient, error) {
+	return m.mockBlacklistClientFunc(ctx, blacklist)
+}
+
+func (m *MockClientRepository) GetBlacklistedClient(
+	ctx context.Context,
+	clientID uint32,
+) (repository.BlacklistedClient, error) {
+	return m.mockGetBlacklistedClientFunc(ctx, clientID)
+}
+
+func (m *MockClientRepository) RemoveBlacklistedClient(ctx context.Context, clientID uint32) error {
+	return m.mockRemoveBlacklistedClientFunc(ctx, clientID)
+}
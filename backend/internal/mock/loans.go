@@ -2,6 +2,7 @@ package mock
 
 import (
 	"context"
+	"time"
 
 	"github.com/EmilioCliff/kokomed-fin/backend/internal/repository"
 	"github.com/EmilioCliff/kokomed-fin/backend/internal/services"
@@ -26,6 +27,14 @@ type MockLoanRepository struct {
 	MockGetExpectedPayments        func(ctx context.Context, category *repository.Category, pgData *pkg.PaginationMetadata) ([]repository.ExpectedPayment, pkg.PaginationMetadata, error)
 	MockListUnpaidInstallmentsData func(ctx context.Context, category *repository.Category, pgData *pkg.PaginationMetadata) ([]repository.UnpaidInstallmentData, pkg.PaginationMetadata, error)
 	MockGetReportLoanData          func(ctx context.Context, filters services.ReportFilters) ([]services.LoanReportData, services.LoanSummary, error)
+	MockGetLoan                    func(ctx context.Context, id uint32) (repository.LoanShort, error)
+	MockGetClientPayableLoan       func(ctx context.Context, clientID uint32) (uint32, error)
+	MockMarkDefaultedLoans         func(ctx context.Context, asOf time.Time, defaultGraceDays uint32) ([]repository.DefaultedLoan, error)
+	MockAccrueLoanPenalties        func(ctx context.Context, asOf time.Time) (repository.PenaltyAccrual, error)
+	MockRestructureLoan            func(ctx context.Context, restructure *repository.RestructureLoan) (repository.LoanRestructure, error)
+	MockTopUpLoan                  func(ctx context.Context, topUp *repository.TopUpLoan) (repository.LoanTopUp, error)
+	MockGetLoanStatus              func(ctx context.Context, id uint32) (string, error)
+	MockGetClientLoans             func(ctx context.Context, clientID uint32, category *repository.Category, pgData *pkg.PaginationMetadata) ([]repository.LoanFullData, pkg.PaginationMetadata, error)
 }
 
 func (m *MockLoanRepository) CreateLoan(
@@ -125,3 +134,56 @@ func (m *MockLoanRepository) GetReportLoanData(
 ) ([]services.LoanReportData, services.LoanSummary, error) {
 	return m.MockGetReportLoanData(ctx, filters)
 }
+
+func (m *MockLoanRepository) GetLoan(ctx context.Context, id uint32) (repository.LoanShort, error) {
+	return m.MockGetLoan(ctx, id)
+}
+
+func (m *MockLoanRepository) GetClientPayableLoan(
+	ctx context.Context,
+	clientID uint32,
+) (uint32, error) {
+	return m.MockGetClientPayableLoan(ctx, clientID)
+}
+
+func (m *MockLoanRepository) MarkDefaultedLoans(
+	ctx context.Context,
+	asOf time.Time,
+	defaultGraceDays uint32,
+) ([]repository.DefaultedLoan, error) {
+	return m.MockMarkDefaultedLoans(ctx, asOf, defaultGraceDays)
+}
+
+func (m *MockLoanRepository) AccrueLoanPenalties(
+	ctx context.Context,
+	asOf time.Time,
+) (repository.PenaltyAccrual, error) {
+	return m.MockAccrueLoanPenalties(ctx, asOf)
+}
+
+func (m *MockLoanRepository) RestructureLoan(
+	ctx context.Context,
+	restructure *repository.RestructureLoan,
+) (repository.LoanRestructure, error) {
+	return m.MockRestructureLoan(ctx, restructure)
+}
+
+func (m *MockLoanRepository) TopUpLoan(
+	ctx context.Context,
+	topUp *repository.TopUpLoan,
+) (repository.LoanTopUp, error) {
+	return m.MockTopUpLoan(ctx, topUp)
+}
+
+func (m *MockLoanRepository) GetLoanStatus(ctx context.Context, id uint32) (string, error) {
+	return m.MockGetLoanStatus(ctx, id)
+}
+
+func (m *MockLoanRepository) GetClientLoans(
+	ctx context.Context,
+	clientID uint32,
+	category *repository.Category,
+	pgData *pkg.PaginationMetadata,
+) ([]repository.LoanFullData, pkg.PaginationMetadata, error) {
+	return m.MockGetClientLoans(ctx, clientID, category, pgData)
+}
@@ -16,9 +16,18 @@ type MockNonPostedRepository struct {
 	mockListNonPostedFunc                    func(ctx context.Context, category *repository.NonPostedCategory, pgData *pkg.PaginationMetadata) ([]repository.NonPosted, pkg.PaginationMetadata, error)
 	mockListNonPostedByTransactionSourceFunc func(ctx context.Context, transactionSource string, pgData *pkg.PaginationMetadata) ([]repository.NonPosted, error)
 	mockListUnassignedNonPostedFunc          func(ctx context.Context, pgData *pkg.PaginationMetadata) ([]repository.NonPosted, error)
-	mockDeleteNonPostedFunc                  func(ctx context.Context, id uint32) error
+	mockDeleteNonPostedFunc                  func(ctx context.Context, id uint32, description string) error
 	mockGetClientNonPostedFunc               func(ctx context.Context, id uint32, phoneNumber string, pgData *pkg.PaginationMetadata) (repository.ClientNonPosted, pkg.PaginationMetadata, error)
+	mockGetProcessedCallbackFunc             func(ctx context.Context, transactionNumber string, transactionSource string) (repository.ProcessedCallback, error)
+	mockListDuplicateNonPostedFunc           func(ctx context.Context) ([]repository.NonPosted, error)
+	mockCreatePaymentValidationLogFunc       func(ctx context.Context, log *repository.PaymentValidationLog) error
+	mockListPaymentValidationLogsFunc        func(ctx context.Context, pgData *pkg.PaginationMetadata) ([]repository.PaymentValidationLog, pkg.PaginationMetadata, error)
+	mockCreateCallbackPayloadFunc            func(ctx context.Context, payload *repository.CallbackPayload) error
+	mockListCallbackPayloadsFunc             func(ctx context.Context, transactionNumber string) ([]repository.CallbackPayload, error)
 	mockGetReportPaymentDataFunc             func(ctx context.Context, filters services.ReportFilters) ([]services.PaymentReportData, services.PaymentSummary, error)
+	mockUpdateNonPostedFunc                  func(ctx context.Context, nonPosted *repository.NonPosted) error
+	mockListPaymentAllocationsFunc           func(ctx context.Context, id uint32) ([]repository.PaymentAllocation, error)
+	mockGetPaymentReceiptDataFunc            func(ctx context.Context, id uint32) (services.PaymentReceiptData, error)
 }
 
 func (m *MockNonPostedRepository) CreateNonPosted(
@@ -57,8 +66,12 @@ func (m *MockNonPostedRepository) ListUnassignedNonPosted(
 ) ([]repository.NonPosted, error) {
 	return m.mockListUnassignedNonPostedFunc(ctx, pgData)
 }
-func (m *MockNonPostedRepository) DeleteNonPosted(ctx context.Context, id uint32) error {
-	return m.mockDeleteNonPostedFunc(ctx, id)
+func (m *MockNonPostedRepository) DeleteNonPosted(
+	ctx context.Context,
+	id uint32,
+	description string,
+) error {
+	return m.mockDeleteNonPostedFunc(ctx, id, description)
 }
 
 func (m *MockNonPostedRepository) GetClientNonPosted(
@@ -70,9 +83,72 @@ func (m *MockNonPostedRepository) GetClientNonPosted(
 	return m.mockGetClientNonPostedFunc(ctx, id, phoneNumber, pgData)
 }
 
+func (m *MockNonPostedRepository) GetProcessedCallback(
+	ctx context.Context,
+	transactionNumber string,
+	transactionSource string,
+) (repository.ProcessedCallback, error) {
+	return m.mockGetProcessedCallbackFunc(ctx, transactionNumber, transactionSource)
+}
+
+func (m *MockNonPostedRepository) ListDuplicateNonPosted(
+	ctx context.Context,
+) ([]repository.NonPosted, error) {
+	return m.mockListDuplicateNonPostedFunc(ctx)
+}
+
+func (m *MockNonPostedRepository) CreatePaymentValidationLog(
+	ctx context.Context,
+	log *repository.PaymentValidationLog,
+) error {
+	return m.mockCreatePaymentValidationLogFunc(ctx, log)
+}
+
+func (m *MockNonPostedRepository) ListPaymentValidationLogs(
+	ctx context.Context,
+	pgData *pkg.PaginationMetadata,
+) ([]repository.PaymentValidationLog, pkg.PaginationMetadata, error) {
+	return m.mockListPaymentValidationLogsFunc(ctx, pgData)
+}
+
+func (m *MockNonPostedRepository) CreateCallbackPayload(
+	ctx context.Context,
+	payload *repository.CallbackPayload,
+) error {
+	return m.mockCreateCallbackPayloadFunc(ctx, payload)
+}
+
+func (m *MockNonPostedRepository) ListCallbackPayloads(
+	ctx context.Context,
+	transactionNumber string,
+) ([]repository.CallbackPayload, error) {
+	return m.mockListCallbackPayloadsFunc(ctx, transactionNumber)
+}
+
 func (m *MockNonPostedRepository) GetReportPaymentData(
 	ctx context.Context,
 	filters services.ReportFilters,
 ) ([]services.PaymentReportData, services.PaymentSummary, error) {
 	return m.mockGetReportPaymentDataFunc(ctx, filters)
 }
+
+func (m *MockNonPostedRepository) UpdateNonPosted(
+	ctx context.Context,
+	nonPosted *repository.NonPosted,
+) error {
+	return m.mockUpdateNonPostedFunc(ctx, nonPosted)
+}
+
+func (m *MockNonPostedRepository) ListPaymentAllocationsByNonPostedId(
+	ctx context.Context,
+	id uint32,
+) ([]repository.PaymentAllocation, error) {
+	return m.mockListPaymentAllocationsFunc(ctx, id)
+}
+
+func (m *MockNonPostedRepository) GetPaymentReceiptData(
+	ctx context.Context,
+	id uint32,
+) (services.PaymentReceiptData, error) {
+	return m.mockGetPaymentReceiptDataFunc(ctx, id)
+}
@@ -185,7 +185,7 @@ func (r *BranchRepository) GetReportBranchData(
 	var maxClients int64
 
 	for i, branch := range branches {
-		disbursedAmount := pkg.InterfaceFloat64(branch.TotalDisbursedAmount)
+		disbursedAmount := pkg.InterfaceMoney(branch.TotalDisbursedAmount).Float64()
 		totalBranches++
 		totalClients += branch.TotalClients
 		totalUsers += branch.TotalUsers
@@ -207,8 +207,8 @@ func (r *BranchRepository) GetReportBranchData(
 			TotalUsers:       branch.TotalUsers,
 			LoansIssued:      branch.TotalLoansIssued,
 			TotalDisbursed:   disbursedAmount,
-			TotalCollected:   pkg.InterfaceFloat64(branch.TotalCollectedAmount),
-			TotalOutstanding: pkg.InterfaceFloat64(branch.TotalOutstandingAmount),
+			TotalCollected:   pkg.InterfaceMoney(branch.TotalCollectedAmount).Float64(),
+			TotalOutstanding: pkg.InterfaceMoney(branch.TotalOutstandingAmount).Float64(),
 			DefaultRate:      pkg.InterfaceFloat64(branch.DefaultRate),
 		}
 	}
@@ -89,46 +89,71 @@ func TestBranchRepository_ListBranches(t *testing.T) {
 	mockQueries := mockdb.NewMockQuerier(ctrl)
 	r.queries = mockQueries
 
+	pgData := &pkg.PaginationMetadata{CurrentPage: 1, PageSize: 10}
+
 	tests := []struct {
 		name       string
 		buildStubs func(mockQueries *mockdb.MockQuerier)
 		wantErr    bool
 		err        error
 		wantResult []repository.Branch
+		wantPgData pkg.PaginationMetadata
 	}{
 		{
 			name: "OK",
 			buildStubs: func(mockQueries *mockdb.MockQuerier) {
 				mockQueries.EXPECT().
-					ListBranches(gomock.Any()).
+					ListBrachesByCategory(gomock.Any(), generated.ListBrachesByCategoryParams{
+						Column1: "",
+						Limit:   10,
+						Offset:  0,
+					}).
 					Times(1).
 					Return([]generated.Branch{
 						{ID: 1, Name: "test"},
 					}, nil)
+				mockQueries.EXPECT().
+					CountBranchesByCategory(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(int64(1), nil)
 			},
 			wantErr: false,
 			err:     nil,
 			wantResult: []repository.Branch{
 				{ID: 1, Name: "test"},
 			},
+			wantPgData: pkg.PaginationMetadata{
+				CurrentPage: 1,
+				PageSize:    10,
+				TotalData:   1,
+				TotalPages:  1,
+			},
 		},
 		{
-			name: "Not Found",
+			name: "No Branches",
 			buildStubs: func(mockQueries *mockdb.MockQuerier) {
 				mockQueries.EXPECT().
-					ListBranches(gomock.Any()).
+					ListBrachesByCategory(gomock.Any(), gomock.Any()).
 					Times(1).
 					Return([]generated.Branch{}, nil)
+				mockQueries.EXPECT().
+					CountBranchesByCategory(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(int64(0), nil)
 			},
 			wantErr:    false,
 			err:        nil,
 			wantResult: []repository.Branch{},
+			wantPgData: pkg.PaginationMetadata{
+				CurrentPage: 1,
+				PageSize:    10,
+			},
 		},
 		{
 			name: "Internal Server Error",
 			buildStubs: func(mockQueries *mockdb.MockQuerier) {
 				mockQueries.EXPECT().
-					ListBranches(gomock.Any()).
+					ListBrachesByCategory(gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(nil, errors.New("error"))
 			},
@@ -141,13 +166,14 @@ func TestBranchRepository_ListBranches(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.buildStubs(mockQueries)
 
-			branches, err := r.ListBranches(context.Background())
+			branches, metadata, err := r.ListBranches(context.Background(), nil, pgData)
 			if tc.wantErr {
 				require.Error(t, err)
 				require.EqualError(t, errors.New(pkg.ErrorCode(err)), tc.err.Error())
 			} else {
 				require.NoError(t, err)
 				require.Equal(t, tc.wantResult, branches)
+				require.Equal(t, tc.wantPgData, metadata)
 			}
 		})
 	}
@@ -0,0 +1,551 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/mysql/generated"
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/repository"
+	"github.com/EmilioCliff/kokomed-fin/backend/pkg"
+)
+
+var _ repository.CashBookRepository = (*CashBookRepository)(nil)
+
+type CashBookRepository struct {
+	db      *Store
+	queries generated.Querier
+}
+
+func NewCashBookRepository(db *Store) *CashBookRepository {
+	return &CashBookRepository{
+		db:      db,
+		queries: generated.New(db.db),
+	}
+}
+
+// businessDay is the Nairobi calendar day a time falls on, as the bare date DATE columns
+// compare against.
+func businessDay(t time.Time) time.Time {
+	local := t.In(pkg.NairobiLocation())
+
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// dayBounds are the instants a run of business days starts and ends, for timestamp columns.
+func dayBounds(from, to time.Time) (time.Time, time.Time) {
+	start := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, pkg.NairobiLocation())
+	end := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, pkg.NairobiLocation())
+
+	return start, end
+}
+
+func checkBranchCashBookOpen(
+	ctx context.Context,
+	q generated.Querier,
+	branchID uint32,
+	date time.Time,
+) error {
+	day := businessDay(date)
+
+	closed, err := q.CheckCashBookClosed(ctx, generated.CheckCashBookClosedParams{
+		BranchID:     branchID,
+		BusinessDate: day,
+	})
+	if err != nil {
+		return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to check cash book: %s", err.Error())
+	}
+
+	if closed {
+		return pkg.Errorf(
+			pkg.INVALID_ERROR,
+			"the branch cash book is closed for %s",
+			day.Format("2006-01-02"),
+		)
+	}
+
+	return nil
+}
+
+// CheckCashBookOpen refuses cash entries dated on a day the user's branch has already closed.
+// Entries by anyone who is not a branch user, such as the system, are not in a cash book.
+func CheckCashBookOpen(
+	ctx context.Context,
+	q generated.Querier,
+	userEmail string,
+	date time.Time,
+) error {
+	user, err := q.GetUserByEmail(ctx, userEmail)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil
+		}
+
+		return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to get user: %s", err.Error())
+	}
+
+	return checkBranchCashBookOpen(ctx, q, user.BranchID, date)
+}
+
+// CheckPaymentCashBookOpen checks the cash book of a payment taken over the counter. M-Pesa
+// payments and money moved within the books never pass through a till.
+func CheckPaymentCashBookOpen(
+	ctx context.Context,
+	q generated.Querier,
+	cashReceipt bool,
+	assignedBy string,
+	paidDate time.Time,
+) error {
+	if !cashReceipt {
+		return nil
+	}
+
+	return CheckCashBookOpen(ctx, q, assignedBy, paidDate)
+}
+
+func checkUserCashBookOpen(
+	ctx context.Context,
+	q generated.Querier,
+	userID uint32,
+	date time.Time,
+) error {
+	user, err := q.GetUser(ctx, userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return pkg.Errorf(pkg.NOT_FOUND_ERROR, "user not found")
+		}
+
+		return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to get user: %s", err.Error())
+	}
+
+	return checkBranchCashBookOpen(ctx, q, user.BranchID, date)
+}
+
+func (r *CashBookRepository) GetCashBook(
+	ctx context.Context,
+	branchID uint32,
+	date time.Time,
+) (repository.CashBook, error) {
+	return buildCashBook(ctx, r.queries, branchID, businessDay(date))
+}
+
+func (r *CashBookRepository) CreateExpense(
+	ctx context.Context,
+	expense *repository.BranchExpense,
+) (repository.BranchExpense, error) {
+	err := r.db.ExecTx(ctx, func(q generated.Querier) error {
+		if err := checkBranchCashBookOpen(ctx, q, expense.BranchID, expense.ExpenseDate); err != nil {
+			return err
+		}
+
+		execResult, err := q.CreateBranchExpense(ctx, generated.CreateBranchExpenseParams{
+			BranchID:    expense.BranchID,
+			ExpenseDate: businessDay(expense.ExpenseDate),
+			Amount:      expense.Amount.Float64(),
+			Description: expense.Description,
+			CreatedBy:   expense.CreatedBy,
+		})
+		if err != nil {
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to create expense: %s", err.Error())
+		}
+
+		id, err := execResult.LastInsertId()
+		if err != nil {
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to get last insert id: %s", err.Error())
+		}
+
+		expense.ID = uint32(id)
+
+		return PostJournalEntry(ctx, q, repository.JournalEntry{
+			EntryType: repository.JournalExpense,
+			Description: fmt.Sprintf(
+				"BRANCH %d EXPENSE: %s",
+				expense.BranchID,
+				expense.Description,
+			),
+			CreatedBy: fmt.Sprintf("USER %d", expense.CreatedBy),
+			Lines: repository.Transfer(
+				repository.AccountOperatingExpenses,
+				repository.AccountCash,
+				expense.Amount,
+			),
+		})
+	})
+	if err != nil {
+		return repository.BranchExpense{}, err
+	}
+
+	expense.ExpenseDate = businessDay(expense.ExpenseDate)
+
+	return *expense, nil
+}
+
+func (r *CashBookRepository) CloseCashBook(
+	ctx context.Context,
+	closeData *repository.CloseCashBook,
+) (repository.CashBook, error) {
+	day := businessDay(closeData.Date)
+	if day.After(businessDay(time.Now())) {
+		return repository.CashBook{}, pkg.Errorf(pkg.INVALID_ERROR, "a future day cannot be closed")
+	}
+
+	var book repository.CashBook
+
+	err := r.db.ExecTx(ctx, func(q generated.Querier) error {
+		// a day is closed once, and not behind a later close
+		if err := checkBranchCashBookOpen(ctx, q, closeData.BranchID, day); err != nil {
+			return err
+		}
+
+		var err error
+
+		book, err = buildCashBook(ctx, q, closeData.BranchID, day)
+		if err != nil {
+			return err
+		}
+
+		variance := closeData.CountedCash - book.ClosingBalance
+		if variance != 0 && closeData.Note == "" {
+			return pkg.Errorf(
+				pkg.INVALID_ERROR,
+				"counted cash differs from the expected %s, a note is required",
+				book.ClosingBalance,
+			)
+		}
+
+		execResult, err := q.CreateCashBookClose(ctx, generated.CreateCashBookCloseParams{
+			BranchID:        closeData.BranchID,
+			BusinessDate:    day,
+			OpeningBalance:  book.OpeningBalance.Float64(),
+			CashReceived:    book.TotalReceived.Float64(),
+			CashDisbursed:   book.TotalDisbursed.Float64(),
+			Expenses:        book.TotalExpenses.Float64(),
+			ExpectedClosing: book.ClosingBalance.Float64(),
+			CountedCash:     closeData.CountedCash.Float64(),
+			ClosedBy:        closeData.ClosedBy,
+		})
+		if err != nil {
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to close cash book: %s", err.Error())
+		}
+
+		closeID, err := execResult.LastInsertId()
+		if err != nil {
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to get last insert id: %s", err.Error())
+		}
+
+		if variance != 0 {
+			if _, err := q.CreateCashVariance(ctx, generated.CreateCashVarianceParams{
+				CloseID:      uint32(closeID),
+				BranchID:     closeData.BranchID,
+				BusinessDate: day,
+				ExpectedCash: book.ClosingBalance.Float64(),
+				CountedCash:  closeData.CountedCash.Float64(),
+				Variance:     variance.Float64(),
+				Note:         closeData.Note,
+				CreatedBy:    closeData.ClosedBy,
+			}); err != nil {
+				return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to record cash variance: %s", err.Error())
+			}
+
+			// a shortage is written off, a surplus brought into the books
+			if err := PostJournalEntry(ctx, q, repository.JournalEntry{
+				EntryType: repository.JournalCashVariance,
+				Description: fmt.Sprintf(
+					"BRANCH %d TILL VARIANCE %s: %s",
+					closeData.BranchID,
+					day.Format("2006-01-02"),
+					closeData.Note,
+				),
+				CreatedBy: fmt.Sprintf("USER %d", closeData.ClosedBy),
+				Lines: repository.Transfer(
+					repository.AccountCashOverShort,
+					repository.AccountCash,
+					-variance,
+				),
+			}); err != nil {
+				return err
+			}
+		}
+
+		book.Closed = true
+		book.Close = &repository.CashBookClose{
+			ID:              uint32(closeID),
+			BusinessDate:    day,
+			ExpectedClosing: book.ClosingBalance,
+			CountedCash:     closeData.CountedCash,
+			Variance:        variance,
+			ClosedBy:        closeData.ClosedBy,
+			ClosedAt:        time.Now(),
+		}
+
+		return nil
+	})
+	if err != nil {
+		return repository.CashBook{}, err
+	}
+
+	return book, nil
+}
+
+func (r *CashBookRepository) ListCashVariances(
+	ctx context.Context,
+	branchID uint32,
+	pgData *pkg.PaginationMetadata,
+) ([]repository.CashVariance, pkg.PaginationMetadata, error) {
+	variances, err := r.queries.ListCashVariances(ctx, generated.ListCashVariancesParams{
+		BranchID: branchID,
+		Limit:    int32(pgData.PageSize),
+		Offset:   pkg.CalculateOffset(pgData.CurrentPage, pgData.PageSize),
+	})
+	if err != nil {
+		return nil, pkg.PaginationMetadata{}, pkg.Errorf(
+			pkg.INTERNAL_ERROR,
+			"failed to list cash variances: %s",
+			err.Error(),
+		)
+	}
+
+	totalVariances, err := r.queries.CountCashVariances(ctx, branchID)
+	if err != nil {
+		return nil, pkg.PaginationMetadata{}, pkg.Errorf(
+			pkg.INTERNAL_ERROR,
+			"failed to count cash variances: %s",
+			err.Error(),
+		)
+	}
+
+	rslt := make([]repository.CashVariance, len(variances))
+	for i, variance := range variances {
+		rslt[i] = repository.CashVariance{
+			ID:           variance.ID,
+			CloseID:      variance.CloseID,
+			BranchID:     variance.BranchID,
+			BusinessDate: variance.BusinessDate,
+			ExpectedCash: pkg.MoneyFromFloat(variance.ExpectedCash),
+			CountedCash:  pkg.MoneyFromFloat(variance.CountedCash),
+			Variance:     pkg.MoneyFromFloat(variance.Variance),
+			Note:         variance.Note,
+			CreatedBy:    variance.CreatedBy,
+			CreatedAt:    variance.CreatedAt,
+		}
+	}
+
+	return rslt, pkg.CreatePaginationMetadata(
+		uint32(totalVariances),
+		pgData.PageSize,
+		pgData.CurrentPage,
+	), nil
+}
+
+func buildCashBook(
+	ctx context.Context,
+	q generated.Querier,
+	branchID uint32,
+	day time.Time,
+) (repository.CashBook, error) {
+	book := repository.CashBook{
+		BranchID: branchID,
+		Date:     day,
+	}
+
+	nextDay := day.AddDate(0, 0, 1)
+
+	var err error
+
+	book.Receipts, book.Disbursements, book.Expenses, err = listCashMovements(ctx, q, branchID, day, nextDay)
+	if err != nil {
+		return repository.CashBook{}, err
+	}
+
+	book.TotalReceived, book.TotalDisbursed, book.TotalExpenses = sumCashMovements(
+		book.Receipts,
+		book.Disbursements,
+		book.Expenses,
+	)
+
+	// the opening balance is counted cash carried from the last close, without one the book
+	// starts empty
+	lastClose, err := q.GetLatestCashBookClose(ctx, generated.GetLatestCashBookCloseParams{
+		BranchID:     branchID,
+		BusinessDate: day,
+	})
+	if err != nil && err != sql.ErrNoRows {
+		return repository.CashBook{}, pkg.Errorf(
+			pkg.INTERNAL_ERROR,
+			"failed to get last cash book close: %s",
+			err.Error(),
+		)
+	}
+
+	if err == nil {
+		book.OpeningBalance = pkg.MoneyFromFloat(lastClose.CountedCash)
+
+		// days left open since the last close still moved cash
+		gapStart := lastClose.BusinessDate.AddDate(0, 0, 1)
+		if gapStart.Before(day) {
+			receipts, disbursements, expenses, err := listCashMovements(ctx, q, branchID, gapStart, day)
+			if err != nil {
+				return repository.CashBook{}, err
+			}
+
+			received, disbursed, spent := sumCashMovements(receipts, disbursements, expenses)
+			book.OpeningBalance += received - disbursed - spent
+		}
+	}
+
+	book.ClosingBalance = book.OpeningBalance + book.TotalReceived - book.TotalDisbursed - book.TotalExpenses
+
+	dayClose, err := q.GetCashBookClose(ctx, generated.GetCashBookCloseParams{
+		BranchID:     branchID,
+		BusinessDate: day,
+	})
+	if err != nil && err != sql.ErrNoRows {
+		return repository.CashBook{}, pkg.Errorf(
+			pkg.INTERNAL_ERROR,
+			"failed to get cash book close: %s",
+			err.Error(),
+		)
+	}
+
+	if err == nil {
+		book.Closed = true
+		book.Close = &repository.CashBookClose{
+			ID:              dayClose.ID,
+			BusinessDate:    dayClose.BusinessDate,
+			ExpectedClosing: pkg.MoneyFromFloat(dayClose.ExpectedClosing),
+			CountedCash:     pkg.MoneyFromFloat(dayClose.CountedCash),
+			Variance:        pkg.MoneyFromFloat(dayClose.CountedCash - dayClose.ExpectedClosing),
+			ClosedBy:        dayClose.ClosedBy,
+			ClosedAt:        dayClose.ClosedAt,
+		}
+	} else {
+		closed, err := q.CheckCashBookClosed(ctx, generated.CheckCashBookClosedParams{
+			BranchID:     branchID,
+			BusinessDate: day,
+		})
+		if err != nil {
+			return repository.CashBook{}, pkg.Errorf(
+				pkg.INTERNAL_ERROR,
+				"failed to check cash book: %s",
+				err.Error(),
+			)
+		}
+
+		// a day skipped before a later close is locked all the same
+		book.Closed = closed
+	}
+
+	return book, nil
+}
+
+// listCashMovements lists the cash that moved through a branch's till on the days from from up
+// to but not including to.
+func listCashMovements(
+	ctx context.Context,
+	q generated.Querier,
+	branchID uint32,
+	from, to time.Time,
+) ([]repository.CashReceipt, []repository.CashDisbursement, []repository.BranchExpense, error) {
+	start, end := dayBounds(from, to)
+
+	receiptRows, err := q.ListBranchCashReceipts(ctx, generated.ListBranchCashReceiptsParams{
+		BranchID: branchID,
+		FromDate: start,
+		ToDate:   end,
+	})
+	if err != nil {
+		return nil, nil, nil, pkg.Errorf(
+			pkg.INTERNAL_ERROR,
+			"failed to list cash receipts: %s",
+			err.Error(),
+		)
+	}
+
+	disbursementRows, err := q.ListBranchCashDisbursements(
+		ctx,
+		generated.ListBranchCashDisbursementsParams{
+			BranchID: branchID,
+			FromDate: from,
+			ToDate:   to,
+		},
+	)
+	if err != nil {
+		return nil, nil, nil, pkg.Errorf(
+			pkg.INTERNAL_ERROR,
+			"failed to list cash disbursements: %s",
+			err.Error(),
+		)
+	}
+
+	expenseRows, err := q.ListBranchExpenses(ctx, generated.ListBranchExpensesParams{
+		BranchID: branchID,
+		FromDate: from,
+		ToDate:   to,
+	})
+	if err != nil {
+		return nil, nil, nil, pkg.Errorf(
+			pkg.INTERNAL_ERROR,
+			"failed to list expenses: %s",
+			err.Error(),
+		)
+	}
+
+	receipts := make([]repository.CashReceipt, len(receiptRows))
+	for i, row := range receiptRows {
+		receipts[i] = repository.CashReceipt{
+			PaymentID:         row.ID,
+			TransactionNumber: row.TransactionNumber,
+			PayingName:        row.PayingName,
+			Amount:            pkg.MoneyFromFloat(row.Amount),
+			PaidDate:          row.PaidDate,
+			ReceivedBy:        row.AssignedBy,
+		}
+	}
+
+	disbursements := make([]repository.CashDisbursement, len(disbursementRows))
+	for i, row := range disbursementRows {
+		disbursements[i] = repository.CashDisbursement{
+			LoanID:      row.ID,
+			ClientName:  row.ClientName,
+			Amount:      pkg.InterfaceMoney(row.CashPaid),
+			DisbursedOn: row.DisbursedOn.Time,
+			DisbursedBy: row.DisbursedByName,
+		}
+	}
+
+	expenses := make([]repository.BranchExpense, len(expenseRows))
+	for i, row := range expenseRows {
+		expenses[i] = repository.BranchExpense{
+			ID:          row.ID,
+			BranchID:    row.BranchID,
+			ExpenseDate: row.ExpenseDate,
+			Amount:      pkg.MoneyFromFloat(row.Amount),
+			Description: row.Description,
+			CreatedBy:   row.CreatedBy,
+			CreatedAt:   row.CreatedAt,
+		}
+	}
+
+	return receipts, disbursements, expenses, nil
+}
+
+func sumCashMovements(
+	receipts []repository.CashReceipt,
+	disbursements []repository.CashDisbursement,
+	expenses []repository.BranchExpense,
+) (received, disbursed, spent pkg.Money) {
+	for _, receipt := range receipts {
+		received += receipt.Amount
+	}
+
+	for _, disbursement := range disbursements {
+		disbursed += disbursement.Amount
+	}
+
+	for _, expense := range expenses {
+		spent += expense.Amount
+	}
+
+	return received, disbursed, spent
+}
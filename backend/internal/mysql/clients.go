@@ -4,7 +4,6 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
-	"strconv"
 	"time"
 
 	"github.com/EmilioCliff/kokomed-fin/backend/internal/mysql/generated"
@@ -139,11 +138,11 @@ func (r *ClientRepository) UpdateClient(
 func (r *ClientRepository) UpdateClientOverpayment(
 	ctx context.Context,
 	phoneNumber string,
-	overpayment float64,
+	overpayment pkg.Money,
 ) error {
 	_, err := r.queries.UpdateClientOverpayment(ctx, generated.UpdateClientOverpaymentParams{
 		PhoneNumber: phoneNumber,
-		Overpayment: overpayment,
+		Overpayment: overpayment.Float64(),
 	})
 	if err != nil {
 		return pkg.Errorf(
@@ -236,9 +235,6 @@ func (r *ClientRepository) ListClients(
 			idNo = &value
 		}
 
-		dueAmountBtye, _ := client.Dueamount.([]byte)
-		dueAmount, _ := strconv.ParseFloat(string(dueAmountBtye), 64)
-
 		result[i] = repository.ClientFullData{
 			ID:          client.ID,
 			FullName:    client.FullName,
@@ -254,7 +250,7 @@ func (r *ClientRepository) ListClients(
 				Email:       client.AssignedUserEmail.String,
 				Role:        string(client.AssignedUserRole.UsersRole),
 			},
-			Overpayment: client.Overpayment,
+			Overpayment: pkg.MoneyFromFloat(client.Overpayment),
 			UpdatedBy: repository.UserShortResponse{
 				ID:          uint32(client.UpdatedUserID.Int32),
 				FullName:    client.UpdatedUserName.String,
@@ -272,7 +268,7 @@ func (r *ClientRepository) ListClients(
 			},
 			CreatedAt:  client.CreatedAt,
 			BranchName: client.BranchName,
-			DueAmount:  dueAmount,
+			DueAmount:  pkg.InterfaceMoney(client.Dueamount),
 		}
 	}
 
@@ -318,6 +314,78 @@ func (r *ClientRepository) GetClientIDByPhoneNumber(
 	return id, nil
 }
 
+func (r *ClientRepository) BlacklistClient(
+	ctx context.Context,
+	blacklist *repository.BlacklistedClient,
+) (repository.BlacklistedClient, error) {
+	execResult, err := r.queries.CreateBlacklistedClient(ctx, generated.CreateBlacklistedClientParams{
+		ClientID:  blacklist.ClientID,
+		Reason:    blacklist.Reason,
+		CreatedBy: blacklist.CreatedBy,
+	})
+	if err != nil {
+		return repository.BlacklistedClient{}, pkg.Errorf(
+			pkg.INTERNAL_ERROR,
+			"failed to blacklist client: %s",
+			err.Error(),
+		)
+	}
+
+	id, err := execResult.LastInsertId()
+	if err != nil {
+		return repository.BlacklistedClient{}, pkg.Errorf(
+			pkg.INTERNAL_ERROR,
+			"failed to get last insert id: %s",
+			err.Error(),
+		)
+	}
+
+	blacklist.ID = uint32(id)
+
+	return *blacklist, nil
+}
+
+func (r *ClientRepository) GetBlacklistedClient(
+	ctx context.Context,
+	clientID uint32,
+) (repository.BlacklistedClient, error) {
+	blacklist, err := r.queries.GetBlacklistedClient(ctx, clientID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return repository.BlacklistedClient{}, pkg.Errorf(
+				pkg.NOT_FOUND_ERROR,
+				"client is not blacklisted",
+			)
+		}
+
+		return repository.BlacklistedClient{}, pkg.Errorf(
+			pkg.INTERNAL_ERROR,
+			"failed to get blacklisted client: %s",
+			err.Error(),
+		)
+	}
+
+	return repository.BlacklistedClient{
+		ID:        blacklist.ID,
+		ClientID:  blacklist.ClientID,
+		Reason:    blacklist.Reason,
+		CreatedBy: blacklist.CreatedBy,
+		CreatedAt: blacklist.CreatedAt,
+	}, nil
+}
+
+func (r *ClientRepository) RemoveBlacklistedClient(ctx context.Context, clientID uint32) error {
+	if err := r.queries.DeleteBlacklistedClient(ctx, clientID); err != nil {
+		return pkg.Errorf(
+			pkg.INTERNAL_ERROR,
+			"failed to remove blacklisted client: %s",
+			err.Error(),
+		)
+	}
+
+	return nil
+}
+
 func (r *ClientRepository) ListClientsByBranch(
 	ctx context.Context,
 	branchID uint32,
@@ -401,9 +469,9 @@ func (r *ClientRepository) GetReportClientAdminData(
 	var maxLoansGiven, maxPaidAmount float64
 
 	for i, client := range clients {
-		totalPaidAmount := pkg.InterfaceFloat64(client.TotalPaid)
-		totalDisbursedAmount := pkg.InterfaceFloat64(client.TotalDisbursed)
-		totalOwedAmount := pkg.InterfaceFloat64(client.TotalOwed)
+		totalPaidAmount := pkg.InterfaceMoney(client.TotalPaid).Float64()
+		totalDisbursedAmount := pkg.InterfaceMoney(client.TotalDisbursed).Float64()
+		totalOwedAmount := pkg.InterfaceMoney(client.TotalOwed).Float64()
 
 		rslt[i] = services.ClientAdminsReportData{
 			Name:           client.Name,
@@ -502,7 +570,7 @@ func convertGeneratedClient(client generated.Client) repository.Client {
 		Active:        client.Active,
 		BranchID:      client.BranchID,
 		AssignedStaff: client.AssignedStaff,
-		Overpayment:   client.Overpayment,
+		Overpayment:   pkg.MoneyFromFloat(client.Overpayment),
 		UpdatedBy:     client.UpdatedBy,
 		UpdatedAt:     client.UpdatedAt,
 		CreatedBy:     client.CreatedBy,
@@ -532,7 +600,7 @@ func convertClientFullData(client generated.GetClientFullDataRow) repository.Cli
 		Gender:      string(client.Gender),
 		Active:      client.Active,
 		BranchName:  client.BranchName,
-		Overpayment: client.Overpayment,
+		Overpayment: pkg.MoneyFromFloat(client.Overpayment),
 		CreatedAt:   client.ClientCreatedAt,
 		AssignedStaff: repository.UserShortResponse{
 			ID:          client.AssignedUserID,
@@ -588,7 +656,7 @@ func convertClientReportData(
 		if err != nil {
 			return services.ClientClientsReportData{}, pkg.Errorf(
 				pkg.INTERNAL_ERROR,
-				"error unmarshalling payments: ",
+				"error unmarshalling payments: %v",
 				err,
 			)
 		}
@@ -29,12 +29,14 @@ func TestClientRepository_CreateClient(t *testing.T) {
 	mockQueries := mockdb.NewMockQuerier(ctrl)
 	r.queries = mockQueries
 
+	createdClient := generated.GetClientFullDataRow{ClientID: 1, ClientName: "test"}
+
 	tests := []struct {
 		name       string
 		buildStubs func(mockQueries *mockdb.MockQuerier)
 		wantErr    bool
 		err        error
-		wantResult repository.Client
+		wantResult repository.ClientFullData
 	}{
 		{
 			name: "OK",
@@ -43,10 +45,14 @@ func TestClientRepository_CreateClient(t *testing.T) {
 					CreateClient(gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(&mockSQLResult{lastInsertID: 1, rowsAffected: 1}, nil)
+				mockQueries.EXPECT().
+					GetClientFullData(gomock.Any(), uint32(1)).
+					Times(1).
+					Return(createdClient, nil)
 			},
 			wantErr:    false,
 			err:        nil,
-			wantResult: repository.Client{ID: 1, FullName: "test"},
+			wantResult: convertClientFullData(createdClient),
 		},
 		{
 			name: "Internal Error",
@@ -58,7 +64,7 @@ func TestClientRepository_CreateClient(t *testing.T) {
 			},
 			wantErr:    true,
 			err:        errors.New(pkg.INTERNAL_ERROR),
-			wantResult: repository.Client{},
+			wantResult: repository.ClientFullData{},
 		},
 	}
 
@@ -95,19 +101,20 @@ func TestClientRepository_UpdateClient(t *testing.T) {
 		buildStubs func(mockQueries *mockdb.MockQuerier)
 		wantErr    bool
 		err        error
-		wantResult repository.Client
 	}{
 		{
 			name: "OK",
 			buildStubs: func(mockQueries *mockdb.MockQuerier) {
 				mockQueries.EXPECT().
-					UpdateClient(gomock.Any(), gomock.Any()).
+					UpdateClient(gomock.Any(), generated.UpdateClientParams{
+						ID:       1,
+						FullName: "test",
+					}).
 					Times(1).
 					Return(&mockSQLResult{lastInsertID: 1, rowsAffected: 1}, nil)
 			},
-			wantErr:    false,
-			err:        nil,
-			wantResult: repository.Client{ID: 1, FullName: "test"},
+			wantErr: false,
+			err:     nil,
 		},
 		{
 			name: "Internal Error",
@@ -117,9 +124,8 @@ func TestClientRepository_UpdateClient(t *testing.T) {
 					Times(1).
 					Return(&mockSQLResult{lastInsertID: 1, rowsAffected: 1}, errors.New("error"))
 			},
-			wantErr:    true,
-			err:        errors.New(pkg.INTERNAL_ERROR),
-			wantResult: repository.Client{},
+			wantErr: true,
+			err:     errors.New(pkg.INTERNAL_ERROR),
 		},
 	}
 
@@ -127,7 +133,7 @@ func TestClientRepository_UpdateClient(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.buildStubs(mockQueries)
 
-			result, err := r.UpdateClient(context.Background(), &repository.Client{
+			err := r.UpdateClient(context.Background(), &repository.UpdateClient{
 				ID:       1,
 				FullName: "test",
 			})
@@ -137,7 +143,6 @@ func TestClientRepository_UpdateClient(t *testing.T) {
 				require.EqualError(t, errors.New(pkg.ErrorCode(err)), tc.err.Error())
 			} else {
 				require.NoError(t, err)
-				require.Equal(t, tc.wantResult, result)
 			}
 		})
 	}
@@ -152,58 +157,69 @@ func TestClientRepository_ListClients(t *testing.T) {
 	mockQueries := mockdb.NewMockQuerier(ctrl)
 	r.queries = mockQueries
 
+	pgData := &pkg.PaginationMetadata{CurrentPage: 1, PageSize: 10}
+
 	tests := []struct {
 		name       string
 		buildStubs func(mockQueries *mockdb.MockQuerier)
 		wantErr    bool
 		err        error
-		wantResult []repository.Client
+		wantIDs    []uint32
+		wantPgData pkg.PaginationMetadata
 	}{
 		{
 			name: "OK",
 			buildStubs: func(mockQueries *mockdb.MockQuerier) {
 				mockQueries.EXPECT().
-					ListClients(gomock.Any(), gomock.AssignableToTypeOf(generated.ListClientsParams{})).
+					ListClientsByCategory(gomock.Any(), generated.ListClientsByCategoryParams{
+						Column1: "",
+						Limit:   10,
+						Offset:  0,
+					}).
 					Times(1).
-					Return([]generated.Client{
+					Return([]generated.ListClientsByCategoryRow{
 						{
-							ID:       1,
-							FullName: "test",
+							ID:          1,
+							FullName:    "test",
+							Overpayment: 250,
 						},
 					}, nil)
+				mockQueries.EXPECT().
+					CountClientsByCategory(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(int64(1), nil)
 			},
 			wantErr: false,
 			err:     nil,
-			wantResult: []repository.Client{
-				{
-					ID:       1,
-					FullName: "test",
-				},
+			wantIDs: []uint32{1},
+			wantPgData: pkg.PaginationMetadata{
+				CurrentPage: 1,
+				PageSize:    10,
+				TotalData:   1,
+				TotalPages:  1,
 			},
 		},
 		{
 			name: "Not Found",
 			buildStubs: func(mockQueries *mockdb.MockQuerier) {
 				mockQueries.EXPECT().
-					ListClients(gomock.Any(), gomock.AssignableToTypeOf(generated.ListClientsParams{})).
+					ListClientsByCategory(gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(nil, sql.ErrNoRows)
 			},
-			wantErr:    true,
-			err:        errors.New(pkg.NOT_FOUND_ERROR),
-			wantResult: nil,
+			wantErr: true,
+			err:     errors.New(pkg.NOT_FOUND_ERROR),
 		},
 		{
 			name: "Internal Error",
 			buildStubs: func(mockQueries *mockdb.MockQuerier) {
 				mockQueries.EXPECT().
-					ListClients(gomock.Any(), gomock.AssignableToTypeOf(generated.ListClientsParams{})).
+					ListClientsByCategory(gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(nil, errors.New("error"))
 			},
-			wantErr:    true,
-			err:        errors.New(pkg.INTERNAL_ERROR),
-			wantResult: nil,
+			wantErr: true,
+			err:     errors.New(pkg.INTERNAL_ERROR),
 		},
 	}
 
@@ -211,20 +227,32 @@ func TestClientRepository_ListClients(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.buildStubs(mockQueries)
 
-			result, err := r.ListClients(context.Background(), &pkg.PaginationMetadata{})
+			result, metadata, err := r.ListClients(
+				context.Background(),
+				&repository.ClientCategorySearch{},
+				pgData,
+			)
 
 			if tc.wantErr {
 				require.Error(t, err)
 				require.EqualError(t, errors.New(pkg.ErrorCode(err)), tc.err.Error())
 			} else {
 				require.NoError(t, err)
-				require.Equal(t, tc.wantResult, result)
+				require.Len(t, result, len(tc.wantIDs))
+
+				for i, id := range tc.wantIDs {
+					require.Equal(t, id, result[i].ID)
+					require.Equal(t, "test", result[i].FullName)
+					require.Equal(t, pkg.MoneyFromFloat(250), result[i].Overpayment)
+				}
+
+				require.Equal(t, tc.wantPgData, metadata)
 			}
 		})
 	}
 }
 
-func TestClientRepository_GetClient(t *testing.T) {
+func TestClientRepository_GetClientFullData(t *testing.T) {
 	r := NewTestClientRepository()
 
 	ctrl := gomock.NewController(t)
@@ -238,46 +266,39 @@ func TestClientRepository_GetClient(t *testing.T) {
 		buildStubs func(mockQueries *mockdb.MockQuerier)
 		wantErr    bool
 		err        error
-		wantResult repository.Client
 	}{
 		{
 			name: "OK",
 			buildStubs: func(mockQueries *mockdb.MockQuerier) {
 				mockQueries.EXPECT().
-					GetClient(gomock.Any(), gomock.Any()).
+					GetClientFullData(gomock.Any(), uint32(1)).
 					Times(1).
-					Return(generated.Client{ID: 1, FullName: "test"}, nil)
+					Return(generated.GetClientFullDataRow{ClientID: 1, ClientName: "test"}, nil)
 			},
 			wantErr: false,
 			err:     nil,
-			wantResult: repository.Client{
-				ID:       1,
-				FullName: "test",
-			},
 		},
 		{
 			name: "Not Found",
 			buildStubs: func(mockQueries *mockdb.MockQuerier) {
 				mockQueries.EXPECT().
-					GetClient(gomock.Any(), gomock.Any()).
+					GetClientFullData(gomock.Any(), gomock.Any()).
 					Times(1).
-					Return(generated.Client{}, sql.ErrNoRows)
+					Return(generated.GetClientFullDataRow{}, sql.ErrNoRows)
 			},
-			wantErr:    true,
-			err:        errors.New(pkg.NOT_FOUND_ERROR),
-			wantResult: repository.Client{},
+			wantErr: true,
+			err:     errors.New(pkg.NOT_FOUND_ERROR),
 		},
 		{
 			name: "Internal Error",
 			buildStubs: func(mockQueries *mockdb.MockQuerier) {
 				mockQueries.EXPECT().
-					GetClient(gomock.Any(), gomock.Any()).
+					GetClientFullData(gomock.Any(), gomock.Any()).
 					Times(1).
-					Return(generated.Client{}, errors.New("error"))
+					Return(generated.GetClientFullDataRow{}, errors.New("error"))
 			},
-			wantErr:    true,
-			err:        errors.New(pkg.INTERNAL_ERROR),
-			wantResult: repository.Client{},
+			wantErr: true,
+			err:     errors.New(pkg.INTERNAL_ERROR),
 		},
 	}
 
@@ -285,14 +306,15 @@ func TestClientRepository_GetClient(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.buildStubs(mockQueries)
 
-			result, err := r.GetClient(context.Background(), 1)
+			result, err := r.GetClientFullData(context.Background(), 1)
 
 			if tc.wantErr {
 				require.Error(t, err)
 				require.EqualError(t, errors.New(pkg.ErrorCode(err)), tc.err.Error())
 			} else {
 				require.NoError(t, err)
-				require.Equal(t, tc.wantResult, result)
+				require.Equal(t, uint32(1), result.ID)
+				require.Equal(t, "test", result.FullName)
 			}
 		})
 	}
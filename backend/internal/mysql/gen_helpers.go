@@ -603,7 +603,9 @@ SELECT
     p.repay_amount,
     l.paid_amount,
     COALESCE(p.repay_amount - l.paid_amount, 0) AS outstanding_amount,
+    COALESCE((SELECT SUM(pen.remaining_amount) FROM installment_penalties pen WHERE pen.loan_id = l.id), 0) AS penalty_balance,
     l.status,
+    EXISTS(SELECT 1 FROM loan_restructures lr WHERE lr.loan_id = l.id) AS restructured,
     l.total_installments,
     COUNT(CASE WHEN i.paid = TRUE THEN i.id END) AS paid_installments,
     l.due_date AS due_date,
@@ -640,7 +642,9 @@ type GetLoansReportDataRow struct {
 	RepayAmount       float64      `json:"repay_amount"`
 	PaidAmount        float64      `json:"paid_amount"`
 	OutstandingAmount interface{}  `json:"outstanding_amount"`
+	PenaltyBalance    interface{}  `json:"penalty_balance"`
 	Status            string       `json:"status"`
+	Restructured      bool         `json:"restructured"`
 	TotalInstallments uint32       `json:"total_installments"`
 	PaidInstallments  int64        `json:"paid_installments"`
 	DueDate           sql.NullTime `json:"due_date"`
@@ -669,7 +673,9 @@ func (q *LoanRepository) GetLoansReportData(ctx context.Context, arg GetLoansRep
 			&i.RepayAmount,
 			&i.PaidAmount,
 			&i.OutstandingAmount,
+			&i.PenaltyBalance,
 			&i.Status,
+			&i.Restructured,
 			&i.TotalInstallments,
 			&i.PaidInstallments,
 			&i.DueDate,
@@ -696,7 +702,9 @@ SELECT
     p.loan_amount,
     p.repay_amount,
     l.paid_amount,
+    COALESCE((SELECT SUM(pen.remaining_amount) FROM installment_penalties pen WHERE pen.loan_id = l.id), 0) AS penalty_balance,
     l.status,
+    EXISTS(SELECT 1 FROM loan_restructures lr WHERE lr.loan_id = l.id) AS restructured,
     l.total_installments,
     COUNT(CASE WHEN i.paid = TRUE THEN i.id END) AS paid_installments,
     (l.total_installments - COUNT(CASE WHEN i.paid = TRUE THEN i.id END)) AS remaining_installments,
@@ -708,7 +716,8 @@ SELECT
                 'remaining_amount', i.remaining_amount,
                 'due_date', i.due_date,
                 'paid', i.paid,
-                'paid_at', i.paid_at
+                'paid_at', i.paid_at,
+                'closed', i.closed_at IS NOT NULL
             )
         ), '[]'
     ) AS installment_details
@@ -729,7 +738,9 @@ type GetLoanReportDataByIdRow struct {
 	LoanAmount            float64     `json:"loan_amount"`
 	RepayAmount           float64     `json:"repay_amount"`
 	PaidAmount            float64     `json:"paid_amount"`
+	PenaltyBalance        interface{} `json:"penalty_balance"`
 	Status                string      `json:"status"`
+	Restructured          bool        `json:"restructured"`
 	TotalInstallments     int64       `json:"total_installments"`
 	PaidInstallments      int64       `json:"paid_installments"`
 	RemainingInstallments int64       `json:"remaining_installments"`
@@ -745,7 +756,9 @@ func (q *LoanRepository) GetLoanReportDataById(ctx context.Context, id uint32) (
 		&i.LoanAmount,
 		&i.RepayAmount,
 		&i.PaidAmount,
+		&i.PenaltyBalance,
 		&i.Status,
+		&i.Restructured,
 		&i.TotalInstallments,
 		&i.PaidInstallments,
 		&i.RemainingInstallments,
@@ -12,14 +12,15 @@ import (
 )
 
 const createPaymentAllocation = `-- name: CreatePaymentAllocation :execresult
-INSERT INTO payment_allocations (non_posted_id, loan_id, installment_id, amount, description)
-VALUES (?, ?, ?, ?, ?)
+INSERT INTO payment_allocations (non_posted_id, loan_id, installment_id, penalty_id, amount, description)
+VALUES (?, ?, ?, ?, ?, ?)
 `
 
 type CreatePaymentAllocationParams struct {
 	NonPostedID   uint32        `json:"non_posted_id"`
 	LoanID        sql.NullInt32 `json:"loan_id"`
 	InstallmentID sql.NullInt32 `json:"installment_id"`
+	PenaltyID     sql.NullInt32 `json:"penalty_id"`
 	Amount        float64       `json:"amount"`
 	Description   string        `json:"description"`
 }
@@ -29,6 +30,7 @@ func (q *Queries) CreatePaymentAllocation(ctx context.Context, arg CreatePayment
 		arg.NonPostedID,
 		arg.LoanID,
 		arg.InstallmentID,
+		arg.PenaltyID,
 		arg.Amount,
 		arg.Description,
 	)
@@ -57,7 +59,7 @@ func (q *Queries) DeletePaymentAllocationsByNonPostedId(ctx context.Context, arg
 
 const listPaymentAllocationsByLoanId = `-- name: ListPaymentAllocationsByLoanId :many
 SELECT 
-  pa.id, pa.non_posted_id, pa.loan_id, pa.installment_id, pa.amount, pa.description, pa.deleted_at, pa.deleted_description, pa.created_at,
+  pa.id, pa.non_posted_id, pa.loan_id, pa.installment_id, pa.amount, pa.description, pa.deleted_at, pa.deleted_description, pa.created_at, pa.penalty_id,
   np.transaction_source,
   np.transaction_number,
   np.account_number,
@@ -80,6 +82,7 @@ type ListPaymentAllocationsByLoanIdRow struct {
 	DeletedAt          sql.NullTime               `json:"deleted_at"`
 	DeletedDescription sql.NullString             `json:"deleted_description"`
 	CreatedAt          time.Time                  `json:"created_at"`
+	PenaltyID          sql.NullInt32              `json:"penalty_id"`
 	TransactionSource  NonPostedTransactionSource `json:"transaction_source"`
 	TransactionNumber  string                     `json:"transaction_number"`
 	AccountNumber      string                     `json:"account_number"`
@@ -107,6 +110,7 @@ func (q *Queries) ListPaymentAllocationsByLoanId(ctx context.Context, loanID sql
 			&i.DeletedAt,
 			&i.DeletedDescription,
 			&i.CreatedAt,
+			&i.PenaltyID,
 			&i.TransactionSource,
 			&i.TransactionNumber,
 			&i.AccountNumber,
@@ -129,7 +133,7 @@ func (q *Queries) ListPaymentAllocationsByLoanId(ctx context.Context, loanID sql
 
 const listPaymentAllocationsByNonPostedID = `-- name: ListPaymentAllocationsByNonPostedID :many
 SELECT 
-  pa.id, pa.non_posted_id, pa.loan_id, pa.installment_id, pa.amount, pa.description, pa.deleted_at, pa.deleted_description, pa.created_at,
+  pa.id, pa.non_posted_id, pa.loan_id, pa.installment_id, pa.amount, pa.description, pa.deleted_at, pa.deleted_description, pa.created_at, pa.penalty_id,
   np.transaction_source,
   np.transaction_number,
   np.account_number,
@@ -152,6 +156,7 @@ type ListPaymentAllocationsByNonPostedIDRow struct {
 	DeletedAt          sql.NullTime               `json:"deleted_at"`
 	DeletedDescription sql.NullString             `json:"deleted_description"`
 	CreatedAt          time.Time                  `json:"created_at"`
+	PenaltyID          sql.NullInt32              `json:"penalty_id"`
 	TransactionSource  NonPostedTransactionSource `json:"transaction_source"`
 	TransactionNumber  string                     `json:"transaction_number"`
 	AccountNumber      string                     `json:"account_number"`
@@ -179,6 +184,7 @@ func (q *Queries) ListPaymentAllocationsByNonPostedID(ctx context.Context, nonPo
 			&i.DeletedAt,
 			&i.DeletedDescription,
 			&i.CreatedAt,
+			&i.PenaltyID,
 			&i.TransactionSource,
 			&i.TransactionNumber,
 			&i.AccountNumber,
@@ -200,7 +206,7 @@ func (q *Queries) ListPaymentAllocationsByNonPostedID(ctx context.Context, nonPo
 }
 
 const listPaymentAllocationsByNonPostedId = `-- name: ListPaymentAllocationsByNonPostedId :many
-SELECT id, non_posted_id, loan_id, installment_id, amount, description, deleted_at, deleted_description, created_at FROM payment_allocations WHERE non_posted_id = ? AND deleted_at IS NULL
+SELECT id, non_posted_id, loan_id, installment_id, amount, description, deleted_at, deleted_description, created_at, penalty_id FROM payment_allocations WHERE non_posted_id = ? AND deleted_at IS NULL
 `
 
 func (q *Queries) ListPaymentAllocationsByNonPostedId(ctx context.Context, nonPostedID uint32) ([]PaymentAllocation, error) {
@@ -222,6 +228,7 @@ func (q *Queries) ListPaymentAllocationsByNonPostedId(ctx context.Context, nonPo
 			&i.DeletedAt,
 			&i.DeletedDescription,
 			&i.CreatedAt,
+			&i.PenaltyID,
 		); err != nil {
 			return nil, err
 		}
@@ -138,6 +138,32 @@ func (q *Queries) GetClient(ctx context.Context, id uint32) (Client, error) {
 	return i, err
 }
 
+const getClientByIDNumber = `-- name: GetClientByIDNumber :one
+SELECT id, full_name, phone_number, id_number, dob, gender, active, branch_id, assigned_staff, overpayment, updated_by, updated_at, created_by, created_at FROM clients WHERE id_number = ? LIMIT 1
+`
+
+func (q *Queries) GetClientByIDNumber(ctx context.Context, idNumber sql.NullString) (Client, error) {
+	row := q.db.QueryRowContext(ctx, getClientByIDNumber, idNumber)
+	var i Client
+	err := row.Scan(
+		&i.ID,
+		&i.FullName,
+		&i.PhoneNumber,
+		&i.IDNumber,
+		&i.Dob,
+		&i.Gender,
+		&i.Active,
+		&i.BranchID,
+		&i.AssignedStaff,
+		&i.Overpayment,
+		&i.UpdatedBy,
+		&i.UpdatedAt,
+		&i.CreatedBy,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
 const getClientByPhoneNumber = `-- name: GetClientByPhoneNumber :one
 SELECT id, full_name, phone_number, id_number, dob, gender, active, branch_id, assigned_staff, overpayment, updated_by, updated_at, created_by, created_at FROM clients WHERE phone_number = ? LIMIT 1
 `
@@ -355,6 +381,41 @@ func (q *Queries) HelperClient(ctx context.Context) ([]HelperClientRow, error) {
 	return items, nil
 }
 
+const listClientNames = `-- name: ListClientNames :many
+SELECT id, full_name FROM clients
+`
+
+type ListClientNamesRow struct {
+	ID       uint32 `json:"id"`
+	FullName string `json:"full_name"`
+}
+
+func (q *Queries) ListClientNames(ctx context.Context) ([]ListClientNamesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listClientNames)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListClientNamesRow{}
+	for rows.Next() {
+		var i ListClientNamesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.FullName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listClients = `-- name: ListClients :many
 SELECT id, full_name, phone_number, id_number, dob, gender, active, branch_id, assigned_staff, overpayment, updated_by, updated_at, created_by, created_at FROM clients LIMIT ? OFFSET ?
 `
@@ -658,6 +719,76 @@ func (q *Queries) ListClientsByCategory(ctx context.Context, arg ListClientsByCa
 	return items, nil
 }
 
+const listClientsByPhoneNumbers = `-- name: ListClientsByPhoneNumbers :many
+SELECT id, full_name, phone_number, id_number, dob, gender, active, branch_id, assigned_staff, overpayment, updated_by, updated_at, created_by, created_at FROM clients
+WHERE phone_number IN (
+    ?,
+    ?,
+    ?,
+    ?
+)
+`
+
+type ListClientsByPhoneNumbersParams struct {
+	LocalPhone         string `json:"local_phone"`
+	InternationalPhone string `json:"international_phone"`
+	PlusPhone          string `json:"plus_phone"`
+	ShortPhone         string `json:"short_phone"`
+}
+
+func (q *Queries) ListClientsByPhoneNumbers(ctx context.Context, arg ListClientsByPhoneNumbersParams) ([]Client, error) {
+	rows, err := q.db.QueryContext(ctx, listClientsByPhoneNumbers,
+		arg.LocalPhone,
+		arg.InternationalPhone,
+		arg.PlusPhone,
+		arg.ShortPhone,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Client{}
+	for rows.Next() {
+		var i Client
+		if err := rows.Scan(
+			&i.ID,
+			&i.FullName,
+			&i.PhoneNumber,
+			&i.IDNumber,
+			&i.Dob,
+			&i.Gender,
+			&i.Active,
+			&i.BranchID,
+			&i.AssignedStaff,
+			&i.Overpayment,
+			&i.UpdatedBy,
+			&i.UpdatedAt,
+			&i.CreatedBy,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockClient = `-- name: LockClient :one
+SELECT id FROM clients WHERE id = ? LIMIT 1 FOR UPDATE
+`
+
+func (q *Queries) LockClient(ctx context.Context, id uint32) (uint32, error) {
+	row := q.db.QueryRowContext(ctx, lockClient, id)
+	err := row.Scan(&id)
+	return id, err
+}
+
 const nullifyClientOverpayment = `-- name: NullifyClientOverpayment :execresult
 UPDATE clients
 SET overpayment = 0
@@ -11,6 +11,23 @@ import (
 	"time"
 )
 
+const countOverdueInstallments = `-- name: CountOverdueInstallments :one
+SELECT COUNT(*) AS overdue_installments FROM installments 
+WHERE loan_id = ? AND paid = FALSE AND closed_at IS NULL AND due_date < ?
+`
+
+type CountOverdueInstallmentsParams struct {
+	LoanID uint32    `json:"loan_id"`
+	AsOf   time.Time `json:"as_of"`
+}
+
+func (q *Queries) CountOverdueInstallments(ctx context.Context, arg CountOverdueInstallmentsParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countOverdueInstallments, arg.LoanID, arg.AsOf)
+	var overdue_installments int64
+	err := row.Scan(&overdue_installments)
+	return overdue_installments, err
+}
+
 const countUnpaidInstallmentsData = `-- name: CountUnpaidInstallmentsData :one
 SELECT COUNT(*) AS total_unpaid_installments
 FROM installments i
@@ -19,6 +36,7 @@ JOIN clients c ON l.client_id = c.id
 
 WHERE 
     (i.paid = FALSE OR i.remaining_amount > 0) 
+    AND i.closed_at IS NULL
     AND i.due_date <= CURDATE()
     AND (
         COALESCE(?, '') = '' 
@@ -66,7 +84,7 @@ func (q *Queries) CreateInstallment(ctx context.Context, arg CreateInstallmentPa
 }
 
 const getInstallment = `-- name: GetInstallment :one
-SELECT id, loan_id, installment_number, amount_due, remaining_amount, paid, paid_at, due_date FROM installments WHERE id = ? LIMIT 1
+SELECT id, loan_id, installment_number, amount_due, remaining_amount, paid, paid_at, due_date, closed_at, restructure_id FROM installments WHERE id = ? LIMIT 1
 `
 
 func (q *Queries) GetInstallment(ctx context.Context, id uint32) (Installment, error) {
@@ -81,6 +99,8 @@ func (q *Queries) GetInstallment(ctx context.Context, id uint32) (Installment, e
 		&i.Paid,
 		&i.PaidAt,
 		&i.DueDate,
+		&i.ClosedAt,
+		&i.RestructureID,
 	)
 	return i, err
 }
@@ -112,6 +132,7 @@ JOIN branches b ON p.branch_id = b.id
 
 WHERE 
     (i.paid = FALSE OR i.remaining_amount > 0) 
+    AND i.closed_at IS NULL
     AND i.due_date <= CURDATE()
     AND (
         COALESCE(?, '') = '' 
@@ -190,8 +211,69 @@ func (q *Queries) GetUnpaidInstallmentsData(ctx context.Context, arg GetUnpaidIn
 	return items, nil
 }
 
+const listDueInstallmentsByAmount = `-- name: ListDueInstallmentsByAmount :many
+SELECT i.id, i.loan_id, l.client_id, i.remaining_amount, i.due_date
+FROM installments i
+JOIN loans l ON i.loan_id = l.id
+WHERE l.status = 'ACTIVE'
+    AND i.paid = FALSE
+    AND i.closed_at IS NULL
+    AND i.remaining_amount BETWEEN ? AND ?
+    AND i.due_date BETWEEN ? AND ?
+ORDER BY i.due_date
+`
+
+type ListDueInstallmentsByAmountParams struct {
+	MinAmount float64   `json:"min_amount"`
+	MaxAmount float64   `json:"max_amount"`
+	FromDate  time.Time `json:"from_date"`
+	ToDate    time.Time `json:"to_date"`
+}
+
+type ListDueInstallmentsByAmountRow struct {
+	ID              uint32    `json:"id"`
+	LoanID          uint32    `json:"loan_id"`
+	ClientID        uint32    `json:"client_id"`
+	RemainingAmount float64   `json:"remaining_amount"`
+	DueDate         time.Time `json:"due_date"`
+}
+
+func (q *Queries) ListDueInstallmentsByAmount(ctx context.Context, arg ListDueInstallmentsByAmountParams) ([]ListDueInstallmentsByAmountRow, error) {
+	rows, err := q.db.QueryContext(ctx, listDueInstallmentsByAmount,
+		arg.MinAmount,
+		arg.MaxAmount,
+		arg.FromDate,
+		arg.ToDate,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListDueInstallmentsByAmountRow{}
+	for rows.Next() {
+		var i ListDueInstallmentsByAmountRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.LoanID,
+			&i.ClientID,
+			&i.RemainingAmount,
+			&i.DueDate,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listInstallmentsByLoan = `-- name: ListInstallmentsByLoan :many
-SELECT id, loan_id, installment_number, amount_due, remaining_amount, paid, paid_at, due_date FROM installments WHERE loan_id = ? ORDER BY due_date ASC
+SELECT id, loan_id, installment_number, amount_due, remaining_amount, paid, paid_at, due_date, closed_at, restructure_id FROM installments WHERE loan_id = ? ORDER BY due_date ASC
 `
 
 func (q *Queries) ListInstallmentsByLoan(ctx context.Context, loanID uint32) ([]Installment, error) {
@@ -212,6 +294,8 @@ func (q *Queries) ListInstallmentsByLoan(ctx context.Context, loanID uint32) ([]
 			&i.Paid,
 			&i.PaidAt,
 			&i.DueDate,
+			&i.ClosedAt,
+			&i.RestructureID,
 		); err != nil {
 			return nil, err
 		}
@@ -227,7 +311,7 @@ func (q *Queries) ListInstallmentsByLoan(ctx context.Context, loanID uint32) ([]
 }
 
 const listUnpaidInstallmentsByLoan = `-- name: ListUnpaidInstallmentsByLoan :many
-SELECT id, loan_id, installment_number, amount_due, remaining_amount, paid, paid_at, due_date FROM installments WHERE loan_id = ? AND remaining_amount > 0 ORDER BY due_date ASC
+SELECT id, loan_id, installment_number, amount_due, remaining_amount, paid, paid_at, due_date, closed_at, restructure_id FROM installments WHERE loan_id = ? AND remaining_amount > 0 AND closed_at IS NULL ORDER BY due_date ASC
 `
 
 func (q *Queries) ListUnpaidInstallmentsByLoan(ctx context.Context, loanID uint32) ([]Installment, error) {
@@ -248,6 +332,8 @@ func (q *Queries) ListUnpaidInstallmentsByLoan(ctx context.Context, loanID uint3
 			&i.Paid,
 			&i.PaidAt,
 			&i.DueDate,
+			&i.ClosedAt,
+			&i.RestructureID,
 		); err != nil {
 			return nil, err
 		}
@@ -262,6 +348,33 @@ func (q *Queries) ListUnpaidInstallmentsByLoan(ctx context.Context, loanID uint3
 	return items, nil
 }
 
+const lockLoanInstallments = `-- name: LockLoanInstallments :many
+SELECT id FROM installments WHERE loan_id = ? ORDER BY id FOR UPDATE
+`
+
+func (q *Queries) LockLoanInstallments(ctx context.Context, loanID uint32) ([]uint32, error) {
+	rows, err := q.db.QueryContext(ctx, lockLoanInstallments, loanID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []uint32{}
+	for rows.Next() {
+		var id uint32
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const payInstallment = `-- name: PayInstallment :execresult
 UPDATE installments 
     SET remaining_amount = ?,
@@ -461,6 +461,20 @@ func (q *Queries) GetClientLoans(ctx context.Context, arg GetClientLoansParams)
 	return items, nil
 }
 
+const getClientPayableLoan = `-- name: GetClientPayableLoan :one
+SELECT id FROM loans 
+WHERE client_id = ? AND status IN ('ACTIVE', 'DEFAULTED')
+ORDER BY status = 'DEFAULTED' DESC, id
+LIMIT 1
+`
+
+func (q *Queries) GetClientPayableLoan(ctx context.Context, clientID uint32) (uint32, error) {
+	row := q.db.QueryRowContext(ctx, getClientPayableLoan, clientID)
+	var id uint32
+	err := row.Scan(&id)
+	return id, err
+}
+
 const getLoan = `-- name: GetLoan :one
 SELECT id, product_id, client_id, loan_officer, loan_purpose, due_date, approved_by, disbursed_on, disbursed_by, total_installments, installments_period, status, processing_fee, paid_amount, updated_by, created_by, created_at, fee_paid FROM loans WHERE id = ? LIMIT 1
 `
@@ -1294,10 +1308,28 @@ func (q *Queries) ListNonDisbursedLoans(ctx context.Context, arg ListNonDisburse
 	return items, nil
 }
 
+const lockLoan = `-- name: LockLoan :one
+SELECT id FROM loans WHERE id = ? LIMIT 1 FOR UPDATE
+`
+
+func (q *Queries) LockLoan(ctx context.Context, id uint32) (uint32, error) {
+	row := q.db.QueryRowContext(ctx, lockLoan, id)
+	err := row.Scan(&id)
+	return id, err
+}
+
+const markLoanDefaulted = `-- name: MarkLoanDefaulted :execresult
+UPDATE loans SET status = 'DEFAULTED' WHERE id = ? AND status = 'ACTIVE'
+`
+
+func (q *Queries) MarkLoanDefaulted(ctx context.Context, id uint32) (sql.Result, error) {
+	return q.db.ExecContext(ctx, markLoanDefaulted, id)
+}
+
 const reduceLoan = `-- name: ReduceLoan :execresult
 UPDATE loans 
     SET paid_amount = paid_amount - ?,
-    status = 'ACTIVE',
+    status = IF(status = 'DEFAULTED', 'DEFAULTED', 'ACTIVE'),
     updated_by = ?
 WHERE id = ?
 `
@@ -11,6 +11,49 @@ import (
 	"time"
 )
 
+type AssignmentRulesRuleType string
+
+const (
+	AssignmentRulesRuleTypeIDNUMBER   AssignmentRulesRuleType = "ID_NUMBER"
+	AssignmentRulesRuleTypeLOANPREFIX AssignmentRulesRuleType = "LOAN_PREFIX"
+	AssignmentRulesRuleTypeNAMEAMOUNT AssignmentRulesRuleType = "NAME_AMOUNT"
+)
+
+func (e *AssignmentRulesRuleType) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = AssignmentRulesRuleType(s)
+	case string:
+		*e = AssignmentRulesRuleType(s)
+	default:
+		return fmt.Errorf("unsupported scan type for AssignmentRulesRuleType: %T", src)
+	}
+	return nil
+}
+
+type NullAssignmentRulesRuleType struct {
+	AssignmentRulesRuleType AssignmentRulesRuleType `json:"assignment_rules_rule_type"`
+	Valid                   bool                    `json:"valid"` // Valid is true if AssignmentRulesRuleType is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullAssignmentRulesRuleType) Scan(value interface{}) error {
+	if value == nil {
+		ns.AssignmentRulesRuleType, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.AssignmentRulesRuleType.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullAssignmentRulesRuleType) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.AssignmentRulesRuleType), nil
+}
+
 type ClientsGender string
 
 const (
@@ -53,13 +96,199 @@ func (ns NullClientsGender) Value() (driver.Value, error) {
 	return string(ns.ClientsGender), nil
 }
 
+type JournalEntriesEntryType string
+
+const (
+	JournalEntriesEntryTypeDISBURSEMENT            JournalEntriesEntryType = "DISBURSEMENT"
+	JournalEntriesEntryTypePROCESSINGFEE           JournalEntriesEntryType = "PROCESSING_FEE"
+	JournalEntriesEntryTypePAYMENTRECEIVED         JournalEntriesEntryType = "PAYMENT_RECEIVED"
+	JournalEntriesEntryTypePAYMENTADJUSTED         JournalEntriesEntryType = "PAYMENT_ADJUSTED"
+	JournalEntriesEntryTypePAYMENTALLOCATED        JournalEntriesEntryType = "PAYMENT_ALLOCATED"
+	JournalEntriesEntryTypePAYMENTREVERSED         JournalEntriesEntryType = "PAYMENT_REVERSED"
+	JournalEntriesEntryTypePAYMENTDELETED          JournalEntriesEntryType = "PAYMENT_DELETED"
+	JournalEntriesEntryTypeOVERPAYMENTAPPLIED      JournalEntriesEntryType = "OVERPAYMENT_APPLIED"
+	JournalEntriesEntryTypeOVERPAYMENTREFUND       JournalEntriesEntryType = "OVERPAYMENT_REFUND"
+	JournalEntriesEntryTypeOVERPAYMENTREFUNDFAILED JournalEntriesEntryType = "OVERPAYMENT_REFUND_FAILED"
+	JournalEntriesEntryTypeEXPENSE                 JournalEntriesEntryType = "EXPENSE"
+	JournalEntriesEntryTypeCASHVARIANCE            JournalEntriesEntryType = "CASH_VARIANCE"
+	JournalEntriesEntryTypePENALTYACCRUED          JournalEntriesEntryType = "PENALTY_ACCRUED"
+	JournalEntriesEntryTypeLOANREFINANCED          JournalEntriesEntryType = "LOAN_REFINANCED"
+	JournalEntriesEntryTypeINTERESTREBATE          JournalEntriesEntryType = "INTEREST_REBATE"
+)
+
+func (e *JournalEntriesEntryType) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = JournalEntriesEntryType(s)
+	case string:
+		*e = JournalEntriesEntryType(s)
+	default:
+		return fmt.Errorf("unsupported scan type for JournalEntriesEntryType: %T", src)
+	}
+	return nil
+}
+
+type NullJournalEntriesEntryType struct {
+	JournalEntriesEntryType JournalEntriesEntryType `json:"journal_entries_entry_type"`
+	Valid                   bool                    `json:"valid"` // Valid is true if JournalEntriesEntryType is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullJournalEntriesEntryType) Scan(value interface{}) error {
+	if value == nil {
+		ns.JournalEntriesEntryType, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.JournalEntriesEntryType.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullJournalEntriesEntryType) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.JournalEntriesEntryType), nil
+}
+
+type LedgerAccountsAccountType string
+
+const (
+	LedgerAccountsAccountTypeASSET     LedgerAccountsAccountType = "ASSET"
+	LedgerAccountsAccountTypeLIABILITY LedgerAccountsAccountType = "LIABILITY"
+	LedgerAccountsAccountTypeEQUITY    LedgerAccountsAccountType = "EQUITY"
+	LedgerAccountsAccountTypeINCOME    LedgerAccountsAccountType = "INCOME"
+	LedgerAccountsAccountTypeEXPENSE   LedgerAccountsAccountType = "EXPENSE"
+)
+
+func (e *LedgerAccountsAccountType) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = LedgerAccountsAccountType(s)
+	case string:
+		*e = LedgerAccountsAccountType(s)
+	default:
+		return fmt.Errorf("unsupported scan type for LedgerAccountsAccountType: %T", src)
+	}
+	return nil
+}
+
+type NullLedgerAccountsAccountType struct {
+	LedgerAccountsAccountType LedgerAccountsAccountType `json:"ledger_accounts_account_type"`
+	Valid                     bool                      `json:"valid"` // Valid is true if LedgerAccountsAccountType is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullLedgerAccountsAccountType) Scan(value interface{}) error {
+	if value == nil {
+		ns.LedgerAccountsAccountType, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.LedgerAccountsAccountType.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullLedgerAccountsAccountType) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.LedgerAccountsAccountType), nil
+}
+
+type LoanDisbursementsStatus string
+
+const (
+	LoanDisbursementsStatusPENDING   LoanDisbursementsStatus = "PENDING"
+	LoanDisbursementsStatusCOMPLETED LoanDisbursementsStatus = "COMPLETED"
+	LoanDisbursementsStatusFAILED    LoanDisbursementsStatus = "FAILED"
+)
+
+func (e *LoanDisbursementsStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = LoanDisbursementsStatus(s)
+	case string:
+		*e = LoanDisbursementsStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for LoanDisbursementsStatus: %T", src)
+	}
+	return nil
+}
+
+type NullLoanDisbursementsStatus struct {
+	LoanDisbursementsStatus LoanDisbursementsStatus `json:"loan_disbursements_status"`
+	Valid                   bool                    `json:"valid"` // Valid is true if LoanDisbursementsStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullLoanDisbursementsStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.LoanDisbursementsStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.LoanDisbursementsStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullLoanDisbursementsStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.LoanDisbursementsStatus), nil
+}
+
+type LoanSettlementQuotesStatus string
+
+const (
+	LoanSettlementQuotesStatusPENDING LoanSettlementQuotesStatus = "PENDING"
+	LoanSettlementQuotesStatusSETTLED LoanSettlementQuotesStatus = "SETTLED"
+)
+
+func (e *LoanSettlementQuotesStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = LoanSettlementQuotesStatus(s)
+	case string:
+		*e = LoanSettlementQuotesStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for LoanSettlementQuotesStatus: %T", src)
+	}
+	return nil
+}
+
+type NullLoanSettlementQuotesStatus struct {
+	LoanSettlementQuotesStatus LoanSettlementQuotesStatus `json:"loan_settlement_quotes_status"`
+	Valid                      bool                       `json:"valid"` // Valid is true if LoanSettlementQuotesStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullLoanSettlementQuotesStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.LoanSettlementQuotesStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.LoanSettlementQuotesStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullLoanSettlementQuotesStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.LoanSettlementQuotesStatus), nil
+}
+
 type LoansStatus string
 
 const (
-	LoansStatusINACTIVE  LoansStatus = "INACTIVE"
-	LoansStatusACTIVE    LoansStatus = "ACTIVE"
-	LoansStatusCOMPLETED LoansStatus = "COMPLETED"
-	LoansStatusDEFAULTED LoansStatus = "DEFAULTED"
+	LoansStatusINACTIVE   LoansStatus = "INACTIVE"
+	LoansStatusDISBURSING LoansStatus = "DISBURSING"
+	LoansStatusACTIVE     LoansStatus = "ACTIVE"
+	LoansStatusCOMPLETED  LoansStatus = "COMPLETED"
+	LoansStatusDEFAULTED  LoansStatus = "DEFAULTED"
 )
 
 func (e *LoansStatus) Scan(src interface{}) error {
@@ -139,6 +368,353 @@ func (ns NullNonPostedTransactionSource) Value() (driver.Value, error) {
 	return string(ns.NonPostedTransactionSource), nil
 }
 
+type OverpaymentRefundsStatus string
+
+const (
+	OverpaymentRefundsStatusPENDING    OverpaymentRefundsStatus = "PENDING"
+	OverpaymentRefundsStatusAPPROVED   OverpaymentRefundsStatus = "APPROVED"
+	OverpaymentRefundsStatusPROCESSING OverpaymentRefundsStatus = "PROCESSING"
+	OverpaymentRefundsStatusPAID       OverpaymentRefundsStatus = "PAID"
+	OverpaymentRefundsStatusREJECTED   OverpaymentRefundsStatus = "REJECTED"
+	OverpaymentRefundsStatusFAILED     OverpaymentRefundsStatus = "FAILED"
+)
+
+func (e *OverpaymentRefundsStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = OverpaymentRefundsStatus(s)
+	case string:
+		*e = OverpaymentRefundsStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for OverpaymentRefundsStatus: %T", src)
+	}
+	return nil
+}
+
+type NullOverpaymentRefundsStatus struct {
+	OverpaymentRefundsStatus OverpaymentRefundsStatus `json:"overpayment_refunds_status"`
+	Valid                    bool                     `json:"valid"` // Valid is true if OverpaymentRefundsStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullOverpaymentRefundsStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.OverpaymentRefundsStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.OverpaymentRefundsStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullOverpaymentRefundsStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.OverpaymentRefundsStatus), nil
+}
+
+type PaymentImportBatchesStatus string
+
+const (
+	PaymentImportBatchesStatusPREVIEW    PaymentImportBatchesStatus = "PREVIEW"
+	PaymentImportBatchesStatusPOSTING    PaymentImportBatchesStatus = "POSTING"
+	PaymentImportBatchesStatusPOSTED     PaymentImportBatchesStatus = "POSTED"
+	PaymentImportBatchesStatusFAILED     PaymentImportBatchesStatus = "FAILED"
+	PaymentImportBatchesStatusROLLEDBACK PaymentImportBatchesStatus = "ROLLED_BACK"
+)
+
+func (e *PaymentImportBatchesStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = PaymentImportBatchesStatus(s)
+	case string:
+		*e = PaymentImportBatchesStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for PaymentImportBatchesStatus: %T", src)
+	}
+	return nil
+}
+
+type NullPaymentImportBatchesStatus struct {
+	PaymentImportBatchesStatus PaymentImportBatchesStatus `json:"payment_import_batches_status"`
+	Valid                      bool                       `json:"valid"` // Valid is true if PaymentImportBatchesStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullPaymentImportBatchesStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.PaymentImportBatchesStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.PaymentImportBatchesStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullPaymentImportBatchesStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.PaymentImportBatchesStatus), nil
+}
+
+type PaymentSplitsStatus string
+
+const (
+	PaymentSplitsStatusACTIVE   PaymentSplitsStatus = "ACTIVE"
+	PaymentSplitsStatusREVERSED PaymentSplitsStatus = "REVERSED"
+)
+
+func (e *PaymentSplitsStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = PaymentSplitsStatus(s)
+	case string:
+		*e = PaymentSplitsStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for PaymentSplitsStatus: %T", src)
+	}
+	return nil
+}
+
+type NullPaymentSplitsStatus struct {
+	PaymentSplitsStatus PaymentSplitsStatus `json:"payment_splits_status"`
+	Valid               bool                `json:"valid"` // Valid is true if PaymentSplitsStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullPaymentSplitsStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.PaymentSplitsStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.PaymentSplitsStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullPaymentSplitsStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.PaymentSplitsStatus), nil
+}
+
+type ProductAllocationStrategiesStrategy string
+
+const (
+	ProductAllocationStrategiesStrategyOLDESTFIRST   ProductAllocationStrategiesStrategy = "OLDEST_FIRST"
+	ProductAllocationStrategiesStrategyCURRENTFIRST  ProductAllocationStrategiesStrategy = "CURRENT_FIRST"
+	ProductAllocationStrategiesStrategySPREADOVERDUE ProductAllocationStrategiesStrategy = "SPREAD_OVERDUE"
+	ProductAllocationStrategiesStrategyWATERFALL     ProductAllocationStrategiesStrategy = "WATERFALL"
+)
+
+func (e *ProductAllocationStrategiesStrategy) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ProductAllocationStrategiesStrategy(s)
+	case string:
+		*e = ProductAllocationStrategiesStrategy(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ProductAllocationStrategiesStrategy: %T", src)
+	}
+	return nil
+}
+
+type NullProductAllocationStrategiesStrategy struct {
+	ProductAllocationStrategiesStrategy ProductAllocationStrategiesStrategy `json:"product_allocation_strategies_strategy"`
+	Valid                               bool                                `json:"valid"` // Valid is true if ProductAllocationStrategiesStrategy is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullProductAllocationStrategiesStrategy) Scan(value interface{}) error {
+	if value == nil {
+		ns.ProductAllocationStrategiesStrategy, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ProductAllocationStrategiesStrategy.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullProductAllocationStrategiesStrategy) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ProductAllocationStrategiesStrategy), nil
+}
+
+type ProductPenaltyRulesFrequency string
+
+const (
+	ProductPenaltyRulesFrequencyDAILY  ProductPenaltyRulesFrequency = "DAILY"
+	ProductPenaltyRulesFrequencyWEEKLY ProductPenaltyRulesFrequency = "WEEKLY"
+)
+
+func (e *ProductPenaltyRulesFrequency) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ProductPenaltyRulesFrequency(s)
+	case string:
+		*e = ProductPenaltyRulesFrequency(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ProductPenaltyRulesFrequency: %T", src)
+	}
+	return nil
+}
+
+type NullProductPenaltyRulesFrequency struct {
+	ProductPenaltyRulesFrequency ProductPenaltyRulesFrequency `json:"product_penalty_rules_frequency"`
+	Valid                        bool                         `json:"valid"` // Valid is true if ProductPenaltyRulesFrequency is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullProductPenaltyRulesFrequency) Scan(value interface{}) error {
+	if value == nil {
+		ns.ProductPenaltyRulesFrequency, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ProductPenaltyRulesFrequency.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullProductPenaltyRulesFrequency) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ProductPenaltyRulesFrequency), nil
+}
+
+type ProductPenaltyRulesPenaltyType string
+
+const (
+	ProductPenaltyRulesPenaltyTypeFLAT       ProductPenaltyRulesPenaltyType = "FLAT"
+	ProductPenaltyRulesPenaltyTypePERCENTAGE ProductPenaltyRulesPenaltyType = "PERCENTAGE"
+)
+
+func (e *ProductPenaltyRulesPenaltyType) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ProductPenaltyRulesPenaltyType(s)
+	case string:
+		*e = ProductPenaltyRulesPenaltyType(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ProductPenaltyRulesPenaltyType: %T", src)
+	}
+	return nil
+}
+
+type NullProductPenaltyRulesPenaltyType struct {
+	ProductPenaltyRulesPenaltyType ProductPenaltyRulesPenaltyType `json:"product_penalty_rules_penalty_type"`
+	Valid                          bool                           `json:"valid"` // Valid is true if ProductPenaltyRulesPenaltyType is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullProductPenaltyRulesPenaltyType) Scan(value interface{}) error {
+	if value == nil {
+		ns.ProductPenaltyRulesPenaltyType, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ProductPenaltyRulesPenaltyType.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullProductPenaltyRulesPenaltyType) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ProductPenaltyRulesPenaltyType), nil
+}
+
+type StatementReconciliationItemsItemType string
+
+const (
+	StatementReconciliationItemsItemTypeMISSINGCALLBACK StatementReconciliationItemsItemType = "MISSING_CALLBACK"
+	StatementReconciliationItemsItemTypeAMOUNTMISMATCH  StatementReconciliationItemsItemType = "AMOUNT_MISMATCH"
+	StatementReconciliationItemsItemTypeNOTONSTATEMENT  StatementReconciliationItemsItemType = "NOT_ON_STATEMENT"
+)
+
+func (e *StatementReconciliationItemsItemType) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = StatementReconciliationItemsItemType(s)
+	case string:
+		*e = StatementReconciliationItemsItemType(s)
+	default:
+		return fmt.Errorf("unsupported scan type for StatementReconciliationItemsItemType: %T", src)
+	}
+	return nil
+}
+
+type NullStatementReconciliationItemsItemType struct {
+	StatementReconciliationItemsItemType StatementReconciliationItemsItemType `json:"statement_reconciliation_items_item_type"`
+	Valid                                bool                                 `json:"valid"` // Valid is true if StatementReconciliationItemsItemType is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullStatementReconciliationItemsItemType) Scan(value interface{}) error {
+	if value == nil {
+		ns.StatementReconciliationItemsItemType, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.StatementReconciliationItemsItemType.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullStatementReconciliationItemsItemType) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.StatementReconciliationItemsItemType), nil
+}
+
+type StkPushRequestsStatus string
+
+const (
+	StkPushRequestsStatusPENDING   StkPushRequestsStatus = "PENDING"
+	StkPushRequestsStatusCOMPLETED StkPushRequestsStatus = "COMPLETED"
+	StkPushRequestsStatusFAILED    StkPushRequestsStatus = "FAILED"
+)
+
+func (e *StkPushRequestsStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = StkPushRequestsStatus(s)
+	case string:
+		*e = StkPushRequestsStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for StkPushRequestsStatus: %T", src)
+	}
+	return nil
+}
+
+type NullStkPushRequestsStatus struct {
+	StkPushRequestsStatus StkPushRequestsStatus `json:"stk_push_requests_status"`
+	Valid                 bool                  `json:"valid"` // Valid is true if StkPushRequestsStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullStkPushRequestsStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.StkPushRequestsStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.StkPushRequestsStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullStkPushRequestsStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.StkPushRequestsStatus), nil
+}
+
 type UsersRole string
 
 const (
@@ -181,11 +757,78 @@ func (ns NullUsersRole) Value() (driver.Value, error) {
 	return string(ns.UsersRole), nil
 }
 
+type AssignmentRule struct {
+	ID                uint32                  `json:"id"`
+	Name              string                  `json:"name"`
+	RuleType          AssignmentRulesRuleType `json:"rule_type"`
+	Priority          uint32                  `json:"priority"`
+	Active            bool                    `json:"active"`
+	Prefix            string                  `json:"prefix"`
+	DaysWindow        uint32                  `json:"days_window"`
+	MinNameSimilarity float64                 `json:"min_name_similarity"`
+	CreatedBy         uint32                  `json:"created_by"`
+	CreatedAt         time.Time               `json:"created_at"`
+	UpdatedAt         time.Time               `json:"updated_at"`
+}
+
+type BlacklistedClient struct {
+	ID        uint32    `json:"id"`
+	ClientID  uint32    `json:"client_id"`
+	Reason    string    `json:"reason"`
+	CreatedBy string    `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
 type Branch struct {
 	ID   uint32 `json:"id"`
 	Name string `json:"name"`
 }
 
+type BranchExpense struct {
+	ID          uint32    `json:"id"`
+	BranchID    uint32    `json:"branch_id"`
+	ExpenseDate time.Time `json:"expense_date"`
+	Amount      float64   `json:"amount"`
+	Description string    `json:"description"`
+	CreatedBy   uint32    `json:"created_by"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type CallbackPayload struct {
+	ID                uint32    `json:"id"`
+	TransactionNumber string    `json:"transaction_number"`
+	CallbackType      string    `json:"callback_type"`
+	Payload           string    `json:"payload"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+type CashBookClose struct {
+	ID              uint32    `json:"id"`
+	BranchID        uint32    `json:"branch_id"`
+	BusinessDate    time.Time `json:"business_date"`
+	OpeningBalance  float64   `json:"opening_balance"`
+	CashReceived    float64   `json:"cash_received"`
+	CashDisbursed   float64   `json:"cash_disbursed"`
+	Expenses        float64   `json:"expenses"`
+	ExpectedClosing float64   `json:"expected_closing"`
+	CountedCash     float64   `json:"counted_cash"`
+	ClosedBy        uint32    `json:"closed_by"`
+	ClosedAt        time.Time `json:"closed_at"`
+}
+
+type CashVariance struct {
+	ID           uint32    `json:"id"`
+	CloseID      uint32    `json:"close_id"`
+	BranchID     uint32    `json:"branch_id"`
+	BusinessDate time.Time `json:"business_date"`
+	ExpectedCash float64   `json:"expected_cash"`
+	CountedCash  float64   `json:"counted_cash"`
+	Variance     float64   `json:"variance"`
+	Note         string    `json:"note"`
+	CreatedBy    uint32    `json:"created_by"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
 type Client struct {
 	ID            uint32         `json:"id"`
 	FullName      string         `json:"full_name"`
@@ -214,14 +857,73 @@ type ClientOverpaymentTransaction struct {
 }
 
 type Installment struct {
-	ID                uint32       `json:"id"`
-	LoanID            uint32       `json:"loan_id"`
-	InstallmentNumber uint32       `json:"installment_number"`
-	AmountDue         float64      `json:"amount_due"`
-	RemainingAmount   float64      `json:"remaining_amount"`
-	Paid              bool         `json:"paid"`
-	PaidAt            sql.NullTime `json:"paid_at"`
-	DueDate           time.Time    `json:"due_date"`
+	ID                uint32        `json:"id"`
+	LoanID            uint32        `json:"loan_id"`
+	InstallmentNumber uint32        `json:"installment_number"`
+	AmountDue         float64       `json:"amount_due"`
+	RemainingAmount   float64       `json:"remaining_amount"`
+	Paid              bool          `json:"paid"`
+	PaidAt            sql.NullTime  `json:"paid_at"`
+	DueDate           time.Time     `json:"due_date"`
+	ClosedAt          sql.NullTime  `json:"closed_at"`
+	RestructureID     sql.NullInt32 `json:"restructure_id"`
+}
+
+type InstallmentPenalty struct {
+	ID              uint32       `json:"id"`
+	LoanID          uint32       `json:"loan_id"`
+	InstallmentID   uint32       `json:"installment_id"`
+	AccruedOn       time.Time    `json:"accrued_on"`
+	Periods         uint32       `json:"periods"`
+	Amount          float64      `json:"amount"`
+	RemainingAmount float64      `json:"remaining_amount"`
+	Paid            bool         `json:"paid"`
+	PaidAt          sql.NullTime `json:"paid_at"`
+	CreatedAt       time.Time    `json:"created_at"`
+}
+
+type JournalEntry struct {
+	ID          uint32                  `json:"id"`
+	EntryType   JournalEntriesEntryType `json:"entry_type"`
+	Description string                  `json:"description"`
+	LoanID      sql.NullInt32           `json:"loan_id"`
+	ClientID    sql.NullInt32           `json:"client_id"`
+	NonPostedID sql.NullInt32           `json:"non_posted_id"`
+	CreatedBy   string                  `json:"created_by"`
+	CreatedAt   time.Time               `json:"created_at"`
+}
+
+type JournalLine struct {
+	ID        uint32  `json:"id"`
+	EntryID   uint32  `json:"entry_id"`
+	AccountID uint32  `json:"account_id"`
+	Debit     float64 `json:"debit"`
+	Credit    float64 `json:"credit"`
+}
+
+type LedgerAccount struct {
+	ID          uint32                    `json:"id"`
+	Code        string                    `json:"code"`
+	Name        string                    `json:"name"`
+	AccountType LedgerAccountsAccountType `json:"account_type"`
+	CreatedAt   time.Time                 `json:"created_at"`
+}
+
+type LoanDisbursement struct {
+	ID                       uint32                  `json:"id"`
+	LoanID                   uint32                  `json:"loan_id"`
+	PhoneNumber              string                  `json:"phone_number"`
+	Amount                   float64                 `json:"amount"`
+	FeeDeducted              bool                    `json:"fee_deducted"`
+	ConversationID           string                  `json:"conversation_id"`
+	OriginatorConversationID string                  `json:"originator_conversation_id"`
+	Status                   LoanDisbursementsStatus `json:"status"`
+	ResultCode               sql.NullInt32           `json:"result_code"`
+	ResultDesc               sql.NullString          `json:"result_desc"`
+	TransactionID            sql.NullString          `json:"transaction_id"`
+	InitiatedBy              uint32                  `json:"initiated_by"`
+	CreatedAt                time.Time               `json:"created_at"`
+	UpdatedAt                sql.NullTime            `json:"updated_at"`
 }
 
 type Loan struct {
@@ -245,6 +947,50 @@ type Loan struct {
 	FeePaid            bool           `json:"fee_paid"`
 }
 
+type LoanRestructure struct {
+	ID                         uint32       `json:"id"`
+	LoanID                     uint32       `json:"loan_id"`
+	OutstandingAmount          float64      `json:"outstanding_amount"`
+	PreviousTotalInstallments  uint32       `json:"previous_total_installments"`
+	PreviousInstallmentsPeriod uint32       `json:"previous_installments_period"`
+	PreviousDueDate            sql.NullTime `json:"previous_due_date"`
+	TotalInstallments          uint32       `json:"total_installments"`
+	InstallmentsPeriod         uint32       `json:"installments_period"`
+	Reason                     string       `json:"reason"`
+	ApprovedBy                 uint32       `json:"approved_by"`
+	CreatedAt                  time.Time    `json:"created_at"`
+}
+
+type LoanSettlementQuote struct {
+	ID                uint32                     `json:"id"`
+	LoanID            uint32                     `json:"loan_id"`
+	OutstandingAmount float64                    `json:"outstanding_amount"`
+	PenaltiesAmount   float64                    `json:"penalties_amount"`
+	UnearnedInterest  float64                    `json:"unearned_interest"`
+	RebatePercent     float64                    `json:"rebate_percent"`
+	RebateAmount      float64                    `json:"rebate_amount"`
+	QuotedAmount      float64                    `json:"quoted_amount"`
+	Status            LoanSettlementQuotesStatus `json:"status"`
+	ExpiresAt         time.Time                  `json:"expires_at"`
+	QuotedBy          uint32                     `json:"quoted_by"`
+	NonPostedID       sql.NullInt32              `json:"non_posted_id"`
+	SettledBy         sql.NullInt32              `json:"settled_by"`
+	SettledAt         sql.NullTime               `json:"settled_at"`
+	CreatedAt         time.Time                  `json:"created_at"`
+}
+
+type LoanTopUp struct {
+	ID               uint32    `json:"id"`
+	LoanID           uint32    `json:"loan_id"`
+	RefinancedLoanID uint32    `json:"refinanced_loan_id"`
+	NonPostedID      uint32    `json:"non_posted_id"`
+	SettledAmount    float64   `json:"settled_amount"`
+	PenaltiesSettled float64   `json:"penalties_settled"`
+	NetDisbursed     float64   `json:"net_disbursed"`
+	CreatedBy        uint32    `json:"created_by"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
 type NonPosted struct {
 	ID                 uint32                     `json:"id"`
 	TransactionNumber  string                     `json:"transaction_number"`
@@ -258,6 +1004,25 @@ type NonPosted struct {
 	AssignedBy         string                     `json:"assigned_by"`
 	DeletedAt          sql.NullTime               `json:"deleted_at"`
 	DeletedDescription sql.NullString             `json:"deleted_description"`
+	CashReceipt        bool                       `json:"cash_receipt"`
+}
+
+type OverpaymentRefund struct {
+	ID              uint32                   `json:"id"`
+	ClientID        uint32                   `json:"client_id"`
+	Amount          float64                  `json:"amount"`
+	Reason          string                   `json:"reason"`
+	Status          OverpaymentRefundsStatus `json:"status"`
+	PayoutMethod    string                   `json:"payout_method"`
+	PayoutPhone     string                   `json:"payout_phone"`
+	PayoutReference string                   `json:"payout_reference"`
+	ConversationID  sql.NullString           `json:"conversation_id"`
+	RequestedBy     uint32                   `json:"requested_by"`
+	ReviewedBy      sql.NullInt32            `json:"reviewed_by"`
+	ReviewNote      string                   `json:"review_note"`
+	ReviewedAt      sql.NullTime             `json:"reviewed_at"`
+	CreatedAt       time.Time                `json:"created_at"`
+	UpdatedAt       time.Time                `json:"updated_at"`
 }
 
 type PaymentAllocation struct {
@@ -270,6 +1035,92 @@ type PaymentAllocation struct {
 	DeletedAt          sql.NullTime   `json:"deleted_at"`
 	DeletedDescription sql.NullString `json:"deleted_description"`
 	CreatedAt          time.Time      `json:"created_at"`
+	PenaltyID          sql.NullInt32  `json:"penalty_id"`
+}
+
+type PaymentImportBatch struct {
+	ID             uint32                     `json:"id"`
+	FileName       string                     `json:"file_name"`
+	Status         PaymentImportBatchesStatus `json:"status"`
+	TotalRows      uint32                     `json:"total_rows"`
+	ValidRows      uint32                     `json:"valid_rows"`
+	PostedRows     uint32                     `json:"posted_rows"`
+	UploadedBy     uint32                     `json:"uploaded_by"`
+	PostedBy       sql.NullInt32              `json:"posted_by"`
+	PostedAt       sql.NullTime               `json:"posted_at"`
+	RolledBackBy   sql.NullInt32              `json:"rolled_back_by"`
+	RolledBackAt   sql.NullTime               `json:"rolled_back_at"`
+	RollbackReason sql.NullString             `json:"rollback_reason"`
+	CreatedAt      time.Time                  `json:"created_at"`
+}
+
+type PaymentImportRow struct {
+	ID          uint32        `json:"id"`
+	BatchID     uint32        `json:"batch_id"`
+	LineNumber  uint32        `json:"line_number"`
+	Identifier  string        `json:"identifier"`
+	ClientID    sql.NullInt32 `json:"client_id"`
+	Amount      float64       `json:"amount"`
+	PaidDate    sql.NullTime  `json:"paid_date"`
+	Reference   string        `json:"reference"`
+	Error       string        `json:"error"`
+	NonPostedID sql.NullInt32 `json:"non_posted_id"`
+	RolledBack  bool          `json:"rolled_back"`
+}
+
+type PaymentReassignment struct {
+	ID           uint32        `json:"id"`
+	NonPostedID  uint32        `json:"non_posted_id"`
+	FromClientID uint32        `json:"from_client_id"`
+	ToClientID   uint32        `json:"to_client_id"`
+	FromLoanID   sql.NullInt32 `json:"from_loan_id"`
+	ToLoanID     sql.NullInt32 `json:"to_loan_id"`
+	Amount       float64       `json:"amount"`
+	Reason       string        `json:"reason"`
+	ReassignedBy uint32        `json:"reassigned_by"`
+	CreatedAt    time.Time     `json:"created_at"`
+}
+
+type PaymentSplit struct {
+	ID             uint32              `json:"id"`
+	NonPostedID    uint32              `json:"non_posted_id"`
+	Status         PaymentSplitsStatus `json:"status"`
+	CreatedBy      uint32              `json:"created_by"`
+	ReversedBy     sql.NullInt32       `json:"reversed_by"`
+	ReversalReason sql.NullString      `json:"reversal_reason"`
+	ReversedAt     sql.NullTime        `json:"reversed_at"`
+	CreatedAt      time.Time           `json:"created_at"`
+}
+
+type PaymentSplitPortion struct {
+	ID       uint32        `json:"id"`
+	SplitID  uint32        `json:"split_id"`
+	ClientID uint32        `json:"client_id"`
+	LoanID   sql.NullInt32 `json:"loan_id"`
+	Amount   float64       `json:"amount"`
+}
+
+type PaymentValidationLog struct {
+	ID                uint32        `json:"id"`
+	TransactionNumber string        `json:"transaction_number"`
+	AccountNumber     string        `json:"account_number"`
+	PhoneNumber       string        `json:"phone_number"`
+	PayingName        string        `json:"paying_name"`
+	Amount            float64       `json:"amount"`
+	ClientID          sql.NullInt32 `json:"client_id"`
+	Accepted          bool          `json:"accepted"`
+	ResultCode        string        `json:"result_code"`
+	Reason            string        `json:"reason"`
+	CreatedAt         time.Time     `json:"created_at"`
+}
+
+type ProcessedCallback struct {
+	ID                uint32        `json:"id"`
+	TransactionNumber string        `json:"transaction_number"`
+	TransactionSource string        `json:"transaction_source"`
+	NonPostedID       uint32        `json:"non_posted_id"`
+	LoanID            sql.NullInt32 `json:"loan_id"`
+	CreatedAt         time.Time     `json:"created_at"`
 }
 
 type Product struct {
@@ -283,6 +1134,76 @@ type Product struct {
 	CreatedAt      time.Time `json:"created_at"`
 }
 
+type ProductAllocationStrategy struct {
+	ProductID uint32                              `json:"product_id"`
+	Strategy  ProductAllocationStrategiesStrategy `json:"strategy"`
+	UpdatedBy uint32                              `json:"updated_by"`
+	UpdatedAt time.Time                           `json:"updated_at"`
+}
+
+type ProductDefaultThreshold struct {
+	ProductID uint32    `json:"product_id"`
+	GraceDays uint32    `json:"grace_days"`
+	UpdatedBy uint32    `json:"updated_by"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type ProductPenaltyRule struct {
+	ProductID   uint32                         `json:"product_id"`
+	PenaltyType ProductPenaltyRulesPenaltyType `json:"penalty_type"`
+	Rate        float64                        `json:"rate"`
+	Frequency   ProductPenaltyRulesFrequency   `json:"frequency"`
+	CapAmount   sql.NullFloat64                `json:"cap_amount"`
+	UpdatedBy   uint32                         `json:"updated_by"`
+	UpdatedAt   time.Time                      `json:"updated_at"`
+}
+
+type StatementReconciliation struct {
+	ID               uint32    `json:"id"`
+	StatementDate    time.Time `json:"statement_date"`
+	FileName         string    `json:"file_name"`
+	TotalLines       uint32    `json:"total_lines"`
+	Matched          uint32    `json:"matched"`
+	MissingCallbacks uint32    `json:"missing_callbacks"`
+	AmountMismatches uint32    `json:"amount_mismatches"`
+	NotOnStatement   uint32    `json:"not_on_statement"`
+	UploadedBy       uint32    `json:"uploaded_by"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+type StatementReconciliationItem struct {
+	ID                  uint32                               `json:"id"`
+	ReconciliationID    uint32                               `json:"reconciliation_id"`
+	ItemType            StatementReconciliationItemsItemType `json:"item_type"`
+	TransactionNumber   string                               `json:"transaction_number"`
+	StatementAmount     float64                              `json:"statement_amount"`
+	RecordedAmount      float64                              `json:"recorded_amount"`
+	NonPostedID         sql.NullInt32                        `json:"non_posted_id"`
+	AccountNumber       string                               `json:"account_number"`
+	PhoneNumber         string                               `json:"phone_number"`
+	PayingName          string                               `json:"paying_name"`
+	PaidDate            time.Time                            `json:"paid_date"`
+	ImportedNonPostedID sql.NullInt32                        `json:"imported_non_posted_id"`
+	ImportedAt          sql.NullTime                         `json:"imported_at"`
+}
+
+type StkPushRequest struct {
+	ID                 uint32                `json:"id"`
+	LoanID             uint32                `json:"loan_id"`
+	ClientID           uint32                `json:"client_id"`
+	PhoneNumber        string                `json:"phone_number"`
+	Amount             float64               `json:"amount"`
+	MerchantRequestID  string                `json:"merchant_request_id"`
+	CheckoutRequestID  string                `json:"checkout_request_id"`
+	Status             StkPushRequestsStatus `json:"status"`
+	ResultCode         sql.NullInt32         `json:"result_code"`
+	ResultDesc         sql.NullString        `json:"result_desc"`
+	MpesaReceiptNumber sql.NullString        `json:"mpesa_receipt_number"`
+	InitiatedBy        uint32                `json:"initiated_by"`
+	CreatedAt          time.Time             `json:"created_at"`
+	UpdatedAt          sql.NullTime          `json:"updated_at"`
+}
+
 type User struct {
 	ID              uint32    `json:"id"`
 	FullName        string    `json:"full_name"`
@@ -105,7 +105,7 @@ func (q *Queries) CountNonPostedByCategory(ctx context.Context, arg CountNonPost
 }
 
 const createNonPosted = `-- name: CreateNonPosted :execresult
-INSERT INTO non_posted (transaction_source, transaction_number, account_number, phone_number, paying_name, amount, paid_date, assign_to, assigned_by) 
+INSERT INTO non_posted (transaction_source, transaction_number, account_number, phone_number, paying_name, amount, paid_date, assign_to, assigned_by, cash_receipt) 
 VALUES (
     ?,
     ?,
@@ -115,6 +115,7 @@ VALUES (
     ?,
     ?,
     ?,
+    ?,
     ?
 )
 `
@@ -129,6 +130,7 @@ type CreateNonPostedParams struct {
 	PaidDate          time.Time                  `json:"paid_date"`
 	AssignTo          sql.NullInt32              `json:"assign_to"`
 	AssignedBy        string                     `json:"assigned_by"`
+	CashReceipt       bool                       `json:"cash_receipt"`
 }
 
 func (q *Queries) CreateNonPosted(ctx context.Context, arg CreateNonPostedParams) (sql.Result, error) {
@@ -142,6 +144,7 @@ func (q *Queries) CreateNonPosted(ctx context.Context, arg CreateNonPostedParams
 		arg.PaidDate,
 		arg.AssignTo,
 		arg.AssignedBy,
+		arg.CashReceipt,
 	)
 }
 
@@ -235,7 +238,7 @@ func (q *Queries) GetClientsNonPosted(ctx context.Context, arg GetClientsNonPost
 
 const getNonPosted = `-- name: GetNonPosted :one
 SELECT 
-    np.id, np.transaction_number, np.account_number, np.phone_number, np.paying_name, np.amount, np.assign_to, np.paid_date, np.transaction_source, np.assigned_by, np.deleted_at, np.deleted_description, 
+    np.id, np.transaction_number, np.account_number, np.phone_number, np.paying_name, np.amount, np.assign_to, np.paid_date, np.transaction_source, np.assigned_by, np.deleted_at, np.deleted_description, np.cash_receipt, 
     -- Client Details (if assigned)
     c.id AS client_id,
     c.full_name AS client_name,
@@ -262,6 +265,7 @@ type GetNonPostedRow struct {
 	AssignedBy         string                     `json:"assigned_by"`
 	DeletedAt          sql.NullTime               `json:"deleted_at"`
 	DeletedDescription sql.NullString             `json:"deleted_description"`
+	CashReceipt        bool                       `json:"cash_receipt"`
 	ClientID           sql.NullInt32              `json:"client_id"`
 	ClientName         sql.NullString             `json:"client_name"`
 	ClientPhone        sql.NullString             `json:"client_phone"`
@@ -285,6 +289,7 @@ func (q *Queries) GetNonPosted(ctx context.Context, id uint32) (GetNonPostedRow,
 		&i.AssignedBy,
 		&i.DeletedAt,
 		&i.DeletedDescription,
+		&i.CashReceipt,
 		&i.ClientID,
 		&i.ClientName,
 		&i.ClientPhone,
@@ -315,7 +320,7 @@ func (q *Queries) GetTotalPaidByIDorAccountNo(ctx context.Context, arg GetTotalP
 }
 
 const listAllNonPosted = `-- name: ListAllNonPosted :many
-SELECT id, transaction_number, account_number, phone_number, paying_name, amount, assign_to, paid_date, transaction_source, assigned_by, deleted_at, deleted_description FROM non_posted LIMIT ? OFFSET ?
+SELECT id, transaction_number, account_number, phone_number, paying_name, amount, assign_to, paid_date, transaction_source, assigned_by, deleted_at, deleted_description, cash_receipt FROM non_posted LIMIT ? OFFSET ?
 `
 
 type ListAllNonPostedParams struct {
@@ -345,6 +350,7 @@ func (q *Queries) ListAllNonPosted(ctx context.Context, arg ListAllNonPostedPara
 			&i.AssignedBy,
 			&i.DeletedAt,
 			&i.DeletedDescription,
+			&i.CashReceipt,
 		); err != nil {
 			return nil, err
 		}
@@ -360,7 +366,7 @@ func (q *Queries) ListAllNonPosted(ctx context.Context, arg ListAllNonPostedPara
 }
 
 const listAllNonPostedByTransactionSource = `-- name: ListAllNonPostedByTransactionSource :many
-SELECT id, transaction_number, account_number, phone_number, paying_name, amount, assign_to, paid_date, transaction_source, assigned_by, deleted_at, deleted_description FROM non_posted WHERE transaction_source = ?
+SELECT id, transaction_number, account_number, phone_number, paying_name, amount, assign_to, paid_date, transaction_source, assigned_by, deleted_at, deleted_description, cash_receipt FROM non_posted WHERE transaction_source = ?
 `
 
 func (q *Queries) ListAllNonPostedByTransactionSource(ctx context.Context, transactionSource NonPostedTransactionSource) ([]NonPosted, error) {
@@ -385,6 +391,107 @@ func (q *Queries) ListAllNonPostedByTransactionSource(ctx context.Context, trans
 			&i.AssignedBy,
 			&i.DeletedAt,
 			&i.DeletedDescription,
+			&i.CashReceipt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDuplicateNonPosted = `-- name: ListDuplicateNonPosted :many
+SELECT np.id, np.transaction_number, np.account_number, np.phone_number, np.paying_name, np.amount, np.assign_to, np.paid_date, np.transaction_source, np.assigned_by, np.deleted_at, np.deleted_description, np.cash_receipt FROM non_posted np
+JOIN (
+    SELECT transaction_number, transaction_source
+    FROM non_posted
+    WHERE deleted_at IS NULL
+    GROUP BY transaction_number, transaction_source
+    HAVING COUNT(*) > 1
+) d ON np.transaction_number = d.transaction_number AND np.transaction_source = d.transaction_source
+WHERE np.deleted_at IS NULL
+ORDER BY np.transaction_number, np.id
+`
+
+func (q *Queries) ListDuplicateNonPosted(ctx context.Context) ([]NonPosted, error) {
+	rows, err := q.db.QueryContext(ctx, listDuplicateNonPosted)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []NonPosted{}
+	for rows.Next() {
+		var i NonPosted
+		if err := rows.Scan(
+			&i.ID,
+			&i.TransactionNumber,
+			&i.AccountNumber,
+			&i.PhoneNumber,
+			&i.PayingName,
+			&i.Amount,
+			&i.AssignTo,
+			&i.PaidDate,
+			&i.TransactionSource,
+			&i.AssignedBy,
+			&i.DeletedAt,
+			&i.DeletedDescription,
+			&i.CashReceipt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listMpesaNonPostedByPaidDate = `-- name: ListMpesaNonPostedByPaidDate :many
+SELECT id, transaction_number, account_number, phone_number, paying_name, amount, assign_to, paid_date, transaction_source, assigned_by, deleted_at, deleted_description, cash_receipt FROM non_posted
+WHERE transaction_source = 'MPESA'
+    AND deleted_at IS NULL
+    AND paid_date BETWEEN ? AND ?
+ORDER BY paid_date
+`
+
+type ListMpesaNonPostedByPaidDateParams struct {
+	FromDate time.Time `json:"from_date"`
+	ToDate   time.Time `json:"to_date"`
+}
+
+func (q *Queries) ListMpesaNonPostedByPaidDate(ctx context.Context, arg ListMpesaNonPostedByPaidDateParams) ([]NonPosted, error) {
+	rows, err := q.db.QueryContext(ctx, listMpesaNonPostedByPaidDate, arg.FromDate, arg.ToDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []NonPosted{}
+	for rows.Next() {
+		var i NonPosted
+		if err := rows.Scan(
+			&i.ID,
+			&i.TransactionNumber,
+			&i.AccountNumber,
+			&i.PhoneNumber,
+			&i.PayingName,
+			&i.Amount,
+			&i.AssignTo,
+			&i.PaidDate,
+			&i.TransactionSource,
+			&i.AssignedBy,
+			&i.DeletedAt,
+			&i.DeletedDescription,
+			&i.CashReceipt,
 		); err != nil {
 			return nil, err
 		}
@@ -401,7 +508,7 @@ func (q *Queries) ListAllNonPostedByTransactionSource(ctx context.Context, trans
 
 const listNonPostedByCategory = `-- name: ListNonPostedByCategory :many
 SELECT 
-    np.id, np.transaction_number, np.account_number, np.phone_number, np.paying_name, np.amount, np.assign_to, np.paid_date, np.transaction_source, np.assigned_by, np.deleted_at, np.deleted_description, 
+    np.id, np.transaction_number, np.account_number, np.phone_number, np.paying_name, np.amount, np.assign_to, np.paid_date, np.transaction_source, np.assigned_by, np.deleted_at, np.deleted_description, np.cash_receipt, 
     -- Client Details (if assigned)
     c.id AS client_id,
     c.full_name AS client_name,
@@ -455,6 +562,7 @@ type ListNonPostedByCategoryRow struct {
 	AssignedBy         string                     `json:"assigned_by"`
 	DeletedAt          sql.NullTime               `json:"deleted_at"`
 	DeletedDescription sql.NullString             `json:"deleted_description"`
+	CashReceipt        bool                       `json:"cash_receipt"`
 	ClientID           sql.NullInt32              `json:"client_id"`
 	ClientName         sql.NullString             `json:"client_name"`
 	ClientPhone        sql.NullString             `json:"client_phone"`
@@ -495,6 +603,7 @@ func (q *Queries) ListNonPostedByCategory(ctx context.Context, arg ListNonPosted
 			&i.AssignedBy,
 			&i.DeletedAt,
 			&i.DeletedDescription,
+			&i.CashReceipt,
 			&i.ClientID,
 			&i.ClientName,
 			&i.ClientPhone,
@@ -515,7 +624,7 @@ func (q *Queries) ListNonPostedByCategory(ctx context.Context, arg ListNonPosted
 }
 
 const listNonPostedByTransactionSource = `-- name: ListNonPostedByTransactionSource :many
-SELECT id, transaction_number, account_number, phone_number, paying_name, amount, assign_to, paid_date, transaction_source, assigned_by, deleted_at, deleted_description FROM non_posted WHERE transaction_source = ? LIMIT ? OFFSET ?
+SELECT id, transaction_number, account_number, phone_number, paying_name, amount, assign_to, paid_date, transaction_source, assigned_by, deleted_at, deleted_description, cash_receipt FROM non_posted WHERE transaction_source = ? LIMIT ? OFFSET ?
 `
 
 type ListNonPostedByTransactionSourceParams struct {
@@ -546,6 +655,7 @@ func (q *Queries) ListNonPostedByTransactionSource(ctx context.Context, arg List
 			&i.AssignedBy,
 			&i.DeletedAt,
 			&i.DeletedDescription,
+			&i.CashReceipt,
 		); err != nil {
 			return nil, err
 		}
@@ -561,7 +671,7 @@ func (q *Queries) ListNonPostedByTransactionSource(ctx context.Context, arg List
 }
 
 const listUnassignedNonPosted = `-- name: ListUnassignedNonPosted :many
-SELECT id, transaction_number, account_number, phone_number, paying_name, amount, assign_to, paid_date, transaction_source, assigned_by, deleted_at, deleted_description FROM non_posted WHERE assign_to IS NULL LIMIT ? OFFSET ?
+SELECT id, transaction_number, account_number, phone_number, paying_name, amount, assign_to, paid_date, transaction_source, assigned_by, deleted_at, deleted_description, cash_receipt FROM non_posted WHERE assign_to IS NULL LIMIT ? OFFSET ?
 `
 
 type ListUnassignedNonPostedParams struct {
@@ -591,6 +701,52 @@ func (q *Queries) ListUnassignedNonPosted(ctx context.Context, arg ListUnassigne
 			&i.AssignedBy,
 			&i.DeletedAt,
 			&i.DeletedDescription,
+			&i.CashReceipt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUnassignedNonPostedForRules = `-- name: ListUnassignedNonPostedForRules :many
+SELECT id, transaction_number, account_number, phone_number, paying_name, amount, assign_to, paid_date, transaction_source, assigned_by, deleted_at, deleted_description, cash_receipt FROM non_posted
+WHERE assign_to IS NULL
+    AND deleted_at IS NULL
+ORDER BY paid_date DESC
+LIMIT ?
+`
+
+func (q *Queries) ListUnassignedNonPostedForRules(ctx context.Context, limit int32) ([]NonPosted, error) {
+	rows, err := q.db.QueryContext(ctx, listUnassignedNonPostedForRules, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []NonPosted{}
+	for rows.Next() {
+		var i NonPosted
+		if err := rows.Scan(
+			&i.ID,
+			&i.TransactionNumber,
+			&i.AccountNumber,
+			&i.PhoneNumber,
+			&i.PayingName,
+			&i.Amount,
+			&i.AssignTo,
+			&i.PaidDate,
+			&i.TransactionSource,
+			&i.AssignedBy,
+			&i.DeletedAt,
+			&i.DeletedDescription,
+			&i.CashReceipt,
 		); err != nil {
 			return nil, err
 		}
@@ -605,6 +761,31 @@ func (q *Queries) ListUnassignedNonPosted(ctx context.Context, arg ListUnassigne
 	return items, nil
 }
 
+const lockNonPosted = `-- name: LockNonPosted :one
+SELECT id, transaction_number, account_number, phone_number, paying_name, amount, assign_to, paid_date, transaction_source, assigned_by, deleted_at, deleted_description, cash_receipt FROM non_posted WHERE id = ? LIMIT 1 FOR UPDATE
+`
+
+func (q *Queries) LockNonPosted(ctx context.Context, id uint32) (NonPosted, error) {
+	row := q.db.QueryRowContext(ctx, lockNonPosted, id)
+	var i NonPosted
+	err := row.Scan(
+		&i.ID,
+		&i.TransactionNumber,
+		&i.AccountNumber,
+		&i.PhoneNumber,
+		&i.PayingName,
+		&i.Amount,
+		&i.AssignTo,
+		&i.PaidDate,
+		&i.TransactionSource,
+		&i.AssignedBy,
+		&i.DeletedAt,
+		&i.DeletedDescription,
+		&i.CashReceipt,
+	)
+	return i, err
+}
+
 const softDeleteNonPosted = `-- name: SoftDeleteNonPosted :exec
 UPDATE non_posted
 SET deleted_at = CURRENT_TIMESTAMP,
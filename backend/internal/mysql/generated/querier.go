@@ -7,14 +7,20 @@ package generated
 import (
 	"context"
 	"database/sql"
+	"time"
 )
 
 type Querier interface {
 	// SELECT * FROM non_posted WHERE id = ? LIMIT 1;
 	AssignNonPosted(ctx context.Context, arg AssignNonPostedParams) (sql.Result, error)
 	CheckActiveLoanForClient(ctx context.Context, clientID uint32) (bool, error)
+	CheckCashBookClosed(ctx context.Context, arg CheckCashBookClosedParams) (bool, error)
 	CheckUserExistance(ctx context.Context, email string) (int64, error)
+	ClaimPaymentImportBatch(ctx context.Context, id uint32) (sql.Result, error)
+	CloseUnpaidInstallments(ctx context.Context, arg CloseUnpaidInstallmentsParams) (sql.Result, error)
+	CountAccountLedger(ctx context.Context, arg CountAccountLedgerParams) (int64, error)
 	CountBranchesByCategory(ctx context.Context, arg CountBranchesByCategoryParams) (int64, error)
+	CountCashVariances(ctx context.Context, branchID uint32) (int64, error)
 	CountClientLoans(ctx context.Context, arg CountClientLoansParams) (int64, error)
 	CountClientsByCategory(ctx context.Context, arg CountClientsByCategoryParams) (int64, error)
 	CountClientsNonPosted(ctx context.Context, arg CountClientsNonPostedParams) (int64, error)
@@ -22,35 +28,76 @@ type Querier interface {
 	CountLoans(ctx context.Context, arg CountLoansParams) (int64, error)
 	CountLoansByCategory(ctx context.Context, arg CountLoansByCategoryParams) (int64, error)
 	CountNonPostedByCategory(ctx context.Context, arg CountNonPostedByCategoryParams) (int64, error)
+	CountOverdueInstallments(ctx context.Context, arg CountOverdueInstallmentsParams) (int64, error)
+	CountOverpaymentRefunds(ctx context.Context) (int64, error)
+	CountOverpaymentRefundsByStatus(ctx context.Context, status OverpaymentRefundsStatus) (int64, error)
+	CountPaymentImportBatches(ctx context.Context) (int64, error)
+	CountPaymentValidationLogs(ctx context.Context) (int64, error)
+	CountStatementReconciliations(ctx context.Context) (int64, error)
 	CountUnpaidInstallmentsData(ctx context.Context, arg CountUnpaidInstallmentsDataParams) (int64, error)
+	CountUnpaidPenaltiesByLoan(ctx context.Context, loanID uint32) (int64, error)
 	CountUsersByCategory(ctx context.Context, arg CountUsersByCategoryParams) (int64, error)
+	CreateAssignmentRule(ctx context.Context, arg CreateAssignmentRuleParams) (sql.Result, error)
+	CreateBlacklistedClient(ctx context.Context, arg CreateBlacklistedClientParams) (sql.Result, error)
 	CreateBranch(ctx context.Context, name string) (sql.Result, error)
+	CreateBranchExpense(ctx context.Context, arg CreateBranchExpenseParams) (sql.Result, error)
+	CreateCallbackPayload(ctx context.Context, arg CreateCallbackPayloadParams) (sql.Result, error)
+	CreateCashBookClose(ctx context.Context, arg CreateCashBookCloseParams) (sql.Result, error)
+	CreateCashVariance(ctx context.Context, arg CreateCashVarianceParams) (sql.Result, error)
 	CreateClient(ctx context.Context, arg CreateClientParams) (sql.Result, error)
 	CreateClientOverpaymentTransaction(ctx context.Context, arg CreateClientOverpaymentTransactionParams) (sql.Result, error)
 	CreateInstallment(ctx context.Context, arg CreateInstallmentParams) (sql.Result, error)
+	CreateInstallmentPenalty(ctx context.Context, arg CreateInstallmentPenaltyParams) (sql.Result, error)
+	CreateJournalEntry(ctx context.Context, arg CreateJournalEntryParams) (sql.Result, error)
+	CreateJournalLine(ctx context.Context, arg CreateJournalLineParams) (sql.Result, error)
 	CreateLoan(ctx context.Context, arg CreateLoanParams) (sql.Result, error)
+	CreateLoanDisbursement(ctx context.Context, arg CreateLoanDisbursementParams) (sql.Result, error)
+	CreateLoanRestructure(ctx context.Context, arg CreateLoanRestructureParams) (sql.Result, error)
+	CreateLoanSettlementQuote(ctx context.Context, arg CreateLoanSettlementQuoteParams) (sql.Result, error)
+	CreateLoanTopUp(ctx context.Context, arg CreateLoanTopUpParams) (sql.Result, error)
 	CreateNonPosted(ctx context.Context, arg CreateNonPostedParams) (sql.Result, error)
+	CreateOverpaymentRefund(ctx context.Context, arg CreateOverpaymentRefundParams) (sql.Result, error)
 	CreatePaymentAllocation(ctx context.Context, arg CreatePaymentAllocationParams) (sql.Result, error)
+	CreatePaymentImportBatch(ctx context.Context, arg CreatePaymentImportBatchParams) (sql.Result, error)
+	CreatePaymentImportRow(ctx context.Context, arg CreatePaymentImportRowParams) (sql.Result, error)
+	CreatePaymentReassignment(ctx context.Context, arg CreatePaymentReassignmentParams) (sql.Result, error)
+	CreatePaymentSplit(ctx context.Context, arg CreatePaymentSplitParams) (sql.Result, error)
+	CreatePaymentSplitPortion(ctx context.Context, arg CreatePaymentSplitPortionParams) (sql.Result, error)
+	CreatePaymentValidationLog(ctx context.Context, arg CreatePaymentValidationLogParams) (sql.Result, error)
+	CreateProcessedCallback(ctx context.Context, arg CreateProcessedCallbackParams) (sql.Result, error)
 	CreateProduct(ctx context.Context, arg CreateProductParams) (sql.Result, error)
+	CreateStatementReconciliation(ctx context.Context, arg CreateStatementReconciliationParams) (sql.Result, error)
+	CreateStatementReconciliationItem(ctx context.Context, arg CreateStatementReconciliationItemParams) (sql.Result, error)
+	CreateStkPushRequest(ctx context.Context, arg CreateStkPushRequestParams) (sql.Result, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (sql.Result, error)
 	DashBoardDataHelper(ctx context.Context) (DashBoardDataHelperRow, error)
 	DashBoardInactiveLoans(ctx context.Context) ([]DashBoardInactiveLoansRow, error)
 	DashBoardRecentsPayments(ctx context.Context) ([]DashBoardRecentsPaymentsRow, error)
 	DeductClientOverpayment(ctx context.Context, arg DeductClientOverpaymentParams) (sql.Result, error)
+	DeleteAssignmentRule(ctx context.Context, id uint32) error
+	DeleteBlacklistedClient(ctx context.Context, clientID uint32) error
 	DeleteBranch(ctx context.Context, id uint32) error
 	DeleteClient(ctx context.Context, id uint32) (sql.Result, error)
 	DeleteLoan(ctx context.Context, id uint32) error
 	DeleteNonPosted(ctx context.Context, id uint32) error
 	DeletePaymentAllocation(ctx context.Context, id uint32) (sql.Result, error)
 	DeletePaymentAllocationsByNonPostedId(ctx context.Context, arg DeletePaymentAllocationsByNonPostedIdParams) (sql.Result, error)
+	DeleteProcessedCallbackByNonPosted(ctx context.Context, nonPostedID uint32) (sql.Result, error)
 	DeleteProduct(ctx context.Context, id uint32) error
+	DeleteProductDefaultThreshold(ctx context.Context, productID uint32) error
+	DeleteProductPenaltyRule(ctx context.Context, productID uint32) error
 	DisburseLoan(ctx context.Context, arg DisburseLoanParams) (sql.Result, error)
 	GetActiveLoanDetails(ctx context.Context, clientID uint32) (GetActiveLoanDetailsRow, error)
+	GetActivePaymentSplitByNonPosted(ctx context.Context, nonPostedID uint32) (PaymentSplit, error)
+	GetAssignmentRule(ctx context.Context, id uint32) (AssignmentRule, error)
+	GetBlacklistedClient(ctx context.Context, clientID uint32) (BlacklistedClient, error)
 	GetBranch(ctx context.Context, id uint32) (Branch, error)
 	GetBranchReportData(ctx context.Context, arg GetBranchReportDataParams) ([]GetBranchReportDataRow, error)
+	GetCashBookClose(ctx context.Context, arg GetCashBookCloseParams) (CashBookClose, error)
 	GetClient(ctx context.Context, id uint32) (Client, error)
 	GetClientActiveLoan(ctx context.Context, arg GetClientActiveLoanParams) (uint32, error)
 	GetClientAdminsReportData(ctx context.Context, arg GetClientAdminsReportDataParams) ([]GetClientAdminsReportDataRow, error)
+	GetClientByIDNumber(ctx context.Context, idNumber sql.NullString) (Client, error)
 	GetClientByPhoneNumber(ctx context.Context, phoneNumber string) (Client, error)
 	GetClientClientsReportData(ctx context.Context, arg GetClientClientsReportDataParams) (GetClientClientsReportDataRow, error)
 	// JOIN users updated ON c.updated_by = updated.id
@@ -62,26 +109,47 @@ type Querier interface {
 	GetClientOverpaymentTransaction(ctx context.Context, id uint32) (ClientOverpaymentTransaction, error)
 	GetClientOverpaymentTransactionByPaymentId(ctx context.Context, paymentID sql.NullInt32) (ClientOverpaymentTransaction, error)
 	GetClientOverpaymentTransactions(ctx context.Context, clientID uint32) ([]ClientOverpaymentTransaction, error)
+	GetClientPayableLoan(ctx context.Context, clientID uint32) (uint32, error)
 	GetClientWithBranchName(ctx context.Context, id uint32) (GetClientWithBranchNameRow, error)
 	GetClientsNonPosted(ctx context.Context, arg GetClientsNonPostedParams) ([]GetClientsNonPostedRow, error)
 	GetInstallment(ctx context.Context, id uint32) (Installment, error)
+	GetLatestCashBookClose(ctx context.Context, arg GetLatestCashBookCloseParams) (CashBookClose, error)
+	GetLedgerAccountByCode(ctx context.Context, code string) (LedgerAccount, error)
 	GetLoan(ctx context.Context, id uint32) (Loan, error)
+	GetLoanAllocationTerms(ctx context.Context, id uint32) (GetLoanAllocationTermsRow, error)
 	GetLoanClientID(ctx context.Context, id uint32) (uint32, error)
 	GetLoanData(ctx context.Context) ([]uint32, error)
 	GetLoanDetails(ctx context.Context, id uint32) (GetLoanDetailsRow, error)
+	GetLoanDisbursementByConversationID(ctx context.Context, conversationID string) (LoanDisbursement, error)
 	GetLoanEvents(ctx context.Context) ([]GetLoanEventsRow, error)
 	// Left joins for optional fields (disbursed_by, updated_by, created_by)
 	GetLoanFullData(ctx context.Context, id uint32) (GetLoanFullDataRow, error)
+	GetLoanInstallmentCounts(ctx context.Context, loanID uint32) (GetLoanInstallmentCountsRow, error)
 	GetLoanReportDataById(ctx context.Context, id uint32) (GetLoanReportDataByIdRow, error)
+	GetLoanSettlementQuote(ctx context.Context, id uint32) (LoanSettlementQuote, error)
 	GetLoanStatus(ctx context.Context, id uint32) (LoansStatus, error)
+	GetLoanTopUpByLoan(ctx context.Context, loanID uint32) (LoanTopUp, error)
+	GetLoanTopUpByRefinancedLoan(ctx context.Context, refinancedLoanID uint32) (LoanTopUp, error)
 	GetLoansReportData(ctx context.Context, arg GetLoansReportDataParams) ([]GetLoansReportDataRow, error)
 	GetNonPosted(ctx context.Context, id uint32) (GetNonPostedRow, error)
+	GetOverpaymentRefund(ctx context.Context, id uint32) (OverpaymentRefund, error)
+	GetOverpaymentRefundByConversationID(ctx context.Context, conversationID sql.NullString) (OverpaymentRefund, error)
+	GetPaymentImportBatch(ctx context.Context, id uint32) (PaymentImportBatch, error)
+	GetPaymentReassignment(ctx context.Context, id uint32) (PaymentReassignment, error)
 	GetPaymentReportData(ctx context.Context, arg GetPaymentReportDataParams) ([]GetPaymentReportDataRow, error)
+	GetPaymentSplit(ctx context.Context, id uint32) (PaymentSplit, error)
+	GetProcessedCallback(ctx context.Context, arg GetProcessedCallbackParams) (ProcessedCallback, error)
 	GetProduct(ctx context.Context, id uint32) (GetProductRow, error)
+	GetProductAllocationStrategy(ctx context.Context, productID uint32) (ProductAllocationStrategy, error)
+	GetProductDefaultThreshold(ctx context.Context, productID uint32) (ProductDefaultThreshold, error)
+	GetProductPenaltyRule(ctx context.Context, productID uint32) (ProductPenaltyRule, error)
 	// SELECT * FROM products WHERE id = ? LIMIT 1;
 	GetProductRepayAmount(ctx context.Context, id uint32) (float64, error)
 	GetProductReportData(ctx context.Context, arg GetProductReportDataParams) ([]GetProductReportDataRow, error)
+	GetStatementReconciliation(ctx context.Context, id uint32) (StatementReconciliation, error)
+	GetStkPushRequestByCheckoutID(ctx context.Context, checkoutRequestID string) (StkPushRequest, error)
 	GetTotalPaidByIDorAccountNo(ctx context.Context, arg GetTotalPaidByIDorAccountNoParams) (interface{}, error)
+	GetTrialBalance(ctx context.Context, asOf time.Time) ([]GetTrialBalanceRow, error)
 	GetUnpaidInstallmentsData(ctx context.Context, arg GetUnpaidInstallmentsDataParams) ([]GetUnpaidInstallmentsDataRow, error)
 	GetUser(ctx context.Context, id uint32) (GetUserRow, error)
 	GetUserAdminsReportData(ctx context.Context, arg GetUserAdminsReportDataParams) ([]GetUserAdminsReportDataRow, error)
@@ -92,50 +160,110 @@ type Querier interface {
 	HelperProduct(ctx context.Context) ([]HelperProductRow, error)
 	HelperUser(ctx context.Context) ([]HelperUserRow, error)
 	HelperUserById(ctx context.Context, id uint32) (string, error)
+	ListAccountLedger(ctx context.Context, arg ListAccountLedgerParams) ([]ListAccountLedgerRow, error)
+	ListActiveAssignmentRules(ctx context.Context) ([]AssignmentRule, error)
 	ListAllNonPosted(ctx context.Context, arg ListAllNonPostedParams) ([]NonPosted, error)
 	ListAllNonPostedByTransactionSource(ctx context.Context, transactionSource NonPostedTransactionSource) ([]NonPosted, error)
+	ListAssignmentRules(ctx context.Context) ([]AssignmentRule, error)
 	ListBrachesByCategory(ctx context.Context, arg ListBrachesByCategoryParams) ([]Branch, error)
+	ListBranchCashDisbursements(ctx context.Context, arg ListBranchCashDisbursementsParams) ([]ListBranchCashDisbursementsRow, error)
+	ListBranchCashReceipts(ctx context.Context, arg ListBranchCashReceiptsParams) ([]ListBranchCashReceiptsRow, error)
+	ListBranchExpenses(ctx context.Context, arg ListBranchExpensesParams) ([]BranchExpense, error)
 	ListBranches(ctx context.Context) ([]Branch, error)
+	ListCallbackPayloadsByTransactionNumber(ctx context.Context, transactionNumber string) ([]CallbackPayload, error)
+	ListCashVariances(ctx context.Context, arg ListCashVariancesParams) ([]CashVariance, error)
+	ListClientNames(ctx context.Context) ([]ListClientNamesRow, error)
 	ListClients(ctx context.Context, arg ListClientsParams) ([]Client, error)
 	ListClientsByActiveStatus(ctx context.Context, arg ListClientsByActiveStatusParams) ([]Client, error)
 	ListClientsByBranch(ctx context.Context, arg ListClientsByBranchParams) ([]Client, error)
 	ListClientsByCategory(ctx context.Context, arg ListClientsByCategoryParams) ([]ListClientsByCategoryRow, error)
+	ListClientsByPhoneNumbers(ctx context.Context, arg ListClientsByPhoneNumbersParams) ([]Client, error)
+	ListDueInstallmentsByAmount(ctx context.Context, arg ListDueInstallmentsByAmountParams) ([]ListDueInstallmentsByAmountRow, error)
+	ListDuplicateNonPosted(ctx context.Context) ([]NonPosted, error)
 	ListExpectedPayments(ctx context.Context, arg ListExpectedPaymentsParams) ([]ListExpectedPaymentsRow, error)
 	ListInstallmentsByLoan(ctx context.Context, loanID uint32) ([]Installment, error)
+	ListInstallmentsForPenaltyAccrual(ctx context.Context, asOf time.Time) ([]ListInstallmentsForPenaltyAccrualRow, error)
+	ListLedgerAccounts(ctx context.Context) ([]LedgerAccount, error)
+	ListLoanDisbursementsByLoan(ctx context.Context, loanID uint32) ([]LoanDisbursement, error)
+	ListLoanRestructures(ctx context.Context, loanID uint32) ([]LoanRestructure, error)
+	ListLoanSettlementQuotes(ctx context.Context, loanID uint32) ([]LoanSettlementQuote, error)
 	// Left joins for optional fields (disbursed_by, updated_by, created_by)
 	ListLoans(ctx context.Context, arg ListLoansParams) ([]ListLoansRow, error)
 	ListLoansByClient(ctx context.Context, arg ListLoansByClientParams) ([]Loan, error)
 	ListLoansByLoanOfficer(ctx context.Context, arg ListLoansByLoanOfficerParams) ([]Loan, error)
 	ListLoansByStatus(ctx context.Context, arg ListLoansByStatusParams) ([]Loan, error)
+	ListLoansPastDefaultThreshold(ctx context.Context, arg ListLoansPastDefaultThresholdParams) ([]ListLoansPastDefaultThresholdRow, error)
+	ListMpesaNonPostedByPaidDate(ctx context.Context, arg ListMpesaNonPostedByPaidDateParams) ([]NonPosted, error)
 	ListNonDisbursedLoans(ctx context.Context, arg ListNonDisbursedLoansParams) ([]Loan, error)
 	ListNonPostedByCategory(ctx context.Context, arg ListNonPostedByCategoryParams) ([]ListNonPostedByCategoryRow, error)
 	ListNonPostedByTransactionSource(ctx context.Context, arg ListNonPostedByTransactionSourceParams) ([]NonPosted, error)
+	ListOverpaymentRefunds(ctx context.Context, arg ListOverpaymentRefundsParams) ([]OverpaymentRefund, error)
+	ListOverpaymentRefundsByStatus(ctx context.Context, arg ListOverpaymentRefundsByStatusParams) ([]OverpaymentRefund, error)
 	ListPaymentAllocationsByLoanId(ctx context.Context, loanID sql.NullInt32) ([]ListPaymentAllocationsByLoanIdRow, error)
 	ListPaymentAllocationsByNonPostedID(ctx context.Context, nonPostedID uint32) ([]ListPaymentAllocationsByNonPostedIDRow, error)
 	ListPaymentAllocationsByNonPostedId(ctx context.Context, nonPostedID uint32) ([]PaymentAllocation, error)
+	ListPaymentImportBatches(ctx context.Context, arg ListPaymentImportBatchesParams) ([]PaymentImportBatch, error)
+	ListPaymentImportRows(ctx context.Context, batchID uint32) ([]PaymentImportRow, error)
+	ListPaymentReassignmentsByNonPosted(ctx context.Context, nonPostedID uint32) ([]PaymentReassignment, error)
+	ListPaymentSplitPortions(ctx context.Context, splitID uint32) ([]PaymentSplitPortion, error)
+	ListPaymentValidationLogs(ctx context.Context, arg ListPaymentValidationLogsParams) ([]PaymentValidationLog, error)
+	ListPenaltiesByLoan(ctx context.Context, loanID uint32) ([]InstallmentPenalty, error)
 	ListProducts(ctx context.Context, arg ListProductsParams) ([]Product, error)
 	ListProductsByBranch(ctx context.Context, arg ListProductsByBranchParams) ([]Product, error)
 	ListProductsByCategory(ctx context.Context, arg ListProductsByCategoryParams) ([]ListProductsByCategoryRow, error)
+	ListStatementReconciliationItems(ctx context.Context, reconciliationID uint32) ([]StatementReconciliationItem, error)
+	ListStatementReconciliations(ctx context.Context, arg ListStatementReconciliationsParams) ([]StatementReconciliation, error)
+	ListStkPushRequestsByLoan(ctx context.Context, loanID uint32) ([]StkPushRequest, error)
 	ListUnassignedNonPosted(ctx context.Context, arg ListUnassignedNonPostedParams) ([]NonPosted, error)
+	ListUnassignedNonPostedForRules(ctx context.Context, limit int32) ([]NonPosted, error)
 	ListUnpaidInstallmentsByLoan(ctx context.Context, loanID uint32) ([]Installment, error)
+	ListUnpaidPenaltiesByLoan(ctx context.Context, loanID uint32) ([]InstallmentPenalty, error)
 	ListUsers(ctx context.Context, arg ListUsersParams) ([]User, error)
 	ListUsersByCategory(ctx context.Context, arg ListUsersByCategoryParams) ([]ListUsersByCategoryRow, error)
+	LockClient(ctx context.Context, id uint32) (uint32, error)
+	LockLoan(ctx context.Context, id uint32) (uint32, error)
+	LockLoanDisbursementByConversationID(ctx context.Context, conversationID string) (LoanDisbursement, error)
+	LockLoanInstallments(ctx context.Context, loanID uint32) ([]uint32, error)
+	LockNonPosted(ctx context.Context, id uint32) (NonPosted, error)
+	LockOverpaymentRefund(ctx context.Context, id uint32) (OverpaymentRefund, error)
+	LockOverpaymentRefundByConversationID(ctx context.Context, conversationID sql.NullString) (OverpaymentRefund, error)
+	MarkLoanDefaulted(ctx context.Context, id uint32) (sql.Result, error)
+	MarkPaymentImportBatchFailed(ctx context.Context, id uint32) (sql.Result, error)
+	MarkPaymentImportBatchPosted(ctx context.Context, arg MarkPaymentImportBatchPostedParams) (sql.Result, error)
+	MarkPaymentImportRowRolledBack(ctx context.Context, id uint32) (sql.Result, error)
+	MarkStatementReconciliationItemImported(ctx context.Context, arg MarkStatementReconciliationItemImportedParams) (sql.Result, error)
 	NullifyClientOverpayment(ctx context.Context, id uint32) (sql.Result, error)
 	PayInstallment(ctx context.Context, arg PayInstallmentParams) (sql.Result, error)
+	PayInstallmentPenalty(ctx context.Context, arg PayInstallmentPenaltyParams) (sql.Result, error)
 	ReduceLoan(ctx context.Context, arg ReduceLoanParams) (sql.Result, error)
+	ReversePaymentSplit(ctx context.Context, arg ReversePaymentSplitParams) (sql.Result, error)
 	RevertInstallment(ctx context.Context, arg RevertInstallmentParams) (sql.Result, error)
+	RevertInstallmentPenalty(ctx context.Context, arg RevertInstallmentPenaltyParams) (sql.Result, error)
+	ReviewOverpaymentRefund(ctx context.Context, arg ReviewOverpaymentRefundParams) (sql.Result, error)
+	RollbackPaymentImportBatch(ctx context.Context, arg RollbackPaymentImportBatchParams) (sql.Result, error)
+	SettleLoanSettlementQuote(ctx context.Context, arg SettleLoanSettlementQuoteParams) (sql.Result, error)
 	SoftDeleteNonPosted(ctx context.Context, arg SoftDeleteNonPostedParams) error
 	TransferLoan(ctx context.Context, arg TransferLoanParams) (sql.Result, error)
+	UpdateAssignmentRule(ctx context.Context, arg UpdateAssignmentRuleParams) (sql.Result, error)
 	UpdateBranch(ctx context.Context, arg UpdateBranchParams) (sql.Result, error)
 	UpdateClient(ctx context.Context, arg UpdateClientParams) (sql.Result, error)
 	UpdateClientOverpayment(ctx context.Context, arg UpdateClientOverpaymentParams) (sql.Result, error)
 	UpdateInstallment(ctx context.Context, arg UpdateInstallmentParams) (sql.Result, error)
 	UpdateLoan(ctx context.Context, arg UpdateLoanParams) (sql.Result, error)
+	UpdateLoanDisbursementResult(ctx context.Context, arg UpdateLoanDisbursementResultParams) (sql.Result, error)
 	UpdateLoanProcessingFeeStatus(ctx context.Context, arg UpdateLoanProcessingFeeStatusParams) (sql.Result, error)
+	UpdateLoanSchedule(ctx context.Context, arg UpdateLoanScheduleParams) (sql.Result, error)
 	UpdateLoanStatus(ctx context.Context, arg UpdateLoanStatusParams) (sql.Result, error)
 	UpdateNonPosted(ctx context.Context, arg UpdateNonPostedParams) (sql.Result, error)
+	UpdateOverpaymentRefundPayout(ctx context.Context, arg UpdateOverpaymentRefundPayoutParams) (sql.Result, error)
+	UpdatePaymentImportRowResult(ctx context.Context, arg UpdatePaymentImportRowResultParams) (sql.Result, error)
+	UpdateProcessedCallbackTransaction(ctx context.Context, arg UpdateProcessedCallbackTransactionParams) (sql.Result, error)
+	UpdateStkPushRequestResult(ctx context.Context, arg UpdateStkPushRequestResultParams) (sql.Result, error)
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (sql.Result, error)
 	UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) (sql.Result, error)
+	UpsertProductAllocationStrategy(ctx context.Context, arg UpsertProductAllocationStrategyParams) (sql.Result, error)
+	UpsertProductDefaultThreshold(ctx context.Context, arg UpsertProductDefaultThresholdParams) (sql.Result, error)
+	UpsertProductPenaltyRule(ctx context.Context, arg UpsertProductPenaltyRuleParams) (sql.Result, error)
 }
 
 var _ Querier = (*Queries)(nil)
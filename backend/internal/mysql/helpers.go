@@ -50,10 +50,10 @@ func (r *HelperRepository) GetDashboardData(ctx context.Context) (repository.Das
 	for idx, loans := range loansData {
 		inactiveLoans[idx] = repository.InactiveLoan{
 			ID:             loans.ID,
-			Amount:         loans.LoanAmount,
+			Amount:         pkg.MoneyFromFloat(loans.LoanAmount),
 			ClientName:     loans.ClientName,
 			ApprovedByName: loans.ApprovedByName,
-			RepayAmount:    loans.RepayAmount,
+			RepayAmount:    pkg.MoneyFromFloat(loans.RepayAmount),
 			ApprovedOn:     loans.CreatedAt,
 		}
 	}
@@ -63,37 +63,42 @@ func (r *HelperRepository) GetDashboardData(ctx context.Context) (repository.Das
 		recentPayments[idx] = repository.Payment{
 			ID:         payment.ID,
 			PayingName: payment.PayingName,
-			Amount:     payment.Amount,
+			Amount:     pkg.MoneyFromFloat(payment.Amount),
 			PaidDate:   payment.PaidDate,
 		}
 	}
 
-	totalPaymentsReceived := pkg.InterfaceFloat64(widgetsData.TotalPaymentsReceived)
-	totalNonPosted := pkg.InterfaceFloat64(widgetsData.TotalNonPosted)
+	totalPaymentsReceived := pkg.InterfaceMoney(widgetsData.TotalPaymentsReceived)
+	totalNonPosted := pkg.InterfaceMoney(widgetsData.TotalNonPosted)
+
+	// the customer and loan widgets show counts in the same fields as the amounts
+	count := func(n int64) pkg.Money {
+		return pkg.MoneyFromFloat(float64(n))
+	}
 
 	widgets := []repository.Widget{
 		{
 			Title:       "Customers",
-			MainAmount:  float64(widgetsData.TotalClients),
-			Active:      float64(widgetsData.ActiveClients),
+			MainAmount:  count(widgetsData.TotalClients),
+			Active:      count(widgetsData.ActiveClients),
 			ActiveTitle: "Active",
-			Closed:      float64(widgetsData.TotalClients) - float64(widgetsData.ActiveClients),
+			Closed:      count(widgetsData.TotalClients - widgetsData.ActiveClients),
 			ClosedTitle: "Inactive",
 		},
 		{
 			Title:       "Loans",
-			MainAmount:  float64(widgetsData.TotalLoans),
-			Active:      float64(widgetsData.ActiveLoans),
+			MainAmount:  count(widgetsData.TotalLoans),
+			Active:      count(widgetsData.ActiveLoans),
 			ActiveTitle: "Active",
-			Closed:      float64(widgetsData.InactiveLoans),
+			Closed:      count(widgetsData.InactiveLoans),
 			ClosedTitle: "Inactive",
 		},
 		{
 			Title:       "Transactions",
-			MainAmount:  pkg.InterfaceFloat64(widgetsData.TotalLoanAmount),
-			Active:      pkg.InterfaceFloat64(widgetsData.TotalLoanDisbursed),
+			MainAmount:  pkg.InterfaceMoney(widgetsData.TotalLoanAmount),
+			Active:      pkg.InterfaceMoney(widgetsData.TotalLoanDisbursed),
 			ActiveTitle: "Disbursed",
-			Closed:      pkg.InterfaceFloat64(widgetsData.TotalLoanPaid),
+			Closed:      pkg.InterfaceMoney(widgetsData.TotalLoanPaid),
 			ClosedTitle: "Completed Loans",
 			Currency:    "Ksh",
 		},
@@ -228,7 +233,7 @@ func (r *HelperRepository) GetUserFullname(ctx context.Context, id uint32) (stri
 			return "", pkg.Errorf(pkg.NOT_FOUND_ERROR, "user not found")
 		}
 
-		return "", pkg.Errorf(pkg.INTERNAL_ERROR, "error getting user fullname", err)
+		return "", pkg.Errorf(pkg.INTERNAL_ERROR, "error getting user fullname: %v", err)
 	}
 
 	return name, err
@@ -305,7 +310,7 @@ func (r *HelperRepository) GetClientNonPayments(
 			AccountNumber:     nonPosted.AccountNumber,
 			PhoneNumber:       nonPosted.PhoneNumber,
 			PayingName:        nonPosted.PayingName,
-			Amount:            nonPosted.Amount,
+			Amount:            pkg.MoneyFromFloat(nonPosted.Amount),
 			PaidDate:          nonPosted.PaidDate,
 			AssignedBy:        nonPosted.AssignedBy,
 		}
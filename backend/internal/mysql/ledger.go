@@ -0,0 +1,281 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/mysql/generated"
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/repository"
+	"github.com/EmilioCliff/kokomed-fin/backend/pkg"
+)
+
+var _ repository.LedgerRepository = (*LedgerRepository)(nil)
+
+type LedgerRepository struct {
+	db      *Store
+	queries generated.Querier
+}
+
+func NewLedgerRepository(db *Store) *LedgerRepository {
+	return &LedgerRepository{
+		db:      db,
+		queries: generated.New(db.db),
+	}
+}
+
+// PostJournalEntry records the entry in the transaction q belongs to so the books move together
+// with the balances they describe. An entry whose debits and credits differ is refused, one
+// with nothing to post is skipped.
+func PostJournalEntry(ctx context.Context, q generated.Querier, entry repository.JournalEntry) error {
+	totalDebit := pkg.Money(0)
+	totalCredit := pkg.Money(0)
+
+	for _, line := range entry.Lines {
+		if line.Debit < 0 || line.Credit < 0 || (line.Debit > 0 && line.Credit > 0) {
+			return pkg.Errorf(
+				pkg.INTERNAL_ERROR,
+				"invalid %s journal line on account %s",
+				entry.EntryType,
+				line.AccountCode,
+			)
+		}
+
+		totalDebit += line.Debit
+		totalCredit += line.Credit
+	}
+
+	if totalDebit != totalCredit {
+		return pkg.Errorf(
+			pkg.INTERNAL_ERROR,
+			"%s journal entry does not balance: debit %s credit %s",
+			entry.EntryType,
+			totalDebit,
+			totalCredit,
+		)
+	}
+
+	if totalDebit == 0 {
+		return nil
+	}
+
+	params := generated.CreateJournalEntryParams{
+		EntryType:   generated.JournalEntriesEntryType(entry.EntryType),
+		Description: entry.Description,
+		CreatedBy:   entry.CreatedBy,
+	}
+
+	if entry.LoanID != nil {
+		params.LoanID = sql.NullInt32{Valid: true, Int32: int32(*entry.LoanID)}
+	}
+
+	if entry.ClientID != nil {
+		params.ClientID = sql.NullInt32{Valid: true, Int32: int32(*entry.ClientID)}
+	}
+
+	if entry.NonPostedID != nil {
+		params.NonPostedID = sql.NullInt32{Valid: true, Int32: int32(*entry.NonPostedID)}
+	}
+
+	if params.CreatedBy == "" {
+		params.CreatedBy = "SYSTEM"
+	}
+
+	execResult, err := q.CreateJournalEntry(ctx, params)
+	if err != nil {
+		return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to create journal entry: %s", err.Error())
+	}
+
+	entryID, err := execResult.LastInsertId()
+	if err != nil {
+		return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to get last insert id: %s", err.Error())
+	}
+
+	for _, line := range entry.Lines {
+		if line.Debit == 0 && line.Credit == 0 {
+			continue
+		}
+
+		execResult, err := q.CreateJournalLine(ctx, generated.CreateJournalLineParams{
+			EntryID: entryID,
+			Debit:   line.Debit.String(),
+			Credit:  line.Credit.String(),
+			Code:    line.AccountCode,
+		})
+		if err != nil {
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to create journal line: %s", err.Error())
+		}
+
+		rows, err := execResult.RowsAffected()
+		if err != nil {
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to get rows affected: %s", err.Error())
+		}
+
+		if rows == 0 {
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "unknown ledger account %s", line.AccountCode)
+		}
+	}
+
+	return nil
+}
+
+func (r *LedgerRepository) ListAccounts(ctx context.Context) ([]repository.LedgerAccount, error) {
+	accounts, err := r.queries.ListLedgerAccounts(ctx)
+	if err != nil {
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "failed to list ledger accounts: %s", err.Error())
+	}
+
+	rslt := make([]repository.LedgerAccount, len(accounts))
+	for i, account := range accounts {
+		rslt[i] = repository.LedgerAccount{
+			ID:          account.ID,
+			Code:        account.Code,
+			Name:        account.Name,
+			AccountType: string(account.AccountType),
+			CreatedAt:   account.CreatedAt,
+		}
+	}
+
+	return rslt, nil
+}
+
+func (r *LedgerRepository) GetTrialBalance(
+	ctx context.Context,
+	asOf time.Time,
+) (repository.TrialBalance, error) {
+	rows, err := r.queries.GetTrialBalance(ctx, asOf)
+	if err != nil {
+		return repository.TrialBalance{}, pkg.Errorf(
+			pkg.INTERNAL_ERROR,
+			"failed to get trial balance: %s",
+			err.Error(),
+		)
+	}
+
+	rslt := repository.TrialBalance{
+		AsOf:     asOf,
+		Accounts: make([]repository.TrialBalanceAccount, len(rows)),
+	}
+
+	for i, row := range rows {
+		account := repository.TrialBalanceAccount{
+			Code:        row.Code,
+			Name:        row.Name,
+			AccountType: string(row.AccountType),
+			Debit:       pkg.InterfaceMoney(row.TotalDebit),
+			Credit:      pkg.InterfaceMoney(row.TotalCredit),
+		}
+
+		// a trial balance lists each account's net balance on its normal side
+		if net := account.Debit - account.Credit; net >= 0 {
+			account.Debit, account.Credit = net, 0
+		} else {
+			account.Debit, account.Credit = 0, -net
+		}
+
+		account.Balance = account.Credit - account.Debit
+		if repository.DebitNormal(account.AccountType) {
+			account.Balance = account.Debit - account.Credit
+		}
+
+		rslt.Accounts[i] = account
+		rslt.TotalDebit += account.Debit
+		rslt.TotalCredit += account.Credit
+	}
+
+	rslt.Balanced = rslt.TotalDebit == rslt.TotalCredit
+
+	return rslt, nil
+}
+
+func (r *LedgerRepository) GetAccountLedger(
+	ctx context.Context,
+	code string,
+	from, to time.Time,
+	pgData *pkg.PaginationMetadata,
+) ([]repository.AccountLedgerEntry, pkg.PaginationMetadata, error) {
+	account, err := r.queries.GetLedgerAccountByCode(ctx, code)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, pkg.PaginationMetadata{}, pkg.Errorf(
+				pkg.NOT_FOUND_ERROR,
+				"ledger account %s not found",
+				code,
+			)
+		}
+
+		return nil, pkg.PaginationMetadata{}, pkg.Errorf(
+			pkg.INTERNAL_ERROR,
+			"failed to get ledger account: %s",
+			err.Error(),
+		)
+	}
+
+	lines, err := r.queries.ListAccountLedger(ctx, generated.ListAccountLedgerParams{
+		Code:     code,
+		FromDate: from,
+		ToDate:   to,
+		Limit:    int32(pgData.PageSize),
+		Offset:   pkg.CalculateOffset(pgData.CurrentPage, pgData.PageSize),
+	})
+	if err != nil {
+		return nil, pkg.PaginationMetadata{}, pkg.Errorf(
+			pkg.INTERNAL_ERROR,
+			"failed to list account ledger: %s",
+			err.Error(),
+		)
+	}
+
+	totalLines, err := r.queries.CountAccountLedger(ctx, generated.CountAccountLedgerParams{
+		Code:     code,
+		FromDate: from,
+		ToDate:   to,
+	})
+	if err != nil {
+		return nil, pkg.PaginationMetadata{}, pkg.Errorf(
+			pkg.INTERNAL_ERROR,
+			"failed to count account ledger: %s",
+			err.Error(),
+		)
+	}
+
+	debitNormal := repository.DebitNormal(string(account.AccountType))
+
+	rslt := make([]repository.AccountLedgerEntry, len(lines))
+	for i, line := range lines {
+		// the running balance is summed as debit minus credit from the first entry
+		balance := pkg.InterfaceMoney(line.RunningBalance)
+		if !debitNormal {
+			balance = -balance
+		}
+
+		rslt[i] = repository.AccountLedgerEntry{
+			EntryID:     line.EntryID,
+			EntryType:   string(line.EntryType),
+			Description: line.Description,
+			CreatedBy:   line.CreatedBy,
+			Debit:       pkg.MoneyFromFloat(line.Debit),
+			Credit:      pkg.MoneyFromFloat(line.Credit),
+			Balance:     balance,
+			CreatedAt:   line.CreatedAt,
+		}
+
+		if line.LoanID.Valid {
+			rslt[i].LoanID = pkg.Uint32Ptr(uint32(line.LoanID.Int32))
+		}
+
+		if line.ClientID.Valid {
+			rslt[i].ClientID = pkg.Uint32Ptr(uint32(line.ClientID.Int32))
+		}
+
+		if line.NonPostedID.Valid {
+			rslt[i].NonPostedID = pkg.Uint32Ptr(uint32(line.NonPostedID.Int32))
+		}
+	}
+
+	return rslt, pkg.CreatePaginationMetadata(
+		uint32(totalLines),
+		pgData.PageSize,
+		pgData.CurrentPage,
+	), nil
+}
@@ -0,0 +1,150 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/mysql/generated"
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/repository"
+	"github.com/EmilioCliff/kokomed-fin/backend/pkg"
+)
+
+// GetClientPayableLoan is the loan a client's payments go to. A defaulted loan is paid before a
+// newer active one.
+func (r *LoanRepository) GetClientPayableLoan(
+	ctx context.Context,
+	clientID uint32,
+) (uint32, error) {
+	loanID, err := r.queries.GetClientPayableLoan(ctx, clientID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, nil
+		}
+
+		return 0, pkg.Errorf(
+			pkg.INTERNAL_ERROR,
+			"failed to get client payable loan: %s",
+			err.Error(),
+		)
+	}
+
+	return loanID, nil
+}
+
+// MarkDefaultedLoans moves active loans to DEFAULTED once their due date plus the product's grace
+// days, or defaultGraceDays for products without a threshold, is before asOf and installments
+// are still unpaid.
+func (r *LoanRepository) MarkDefaultedLoans(
+	ctx context.Context,
+	asOf time.Time,
+	defaultGraceDays uint32,
+) ([]repository.DefaultedLoan, error) {
+	loans, err := r.queries.ListLoansPastDefaultThreshold(
+		ctx,
+		generated.ListLoansPastDefaultThresholdParams{
+			DefaultGraceDays: int32(defaultGraceDays),
+			AsOf:             businessDay(asOf),
+		},
+	)
+	if err != nil {
+		return nil, pkg.Errorf(
+			pkg.INTERNAL_ERROR,
+			"failed to list loans past default threshold: %s",
+			err.Error(),
+		)
+	}
+
+	defaulted := []repository.DefaultedLoan{}
+
+	for _, loan := range loans {
+		execResult, err := r.queries.MarkLoanDefaulted(ctx, loan.ID)
+		if err != nil {
+			return defaulted, pkg.Errorf(
+				pkg.INTERNAL_ERROR,
+				"failed to mark loan %d defaulted: %s",
+				loan.ID,
+				err.Error(),
+			)
+		}
+
+		// a payment may have completed the loan since it was listed
+		if rows, err := execResult.RowsAffected(); err != nil || rows == 0 {
+			continue
+		}
+
+		defaulted = append(defaulted, repository.DefaultedLoan{
+			LoanID:       loan.ID,
+			ClientID:     loan.ClientID,
+			ClientName:   loan.ClientName,
+			LoanOfficer:  loan.LoanOfficer,
+			OfficerName:  loan.OfficerName,
+			OfficerEmail: loan.OfficerEmail,
+			DueDate:      loan.DueDate.Time,
+			Arrears:      pkg.InterfaceMoney(loan.Arrears),
+		})
+	}
+
+	return defaulted, nil
+}
+
+// RestoreDefaultedLoan moves a defaulted loan back to ACTIVE once none of its installments is
+// overdue. A loan paid off completely is completed by the payment instead.
+func RestoreDefaultedLoan(ctx context.Context, q generated.Querier, loanID uint32) error {
+	status, err := q.GetLoanStatus(ctx, loanID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return pkg.Errorf(pkg.NOT_FOUND_ERROR, "no loan found")
+		}
+
+		return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to get loan status: %s", err.Error())
+	}
+
+	if status != generated.LoansStatusDEFAULTED {
+		return nil
+	}
+
+	overdue, err := q.CountOverdueInstallments(ctx, generated.CountOverdueInstallmentsParams{
+		LoanID: loanID,
+		AsOf:   businessDay(time.Now()),
+	})
+	if err != nil {
+		return pkg.Errorf(
+			pkg.INTERNAL_ERROR,
+			"failed to count overdue installments: %s",
+			err.Error(),
+		)
+	}
+
+	if overdue > 0 {
+		return nil
+	}
+
+	clientID, err := q.GetLoanClientID(ctx, loanID)
+	if err != nil {
+		return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to get loan client: %s", err.Error())
+	}
+
+	// the client took another loan meanwhile, only one can be active
+	hasActiveLoan, err := q.CheckActiveLoanForClient(ctx, clientID)
+	if err != nil {
+		return pkg.Errorf(
+			pkg.INTERNAL_ERROR,
+			"failed to check if client has an active loan: %s",
+			err.Error(),
+		)
+	}
+
+	if hasActiveLoan {
+		return nil
+	}
+
+	if _, err := q.UpdateLoanStatus(ctx, generated.UpdateLoanStatusParams{
+		ID:     loanID,
+		Status: generated.LoansStatusACTIVE,
+	}); err != nil {
+		return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to change loans status: %s", err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,195 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/mysql/generated"
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/repository"
+	"github.com/EmilioCliff/kokomed-fin/backend/pkg"
+)
+
+// AccrueLoanPenalties charges every overdue installment whose product has a penalty rule for the
+// days or weeks it has been overdue since it was last charged. A run charges an installment at
+// most once per day, so running it again the same day changes nothing.
+func (r *LoanRepository) AccrueLoanPenalties(
+	ctx context.Context,
+	asOf time.Time,
+) (repository.PenaltyAccrual, error) {
+	today := businessDay(asOf)
+
+	installments, err := r.queries.ListInstallmentsForPenaltyAccrual(ctx, today)
+	if err != nil {
+		return repository.PenaltyAccrual{}, pkg.Errorf(
+			pkg.INTERNAL_ERROR,
+			"failed to list installments for penalty accrual: %s",
+			err.Error(),
+		)
+	}
+
+	accrual := repository.PenaltyAccrual{}
+
+	for _, installment := range installments {
+		periods, amount := penaltyDue(installment, today)
+		if periods == 0 || amount <= 0 {
+			continue
+		}
+
+		charged := false
+
+		err := r.db.ExecTx(ctx, func(q generated.Querier) error {
+			var err error
+			charged, err = accruePenalty(ctx, q, installment, today, periods, amount)
+
+			return err
+		})
+		if err != nil {
+			return accrual, err
+		}
+
+		if !charged {
+			continue
+		}
+
+		accrual.Installments++
+		accrual.Amount += amount
+	}
+
+	return accrual, nil
+}
+
+// penaltyDue is the periods the installment has not been charged for yet and what they cost,
+// kept within the rule's cap.
+func penaltyDue(
+	installment generated.ListInstallmentsForPenaltyAccrualRow,
+	today time.Time,
+) (uint32, pkg.Money) {
+	daysOverdue := int64(today.Sub(installment.DueDate).Hours() / 24)
+
+	overduePeriods := daysOverdue
+	if installment.Frequency == generated.ProductPenaltyRulesFrequencyWEEKLY {
+		overduePeriods = daysOverdue / 7
+	}
+
+	periods := overduePeriods - installment.ChargedPeriods
+	if periods <= 0 {
+		return 0, 0
+	}
+
+	charge := pkg.MoneyFromFloat(installment.Rate)
+	if installment.PenaltyType == generated.ProductPenaltyRulesPenaltyTypePERCENTAGE {
+		charge = pkg.MoneyFromFloat(installment.RemainingAmount).Share(
+			pkg.MoneyFromFloat(installment.Rate),
+			pkg.MoneyFromFloat(100),
+		)
+	}
+
+	amount := charge * pkg.Money(periods)
+
+	if installment.CapAmount.Valid {
+		charged := pkg.InterfaceMoney(installment.ChargedAmount)
+		amount = pkg.MinMoney(amount, pkg.MoneyFromFloat(installment.CapAmount.Float64)-charged)
+	}
+
+	return uint32(periods), amount
+}
+
+func accruePenalty(
+	ctx context.Context,
+	q generated.Querier,
+	installment generated.ListInstallmentsForPenaltyAccrualRow,
+	today time.Time,
+	periods uint32,
+	amount pkg.Money,
+) (bool, error) {
+	// a payment on the loan waits for the charge instead of settling the installment around it
+	if err := LockLoanForPayment(ctx, q, installment.ClientID, installment.LoanID); err != nil {
+		return false, err
+	}
+
+	// the installment may have been paid since it was listed
+	current, err := q.GetInstallment(ctx, installment.InstallmentID)
+	if err != nil {
+		return false, pkg.Errorf(pkg.INTERNAL_ERROR, "failed to get installment: %s", err.Error())
+	}
+
+	if current.Paid {
+		return false, nil
+	}
+
+	if _, err := q.CreateInstallmentPenalty(ctx, generated.CreateInstallmentPenaltyParams{
+		LoanID:        installment.LoanID,
+		InstallmentID: installment.InstallmentID,
+		AccruedOn:     today,
+		Periods:       periods,
+		Amount:        amount.Float64(),
+	}); err != nil {
+		// another run charged the installment today
+		if isDuplicateEntry(err) {
+			return false, nil
+		}
+
+		return false, pkg.Errorf(
+			pkg.INTERNAL_ERROR,
+			"failed to create installment penalty: %s",
+			err.Error(),
+		)
+	}
+
+	return true, PostJournalEntry(ctx, q, repository.JournalEntry{
+		EntryType: repository.JournalPenaltyAccrued,
+		Description: fmt.Sprintf(
+			"PENALTY ACCRUED: installment %d overdue since %s",
+			installment.InstallmentID,
+			installment.DueDate.Format("2006-01-02"),
+		),
+		LoanID:    &installment.LoanID,
+		ClientID:  &installment.ClientID,
+		CreatedBy: "SYSTEM",
+		Lines: repository.Transfer(
+			repository.AccountPenaltiesReceivable,
+			repository.AccountPenaltyIncome,
+			amount,
+		),
+	})
+}
+
+func (r *LoanRepository) listLoanPenalties(
+	ctx context.Context,
+	loanID uint32,
+) ([]repository.Penalty, pkg.Money, error) {
+	penalties, err := r.queries.ListPenaltiesByLoan(ctx, loanID)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, 0, pkg.Errorf(
+			pkg.INTERNAL_ERROR,
+			"failed to get loan penalties: %s",
+			err.Error(),
+		)
+	}
+
+	rslt := make([]repository.Penalty, len(penalties))
+	balance := pkg.Money(0)
+
+	for i, penalty := range penalties {
+		rslt[i] = repository.Penalty{
+			ID:              penalty.ID,
+			LoanID:          penalty.LoanID,
+			InstallmentID:   penalty.InstallmentID,
+			AccruedOn:       penalty.AccruedOn.Format("2006-01-02"),
+			Periods:         penalty.Periods,
+			Amount:          pkg.MoneyFromFloat(penalty.Amount),
+			RemainingAmount: pkg.MoneyFromFloat(penalty.RemainingAmount),
+			Paid:            penalty.Paid,
+		}
+
+		if penalty.PaidAt.Valid {
+			rslt[i].PaidAt = penalty.PaidAt.Time.Format("2006-01-02")
+		}
+
+		balance += rslt[i].RemainingAmount
+	}
+
+	return rslt, balance, nil
+}
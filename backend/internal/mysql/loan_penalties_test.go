@@ -0,0 +1,304 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/mysql/generated"
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/mysql/mockdb"
+	"github.com/EmilioCliff/kokomed-fin/backend/pkg"
+	mysqldriver "github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var penaltyToday = time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+
+func penaltyInstallment(
+	frequency generated.ProductPenaltyRulesFrequency,
+	penaltyType generated.ProductPenaltyRulesPenaltyType,
+	rate float64,
+	daysOverdue int,
+) generated.ListInstallmentsForPenaltyAccrualRow {
+	return generated.ListInstallmentsForPenaltyAccrualRow{
+		InstallmentID:   7,
+		LoanID:          3,
+		ClientID:        2,
+		DueDate:         penaltyToday.AddDate(0, 0, -daysOverdue),
+		RemainingAmount: 1300,
+		PenaltyType:     penaltyType,
+		Rate:            rate,
+		Frequency:       frequency,
+		ChargedAmount:   []byte("0.00"),
+	}
+}
+
+func TestPenaltyDue(t *testing.T) {
+	daily := generated.ProductPenaltyRulesFrequencyDAILY
+	weekly := generated.ProductPenaltyRulesFrequencyWEEKLY
+	flat := generated.ProductPenaltyRulesPenaltyTypeFLAT
+	percentage := generated.ProductPenaltyRulesPenaltyTypePERCENTAGE
+
+	tests := []struct {
+		name        string
+		installment func() generated.ListInstallmentsForPenaltyAccrualRow
+		wantPeriods uint32
+		wantAmount  pkg.Money
+	}{
+		{
+			name: "daily flat",
+			installment: func() generated.ListInstallmentsForPenaltyAccrualRow {
+				return penaltyInstallment(daily, flat, 50, 3)
+			},
+			wantPeriods: 3,
+			wantAmount:  pkg.MoneyFromFloat(150),
+		},
+		{
+			name: "daily charges only the days not charged yet",
+			installment: func() generated.ListInstallmentsForPenaltyAccrualRow {
+				installment := penaltyInstallment(daily, flat, 50, 3)
+				installment.ChargedPeriods = 2
+				installment.ChargedAmount = []byte("100.00")
+
+				return installment
+			},
+			wantPeriods: 1,
+			wantAmount:  pkg.MoneyFromFloat(50),
+		},
+		{
+			name: "charged once today",
+			installment: func() generated.ListInstallmentsForPenaltyAccrualRow {
+				installment := penaltyInstallment(daily, flat, 50, 3)
+				installment.ChargedPeriods = 3
+				installment.ChargedAmount = []byte("150.00")
+
+				return installment
+			},
+			wantPeriods: 0,
+			wantAmount:  0,
+		},
+		{
+			name: "daily percentage of the remaining amount",
+			installment: func() generated.ListInstallmentsForPenaltyAccrualRow {
+				return penaltyInstallment(daily, percentage, 5, 2)
+			},
+			wantPeriods: 2,
+			wantAmount:  pkg.MoneyFromFloat(130),
+		},
+		{
+			name: "weekly before a full week",
+			installment: func() generated.ListInstallmentsForPenaltyAccrualRow {
+				return penaltyInstallment(weekly, flat, 100, 6)
+			},
+			wantPeriods: 0,
+			wantAmount:  0,
+		},
+		{
+			name: "weekly counts whole weeks",
+			installment: func() generated.ListInstallmentsForPenaltyAccrualRow {
+				return penaltyInstallment(weekly, flat, 100, 13)
+			},
+			wantPeriods: 1,
+			wantAmount:  pkg.MoneyFromFloat(100),
+		},
+		{
+			name: "weekly charged week is not charged again",
+			installment: func() generated.ListInstallmentsForPenaltyAccrualRow {
+				installment := penaltyInstallment(weekly, flat, 100, 13)
+				installment.ChargedPeriods = 1
+				installment.ChargedAmount = []byte("100.00")
+
+				return installment
+			},
+			wantPeriods: 0,
+			wantAmount:  0,
+		},
+		{
+			name: "weekly second week",
+			installment: func() generated.ListInstallmentsForPenaltyAccrualRow {
+				installment := penaltyInstallment(weekly, flat, 100, 14)
+				installment.ChargedPeriods = 1
+				installment.ChargedAmount = []byte("100.00")
+
+				return installment
+			},
+			wantPeriods: 1,
+			wantAmount:  pkg.MoneyFromFloat(100),
+		},
+		{
+			name: "cap limits the charge",
+			installment: func() generated.ListInstallmentsForPenaltyAccrualRow {
+				installment := penaltyInstallment(daily, flat, 50, 10)
+				installment.CapAmount = sql.NullFloat64{Float64: 300, Valid: true}
+
+				return installment
+			},
+			wantPeriods: 10,
+			wantAmount:  pkg.MoneyFromFloat(300),
+		},
+		{
+			name: "cap counts what was charged before",
+			installment: func() generated.ListInstallmentsForPenaltyAccrualRow {
+				installment := penaltyInstallment(daily, flat, 50, 10)
+				installment.CapAmount = sql.NullFloat64{Float64: 300, Valid: true}
+				installment.ChargedPeriods = 5
+				installment.ChargedAmount = []byte("250.00")
+
+				return installment
+			},
+			wantPeriods: 5,
+			wantAmount:  pkg.MoneyFromFloat(50),
+		},
+		{
+			name: "cap reached",
+			installment: func() generated.ListInstallmentsForPenaltyAccrualRow {
+				installment := penaltyInstallment(daily, flat, 50, 10)
+				installment.CapAmount = sql.NullFloat64{Float64: 300, Valid: true}
+				installment.ChargedPeriods = 6
+				installment.ChargedAmount = []byte("300.00")
+
+				return installment
+			},
+			wantPeriods: 4,
+			wantAmount:  0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			periods, amount := penaltyDue(tc.installment(), penaltyToday)
+
+			require.Equal(t, tc.wantPeriods, periods)
+			require.Equal(t, tc.wantAmount, amount)
+		})
+	}
+}
+
+func TestAccruePenalty(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	installment := penaltyInstallment(
+		generated.ProductPenaltyRulesFrequencyDAILY,
+		generated.ProductPenaltyRulesPenaltyTypeFLAT,
+		50,
+		3,
+	)
+
+	lockLoan := func(mockQueries *mockdb.MockQuerier) {
+		mockQueries.EXPECT().LockClient(gomock.Any(), installment.ClientID).Times(1).Return(installment.ClientID, nil)
+		mockQueries.EXPECT().LockLoan(gomock.Any(), installment.LoanID).Times(1).Return(installment.LoanID, nil)
+		mockQueries.EXPECT().LockLoanInstallments(gomock.Any(), installment.LoanID).Times(1).Return(nil, nil)
+	}
+
+	tests := []struct {
+		name        string
+		buildStubs  func(mockQueries *mockdb.MockQuerier)
+		wantCharged bool
+		wantErr     bool
+		err         error
+	}{
+		{
+			name: "OK",
+			buildStubs: func(mockQueries *mockdb.MockQuerier) {
+				lockLoan(mockQueries)
+				mockQueries.EXPECT().
+					GetInstallment(gomock.Any(), installment.InstallmentID).
+					Times(1).
+					Return(generated.Installment{ID: installment.InstallmentID}, nil)
+				mockQueries.EXPECT().
+					CreateInstallmentPenalty(gomock.Any(), generated.CreateInstallmentPenaltyParams{
+						LoanID:        installment.LoanID,
+						InstallmentID: installment.InstallmentID,
+						AccruedOn:     penaltyToday,
+						Periods:       3,
+						Amount:        150,
+					}).
+					Times(1).
+					Return(&mockSQLResult{lastInsertID: 1, rowsAffected: 1}, nil)
+				mockQueries.EXPECT().
+					CreateJournalEntry(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(&mockSQLResult{lastInsertID: 1, rowsAffected: 1}, nil)
+				mockQueries.EXPECT().
+					CreateJournalLine(gomock.Any(), gomock.Any()).
+					Times(2).
+					Return(&mockSQLResult{rowsAffected: 1}, nil)
+			},
+			wantCharged: true,
+		},
+		{
+			name: "Installment Paid",
+			buildStubs: func(mockQueries *mockdb.MockQuerier) {
+				lockLoan(mockQueries)
+				mockQueries.EXPECT().
+					GetInstallment(gomock.Any(), installment.InstallmentID).
+					Times(1).
+					Return(generated.Installment{ID: installment.InstallmentID, Paid: true}, nil)
+				mockQueries.EXPECT().CreateInstallmentPenalty(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantCharged: false,
+		},
+		{
+			name: "Already Charged Today",
+			buildStubs: func(mockQueries *mockdb.MockQuerier) {
+				lockLoan(mockQueries)
+				mockQueries.EXPECT().
+					GetInstallment(gomock.Any(), installment.InstallmentID).
+					Times(1).
+					Return(generated.Installment{ID: installment.InstallmentID}, nil)
+				mockQueries.EXPECT().
+					CreateInstallmentPenalty(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, &mysqldriver.MySQLError{Number: errDuplicateEntry})
+				mockQueries.EXPECT().CreateJournalEntry(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantCharged: false,
+		},
+		{
+			name: "Internal Error",
+			buildStubs: func(mockQueries *mockdb.MockQuerier) {
+				lockLoan(mockQueries)
+				mockQueries.EXPECT().
+					GetInstallment(gomock.Any(), installment.InstallmentID).
+					Times(1).
+					Return(generated.Installment{ID: installment.InstallmentID}, nil)
+				mockQueries.EXPECT().
+					CreateInstallmentPenalty(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, errors.New("internal error"))
+			},
+			wantErr: true,
+			err:     errors.New(pkg.INTERNAL_ERROR),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockQueries := mockdb.NewMockQuerier(ctrl)
+			tc.buildStubs(mockQueries)
+
+			charged, err := accruePenalty(
+				context.Background(),
+				mockQueries,
+				installment,
+				penaltyToday,
+				3,
+				pkg.MoneyFromFloat(150),
+			)
+
+			if tc.wantErr {
+				require.Error(t, err)
+				require.EqualError(t, errors.New(pkg.ErrorCode(err)), tc.err.Error())
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.wantCharged, charged)
+		})
+	}
+}
@@ -0,0 +1,216 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/mysql/generated"
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/repository"
+	"github.com/EmilioCliff/kokomed-fin/backend/pkg"
+)
+
+// RestructureLoan closes an active loan's unpaid installments and spreads what is left on them
+// over a new schedule starting today. The closed installments keep what was owed on them so the
+// original schedule can still be seen. Penalties already charged stay on the loan.
+func (r *LoanRepository) RestructureLoan(
+	ctx context.Context,
+	restructure *repository.RestructureLoan,
+) (repository.LoanRestructure, error) {
+	if restructure.TotalInstallments == 0 || restructure.InstallmentsPeriod == 0 {
+		return repository.LoanRestructure{}, pkg.Errorf(
+			pkg.INVALID_ERROR,
+			"installments and installments period must be more than 0",
+		)
+	}
+
+	loan, err := r.queries.GetLoan(ctx, restructure.LoanID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return repository.LoanRestructure{}, pkg.Errorf(pkg.NOT_FOUND_ERROR, "loan not found")
+		}
+
+		return repository.LoanRestructure{}, pkg.Errorf(
+			pkg.INTERNAL_ERROR,
+			"failed to get loan: %s",
+			err.Error(),
+		)
+	}
+
+	var restructureID uint32
+
+	err = r.db.ExecTx(ctx, func(q generated.Querier) error {
+		// a payment on the loan either lands before the schedule is replaced or on the new one
+		if err := LockLoanForPayment(ctx, q, loan.ClientID, loan.ID); err != nil {
+			return err
+		}
+
+		current, err := q.GetLoan(ctx, loan.ID)
+		if err != nil {
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to get loan: %s", err.Error())
+		}
+
+		if current.Status != generated.LoansStatusACTIVE {
+			return pkg.Errorf(pkg.INVALID_ERROR, "only active loans can be restructured")
+		}
+
+		unpaid, err := q.ListUnpaidInstallmentsByLoan(ctx, loan.ID)
+		if err != nil {
+			return pkg.Errorf(
+				pkg.INTERNAL_ERROR,
+				"failed to list unpaid installments: %s",
+				err.Error(),
+			)
+		}
+
+		outstanding := pkg.Money(0)
+		for _, installment := range unpaid {
+			outstanding += pkg.MoneyFromFloat(installment.RemainingAmount)
+		}
+
+		if outstanding <= 0 {
+			return pkg.Errorf(pkg.INVALID_ERROR, "loan has no unpaid installments to restructure")
+		}
+
+		counts, err := q.GetLoanInstallmentCounts(ctx, loan.ID)
+		if err != nil {
+			return pkg.Errorf(
+				pkg.INTERNAL_ERROR,
+				"failed to count loan installments: %s",
+				err.Error(),
+			)
+		}
+
+		execResult, err := q.CreateLoanRestructure(ctx, generated.CreateLoanRestructureParams{
+			LoanID:                     loan.ID,
+			OutstandingAmount:          outstanding.Float64(),
+			PreviousTotalInstallments:  current.TotalInstallments,
+			PreviousInstallmentsPeriod: current.InstallmentsPeriod,
+			PreviousDueDate:            current.DueDate,
+			TotalInstallments:          restructure.TotalInstallments,
+			InstallmentsPeriod:         restructure.InstallmentsPeriod,
+			Reason:                     restructure.Reason,
+			ApprovedBy:                 restructure.ApprovedBy,
+		})
+		if err != nil {
+			return pkg.Errorf(
+				pkg.INTERNAL_ERROR,
+				"failed to create loan restructure: %s",
+				err.Error(),
+			)
+		}
+
+		id, err := execResult.LastInsertId()
+		if err != nil {
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to get last insert id: %s", err.Error())
+		}
+
+		restructureID = uint32(id)
+
+		if _, err := q.CloseUnpaidInstallments(ctx, generated.CloseUnpaidInstallmentsParams{
+			RestructureID: sql.NullInt32{
+				Valid: true,
+				Int32: int32(restructureID),
+			},
+			LoanID: loan.ID,
+		}); err != nil {
+			return pkg.Errorf(
+				pkg.INTERNAL_ERROR,
+				"failed to close unpaid installments: %s",
+				err.Error(),
+			)
+		}
+
+		dueDate, err := helperCreateSchedule(
+			ctx,
+			q,
+			loan.ID,
+			outstanding,
+			businessDay(time.Now()),
+			uint32(counts.LastInstallmentNumber)+1,
+			restructure.TotalInstallments,
+			restructure.InstallmentsPeriod,
+		)
+		if err != nil {
+			return err
+		}
+
+		if _, err := q.UpdateLoanSchedule(ctx, generated.UpdateLoanScheduleParams{
+			ID:                 loan.ID,
+			TotalInstallments:  uint32(counts.PaidInstallments) + restructure.TotalInstallments,
+			InstallmentsPeriod: restructure.InstallmentsPeriod,
+			DueDate: sql.NullTime{
+				Valid: true,
+				Time:  dueDate,
+			},
+			UpdatedBy: sql.NullInt32{
+				Valid: true,
+				Int32: int32(restructure.ApprovedBy),
+			},
+		}); err != nil {
+			return pkg.Errorf(
+				pkg.INTERNAL_ERROR,
+				"failed to update loan schedule: %s",
+				err.Error(),
+			)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return repository.LoanRestructure{}, err
+	}
+
+	restructures, err := r.listLoanRestructures(ctx, loan.ID)
+	if err != nil {
+		return repository.LoanRestructure{}, err
+	}
+
+	for _, rslt := range restructures {
+		if rslt.ID == restructureID {
+			return rslt, nil
+		}
+	}
+
+	return repository.LoanRestructure{}, pkg.Errorf(
+		pkg.INTERNAL_ERROR,
+		"failed to get created loan restructure",
+	)
+}
+
+func (r *LoanRepository) listLoanRestructures(
+	ctx context.Context,
+	loanID uint32,
+) ([]repository.LoanRestructure, error) {
+	restructures, err := r.queries.ListLoanRestructures(ctx, loanID)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, pkg.Errorf(
+			pkg.INTERNAL_ERROR,
+			"failed to get loan restructures: %s",
+			err.Error(),
+		)
+	}
+
+	rslt := make([]repository.LoanRestructure, len(restructures))
+
+	for i, restructure := range restructures {
+		rslt[i] = repository.LoanRestructure{
+			ID:                         restructure.ID,
+			LoanID:                     restructure.LoanID,
+			OutstandingAmount:          pkg.MoneyFromFloat(restructure.OutstandingAmount),
+			PreviousTotalInstallments:  restructure.PreviousTotalInstallments,
+			PreviousInstallmentsPeriod: restructure.PreviousInstallmentsPeriod,
+			TotalInstallments:          restructure.TotalInstallments,
+			InstallmentsPeriod:         restructure.InstallmentsPeriod,
+			Reason:                     restructure.Reason,
+			ApprovedBy:                 restructure.ApprovedBy,
+			CreatedAt:                  restructure.CreatedAt,
+		}
+
+		if restructure.PreviousDueDate.Valid {
+			rslt[i].PreviousDueDate = restructure.PreviousDueDate.Time.Format("2006-01-02")
+		}
+	}
+
+	return rslt, nil
+}
@@ -0,0 +1,217 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/mysql/generated"
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/repository"
+	"github.com/EmilioCliff/kokomed-fin/backend/pkg"
+)
+
+// TopUpLoan disburses a new loan to the client of an active loan and settles what is left on
+// the old loan, penalties included, out of the new principal. The settlement is an internal
+// payment the loan payer allocates to the old loan like any other payment, which completes it.
+// Only the rest of the principal is paid out.
+func (r *LoanRepository) TopUpLoan(
+	ctx context.Context,
+	topUp *repository.TopUpLoan,
+) (repository.LoanTopUp, error) {
+	newLoan := &topUp.NewLoan
+	if newLoan.DueDate == nil || newLoan.DisbursedBy == nil || newLoan.DisbursedOn == nil {
+		return repository.LoanTopUp{}, pkg.Errorf(
+			pkg.INVALID_ERROR,
+			"a top up loan must be disbursed when it is created",
+		)
+	}
+
+	oldLoan, err := r.queries.GetLoan(ctx, topUp.LoanID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return repository.LoanTopUp{}, pkg.Errorf(pkg.NOT_FOUND_ERROR, "loan not found")
+		}
+
+		return repository.LoanTopUp{}, pkg.Errorf(
+			pkg.INTERNAL_ERROR,
+			"failed to get loan: %s",
+			err.Error(),
+		)
+	}
+
+	newLoan.ClientID = oldLoan.ClientID
+	newLoan.Status = string(generated.LoansStatusACTIVE)
+	newLoan.UseOverpayment = false
+
+	var topUpID uint32
+
+	err = r.db.ExecTx(ctx, func(q generated.Querier) error {
+		// a payment on the old loan either lands before it is settled or finds it completed
+		if err := LockLoanForPayment(ctx, q, oldLoan.ClientID, oldLoan.ID); err != nil {
+			return err
+		}
+
+		current, err := q.GetLoan(ctx, oldLoan.ID)
+		if err != nil {
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to get loan: %s", err.Error())
+		}
+
+		if current.Status != generated.LoansStatusACTIVE {
+			return pkg.Errorf(pkg.INVALID_ERROR, "only active loans can be topped up")
+		}
+
+		installments, err := q.ListUnpaidInstallmentsByLoan(ctx, oldLoan.ID)
+		if err != nil {
+			return pkg.Errorf(
+				pkg.INTERNAL_ERROR,
+				"failed to list unpaid installments: %s",
+				err.Error(),
+			)
+		}
+
+		penalties, err := q.ListUnpaidPenaltiesByLoan(ctx, oldLoan.ID)
+		if err != nil {
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to list unpaid penalties: %s", err.Error())
+		}
+
+		settled := pkg.Money(0)
+		for _, installment := range installments {
+			settled += pkg.MoneyFromFloat(installment.RemainingAmount)
+		}
+
+		penaltiesSettled := pkg.Money(0)
+		for _, penalty := range penalties {
+			penaltiesSettled += pkg.MoneyFromFloat(penalty.RemainingAmount)
+		}
+
+		product, err := q.GetProduct(ctx, newLoan.ProductID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return pkg.Errorf(pkg.NOT_FOUND_ERROR, "product not found")
+			}
+
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to get product: %s", err.Error())
+		}
+
+		loanAmount := pkg.MoneyFromFloat(product.LoanAmount)
+		outstanding := settled + penaltiesSettled
+
+		if outstanding <= 0 {
+			return pkg.Errorf(pkg.INVALID_ERROR, "loan %d has nothing left to settle", oldLoan.ID)
+		}
+
+		if loanAmount <= outstanding {
+			return pkg.Errorf(
+				pkg.INVALID_ERROR,
+				"the new loan amount %.2f must be more than the %.2f outstanding on loan %d",
+				loanAmount.Float64(),
+				outstanding.Float64(),
+				oldLoan.ID,
+			)
+		}
+
+		if err := helperCreateLoan(ctx, q, r.payer, newLoan); err != nil {
+			return err
+		}
+
+		if r.payer == nil {
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "no loan payer is set to settle the loan")
+		}
+
+		paymentID, err := r.payer.SettleRefinancedLoan(
+			ctx,
+			q,
+			oldLoan.ID,
+			oldLoan.ClientID,
+			newLoan.ID,
+			*newLoan.DisbursedBy,
+			outstanding,
+		)
+		if err != nil {
+			return err
+		}
+
+		topUpResult, err := q.CreateLoanTopUp(ctx, generated.CreateLoanTopUpParams{
+			LoanID:           newLoan.ID,
+			RefinancedLoanID: oldLoan.ID,
+			NonPostedID:      paymentID,
+			SettledAmount:    settled.Float64(),
+			PenaltiesSettled: penaltiesSettled.Float64(),
+			NetDisbursed:     (loanAmount - outstanding).Float64(),
+			CreatedBy:        *newLoan.DisbursedBy,
+		})
+		if err != nil {
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to create loan top up: %s", err.Error())
+		}
+
+		id, err := topUpResult.LastInsertId()
+		if err != nil {
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to get last insert id: %s", err.Error())
+		}
+
+		topUpID = uint32(id)
+
+		return nil
+	})
+	if err != nil {
+		return repository.LoanTopUp{}, err
+	}
+
+	topUpLoan, err := r.queries.GetLoanTopUpByLoan(ctx, newLoan.ID)
+	if err != nil {
+		return repository.LoanTopUp{}, pkg.Errorf(
+			pkg.INTERNAL_ERROR,
+			"failed to get loan top up %d: %s",
+			topUpID,
+			err.Error(),
+		)
+	}
+
+	createdLoan, err := r.queries.GetLoanFullData(ctx, newLoan.ID)
+	if err != nil {
+		return repository.LoanTopUp{}, pkg.Errorf(
+			pkg.INTERNAL_ERROR,
+			"failed to get created loan: %s",
+			err.Error(),
+		)
+	}
+
+	rslt := convertGeneratedLoanTopUp(topUpLoan)
+	loanData := convertGetLoanFullDataRowToRepo(&createdLoan)
+	rslt.Loan = &loanData
+
+	return rslt, nil
+}
+
+// getLoanTopUp returns the top-up found by get, nil when there is none.
+func (r *LoanRepository) getLoanTopUp(
+	ctx context.Context,
+	loanID uint32,
+	get func(context.Context, uint32) (generated.LoanTopUp, error),
+) (*repository.LoanTopUp, error) {
+	topUp, err := get(ctx, loanID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "failed to get loan top up: %s", err.Error())
+	}
+
+	rslt := convertGeneratedLoanTopUp(topUp)
+
+	return &rslt, nil
+}
+
+func convertGeneratedLoanTopUp(topUp generated.LoanTopUp) repository.LoanTopUp {
+	return repository.LoanTopUp{
+		ID:               topUp.ID,
+		LoanID:           topUp.LoanID,
+		RefinancedLoanID: topUp.RefinancedLoanID,
+		NonPostedID:      topUp.NonPostedID,
+		SettledAmount:    pkg.MoneyFromFloat(topUp.SettledAmount),
+		PenaltiesSettled: pkg.MoneyFromFloat(topUp.PenaltiesSettled),
+		NetDisbursed:     pkg.MoneyFromFloat(topUp.NetDisbursed),
+		CreatedBy:        topUp.CreatedBy,
+		CreatedAt:        topUp.CreatedAt,
+	}
+}
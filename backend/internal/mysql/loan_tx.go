@@ -3,6 +3,7 @@ package mysql
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"time"
 
 	"github.com/EmilioCliff/kokomed-fin/backend/internal/mysql/generated"
@@ -34,78 +35,109 @@ func (r *LoanRepository) CreateLoan(
 	}
 
 	err := r.db.ExecTx(ctx, func(q generated.Querier) error {
-		// create the loan
-		params := generated.CreateLoanParams{
-			ProductID:          loan.ProductID,
-			ClientID:           loan.ClientID,
-			LoanOfficer:        loan.LoanOfficerID,
-			ApprovedBy:         loan.ApprovedBy,
-			TotalInstallments:  loan.TotalInstallments,
-			InstallmentsPeriod: loan.InstallmentsPeriod,
-			ProcessingFee:      loan.ProcessingFee,
-			FeePaid:            loan.FeePaid,
-			CreatedBy:          loan.CreatedBy,
-			Status:             generated.LoansStatusINACTIVE,
+		return helperCreateLoan(ctx, q, r.payer, loan)
+	})
+	if err != nil {
+		return repository.LoanFullData{}, err
+	}
+
+	updateLoan, err := r.queries.GetLoanFullData(ctx, loan.ID)
+	if err != nil {
+		return repository.LoanFullData{}, pkg.Errorf(
+			pkg.INTERNAL_ERROR,
+			"failed to get created loan: %s",
+			err.Error(),
+		)
+	}
+
+	return convertGetLoanFullDataRowToRepo(&updateLoan), nil
+}
+
+// helperCreateLoan inserts the loan and, when it is disbursed already, posts its fee,
+// creates its installments and books the disbursement.
+func helperCreateLoan(
+	ctx context.Context,
+	q generated.Querier,
+	payer LoanPayer,
+	loan *repository.Loan,
+) error {
+	// create the loan
+	params := generated.CreateLoanParams{
+		ProductID:          loan.ProductID,
+		ClientID:           loan.ClientID,
+		LoanOfficer:        loan.LoanOfficerID,
+		ApprovedBy:         loan.ApprovedBy,
+		TotalInstallments:  loan.TotalInstallments,
+		InstallmentsPeriod: loan.InstallmentsPeriod,
+		ProcessingFee:      loan.ProcessingFee.Float64(),
+		FeePaid:            loan.FeePaid,
+		CreatedBy:          loan.CreatedBy,
+		Status:             generated.LoansStatusINACTIVE,
+	}
+
+	if loan.LoanPurpose != nil {
+		params.LoanPurpose = sql.NullString{
+			Valid:  true,
+			String: *loan.LoanPurpose,
 		}
+	}
 
-		if loan.LoanPurpose != nil {
-			params.LoanPurpose = sql.NullString{
-				Valid:  true,
-				String: *loan.LoanPurpose,
-			}
+	if loan.DueDate != nil && loan.DisbursedBy != nil && loan.DisbursedOn != nil {
+		params.DueDate = sql.NullTime{
+			Valid: true,
+			Time:  *loan.DueDate,
+		}
+		params.DisbursedOn = sql.NullTime{
+			Valid: true,
+			Time:  *loan.DisbursedOn,
+		}
+		params.DisbursedBy = sql.NullInt32{
+			Valid: true,
+			Int32: int32(*loan.DisbursedBy),
 		}
+		params.Status = generated.LoansStatusACTIVE
 
-		if loan.DueDate != nil && loan.DisbursedBy != nil && loan.DisbursedOn != nil {
-			params.DueDate = sql.NullTime{
-				Valid: true,
-				Time:  *loan.DueDate,
-			}
-			params.DisbursedOn = sql.NullTime{
-				Valid: true,
-				Time:  *loan.DisbursedOn,
-			}
-			params.DisbursedBy = sql.NullInt32{
-				Valid: true,
-				Int32: int32(*loan.DisbursedBy),
-			}
-			params.Status = generated.LoansStatusACTIVE
+		if err := checkUserCashBookOpen(ctx, q, *loan.DisbursedBy, *loan.DisbursedOn); err != nil {
+			return err
 		}
+	}
 
-		execResult, err := q.CreateLoan(ctx, params)
-		if err != nil {
-			return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to create loan: %s", err.Error())
+	execResult, err := q.CreateLoan(ctx, params)
+	if err != nil {
+		return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to create loan: %s", err.Error())
+	}
+
+	id, err := execResult.LastInsertId()
+	if err != nil {
+		return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to get last insert id: %s", err.Error())
+	}
+
+	loan.ID = uint32(id)
+
+	if loan.FeePaid {
+		if err := helperPostProcessingFee(ctx, q, loan.ID, loan.ClientID, loan.ProcessingFee); err != nil {
+			return err
 		}
+	}
 
-		id, err := execResult.LastInsertId()
-		if err != nil {
-			return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to get last insert id: %s", err.Error())
+	// create loan installments if loan is disbursed already(loan is ACTIVE)
+	if params.Status == generated.LoansStatusACTIVE {
+		if err := helperCreateInstallation(ctx, q, *loan.DisbursedOn, loan.ID, loan.ProductID, params.TotalInstallments, params.InstallmentsPeriod); err != nil {
+			return err
 		}
 
-		loan.ID = uint32(id)
+		if err := helperPostDisbursement(ctx, q, loan.ID, loan.ClientID, loan.ProductID, *loan.DisbursedBy); err != nil {
+			return err
+		}
 
-		// create loan installments if loan is disbursed already(loan is ACTIVE)
-		if params.Status == generated.LoansStatusACTIVE {
-			if err := helperCreateInstallation(ctx, q, *loan.DisbursedOn, loan.ID, loan.ProductID, params.TotalInstallments, params.InstallmentsPeriod); err != nil {
+		if loan.UseOverpayment {
+			if err := helperApplyOverpayment(ctx, q, payer, loan.ID, loan.ClientID, *loan.DisbursedBy); err != nil {
 				return err
 			}
 		}
-
-		return nil
-	})
-	if err != nil {
-		return repository.LoanFullData{}, err
-	}
-
-	updateLoan, err := r.queries.GetLoanFullData(ctx, loan.ID)
-	if err != nil {
-		return repository.LoanFullData{}, pkg.Errorf(
-			pkg.INTERNAL_ERROR,
-			"failed to get created loan: %s",
-			err.Error(),
-		)
 	}
 
-	return convertGetLoanFullDataRowToRepo(&updateLoan), nil
+	return nil
 }
 
 func (r *LoanRepository) DisburseLoan(
@@ -133,66 +165,149 @@ func (r *LoanRepository) DisburseLoan(
 	}
 
 	err = r.db.ExecTx(ctx, func(q generated.Querier) error {
-		params := generated.DisburseLoanParams{
-			ID: disburseLoan.ID,
-			DisbursedBy: sql.NullInt32{
-				Valid: true,
-				Int32: int32(disburseLoan.DisbursedBy),
-			},
+		// a loan being paid out over mpesa is activated by the b2c result, not by hand
+		if err := lockLoan(ctx, q, loan.ID); err != nil {
+			return err
 		}
 
-		if disburseLoan.DisbursedOn != nil {
-			params.DisbursedOn = sql.NullTime{
-				Valid: true,
-				Time:  *disburseLoan.DisbursedOn,
-			}
+		status, err := q.GetLoanStatus(ctx, loan.ID)
+		if err != nil {
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to get loan status: %s", err.Error())
+		}
 
-			dueDate := (*disburseLoan.DisbursedOn).AddDate(
-				0,
-				0,
-				int(loan.InstallmentsPeriod)*int(loan.TotalInstallments),
-			)
+		if status == generated.LoansStatusDISBURSING {
+			return pkg.Errorf(pkg.INVALID_ERROR, "loan is being disbursed over mpesa")
+		}
 
-			params.DueDate = sql.NullTime{
-				Valid: true,
-				Time:  dueDate,
-			}
+		return helperDisburseLoan(ctx, q, r.payer, loan, disburseLoan, true)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return loan.ClientID, nil
+}
+
+// DisburseB2CLoan activates a loan whose m-pesa payout has completed, in the caller's
+// transaction. The money left through m-pesa and not a till, so no cash book is checked.
+func DisburseB2CLoan(
+	ctx context.Context,
+	q generated.Querier,
+	disburseLoan *repository.DisburseLoan,
+) error {
+	generatedLoan, err := q.GetLoan(ctx, disburseLoan.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return pkg.Errorf(pkg.NOT_FOUND_ERROR, "loan not found")
 		}
 
-		if disburseLoan.Status != nil {
-			params.Status = generated.NullLoansStatus{
-				Valid:       true,
-				LoansStatus: generated.LoansStatus(*disburseLoan.Status),
+		return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to get loan: %s", err.Error())
+	}
+
+	loan := convertGeneratedLoan(generatedLoan)
+
+	hasActiveLoan, err := q.CheckActiveLoanForClient(ctx, loan.ClientID)
+	if err != nil {
+		return pkg.Errorf(
+			pkg.INTERNAL_ERROR,
+			"failed to check if client has an active loan: %s",
+			err.Error(),
+		)
+	}
+
+	if hasActiveLoan {
+		return pkg.Errorf(pkg.ALREADY_EXISTS_ERROR, "client already has an active loan")
+	}
+
+	return helperDisburseLoan(ctx, q, nil, loan, disburseLoan, false)
+}
+
+// helperDisburseLoan updates the loan's disbursement and, when it is activated, creates its
+// installments and books the disbursement. Disbursements paid from a till check the cash book.
+func helperDisburseLoan(
+	ctx context.Context,
+	q generated.Querier,
+	payer LoanPayer,
+	loan repository.Loan,
+	disburseLoan *repository.DisburseLoan,
+	checkCashBook bool,
+) error {
+	params := generated.DisburseLoanParams{
+		ID: disburseLoan.ID,
+		DisbursedBy: sql.NullInt32{
+			Valid: true,
+			Int32: int32(disburseLoan.DisbursedBy),
+		},
+	}
+
+	if disburseLoan.DisbursedOn != nil {
+		if checkCashBook {
+			if err := checkUserCashBookOpen(ctx, q, disburseLoan.DisbursedBy, *disburseLoan.DisbursedOn); err != nil {
+				return err
 			}
 		}
 
-		if disburseLoan.FeePaid != nil {
-			params.FeePaid = sql.NullBool{
-				Valid: true,
-				Bool:  true,
-			}
+		params.DisbursedOn = sql.NullTime{
+			Valid: true,
+			Time:  *disburseLoan.DisbursedOn,
 		}
 
-		_, err = r.queries.DisburseLoan(ctx, params)
-		if err != nil {
-			return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to disburse loan: %s", err.Error())
+		dueDate := (*disburseLoan.DisbursedOn).AddDate(
+			0,
+			0,
+			int(loan.InstallmentsPeriod)*int(loan.TotalInstallments),
+		)
+
+		params.DueDate = sql.NullTime{
+			Valid: true,
+			Time:  dueDate,
 		}
+	}
 
-		// here we will create installments if and only if the status was changed to active
-		if disburseLoan.Status != nil &&
-			generated.LoansStatus(*disburseLoan.Status) == generated.LoansStatusACTIVE {
-			if err = helperCreateInstallation(ctx, q, *disburseLoan.DisbursedOn, loan.ID, loan.ProductID, loan.TotalInstallments, loan.InstallmentsPeriod); err != nil {
-				return err
-			}
+	if disburseLoan.Status != nil {
+		params.Status = generated.NullLoansStatus{
+			Valid:       true,
+			LoansStatus: generated.LoansStatus(*disburseLoan.Status),
+		}
+	}
+
+	if disburseLoan.FeePaid != nil {
+		params.FeePaid = sql.NullBool{
+			Valid: true,
+			Bool:  true,
 		}
+	}
 
-		return nil
-	})
+	_, err := q.DisburseLoan(ctx, params)
 	if err != nil {
-		return 0, err
+		return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to disburse loan: %s", err.Error())
 	}
 
-	return loan.ClientID, nil
+	if disburseLoan.FeePaid != nil && *disburseLoan.FeePaid && !loan.FeePaid {
+		if err = helperPostProcessingFee(ctx, q, loan.ID, loan.ClientID, loan.ProcessingFee); err != nil {
+			return err
+		}
+	}
+
+	// here we will create installments if and only if the status was changed to active
+	if disburseLoan.Status != nil &&
+		generated.LoansStatus(*disburseLoan.Status) == generated.LoansStatusACTIVE {
+		if err = helperCreateInstallation(ctx, q, *disburseLoan.DisbursedOn, loan.ID, loan.ProductID, loan.TotalInstallments, loan.InstallmentsPeriod); err != nil {
+			return err
+		}
+
+		if err = helperPostDisbursement(ctx, q, loan.ID, loan.ClientID, loan.ProductID, disburseLoan.DisbursedBy); err != nil {
+			return err
+		}
+
+		if disburseLoan.UseOverpayment {
+			if err = helperApplyOverpayment(ctx, q, payer, loan.ID, loan.ClientID, disburseLoan.DisbursedBy); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
 }
 
 func helperCreateInstallation(
@@ -206,25 +321,115 @@ func helperCreateInstallation(
 		return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to get product repay amount: %s", err.Error())
 	}
 
-	installmentAmount := repayAmout / float64(totalInstallment)
-	firstDueDate := disbursedDate.AddDate(0, 0, int(intallmentPeriod))
+	_, err = helperCreateSchedule(
+		ctx,
+		q,
+		loanID,
+		pkg.MoneyFromFloat(repayAmout),
+		disbursedDate,
+		1,
+		totalInstallment,
+		intallmentPeriod,
+	)
+
+	return err
+}
 
-	for i := 0; i < int(totalInstallment); i++ {
+// helperCreateSchedule spreads amount over totalInstallment installments falling due every
+// intallmentPeriod days from startDate, numbered from firstNumber. It returns the last due date.
+func helperCreateSchedule(
+	ctx context.Context,
+	q generated.Querier,
+	loanID uint32,
+	amount pkg.Money,
+	startDate time.Time,
+	firstNumber, totalInstallment, intallmentPeriod uint32,
+) (time.Time, error) {
+	// the installments add up to the amount exactly, the last one takes the odd cents
+	installmentAmounts := amount.Split(int(totalInstallment))
+	firstDueDate := startDate.AddDate(0, 0, int(intallmentPeriod))
+	lastDueDate := firstDueDate
+
+	for i, installmentAmount := range installmentAmounts {
 		dueDate := firstDueDate.AddDate(0, 0, i*int(intallmentPeriod))
 
 		_, err := q.CreateInstallment(ctx, generated.CreateInstallmentParams{
 			LoanID:            loanID,
-			InstallmentNumber: uint32(i + 1),
-			AmountDue:         installmentAmount,
-			RemainingAmount:   installmentAmount,
+			InstallmentNumber: firstNumber + uint32(i),
+			AmountDue:         installmentAmount.Float64(),
+			RemainingAmount:   installmentAmount.Float64(),
 			DueDate:           dueDate,
 		})
 		if err != nil {
-			return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to create installment: %s", err.Error())
+			return time.Time{}, pkg.Errorf(
+				pkg.INTERNAL_ERROR,
+				"failed to create installment: %s",
+				err.Error(),
+			)
 		}
+
+		lastDueDate = dueDate
 	}
 
-	return nil
+	return lastDueDate, nil
+}
+
+// helperPostDisbursement books the loan: the client owes the repay amount, the principal was
+// paid out and the interest is earned on the flat rate the product charges.
+func helperPostDisbursement(
+	ctx context.Context,
+	q generated.Querier,
+	loanID, clientID, productID, disbursedBy uint32,
+) error {
+	product, err := q.GetProduct(ctx, productID)
+	if err != nil {
+		return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to get product: %s", err.Error())
+	}
+
+	repayAmount := pkg.MoneyFromFloat(product.RepayAmount)
+	loanAmount := pkg.MoneyFromFloat(product.LoanAmount)
+
+	return PostJournalEntry(ctx, q, repository.JournalEntry{
+		EntryType:   repository.JournalDisbursement,
+		Description: fmt.Sprintf("LOAN %d DISBURSED by user %d", loanID, disbursedBy),
+		LoanID:      &loanID,
+		ClientID:    &clientID,
+		Lines: []repository.JournalLine{
+			{AccountCode: repository.AccountLoansReceivable, Debit: repayAmount},
+			{AccountCode: repository.AccountCash, Credit: loanAmount},
+			{AccountCode: repository.AccountInterestIncome, Credit: repayAmount - loanAmount},
+		},
+	})
+}
+
+// helperPostProcessingFee books a processing fee once it has been paid.
+func helperPostProcessingFee(
+	ctx context.Context,
+	q generated.Querier,
+	loanID, clientID uint32,
+	fee pkg.Money,
+) error {
+	return PostJournalEntry(ctx, q, repository.JournalEntry{
+		EntryType:   repository.JournalProcessingFee,
+		Description: fmt.Sprintf("LOAN %d PROCESSING FEE PAID", loanID),
+		LoanID:      &loanID,
+		ClientID:    &clientID,
+		Lines:       repository.Transfer(repository.AccountCash, repository.AccountFeeIncome, fee),
+	})
+}
+
+// helperApplyOverpayment draws the client's overpayment down into the loan through the payer.
+func helperApplyOverpayment(
+	ctx context.Context,
+	q generated.Querier,
+	payer LoanPayer,
+	loanID, clientID, appliedBy uint32,
+) error {
+	if payer == nil {
+		return pkg.Errorf(pkg.INTERNAL_ERROR, "no loan payer is set to apply the overpayment")
+	}
+
+	return payer.ApplyOverpayment(ctx, q, loanID, clientID, appliedBy)
 }
 
 func convertGetLoanFullDataRowToRepo(loan *generated.GetLoanFullDataRow) repository.LoanFullData {
@@ -233,9 +438,9 @@ func convertGetLoanFullDataRowToRepo(loan *generated.GetLoanFullDataRow) reposit
 		Product: repository.ProductShort{
 			ID:             loan.ProductID,
 			BranchName:     loan.ProductBranchName, // You might need to join the product's branch name if required
-			LoanAmount:     loan.LoanAmount,
-			RepayAmount:    loan.RepayAmount,
-			InterestAmount: loan.InterestAmount,
+			LoanAmount:     pkg.MoneyFromFloat(loan.LoanAmount),
+			RepayAmount:    pkg.MoneyFromFloat(loan.RepayAmount),
+			InterestAmount: pkg.MoneyFromFloat(loan.InterestAmount),
 		},
 		Client: repository.ClientShort{
 			ID:          loan.ClientID,
@@ -262,10 +467,10 @@ func convertGetLoanFullDataRowToRepo(loan *generated.GetLoanFullDataRow) reposit
 		TotalInstallments:  loan.TotalInstallments,
 		InstallmentsPeriod: loan.InstallmentsPeriod,
 		Status:             string(loan.Status),
-		ProcessingFee:      loan.ProcessingFee,
+		ProcessingFee:      pkg.MoneyFromFloat(loan.ProcessingFee),
 		FeePaid:            loan.FeePaid,
-		PaidAmount:         loan.PaidAmount,
-		RemainingAmount:    loan.RepayAmount - loan.PaidAmount,
+		PaidAmount:         pkg.MoneyFromFloat(loan.PaidAmount),
+		RemainingAmount:    pkg.MoneyFromFloat(loan.RepayAmount) - pkg.MoneyFromFloat(loan.PaidAmount),
 		CreatedBy: repository.UserShortResponse{
 			ID:          loan.CreatedBy,
 			FullName:    loan.CreatedByName.String,
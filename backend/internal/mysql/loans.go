@@ -19,6 +19,29 @@ var _ repository.LoansRepository = (*LoanRepository)(nil)
 type LoanRepository struct {
 	db      *Store
 	queries generated.Querier
+	payer   LoanPayer
+}
+
+// LoanPayer pays a loan from money the client already holds with the business, in the caller's
+// transaction. It is provided by the payments service so that every payment of a loan is
+// allocated, journaled and completed the same way.
+type LoanPayer interface {
+	// ApplyOverpayment draws the client's overpayment down into the loan.
+	ApplyOverpayment(ctx context.Context, q generated.Querier, loanID, clientID, appliedBy uint32) error
+	// SettleRefinancedLoan pays off a loan being topped up with amount kept back from the new
+	// loan's principal and returns the settlement payment.
+	SettleRefinancedLoan(
+		ctx context.Context,
+		q generated.Querier,
+		loanID, clientID, newLoanID, settledBy uint32,
+		amount pkg.Money,
+	) (uint32, error)
+}
+
+// SetLoanPayer sets the payer used when a loan is disbursed against the client's overpayment
+// or settled by a top up.
+func (r *LoanRepository) SetLoanPayer(payer LoanPayer) {
+	r.payer = payer
 }
 
 func NewLoanRepository(db *Store) *LoanRepository {
@@ -178,12 +201,12 @@ func (r *LoanRepository) GetLoan(ctx context.Context, id uint32) (repository.Loa
 
 	rslt := repository.LoanShort{
 		ID:          loan.ID,
-		LoanAmount:  loan.LoanAmount,
+		LoanAmount:  pkg.MoneyFromFloat(loan.LoanAmount),
 		Status:      string(loan.Status),
-		RepayAmount: loan.RepayAmount,
+		RepayAmount: pkg.MoneyFromFloat(loan.RepayAmount),
 		DisbursedOn: loan.DisbursedOn.Time.Format("2006-01-02"),
 		DueDate:     loan.DueDate.Time.Format("2006-01-02"),
-		PaidAmount:  loan.PaidAmount,
+		PaidAmount:  pkg.MoneyFromFloat(loan.PaidAmount),
 	}
 
 	client, err := r.queries.GetClientWithBranchName(ctx, loan.ClientID)
@@ -200,7 +223,7 @@ func (r *LoanRepository) GetLoan(ctx context.Context, id uint32) (repository.Loa
 		FullName:    client.FullName,
 		PhoneNumber: client.PhoneNumber,
 		BranchName:  client.BranchName,
-		Overpayment: client.Overpayment,
+		Overpayment: pkg.MoneyFromFloat(client.Overpayment),
 		Active:      client.Active,
 	}
 
@@ -284,7 +307,7 @@ func (r *LoanRepository) GetLoan(ctx context.Context, id uint32) (repository.Loa
 				NonPostedID:   allocation.NonPostedID,
 				LoanID:        nil,
 				InstallmentID: nil,
-				Amount:        allocation.Amount,
+				Amount:        pkg.MoneyFromFloat(allocation.Amount),
 				Description:   allocation.Description,
 				CreatedAt:     allocation.CreatedAt,
 			}
@@ -299,6 +322,10 @@ func (r *LoanRepository) GetLoan(ctx context.Context, id uint32) (repository.Loa
 				p.InstallmentID = &value
 			}
 
+			if allocation.PenaltyID.Valid {
+				p.PenaltyID = pkg.Uint32Ptr(uint32(allocation.PenaltyID.Int32))
+			}
+
 			if allocation.DeletedAt.Valid {
 				p.DeletedAt = &allocation.DeletedAt.Time
 			}
@@ -310,7 +337,7 @@ func (r *LoanRepository) GetLoan(ctx context.Context, id uint32) (repository.Loa
 
 			nonPosted := repository.NonPostedShort{
 				ID:                allocation.NonPostedID,
-				Amount:            allocation.Amount,
+				Amount:            pkg.MoneyFromFloat(allocation.Amount),
 				TransactionSource: string(allocation.TransactionSource),
 				TransactionNumber: allocation.TransactionNumber,
 				AccountNumber:     allocation.AccountNumber,
@@ -327,6 +354,26 @@ func (r *LoanRepository) GetLoan(ctx context.Context, id uint32) (repository.Loa
 		rslt.NonPosted = []repository.NonPostedShort{}
 	}
 
+	rslt.Penalties, rslt.PenaltyBalance, err = r.listLoanPenalties(ctx, loan.ID)
+	if err != nil {
+		return repository.LoanShort{}, err
+	}
+
+	rslt.Restructures, err = r.listLoanRestructures(ctx, loan.ID)
+	if err != nil {
+		return repository.LoanShort{}, err
+	}
+
+	rslt.TopUp, err = r.getLoanTopUp(ctx, loan.ID, r.queries.GetLoanTopUpByLoan)
+	if err != nil {
+		return repository.LoanShort{}, err
+	}
+
+	rslt.RefinancedBy, err = r.getLoanTopUp(ctx, loan.ID, r.queries.GetLoanTopUpByRefinancedLoan)
+	if err != nil {
+		return repository.LoanShort{}, err
+	}
+
 	return rslt, nil
 }
 
@@ -463,9 +510,9 @@ func (r *LoanRepository) GetExpectedPayments(
 			BranchName:      payment.BranchName,
 			ClientName:      payment.ClientName,
 			LoanOfficerName: payment.LoanOfficerName,
-			LoanAmount:      payment.LoanAmount,
-			RepayAmount:     payment.RepayAmount,
-			TotalUnpaid:     pkg.InterfaceFloat64(payment.TotalUnpaid),
+			LoanAmount:      pkg.MoneyFromFloat(payment.LoanAmount),
+			RepayAmount:     pkg.MoneyFromFloat(payment.RepayAmount),
+			TotalUnpaid:     pkg.InterfaceMoney(payment.TotalUnpaid),
 			DueDate:         payment.DueDate.Time.Format("2006-01-02"),
 		}
 	}
@@ -542,7 +589,7 @@ func (r *LoanRepository) UpdateInstallment(
 ) (repository.Installment, error) {
 	params := generated.UpdateInstallmentParams{
 		ID:              installment.ID,
-		RemainingAmount: installment.RemainingAmount,
+		RemainingAmount: installment.RemainingAmount.Float64(),
 	}
 
 	if installment.Paid != nil {
@@ -621,11 +668,13 @@ func (r *LoanRepository) GetReportLoanData(
 			ClientName:        loan.ClientName,
 			BranchName:        loan.BranchName,
 			LoanOfficer:       loan.LoanOfficer,
-			LoanAmount:        loan.LoanAmount,
-			RepayAmount:       loan.RepayAmount,
-			PaidAmount:        loan.PaidAmount,
-			OutstandingAmount: loan.RepayAmount - loan.PaidAmount,
+			LoanAmount:        pkg.MoneyFromFloat(loan.LoanAmount),
+			RepayAmount:       pkg.MoneyFromFloat(loan.RepayAmount),
+			PaidAmount:        pkg.MoneyFromFloat(loan.PaidAmount),
+			OutstandingAmount: pkg.MoneyFromFloat(loan.RepayAmount) - pkg.MoneyFromFloat(loan.PaidAmount),
+			PenaltyBalance:    pkg.InterfaceMoney(loan.PenaltyBalance),
 			Status:            loan.Status,
+			Restructured:      loan.Restructured,
 			TotalInstallments: loan.TotalInstallments,
 			PaidInstallments:  loan.PaidInstallments,
 			DueDate:           dueDate,
@@ -634,9 +683,14 @@ func (r *LoanRepository) GetReportLoanData(
 		}
 
 		summary.TotalLoans++
-		summary.TotalDisbursedAmount += loan.LoanAmount
-		summary.TotalRepaidAmount += loan.PaidAmount
-		summary.TotalOutstanding += loan.RepayAmount - loan.PaidAmount
+		summary.TotalDisbursedAmount += rslt[i].LoanAmount
+		summary.TotalRepaidAmount += rslt[i].PaidAmount
+		summary.TotalOutstanding += rslt[i].OutstandingAmount
+		summary.TotalPenaltyBalance += rslt[i].PenaltyBalance
+
+		if loan.Restructured {
+			summary.TotalRestructuredLoans++
+		}
 
 		switch loan.Status {
 		case "ACTIVE":
@@ -716,16 +770,17 @@ func (r *LoanRepository) GetLoanEvents(ctx context.Context) ([]repository.LoanEv
 			dueDate = &d
 		}
 
-		var paymentDue *float64
+		var paymentDue *pkg.Money
 		if event.PaymentDue.Valid {
-			paymentDue = &event.PaymentDue.Float64
+			due := pkg.MoneyFromFloat(event.PaymentDue.Float64)
+			paymentDue = &due
 		}
 
 		rslt = append(rslt, repository.LoanEvent{
 			ID:         fmt.Sprintf("LN%03d", event.LoanID),
 			LoanID:     event.LoanID,
 			ClientName: event.ClientName,
-			LoanAmount: event.LoanAmount,
+			LoanAmount: pkg.MoneyFromFloat(event.LoanAmount),
 			Date:       disbursedDate,
 			Type:       "disbursed",
 			Title:      "Loan Disbursed",
@@ -737,7 +792,7 @@ func (r *LoanRepository) GetLoanEvents(ctx context.Context) ([]repository.LoanEv
 				ID:         fmt.Sprintf("LN%03d", event.LoanID),
 				LoanID:     event.LoanID,
 				ClientName: event.ClientName,
-				LoanAmount: event.LoanAmount,
+				LoanAmount: pkg.MoneyFromFloat(event.LoanAmount),
 				Date:       dueDate,
 				PaymentDue: paymentDue,
 				Type:       "due",
@@ -814,7 +869,7 @@ func (r *LoanRepository) ListUnpaidInstallmentsData(
 	for i, installment := range installments {
 		result[i] = repository.UnpaidInstallmentData{
 			InstallmentNumber: installment.InstallmentNumber,
-			RemainingAmount:   installment.RemainingAmount,
+			RemainingAmount:   pkg.MoneyFromFloat(installment.RemainingAmount),
 			DueDate:           installment.DueDate.Format("2006-01-02"),
 			LoanOfficer:       installment.LoanOfficer,
 			LoanId:            installment.LoanID,
@@ -823,11 +878,12 @@ func (r *LoanRepository) ListUnpaidInstallmentsData(
 				installment.ProductBranchname,
 				installment.LoanAmount,
 			),
-			ClientId:       installment.ClientID,
-			FullName:       installment.ClientName,
-			PhoneNumber:    installment.ClientPhone,
-			ClientBranch:   installment.ClientBranchname,
-			TotalDueAmount: installment.RepayAmount - installment.LoanPaidAmount,
+			ClientId:     installment.ClientID,
+			FullName:     installment.ClientName,
+			PhoneNumber:  installment.ClientPhone,
+			ClientBranch: installment.ClientBranchname,
+			TotalDueAmount: pkg.MoneyFromFloat(installment.RepayAmount) -
+				pkg.MoneyFromFloat(installment.LoanPaidAmount),
 		}
 	}
 
@@ -849,9 +905,9 @@ func convertListLoanRowToRepo(loan *generated.ListLoansRow) repository.LoanFullD
 		Product: repository.ProductShort{
 			ID:             loan.ProductID,
 			BranchName:     loan.ProductBranchName, // You might need to join the product's branch name if required
-			LoanAmount:     loan.LoanAmount,
-			RepayAmount:    loan.RepayAmount,
-			InterestAmount: loan.InterestAmount,
+			LoanAmount:     pkg.MoneyFromFloat(loan.LoanAmount),
+			RepayAmount:    pkg.MoneyFromFloat(loan.RepayAmount),
+			InterestAmount: pkg.MoneyFromFloat(loan.InterestAmount),
 		},
 		Client: repository.ClientShort{
 			ID:          loan.ClientID,
@@ -878,10 +934,10 @@ func convertListLoanRowToRepo(loan *generated.ListLoansRow) repository.LoanFullD
 		TotalInstallments:  loan.TotalInstallments,
 		InstallmentsPeriod: loan.InstallmentsPeriod,
 		Status:             string(loan.Status),
-		ProcessingFee:      loan.ProcessingFee,
+		ProcessingFee:      pkg.MoneyFromFloat(loan.ProcessingFee),
 		FeePaid:            loan.FeePaid,
-		PaidAmount:         loan.PaidAmount,
-		RemainingAmount:    loan.RepayAmount - loan.PaidAmount,
+		PaidAmount:         pkg.MoneyFromFloat(loan.PaidAmount),
+		RemainingAmount:    pkg.MoneyFromFloat(loan.RepayAmount) - pkg.MoneyFromFloat(loan.PaidAmount),
 		CreatedBy: repository.UserShortResponse{
 			ID:          loan.CreatedBy,
 			FullName:    loan.CreatedByName.String,
@@ -949,9 +1005,9 @@ func convertClientLoanRowToRepo(loan *generated.GetClientLoansRow) repository.Lo
 		Product: repository.ProductShort{
 			ID:             loan.ProductID,
 			BranchName:     loan.ProductBranchName, // You might need to join the product's branch name if required
-			LoanAmount:     loan.LoanAmount,
-			RepayAmount:    loan.RepayAmount,
-			InterestAmount: loan.InterestAmount,
+			LoanAmount:     pkg.MoneyFromFloat(loan.LoanAmount),
+			RepayAmount:    pkg.MoneyFromFloat(loan.RepayAmount),
+			InterestAmount: pkg.MoneyFromFloat(loan.InterestAmount),
 		},
 		Client: repository.ClientShort{
 			ID:          loan.ClientID,
@@ -978,10 +1034,10 @@ func convertClientLoanRowToRepo(loan *generated.GetClientLoansRow) repository.Lo
 		TotalInstallments:  loan.TotalInstallments,
 		InstallmentsPeriod: loan.InstallmentsPeriod,
 		Status:             string(loan.Status),
-		ProcessingFee:      loan.ProcessingFee,
+		ProcessingFee:      pkg.MoneyFromFloat(loan.ProcessingFee),
 		FeePaid:            loan.FeePaid,
-		PaidAmount:         loan.PaidAmount,
-		RemainingAmount:    loan.RepayAmount - loan.PaidAmount,
+		PaidAmount:         pkg.MoneyFromFloat(loan.PaidAmount),
+		RemainingAmount:    pkg.MoneyFromFloat(loan.RepayAmount) - pkg.MoneyFromFloat(loan.PaidAmount),
 		CreatedBy: repository.UserShortResponse{
 			ID:          loan.CreatedBy,
 			FullName:    loan.CreatedByName.String,
@@ -1067,8 +1123,8 @@ func convertGeneratedLoan(loan generated.Loan) repository.Loan {
 		TotalInstallments:  loan.TotalInstallments,
 		InstallmentsPeriod: loan.InstallmentsPeriod,
 		Status:             string(loan.Status),
-		ProcessingFee:      loan.ProcessingFee,
-		PaidAmount:         loan.PaidAmount,
+		ProcessingFee:      pkg.MoneyFromFloat(loan.ProcessingFee),
+		PaidAmount:         pkg.MoneyFromFloat(loan.PaidAmount),
 		UpdatedBy:          updatedBy,
 		CreatedBy:          loan.CreatedBy,
 		CreatedAt:          loan.CreatedAt,
@@ -1077,16 +1133,23 @@ func convertGeneratedLoan(loan generated.Loan) repository.Loan {
 }
 
 func convertGeneratedInstallment(installment generated.Installment) repository.Installment {
-	return repository.Installment{
+	rslt := repository.Installment{
 		ID:              installment.ID,
 		LoanID:          installment.LoanID,
 		InstallmentNo:   installment.InstallmentNumber,
-		Amount:          installment.AmountDue,
-		RemainingAmount: installment.RemainingAmount,
+		Amount:          pkg.MoneyFromFloat(installment.AmountDue),
+		RemainingAmount: pkg.MoneyFromFloat(installment.RemainingAmount),
 		Paid:            installment.Paid,
 		PaidAt:          installment.PaidAt.Time.Format("2006-01-02"),
 		DueDate:         installment.DueDate.Format("2006-01-02"),
+		Closed:          installment.ClosedAt.Valid,
+	}
+
+	if installment.RestructureID.Valid {
+		rslt.RestructureID = pkg.Uint32Ptr(uint32(installment.RestructureID.Int32))
 	}
+
+	return rslt
 }
 
 func convertGeneratedInstallmentList(
@@ -1094,16 +1157,7 @@ func convertGeneratedInstallmentList(
 ) []repository.Installment {
 	rslt := make([]repository.Installment, len(installments))
 	for idx, installment := range installments {
-		rslt[idx] = repository.Installment{
-			ID:              installment.ID,
-			LoanID:          installment.LoanID,
-			InstallmentNo:   installment.InstallmentNumber,
-			Amount:          installment.AmountDue,
-			RemainingAmount: installment.RemainingAmount,
-			Paid:            installment.Paid,
-			PaidAt:          installment.PaidAt.Time.Format("2006-01-02"),
-			DueDate:         installment.DueDate.Format("2006-01-02"),
-		}
+		rslt[idx] = convertGeneratedInstallment(installment)
 	}
 
 	return rslt
@@ -1124,7 +1178,7 @@ func convertLoanReportDataById(row GetLoanReportDataByIdRow) (services.LoanRepor
 		if err != nil {
 			return services.LoanReportDataById{}, pkg.Errorf(
 				pkg.INTERNAL_ERROR,
-				"error unmarshalling installments: ",
+				"error unmarshalling installments: %v",
 				err,
 			)
 		}
@@ -1136,7 +1190,9 @@ func convertLoanReportDataById(row GetLoanReportDataByIdRow) (services.LoanRepor
 		LoanAmount:            row.LoanAmount,
 		RepayAmount:           row.RepayAmount,
 		PaidAmount:            row.PaidAmount,
+		PenaltyBalance:        pkg.InterfaceMoney(row.PenaltyBalance),
 		Status:                row.Status,
+		Restructured:          row.Restructured,
 		TotalInstallments:     row.TotalInstallments,
 		PaidInstallments:      row.PaidInstallments,
 		RemainingInstallments: row.RemainingInstallments,
@@ -0,0 +1,95 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/mysql/generated"
+	"github.com/EmilioCliff/kokomed-fin/backend/pkg"
+)
+
+// LockLoanForPayment takes row locks on the client, the loan and the loan's installments until
+// the transaction ends. A second payment on the same loan waits here and then reads what the
+// first one committed instead of paying the same installments again. The rows are always
+// locked in this order so two payments never hold them the other way round.
+func LockLoanForPayment(ctx context.Context, q generated.Querier, clientID, loanID uint32) error {
+	if err := LockClientForUpdate(ctx, q, clientID); err != nil {
+		return err
+	}
+
+	return lockLoan(ctx, q, loanID)
+}
+
+// LockClientsAndLoans takes the locks of a change that touches more than one client or loan,
+// such as reverting a payment and paying it again elsewhere. The clients are locked first and
+// then the loans with their installments, each in id order, so it never waits on a payment
+// holding them the other way round. Call it before the first row is changed.
+func LockClientsAndLoans(
+	ctx context.Context,
+	q generated.Querier,
+	clientIDs []uint32,
+	loanIDs []uint32,
+) error {
+	for _, clientID := range sortedUnique(clientIDs) {
+		if err := LockClientForUpdate(ctx, q, clientID); err != nil {
+			return err
+		}
+	}
+
+	for _, loanID := range sortedUnique(loanIDs) {
+		if err := lockLoan(ctx, q, loanID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func lockLoan(ctx context.Context, q generated.Querier, loanID uint32) error {
+	if _, err := q.LockLoan(ctx, loanID); err != nil {
+		if err == sql.ErrNoRows {
+			return pkg.Errorf(pkg.NOT_FOUND_ERROR, "loan not found")
+		}
+
+		return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to lock loan: %s", err.Error())
+	}
+
+	if _, err := q.LockLoanInstallments(ctx, loanID); err != nil {
+		return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to lock installments: %s", err.Error())
+	}
+
+	return nil
+}
+
+// LockClientForUpdate locks the client row so its overpayment can be read and changed
+// without another transaction changing it in between.
+func LockClientForUpdate(ctx context.Context, q generated.Querier, clientID uint32) error {
+	if _, err := q.LockClient(ctx, clientID); err != nil {
+		if err == sql.ErrNoRows {
+			return pkg.Errorf(pkg.NOT_FOUND_ERROR, "client not found")
+		}
+
+		return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to lock client: %s", err.Error())
+	}
+
+	return nil
+}
+
+func sortedUnique(ids []uint32) []uint32 {
+	seen := make(map[uint32]bool, len(ids))
+	rslt := make([]uint32, 0, len(ids))
+
+	for _, id := range ids {
+		if id == 0 || seen[id] {
+			continue
+		}
+
+		seen[id] = true
+		rslt = append(rslt, id)
+	}
+
+	sort.Slice(rslt, func(i, j int) bool { return rslt[i] < rslt[j] })
+
+	return rslt
+}
@@ -0,0 +1,5 @@
+DROP INDEX idx_non_posted_transaction_number ON `non_posted`;
+
+ALTER TABLE processed_callbacks DROP FOREIGN KEY fk_processed_callbacks_non_posted_id;
+
+DROP TABLE IF EXISTS processed_callbacks;
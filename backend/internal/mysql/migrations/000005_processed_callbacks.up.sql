@@ -0,0 +1,25 @@
+CREATE TABLE `processed_callbacks` (
+  `id` INT PRIMARY KEY AUTO_INCREMENT,
+  `transaction_number` VARCHAR(255) NOT NULL,
+  `transaction_source` VARCHAR(50) NOT NULL,
+  `non_posted_id` INT NOT NULL,
+  `loan_id` INT NULL,
+  `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+  CONSTRAINT uq_processed_callbacks_transaction UNIQUE (`transaction_number`, `transaction_source`),
+  CONSTRAINT fk_processed_callbacks_non_posted_id FOREIGN KEY (`non_posted_id`) REFERENCES `non_posted` (`id`)
+);
+
+-- backfill from existing payments, the first occurrence of a transaction is taken as the original
+INSERT IGNORE INTO `processed_callbacks` (`transaction_number`, `transaction_source`, `non_posted_id`, `loan_id`)
+SELECT 
+    np.transaction_number,
+    np.transaction_source,
+    np.id,
+    (SELECT MIN(pa.loan_id) FROM payment_allocations pa WHERE pa.non_posted_id = np.id AND pa.deleted_at IS NULL)
+FROM non_posted np
+WHERE np.id IN (
+    SELECT MIN(id) FROM non_posted GROUP BY transaction_number, transaction_source
+);
+
+CREATE INDEX idx_non_posted_transaction_number ON `non_posted` (`transaction_number`);
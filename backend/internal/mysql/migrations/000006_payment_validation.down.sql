@@ -0,0 +1,4 @@
+ALTER TABLE blacklisted_clients DROP FOREIGN KEY fk_blacklisted_clients_client_id;
+
+DROP TABLE IF EXISTS blacklisted_clients;
+DROP TABLE IF EXISTS payment_validation_logs;
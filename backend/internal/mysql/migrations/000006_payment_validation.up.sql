@@ -0,0 +1,26 @@
+CREATE TABLE `blacklisted_clients` (
+  `id` INT PRIMARY KEY AUTO_INCREMENT,
+  `client_id` INT NOT NULL UNIQUE,
+  `reason` TEXT NOT NULL,
+  `created_by` VARCHAR(255) NOT NULL DEFAULT 'SYSTEM',
+  `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+  CONSTRAINT fk_blacklisted_clients_client_id FOREIGN KEY (`client_id`) REFERENCES `clients` (`id`)
+);
+
+CREATE TABLE `payment_validation_logs` (
+  `id` INT PRIMARY KEY AUTO_INCREMENT,
+  `transaction_number` VARCHAR(255) NOT NULL,
+  `account_number` VARCHAR(255) NOT NULL,
+  `phone_number` VARCHAR(2048) NOT NULL,
+  `paying_name` VARCHAR(255) NOT NULL,
+  `amount` DECIMAL(10,2) NOT NULL,
+  `client_id` INT NULL,
+  `accepted` BOOLEAN NOT NULL,
+  `result_code` VARCHAR(20) NOT NULL,
+  `reason` TEXT NOT NULL,
+  `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_payment_validation_logs_created_at ON `payment_validation_logs` (`created_at`);
+CREATE INDEX idx_payment_validation_logs_transaction_number ON `payment_validation_logs` (`transaction_number`);
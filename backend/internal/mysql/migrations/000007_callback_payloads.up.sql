@@ -0,0 +1,9 @@
+CREATE TABLE `callback_payloads` (
+  `id` INT PRIMARY KEY AUTO_INCREMENT,
+  `transaction_number` VARCHAR(255) NOT NULL DEFAULT '',
+  `callback_type` VARCHAR(50) NOT NULL,
+  `payload` TEXT NOT NULL,
+  `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_callback_payloads_transaction_number ON `callback_payloads` (`transaction_number`);
@@ -0,0 +1,5 @@
+ALTER TABLE stk_push_requests DROP FOREIGN KEY fk_stk_push_requests_loan_id;
+ALTER TABLE stk_push_requests DROP FOREIGN KEY fk_stk_push_requests_client_id;
+ALTER TABLE stk_push_requests DROP FOREIGN KEY fk_stk_push_requests_initiated_by;
+
+DROP TABLE IF EXISTS stk_push_requests;
@@ -0,0 +1,23 @@
+CREATE TABLE `stk_push_requests` (
+  `id` INT PRIMARY KEY AUTO_INCREMENT,
+  `loan_id` INT NOT NULL,
+  `client_id` INT NOT NULL,
+  `phone_number` VARCHAR(20) NOT NULL,
+  `amount` DECIMAL(10,2) NOT NULL,
+  `merchant_request_id` VARCHAR(255) NOT NULL,
+  `checkout_request_id` VARCHAR(255) NOT NULL UNIQUE,
+  `status` ENUM('PENDING', 'COMPLETED', 'FAILED') NOT NULL DEFAULT 'PENDING',
+  `result_code` INT NULL,
+  `result_desc` TEXT NULL,
+  `mpesa_receipt_number` VARCHAR(255) NULL,
+  `initiated_by` INT NOT NULL,
+  `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` TIMESTAMP NULL DEFAULT NULL,
+
+  CONSTRAINT fk_stk_push_requests_loan_id FOREIGN KEY (`loan_id`) REFERENCES `loans` (`id`),
+  CONSTRAINT fk_stk_push_requests_client_id FOREIGN KEY (`client_id`) REFERENCES `clients` (`id`),
+  CONSTRAINT fk_stk_push_requests_initiated_by FOREIGN KEY (`initiated_by`) REFERENCES `users` (`id`)
+);
+
+CREATE INDEX idx_stk_push_requests_loan_id ON `stk_push_requests` (`loan_id`);
+CREATE INDEX idx_stk_push_requests_status ON `stk_push_requests` (`status`);
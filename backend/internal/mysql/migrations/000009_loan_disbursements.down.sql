@@ -0,0 +1,7 @@
+ALTER TABLE loan_disbursements DROP FOREIGN KEY fk_loan_disbursements_loan_id;
+ALTER TABLE loan_disbursements DROP FOREIGN KEY fk_loan_disbursements_initiated_by;
+
+DROP TABLE IF EXISTS loan_disbursements;
+
+UPDATE `loans` SET `status` = 'INACTIVE' WHERE `status` = 'DISBURSING';
+ALTER TABLE `loans` MODIFY `status` ENUM('INACTIVE', 'ACTIVE', 'COMPLETED', 'DEFAULTED') NOT NULL;
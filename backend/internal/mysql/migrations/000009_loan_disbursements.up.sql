@@ -0,0 +1,23 @@
+ALTER TABLE `loans` MODIFY `status` ENUM('INACTIVE', 'DISBURSING', 'ACTIVE', 'COMPLETED', 'DEFAULTED') NOT NULL;
+
+CREATE TABLE `loan_disbursements` (
+  `id` INT PRIMARY KEY AUTO_INCREMENT,
+  `loan_id` INT NOT NULL,
+  `phone_number` VARCHAR(20) NOT NULL,
+  `amount` DECIMAL(10,2) NOT NULL,
+  `fee_deducted` BOOLEAN NOT NULL DEFAULT FALSE,
+  `conversation_id` VARCHAR(255) NOT NULL UNIQUE,
+  `originator_conversation_id` VARCHAR(255) NOT NULL,
+  `status` ENUM('PENDING', 'COMPLETED', 'FAILED') NOT NULL DEFAULT 'PENDING',
+  `result_code` INT NULL,
+  `result_desc` TEXT NULL,
+  `transaction_id` VARCHAR(255) NULL,
+  `initiated_by` INT NOT NULL,
+  `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` TIMESTAMP NULL DEFAULT NULL,
+
+  CONSTRAINT fk_loan_disbursements_loan_id FOREIGN KEY (`loan_id`) REFERENCES `loans` (`id`),
+  CONSTRAINT fk_loan_disbursements_initiated_by FOREIGN KEY (`initiated_by`) REFERENCES `users` (`id`)
+);
+
+CREATE INDEX idx_loan_disbursements_loan_id ON `loan_disbursements` (`loan_id`);
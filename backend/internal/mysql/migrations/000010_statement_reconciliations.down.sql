@@ -0,0 +1,9 @@
+DROP INDEX idx_non_posted_paid_date ON `non_posted`;
+
+ALTER TABLE statement_reconciliation_items DROP FOREIGN KEY fk_statement_reconciliation_items_reconciliation_id;
+ALTER TABLE statement_reconciliation_items DROP FOREIGN KEY fk_statement_reconciliation_items_non_posted_id;
+ALTER TABLE statement_reconciliation_items DROP FOREIGN KEY fk_statement_reconciliation_items_imported_non_posted_id;
+ALTER TABLE statement_reconciliations DROP FOREIGN KEY fk_statement_reconciliations_uploaded_by;
+
+DROP TABLE IF EXISTS statement_reconciliation_items;
+DROP TABLE IF EXISTS statement_reconciliations;
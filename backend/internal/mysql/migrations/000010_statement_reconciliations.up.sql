@@ -0,0 +1,37 @@
+CREATE TABLE `statement_reconciliations` (
+  `id` INT PRIMARY KEY AUTO_INCREMENT,
+  `statement_date` DATE NOT NULL,
+  `file_name` VARCHAR(255) NOT NULL,
+  `total_lines` INT NOT NULL DEFAULT 0,
+  `matched` INT NOT NULL DEFAULT 0,
+  `missing_callbacks` INT NOT NULL DEFAULT 0,
+  `amount_mismatches` INT NOT NULL DEFAULT 0,
+  `not_on_statement` INT NOT NULL DEFAULT 0,
+  `uploaded_by` INT NOT NULL,
+  `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+  CONSTRAINT fk_statement_reconciliations_uploaded_by FOREIGN KEY (`uploaded_by`) REFERENCES `users` (`id`)
+);
+
+CREATE TABLE `statement_reconciliation_items` (
+  `id` INT PRIMARY KEY AUTO_INCREMENT,
+  `reconciliation_id` INT NOT NULL,
+  `item_type` ENUM('MISSING_CALLBACK', 'AMOUNT_MISMATCH', 'NOT_ON_STATEMENT') NOT NULL,
+  `transaction_number` VARCHAR(255) NOT NULL,
+  `statement_amount` DECIMAL(10,2) NOT NULL DEFAULT 0.00,
+  `recorded_amount` DECIMAL(10,2) NOT NULL DEFAULT 0.00,
+  `non_posted_id` INT NULL,
+  `account_number` VARCHAR(255) NOT NULL DEFAULT '',
+  `phone_number` VARCHAR(20) NOT NULL DEFAULT '',
+  `paying_name` VARCHAR(255) NOT NULL DEFAULT '',
+  `paid_date` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `imported_non_posted_id` INT NULL,
+  `imported_at` TIMESTAMP NULL DEFAULT NULL,
+
+  CONSTRAINT fk_statement_reconciliation_items_reconciliation_id FOREIGN KEY (`reconciliation_id`) REFERENCES `statement_reconciliations` (`id`),
+  CONSTRAINT fk_statement_reconciliation_items_non_posted_id FOREIGN KEY (`non_posted_id`) REFERENCES `non_posted` (`id`),
+  CONSTRAINT fk_statement_reconciliation_items_imported_non_posted_id FOREIGN KEY (`imported_non_posted_id`) REFERENCES `non_posted` (`id`)
+);
+
+CREATE INDEX idx_statement_reconciliation_items_reconciliation_id ON `statement_reconciliation_items` (`reconciliation_id`);
+CREATE INDEX idx_non_posted_paid_date ON `non_posted` (`paid_date`);
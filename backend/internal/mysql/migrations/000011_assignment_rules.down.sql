@@ -0,0 +1,3 @@
+ALTER TABLE assignment_rules DROP FOREIGN KEY fk_assignment_rules_created_by;
+
+DROP TABLE IF EXISTS assignment_rules;
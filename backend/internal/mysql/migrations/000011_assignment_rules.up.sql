@@ -0,0 +1,17 @@
+CREATE TABLE `assignment_rules` (
+  `id` INT PRIMARY KEY AUTO_INCREMENT,
+  `name` VARCHAR(255) NOT NULL,
+  `rule_type` ENUM('ID_NUMBER', 'LOAN_PREFIX', 'NAME_AMOUNT') NOT NULL,
+  `priority` INT NOT NULL DEFAULT 0,
+  `active` BOOLEAN NOT NULL DEFAULT TRUE,
+  `prefix` VARCHAR(20) NOT NULL DEFAULT 'LN',
+  `days_window` INT NOT NULL DEFAULT 3,
+  `min_name_similarity` DECIMAL(3,2) NOT NULL DEFAULT 0.80,
+  `created_by` INT NOT NULL,
+  `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+  CONSTRAINT fk_assignment_rules_created_by FOREIGN KEY (`created_by`) REFERENCES `users` (`id`)
+);
+
+CREATE INDEX idx_assignment_rules_priority ON `assignment_rules` (`active`, `priority`);
@@ -0,0 +1,9 @@
+ALTER TABLE payment_split_portions DROP FOREIGN KEY fk_payment_split_portions_split_id;
+ALTER TABLE payment_split_portions DROP FOREIGN KEY fk_payment_split_portions_client_id;
+ALTER TABLE payment_split_portions DROP FOREIGN KEY fk_payment_split_portions_loan_id;
+ALTER TABLE payment_splits DROP FOREIGN KEY fk_payment_splits_non_posted_id;
+ALTER TABLE payment_splits DROP FOREIGN KEY fk_payment_splits_created_by;
+ALTER TABLE payment_splits DROP FOREIGN KEY fk_payment_splits_reversed_by;
+
+DROP TABLE IF EXISTS payment_split_portions;
+DROP TABLE IF EXISTS payment_splits;
@@ -0,0 +1,29 @@
+CREATE TABLE `payment_splits` (
+  `id` INT PRIMARY KEY AUTO_INCREMENT,
+  `non_posted_id` INT NOT NULL,
+  `status` ENUM('ACTIVE', 'REVERSED') NOT NULL DEFAULT 'ACTIVE',
+  `created_by` INT NOT NULL,
+  `reversed_by` INT NULL,
+  `reversal_reason` TEXT NULL,
+  `reversed_at` TIMESTAMP NULL DEFAULT NULL,
+  `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+  CONSTRAINT fk_payment_splits_non_posted_id FOREIGN KEY (`non_posted_id`) REFERENCES `non_posted` (`id`),
+  CONSTRAINT fk_payment_splits_created_by FOREIGN KEY (`created_by`) REFERENCES `users` (`id`),
+  CONSTRAINT fk_payment_splits_reversed_by FOREIGN KEY (`reversed_by`) REFERENCES `users` (`id`)
+);
+
+CREATE TABLE `payment_split_portions` (
+  `id` INT PRIMARY KEY AUTO_INCREMENT,
+  `split_id` INT NOT NULL,
+  `client_id` INT NOT NULL,
+  `loan_id` INT NULL,
+  `amount` DECIMAL(10,2) NOT NULL,
+
+  CONSTRAINT fk_payment_split_portions_split_id FOREIGN KEY (`split_id`) REFERENCES `payment_splits` (`id`),
+  CONSTRAINT fk_payment_split_portions_client_id FOREIGN KEY (`client_id`) REFERENCES `clients` (`id`),
+  CONSTRAINT fk_payment_split_portions_loan_id FOREIGN KEY (`loan_id`) REFERENCES `loans` (`id`)
+);
+
+CREATE INDEX idx_payment_splits_non_posted_id ON `payment_splits` (`non_posted_id`);
+CREATE INDEX idx_payment_split_portions_split_id ON `payment_split_portions` (`split_id`);
@@ -0,0 +1,5 @@
+ALTER TABLE overpayment_refunds DROP FOREIGN KEY fk_overpayment_refunds_client_id;
+ALTER TABLE overpayment_refunds DROP FOREIGN KEY fk_overpayment_refunds_requested_by;
+ALTER TABLE overpayment_refunds DROP FOREIGN KEY fk_overpayment_refunds_reviewed_by;
+
+DROP TABLE IF EXISTS overpayment_refunds;
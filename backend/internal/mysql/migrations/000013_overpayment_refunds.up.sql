@@ -0,0 +1,24 @@
+CREATE TABLE `overpayment_refunds` (
+  `id` INT PRIMARY KEY AUTO_INCREMENT,
+  `client_id` INT NOT NULL,
+  `amount` DECIMAL(10,2) NOT NULL,
+  `reason` TEXT NOT NULL,
+  `status` ENUM('PENDING', 'APPROVED', 'PROCESSING', 'PAID', 'REJECTED', 'FAILED') NOT NULL DEFAULT 'PENDING',
+  `payout_method` VARCHAR(20) NOT NULL DEFAULT '',
+  `payout_phone` VARCHAR(20) NOT NULL DEFAULT '',
+  `payout_reference` VARCHAR(255) NOT NULL DEFAULT '',
+  `conversation_id` VARCHAR(255) NULL UNIQUE,
+  `requested_by` INT NOT NULL,
+  `reviewed_by` INT NULL,
+  `review_note` VARCHAR(255) NOT NULL DEFAULT '',
+  `reviewed_at` TIMESTAMP NULL DEFAULT NULL,
+  `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+  CONSTRAINT fk_overpayment_refunds_client_id FOREIGN KEY (`client_id`) REFERENCES `clients` (`id`),
+  CONSTRAINT fk_overpayment_refunds_requested_by FOREIGN KEY (`requested_by`) REFERENCES `users` (`id`),
+  CONSTRAINT fk_overpayment_refunds_reviewed_by FOREIGN KEY (`reviewed_by`) REFERENCES `users` (`id`)
+);
+
+CREATE INDEX idx_overpayment_refunds_client_id ON `overpayment_refunds` (`client_id`);
+CREATE INDEX idx_overpayment_refunds_status ON `overpayment_refunds` (`status`);
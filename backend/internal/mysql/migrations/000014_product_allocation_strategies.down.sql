@@ -0,0 +1,4 @@
+ALTER TABLE product_allocation_strategies DROP FOREIGN KEY fk_product_allocation_strategies_product_id;
+ALTER TABLE product_allocation_strategies DROP FOREIGN KEY fk_product_allocation_strategies_updated_by;
+
+DROP TABLE IF EXISTS product_allocation_strategies;
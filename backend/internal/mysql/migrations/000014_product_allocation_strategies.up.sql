@@ -0,0 +1,9 @@
+CREATE TABLE `product_allocation_strategies` (
+  `product_id` INT PRIMARY KEY,
+  `strategy` ENUM('OLDEST_FIRST', 'CURRENT_FIRST', 'SPREAD_OVERDUE', 'WATERFALL') NOT NULL DEFAULT 'OLDEST_FIRST',
+  `updated_by` INT NOT NULL,
+  `updated_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+  CONSTRAINT fk_product_allocation_strategies_product_id FOREIGN KEY (`product_id`) REFERENCES `products` (`id`),
+  CONSTRAINT fk_product_allocation_strategies_updated_by FOREIGN KEY (`updated_by`) REFERENCES `users` (`id`)
+);
@@ -0,0 +1,6 @@
+ALTER TABLE payment_reassignments DROP FOREIGN KEY fk_payment_reassignments_non_posted_id;
+ALTER TABLE payment_reassignments DROP FOREIGN KEY fk_payment_reassignments_from_client_id;
+ALTER TABLE payment_reassignments DROP FOREIGN KEY fk_payment_reassignments_to_client_id;
+ALTER TABLE payment_reassignments DROP FOREIGN KEY fk_payment_reassignments_reassigned_by;
+
+DROP TABLE IF EXISTS payment_reassignments;
@@ -0,0 +1,19 @@
+CREATE TABLE `payment_reassignments` (
+  `id` INT PRIMARY KEY AUTO_INCREMENT,
+  `non_posted_id` INT NOT NULL,
+  `from_client_id` INT NOT NULL,
+  `to_client_id` INT NOT NULL,
+  `from_loan_id` INT NULL,
+  `to_loan_id` INT NULL,
+  `amount` DECIMAL(10,2) NOT NULL,
+  `reason` TEXT NOT NULL,
+  `reassigned_by` INT NOT NULL,
+  `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+  CONSTRAINT fk_payment_reassignments_non_posted_id FOREIGN KEY (`non_posted_id`) REFERENCES `non_posted` (`id`),
+  CONSTRAINT fk_payment_reassignments_from_client_id FOREIGN KEY (`from_client_id`) REFERENCES `clients` (`id`),
+  CONSTRAINT fk_payment_reassignments_to_client_id FOREIGN KEY (`to_client_id`) REFERENCES `clients` (`id`),
+  CONSTRAINT fk_payment_reassignments_reassigned_by FOREIGN KEY (`reassigned_by`) REFERENCES `users` (`id`)
+);
+
+CREATE INDEX idx_payment_reassignments_non_posted_id ON `payment_reassignments` (`non_posted_id`);
@@ -0,0 +1,9 @@
+ALTER TABLE payment_import_rows DROP FOREIGN KEY fk_payment_import_rows_batch_id;
+ALTER TABLE payment_import_rows DROP FOREIGN KEY fk_payment_import_rows_client_id;
+ALTER TABLE payment_import_rows DROP FOREIGN KEY fk_payment_import_rows_non_posted_id;
+ALTER TABLE payment_import_batches DROP FOREIGN KEY fk_payment_import_batches_uploaded_by;
+ALTER TABLE payment_import_batches DROP FOREIGN KEY fk_payment_import_batches_posted_by;
+ALTER TABLE payment_import_batches DROP FOREIGN KEY fk_payment_import_batches_rolled_back_by;
+
+DROP TABLE IF EXISTS payment_import_rows;
+DROP TABLE IF EXISTS payment_import_batches;
@@ -0,0 +1,39 @@
+CREATE TABLE `payment_import_batches` (
+  `id` INT PRIMARY KEY AUTO_INCREMENT,
+  `file_name` VARCHAR(255) NOT NULL,
+  `status` ENUM('PREVIEW', 'POSTING', 'POSTED', 'ROLLED_BACK') NOT NULL DEFAULT 'PREVIEW',
+  `total_rows` INT NOT NULL DEFAULT 0,
+  `valid_rows` INT NOT NULL DEFAULT 0,
+  `posted_rows` INT NOT NULL DEFAULT 0,
+  `uploaded_by` INT NOT NULL,
+  `posted_by` INT NULL,
+  `posted_at` TIMESTAMP NULL DEFAULT NULL,
+  `rolled_back_by` INT NULL,
+  `rolled_back_at` TIMESTAMP NULL DEFAULT NULL,
+  `rollback_reason` TEXT NULL,
+  `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+  CONSTRAINT fk_payment_import_batches_uploaded_by FOREIGN KEY (`uploaded_by`) REFERENCES `users` (`id`),
+  CONSTRAINT fk_payment_import_batches_posted_by FOREIGN KEY (`posted_by`) REFERENCES `users` (`id`),
+  CONSTRAINT fk_payment_import_batches_rolled_back_by FOREIGN KEY (`rolled_back_by`) REFERENCES `users` (`id`)
+);
+
+CREATE TABLE `payment_import_rows` (
+  `id` INT PRIMARY KEY AUTO_INCREMENT,
+  `batch_id` INT NOT NULL,
+  `line_number` INT NOT NULL,
+  `identifier` VARCHAR(255) NOT NULL,
+  `client_id` INT NULL,
+  `amount` DECIMAL(10,2) NOT NULL DEFAULT 0.00,
+  `paid_date` TIMESTAMP NULL DEFAULT NULL,
+  `reference` VARCHAR(255) NOT NULL,
+  `error` VARCHAR(255) NOT NULL DEFAULT '',
+  `non_posted_id` INT NULL,
+  `rolled_back` BOOLEAN NOT NULL DEFAULT FALSE,
+
+  CONSTRAINT fk_payment_import_rows_batch_id FOREIGN KEY (`batch_id`) REFERENCES `payment_import_batches` (`id`),
+  CONSTRAINT fk_payment_import_rows_client_id FOREIGN KEY (`client_id`) REFERENCES `clients` (`id`),
+  CONSTRAINT fk_payment_import_rows_non_posted_id FOREIGN KEY (`non_posted_id`) REFERENCES `non_posted` (`id`)
+);
+
+CREATE INDEX idx_payment_import_rows_batch_id ON `payment_import_rows` (`batch_id`);
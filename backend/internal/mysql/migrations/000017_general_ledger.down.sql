@@ -0,0 +1,9 @@
+ALTER TABLE journal_lines DROP FOREIGN KEY fk_journal_lines_entry_id;
+ALTER TABLE journal_lines DROP FOREIGN KEY fk_journal_lines_account_id;
+ALTER TABLE journal_entries DROP FOREIGN KEY fk_journal_entries_loan_id;
+ALTER TABLE journal_entries DROP FOREIGN KEY fk_journal_entries_client_id;
+ALTER TABLE journal_entries DROP FOREIGN KEY fk_journal_entries_non_posted_id;
+
+DROP TABLE IF EXISTS journal_lines;
+DROP TABLE IF EXISTS journal_entries;
+DROP TABLE IF EXISTS ledger_accounts;
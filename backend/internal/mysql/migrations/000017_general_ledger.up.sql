@@ -0,0 +1,55 @@
+CREATE TABLE `ledger_accounts` (
+  `id` INT PRIMARY KEY AUTO_INCREMENT,
+  `code` VARCHAR(20) NOT NULL UNIQUE,
+  `name` VARCHAR(255) NOT NULL,
+  `account_type` ENUM('ASSET', 'LIABILITY', 'EQUITY', 'INCOME', 'EXPENSE') NOT NULL,
+  `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO `ledger_accounts` (`code`, `name`, `account_type`) VALUES
+  ('1000', 'Cash and M-Pesa Float', 'ASSET'),
+  ('1100', 'Loans Receivable', 'ASSET'),
+  ('2000', 'Unallocated Payments', 'LIABILITY'),
+  ('2100', 'Client Overpayments', 'LIABILITY'),
+  ('4000', 'Interest Income', 'INCOME'),
+  ('4100', 'Fee Income', 'INCOME');
+
+CREATE TABLE `journal_entries` (
+  `id` INT PRIMARY KEY AUTO_INCREMENT,
+  `entry_type` ENUM(
+    'DISBURSEMENT',
+    'PROCESSING_FEE',
+    'PAYMENT_RECEIVED',
+    'PAYMENT_ADJUSTED',
+    'PAYMENT_ALLOCATED',
+    'PAYMENT_REVERSED',
+    'PAYMENT_DELETED',
+    'OVERPAYMENT_APPLIED',
+    'OVERPAYMENT_REFUND',
+    'OVERPAYMENT_REFUND_FAILED'
+  ) NOT NULL,
+  `description` TEXT NOT NULL,
+  `loan_id` INT NULL,
+  `client_id` INT NULL,
+  `non_posted_id` INT NULL,
+  `created_by` VARCHAR(255) NOT NULL,
+  `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+  CONSTRAINT fk_journal_entries_loan_id FOREIGN KEY (`loan_id`) REFERENCES `loans` (`id`),
+  CONSTRAINT fk_journal_entries_client_id FOREIGN KEY (`client_id`) REFERENCES `clients` (`id`),
+  CONSTRAINT fk_journal_entries_non_posted_id FOREIGN KEY (`non_posted_id`) REFERENCES `non_posted` (`id`)
+);
+
+CREATE TABLE `journal_lines` (
+  `id` INT PRIMARY KEY AUTO_INCREMENT,
+  `entry_id` INT NOT NULL,
+  `account_id` INT NOT NULL,
+  `debit` DECIMAL(15,2) NOT NULL DEFAULT 0.00,
+  `credit` DECIMAL(15,2) NOT NULL DEFAULT 0.00,
+
+  CONSTRAINT fk_journal_lines_entry_id FOREIGN KEY (`entry_id`) REFERENCES `journal_entries` (`id`),
+  CONSTRAINT fk_journal_lines_account_id FOREIGN KEY (`account_id`) REFERENCES `ledger_accounts` (`id`)
+);
+
+CREATE INDEX idx_journal_entries_created_at ON `journal_entries` (`created_at`);
+CREATE INDEX idx_journal_lines_account_id ON `journal_lines` (`account_id`);
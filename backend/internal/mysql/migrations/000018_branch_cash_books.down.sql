@@ -0,0 +1,26 @@
+ALTER TABLE `journal_entries` MODIFY `entry_type` ENUM(
+  'DISBURSEMENT',
+  'PROCESSING_FEE',
+  'PAYMENT_RECEIVED',
+  'PAYMENT_ADJUSTED',
+  'PAYMENT_ALLOCATED',
+  'PAYMENT_REVERSED',
+  'PAYMENT_DELETED',
+  'OVERPAYMENT_APPLIED',
+  'OVERPAYMENT_REFUND',
+  'OVERPAYMENT_REFUND_FAILED'
+) NOT NULL;
+
+DELETE FROM `ledger_accounts` WHERE `code` IN ('5000', '5100');
+
+ALTER TABLE cash_variances DROP FOREIGN KEY fk_cash_variances_close_id;
+ALTER TABLE cash_variances DROP FOREIGN KEY fk_cash_variances_branch_id;
+ALTER TABLE cash_variances DROP FOREIGN KEY fk_cash_variances_created_by;
+ALTER TABLE cash_book_closes DROP FOREIGN KEY fk_cash_book_closes_branch_id;
+ALTER TABLE cash_book_closes DROP FOREIGN KEY fk_cash_book_closes_closed_by;
+ALTER TABLE branch_expenses DROP FOREIGN KEY fk_branch_expenses_branch_id;
+ALTER TABLE branch_expenses DROP FOREIGN KEY fk_branch_expenses_created_by;
+
+DROP TABLE IF EXISTS cash_variances;
+DROP TABLE IF EXISTS cash_book_closes;
+DROP TABLE IF EXISTS branch_expenses;
@@ -0,0 +1,68 @@
+CREATE TABLE `branch_expenses` (
+  `id` INT PRIMARY KEY AUTO_INCREMENT,
+  `branch_id` INT NOT NULL,
+  `expense_date` DATE NOT NULL,
+  `amount` DECIMAL(10,2) NOT NULL,
+  `description` VARCHAR(255) NOT NULL,
+  `created_by` INT NOT NULL,
+  `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+  CONSTRAINT fk_branch_expenses_branch_id FOREIGN KEY (`branch_id`) REFERENCES `branches` (`id`),
+  CONSTRAINT fk_branch_expenses_created_by FOREIGN KEY (`created_by`) REFERENCES `users` (`id`)
+);
+
+CREATE TABLE `cash_book_closes` (
+  `id` INT PRIMARY KEY AUTO_INCREMENT,
+  `branch_id` INT NOT NULL,
+  `business_date` DATE NOT NULL,
+  `opening_balance` DECIMAL(10,2) NOT NULL,
+  `cash_received` DECIMAL(10,2) NOT NULL,
+  `cash_disbursed` DECIMAL(10,2) NOT NULL,
+  `expenses` DECIMAL(10,2) NOT NULL,
+  `expected_closing` DECIMAL(10,2) NOT NULL,
+  `counted_cash` DECIMAL(10,2) NOT NULL,
+  `closed_by` INT NOT NULL,
+  `closed_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+  UNIQUE KEY uq_cash_book_closes_branch_date (`branch_id`, `business_date`),
+  CONSTRAINT fk_cash_book_closes_branch_id FOREIGN KEY (`branch_id`) REFERENCES `branches` (`id`),
+  CONSTRAINT fk_cash_book_closes_closed_by FOREIGN KEY (`closed_by`) REFERENCES `users` (`id`)
+);
+
+CREATE TABLE `cash_variances` (
+  `id` INT PRIMARY KEY AUTO_INCREMENT,
+  `close_id` INT NOT NULL,
+  `branch_id` INT NOT NULL,
+  `business_date` DATE NOT NULL,
+  `expected_cash` DECIMAL(10,2) NOT NULL,
+  `counted_cash` DECIMAL(10,2) NOT NULL,
+  `variance` DECIMAL(10,2) NOT NULL,
+  `note` TEXT NOT NULL,
+  `created_by` INT NOT NULL,
+  `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+  CONSTRAINT fk_cash_variances_close_id FOREIGN KEY (`close_id`) REFERENCES `cash_book_closes` (`id`),
+  CONSTRAINT fk_cash_variances_branch_id FOREIGN KEY (`branch_id`) REFERENCES `branches` (`id`),
+  CONSTRAINT fk_cash_variances_created_by FOREIGN KEY (`created_by`) REFERENCES `users` (`id`)
+);
+
+CREATE INDEX idx_branch_expenses_branch_date ON `branch_expenses` (`branch_id`, `expense_date`);
+
+INSERT INTO `ledger_accounts` (`code`, `name`, `account_type`) VALUES
+  ('5000', 'Operating Expenses', 'EXPENSE'),
+  ('5100', 'Cash Over and Short', 'EXPENSE');
+
+ALTER TABLE `journal_entries` MODIFY `entry_type` ENUM(
+  'DISBURSEMENT',
+  'PROCESSING_FEE',
+  'PAYMENT_RECEIVED',
+  'PAYMENT_ADJUSTED',
+  'PAYMENT_ALLOCATED',
+  'PAYMENT_REVERSED',
+  'PAYMENT_DELETED',
+  'OVERPAYMENT_APPLIED',
+  'OVERPAYMENT_REFUND',
+  'OVERPAYMENT_REFUND_FAILED',
+  'EXPENSE',
+  'CASH_VARIANCE'
+) NOT NULL;
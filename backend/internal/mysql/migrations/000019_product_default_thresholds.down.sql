@@ -0,0 +1,4 @@
+ALTER TABLE product_default_thresholds DROP FOREIGN KEY fk_product_default_thresholds_product_id;
+ALTER TABLE product_default_thresholds DROP FOREIGN KEY fk_product_default_thresholds_updated_by;
+
+DROP TABLE IF EXISTS product_default_thresholds;
@@ -0,0 +1,9 @@
+CREATE TABLE `product_default_thresholds` (
+  `product_id` INT PRIMARY KEY,
+  `grace_days` INT UNSIGNED NOT NULL,
+  `updated_by` INT NOT NULL,
+  `updated_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+  CONSTRAINT fk_product_default_thresholds_product_id FOREIGN KEY (`product_id`) REFERENCES `products` (`id`),
+  CONSTRAINT fk_product_default_thresholds_updated_by FOREIGN KEY (`updated_by`) REFERENCES `users` (`id`)
+);
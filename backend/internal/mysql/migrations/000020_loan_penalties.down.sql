@@ -0,0 +1,27 @@
+ALTER TABLE `journal_entries` MODIFY `entry_type` ENUM(
+  'DISBURSEMENT',
+  'PROCESSING_FEE',
+  'PAYMENT_RECEIVED',
+  'PAYMENT_ADJUSTED',
+  'PAYMENT_ALLOCATED',
+  'PAYMENT_REVERSED',
+  'PAYMENT_DELETED',
+  'OVERPAYMENT_APPLIED',
+  'OVERPAYMENT_REFUND',
+  'OVERPAYMENT_REFUND_FAILED',
+  'EXPENSE',
+  'CASH_VARIANCE'
+) NOT NULL;
+
+DELETE FROM `ledger_accounts` WHERE `code` IN ('1200', '4200');
+
+ALTER TABLE payment_allocations DROP FOREIGN KEY fk_payment_allocations_penalty_id;
+ALTER TABLE payment_allocations DROP COLUMN penalty_id;
+
+ALTER TABLE installment_penalties DROP FOREIGN KEY fk_installment_penalties_loan_id;
+ALTER TABLE installment_penalties DROP FOREIGN KEY fk_installment_penalties_installment_id;
+ALTER TABLE product_penalty_rules DROP FOREIGN KEY fk_product_penalty_rules_product_id;
+ALTER TABLE product_penalty_rules DROP FOREIGN KEY fk_product_penalty_rules_updated_by;
+
+DROP TABLE IF EXISTS installment_penalties;
+DROP TABLE IF EXISTS product_penalty_rules;
@@ -0,0 +1,54 @@
+CREATE TABLE `product_penalty_rules` (
+  `product_id` INT PRIMARY KEY,
+  `penalty_type` ENUM('FLAT', 'PERCENTAGE') NOT NULL,
+  `rate` DECIMAL(10,2) NOT NULL,
+  `frequency` ENUM('DAILY', 'WEEKLY') NOT NULL,
+  `cap_amount` DECIMAL(10,2) NULL,
+  `updated_by` INT NOT NULL,
+  `updated_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+  CONSTRAINT fk_product_penalty_rules_product_id FOREIGN KEY (`product_id`) REFERENCES `products` (`id`),
+  CONSTRAINT fk_product_penalty_rules_updated_by FOREIGN KEY (`updated_by`) REFERENCES `users` (`id`)
+);
+
+CREATE TABLE `installment_penalties` (
+  `id` INT PRIMARY KEY AUTO_INCREMENT,
+  `loan_id` INT NOT NULL,
+  `installment_id` INT NOT NULL,
+  `accrued_on` DATE NOT NULL,
+  `periods` INT UNSIGNED NOT NULL,
+  `amount` DECIMAL(10,2) NOT NULL,
+  `remaining_amount` DECIMAL(10,2) NOT NULL,
+  `paid` BOOLEAN NOT NULL DEFAULT FALSE,
+  `paid_at` TIMESTAMP NULL,
+  `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+  UNIQUE KEY uq_installment_penalties_installment_date (`installment_id`, `accrued_on`),
+  CONSTRAINT fk_installment_penalties_loan_id FOREIGN KEY (`loan_id`) REFERENCES `loans` (`id`),
+  CONSTRAINT fk_installment_penalties_installment_id FOREIGN KEY (`installment_id`) REFERENCES `installments` (`id`)
+);
+
+CREATE INDEX idx_installment_penalties_loan_id ON `installment_penalties` (`loan_id`);
+
+ALTER TABLE `payment_allocations` ADD COLUMN `penalty_id` INT NULL;
+ALTER TABLE `payment_allocations` ADD CONSTRAINT fk_payment_allocations_penalty_id FOREIGN KEY (`penalty_id`) REFERENCES `installment_penalties` (`id`);
+
+INSERT INTO `ledger_accounts` (`code`, `name`, `account_type`) VALUES
+  ('1200', 'Penalties Receivable', 'ASSET'),
+  ('4200', 'Penalty Income', 'INCOME');
+
+ALTER TABLE `journal_entries` MODIFY `entry_type` ENUM(
+  'DISBURSEMENT',
+  'PROCESSING_FEE',
+  'PAYMENT_RECEIVED',
+  'PAYMENT_ADJUSTED',
+  'PAYMENT_ALLOCATED',
+  'PAYMENT_REVERSED',
+  'PAYMENT_DELETED',
+  'OVERPAYMENT_APPLIED',
+  'OVERPAYMENT_REFUND',
+  'OVERPAYMENT_REFUND_FAILED',
+  'EXPENSE',
+  'CASH_VARIANCE',
+  'PENALTY_ACCRUED'
+) NOT NULL;
@@ -0,0 +1,8 @@
+ALTER TABLE installments DROP FOREIGN KEY fk_installments_restructure_id;
+ALTER TABLE installments DROP COLUMN restructure_id;
+ALTER TABLE installments DROP COLUMN closed_at;
+
+ALTER TABLE loan_restructures DROP FOREIGN KEY fk_loan_restructures_loan_id;
+ALTER TABLE loan_restructures DROP FOREIGN KEY fk_loan_restructures_approved_by;
+
+DROP TABLE IF EXISTS loan_restructures;
@@ -0,0 +1,23 @@
+CREATE TABLE `loan_restructures` (
+  `id` INT PRIMARY KEY AUTO_INCREMENT,
+  `loan_id` INT NOT NULL,
+  `outstanding_amount` DECIMAL(10,2) NOT NULL,
+  `previous_total_installments` INT NOT NULL,
+  `previous_installments_period` INT NOT NULL,
+  `previous_due_date` DATE NULL,
+  `total_installments` INT NOT NULL,
+  `installments_period` INT NOT NULL,
+  `reason` TEXT NOT NULL,
+  `approved_by` INT NOT NULL,
+  `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+  CONSTRAINT fk_loan_restructures_loan_id FOREIGN KEY (`loan_id`) REFERENCES `loans` (`id`),
+  CONSTRAINT fk_loan_restructures_approved_by FOREIGN KEY (`approved_by`) REFERENCES `users` (`id`)
+);
+
+CREATE INDEX idx_loan_restructures_loan_id ON `loan_restructures` (`loan_id`);
+
+-- installments replaced by a restructure keep what was owed on them and are closed
+ALTER TABLE `installments` ADD COLUMN `closed_at` TIMESTAMP NULL;
+ALTER TABLE `installments` ADD COLUMN `restructure_id` INT NULL;
+ALTER TABLE `installments` ADD CONSTRAINT fk_installments_restructure_id FOREIGN KEY (`restructure_id`) REFERENCES `loan_restructures` (`id`);
@@ -0,0 +1,22 @@
+ALTER TABLE `journal_entries` MODIFY `entry_type` ENUM(
+  'DISBURSEMENT',
+  'PROCESSING_FEE',
+  'PAYMENT_RECEIVED',
+  'PAYMENT_ADJUSTED',
+  'PAYMENT_ALLOCATED',
+  'PAYMENT_REVERSED',
+  'PAYMENT_DELETED',
+  'OVERPAYMENT_APPLIED',
+  'OVERPAYMENT_REFUND',
+  'OVERPAYMENT_REFUND_FAILED',
+  'EXPENSE',
+  'CASH_VARIANCE',
+  'PENALTY_ACCRUED'
+) NOT NULL;
+
+ALTER TABLE loan_top_ups DROP FOREIGN KEY fk_loan_top_ups_loan_id;
+ALTER TABLE loan_top_ups DROP FOREIGN KEY fk_loan_top_ups_refinanced_loan_id;
+ALTER TABLE loan_top_ups DROP FOREIGN KEY fk_loan_top_ups_non_posted_id;
+ALTER TABLE loan_top_ups DROP FOREIGN KEY fk_loan_top_ups_created_by;
+
+DROP TABLE IF EXISTS loan_top_ups;
@@ -0,0 +1,35 @@
+CREATE TABLE `loan_top_ups` (
+  `id` INT PRIMARY KEY AUTO_INCREMENT,
+  `loan_id` INT NOT NULL,
+  `refinanced_loan_id` INT NOT NULL,
+  `non_posted_id` INT NOT NULL,
+  `settled_amount` DECIMAL(10,2) NOT NULL,
+  `penalties_settled` DECIMAL(10,2) NOT NULL,
+  `net_disbursed` DECIMAL(10,2) NOT NULL,
+  `created_by` INT NOT NULL,
+  `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+  UNIQUE KEY uq_loan_top_ups_loan_id (`loan_id`),
+  UNIQUE KEY uq_loan_top_ups_refinanced_loan_id (`refinanced_loan_id`),
+  CONSTRAINT fk_loan_top_ups_loan_id FOREIGN KEY (`loan_id`) REFERENCES `loans` (`id`),
+  CONSTRAINT fk_loan_top_ups_refinanced_loan_id FOREIGN KEY (`refinanced_loan_id`) REFERENCES `loans` (`id`),
+  CONSTRAINT fk_loan_top_ups_non_posted_id FOREIGN KEY (`non_posted_id`) REFERENCES `non_posted` (`id`),
+  CONSTRAINT fk_loan_top_ups_created_by FOREIGN KEY (`created_by`) REFERENCES `users` (`id`)
+);
+
+ALTER TABLE `journal_entries` MODIFY `entry_type` ENUM(
+  'DISBURSEMENT',
+  'PROCESSING_FEE',
+  'PAYMENT_RECEIVED',
+  'PAYMENT_ADJUSTED',
+  'PAYMENT_ALLOCATED',
+  'PAYMENT_REVERSED',
+  'PAYMENT_DELETED',
+  'OVERPAYMENT_APPLIED',
+  'OVERPAYMENT_REFUND',
+  'OVERPAYMENT_REFUND_FAILED',
+  'EXPENSE',
+  'CASH_VARIANCE',
+  'PENALTY_ACCRUED',
+  'LOAN_REFINANCED'
+) NOT NULL;
@@ -0,0 +1,23 @@
+ALTER TABLE `journal_entries` MODIFY `entry_type` ENUM(
+  'DISBURSEMENT',
+  'PROCESSING_FEE',
+  'PAYMENT_RECEIVED',
+  'PAYMENT_ADJUSTED',
+  'PAYMENT_ALLOCATED',
+  'PAYMENT_REVERSED',
+  'PAYMENT_DELETED',
+  'OVERPAYMENT_APPLIED',
+  'OVERPAYMENT_REFUND',
+  'OVERPAYMENT_REFUND_FAILED',
+  'EXPENSE',
+  'CASH_VARIANCE',
+  'PENALTY_ACCRUED',
+  'LOAN_REFINANCED'
+) NOT NULL;
+
+ALTER TABLE loan_settlement_quotes DROP FOREIGN KEY fk_loan_settlement_quotes_loan_id;
+ALTER TABLE loan_settlement_quotes DROP FOREIGN KEY fk_loan_settlement_quotes_quoted_by;
+ALTER TABLE loan_settlement_quotes DROP FOREIGN KEY fk_loan_settlement_quotes_non_posted_id;
+ALTER TABLE loan_settlement_quotes DROP FOREIGN KEY fk_loan_settlement_quotes_settled_by;
+
+DROP TABLE IF EXISTS loan_settlement_quotes;
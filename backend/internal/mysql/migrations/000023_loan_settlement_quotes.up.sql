@@ -0,0 +1,42 @@
+CREATE TABLE `loan_settlement_quotes` (
+  `id` INT PRIMARY KEY AUTO_INCREMENT,
+  `loan_id` INT NOT NULL,
+  `outstanding_amount` DECIMAL(10,2) NOT NULL,
+  `penalties_amount` DECIMAL(10,2) NOT NULL,
+  `unearned_interest` DECIMAL(10,2) NOT NULL,
+  `rebate_percent` DECIMAL(5,2) NOT NULL,
+  `rebate_amount` DECIMAL(10,2) NOT NULL,
+  `quoted_amount` DECIMAL(10,2) NOT NULL,
+  `status` ENUM('PENDING', 'SETTLED') NOT NULL DEFAULT 'PENDING',
+  `expires_at` TIMESTAMP NOT NULL,
+  `quoted_by` INT NOT NULL,
+  `non_posted_id` INT NULL,
+  `settled_by` INT NULL,
+  `settled_at` TIMESTAMP NULL,
+  `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+  CONSTRAINT fk_loan_settlement_quotes_loan_id FOREIGN KEY (`loan_id`) REFERENCES `loans` (`id`),
+  CONSTRAINT fk_loan_settlement_quotes_quoted_by FOREIGN KEY (`quoted_by`) REFERENCES `users` (`id`),
+  CONSTRAINT fk_loan_settlement_quotes_non_posted_id FOREIGN KEY (`non_posted_id`) REFERENCES `non_posted` (`id`),
+  CONSTRAINT fk_loan_settlement_quotes_settled_by FOREIGN KEY (`settled_by`) REFERENCES `users` (`id`)
+);
+
+CREATE INDEX idx_loan_settlement_quotes_loan_id ON `loan_settlement_quotes` (`loan_id`);
+
+ALTER TABLE `journal_entries` MODIFY `entry_type` ENUM(
+  'DISBURSEMENT',
+  'PROCESSING_FEE',
+  'PAYMENT_RECEIVED',
+  'PAYMENT_ADJUSTED',
+  'PAYMENT_ALLOCATED',
+  'PAYMENT_REVERSED',
+  'PAYMENT_DELETED',
+  'OVERPAYMENT_APPLIED',
+  'OVERPAYMENT_REFUND',
+  'OVERPAYMENT_REFUND_FAILED',
+  'EXPENSE',
+  'CASH_VARIANCE',
+  'PENALTY_ACCRUED',
+  'LOAN_REFINANCED',
+  'INTEREST_REBATE'
+) NOT NULL;
@@ -0,0 +1 @@
+ALTER TABLE `non_posted` DROP COLUMN `cash_receipt`;
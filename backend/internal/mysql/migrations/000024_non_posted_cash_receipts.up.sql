@@ -0,0 +1,15 @@
+ALTER TABLE `non_posted` ADD COLUMN `cash_receipt` BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- payments keyed in at a branch by its users, m-pesa payments assigned by hand were switched
+-- to INTERNAL but still have their m-pesa callback
+UPDATE `non_posted` np
+JOIN `users` u ON np.assigned_by = u.email
+SET np.cash_receipt = TRUE
+WHERE np.transaction_source = 'INTERNAL'
+    AND np.transaction_number NOT LIKE 'OVERPAYMENT-%'
+    AND np.transaction_number NOT LIKE 'TOPUP-%'
+    AND np.transaction_number NOT LIKE 'SETTLEMENT-%'
+    AND NOT EXISTS (
+        SELECT 1 FROM `processed_callbacks` pc
+        WHERE pc.non_posted_id = np.id AND pc.transaction_source = 'MPESA'
+    );
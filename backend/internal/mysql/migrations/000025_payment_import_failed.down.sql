@@ -0,0 +1,4 @@
+UPDATE payment_import_batches SET status = 'POSTING' WHERE status = 'FAILED';
+
+ALTER TABLE payment_import_batches
+  MODIFY `status` ENUM('PREVIEW', 'POSTING', 'POSTED', 'ROLLED_BACK') NOT NULL DEFAULT 'PREVIEW';
@@ -0,0 +1,2 @@
+ALTER TABLE payment_import_batches
+  MODIFY `status` ENUM('PREVIEW', 'POSTING', 'POSTED', 'FAILED', 'ROLLED_BACK') NOT NULL DEFAULT 'PREVIEW';
@@ -13,6 +13,7 @@ import (
 	context "context"
 	sql "database/sql"
 	reflect "reflect"
+	time "time"
 
 	generated "github.com/EmilioCliff/kokomed-fin/backend/internal/mysql/generated"
 	gomock "go.uber.org/mock/gomock"
@@ -72,6 +73,21 @@ func (mr *MockQuerierMockRecorder) CheckActiveLoanForClient(ctx, clientID any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckActiveLoanForClient", reflect.TypeOf((*MockQuerier)(nil).CheckActiveLoanForClient), ctx, clientID)
 }
 
+// CheckCashBookClosed mocks base method.
+func (m *MockQuerier) CheckCashBookClosed(ctx context.Context, arg generated.CheckCashBookClosedParams) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckCashBookClosed", ctx, arg)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckCashBookClosed indicates an expected call of CheckCashBookClosed.
+func (mr *MockQuerierMockRecorder) CheckCashBookClosed(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckCashBookClosed", reflect.TypeOf((*MockQuerier)(nil).CheckCashBookClosed), ctx, arg)
+}
+
 // CheckUserExistance mocks base method.
 func (m *MockQuerier) CheckUserExistance(ctx context.Context, email string) (int64, error) {
 	m.ctrl.T.Helper()
@@ -87,6 +103,51 @@ func (mr *MockQuerierMockRecorder) CheckUserExistance(ctx, email any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckUserExistance", reflect.TypeOf((*MockQuerier)(nil).CheckUserExistance), ctx, email)
 }
 
+// ClaimPaymentImportBatch mocks base method.
+func (m *MockQuerier) ClaimPaymentImportBatch(ctx context.Context, id uint32) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimPaymentImportBatch", ctx, id)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimPaymentImportBatch indicates an expected call of ClaimPaymentImportBatch.
+func (mr *MockQuerierMockRecorder) ClaimPaymentImportBatch(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimPaymentImportBatch", reflect.TypeOf((*MockQuerier)(nil).ClaimPaymentImportBatch), ctx, id)
+}
+
+// CloseUnpaidInstallments mocks base method.
+func (m *MockQuerier) CloseUnpaidInstallments(ctx context.Context, arg generated.CloseUnpaidInstallmentsParams) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseUnpaidInstallments", ctx, arg)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CloseUnpaidInstallments indicates an expected call of CloseUnpaidInstallments.
+func (mr *MockQuerierMockRecorder) CloseUnpaidInstallments(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseUnpaidInstallments", reflect.TypeOf((*MockQuerier)(nil).CloseUnpaidInstallments), ctx, arg)
+}
+
+// CountAccountLedger mocks base method.
+func (m *MockQuerier) CountAccountLedger(ctx context.Context, arg generated.CountAccountLedgerParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountAccountLedger", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAccountLedger indicates an expected call of CountAccountLedger.
+func (mr *MockQuerierMockRecorder) CountAccountLedger(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAccountLedger", reflect.TypeOf((*MockQuerier)(nil).CountAccountLedger), ctx, arg)
+}
+
 // CountBranchesByCategory mocks base method.
 func (m *MockQuerier) CountBranchesByCategory(ctx context.Context, arg generated.CountBranchesByCategoryParams) (int64, error) {
 	m.ctrl.T.Helper()
@@ -102,6 +163,36 @@ func (mr *MockQuerierMockRecorder) CountBranchesByCategory(ctx, arg any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountBranchesByCategory", reflect.TypeOf((*MockQuerier)(nil).CountBranchesByCategory), ctx, arg)
 }
 
+// CountCashVariances mocks base method.
+func (m *MockQuerier) CountCashVariances(ctx context.Context, branchID uint32) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountCashVariances", ctx, branchID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountCashVariances indicates an expected call of CountCashVariances.
+func (mr *MockQuerierMockRecorder) CountCashVariances(ctx, branchID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountCashVariances", reflect.TypeOf((*MockQuerier)(nil).CountCashVariances), ctx, branchID)
+}
+
+// CountClientLoans mocks base method.
+func (m *MockQuerier) CountClientLoans(ctx context.Context, arg generated.CountClientLoansParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountClientLoans", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountClientLoans indicates an expected call of CountClientLoans.
+func (mr *MockQuerierMockRecorder) CountClientLoans(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountClientLoans", reflect.TypeOf((*MockQuerier)(nil).CountClientLoans), ctx, arg)
+}
+
 // CountClientsByCategory mocks base method.
 func (m *MockQuerier) CountClientsByCategory(ctx context.Context, arg generated.CountClientsByCategoryParams) (int64, error) {
 	m.ctrl.T.Helper()
@@ -192,6 +283,96 @@ func (mr *MockQuerierMockRecorder) CountNonPostedByCategory(ctx, arg any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountNonPostedByCategory", reflect.TypeOf((*MockQuerier)(nil).CountNonPostedByCategory), ctx, arg)
 }
 
+// CountOverdueInstallments mocks base method.
+func (m *MockQuerier) CountOverdueInstallments(ctx context.Context, arg generated.CountOverdueInstallmentsParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountOverdueInstallments", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountOverdueInstallments indicates an expected call of CountOverdueInstallments.
+func (mr *MockQuerierMockRecorder) CountOverdueInstallments(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountOverdueInstallments", reflect.TypeOf((*MockQuerier)(nil).CountOverdueInstallments), ctx, arg)
+}
+
+// CountOverpaymentRefunds mocks base method.
+func (m *MockQuerier) CountOverpaymentRefunds(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountOverpaymentRefunds", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountOverpaymentRefunds indicates an expected call of CountOverpaymentRefunds.
+func (mr *MockQuerierMockRecorder) CountOverpaymentRefunds(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountOverpaymentRefunds", reflect.TypeOf((*MockQuerier)(nil).CountOverpaymentRefunds), ctx)
+}
+
+// CountOverpaymentRefundsByStatus mocks base method.
+func (m *MockQuerier) CountOverpaymentRefundsByStatus(ctx context.Context, status generated.OverpaymentRefundsStatus) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountOverpaymentRefundsByStatus", ctx, status)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountOverpaymentRefundsByStatus indicates an expected call of CountOverpaymentRefundsByStatus.
+func (mr *MockQuerierMockRecorder) CountOverpaymentRefundsByStatus(ctx, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountOverpaymentRefundsByStatus", reflect.TypeOf((*MockQuerier)(nil).CountOverpaymentRefundsByStatus), ctx, status)
+}
+
+// CountPaymentImportBatches mocks base method.
+func (m *MockQuerier) CountPaymentImportBatches(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountPaymentImportBatches", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountPaymentImportBatches indicates an expected call of CountPaymentImportBatches.
+func (mr *MockQuerierMockRecorder) CountPaymentImportBatches(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountPaymentImportBatches", reflect.TypeOf((*MockQuerier)(nil).CountPaymentImportBatches), ctx)
+}
+
+// CountPaymentValidationLogs mocks base method.
+func (m *MockQuerier) CountPaymentValidationLogs(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountPaymentValidationLogs", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountPaymentValidationLogs indicates an expected call of CountPaymentValidationLogs.
+func (mr *MockQuerierMockRecorder) CountPaymentValidationLogs(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountPaymentValidationLogs", reflect.TypeOf((*MockQuerier)(nil).CountPaymentValidationLogs), ctx)
+}
+
+// CountStatementReconciliations mocks base method.
+func (m *MockQuerier) CountStatementReconciliations(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountStatementReconciliations", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountStatementReconciliations indicates an expected call of CountStatementReconciliations.
+func (mr *MockQuerierMockRecorder) CountStatementReconciliations(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountStatementReconciliations", reflect.TypeOf((*MockQuerier)(nil).CountStatementReconciliations), ctx)
+}
+
 // CountUnpaidInstallmentsData mocks base method.
 func (m *MockQuerier) CountUnpaidInstallmentsData(ctx context.Context, arg generated.CountUnpaidInstallmentsDataParams) (int64, error) {
 	m.ctrl.T.Helper()
@@ -207,6 +388,21 @@ func (mr *MockQuerierMockRecorder) CountUnpaidInstallmentsData(ctx, arg any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUnpaidInstallmentsData", reflect.TypeOf((*MockQuerier)(nil).CountUnpaidInstallmentsData), ctx, arg)
 }
 
+// CountUnpaidPenaltiesByLoan mocks base method.
+func (m *MockQuerier) CountUnpaidPenaltiesByLoan(ctx context.Context, loanID uint32) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUnpaidPenaltiesByLoan", ctx, loanID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUnpaidPenaltiesByLoan indicates an expected call of CountUnpaidPenaltiesByLoan.
+func (mr *MockQuerierMockRecorder) CountUnpaidPenaltiesByLoan(ctx, loanID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUnpaidPenaltiesByLoan", reflect.TypeOf((*MockQuerier)(nil).CountUnpaidPenaltiesByLoan), ctx, loanID)
+}
+
 // CountUsersByCategory mocks base method.
 func (m *MockQuerier) CountUsersByCategory(ctx context.Context, arg generated.CountUsersByCategoryParams) (int64, error) {
 	m.ctrl.T.Helper()
@@ -222,6 +418,36 @@ func (mr *MockQuerierMockRecorder) CountUsersByCategory(ctx, arg any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUsersByCategory", reflect.TypeOf((*MockQuerier)(nil).CountUsersByCategory), ctx, arg)
 }
 
+// CreateAssignmentRule mocks base method.
+func (m *MockQuerier) CreateAssignmentRule(ctx context.Context, arg generated.CreateAssignmentRuleParams) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAssignmentRule", ctx, arg)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAssignmentRule indicates an expected call of CreateAssignmentRule.
+func (mr *MockQuerierMockRecorder) CreateAssignmentRule(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAssignmentRule", reflect.TypeOf((*MockQuerier)(nil).CreateAssignmentRule), ctx, arg)
+}
+
+// CreateBlacklistedClient mocks base method.
+func (m *MockQuerier) CreateBlacklistedClient(ctx context.Context, arg generated.CreateBlacklistedClientParams) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBlacklistedClient", ctx, arg)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBlacklistedClient indicates an expected call of CreateBlacklistedClient.
+func (mr *MockQuerierMockRecorder) CreateBlacklistedClient(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBlacklistedClient", reflect.TypeOf((*MockQuerier)(nil).CreateBlacklistedClient), ctx, arg)
+}
+
 // CreateBranch mocks base method.
 func (m *MockQuerier) CreateBranch(ctx context.Context, name string) (sql.Result, error) {
 	m.ctrl.T.Helper()
@@ -237,6 +463,66 @@ func (mr *MockQuerierMockRecorder) CreateBranch(ctx, name any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBranch", reflect.TypeOf((*MockQuerier)(nil).CreateBranch), ctx, name)
 }
 
+// CreateBranchExpense mocks base method.
+func (m *MockQuerier) CreateBranchExpense(ctx context.Context, arg generated.CreateBranchExpenseParams) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBranchExpense", ctx, arg)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBranchExpense indicates an expected call of CreateBranchExpense.
+func (mr *MockQuerierMockRecorder) CreateBranchExpense(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBranchExpense", reflect.TypeOf((*MockQuerier)(nil).CreateBranchExpense), ctx, arg)
+}
+
+// CreateCallbackPayload mocks base method.
+func (m *MockQuerier) CreateCallbackPayload(ctx context.Context, arg generated.CreateCallbackPayloadParams) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCallbackPayload", ctx, arg)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCallbackPayload indicates an expected call of CreateCallbackPayload.
+func (mr *MockQuerierMockRecorder) CreateCallbackPayload(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCallbackPayload", reflect.TypeOf((*MockQuerier)(nil).CreateCallbackPayload), ctx, arg)
+}
+
+// CreateCashBookClose mocks base method.
+func (m *MockQuerier) CreateCashBookClose(ctx context.Context, arg generated.CreateCashBookCloseParams) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCashBookClose", ctx, arg)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCashBookClose indicates an expected call of CreateCashBookClose.
+func (mr *MockQuerierMockRecorder) CreateCashBookClose(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCashBookClose", reflect.TypeOf((*MockQuerier)(nil).CreateCashBookClose), ctx, arg)
+}
+
+// CreateCashVariance mocks base method.
+func (m *MockQuerier) CreateCashVariance(ctx context.Context, arg generated.CreateCashVarianceParams) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCashVariance", ctx, arg)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCashVariance indicates an expected call of CreateCashVariance.
+func (mr *MockQuerierMockRecorder) CreateCashVariance(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCashVariance", reflect.TypeOf((*MockQuerier)(nil).CreateCashVariance), ctx, arg)
+}
+
 // CreateClient mocks base method.
 func (m *MockQuerier) CreateClient(ctx context.Context, arg generated.CreateClientParams) (sql.Result, error) {
 	m.ctrl.T.Helper()
@@ -282,157 +568,470 @@ func (mr *MockQuerierMockRecorder) CreateInstallment(ctx, arg any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInstallment", reflect.TypeOf((*MockQuerier)(nil).CreateInstallment), ctx, arg)
 }
 
-// CreateLoan mocks base method.
-func (m *MockQuerier) CreateLoan(ctx context.Context, arg generated.CreateLoanParams) (sql.Result, error) {
+// CreateInstallmentPenalty mocks base method.
+func (m *MockQuerier) CreateInstallmentPenalty(ctx context.Context, arg generated.CreateInstallmentPenaltyParams) (sql.Result, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateLoan", ctx, arg)
+	ret := m.ctrl.Call(m, "CreateInstallmentPenalty", ctx, arg)
 	ret0, _ := ret[0].(sql.Result)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateLoan indicates an expected call of CreateLoan.
-func (mr *MockQuerierMockRecorder) CreateLoan(ctx, arg any) *gomock.Call {
+// CreateInstallmentPenalty indicates an expected call of CreateInstallmentPenalty.
+func (mr *MockQuerierMockRecorder) CreateInstallmentPenalty(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLoan", reflect.TypeOf((*MockQuerier)(nil).CreateLoan), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInstallmentPenalty", reflect.TypeOf((*MockQuerier)(nil).CreateInstallmentPenalty), ctx, arg)
 }
 
-// CreateNonPosted mocks base method.
-func (m *MockQuerier) CreateNonPosted(ctx context.Context, arg generated.CreateNonPostedParams) (sql.Result, error) {
+// CreateJournalEntry mocks base method.
+func (m *MockQuerier) CreateJournalEntry(ctx context.Context, arg generated.CreateJournalEntryParams) (sql.Result, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateNonPosted", ctx, arg)
+	ret := m.ctrl.Call(m, "CreateJournalEntry", ctx, arg)
 	ret0, _ := ret[0].(sql.Result)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateNonPosted indicates an expected call of CreateNonPosted.
-func (mr *MockQuerierMockRecorder) CreateNonPosted(ctx, arg any) *gomock.Call {
+// CreateJournalEntry indicates an expected call of CreateJournalEntry.
+func (mr *MockQuerierMockRecorder) CreateJournalEntry(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNonPosted", reflect.TypeOf((*MockQuerier)(nil).CreateNonPosted), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateJournalEntry", reflect.TypeOf((*MockQuerier)(nil).CreateJournalEntry), ctx, arg)
 }
 
-// CreatePaymentAllocation mocks base method.
-func (m *MockQuerier) CreatePaymentAllocation(ctx context.Context, arg generated.CreatePaymentAllocationParams) (sql.Result, error) {
+// CreateJournalLine mocks base method.
+func (m *MockQuerier) CreateJournalLine(ctx context.Context, arg generated.CreateJournalLineParams) (sql.Result, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreatePaymentAllocation", ctx, arg)
+	ret := m.ctrl.Call(m, "CreateJournalLine", ctx, arg)
 	ret0, _ := ret[0].(sql.Result)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreatePaymentAllocation indicates an expected call of CreatePaymentAllocation.
-func (mr *MockQuerierMockRecorder) CreatePaymentAllocation(ctx, arg any) *gomock.Call {
+// CreateJournalLine indicates an expected call of CreateJournalLine.
+func (mr *MockQuerierMockRecorder) CreateJournalLine(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePaymentAllocation", reflect.TypeOf((*MockQuerier)(nil).CreatePaymentAllocation), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateJournalLine", reflect.TypeOf((*MockQuerier)(nil).CreateJournalLine), ctx, arg)
 }
 
-// CreateProduct mocks base method.
-func (m *MockQuerier) CreateProduct(ctx context.Context, arg generated.CreateProductParams) (sql.Result, error) {
+// CreateLoan mocks base method.
+func (m *MockQuerier) CreateLoan(ctx context.Context, arg generated.CreateLoanParams) (sql.Result, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateProduct", ctx, arg)
+	ret := m.ctrl.Call(m, "CreateLoan", ctx, arg)
 	ret0, _ := ret[0].(sql.Result)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateProduct indicates an expected call of CreateProduct.
-func (mr *MockQuerierMockRecorder) CreateProduct(ctx, arg any) *gomock.Call {
+// CreateLoan indicates an expected call of CreateLoan.
+func (mr *MockQuerierMockRecorder) CreateLoan(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProduct", reflect.TypeOf((*MockQuerier)(nil).CreateProduct), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLoan", reflect.TypeOf((*MockQuerier)(nil).CreateLoan), ctx, arg)
 }
 
-// CreateUser mocks base method.
-func (m *MockQuerier) CreateUser(ctx context.Context, arg generated.CreateUserParams) (sql.Result, error) {
+// CreateLoanDisbursement mocks base method.
+func (m *MockQuerier) CreateLoanDisbursement(ctx context.Context, arg generated.CreateLoanDisbursementParams) (sql.Result, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateUser", ctx, arg)
+	ret := m.ctrl.Call(m, "CreateLoanDisbursement", ctx, arg)
 	ret0, _ := ret[0].(sql.Result)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateUser indicates an expected call of CreateUser.
-func (mr *MockQuerierMockRecorder) CreateUser(ctx, arg any) *gomock.Call {
+// CreateLoanDisbursement indicates an expected call of CreateLoanDisbursement.
+func (mr *MockQuerierMockRecorder) CreateLoanDisbursement(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockQuerier)(nil).CreateUser), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLoanDisbursement", reflect.TypeOf((*MockQuerier)(nil).CreateLoanDisbursement), ctx, arg)
 }
 
-// DashBoardDataHelper mocks base method.
-func (m *MockQuerier) DashBoardDataHelper(ctx context.Context) (generated.DashBoardDataHelperRow, error) {
+// CreateLoanRestructure mocks base method.
+func (m *MockQuerier) CreateLoanRestructure(ctx context.Context, arg generated.CreateLoanRestructureParams) (sql.Result, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DashBoardDataHelper", ctx)
-	ret0, _ := ret[0].(generated.DashBoardDataHelperRow)
+	ret := m.ctrl.Call(m, "CreateLoanRestructure", ctx, arg)
+	ret0, _ := ret[0].(sql.Result)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// DashBoardDataHelper indicates an expected call of DashBoardDataHelper.
-func (mr *MockQuerierMockRecorder) DashBoardDataHelper(ctx any) *gomock.Call {
+// CreateLoanRestructure indicates an expected call of CreateLoanRestructure.
+func (mr *MockQuerierMockRecorder) CreateLoanRestructure(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DashBoardDataHelper", reflect.TypeOf((*MockQuerier)(nil).DashBoardDataHelper), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLoanRestructure", reflect.TypeOf((*MockQuerier)(nil).CreateLoanRestructure), ctx, arg)
 }
 
-// DashBoardInactiveLoans mocks base method.
-func (m *MockQuerier) DashBoardInactiveLoans(ctx context.Context) ([]generated.DashBoardInactiveLoansRow, error) {
+// CreateLoanSettlementQuote mocks base method.
+func (m *MockQuerier) CreateLoanSettlementQuote(ctx context.Context, arg generated.CreateLoanSettlementQuoteParams) (sql.Result, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DashBoardInactiveLoans", ctx)
-	ret0, _ := ret[0].([]generated.DashBoardInactiveLoansRow)
+	ret := m.ctrl.Call(m, "CreateLoanSettlementQuote", ctx, arg)
+	ret0, _ := ret[0].(sql.Result)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// DashBoardInactiveLoans indicates an expected call of DashBoardInactiveLoans.
-func (mr *MockQuerierMockRecorder) DashBoardInactiveLoans(ctx any) *gomock.Call {
+// CreateLoanSettlementQuote indicates an expected call of CreateLoanSettlementQuote.
+func (mr *MockQuerierMockRecorder) CreateLoanSettlementQuote(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DashBoardInactiveLoans", reflect.TypeOf((*MockQuerier)(nil).DashBoardInactiveLoans), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLoanSettlementQuote", reflect.TypeOf((*MockQuerier)(nil).CreateLoanSettlementQuote), ctx, arg)
 }
 
-// DashBoardRecentsPayments mocks base method.
-func (m *MockQuerier) DashBoardRecentsPayments(ctx context.Context) ([]generated.DashBoardRecentsPaymentsRow, error) {
+// CreateLoanTopUp mocks base method.
+func (m *MockQuerier) CreateLoanTopUp(ctx context.Context, arg generated.CreateLoanTopUpParams) (sql.Result, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DashBoardRecentsPayments", ctx)
-	ret0, _ := ret[0].([]generated.DashBoardRecentsPaymentsRow)
+	ret := m.ctrl.Call(m, "CreateLoanTopUp", ctx, arg)
+	ret0, _ := ret[0].(sql.Result)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// DashBoardRecentsPayments indicates an expected call of DashBoardRecentsPayments.
-func (mr *MockQuerierMockRecorder) DashBoardRecentsPayments(ctx any) *gomock.Call {
+// CreateLoanTopUp indicates an expected call of CreateLoanTopUp.
+func (mr *MockQuerierMockRecorder) CreateLoanTopUp(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DashBoardRecentsPayments", reflect.TypeOf((*MockQuerier)(nil).DashBoardRecentsPayments), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLoanTopUp", reflect.TypeOf((*MockQuerier)(nil).CreateLoanTopUp), ctx, arg)
 }
 
-// DeleteBranch mocks base method.
-func (m *MockQuerier) DeleteBranch(ctx context.Context, id uint32) error {
+// CreateNonPosted mocks base method.
+func (m *MockQuerier) CreateNonPosted(ctx context.Context, arg generated.CreateNonPostedParams) (sql.Result, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteBranch", ctx, id)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "CreateNonPosted", ctx, arg)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteBranch indicates an expected call of DeleteBranch.
-func (mr *MockQuerierMockRecorder) DeleteBranch(ctx, id any) *gomock.Call {
+// CreateNonPosted indicates an expected call of CreateNonPosted.
+func (mr *MockQuerierMockRecorder) CreateNonPosted(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBranch", reflect.TypeOf((*MockQuerier)(nil).DeleteBranch), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNonPosted", reflect.TypeOf((*MockQuerier)(nil).CreateNonPosted), ctx, arg)
 }
 
-// DeleteClient mocks base method.
-func (m *MockQuerier) DeleteClient(ctx context.Context, id uint32) (sql.Result, error) {
+// CreateOverpaymentRefund mocks base method.
+func (m *MockQuerier) CreateOverpaymentRefund(ctx context.Context, arg generated.CreateOverpaymentRefundParams) (sql.Result, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteClient", ctx, id)
+	ret := m.ctrl.Call(m, "CreateOverpaymentRefund", ctx, arg)
 	ret0, _ := ret[0].(sql.Result)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// DeleteClient indicates an expected call of DeleteClient.
-func (mr *MockQuerierMockRecorder) DeleteClient(ctx, id any) *gomock.Call {
+// CreateOverpaymentRefund indicates an expected call of CreateOverpaymentRefund.
+func (mr *MockQuerierMockRecorder) CreateOverpaymentRefund(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteClient", reflect.TypeOf((*MockQuerier)(nil).DeleteClient), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOverpaymentRefund", reflect.TypeOf((*MockQuerier)(nil).CreateOverpaymentRefund), ctx, arg)
 }
 
-// DeleteLoan mocks base method.
-func (m *MockQuerier) DeleteLoan(ctx context.Context, id uint32) error {
+// CreatePaymentAllocation mocks base method.
+func (m *MockQuerier) CreatePaymentAllocation(ctx context.Context, arg generated.CreatePaymentAllocationParams) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePaymentAllocation", ctx, arg)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePaymentAllocation indicates an expected call of CreatePaymentAllocation.
+func (mr *MockQuerierMockRecorder) CreatePaymentAllocation(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePaymentAllocation", reflect.TypeOf((*MockQuerier)(nil).CreatePaymentAllocation), ctx, arg)
+}
+
+// CreatePaymentImportBatch mocks base method.
+func (m *MockQuerier) CreatePaymentImportBatch(ctx context.Context, arg generated.CreatePaymentImportBatchParams) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePaymentImportBatch", ctx, arg)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePaymentImportBatch indicates an expected call of CreatePaymentImportBatch.
+func (mr *MockQuerierMockRecorder) CreatePaymentImportBatch(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePaymentImportBatch", reflect.TypeOf((*MockQuerier)(nil).CreatePaymentImportBatch), ctx, arg)
+}
+
+// CreatePaymentImportRow mocks base method.
+func (m *MockQuerier) CreatePaymentImportRow(ctx context.Context, arg generated.CreatePaymentImportRowParams) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePaymentImportRow", ctx, arg)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePaymentImportRow indicates an expected call of CreatePaymentImportRow.
+func (mr *MockQuerierMockRecorder) CreatePaymentImportRow(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePaymentImportRow", reflect.TypeOf((*MockQuerier)(nil).CreatePaymentImportRow), ctx, arg)
+}
+
+// CreatePaymentReassignment mocks base method.
+func (m *MockQuerier) CreatePaymentReassignment(ctx context.Context, arg generated.CreatePaymentReassignmentParams) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePaymentReassignment", ctx, arg)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePaymentReassignment indicates an expected call of CreatePaymentReassignment.
+func (mr *MockQuerierMockRecorder) CreatePaymentReassignment(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePaymentReassignment", reflect.TypeOf((*MockQuerier)(nil).CreatePaymentReassignment), ctx, arg)
+}
+
+// CreatePaymentSplit mocks base method.
+func (m *MockQuerier) CreatePaymentSplit(ctx context.Context, arg generated.CreatePaymentSplitParams) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePaymentSplit", ctx, arg)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePaymentSplit indicates an expected call of CreatePaymentSplit.
+func (mr *MockQuerierMockRecorder) CreatePaymentSplit(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePaymentSplit", reflect.TypeOf((*MockQuerier)(nil).CreatePaymentSplit), ctx, arg)
+}
+
+// CreatePaymentSplitPortion mocks base method.
+func (m *MockQuerier) CreatePaymentSplitPortion(ctx context.Context, arg generated.CreatePaymentSplitPortionParams) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePaymentSplitPortion", ctx, arg)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePaymentSplitPortion indicates an expected call of CreatePaymentSplitPortion.
+func (mr *MockQuerierMockRecorder) CreatePaymentSplitPortion(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePaymentSplitPortion", reflect.TypeOf((*MockQuerier)(nil).CreatePaymentSplitPortion), ctx, arg)
+}
+
+// CreatePaymentValidationLog mocks base method.
+func (m *MockQuerier) CreatePaymentValidationLog(ctx context.Context, arg generated.CreatePaymentValidationLogParams) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePaymentValidationLog", ctx, arg)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePaymentValidationLog indicates an expected call of CreatePaymentValidationLog.
+func (mr *MockQuerierMockRecorder) CreatePaymentValidationLog(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePaymentValidationLog", reflect.TypeOf((*MockQuerier)(nil).CreatePaymentValidationLog), ctx, arg)
+}
+
+// CreateProcessedCallback mocks base method.
+func (m *MockQuerier) CreateProcessedCallback(ctx context.Context, arg generated.CreateProcessedCallbackParams) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProcessedCallback", ctx, arg)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateProcessedCallback indicates an expected call of CreateProcessedCallback.
+func (mr *MockQuerierMockRecorder) CreateProcessedCallback(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProcessedCallback", reflect.TypeOf((*MockQuerier)(nil).CreateProcessedCallback), ctx, arg)
+}
+
+// CreateProduct mocks base method.
+func (m *MockQuerier) CreateProduct(ctx context.Context, arg generated.CreateProductParams) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProduct", ctx, arg)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateProduct indicates an expected call of CreateProduct.
+func (mr *MockQuerierMockRecorder) CreateProduct(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProduct", reflect.TypeOf((*MockQuerier)(nil).CreateProduct), ctx, arg)
+}
+
+// CreateStatementReconciliation mocks base method.
+func (m *MockQuerier) CreateStatementReconciliation(ctx context.Context, arg generated.CreateStatementReconciliationParams) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateStatementReconciliation", ctx, arg)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateStatementReconciliation indicates an expected call of CreateStatementReconciliation.
+func (mr *MockQuerierMockRecorder) CreateStatementReconciliation(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateStatementReconciliation", reflect.TypeOf((*MockQuerier)(nil).CreateStatementReconciliation), ctx, arg)
+}
+
+// CreateStatementReconciliationItem mocks base method.
+func (m *MockQuerier) CreateStatementReconciliationItem(ctx context.Context, arg generated.CreateStatementReconciliationItemParams) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateStatementReconciliationItem", ctx, arg)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateStatementReconciliationItem indicates an expected call of CreateStatementReconciliationItem.
+func (mr *MockQuerierMockRecorder) CreateStatementReconciliationItem(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateStatementReconciliationItem", reflect.TypeOf((*MockQuerier)(nil).CreateStatementReconciliationItem), ctx, arg)
+}
+
+// CreateStkPushRequest mocks base method.
+func (m *MockQuerier) CreateStkPushRequest(ctx context.Context, arg generated.CreateStkPushRequestParams) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateStkPushRequest", ctx, arg)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateStkPushRequest indicates an expected call of CreateStkPushRequest.
+func (mr *MockQuerierMockRecorder) CreateStkPushRequest(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateStkPushRequest", reflect.TypeOf((*MockQuerier)(nil).CreateStkPushRequest), ctx, arg)
+}
+
+// CreateUser mocks base method.
+func (m *MockQuerier) CreateUser(ctx context.Context, arg generated.CreateUserParams) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUser", ctx, arg)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUser indicates an expected call of CreateUser.
+func (mr *MockQuerierMockRecorder) CreateUser(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockQuerier)(nil).CreateUser), ctx, arg)
+}
+
+// DashBoardDataHelper mocks base method.
+func (m *MockQuerier) DashBoardDataHelper(ctx context.Context) (generated.DashBoardDataHelperRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DashBoardDataHelper", ctx)
+	ret0, _ := ret[0].(generated.DashBoardDataHelperRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DashBoardDataHelper indicates an expected call of DashBoardDataHelper.
+func (mr *MockQuerierMockRecorder) DashBoardDataHelper(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DashBoardDataHelper", reflect.TypeOf((*MockQuerier)(nil).DashBoardDataHelper), ctx)
+}
+
+// DashBoardInactiveLoans mocks base method.
+func (m *MockQuerier) DashBoardInactiveLoans(ctx context.Context) ([]generated.DashBoardInactiveLoansRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DashBoardInactiveLoans", ctx)
+	ret0, _ := ret[0].([]generated.DashBoardInactiveLoansRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DashBoardInactiveLoans indicates an expected call of DashBoardInactiveLoans.
+func (mr *MockQuerierMockRecorder) DashBoardInactiveLoans(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DashBoardInactiveLoans", reflect.TypeOf((*MockQuerier)(nil).DashBoardInactiveLoans), ctx)
+}
+
+// DashBoardRecentsPayments mocks base method.
+func (m *MockQuerier) DashBoardRecentsPayments(ctx context.Context) ([]generated.DashBoardRecentsPaymentsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DashBoardRecentsPayments", ctx)
+	ret0, _ := ret[0].([]generated.DashBoardRecentsPaymentsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DashBoardRecentsPayments indicates an expected call of DashBoardRecentsPayments.
+func (mr *MockQuerierMockRecorder) DashBoardRecentsPayments(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DashBoardRecentsPayments", reflect.TypeOf((*MockQuerier)(nil).DashBoardRecentsPayments), ctx)
+}
+
+// DeductClientOverpayment mocks base method.
+func (m *MockQuerier) DeductClientOverpayment(ctx context.Context, arg generated.DeductClientOverpaymentParams) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeductClientOverpayment", ctx, arg)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeductClientOverpayment indicates an expected call of DeductClientOverpayment.
+func (mr *MockQuerierMockRecorder) DeductClientOverpayment(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeductClientOverpayment", reflect.TypeOf((*MockQuerier)(nil).DeductClientOverpayment), ctx, arg)
+}
+
+// DeleteAssignmentRule mocks base method.
+func (m *MockQuerier) DeleteAssignmentRule(ctx context.Context, id uint32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAssignmentRule", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAssignmentRule indicates an expected call of DeleteAssignmentRule.
+func (mr *MockQuerierMockRecorder) DeleteAssignmentRule(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAssignmentRule", reflect.TypeOf((*MockQuerier)(nil).DeleteAssignmentRule), ctx, id)
+}
+
+// DeleteBlacklistedClient mocks base method.
+func (m *MockQuerier) DeleteBlacklistedClient(ctx context.Context, clientID uint32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBlacklistedClient", ctx, clientID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBlacklistedClient indicates an expected call of DeleteBlacklistedClient.
+func (mr *MockQuerierMockRecorder) DeleteBlacklistedClient(ctx, clientID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBlacklistedClient", reflect.TypeOf((*MockQuerier)(nil).DeleteBlacklistedClient), ctx, clientID)
+}
+
+// DeleteBranch mocks base method.
+func (m *MockQuerier) DeleteBranch(ctx context.Context, id uint32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBranch", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBranch indicates an expected call of DeleteBranch.
+func (mr *MockQuerierMockRecorder) DeleteBranch(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBranch", reflect.TypeOf((*MockQuerier)(nil).DeleteBranch), ctx, id)
+}
+
+// DeleteClient mocks base method.
+func (m *MockQuerier) DeleteClient(ctx context.Context, id uint32) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteClient", ctx, id)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteClient indicates an expected call of DeleteClient.
+func (mr *MockQuerierMockRecorder) DeleteClient(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteClient", reflect.TypeOf((*MockQuerier)(nil).DeleteClient), ctx, id)
+}
+
+// DeleteLoan mocks base method.
+func (m *MockQuerier) DeleteLoan(ctx context.Context, id uint32) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "DeleteLoan", ctx, id)
 	ret0, _ := ret[0].(error)
@@ -475,1037 +1074,2339 @@ func (mr *MockQuerierMockRecorder) DeletePaymentAllocation(ctx, id any) *gomock.
 }
 
 // DeletePaymentAllocationsByNonPostedId mocks base method.
-func (m *MockQuerier) DeletePaymentAllocationsByNonPostedId(ctx context.Context, nonPostedID uint32) (sql.Result, error) {
+func (m *MockQuerier) DeletePaymentAllocationsByNonPostedId(ctx context.Context, arg generated.DeletePaymentAllocationsByNonPostedIdParams) (sql.Result, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeletePaymentAllocationsByNonPostedId", ctx, nonPostedID)
+	ret := m.ctrl.Call(m, "DeletePaymentAllocationsByNonPostedId", ctx, arg)
 	ret0, _ := ret[0].(sql.Result)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // DeletePaymentAllocationsByNonPostedId indicates an expected call of DeletePaymentAllocationsByNonPostedId.
-func (mr *MockQuerierMockRecorder) DeletePaymentAllocationsByNonPostedId(ctx, nonPostedID any) *gomock.Call {
+func (mr *MockQuerierMockRecorder) DeletePaymentAllocationsByNonPostedId(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePaymentAllocationsByNonPostedId", reflect.TypeOf((*MockQuerier)(nil).DeletePaymentAllocationsByNonPostedId), ctx, arg)
+}
+
+// DeleteProcessedCallbackByNonPosted mocks base method.
+func (m *MockQuerier) DeleteProcessedCallbackByNonPosted(ctx context.Context, nonPostedID uint32) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProcessedCallbackByNonPosted", ctx, nonPostedID)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteProcessedCallbackByNonPosted indicates an expected call of DeleteProcessedCallbackByNonPosted.
+func (mr *MockQuerierMockRecorder) DeleteProcessedCallbackByNonPosted(ctx, nonPostedID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePaymentAllocationsByNonPostedId", reflect.TypeOf((*MockQuerier)(nil).DeletePaymentAllocationsByNonPostedId), ctx, nonPostedID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProcessedCallbackByNonPosted", reflect.TypeOf((*MockQuerier)(nil).DeleteProcessedCallbackByNonPosted), ctx, nonPostedID)
 }
 
 // DeleteProduct mocks base method.
 func (m *MockQuerier) DeleteProduct(ctx context.Context, id uint32) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteProduct", ctx, id)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "DeleteProduct", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteProduct indicates an expected call of DeleteProduct.
+func (mr *MockQuerierMockRecorder) DeleteProduct(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProduct", reflect.TypeOf((*MockQuerier)(nil).DeleteProduct), ctx, id)
+}
+
+// DeleteProductDefaultThreshold mocks base method.
+func (m *MockQuerier) DeleteProductDefaultThreshold(ctx context.Context, productID uint32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProductDefaultThreshold", ctx, productID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteProductDefaultThreshold indicates an expected call of DeleteProductDefaultThreshold.
+func (mr *MockQuerierMockRecorder) DeleteProductDefaultThreshold(ctx, productID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProductDefaultThreshold", reflect.TypeOf((*MockQuerier)(nil).DeleteProductDefaultThreshold), ctx, productID)
+}
+
+// DeleteProductPenaltyRule mocks base method.
+func (m *MockQuerier) DeleteProductPenaltyRule(ctx context.Context, productID uint32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProductPenaltyRule", ctx, productID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteProductPenaltyRule indicates an expected call of DeleteProductPenaltyRule.
+func (mr *MockQuerierMockRecorder) DeleteProductPenaltyRule(ctx, productID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProductPenaltyRule", reflect.TypeOf((*MockQuerier)(nil).DeleteProductPenaltyRule), ctx, productID)
+}
+
+// DisburseLoan mocks base method.
+func (m *MockQuerier) DisburseLoan(ctx context.Context, arg generated.DisburseLoanParams) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisburseLoan", ctx, arg)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DisburseLoan indicates an expected call of DisburseLoan.
+func (mr *MockQuerierMockRecorder) DisburseLoan(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisburseLoan", reflect.TypeOf((*MockQuerier)(nil).DisburseLoan), ctx, arg)
+}
+
+// GetActiveLoanDetails mocks base method.
+func (m *MockQuerier) GetActiveLoanDetails(ctx context.Context, clientID uint32) (generated.GetActiveLoanDetailsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveLoanDetails", ctx, clientID)
+	ret0, _ := ret[0].(generated.GetActiveLoanDetailsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveLoanDetails indicates an expected call of GetActiveLoanDetails.
+func (mr *MockQuerierMockRecorder) GetActiveLoanDetails(ctx, clientID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveLoanDetails", reflect.TypeOf((*MockQuerier)(nil).GetActiveLoanDetails), ctx, clientID)
+}
+
+// GetActivePaymentSplitByNonPosted mocks base method.
+func (m *MockQuerier) GetActivePaymentSplitByNonPosted(ctx context.Context, nonPostedID uint32) (generated.PaymentSplit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActivePaymentSplitByNonPosted", ctx, nonPostedID)
+	ret0, _ := ret[0].(generated.PaymentSplit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActivePaymentSplitByNonPosted indicates an expected call of GetActivePaymentSplitByNonPosted.
+func (mr *MockQuerierMockRecorder) GetActivePaymentSplitByNonPosted(ctx, nonPostedID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActivePaymentSplitByNonPosted", reflect.TypeOf((*MockQuerier)(nil).GetActivePaymentSplitByNonPosted), ctx, nonPostedID)
+}
+
+// GetAssignmentRule mocks base method.
+func (m *MockQuerier) GetAssignmentRule(ctx context.Context, id uint32) (generated.AssignmentRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAssignmentRule", ctx, id)
+	ret0, _ := ret[0].(generated.AssignmentRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAssignmentRule indicates an expected call of GetAssignmentRule.
+func (mr *MockQuerierMockRecorder) GetAssignmentRule(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssignmentRule", reflect.TypeOf((*MockQuerier)(nil).GetAssignmentRule), ctx, id)
+}
+
+// GetBlacklistedClient mocks base method.
+func (m *MockQuerier) GetBlacklistedClient(ctx context.Context, clientID uint32) (generated.BlacklistedClient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlacklistedClient", ctx, clientID)
+	ret0, _ := ret[0].(generated.BlacklistedClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlacklistedClient indicates an expected call of GetBlacklistedClient.
+func (mr *MockQuerierMockRecorder) GetBlacklistedClient(ctx, clientID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlacklistedClient", reflect.TypeOf((*MockQuerier)(nil).GetBlacklistedClient), ctx, clientID)
+}
+
+// GetBranch mocks base method.
+func (m *MockQuerier) GetBranch(ctx context.Context, id uint32) (generated.Branch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBranch", ctx, id)
+	ret0, _ := ret[0].(generated.Branch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBranch indicates an expected call of GetBranch.
+func (mr *MockQuerierMockRecorder) GetBranch(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBranch", reflect.TypeOf((*MockQuerier)(nil).GetBranch), ctx, id)
+}
+
+// GetBranchReportData mocks base method.
+func (m *MockQuerier) GetBranchReportData(ctx context.Context, arg generated.GetBranchReportDataParams) ([]generated.GetBranchReportDataRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBranchReportData", ctx, arg)
+	ret0, _ := ret[0].([]generated.GetBranchReportDataRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBranchReportData indicates an expected call of GetBranchReportData.
+func (mr *MockQuerierMockRecorder) GetBranchReportData(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBranchReportData", reflect.TypeOf((*MockQuerier)(nil).GetBranchReportData), ctx, arg)
+}
+
+// GetCashBookClose mocks base method.
+func (m *MockQuerier) GetCashBookClose(ctx context.Context, arg generated.GetCashBookCloseParams) (generated.CashBookClose, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCashBookClose", ctx, arg)
+	ret0, _ := ret[0].(generated.CashBookClose)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCashBookClose indicates an expected call of GetCashBookClose.
+func (mr *MockQuerierMockRecorder) GetCashBookClose(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCashBookClose", reflect.TypeOf((*MockQuerier)(nil).GetCashBookClose), ctx, arg)
+}
+
+// GetClient mocks base method.
+func (m *MockQuerier) GetClient(ctx context.Context, id uint32) (generated.Client, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClient", ctx, id)
+	ret0, _ := ret[0].(generated.Client)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClient indicates an expected call of GetClient.
+func (mr *MockQuerierMockRecorder) GetClient(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClient", reflect.TypeOf((*MockQuerier)(nil).GetClient), ctx, id)
+}
+
+// GetClientActiveLoan mocks base method.
+func (m *MockQuerier) GetClientActiveLoan(ctx context.Context, arg generated.GetClientActiveLoanParams) (uint32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClientActiveLoan", ctx, arg)
+	ret0, _ := ret[0].(uint32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClientActiveLoan indicates an expected call of GetClientActiveLoan.
+func (mr *MockQuerierMockRecorder) GetClientActiveLoan(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClientActiveLoan", reflect.TypeOf((*MockQuerier)(nil).GetClientActiveLoan), ctx, arg)
+}
+
+// GetClientAdminsReportData mocks base method.
+func (m *MockQuerier) GetClientAdminsReportData(ctx context.Context, arg generated.GetClientAdminsReportDataParams) ([]generated.GetClientAdminsReportDataRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClientAdminsReportData", ctx, arg)
+	ret0, _ := ret[0].([]generated.GetClientAdminsReportDataRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClientAdminsReportData indicates an expected call of GetClientAdminsReportData.
+func (mr *MockQuerierMockRecorder) GetClientAdminsReportData(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClientAdminsReportData", reflect.TypeOf((*MockQuerier)(nil).GetClientAdminsReportData), ctx, arg)
+}
+
+// GetClientByIDNumber mocks base method.
+func (m *MockQuerier) GetClientByIDNumber(ctx context.Context, idNumber sql.NullString) (generated.Client, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClientByIDNumber", ctx, idNumber)
+	ret0, _ := ret[0].(generated.Client)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClientByIDNumber indicates an expected call of GetClientByIDNumber.
+func (mr *MockQuerierMockRecorder) GetClientByIDNumber(ctx, idNumber any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClientByIDNumber", reflect.TypeOf((*MockQuerier)(nil).GetClientByIDNumber), ctx, idNumber)
+}
+
+// GetClientByPhoneNumber mocks base method.
+func (m *MockQuerier) GetClientByPhoneNumber(ctx context.Context, phoneNumber string) (generated.Client, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClientByPhoneNumber", ctx, phoneNumber)
+	ret0, _ := ret[0].(generated.Client)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClientByPhoneNumber indicates an expected call of GetClientByPhoneNumber.
+func (mr *MockQuerierMockRecorder) GetClientByPhoneNumber(ctx, phoneNumber any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClientByPhoneNumber", reflect.TypeOf((*MockQuerier)(nil).GetClientByPhoneNumber), ctx, phoneNumber)
+}
+
+// GetClientClientsReportData mocks base method.
+func (m *MockQuerier) GetClientClientsReportData(ctx context.Context, arg generated.GetClientClientsReportDataParams) (generated.GetClientClientsReportDataRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClientClientsReportData", ctx, arg)
+	ret0, _ := ret[0].(generated.GetClientClientsReportDataRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClientClientsReportData indicates an expected call of GetClientClientsReportData.
+func (mr *MockQuerierMockRecorder) GetClientClientsReportData(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClientClientsReportData", reflect.TypeOf((*MockQuerier)(nil).GetClientClientsReportData), ctx, arg)
+}
+
+// GetClientFullData mocks base method.
+func (m *MockQuerier) GetClientFullData(ctx context.Context, id uint32) (generated.GetClientFullDataRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClientFullData", ctx, id)
+	ret0, _ := ret[0].(generated.GetClientFullDataRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClientFullData indicates an expected call of GetClientFullData.
+func (mr *MockQuerierMockRecorder) GetClientFullData(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClientFullData", reflect.TypeOf((*MockQuerier)(nil).GetClientFullData), ctx, id)
+}
+
+// GetClientIDByPhoneNumber mocks base method.
+func (m *MockQuerier) GetClientIDByPhoneNumber(ctx context.Context, phoneNumber string) (uint32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClientIDByPhoneNumber", ctx, phoneNumber)
+	ret0, _ := ret[0].(uint32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClientIDByPhoneNumber indicates an expected call of GetClientIDByPhoneNumber.
+func (mr *MockQuerierMockRecorder) GetClientIDByPhoneNumber(ctx, phoneNumber any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClientIDByPhoneNumber", reflect.TypeOf((*MockQuerier)(nil).GetClientIDByPhoneNumber), ctx, phoneNumber)
+}
+
+// GetClientLoans mocks base method.
+func (m *MockQuerier) GetClientLoans(ctx context.Context, arg generated.GetClientLoansParams) ([]generated.GetClientLoansRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClientLoans", ctx, arg)
+	ret0, _ := ret[0].([]generated.GetClientLoansRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClientLoans indicates an expected call of GetClientLoans.
+func (mr *MockQuerierMockRecorder) GetClientLoans(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClientLoans", reflect.TypeOf((*MockQuerier)(nil).GetClientLoans), ctx, arg)
+}
+
+// GetClientOverpayment mocks base method.
+func (m *MockQuerier) GetClientOverpayment(ctx context.Context, id uint32) (float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClientOverpayment", ctx, id)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClientOverpayment indicates an expected call of GetClientOverpayment.
+func (mr *MockQuerierMockRecorder) GetClientOverpayment(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClientOverpayment", reflect.TypeOf((*MockQuerier)(nil).GetClientOverpayment), ctx, id)
+}
+
+// GetClientOverpaymentTransaction mocks base method.
+func (m *MockQuerier) GetClientOverpaymentTransaction(ctx context.Context, id uint32) (generated.ClientOverpaymentTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClientOverpaymentTransaction", ctx, id)
+	ret0, _ := ret[0].(generated.ClientOverpaymentTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClientOverpaymentTransaction indicates an expected call of GetClientOverpaymentTransaction.
+func (mr *MockQuerierMockRecorder) GetClientOverpaymentTransaction(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClientOverpaymentTransaction", reflect.TypeOf((*MockQuerier)(nil).GetClientOverpaymentTransaction), ctx, id)
+}
+
+// GetClientOverpaymentTransactionByPaymentId mocks base method.
+func (m *MockQuerier) GetClientOverpaymentTransactionByPaymentId(ctx context.Context, paymentID sql.NullInt32) (generated.ClientOverpaymentTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClientOverpaymentTransactionByPaymentId", ctx, paymentID)
+	ret0, _ := ret[0].(generated.ClientOverpaymentTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClientOverpaymentTransactionByPaymentId indicates an expected call of GetClientOverpaymentTransactionByPaymentId.
+func (mr *MockQuerierMockRecorder) GetClientOverpaymentTransactionByPaymentId(ctx, paymentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClientOverpaymentTransactionByPaymentId", reflect.TypeOf((*MockQuerier)(nil).GetClientOverpaymentTransactionByPaymentId), ctx, paymentID)
+}
+
+// GetClientOverpaymentTransactions mocks base method.
+func (m *MockQuerier) GetClientOverpaymentTransactions(ctx context.Context, clientID uint32) ([]generated.ClientOverpaymentTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClientOverpaymentTransactions", ctx, clientID)
+	ret0, _ := ret[0].([]generated.ClientOverpaymentTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClientOverpaymentTransactions indicates an expected call of GetClientOverpaymentTransactions.
+func (mr *MockQuerierMockRecorder) GetClientOverpaymentTransactions(ctx, clientID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClientOverpaymentTransactions", reflect.TypeOf((*MockQuerier)(nil).GetClientOverpaymentTransactions), ctx, clientID)
+}
+
+// GetClientPayableLoan mocks base method.
+func (m *MockQuerier) GetClientPayableLoan(ctx context.Context, clientID uint32) (uint32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClientPayableLoan", ctx, clientID)
+	ret0, _ := ret[0].(uint32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClientPayableLoan indicates an expected call of GetClientPayableLoan.
+func (mr *MockQuerierMockRecorder) GetClientPayableLoan(ctx, clientID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClientPayableLoan", reflect.TypeOf((*MockQuerier)(nil).GetClientPayableLoan), ctx, clientID)
+}
+
+// GetClientWithBranchName mocks base method.
+func (m *MockQuerier) GetClientWithBranchName(ctx context.Context, id uint32) (generated.GetClientWithBranchNameRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClientWithBranchName", ctx, id)
+	ret0, _ := ret[0].(generated.GetClientWithBranchNameRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClientWithBranchName indicates an expected call of GetClientWithBranchName.
+func (mr *MockQuerierMockRecorder) GetClientWithBranchName(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClientWithBranchName", reflect.TypeOf((*MockQuerier)(nil).GetClientWithBranchName), ctx, id)
+}
+
+// GetClientsNonPosted mocks base method.
+func (m *MockQuerier) GetClientsNonPosted(ctx context.Context, arg generated.GetClientsNonPostedParams) ([]generated.GetClientsNonPostedRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClientsNonPosted", ctx, arg)
+	ret0, _ := ret[0].([]generated.GetClientsNonPostedRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClientsNonPosted indicates an expected call of GetClientsNonPosted.
+func (mr *MockQuerierMockRecorder) GetClientsNonPosted(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClientsNonPosted", reflect.TypeOf((*MockQuerier)(nil).GetClientsNonPosted), ctx, arg)
+}
+
+// GetInstallment mocks base method.
+func (m *MockQuerier) GetInstallment(ctx context.Context, id uint32) (generated.Installment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInstallment", ctx, id)
+	ret0, _ := ret[0].(generated.Installment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInstallment indicates an expected call of GetInstallment.
+func (mr *MockQuerierMockRecorder) GetInstallment(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInstallment", reflect.TypeOf((*MockQuerier)(nil).GetInstallment), ctx, id)
+}
+
+// GetLatestCashBookClose mocks base method.
+func (m *MockQuerier) GetLatestCashBookClose(ctx context.Context, arg generated.GetLatestCashBookCloseParams) (generated.CashBookClose, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestCashBookClose", ctx, arg)
+	ret0, _ := ret[0].(generated.CashBookClose)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestCashBookClose indicates an expected call of GetLatestCashBookClose.
+func (mr *MockQuerierMockRecorder) GetLatestCashBookClose(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestCashBookClose", reflect.TypeOf((*MockQuerier)(nil).GetLatestCashBookClose), ctx, arg)
+}
+
+// GetLedgerAccountByCode mocks base method.
+func (m *MockQuerier) GetLedgerAccountByCode(ctx context.Context, code string) (generated.LedgerAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLedgerAccountByCode", ctx, code)
+	ret0, _ := ret[0].(generated.LedgerAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLedgerAccountByCode indicates an expected call of GetLedgerAccountByCode.
+func (mr *MockQuerierMockRecorder) GetLedgerAccountByCode(ctx, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLedgerAccountByCode", reflect.TypeOf((*MockQuerier)(nil).GetLedgerAccountByCode), ctx, code)
+}
+
+// GetLoan mocks base method.
+func (m *MockQuerier) GetLoan(ctx context.Context, id uint32) (generated.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoan", ctx, id)
+	ret0, _ := ret[0].(generated.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoan indicates an expected call of GetLoan.
+func (mr *MockQuerierMockRecorder) GetLoan(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoan", reflect.TypeOf((*MockQuerier)(nil).GetLoan), ctx, id)
+}
+
+// GetLoanAllocationTerms mocks base method.
+func (m *MockQuerier) GetLoanAllocationTerms(ctx context.Context, id uint32) (generated.GetLoanAllocationTermsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoanAllocationTerms", ctx, id)
+	ret0, _ := ret[0].(generated.GetLoanAllocationTermsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoanAllocationTerms indicates an expected call of GetLoanAllocationTerms.
+func (mr *MockQuerierMockRecorder) GetLoanAllocationTerms(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoanAllocationTerms", reflect.TypeOf((*MockQuerier)(nil).GetLoanAllocationTerms), ctx, id)
+}
+
+// GetLoanClientID mocks base method.
+func (m *MockQuerier) GetLoanClientID(ctx context.Context, id uint32) (uint32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoanClientID", ctx, id)
+	ret0, _ := ret[0].(uint32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoanClientID indicates an expected call of GetLoanClientID.
+func (mr *MockQuerierMockRecorder) GetLoanClientID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoanClientID", reflect.TypeOf((*MockQuerier)(nil).GetLoanClientID), ctx, id)
+}
+
+// GetLoanData mocks base method.
+func (m *MockQuerier) GetLoanData(ctx context.Context) ([]uint32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoanData", ctx)
+	ret0, _ := ret[0].([]uint32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoanData indicates an expected call of GetLoanData.
+func (mr *MockQuerierMockRecorder) GetLoanData(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoanData", reflect.TypeOf((*MockQuerier)(nil).GetLoanData), ctx)
+}
+
+// GetLoanDetails mocks base method.
+func (m *MockQuerier) GetLoanDetails(ctx context.Context, id uint32) (generated.GetLoanDetailsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoanDetails", ctx, id)
+	ret0, _ := ret[0].(generated.GetLoanDetailsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoanDetails indicates an expected call of GetLoanDetails.
+func (mr *MockQuerierMockRecorder) GetLoanDetails(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoanDetails", reflect.TypeOf((*MockQuerier)(nil).GetLoanDetails), ctx, id)
+}
+
+// GetLoanDisbursementByConversationID mocks base method.
+func (m *MockQuerier) GetLoanDisbursementByConversationID(ctx context.Context, conversationID string) (generated.LoanDisbursement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoanDisbursementByConversationID", ctx, conversationID)
+	ret0, _ := ret[0].(generated.LoanDisbursement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoanDisbursementByConversationID indicates an expected call of GetLoanDisbursementByConversationID.
+func (mr *MockQuerierMockRecorder) GetLoanDisbursementByConversationID(ctx, conversationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoanDisbursementByConversationID", reflect.TypeOf((*MockQuerier)(nil).GetLoanDisbursementByConversationID), ctx, conversationID)
+}
+
+// GetLoanEvents mocks base method.
+func (m *MockQuerier) GetLoanEvents(ctx context.Context) ([]generated.GetLoanEventsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoanEvents", ctx)
+	ret0, _ := ret[0].([]generated.GetLoanEventsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoanEvents indicates an expected call of GetLoanEvents.
+func (mr *MockQuerierMockRecorder) GetLoanEvents(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoanEvents", reflect.TypeOf((*MockQuerier)(nil).GetLoanEvents), ctx)
+}
+
+// GetLoanFullData mocks base method.
+func (m *MockQuerier) GetLoanFullData(ctx context.Context, id uint32) (generated.GetLoanFullDataRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoanFullData", ctx, id)
+	ret0, _ := ret[0].(generated.GetLoanFullDataRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoanFullData indicates an expected call of GetLoanFullData.
+func (mr *MockQuerierMockRecorder) GetLoanFullData(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoanFullData", reflect.TypeOf((*MockQuerier)(nil).GetLoanFullData), ctx, id)
+}
+
+// GetLoanInstallmentCounts mocks base method.
+func (m *MockQuerier) GetLoanInstallmentCounts(ctx context.Context, loanID uint32) (generated.GetLoanInstallmentCountsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoanInstallmentCounts", ctx, loanID)
+	ret0, _ := ret[0].(generated.GetLoanInstallmentCountsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoanInstallmentCounts indicates an expected call of GetLoanInstallmentCounts.
+func (mr *MockQuerierMockRecorder) GetLoanInstallmentCounts(ctx, loanID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoanInstallmentCounts", reflect.TypeOf((*MockQuerier)(nil).GetLoanInstallmentCounts), ctx, loanID)
+}
+
+// GetLoanReportDataById mocks base method.
+func (m *MockQuerier) GetLoanReportDataById(ctx context.Context, id uint32) (generated.GetLoanReportDataByIdRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoanReportDataById", ctx, id)
+	ret0, _ := ret[0].(generated.GetLoanReportDataByIdRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoanReportDataById indicates an expected call of GetLoanReportDataById.
+func (mr *MockQuerierMockRecorder) GetLoanReportDataById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoanReportDataById", reflect.TypeOf((*MockQuerier)(nil).GetLoanReportDataById), ctx, id)
+}
+
+// GetLoanSettlementQuote mocks base method.
+func (m *MockQuerier) GetLoanSettlementQuote(ctx context.Context, id uint32) (generated.LoanSettlementQuote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoanSettlementQuote", ctx, id)
+	ret0, _ := ret[0].(generated.LoanSettlementQuote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoanSettlementQuote indicates an expected call of GetLoanSettlementQuote.
+func (mr *MockQuerierMockRecorder) GetLoanSettlementQuote(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoanSettlementQuote", reflect.TypeOf((*MockQuerier)(nil).GetLoanSettlementQuote), ctx, id)
+}
+
+// GetLoanStatus mocks base method.
+func (m *MockQuerier) GetLoanStatus(ctx context.Context, id uint32) (generated.LoansStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoanStatus", ctx, id)
+	ret0, _ := ret[0].(generated.LoansStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoanStatus indicates an expected call of GetLoanStatus.
+func (mr *MockQuerierMockRecorder) GetLoanStatus(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoanStatus", reflect.TypeOf((*MockQuerier)(nil).GetLoanStatus), ctx, id)
+}
+
+// GetLoanTopUpByLoan mocks base method.
+func (m *MockQuerier) GetLoanTopUpByLoan(ctx context.Context, loanID uint32) (generated.LoanTopUp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoanTopUpByLoan", ctx, loanID)
+	ret0, _ := ret[0].(generated.LoanTopUp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoanTopUpByLoan indicates an expected call of GetLoanTopUpByLoan.
+func (mr *MockQuerierMockRecorder) GetLoanTopUpByLoan(ctx, loanID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoanTopUpByLoan", reflect.TypeOf((*MockQuerier)(nil).GetLoanTopUpByLoan), ctx, loanID)
+}
+
+// GetLoanTopUpByRefinancedLoan mocks base method.
+func (m *MockQuerier) GetLoanTopUpByRefinancedLoan(ctx context.Context, refinancedLoanID uint32) (generated.LoanTopUp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoanTopUpByRefinancedLoan", ctx, refinancedLoanID)
+	ret0, _ := ret[0].(generated.LoanTopUp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoanTopUpByRefinancedLoan indicates an expected call of GetLoanTopUpByRefinancedLoan.
+func (mr *MockQuerierMockRecorder) GetLoanTopUpByRefinancedLoan(ctx, refinancedLoanID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoanTopUpByRefinancedLoan", reflect.TypeOf((*MockQuerier)(nil).GetLoanTopUpByRefinancedLoan), ctx, refinancedLoanID)
+}
+
+// GetLoansReportData mocks base method.
+func (m *MockQuerier) GetLoansReportData(ctx context.Context, arg generated.GetLoansReportDataParams) ([]generated.GetLoansReportDataRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoansReportData", ctx, arg)
+	ret0, _ := ret[0].([]generated.GetLoansReportDataRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoansReportData indicates an expected call of GetLoansReportData.
+func (mr *MockQuerierMockRecorder) GetLoansReportData(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoansReportData", reflect.TypeOf((*MockQuerier)(nil).GetLoansReportData), ctx, arg)
+}
+
+// GetNonPosted mocks base method.
+func (m *MockQuerier) GetNonPosted(ctx context.Context, id uint32) (generated.GetNonPostedRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNonPosted", ctx, id)
+	ret0, _ := ret[0].(generated.GetNonPostedRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNonPosted indicates an expected call of GetNonPosted.
+func (mr *MockQuerierMockRecorder) GetNonPosted(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNonPosted", reflect.TypeOf((*MockQuerier)(nil).GetNonPosted), ctx, id)
+}
+
+// GetOverpaymentRefund mocks base method.
+func (m *MockQuerier) GetOverpaymentRefund(ctx context.Context, id uint32) (generated.OverpaymentRefund, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOverpaymentRefund", ctx, id)
+	ret0, _ := ret[0].(generated.OverpaymentRefund)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOverpaymentRefund indicates an expected call of GetOverpaymentRefund.
+func (mr *MockQuerierMockRecorder) GetOverpaymentRefund(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOverpaymentRefund", reflect.TypeOf((*MockQuerier)(nil).GetOverpaymentRefund), ctx, id)
+}
+
+// GetOverpaymentRefundByConversationID mocks base method.
+func (m *MockQuerier) GetOverpaymentRefundByConversationID(ctx context.Context, conversationID sql.NullString) (generated.OverpaymentRefund, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOverpaymentRefundByConversationID", ctx, conversationID)
+	ret0, _ := ret[0].(generated.OverpaymentRefund)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOverpaymentRefundByConversationID indicates an expected call of GetOverpaymentRefundByConversationID.
+func (mr *MockQuerierMockRecorder) GetOverpaymentRefundByConversationID(ctx, conversationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOverpaymentRefundByConversationID", reflect.TypeOf((*MockQuerier)(nil).GetOverpaymentRefundByConversationID), ctx, conversationID)
+}
+
+// GetPaymentImportBatch mocks base method.
+func (m *MockQuerier) GetPaymentImportBatch(ctx context.Context, id uint32) (generated.PaymentImportBatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaymentImportBatch", ctx, id)
+	ret0, _ := ret[0].(generated.PaymentImportBatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaymentImportBatch indicates an expected call of GetPaymentImportBatch.
+func (mr *MockQuerierMockRecorder) GetPaymentImportBatch(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentImportBatch", reflect.TypeOf((*MockQuerier)(nil).GetPaymentImportBatch), ctx, id)
+}
+
+// GetPaymentReassignment mocks base method.
+func (m *MockQuerier) GetPaymentReassignment(ctx context.Context, id uint32) (generated.PaymentReassignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaymentReassignment", ctx, id)
+	ret0, _ := ret[0].(generated.PaymentReassignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaymentReassignment indicates an expected call of GetPaymentReassignment.
+func (mr *MockQuerierMockRecorder) GetPaymentReassignment(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentReassignment", reflect.TypeOf((*MockQuerier)(nil).GetPaymentReassignment), ctx, id)
+}
+
+// GetPaymentReportData mocks base method.
+func (m *MockQuerier) GetPaymentReportData(ctx context.Context, arg generated.GetPaymentReportDataParams) ([]generated.GetPaymentReportDataRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaymentReportData", ctx, arg)
+	ret0, _ := ret[0].([]generated.GetPaymentReportDataRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaymentReportData indicates an expected call of GetPaymentReportData.
+func (mr *MockQuerierMockRecorder) GetPaymentReportData(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentReportData", reflect.TypeOf((*MockQuerier)(nil).GetPaymentReportData), ctx, arg)
+}
+
+// GetPaymentSplit mocks base method.
+func (m *MockQuerier) GetPaymentSplit(ctx context.Context, id uint32) (generated.PaymentSplit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaymentSplit", ctx, id)
+	ret0, _ := ret[0].(generated.PaymentSplit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaymentSplit indicates an expected call of GetPaymentSplit.
+func (mr *MockQuerierMockRecorder) GetPaymentSplit(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentSplit", reflect.TypeOf((*MockQuerier)(nil).GetPaymentSplit), ctx, id)
+}
+
+// GetProcessedCallback mocks base method.
+func (m *MockQuerier) GetProcessedCallback(ctx context.Context, arg generated.GetProcessedCallbackParams) (generated.ProcessedCallback, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProcessedCallback", ctx, arg)
+	ret0, _ := ret[0].(generated.ProcessedCallback)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProcessedCallback indicates an expected call of GetProcessedCallback.
+func (mr *MockQuerierMockRecorder) GetProcessedCallback(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProcessedCallback", reflect.TypeOf((*MockQuerier)(nil).GetProcessedCallback), ctx, arg)
+}
+
+// GetProduct mocks base method.
+func (m *MockQuerier) GetProduct(ctx context.Context, id uint32) (generated.GetProductRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProduct", ctx, id)
+	ret0, _ := ret[0].(generated.GetProductRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProduct indicates an expected call of GetProduct.
+func (mr *MockQuerierMockRecorder) GetProduct(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProduct", reflect.TypeOf((*MockQuerier)(nil).GetProduct), ctx, id)
+}
+
+// GetProductAllocationStrategy mocks base method.
+func (m *MockQuerier) GetProductAllocationStrategy(ctx context.Context, productID uint32) (generated.ProductAllocationStrategy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductAllocationStrategy", ctx, productID)
+	ret0, _ := ret[0].(generated.ProductAllocationStrategy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductAllocationStrategy indicates an expected call of GetProductAllocationStrategy.
+func (mr *MockQuerierMockRecorder) GetProductAllocationStrategy(ctx, productID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductAllocationStrategy", reflect.TypeOf((*MockQuerier)(nil).GetProductAllocationStrategy), ctx, productID)
+}
+
+// GetProductDefaultThreshold mocks base method.
+func (m *MockQuerier) GetProductDefaultThreshold(ctx context.Context, productID uint32) (generated.ProductDefaultThreshold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductDefaultThreshold", ctx, productID)
+	ret0, _ := ret[0].(generated.ProductDefaultThreshold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductDefaultThreshold indicates an expected call of GetProductDefaultThreshold.
+func (mr *MockQuerierMockRecorder) GetProductDefaultThreshold(ctx, productID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductDefaultThreshold", reflect.TypeOf((*MockQuerier)(nil).GetProductDefaultThreshold), ctx, productID)
+}
+
+// GetProductPenaltyRule mocks base method.
+func (m *MockQuerier) GetProductPenaltyRule(ctx context.Context, productID uint32) (generated.ProductPenaltyRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductPenaltyRule", ctx, productID)
+	ret0, _ := ret[0].(generated.ProductPenaltyRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductPenaltyRule indicates an expected call of GetProductPenaltyRule.
+func (mr *MockQuerierMockRecorder) GetProductPenaltyRule(ctx, productID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductPenaltyRule", reflect.TypeOf((*MockQuerier)(nil).GetProductPenaltyRule), ctx, productID)
+}
+
+// GetProductRepayAmount mocks base method.
+func (m *MockQuerier) GetProductRepayAmount(ctx context.Context, id uint32) (float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductRepayAmount", ctx, id)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductRepayAmount indicates an expected call of GetProductRepayAmount.
+func (mr *MockQuerierMockRecorder) GetProductRepayAmount(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductRepayAmount", reflect.TypeOf((*MockQuerier)(nil).GetProductRepayAmount), ctx, id)
+}
+
+// GetProductReportData mocks base method.
+func (m *MockQuerier) GetProductReportData(ctx context.Context, arg generated.GetProductReportDataParams) ([]generated.GetProductReportDataRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductReportData", ctx, arg)
+	ret0, _ := ret[0].([]generated.GetProductReportDataRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductReportData indicates an expected call of GetProductReportData.
+func (mr *MockQuerierMockRecorder) GetProductReportData(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductReportData", reflect.TypeOf((*MockQuerier)(nil).GetProductReportData), ctx, arg)
+}
+
+// GetStatementReconciliation mocks base method.
+func (m *MockQuerier) GetStatementReconciliation(ctx context.Context, id uint32) (generated.StatementReconciliation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStatementReconciliation", ctx, id)
+	ret0, _ := ret[0].(generated.StatementReconciliation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStatementReconciliation indicates an expected call of GetStatementReconciliation.
+func (mr *MockQuerierMockRecorder) GetStatementReconciliation(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatementReconciliation", reflect.TypeOf((*MockQuerier)(nil).GetStatementReconciliation), ctx, id)
+}
+
+// GetStkPushRequestByCheckoutID mocks base method.
+func (m *MockQuerier) GetStkPushRequestByCheckoutID(ctx context.Context, checkoutRequestID string) (generated.StkPushRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStkPushRequestByCheckoutID", ctx, checkoutRequestID)
+	ret0, _ := ret[0].(generated.StkPushRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStkPushRequestByCheckoutID indicates an expected call of GetStkPushRequestByCheckoutID.
+func (mr *MockQuerierMockRecorder) GetStkPushRequestByCheckoutID(ctx, checkoutRequestID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStkPushRequestByCheckoutID", reflect.TypeOf((*MockQuerier)(nil).GetStkPushRequestByCheckoutID), ctx, checkoutRequestID)
+}
+
+// GetTotalPaidByIDorAccountNo mocks base method.
+func (m *MockQuerier) GetTotalPaidByIDorAccountNo(ctx context.Context, arg generated.GetTotalPaidByIDorAccountNoParams) (interface{}, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTotalPaidByIDorAccountNo", ctx, arg)
+	ret0, _ := ret[0].(interface{})
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTotalPaidByIDorAccountNo indicates an expected call of GetTotalPaidByIDorAccountNo.
+func (mr *MockQuerierMockRecorder) GetTotalPaidByIDorAccountNo(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTotalPaidByIDorAccountNo", reflect.TypeOf((*MockQuerier)(nil).GetTotalPaidByIDorAccountNo), ctx, arg)
+}
+
+// GetTrialBalance mocks base method.
+func (m *MockQuerier) GetTrialBalance(ctx context.Context, asOf time.Time) ([]generated.GetTrialBalanceRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrialBalance", ctx, asOf)
+	ret0, _ := ret[0].([]generated.GetTrialBalanceRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrialBalance indicates an expected call of GetTrialBalance.
+func (mr *MockQuerierMockRecorder) GetTrialBalance(ctx, asOf any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrialBalance", reflect.TypeOf((*MockQuerier)(nil).GetTrialBalance), ctx, asOf)
+}
+
+// GetUnpaidInstallmentsData mocks base method.
+func (m *MockQuerier) GetUnpaidInstallmentsData(ctx context.Context, arg generated.GetUnpaidInstallmentsDataParams) ([]generated.GetUnpaidInstallmentsDataRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnpaidInstallmentsData", ctx, arg)
+	ret0, _ := ret[0].([]generated.GetUnpaidInstallmentsDataRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnpaidInstallmentsData indicates an expected call of GetUnpaidInstallmentsData.
+func (mr *MockQuerierMockRecorder) GetUnpaidInstallmentsData(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnpaidInstallmentsData", reflect.TypeOf((*MockQuerier)(nil).GetUnpaidInstallmentsData), ctx, arg)
+}
+
+// GetUser mocks base method.
+func (m *MockQuerier) GetUser(ctx context.Context, id uint32) (generated.GetUserRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", ctx, id)
+	ret0, _ := ret[0].(generated.GetUserRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockQuerierMockRecorder) GetUser(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockQuerier)(nil).GetUser), ctx, id)
+}
+
+// GetUserAdminsReportData mocks base method.
+func (m *MockQuerier) GetUserAdminsReportData(ctx context.Context, arg generated.GetUserAdminsReportDataParams) ([]generated.GetUserAdminsReportDataRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserAdminsReportData", ctx, arg)
+	ret0, _ := ret[0].([]generated.GetUserAdminsReportDataRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserAdminsReportData indicates an expected call of GetUserAdminsReportData.
+func (mr *MockQuerierMockRecorder) GetUserAdminsReportData(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserAdminsReportData", reflect.TypeOf((*MockQuerier)(nil).GetUserAdminsReportData), ctx, arg)
+}
+
+// GetUserByEmail mocks base method.
+func (m *MockQuerier) GetUserByEmail(ctx context.Context, email string) (generated.GetUserByEmailRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByEmail", ctx, email)
+	ret0, _ := ret[0].(generated.GetUserByEmailRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByEmail indicates an expected call of GetUserByEmail.
+func (mr *MockQuerierMockRecorder) GetUserByEmail(ctx, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmail", reflect.TypeOf((*MockQuerier)(nil).GetUserByEmail), ctx, email)
+}
+
+// GetUserUsersReportData mocks base method.
+func (m *MockQuerier) GetUserUsersReportData(ctx context.Context, arg generated.GetUserUsersReportDataParams) (generated.GetUserUsersReportDataRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserUsersReportData", ctx, arg)
+	ret0, _ := ret[0].(generated.GetUserUsersReportDataRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserUsersReportData indicates an expected call of GetUserUsersReportData.
+func (mr *MockQuerierMockRecorder) GetUserUsersReportData(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserUsersReportData", reflect.TypeOf((*MockQuerier)(nil).GetUserUsersReportData), ctx, arg)
+}
+
+// HelperClient mocks base method.
+func (m *MockQuerier) HelperClient(ctx context.Context) ([]generated.HelperClientRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HelperClient", ctx)
+	ret0, _ := ret[0].([]generated.HelperClientRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HelperClient indicates an expected call of HelperClient.
+func (mr *MockQuerierMockRecorder) HelperClient(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HelperClient", reflect.TypeOf((*MockQuerier)(nil).HelperClient), ctx)
+}
+
+// HelperProduct mocks base method.
+func (m *MockQuerier) HelperProduct(ctx context.Context) ([]generated.HelperProductRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HelperProduct", ctx)
+	ret0, _ := ret[0].([]generated.HelperProductRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HelperProduct indicates an expected call of HelperProduct.
+func (mr *MockQuerierMockRecorder) HelperProduct(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HelperProduct", reflect.TypeOf((*MockQuerier)(nil).HelperProduct), ctx)
+}
+
+// HelperUser mocks base method.
+func (m *MockQuerier) HelperUser(ctx context.Context) ([]generated.HelperUserRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HelperUser", ctx)
+	ret0, _ := ret[0].([]generated.HelperUserRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HelperUser indicates an expected call of HelperUser.
+func (mr *MockQuerierMockRecorder) HelperUser(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HelperUser", reflect.TypeOf((*MockQuerier)(nil).HelperUser), ctx)
+}
+
+// HelperUserById mocks base method.
+func (m *MockQuerier) HelperUserById(ctx context.Context, id uint32) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HelperUserById", ctx, id)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HelperUserById indicates an expected call of HelperUserById.
+func (mr *MockQuerierMockRecorder) HelperUserById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HelperUserById", reflect.TypeOf((*MockQuerier)(nil).HelperUserById), ctx, id)
+}
+
+// ListAccountLedger mocks base method.
+func (m *MockQuerier) ListAccountLedger(ctx context.Context, arg generated.ListAccountLedgerParams) ([]generated.ListAccountLedgerRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccountLedger", ctx, arg)
+	ret0, _ := ret[0].([]generated.ListAccountLedgerRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccountLedger indicates an expected call of ListAccountLedger.
+func (mr *MockQuerierMockRecorder) ListAccountLedger(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccountLedger", reflect.TypeOf((*MockQuerier)(nil).ListAccountLedger), ctx, arg)
+}
+
+// ListActiveAssignmentRules mocks base method.
+func (m *MockQuerier) ListActiveAssignmentRules(ctx context.Context) ([]generated.AssignmentRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActiveAssignmentRules", ctx)
+	ret0, _ := ret[0].([]generated.AssignmentRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActiveAssignmentRules indicates an expected call of ListActiveAssignmentRules.
+func (mr *MockQuerierMockRecorder) ListActiveAssignmentRules(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActiveAssignmentRules", reflect.TypeOf((*MockQuerier)(nil).ListActiveAssignmentRules), ctx)
+}
+
+// ListAllNonPosted mocks base method.
+func (m *MockQuerier) ListAllNonPosted(ctx context.Context, arg generated.ListAllNonPostedParams) ([]generated.NonPosted, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAllNonPosted", ctx, arg)
+	ret0, _ := ret[0].([]generated.NonPosted)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAllNonPosted indicates an expected call of ListAllNonPosted.
+func (mr *MockQuerierMockRecorder) ListAllNonPosted(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAllNonPosted", reflect.TypeOf((*MockQuerier)(nil).ListAllNonPosted), ctx, arg)
+}
+
+// ListAllNonPostedByTransactionSource mocks base method.
+func (m *MockQuerier) ListAllNonPostedByTransactionSource(ctx context.Context, transactionSource generated.NonPostedTransactionSource) ([]generated.NonPosted, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAllNonPostedByTransactionSource", ctx, transactionSource)
+	ret0, _ := ret[0].([]generated.NonPosted)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAllNonPostedByTransactionSource indicates an expected call of ListAllNonPostedByTransactionSource.
+func (mr *MockQuerierMockRecorder) ListAllNonPostedByTransactionSource(ctx, transactionSource any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAllNonPostedByTransactionSource", reflect.TypeOf((*MockQuerier)(nil).ListAllNonPostedByTransactionSource), ctx, transactionSource)
+}
+
+// ListAssignmentRules mocks base method.
+func (m *MockQuerier) ListAssignmentRules(ctx context.Context) ([]generated.AssignmentRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAssignmentRules", ctx)
+	ret0, _ := ret[0].([]generated.AssignmentRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAssignmentRules indicates an expected call of ListAssignmentRules.
+func (mr *MockQuerierMockRecorder) ListAssignmentRules(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAssignmentRules", reflect.TypeOf((*MockQuerier)(nil).ListAssignmentRules), ctx)
+}
+
+// ListBrachesByCategory mocks base method.
+func (m *MockQuerier) ListBrachesByCategory(ctx context.Context, arg generated.ListBrachesByCategoryParams) ([]generated.Branch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBrachesByCategory", ctx, arg)
+	ret0, _ := ret[0].([]generated.Branch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBrachesByCategory indicates an expected call of ListBrachesByCategory.
+func (mr *MockQuerierMockRecorder) ListBrachesByCategory(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBrachesByCategory", reflect.TypeOf((*MockQuerier)(nil).ListBrachesByCategory), ctx, arg)
+}
+
+// ListBranchCashDisbursements mocks base method.
+func (m *MockQuerier) ListBranchCashDisbursements(ctx context.Context, arg generated.ListBranchCashDisbursementsParams) ([]generated.ListBranchCashDisbursementsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBranchCashDisbursements", ctx, arg)
+	ret0, _ := ret[0].([]generated.ListBranchCashDisbursementsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBranchCashDisbursements indicates an expected call of ListBranchCashDisbursements.
+func (mr *MockQuerierMockRecorder) ListBranchCashDisbursements(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBranchCashDisbursements", reflect.TypeOf((*MockQuerier)(nil).ListBranchCashDisbursements), ctx, arg)
+}
+
+// ListBranchCashReceipts mocks base method.
+func (m *MockQuerier) ListBranchCashReceipts(ctx context.Context, arg generated.ListBranchCashReceiptsParams) ([]generated.ListBranchCashReceiptsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBranchCashReceipts", ctx, arg)
+	ret0, _ := ret[0].([]generated.ListBranchCashReceiptsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBranchCashReceipts indicates an expected call of ListBranchCashReceipts.
+func (mr *MockQuerierMockRecorder) ListBranchCashReceipts(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBranchCashReceipts", reflect.TypeOf((*MockQuerier)(nil).ListBranchCashReceipts), ctx, arg)
+}
+
+// ListBranchExpenses mocks base method.
+func (m *MockQuerier) ListBranchExpenses(ctx context.Context, arg generated.ListBranchExpensesParams) ([]generated.BranchExpense, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBranchExpenses", ctx, arg)
+	ret0, _ := ret[0].([]generated.BranchExpense)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBranchExpenses indicates an expected call of ListBranchExpenses.
+func (mr *MockQuerierMockRecorder) ListBranchExpenses(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBranchExpenses", reflect.TypeOf((*MockQuerier)(nil).ListBranchExpenses), ctx, arg)
+}
+
+// ListBranches mocks base method.
+func (m *MockQuerier) ListBranches(ctx context.Context) ([]generated.Branch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBranches", ctx)
+	ret0, _ := ret[0].([]generated.Branch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBranches indicates an expected call of ListBranches.
+func (mr *MockQuerierMockRecorder) ListBranches(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBranches", reflect.TypeOf((*MockQuerier)(nil).ListBranches), ctx)
+}
+
+// ListCallbackPayloadsByTransactionNumber mocks base method.
+func (m *MockQuerier) ListCallbackPayloadsByTransactionNumber(ctx context.Context, transactionNumber string) ([]generated.CallbackPayload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCallbackPayloadsByTransactionNumber", ctx, transactionNumber)
+	ret0, _ := ret[0].([]generated.CallbackPayload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCallbackPayloadsByTransactionNumber indicates an expected call of ListCallbackPayloadsByTransactionNumber.
+func (mr *MockQuerierMockRecorder) ListCallbackPayloadsByTransactionNumber(ctx, transactionNumber any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCallbackPayloadsByTransactionNumber", reflect.TypeOf((*MockQuerier)(nil).ListCallbackPayloadsByTransactionNumber), ctx, transactionNumber)
+}
+
+// ListCashVariances mocks base method.
+func (m *MockQuerier) ListCashVariances(ctx context.Context, arg generated.ListCashVariancesParams) ([]generated.CashVariance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCashVariances", ctx, arg)
+	ret0, _ := ret[0].([]generated.CashVariance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCashVariances indicates an expected call of ListCashVariances.
+func (mr *MockQuerierMockRecorder) ListCashVariances(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCashVariances", reflect.TypeOf((*MockQuerier)(nil).ListCashVariances), ctx, arg)
+}
+
+// ListClientNames mocks base method.
+func (m *MockQuerier) ListClientNames(ctx context.Context) ([]generated.ListClientNamesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListClientNames", ctx)
+	ret0, _ := ret[0].([]generated.ListClientNamesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListClientNames indicates an expected call of ListClientNames.
+func (mr *MockQuerierMockRecorder) ListClientNames(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListClientNames", reflect.TypeOf((*MockQuerier)(nil).ListClientNames), ctx)
+}
+
+// ListClients mocks base method.
+func (m *MockQuerier) ListClients(ctx context.Context, arg generated.ListClientsParams) ([]generated.Client, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListClients", ctx, arg)
+	ret0, _ := ret[0].([]generated.Client)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteProduct indicates an expected call of DeleteProduct.
-func (mr *MockQuerierMockRecorder) DeleteProduct(ctx, id any) *gomock.Call {
+// ListClients indicates an expected call of ListClients.
+func (mr *MockQuerierMockRecorder) ListClients(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProduct", reflect.TypeOf((*MockQuerier)(nil).DeleteProduct), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListClients", reflect.TypeOf((*MockQuerier)(nil).ListClients), ctx, arg)
 }
 
-// DisburseLoan mocks base method.
-func (m *MockQuerier) DisburseLoan(ctx context.Context, arg generated.DisburseLoanParams) (sql.Result, error) {
+// ListClientsByActiveStatus mocks base method.
+func (m *MockQuerier) ListClientsByActiveStatus(ctx context.Context, arg generated.ListClientsByActiveStatusParams) ([]generated.Client, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DisburseLoan", ctx, arg)
-	ret0, _ := ret[0].(sql.Result)
+	ret := m.ctrl.Call(m, "ListClientsByActiveStatus", ctx, arg)
+	ret0, _ := ret[0].([]generated.Client)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// DisburseLoan indicates an expected call of DisburseLoan.
-func (mr *MockQuerierMockRecorder) DisburseLoan(ctx, arg any) *gomock.Call {
+// ListClientsByActiveStatus indicates an expected call of ListClientsByActiveStatus.
+func (mr *MockQuerierMockRecorder) ListClientsByActiveStatus(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisburseLoan", reflect.TypeOf((*MockQuerier)(nil).DisburseLoan), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListClientsByActiveStatus", reflect.TypeOf((*MockQuerier)(nil).ListClientsByActiveStatus), ctx, arg)
 }
 
-// GetActiveLoanDetails mocks base method.
-func (m *MockQuerier) GetActiveLoanDetails(ctx context.Context, clientID uint32) (generated.GetActiveLoanDetailsRow, error) {
+// ListClientsByBranch mocks base method.
+func (m *MockQuerier) ListClientsByBranch(ctx context.Context, arg generated.ListClientsByBranchParams) ([]generated.Client, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetActiveLoanDetails", ctx, clientID)
-	ret0, _ := ret[0].(generated.GetActiveLoanDetailsRow)
+	ret := m.ctrl.Call(m, "ListClientsByBranch", ctx, arg)
+	ret0, _ := ret[0].([]generated.Client)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetActiveLoanDetails indicates an expected call of GetActiveLoanDetails.
-func (mr *MockQuerierMockRecorder) GetActiveLoanDetails(ctx, clientID any) *gomock.Call {
+// ListClientsByBranch indicates an expected call of ListClientsByBranch.
+func (mr *MockQuerierMockRecorder) ListClientsByBranch(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveLoanDetails", reflect.TypeOf((*MockQuerier)(nil).GetActiveLoanDetails), ctx, clientID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListClientsByBranch", reflect.TypeOf((*MockQuerier)(nil).ListClientsByBranch), ctx, arg)
 }
 
-// GetBranch mocks base method.
-func (m *MockQuerier) GetBranch(ctx context.Context, id uint32) (generated.Branch, error) {
+// ListClientsByCategory mocks base method.
+func (m *MockQuerier) ListClientsByCategory(ctx context.Context, arg generated.ListClientsByCategoryParams) ([]generated.ListClientsByCategoryRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetBranch", ctx, id)
-	ret0, _ := ret[0].(generated.Branch)
+	ret := m.ctrl.Call(m, "ListClientsByCategory", ctx, arg)
+	ret0, _ := ret[0].([]generated.ListClientsByCategoryRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetBranch indicates an expected call of GetBranch.
-func (mr *MockQuerierMockRecorder) GetBranch(ctx, id any) *gomock.Call {
+// ListClientsByCategory indicates an expected call of ListClientsByCategory.
+func (mr *MockQuerierMockRecorder) ListClientsByCategory(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBranch", reflect.TypeOf((*MockQuerier)(nil).GetBranch), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListClientsByCategory", reflect.TypeOf((*MockQuerier)(nil).ListClientsByCategory), ctx, arg)
 }
 
-// GetBranchReportData mocks base method.
-func (m *MockQuerier) GetBranchReportData(ctx context.Context, arg generated.GetBranchReportDataParams) ([]generated.GetBranchReportDataRow, error) {
+// ListClientsByPhoneNumbers mocks base method.
+func (m *MockQuerier) ListClientsByPhoneNumbers(ctx context.Context, arg generated.ListClientsByPhoneNumbersParams) ([]generated.Client, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetBranchReportData", ctx, arg)
-	ret0, _ := ret[0].([]generated.GetBranchReportDataRow)
+	ret := m.ctrl.Call(m, "ListClientsByPhoneNumbers", ctx, arg)
+	ret0, _ := ret[0].([]generated.Client)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetBranchReportData indicates an expected call of GetBranchReportData.
-func (mr *MockQuerierMockRecorder) GetBranchReportData(ctx, arg any) *gomock.Call {
+// ListClientsByPhoneNumbers indicates an expected call of ListClientsByPhoneNumbers.
+func (mr *MockQuerierMockRecorder) ListClientsByPhoneNumbers(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBranchReportData", reflect.TypeOf((*MockQuerier)(nil).GetBranchReportData), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListClientsByPhoneNumbers", reflect.TypeOf((*MockQuerier)(nil).ListClientsByPhoneNumbers), ctx, arg)
 }
 
-// GetClient mocks base method.
-func (m *MockQuerier) GetClient(ctx context.Context, id uint32) (generated.Client, error) {
+// ListDueInstallmentsByAmount mocks base method.
+func (m *MockQuerier) ListDueInstallmentsByAmount(ctx context.Context, arg generated.ListDueInstallmentsByAmountParams) ([]generated.ListDueInstallmentsByAmountRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetClient", ctx, id)
-	ret0, _ := ret[0].(generated.Client)
+	ret := m.ctrl.Call(m, "ListDueInstallmentsByAmount", ctx, arg)
+	ret0, _ := ret[0].([]generated.ListDueInstallmentsByAmountRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetClient indicates an expected call of GetClient.
-func (mr *MockQuerierMockRecorder) GetClient(ctx, id any) *gomock.Call {
+// ListDueInstallmentsByAmount indicates an expected call of ListDueInstallmentsByAmount.
+func (mr *MockQuerierMockRecorder) ListDueInstallmentsByAmount(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClient", reflect.TypeOf((*MockQuerier)(nil).GetClient), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDueInstallmentsByAmount", reflect.TypeOf((*MockQuerier)(nil).ListDueInstallmentsByAmount), ctx, arg)
 }
 
-// GetClientActiveLoan mocks base method.
-func (m *MockQuerier) GetClientActiveLoan(ctx context.Context, arg generated.GetClientActiveLoanParams) (uint32, error) {
+// ListDuplicateNonPosted mocks base method.
+func (m *MockQuerier) ListDuplicateNonPosted(ctx context.Context) ([]generated.NonPosted, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetClientActiveLoan", ctx, arg)
-	ret0, _ := ret[0].(uint32)
+	ret := m.ctrl.Call(m, "ListDuplicateNonPosted", ctx)
+	ret0, _ := ret[0].([]generated.NonPosted)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetClientActiveLoan indicates an expected call of GetClientActiveLoan.
-func (mr *MockQuerierMockRecorder) GetClientActiveLoan(ctx, arg any) *gomock.Call {
+// ListDuplicateNonPosted indicates an expected call of ListDuplicateNonPosted.
+func (mr *MockQuerierMockRecorder) ListDuplicateNonPosted(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClientActiveLoan", reflect.TypeOf((*MockQuerier)(nil).GetClientActiveLoan), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDuplicateNonPosted", reflect.TypeOf((*MockQuerier)(nil).ListDuplicateNonPosted), ctx)
 }
 
-// GetClientAdminsReportData mocks base method.
-func (m *MockQuerier) GetClientAdminsReportData(ctx context.Context, arg generated.GetClientAdminsReportDataParams) ([]generated.GetClientAdminsReportDataRow, error) {
+// ListExpectedPayments mocks base method.
+func (m *MockQuerier) ListExpectedPayments(ctx context.Context, arg generated.ListExpectedPaymentsParams) ([]generated.ListExpectedPaymentsRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetClientAdminsReportData", ctx, arg)
-	ret0, _ := ret[0].([]generated.GetClientAdminsReportDataRow)
+	ret := m.ctrl.Call(m, "ListExpectedPayments", ctx, arg)
+	ret0, _ := ret[0].([]generated.ListExpectedPaymentsRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetClientAdminsReportData indicates an expected call of GetClientAdminsReportData.
-func (mr *MockQuerierMockRecorder) GetClientAdminsReportData(ctx, arg any) *gomock.Call {
+// ListExpectedPayments indicates an expected call of ListExpectedPayments.
+func (mr *MockQuerierMockRecorder) ListExpectedPayments(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClientAdminsReportData", reflect.TypeOf((*MockQuerier)(nil).GetClientAdminsReportData), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpectedPayments", reflect.TypeOf((*MockQuerier)(nil).ListExpectedPayments), ctx, arg)
 }
 
-// GetClientByPhoneNumber mocks base method.
-func (m *MockQuerier) GetClientByPhoneNumber(ctx context.Context, phoneNumber string) (generated.Client, error) {
+// ListInstallmentsByLoan mocks base method.
+func (m *MockQuerier) ListInstallmentsByLoan(ctx context.Context, loanID uint32) ([]generated.Installment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetClientByPhoneNumber", ctx, phoneNumber)
-	ret0, _ := ret[0].(generated.Client)
+	ret := m.ctrl.Call(m, "ListInstallmentsByLoan", ctx, loanID)
+	ret0, _ := ret[0].([]generated.Installment)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetClientByPhoneNumber indicates an expected call of GetClientByPhoneNumber.
-func (mr *MockQuerierMockRecorder) GetClientByPhoneNumber(ctx, phoneNumber any) *gomock.Call {
+// ListInstallmentsByLoan indicates an expected call of ListInstallmentsByLoan.
+func (mr *MockQuerierMockRecorder) ListInstallmentsByLoan(ctx, loanID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClientByPhoneNumber", reflect.TypeOf((*MockQuerier)(nil).GetClientByPhoneNumber), ctx, phoneNumber)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInstallmentsByLoan", reflect.TypeOf((*MockQuerier)(nil).ListInstallmentsByLoan), ctx, loanID)
 }
 
-// GetClientClientsReportData mocks base method.
-func (m *MockQuerier) GetClientClientsReportData(ctx context.Context, arg generated.GetClientClientsReportDataParams) (generated.GetClientClientsReportDataRow, error) {
+// ListInstallmentsForPenaltyAccrual mocks base method.
+func (m *MockQuerier) ListInstallmentsForPenaltyAccrual(ctx context.Context, asOf time.Time) ([]generated.ListInstallmentsForPenaltyAccrualRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetClientClientsReportData", ctx, arg)
-	ret0, _ := ret[0].(generated.GetClientClientsReportDataRow)
+	ret := m.ctrl.Call(m, "ListInstallmentsForPenaltyAccrual", ctx, asOf)
+	ret0, _ := ret[0].([]generated.ListInstallmentsForPenaltyAccrualRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetClientClientsReportData indicates an expected call of GetClientClientsReportData.
-func (mr *MockQuerierMockRecorder) GetClientClientsReportData(ctx, arg any) *gomock.Call {
+// ListInstallmentsForPenaltyAccrual indicates an expected call of ListInstallmentsForPenaltyAccrual.
+func (mr *MockQuerierMockRecorder) ListInstallmentsForPenaltyAccrual(ctx, asOf any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClientClientsReportData", reflect.TypeOf((*MockQuerier)(nil).GetClientClientsReportData), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInstallmentsForPenaltyAccrual", reflect.TypeOf((*MockQuerier)(nil).ListInstallmentsForPenaltyAccrual), ctx, asOf)
 }
 
-// GetClientFullData mocks base method.
-func (m *MockQuerier) GetClientFullData(ctx context.Context, id uint32) (generated.GetClientFullDataRow, error) {
+// ListLedgerAccounts mocks base method.
+func (m *MockQuerier) ListLedgerAccounts(ctx context.Context) ([]generated.LedgerAccount, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetClientFullData", ctx, id)
-	ret0, _ := ret[0].(generated.GetClientFullDataRow)
+	ret := m.ctrl.Call(m, "ListLedgerAccounts", ctx)
+	ret0, _ := ret[0].([]generated.LedgerAccount)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetClientFullData indicates an expected call of GetClientFullData.
-func (mr *MockQuerierMockRecorder) GetClientFullData(ctx, id any) *gomock.Call {
+// ListLedgerAccounts indicates an expected call of ListLedgerAccounts.
+func (mr *MockQuerierMockRecorder) ListLedgerAccounts(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClientFullData", reflect.TypeOf((*MockQuerier)(nil).GetClientFullData), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLedgerAccounts", reflect.TypeOf((*MockQuerier)(nil).ListLedgerAccounts), ctx)
 }
 
-// GetClientIDByPhoneNumber mocks base method.
-func (m *MockQuerier) GetClientIDByPhoneNumber(ctx context.Context, phoneNumber string) (uint32, error) {
+// ListLoanDisbursementsByLoan mocks base method.
+func (m *MockQuerier) ListLoanDisbursementsByLoan(ctx context.Context, loanID uint32) ([]generated.LoanDisbursement, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetClientIDByPhoneNumber", ctx, phoneNumber)
-	ret0, _ := ret[0].(uint32)
+	ret := m.ctrl.Call(m, "ListLoanDisbursementsByLoan", ctx, loanID)
+	ret0, _ := ret[0].([]generated.LoanDisbursement)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetClientIDByPhoneNumber indicates an expected call of GetClientIDByPhoneNumber.
-func (mr *MockQuerierMockRecorder) GetClientIDByPhoneNumber(ctx, phoneNumber any) *gomock.Call {
+// ListLoanDisbursementsByLoan indicates an expected call of ListLoanDisbursementsByLoan.
+func (mr *MockQuerierMockRecorder) ListLoanDisbursementsByLoan(ctx, loanID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClientIDByPhoneNumber", reflect.TypeOf((*MockQuerier)(nil).GetClientIDByPhoneNumber), ctx, phoneNumber)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLoanDisbursementsByLoan", reflect.TypeOf((*MockQuerier)(nil).ListLoanDisbursementsByLoan), ctx, loanID)
 }
 
-// GetClientOverpayment mocks base method.
-func (m *MockQuerier) GetClientOverpayment(ctx context.Context, id uint32) (float64, error) {
+// ListLoanRestructures mocks base method.
+func (m *MockQuerier) ListLoanRestructures(ctx context.Context, loanID uint32) ([]generated.LoanRestructure, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetClientOverpayment", ctx, id)
-	ret0, _ := ret[0].(float64)
+	ret := m.ctrl.Call(m, "ListLoanRestructures", ctx, loanID)
+	ret0, _ := ret[0].([]generated.LoanRestructure)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetClientOverpayment indicates an expected call of GetClientOverpayment.
-func (mr *MockQuerierMockRecorder) GetClientOverpayment(ctx, id any) *gomock.Call {
+// ListLoanRestructures indicates an expected call of ListLoanRestructures.
+func (mr *MockQuerierMockRecorder) ListLoanRestructures(ctx, loanID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClientOverpayment", reflect.TypeOf((*MockQuerier)(nil).GetClientOverpayment), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLoanRestructures", reflect.TypeOf((*MockQuerier)(nil).ListLoanRestructures), ctx, loanID)
 }
 
-// GetClientOverpaymentTransaction mocks base method.
-func (m *MockQuerier) GetClientOverpaymentTransaction(ctx context.Context, id uint32) (generated.ClientOverpaymentTransaction, error) {
+// ListLoanSettlementQuotes mocks base method.
+func (m *MockQuerier) ListLoanSettlementQuotes(ctx context.Context, loanID uint32) ([]generated.LoanSettlementQuote, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetClientOverpaymentTransaction", ctx, id)
-	ret0, _ := ret[0].(generated.ClientOverpaymentTransaction)
+	ret := m.ctrl.Call(m, "ListLoanSettlementQuotes", ctx, loanID)
+	ret0, _ := ret[0].([]generated.LoanSettlementQuote)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetClientOverpaymentTransaction indicates an expected call of GetClientOverpaymentTransaction.
-func (mr *MockQuerierMockRecorder) GetClientOverpaymentTransaction(ctx, id any) *gomock.Call {
+// ListLoanSettlementQuotes indicates an expected call of ListLoanSettlementQuotes.
+func (mr *MockQuerierMockRecorder) ListLoanSettlementQuotes(ctx, loanID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClientOverpaymentTransaction", reflect.TypeOf((*MockQuerier)(nil).GetClientOverpaymentTransaction), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLoanSettlementQuotes", reflect.TypeOf((*MockQuerier)(nil).ListLoanSettlementQuotes), ctx, loanID)
 }
 
-// GetClientOverpaymentTransactionByPaymentId mocks base method.
-func (m *MockQuerier) GetClientOverpaymentTransactionByPaymentId(ctx context.Context, paymentID sql.NullInt32) (generated.ClientOverpaymentTransaction, error) {
+// ListLoans mocks base method.
+func (m *MockQuerier) ListLoans(ctx context.Context, arg generated.ListLoansParams) ([]generated.ListLoansRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetClientOverpaymentTransactionByPaymentId", ctx, paymentID)
-	ret0, _ := ret[0].(generated.ClientOverpaymentTransaction)
+	ret := m.ctrl.Call(m, "ListLoans", ctx, arg)
+	ret0, _ := ret[0].([]generated.ListLoansRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetClientOverpaymentTransactionByPaymentId indicates an expected call of GetClientOverpaymentTransactionByPaymentId.
-func (mr *MockQuerierMockRecorder) GetClientOverpaymentTransactionByPaymentId(ctx, paymentID any) *gomock.Call {
+// ListLoans indicates an expected call of ListLoans.
+func (mr *MockQuerierMockRecorder) ListLoans(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClientOverpaymentTransactionByPaymentId", reflect.TypeOf((*MockQuerier)(nil).GetClientOverpaymentTransactionByPaymentId), ctx, paymentID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLoans", reflect.TypeOf((*MockQuerier)(nil).ListLoans), ctx, arg)
 }
 
-// GetClientOverpaymentTransactions mocks base method.
-func (m *MockQuerier) GetClientOverpaymentTransactions(ctx context.Context, clientID uint32) ([]generated.ClientOverpaymentTransaction, error) {
+// ListLoansByClient mocks base method.
+func (m *MockQuerier) ListLoansByClient(ctx context.Context, arg generated.ListLoansByClientParams) ([]generated.Loan, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetClientOverpaymentTransactions", ctx, clientID)
-	ret0, _ := ret[0].([]generated.ClientOverpaymentTransaction)
+	ret := m.ctrl.Call(m, "ListLoansByClient", ctx, arg)
+	ret0, _ := ret[0].([]generated.Loan)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetClientOverpaymentTransactions indicates an expected call of GetClientOverpaymentTransactions.
-func (mr *MockQuerierMockRecorder) GetClientOverpaymentTransactions(ctx, clientID any) *gomock.Call {
+// ListLoansByClient indicates an expected call of ListLoansByClient.
+func (mr *MockQuerierMockRecorder) ListLoansByClient(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClientOverpaymentTransactions", reflect.TypeOf((*MockQuerier)(nil).GetClientOverpaymentTransactions), ctx, clientID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLoansByClient", reflect.TypeOf((*MockQuerier)(nil).ListLoansByClient), ctx, arg)
 }
 
-// GetClientsNonPosted mocks base method.
-func (m *MockQuerier) GetClientsNonPosted(ctx context.Context, arg generated.GetClientsNonPostedParams) ([]generated.GetClientsNonPostedRow, error) {
+// ListLoansByLoanOfficer mocks base method.
+func (m *MockQuerier) ListLoansByLoanOfficer(ctx context.Context, arg generated.ListLoansByLoanOfficerParams) ([]generated.Loan, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetClientsNonPosted", ctx, arg)
-	ret0, _ := ret[0].([]generated.GetClientsNonPostedRow)
+	ret := m.ctrl.Call(m, "ListLoansByLoanOfficer", ctx, arg)
+	ret0, _ := ret[0].([]generated.Loan)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetClientsNonPosted indicates an expected call of GetClientsNonPosted.
-func (mr *MockQuerierMockRecorder) GetClientsNonPosted(ctx, arg any) *gomock.Call {
+// ListLoansByLoanOfficer indicates an expected call of ListLoansByLoanOfficer.
+func (mr *MockQuerierMockRecorder) ListLoansByLoanOfficer(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClientsNonPosted", reflect.TypeOf((*MockQuerier)(nil).GetClientsNonPosted), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLoansByLoanOfficer", reflect.TypeOf((*MockQuerier)(nil).ListLoansByLoanOfficer), ctx, arg)
 }
 
-// GetInstallment mocks base method.
-func (m *MockQuerier) GetInstallment(ctx context.Context, id uint32) (generated.Installment, error) {
+// ListLoansByStatus mocks base method.
+func (m *MockQuerier) ListLoansByStatus(ctx context.Context, arg generated.ListLoansByStatusParams) ([]generated.Loan, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetInstallment", ctx, id)
-	ret0, _ := ret[0].(generated.Installment)
+	ret := m.ctrl.Call(m, "ListLoansByStatus", ctx, arg)
+	ret0, _ := ret[0].([]generated.Loan)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetInstallment indicates an expected call of GetInstallment.
-func (mr *MockQuerierMockRecorder) GetInstallment(ctx, id any) *gomock.Call {
+// ListLoansByStatus indicates an expected call of ListLoansByStatus.
+func (mr *MockQuerierMockRecorder) ListLoansByStatus(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInstallment", reflect.TypeOf((*MockQuerier)(nil).GetInstallment), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLoansByStatus", reflect.TypeOf((*MockQuerier)(nil).ListLoansByStatus), ctx, arg)
 }
 
-// GetLoan mocks base method.
-func (m *MockQuerier) GetLoan(ctx context.Context, id uint32) (generated.Loan, error) {
+// ListLoansPastDefaultThreshold mocks base method.
+func (m *MockQuerier) ListLoansPastDefaultThreshold(ctx context.Context, arg generated.ListLoansPastDefaultThresholdParams) ([]generated.ListLoansPastDefaultThresholdRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetLoan", ctx, id)
-	ret0, _ := ret[0].(generated.Loan)
+	ret := m.ctrl.Call(m, "ListLoansPastDefaultThreshold", ctx, arg)
+	ret0, _ := ret[0].([]generated.ListLoansPastDefaultThresholdRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetLoan indicates an expected call of GetLoan.
-func (mr *MockQuerierMockRecorder) GetLoan(ctx, id any) *gomock.Call {
+// ListLoansPastDefaultThreshold indicates an expected call of ListLoansPastDefaultThreshold.
+func (mr *MockQuerierMockRecorder) ListLoansPastDefaultThreshold(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoan", reflect.TypeOf((*MockQuerier)(nil).GetLoan), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLoansPastDefaultThreshold", reflect.TypeOf((*MockQuerier)(nil).ListLoansPastDefaultThreshold), ctx, arg)
 }
 
-// GetLoanClientID mocks base method.
-func (m *MockQuerier) GetLoanClientID(ctx context.Context, id uint32) (uint32, error) {
+// ListMpesaNonPostedByPaidDate mocks base method.
+func (m *MockQuerier) ListMpesaNonPostedByPaidDate(ctx context.Context, arg generated.ListMpesaNonPostedByPaidDateParams) ([]generated.NonPosted, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetLoanClientID", ctx, id)
-	ret0, _ := ret[0].(uint32)
+	ret := m.ctrl.Call(m, "ListMpesaNonPostedByPaidDate", ctx, arg)
+	ret0, _ := ret[0].([]generated.NonPosted)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetLoanClientID indicates an expected call of GetLoanClientID.
-func (mr *MockQuerierMockRecorder) GetLoanClientID(ctx, id any) *gomock.Call {
+// ListMpesaNonPostedByPaidDate indicates an expected call of ListMpesaNonPostedByPaidDate.
+func (mr *MockQuerierMockRecorder) ListMpesaNonPostedByPaidDate(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoanClientID", reflect.TypeOf((*MockQuerier)(nil).GetLoanClientID), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMpesaNonPostedByPaidDate", reflect.TypeOf((*MockQuerier)(nil).ListMpesaNonPostedByPaidDate), ctx, arg)
 }
 
-// GetLoanData mocks base method.
-func (m *MockQuerier) GetLoanData(ctx context.Context) ([]uint32, error) {
+// ListNonDisbursedLoans mocks base method.
+func (m *MockQuerier) ListNonDisbursedLoans(ctx context.Context, arg generated.ListNonDisbursedLoansParams) ([]generated.Loan, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetLoanData", ctx)
-	ret0, _ := ret[0].([]uint32)
+	ret := m.ctrl.Call(m, "ListNonDisbursedLoans", ctx, arg)
+	ret0, _ := ret[0].([]generated.Loan)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetLoanData indicates an expected call of GetLoanData.
-func (mr *MockQuerierMockRecorder) GetLoanData(ctx any) *gomock.Call {
+// ListNonDisbursedLoans indicates an expected call of ListNonDisbursedLoans.
+func (mr *MockQuerierMockRecorder) ListNonDisbursedLoans(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoanData", reflect.TypeOf((*MockQuerier)(nil).GetLoanData), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNonDisbursedLoans", reflect.TypeOf((*MockQuerier)(nil).ListNonDisbursedLoans), ctx, arg)
 }
 
-// GetLoanEvents mocks base method.
-func (m *MockQuerier) GetLoanEvents(ctx context.Context) ([]generated.GetLoanEventsRow, error) {
+// ListNonPostedByCategory mocks base method.
+func (m *MockQuerier) ListNonPostedByCategory(ctx context.Context, arg generated.ListNonPostedByCategoryParams) ([]generated.ListNonPostedByCategoryRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetLoanEvents", ctx)
-	ret0, _ := ret[0].([]generated.GetLoanEventsRow)
+	ret := m.ctrl.Call(m, "ListNonPostedByCategory", ctx, arg)
+	ret0, _ := ret[0].([]generated.ListNonPostedByCategoryRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetLoanEvents indicates an expected call of GetLoanEvents.
-func (mr *MockQuerierMockRecorder) GetLoanEvents(ctx any) *gomock.Call {
+// ListNonPostedByCategory indicates an expected call of ListNonPostedByCategory.
+func (mr *MockQuerierMockRecorder) ListNonPostedByCategory(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoanEvents", reflect.TypeOf((*MockQuerier)(nil).GetLoanEvents), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNonPostedByCategory", reflect.TypeOf((*MockQuerier)(nil).ListNonPostedByCategory), ctx, arg)
 }
 
-// GetLoanFullData mocks base method.
-func (m *MockQuerier) GetLoanFullData(ctx context.Context, id uint32) (generated.GetLoanFullDataRow, error) {
+// ListNonPostedByTransactionSource mocks base method.
+func (m *MockQuerier) ListNonPostedByTransactionSource(ctx context.Context, arg generated.ListNonPostedByTransactionSourceParams) ([]generated.NonPosted, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetLoanFullData", ctx, id)
-	ret0, _ := ret[0].(generated.GetLoanFullDataRow)
+	ret := m.ctrl.Call(m, "ListNonPostedByTransactionSource", ctx, arg)
+	ret0, _ := ret[0].([]generated.NonPosted)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetLoanFullData indicates an expected call of GetLoanFullData.
-func (mr *MockQuerierMockRecorder) GetLoanFullData(ctx, id any) *gomock.Call {
+// ListNonPostedByTransactionSource indicates an expected call of ListNonPostedByTransactionSource.
+func (mr *MockQuerierMockRecorder) ListNonPostedByTransactionSource(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoanFullData", reflect.TypeOf((*MockQuerier)(nil).GetLoanFullData), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNonPostedByTransactionSource", reflect.TypeOf((*MockQuerier)(nil).ListNonPostedByTransactionSource), ctx, arg)
 }
 
-// GetLoanReportDataById mocks base method.
-func (m *MockQuerier) GetLoanReportDataById(ctx context.Context, id uint32) (generated.GetLoanReportDataByIdRow, error) {
+// ListOverpaymentRefunds mocks base method.
+func (m *MockQuerier) ListOverpaymentRefunds(ctx context.Context, arg generated.ListOverpaymentRefundsParams) ([]generated.OverpaymentRefund, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetLoanReportDataById", ctx, id)
-	ret0, _ := ret[0].(generated.GetLoanReportDataByIdRow)
+	ret := m.ctrl.Call(m, "ListOverpaymentRefunds", ctx, arg)
+	ret0, _ := ret[0].([]generated.OverpaymentRefund)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetLoanReportDataById indicates an expected call of GetLoanReportDataById.
-func (mr *MockQuerierMockRecorder) GetLoanReportDataById(ctx, id any) *gomock.Call {
+// ListOverpaymentRefunds indicates an expected call of ListOverpaymentRefunds.
+func (mr *MockQuerierMockRecorder) ListOverpaymentRefunds(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoanReportDataById", reflect.TypeOf((*MockQuerier)(nil).GetLoanReportDataById), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOverpaymentRefunds", reflect.TypeOf((*MockQuerier)(nil).ListOverpaymentRefunds), ctx, arg)
 }
 
-// GetLoansReportData mocks base method.
-func (m *MockQuerier) GetLoansReportData(ctx context.Context, arg generated.GetLoansReportDataParams) ([]generated.GetLoansReportDataRow, error) {
+// ListOverpaymentRefundsByStatus mocks base method.
+func (m *MockQuerier) ListOverpaymentRefundsByStatus(ctx context.Context, arg generated.ListOverpaymentRefundsByStatusParams) ([]generated.OverpaymentRefund, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetLoansReportData", ctx, arg)
-	ret0, _ := ret[0].([]generated.GetLoansReportDataRow)
+	ret := m.ctrl.Call(m, "ListOverpaymentRefundsByStatus", ctx, arg)
+	ret0, _ := ret[0].([]generated.OverpaymentRefund)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetLoansReportData indicates an expected call of GetLoansReportData.
-func (mr *MockQuerierMockRecorder) GetLoansReportData(ctx, arg any) *gomock.Call {
+// ListOverpaymentRefundsByStatus indicates an expected call of ListOverpaymentRefundsByStatus.
+func (mr *MockQuerierMockRecorder) ListOverpaymentRefundsByStatus(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoansReportData", reflect.TypeOf((*MockQuerier)(nil).GetLoansReportData), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOverpaymentRefundsByStatus", reflect.TypeOf((*MockQuerier)(nil).ListOverpaymentRefundsByStatus), ctx, arg)
 }
 
-// GetNonPosted mocks base method.
-func (m *MockQuerier) GetNonPosted(ctx context.Context, id uint32) (generated.NonPosted, error) {
+// ListPaymentAllocationsByLoanId mocks base method.
+func (m *MockQuerier) ListPaymentAllocationsByLoanId(ctx context.Context, loanID sql.NullInt32) ([]generated.ListPaymentAllocationsByLoanIdRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetNonPosted", ctx, id)
-	ret0, _ := ret[0].(generated.NonPosted)
+	ret := m.ctrl.Call(m, "ListPaymentAllocationsByLoanId", ctx, loanID)
+	ret0, _ := ret[0].([]generated.ListPaymentAllocationsByLoanIdRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetNonPosted indicates an expected call of GetNonPosted.
-func (mr *MockQuerierMockRecorder) GetNonPosted(ctx, id any) *gomock.Call {
+// ListPaymentAllocationsByLoanId indicates an expected call of ListPaymentAllocationsByLoanId.
+func (mr *MockQuerierMockRecorder) ListPaymentAllocationsByLoanId(ctx, loanID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNonPosted", reflect.TypeOf((*MockQuerier)(nil).GetNonPosted), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPaymentAllocationsByLoanId", reflect.TypeOf((*MockQuerier)(nil).ListPaymentAllocationsByLoanId), ctx, loanID)
 }
 
-// GetPaymentReportData mocks base method.
-func (m *MockQuerier) GetPaymentReportData(ctx context.Context, arg generated.GetPaymentReportDataParams) ([]generated.GetPaymentReportDataRow, error) {
+// ListPaymentAllocationsByNonPostedID mocks base method.
+func (m *MockQuerier) ListPaymentAllocationsByNonPostedID(ctx context.Context, nonPostedID uint32) ([]generated.ListPaymentAllocationsByNonPostedIDRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPaymentReportData", ctx, arg)
-	ret0, _ := ret[0].([]generated.GetPaymentReportDataRow)
+	ret := m.ctrl.Call(m, "ListPaymentAllocationsByNonPostedID", ctx, nonPostedID)
+	ret0, _ := ret[0].([]generated.ListPaymentAllocationsByNonPostedIDRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetPaymentReportData indicates an expected call of GetPaymentReportData.
-func (mr *MockQuerierMockRecorder) GetPaymentReportData(ctx, arg any) *gomock.Call {
+// ListPaymentAllocationsByNonPostedID indicates an expected call of ListPaymentAllocationsByNonPostedID.
+func (mr *MockQuerierMockRecorder) ListPaymentAllocationsByNonPostedID(ctx, nonPostedID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentReportData", reflect.TypeOf((*MockQuerier)(nil).GetPaymentReportData), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPaymentAllocationsByNonPostedID", reflect.TypeOf((*MockQuerier)(nil).ListPaymentAllocationsByNonPostedID), ctx, nonPostedID)
 }
 
-// GetProduct mocks base method.
-func (m *MockQuerier) GetProduct(ctx context.Context, id uint32) (generated.GetProductRow, error) {
+// ListPaymentAllocationsByNonPostedId mocks base method.
+func (m *MockQuerier) ListPaymentAllocationsByNonPostedId(ctx context.Context, nonPostedID uint32) ([]generated.PaymentAllocation, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetProduct", ctx, id)
-	ret0, _ := ret[0].(generated.GetProductRow)
+	ret := m.ctrl.Call(m, "ListPaymentAllocationsByNonPostedId", ctx, nonPostedID)
+	ret0, _ := ret[0].([]generated.PaymentAllocation)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetProduct indicates an expected call of GetProduct.
-func (mr *MockQuerierMockRecorder) GetProduct(ctx, id any) *gomock.Call {
+// ListPaymentAllocationsByNonPostedId indicates an expected call of ListPaymentAllocationsByNonPostedId.
+func (mr *MockQuerierMockRecorder) ListPaymentAllocationsByNonPostedId(ctx, nonPostedID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProduct", reflect.TypeOf((*MockQuerier)(nil).GetProduct), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPaymentAllocationsByNonPostedId", reflect.TypeOf((*MockQuerier)(nil).ListPaymentAllocationsByNonPostedId), ctx, nonPostedID)
 }
 
-// GetProductRepayAmount mocks base method.
-func (m *MockQuerier) GetProductRepayAmount(ctx context.Context, id uint32) (float64, error) {
+// ListPaymentImportBatches mocks base method.
+func (m *MockQuerier) ListPaymentImportBatches(ctx context.Context, arg generated.ListPaymentImportBatchesParams) ([]generated.PaymentImportBatch, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetProductRepayAmount", ctx, id)
-	ret0, _ := ret[0].(float64)
+	ret := m.ctrl.Call(m, "ListPaymentImportBatches", ctx, arg)
+	ret0, _ := ret[0].([]generated.PaymentImportBatch)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetProductRepayAmount indicates an expected call of GetProductRepayAmount.
-func (mr *MockQuerierMockRecorder) GetProductRepayAmount(ctx, id any) *gomock.Call {
+// ListPaymentImportBatches indicates an expected call of ListPaymentImportBatches.
+func (mr *MockQuerierMockRecorder) ListPaymentImportBatches(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductRepayAmount", reflect.TypeOf((*MockQuerier)(nil).GetProductRepayAmount), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPaymentImportBatches", reflect.TypeOf((*MockQuerier)(nil).ListPaymentImportBatches), ctx, arg)
 }
 
-// GetProductReportData mocks base method.
-func (m *MockQuerier) GetProductReportData(ctx context.Context, arg generated.GetProductReportDataParams) ([]generated.GetProductReportDataRow, error) {
+// ListPaymentImportRows mocks base method.
+func (m *MockQuerier) ListPaymentImportRows(ctx context.Context, batchID uint32) ([]generated.PaymentImportRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetProductReportData", ctx, arg)
-	ret0, _ := ret[0].([]generated.GetProductReportDataRow)
+	ret := m.ctrl.Call(m, "ListPaymentImportRows", ctx, batchID)
+	ret0, _ := ret[0].([]generated.PaymentImportRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetProductReportData indicates an expected call of GetProductReportData.
-func (mr *MockQuerierMockRecorder) GetProductReportData(ctx, arg any) *gomock.Call {
+// ListPaymentImportRows indicates an expected call of ListPaymentImportRows.
+func (mr *MockQuerierMockRecorder) ListPaymentImportRows(ctx, batchID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductReportData", reflect.TypeOf((*MockQuerier)(nil).GetProductReportData), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPaymentImportRows", reflect.TypeOf((*MockQuerier)(nil).ListPaymentImportRows), ctx, batchID)
 }
 
-// GetTotalPaidByIDorAccountNo mocks base method.
-func (m *MockQuerier) GetTotalPaidByIDorAccountNo(ctx context.Context, arg generated.GetTotalPaidByIDorAccountNoParams) (any, error) {
+// ListPaymentReassignmentsByNonPosted mocks base method.
+func (m *MockQuerier) ListPaymentReassignmentsByNonPosted(ctx context.Context, nonPostedID uint32) ([]generated.PaymentReassignment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTotalPaidByIDorAccountNo", ctx, arg)
-	ret0, _ := ret[0].(any)
+	ret := m.ctrl.Call(m, "ListPaymentReassignmentsByNonPosted", ctx, nonPostedID)
+	ret0, _ := ret[0].([]generated.PaymentReassignment)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetTotalPaidByIDorAccountNo indicates an expected call of GetTotalPaidByIDorAccountNo.
-func (mr *MockQuerierMockRecorder) GetTotalPaidByIDorAccountNo(ctx, arg any) *gomock.Call {
+// ListPaymentReassignmentsByNonPosted indicates an expected call of ListPaymentReassignmentsByNonPosted.
+func (mr *MockQuerierMockRecorder) ListPaymentReassignmentsByNonPosted(ctx, nonPostedID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTotalPaidByIDorAccountNo", reflect.TypeOf((*MockQuerier)(nil).GetTotalPaidByIDorAccountNo), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPaymentReassignmentsByNonPosted", reflect.TypeOf((*MockQuerier)(nil).ListPaymentReassignmentsByNonPosted), ctx, nonPostedID)
 }
 
-// GetUnpaidInstallmentsData mocks base method.
-func (m *MockQuerier) GetUnpaidInstallmentsData(ctx context.Context, arg generated.GetUnpaidInstallmentsDataParams) ([]generated.GetUnpaidInstallmentsDataRow, error) {
+// ListPaymentSplitPortions mocks base method.
+func (m *MockQuerier) ListPaymentSplitPortions(ctx context.Context, splitID uint32) ([]generated.PaymentSplitPortion, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUnpaidInstallmentsData", ctx, arg)
-	ret0, _ := ret[0].([]generated.GetUnpaidInstallmentsDataRow)
+	ret := m.ctrl.Call(m, "ListPaymentSplitPortions", ctx, splitID)
+	ret0, _ := ret[0].([]generated.PaymentSplitPortion)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUnpaidInstallmentsData indicates an expected call of GetUnpaidInstallmentsData.
-func (mr *MockQuerierMockRecorder) GetUnpaidInstallmentsData(ctx, arg any) *gomock.Call {
+// ListPaymentSplitPortions indicates an expected call of ListPaymentSplitPortions.
+func (mr *MockQuerierMockRecorder) ListPaymentSplitPortions(ctx, splitID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnpaidInstallmentsData", reflect.TypeOf((*MockQuerier)(nil).GetUnpaidInstallmentsData), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPaymentSplitPortions", reflect.TypeOf((*MockQuerier)(nil).ListPaymentSplitPortions), ctx, splitID)
 }
 
-// GetUser mocks base method.
-func (m *MockQuerier) GetUser(ctx context.Context, id uint32) (generated.GetUserRow, error) {
+// ListPaymentValidationLogs mocks base method.
+func (m *MockQuerier) ListPaymentValidationLogs(ctx context.Context, arg generated.ListPaymentValidationLogsParams) ([]generated.PaymentValidationLog, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUser", ctx, id)
-	ret0, _ := ret[0].(generated.GetUserRow)
+	ret := m.ctrl.Call(m, "ListPaymentValidationLogs", ctx, arg)
+	ret0, _ := ret[0].([]generated.PaymentValidationLog)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUser indicates an expected call of GetUser.
-func (mr *MockQuerierMockRecorder) GetUser(ctx, id any) *gomock.Call {
+// ListPaymentValidationLogs indicates an expected call of ListPaymentValidationLogs.
+func (mr *MockQuerierMockRecorder) ListPaymentValidationLogs(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockQuerier)(nil).GetUser), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPaymentValidationLogs", reflect.TypeOf((*MockQuerier)(nil).ListPaymentValidationLogs), ctx, arg)
 }
 
-// GetUserAdminsReportData mocks base method.
-func (m *MockQuerier) GetUserAdminsReportData(ctx context.Context, arg generated.GetUserAdminsReportDataParams) ([]generated.GetUserAdminsReportDataRow, error) {
+// ListPenaltiesByLoan mocks base method.
+func (m *MockQuerier) ListPenaltiesByLoan(ctx context.Context, loanID uint32) ([]generated.InstallmentPenalty, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserAdminsReportData", ctx, arg)
-	ret0, _ := ret[0].([]generated.GetUserAdminsReportDataRow)
+	ret := m.ctrl.Call(m, "ListPenaltiesByLoan", ctx, loanID)
+	ret0, _ := ret[0].([]generated.InstallmentPenalty)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUserAdminsReportData indicates an expected call of GetUserAdminsReportData.
-func (mr *MockQuerierMockRecorder) GetUserAdminsReportData(ctx, arg any) *gomock.Call {
+// ListPenaltiesByLoan indicates an expected call of ListPenaltiesByLoan.
+func (mr *MockQuerierMockRecorder) ListPenaltiesByLoan(ctx, loanID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserAdminsReportData", reflect.TypeOf((*MockQuerier)(nil).GetUserAdminsReportData), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPenaltiesByLoan", reflect.TypeOf((*MockQuerier)(nil).ListPenaltiesByLoan), ctx, loanID)
 }
 
-// GetUserByEmail mocks base method.
-func (m *MockQuerier) GetUserByEmail(ctx context.Context, email string) (generated.GetUserByEmailRow, error) {
+// ListProducts mocks base method.
+func (m *MockQuerier) ListProducts(ctx context.Context, arg generated.ListProductsParams) ([]generated.Product, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserByEmail", ctx, email)
-	ret0, _ := ret[0].(generated.GetUserByEmailRow)
+	ret := m.ctrl.Call(m, "ListProducts", ctx, arg)
+	ret0, _ := ret[0].([]generated.Product)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUserByEmail indicates an expected call of GetUserByEmail.
-func (mr *MockQuerierMockRecorder) GetUserByEmail(ctx, email any) *gomock.Call {
+// ListProducts indicates an expected call of ListProducts.
+func (mr *MockQuerierMockRecorder) ListProducts(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmail", reflect.TypeOf((*MockQuerier)(nil).GetUserByEmail), ctx, email)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProducts", reflect.TypeOf((*MockQuerier)(nil).ListProducts), ctx, arg)
 }
 
-// GetUserUsersReportData mocks base method.
-func (m *MockQuerier) GetUserUsersReportData(ctx context.Context, arg generated.GetUserUsersReportDataParams) (generated.GetUserUsersReportDataRow, error) {
+// ListProductsByBranch mocks base method.
+func (m *MockQuerier) ListProductsByBranch(ctx context.Context, arg generated.ListProductsByBranchParams) ([]generated.Product, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserUsersReportData", ctx, arg)
-	ret0, _ := ret[0].(generated.GetUserUsersReportDataRow)
+	ret := m.ctrl.Call(m, "ListProductsByBranch", ctx, arg)
+	ret0, _ := ret[0].([]generated.Product)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUserUsersReportData indicates an expected call of GetUserUsersReportData.
-func (mr *MockQuerierMockRecorder) GetUserUsersReportData(ctx, arg any) *gomock.Call {
+// ListProductsByBranch indicates an expected call of ListProductsByBranch.
+func (mr *MockQuerierMockRecorder) ListProductsByBranch(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserUsersReportData", reflect.TypeOf((*MockQuerier)(nil).GetUserUsersReportData), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProductsByBranch", reflect.TypeOf((*MockQuerier)(nil).ListProductsByBranch), ctx, arg)
 }
 
-// HelperClient mocks base method.
-func (m *MockQuerier) HelperClient(ctx context.Context) ([]generated.HelperClientRow, error) {
+// ListProductsByCategory mocks base method.
+func (m *MockQuerier) ListProductsByCategory(ctx context.Context, arg generated.ListProductsByCategoryParams) ([]generated.ListProductsByCategoryRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "HelperClient", ctx)
-	ret0, _ := ret[0].([]generated.HelperClientRow)
+	ret := m.ctrl.Call(m, "ListProductsByCategory", ctx, arg)
+	ret0, _ := ret[0].([]generated.ListProductsByCategoryRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// HelperClient indicates an expected call of HelperClient.
-func (mr *MockQuerierMockRecorder) HelperClient(ctx any) *gomock.Call {
+// ListProductsByCategory indicates an expected call of ListProductsByCategory.
+func (mr *MockQuerierMockRecorder) ListProductsByCategory(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HelperClient", reflect.TypeOf((*MockQuerier)(nil).HelperClient), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProductsByCategory", reflect.TypeOf((*MockQuerier)(nil).ListProductsByCategory), ctx, arg)
 }
 
-// HelperProduct mocks base method.
-func (m *MockQuerier) HelperProduct(ctx context.Context) ([]generated.HelperProductRow, error) {
+// ListStatementReconciliationItems mocks base method.
+func (m *MockQuerier) ListStatementReconciliationItems(ctx context.Context, reconciliationID uint32) ([]generated.StatementReconciliationItem, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "HelperProduct", ctx)
-	ret0, _ := ret[0].([]generated.HelperProductRow)
+	ret := m.ctrl.Call(m, "ListStatementReconciliationItems", ctx, reconciliationID)
+	ret0, _ := ret[0].([]generated.StatementReconciliationItem)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// HelperProduct indicates an expected call of HelperProduct.
-func (mr *MockQuerierMockRecorder) HelperProduct(ctx any) *gomock.Call {
+// ListStatementReconciliationItems indicates an expected call of ListStatementReconciliationItems.
+func (mr *MockQuerierMockRecorder) ListStatementReconciliationItems(ctx, reconciliationID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HelperProduct", reflect.TypeOf((*MockQuerier)(nil).HelperProduct), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStatementReconciliationItems", reflect.TypeOf((*MockQuerier)(nil).ListStatementReconciliationItems), ctx, reconciliationID)
 }
 
-// HelperUser mocks base method.
-func (m *MockQuerier) HelperUser(ctx context.Context) ([]generated.HelperUserRow, error) {
+// ListStatementReconciliations mocks base method.
+func (m *MockQuerier) ListStatementReconciliations(ctx context.Context, arg generated.ListStatementReconciliationsParams) ([]generated.StatementReconciliation, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "HelperUser", ctx)
-	ret0, _ := ret[0].([]generated.HelperUserRow)
+	ret := m.ctrl.Call(m, "ListStatementReconciliations", ctx, arg)
+	ret0, _ := ret[0].([]generated.StatementReconciliation)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// HelperUser indicates an expected call of HelperUser.
-func (mr *MockQuerierMockRecorder) HelperUser(ctx any) *gomock.Call {
+// ListStatementReconciliations indicates an expected call of ListStatementReconciliations.
+func (mr *MockQuerierMockRecorder) ListStatementReconciliations(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HelperUser", reflect.TypeOf((*MockQuerier)(nil).HelperUser), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStatementReconciliations", reflect.TypeOf((*MockQuerier)(nil).ListStatementReconciliations), ctx, arg)
 }
 
-// HelperUserById mocks base method.
-func (m *MockQuerier) HelperUserById(ctx context.Context, id uint32) (string, error) {
+// ListStkPushRequestsByLoan mocks base method.
+func (m *MockQuerier) ListStkPushRequestsByLoan(ctx context.Context, loanID uint32) ([]generated.StkPushRequest, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "HelperUserById", ctx, id)
-	ret0, _ := ret[0].(string)
+	ret := m.ctrl.Call(m, "ListStkPushRequestsByLoan", ctx, loanID)
+	ret0, _ := ret[0].([]generated.StkPushRequest)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// HelperUserById indicates an expected call of HelperUserById.
-func (mr *MockQuerierMockRecorder) HelperUserById(ctx, id any) *gomock.Call {
+// ListStkPushRequestsByLoan indicates an expected call of ListStkPushRequestsByLoan.
+func (mr *MockQuerierMockRecorder) ListStkPushRequestsByLoan(ctx, loanID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HelperUserById", reflect.TypeOf((*MockQuerier)(nil).HelperUserById), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStkPushRequestsByLoan", reflect.TypeOf((*MockQuerier)(nil).ListStkPushRequestsByLoan), ctx, loanID)
 }
 
-// ListAllNonPosted mocks base method.
-func (m *MockQuerier) ListAllNonPosted(ctx context.Context, arg generated.ListAllNonPostedParams) ([]generated.NonPosted, error) {
+// ListUnassignedNonPosted mocks base method.
+func (m *MockQuerier) ListUnassignedNonPosted(ctx context.Context, arg generated.ListUnassignedNonPostedParams) ([]generated.NonPosted, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListAllNonPosted", ctx, arg)
+	ret := m.ctrl.Call(m, "ListUnassignedNonPosted", ctx, arg)
 	ret0, _ := ret[0].([]generated.NonPosted)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListAllNonPosted indicates an expected call of ListAllNonPosted.
-func (mr *MockQuerierMockRecorder) ListAllNonPosted(ctx, arg any) *gomock.Call {
+// ListUnassignedNonPosted indicates an expected call of ListUnassignedNonPosted.
+func (mr *MockQuerierMockRecorder) ListUnassignedNonPosted(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAllNonPosted", reflect.TypeOf((*MockQuerier)(nil).ListAllNonPosted), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUnassignedNonPosted", reflect.TypeOf((*MockQuerier)(nil).ListUnassignedNonPosted), ctx, arg)
 }
 
-// ListAllNonPostedByTransactionSource mocks base method.
-func (m *MockQuerier) ListAllNonPostedByTransactionSource(ctx context.Context, transactionSource generated.NonPostedTransactionSource) ([]generated.NonPosted, error) {
+// ListUnassignedNonPostedForRules mocks base method.
+func (m *MockQuerier) ListUnassignedNonPostedForRules(ctx context.Context, limit int32) ([]generated.NonPosted, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListAllNonPostedByTransactionSource", ctx, transactionSource)
+	ret := m.ctrl.Call(m, "ListUnassignedNonPostedForRules", ctx, limit)
 	ret0, _ := ret[0].([]generated.NonPosted)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListAllNonPostedByTransactionSource indicates an expected call of ListAllNonPostedByTransactionSource.
-func (mr *MockQuerierMockRecorder) ListAllNonPostedByTransactionSource(ctx, transactionSource any) *gomock.Call {
+// ListUnassignedNonPostedForRules indicates an expected call of ListUnassignedNonPostedForRules.
+func (mr *MockQuerierMockRecorder) ListUnassignedNonPostedForRules(ctx, limit any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAllNonPostedByTransactionSource", reflect.TypeOf((*MockQuerier)(nil).ListAllNonPostedByTransactionSource), ctx, transactionSource)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUnassignedNonPostedForRules", reflect.TypeOf((*MockQuerier)(nil).ListUnassignedNonPostedForRules), ctx, limit)
 }
 
-// ListBrachesByCategory mocks base method.
-func (m *MockQuerier) ListBrachesByCategory(ctx context.Context, arg generated.ListBrachesByCategoryParams) ([]generated.Branch, error) {
+// ListUnpaidInstallmentsByLoan mocks base method.
+func (m *MockQuerier) ListUnpaidInstallmentsByLoan(ctx context.Context, loanID uint32) ([]generated.Installment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListBrachesByCategory", ctx, arg)
-	ret0, _ := ret[0].([]generated.Branch)
+	ret := m.ctrl.Call(m, "ListUnpaidInstallmentsByLoan", ctx, loanID)
+	ret0, _ := ret[0].([]generated.Installment)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListBrachesByCategory indicates an expected call of ListBrachesByCategory.
-func (mr *MockQuerierMockRecorder) ListBrachesByCategory(ctx, arg any) *gomock.Call {
+// ListUnpaidInstallmentsByLoan indicates an expected call of ListUnpaidInstallmentsByLoan.
+func (mr *MockQuerierMockRecorder) ListUnpaidInstallmentsByLoan(ctx, loanID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBrachesByCategory", reflect.TypeOf((*MockQuerier)(nil).ListBrachesByCategory), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUnpaidInstallmentsByLoan", reflect.TypeOf((*MockQuerier)(nil).ListUnpaidInstallmentsByLoan), ctx, loanID)
 }
 
-// ListBranches mocks base method.
-func (m *MockQuerier) ListBranches(ctx context.Context) ([]generated.Branch, error) {
+// ListUnpaidPenaltiesByLoan mocks base method.
+func (m *MockQuerier) ListUnpaidPenaltiesByLoan(ctx context.Context, loanID uint32) ([]generated.InstallmentPenalty, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListBranches", ctx)
-	ret0, _ := ret[0].([]generated.Branch)
+	ret := m.ctrl.Call(m, "ListUnpaidPenaltiesByLoan", ctx, loanID)
+	ret0, _ := ret[0].([]generated.InstallmentPenalty)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListBranches indicates an expected call of ListBranches.
-func (mr *MockQuerierMockRecorder) ListBranches(ctx any) *gomock.Call {
+// ListUnpaidPenaltiesByLoan indicates an expected call of ListUnpaidPenaltiesByLoan.
+func (mr *MockQuerierMockRecorder) ListUnpaidPenaltiesByLoan(ctx, loanID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBranches", reflect.TypeOf((*MockQuerier)(nil).ListBranches), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUnpaidPenaltiesByLoan", reflect.TypeOf((*MockQuerier)(nil).ListUnpaidPenaltiesByLoan), ctx, loanID)
 }
 
-// ListClients mocks base method.
-func (m *MockQuerier) ListClients(ctx context.Context, arg generated.ListClientsParams) ([]generated.Client, error) {
+// ListUsers mocks base method.
+func (m *MockQuerier) ListUsers(ctx context.Context, arg generated.ListUsersParams) ([]generated.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListClients", ctx, arg)
-	ret0, _ := ret[0].([]generated.Client)
+	ret := m.ctrl.Call(m, "ListUsers", ctx, arg)
+	ret0, _ := ret[0].([]generated.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListClients indicates an expected call of ListClients.
-func (mr *MockQuerierMockRecorder) ListClients(ctx, arg any) *gomock.Call {
+// ListUsers indicates an expected call of ListUsers.
+func (mr *MockQuerierMockRecorder) ListUsers(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListClients", reflect.TypeOf((*MockQuerier)(nil).ListClients), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockQuerier)(nil).ListUsers), ctx, arg)
 }
 
-// ListClientsByActiveStatus mocks base method.
-func (m *MockQuerier) ListClientsByActiveStatus(ctx context.Context, arg generated.ListClientsByActiveStatusParams) ([]generated.Client, error) {
+// ListUsersByCategory mocks base method.
+func (m *MockQuerier) ListUsersByCategory(ctx context.Context, arg generated.ListUsersByCategoryParams) ([]generated.ListUsersByCategoryRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListClientsByActiveStatus", ctx, arg)
-	ret0, _ := ret[0].([]generated.Client)
+	ret := m.ctrl.Call(m, "ListUsersByCategory", ctx, arg)
+	ret0, _ := ret[0].([]generated.ListUsersByCategoryRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListClientsByActiveStatus indicates an expected call of ListClientsByActiveStatus.
-func (mr *MockQuerierMockRecorder) ListClientsByActiveStatus(ctx, arg any) *gomock.Call {
+// ListUsersByCategory indicates an expected call of ListUsersByCategory.
+func (mr *MockQuerierMockRecorder) ListUsersByCategory(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListClientsByActiveStatus", reflect.TypeOf((*MockQuerier)(nil).ListClientsByActiveStatus), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsersByCategory", reflect.TypeOf((*MockQuerier)(nil).ListUsersByCategory), ctx, arg)
 }
 
-// ListClientsByBranch mocks base method.
-func (m *MockQuerier) ListClientsByBranch(ctx context.Context, arg generated.ListClientsByBranchParams) ([]generated.Client, error) {
+// LockClient mocks base method.
+func (m *MockQuerier) LockClient(ctx context.Context, id uint32) (uint32, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListClientsByBranch", ctx, arg)
-	ret0, _ := ret[0].([]generated.Client)
+	ret := m.ctrl.Call(m, "LockClient", ctx, id)
+	ret0, _ := ret[0].(uint32)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListClientsByBranch indicates an expected call of ListClientsByBranch.
-func (mr *MockQuerierMockRecorder) ListClientsByBranch(ctx, arg any) *gomock.Call {
+// LockClient indicates an expected call of LockClient.
+func (mr *MockQuerierMockRecorder) LockClient(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListClientsByBranch", reflect.TypeOf((*MockQuerier)(nil).ListClientsByBranch), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockClient", reflect.TypeOf((*MockQuerier)(nil).LockClient), ctx, id)
 }
 
-// ListClientsByCategory mocks base method.
-func (m *MockQuerier) ListClientsByCategory(ctx context.Context, arg generated.ListClientsByCategoryParams) ([]generated.ListClientsByCategoryRow, error) {
+// LockLoan mocks base method.
+func (m *MockQuerier) LockLoan(ctx context.Context, id uint32) (uint32, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListClientsByCategory", ctx, arg)
-	ret0, _ := ret[0].([]generated.ListClientsByCategoryRow)
+	ret := m.ctrl.Call(m, "LockLoan", ctx, id)
+	ret0, _ := ret[0].(uint32)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListClientsByCategory indicates an expected call of ListClientsByCategory.
-func (mr *MockQuerierMockRecorder) ListClientsByCategory(ctx, arg any) *gomock.Call {
+// LockLoan indicates an expected call of LockLoan.
+func (mr *MockQuerierMockRecorder) LockLoan(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListClientsByCategory", reflect.TypeOf((*MockQuerier)(nil).ListClientsByCategory), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockLoan", reflect.TypeOf((*MockQuerier)(nil).LockLoan), ctx, id)
 }
 
-// ListExpectedPayments mocks base method.
-func (m *MockQuerier) ListExpectedPayments(ctx context.Context, arg generated.ListExpectedPaymentsParams) ([]generated.ListExpectedPaymentsRow, error) {
+// LockLoanDisbursementByConversationID mocks base method.
+func (m *MockQuerier) LockLoanDisbursementByConversationID(ctx context.Context, conversationID string) (generated.LoanDisbursement, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListExpectedPayments", ctx, arg)
-	ret0, _ := ret[0].([]generated.ListExpectedPaymentsRow)
+	ret := m.ctrl.Call(m, "LockLoanDisbursementByConversationID", ctx, conversationID)
+	ret0, _ := ret[0].(generated.LoanDisbursement)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListExpectedPayments indicates an expected call of ListExpectedPayments.
-func (mr *MockQuerierMockRecorder) ListExpectedPayments(ctx, arg any) *gomock.Call {
+// LockLoanDisbursementByConversationID indicates an expected call of LockLoanDisbursementByConversationID.
+func (mr *MockQuerierMockRecorder) LockLoanDisbursementByConversationID(ctx, conversationID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpectedPayments", reflect.TypeOf((*MockQuerier)(nil).ListExpectedPayments), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockLoanDisbursementByConversationID", reflect.TypeOf((*MockQuerier)(nil).LockLoanDisbursementByConversationID), ctx, conversationID)
 }
 
-// ListInstallmentsByLoan mocks base method.
-func (m *MockQuerier) ListInstallmentsByLoan(ctx context.Context, loanID uint32) ([]generated.Installment, error) {
+// LockLoanInstallments mocks base method.
+func (m *MockQuerier) LockLoanInstallments(ctx context.Context, loanID uint32) ([]uint32, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListInstallmentsByLoan", ctx, loanID)
-	ret0, _ := ret[0].([]generated.Installment)
+	ret := m.ctrl.Call(m, "LockLoanInstallments", ctx, loanID)
+	ret0, _ := ret[0].([]uint32)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListInstallmentsByLoan indicates an expected call of ListInstallmentsByLoan.
-func (mr *MockQuerierMockRecorder) ListInstallmentsByLoan(ctx, loanID any) *gomock.Call {
+// LockLoanInstallments indicates an expected call of LockLoanInstallments.
+func (mr *MockQuerierMockRecorder) LockLoanInstallments(ctx, loanID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInstallmentsByLoan", reflect.TypeOf((*MockQuerier)(nil).ListInstallmentsByLoan), ctx, loanID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockLoanInstallments", reflect.TypeOf((*MockQuerier)(nil).LockLoanInstallments), ctx, loanID)
 }
 
-// ListLoans mocks base method.
-func (m *MockQuerier) ListLoans(ctx context.Context, arg generated.ListLoansParams) ([]generated.ListLoansRow, error) {
+// LockNonPosted mocks base method.
+func (m *MockQuerier) LockNonPosted(ctx context.Context, id uint32) (generated.NonPosted, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListLoans", ctx, arg)
-	ret0, _ := ret[0].([]generated.ListLoansRow)
+	ret := m.ctrl.Call(m, "LockNonPosted", ctx, id)
+	ret0, _ := ret[0].(generated.NonPosted)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListLoans indicates an expected call of ListLoans.
-func (mr *MockQuerierMockRecorder) ListLoans(ctx, arg any) *gomock.Call {
+// LockNonPosted indicates an expected call of LockNonPosted.
+func (mr *MockQuerierMockRecorder) LockNonPosted(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLoans", reflect.TypeOf((*MockQuerier)(nil).ListLoans), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockNonPosted", reflect.TypeOf((*MockQuerier)(nil).LockNonPosted), ctx, id)
 }
-
-// ListLoansByClient mocks base method.
-func (m *MockQuerier) ListLoansByClient(ctx context.Context, arg generated.ListLoansByClientParams) ([]generated.Loan, error) {
+
+// LockOverpaymentRefund mocks base method.
+func (m *MockQuerier) LockOverpaymentRefund(ctx context.Context, id uint32) (generated.OverpaymentRefund, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListLoansByClient", ctx, arg)
-	ret0, _ := ret[0].([]generated.Loan)
+	ret := m.ctrl.Call(m, "LockOverpaymentRefund", ctx, id)
+	ret0, _ := ret[0].(generated.OverpaymentRefund)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListLoansByClient indicates an expected call of ListLoansByClient.
-func (mr *MockQuerierMockRecorder) ListLoansByClient(ctx, arg any) *gomock.Call {
+// LockOverpaymentRefund indicates an expected call of LockOverpaymentRefund.
+func (mr *MockQuerierMockRecorder) LockOverpaymentRefund(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLoansByClient", reflect.TypeOf((*MockQuerier)(nil).ListLoansByClient), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockOverpaymentRefund", reflect.TypeOf((*MockQuerier)(nil).LockOverpaymentRefund), ctx, id)
 }
 
-// ListLoansByLoanOfficer mocks base method.
-func (m *MockQuerier) ListLoansByLoanOfficer(ctx context.Context, arg generated.ListLoansByLoanOfficerParams) ([]generated.Loan, error) {
+// LockOverpaymentRefundByConversationID mocks base method.
+func (m *MockQuerier) LockOverpaymentRefundByConversationID(ctx context.Context, conversationID sql.NullString) (generated.OverpaymentRefund, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListLoansByLoanOfficer", ctx, arg)
-	ret0, _ := ret[0].([]generated.Loan)
+	ret := m.ctrl.Call(m, "LockOverpaymentRefundByConversationID", ctx, conversationID)
+	ret0, _ := ret[0].(generated.OverpaymentRefund)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListLoansByLoanOfficer indicates an expected call of ListLoansByLoanOfficer.
-func (mr *MockQuerierMockRecorder) ListLoansByLoanOfficer(ctx, arg any) *gomock.Call {
+// LockOverpaymentRefundByConversationID indicates an expected call of LockOverpaymentRefundByConversationID.
+func (mr *MockQuerierMockRecorder) LockOverpaymentRefundByConversationID(ctx, conversationID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLoansByLoanOfficer", reflect.TypeOf((*MockQuerier)(nil).ListLoansByLoanOfficer), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockOverpaymentRefundByConversationID", reflect.TypeOf((*MockQuerier)(nil).LockOverpaymentRefundByConversationID), ctx, conversationID)
 }
 
-// ListLoansByStatus mocks base method.
-func (m *MockQuerier) ListLoansByStatus(ctx context.Context, arg generated.ListLoansByStatusParams) ([]generated.Loan, error) {
+// MarkLoanDefaulted mocks base method.
+func (m *MockQuerier) MarkLoanDefaulted(ctx context.Context, id uint32) (sql.Result, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListLoansByStatus", ctx, arg)
-	ret0, _ := ret[0].([]generated.Loan)
+	ret := m.ctrl.Call(m, "MarkLoanDefaulted", ctx, id)
+	ret0, _ := ret[0].(sql.Result)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListLoansByStatus indicates an expected call of ListLoansByStatus.
-func (mr *MockQuerierMockRecorder) ListLoansByStatus(ctx, arg any) *gomock.Call {
+// MarkLoanDefaulted indicates an expected call of MarkLoanDefaulted.
+func (mr *MockQuerierMockRecorder) MarkLoanDefaulted(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLoansByStatus", reflect.TypeOf((*MockQuerier)(nil).ListLoansByStatus), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkLoanDefaulted", reflect.TypeOf((*MockQuerier)(nil).MarkLoanDefaulted), ctx, id)
 }
 
-// ListNonDisbursedLoans mocks base method.
-func (m *MockQuerier) ListNonDisbursedLoans(ctx context.Context, arg generated.ListNonDisbursedLoansParams) ([]generated.Loan, error) {
+// MarkPaymentImportBatchFailed mocks base method.
+func (m *MockQuerier) MarkPaymentImportBatchFailed(ctx context.Context, id uint32) (sql.Result, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListNonDisbursedLoans", ctx, arg)
-	ret0, _ := ret[0].([]generated.Loan)
+	ret := m.ctrl.Call(m, "MarkPaymentImportBatchFailed", ctx, id)
+	ret0, _ := ret[0].(sql.Result)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListNonDisbursedLoans indicates an expected call of ListNonDisbursedLoans.
-func (mr *MockQuerierMockRecorder) ListNonDisbursedLoans(ctx, arg any) *gomock.Call {
+// MarkPaymentImportBatchFailed indicates an expected call of MarkPaymentImportBatchFailed.
+func (mr *MockQuerierMockRecorder) MarkPaymentImportBatchFailed(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNonDisbursedLoans", reflect.TypeOf((*MockQuerier)(nil).ListNonDisbursedLoans), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkPaymentImportBatchFailed", reflect.TypeOf((*MockQuerier)(nil).MarkPaymentImportBatchFailed), ctx, id)
 }
 
-// ListNonPostedByCategory mocks base method.
-func (m *MockQuerier) ListNonPostedByCategory(ctx context.Context, arg generated.ListNonPostedByCategoryParams) ([]generated.ListNonPostedByCategoryRow, error) {
+// MarkPaymentImportBatchPosted mocks base method.
+func (m *MockQuerier) MarkPaymentImportBatchPosted(ctx context.Context, arg generated.MarkPaymentImportBatchPostedParams) (sql.Result, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListNonPostedByCategory", ctx, arg)
-	ret0, _ := ret[0].([]generated.ListNonPostedByCategoryRow)
+	ret := m.ctrl.Call(m, "MarkPaymentImportBatchPosted", ctx, arg)
+	ret0, _ := ret[0].(sql.Result)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListNonPostedByCategory indicates an expected call of ListNonPostedByCategory.
-func (mr *MockQuerierMockRecorder) ListNonPostedByCategory(ctx, arg any) *gomock.Call {
+// MarkPaymentImportBatchPosted indicates an expected call of MarkPaymentImportBatchPosted.
+func (mr *MockQuerierMockRecorder) MarkPaymentImportBatchPosted(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNonPostedByCategory", reflect.TypeOf((*MockQuerier)(nil).ListNonPostedByCategory), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkPaymentImportBatchPosted", reflect.TypeOf((*MockQuerier)(nil).MarkPaymentImportBatchPosted), ctx, arg)
 }
 
-// ListNonPostedByTransactionSource mocks base method.
-func (m *MockQuerier) ListNonPostedByTransactionSource(ctx context.Context, arg generated.ListNonPostedByTransactionSourceParams) ([]generated.NonPosted, error) {
+// MarkPaymentImportRowRolledBack mocks base method.
+func (m *MockQuerier) MarkPaymentImportRowRolledBack(ctx context.Context, id uint32) (sql.Result, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListNonPostedByTransactionSource", ctx, arg)
-	ret0, _ := ret[0].([]generated.NonPosted)
+	ret := m.ctrl.Call(m, "MarkPaymentImportRowRolledBack", ctx, id)
+	ret0, _ := ret[0].(sql.Result)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListNonPostedByTransactionSource indicates an expected call of ListNonPostedByTransactionSource.
-func (mr *MockQuerierMockRecorder) ListNonPostedByTransactionSource(ctx, arg any) *gomock.Call {
+// MarkPaymentImportRowRolledBack indicates an expected call of MarkPaymentImportRowRolledBack.
+func (mr *MockQuerierMockRecorder) MarkPaymentImportRowRolledBack(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNonPostedByTransactionSource", reflect.TypeOf((*MockQuerier)(nil).ListNonPostedByTransactionSource), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkPaymentImportRowRolledBack", reflect.TypeOf((*MockQuerier)(nil).MarkPaymentImportRowRolledBack), ctx, id)
 }
 
-// ListPaymentAllocationsByLoanId mocks base method.
-func (m *MockQuerier) ListPaymentAllocationsByLoanId(ctx context.Context, loanID sql.NullInt32) ([]generated.PaymentAllocation, error) {
+// MarkStatementReconciliationItemImported mocks base method.
+func (m *MockQuerier) MarkStatementReconciliationItemImported(ctx context.Context, arg generated.MarkStatementReconciliationItemImportedParams) (sql.Result, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListPaymentAllocationsByLoanId", ctx, loanID)
-	ret0, _ := ret[0].([]generated.PaymentAllocation)
+	ret := m.ctrl.Call(m, "MarkStatementReconciliationItemImported", ctx, arg)
+	ret0, _ := ret[0].(sql.Result)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListPaymentAllocationsByLoanId indicates an expected call of ListPaymentAllocationsByLoanId.
-func (mr *MockQuerierMockRecorder) ListPaymentAllocationsByLoanId(ctx, loanID any) *gomock.Call {
+// MarkStatementReconciliationItemImported indicates an expected call of MarkStatementReconciliationItemImported.
+func (mr *MockQuerierMockRecorder) MarkStatementReconciliationItemImported(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPaymentAllocationsByLoanId", reflect.TypeOf((*MockQuerier)(nil).ListPaymentAllocationsByLoanId), ctx, loanID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkStatementReconciliationItemImported", reflect.TypeOf((*MockQuerier)(nil).MarkStatementReconciliationItemImported), ctx, arg)
 }
 
-// ListPaymentAllocationsByNonPostedId mocks base method.
-func (m *MockQuerier) ListPaymentAllocationsByNonPostedId(ctx context.Context, nonPostedID uint32) ([]generated.PaymentAllocation, error) {
+// NullifyClientOverpayment mocks base method.
+func (m *MockQuerier) NullifyClientOverpayment(ctx context.Context, id uint32) (sql.Result, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListPaymentAllocationsByNonPostedId", ctx, nonPostedID)
-	ret0, _ := ret[0].([]generated.PaymentAllocation)
+	ret := m.ctrl.Call(m, "NullifyClientOverpayment", ctx, id)
+	ret0, _ := ret[0].(sql.Result)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListPaymentAllocationsByNonPostedId indicates an expected call of ListPaymentAllocationsByNonPostedId.
-func (mr *MockQuerierMockRecorder) ListPaymentAllocationsByNonPostedId(ctx, nonPostedID any) *gomock.Call {
+// NullifyClientOverpayment indicates an expected call of NullifyClientOverpayment.
+func (mr *MockQuerierMockRecorder) NullifyClientOverpayment(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPaymentAllocationsByNonPostedId", reflect.TypeOf((*MockQuerier)(nil).ListPaymentAllocationsByNonPostedId), ctx, nonPostedID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NullifyClientOverpayment", reflect.TypeOf((*MockQuerier)(nil).NullifyClientOverpayment), ctx, id)
 }
 
-// ListProducts mocks base method.
-func (m *MockQuerier) ListProducts(ctx context.Context, arg generated.ListProductsParams) ([]generated.Product, error) {
+// PayInstallment mocks base method.
+func (m *MockQuerier) PayInstallment(ctx context.Context, arg generated.PayInstallmentParams) (sql.Result, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListProducts", ctx, arg)
-	ret0, _ := ret[0].([]generated.Product)
+	ret := m.ctrl.Call(m, "PayInstallment", ctx, arg)
+	ret0, _ := ret[0].(sql.Result)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListProducts indicates an expected call of ListProducts.
-func (mr *MockQuerierMockRecorder) ListProducts(ctx, arg any) *gomock.Call {
+// PayInstallment indicates an expected call of PayInstallment.
+func (mr *MockQuerierMockRecorder) PayInstallment(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProducts", reflect.TypeOf((*MockQuerier)(nil).ListProducts), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PayInstallment", reflect.TypeOf((*MockQuerier)(nil).PayInstallment), ctx, arg)
 }
 
-// ListProductsByBranch mocks base method.
-func (m *MockQuerier) ListProductsByBranch(ctx context.Context, arg generated.ListProductsByBranchParams) ([]generated.Product, error) {
+// PayInstallmentPenalty mocks base method.
+func (m *MockQuerier) PayInstallmentPenalty(ctx context.Context, arg generated.PayInstallmentPenaltyParams) (sql.Result, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListProductsByBranch", ctx, arg)
-	ret0, _ := ret[0].([]generated.Product)
+	ret := m.ctrl.Call(m, "PayInstallmentPenalty", ctx, arg)
+	ret0, _ := ret[0].(sql.Result)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListProductsByBranch indicates an expected call of ListProductsByBranch.
-func (mr *MockQuerierMockRecorder) ListProductsByBranch(ctx, arg any) *gomock.Call {
+// PayInstallmentPenalty indicates an expected call of PayInstallmentPenalty.
+func (mr *MockQuerierMockRecorder) PayInstallmentPenalty(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProductsByBranch", reflect.TypeOf((*MockQuerier)(nil).ListProductsByBranch), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PayInstallmentPenalty", reflect.TypeOf((*MockQuerier)(nil).PayInstallmentPenalty), ctx, arg)
 }
 
-// ListProductsByCategory mocks base method.
-func (m *MockQuerier) ListProductsByCategory(ctx context.Context, arg generated.ListProductsByCategoryParams) ([]generated.ListProductsByCategoryRow, error) {
+// ReduceLoan mocks base method.
+func (m *MockQuerier) ReduceLoan(ctx context.Context, arg generated.ReduceLoanParams) (sql.Result, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListProductsByCategory", ctx, arg)
-	ret0, _ := ret[0].([]generated.ListProductsByCategoryRow)
+	ret := m.ctrl.Call(m, "ReduceLoan", ctx, arg)
+	ret0, _ := ret[0].(sql.Result)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListProductsByCategory indicates an expected call of ListProductsByCategory.
-func (mr *MockQuerierMockRecorder) ListProductsByCategory(ctx, arg any) *gomock.Call {
+// ReduceLoan indicates an expected call of ReduceLoan.
+func (mr *MockQuerierMockRecorder) ReduceLoan(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProductsByCategory", reflect.TypeOf((*MockQuerier)(nil).ListProductsByCategory), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReduceLoan", reflect.TypeOf((*MockQuerier)(nil).ReduceLoan), ctx, arg)
 }
 
-// ListUnassignedNonPosted mocks base method.
-func (m *MockQuerier) ListUnassignedNonPosted(ctx context.Context, arg generated.ListUnassignedNonPostedParams) ([]generated.NonPosted, error) {
+// ReversePaymentSplit mocks base method.
+func (m *MockQuerier) ReversePaymentSplit(ctx context.Context, arg generated.ReversePaymentSplitParams) (sql.Result, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListUnassignedNonPosted", ctx, arg)
-	ret0, _ := ret[0].([]generated.NonPosted)
+	ret := m.ctrl.Call(m, "ReversePaymentSplit", ctx, arg)
+	ret0, _ := ret[0].(sql.Result)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListUnassignedNonPosted indicates an expected call of ListUnassignedNonPosted.
-func (mr *MockQuerierMockRecorder) ListUnassignedNonPosted(ctx, arg any) *gomock.Call {
+// ReversePaymentSplit indicates an expected call of ReversePaymentSplit.
+func (mr *MockQuerierMockRecorder) ReversePaymentSplit(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUnassignedNonPosted", reflect.TypeOf((*MockQuerier)(nil).ListUnassignedNonPosted), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReversePaymentSplit", reflect.TypeOf((*MockQuerier)(nil).ReversePaymentSplit), ctx, arg)
 }
 
-// ListUnpaidInstallmentsByLoan mocks base method.
-func (m *MockQuerier) ListUnpaidInstallmentsByLoan(ctx context.Context, loanID uint32) ([]generated.Installment, error) {
+// RevertInstallment mocks base method.
+func (m *MockQuerier) RevertInstallment(ctx context.Context, arg generated.RevertInstallmentParams) (sql.Result, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListUnpaidInstallmentsByLoan", ctx, loanID)
-	ret0, _ := ret[0].([]generated.Installment)
+	ret := m.ctrl.Call(m, "RevertInstallment", ctx, arg)
+	ret0, _ := ret[0].(sql.Result)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListUnpaidInstallmentsByLoan indicates an expected call of ListUnpaidInstallmentsByLoan.
-func (mr *MockQuerierMockRecorder) ListUnpaidInstallmentsByLoan(ctx, loanID any) *gomock.Call {
+// RevertInstallment indicates an expected call of RevertInstallment.
+func (mr *MockQuerierMockRecorder) RevertInstallment(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUnpaidInstallmentsByLoan", reflect.TypeOf((*MockQuerier)(nil).ListUnpaidInstallmentsByLoan), ctx, loanID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevertInstallment", reflect.TypeOf((*MockQuerier)(nil).RevertInstallment), ctx, arg)
 }
 
-// ListUsers mocks base method.
-func (m *MockQuerier) ListUsers(ctx context.Context, arg generated.ListUsersParams) ([]generated.User, error) {
+// RevertInstallmentPenalty mocks base method.
+func (m *MockQuerier) RevertInstallmentPenalty(ctx context.Context, arg generated.RevertInstallmentPenaltyParams) (sql.Result, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListUsers", ctx, arg)
-	ret0, _ := ret[0].([]generated.User)
+	ret := m.ctrl.Call(m, "RevertInstallmentPenalty", ctx, arg)
+	ret0, _ := ret[0].(sql.Result)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListUsers indicates an expected call of ListUsers.
-func (mr *MockQuerierMockRecorder) ListUsers(ctx, arg any) *gomock.Call {
+// RevertInstallmentPenalty indicates an expected call of RevertInstallmentPenalty.
+func (mr *MockQuerierMockRecorder) RevertInstallmentPenalty(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockQuerier)(nil).ListUsers), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevertInstallmentPenalty", reflect.TypeOf((*MockQuerier)(nil).RevertInstallmentPenalty), ctx, arg)
 }
 
-// ListUsersByCategory mocks base method.
-func (m *MockQuerier) ListUsersByCategory(ctx context.Context, arg generated.ListUsersByCategoryParams) ([]generated.ListUsersByCategoryRow, error) {
+// ReviewOverpaymentRefund mocks base method.
+func (m *MockQuerier) ReviewOverpaymentRefund(ctx context.Context, arg generated.ReviewOverpaymentRefundParams) (sql.Result, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListUsersByCategory", ctx, arg)
-	ret0, _ := ret[0].([]generated.ListUsersByCategoryRow)
+	ret := m.ctrl.Call(m, "ReviewOverpaymentRefund", ctx, arg)
+	ret0, _ := ret[0].(sql.Result)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListUsersByCategory indicates an expected call of ListUsersByCategory.
-func (mr *MockQuerierMockRecorder) ListUsersByCategory(ctx, arg any) *gomock.Call {
+// ReviewOverpaymentRefund indicates an expected call of ReviewOverpaymentRefund.
+func (mr *MockQuerierMockRecorder) ReviewOverpaymentRefund(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsersByCategory", reflect.TypeOf((*MockQuerier)(nil).ListUsersByCategory), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReviewOverpaymentRefund", reflect.TypeOf((*MockQuerier)(nil).ReviewOverpaymentRefund), ctx, arg)
 }
 
-// NullifyClientOverpayment mocks base method.
-func (m *MockQuerier) NullifyClientOverpayment(ctx context.Context, id uint32) (sql.Result, error) {
+// RollbackPaymentImportBatch mocks base method.
+func (m *MockQuerier) RollbackPaymentImportBatch(ctx context.Context, arg generated.RollbackPaymentImportBatchParams) (sql.Result, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "NullifyClientOverpayment", ctx, id)
+	ret := m.ctrl.Call(m, "RollbackPaymentImportBatch", ctx, arg)
 	ret0, _ := ret[0].(sql.Result)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// NullifyClientOverpayment indicates an expected call of NullifyClientOverpayment.
-func (mr *MockQuerierMockRecorder) NullifyClientOverpayment(ctx, id any) *gomock.Call {
+// RollbackPaymentImportBatch indicates an expected call of RollbackPaymentImportBatch.
+func (mr *MockQuerierMockRecorder) RollbackPaymentImportBatch(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NullifyClientOverpayment", reflect.TypeOf((*MockQuerier)(nil).NullifyClientOverpayment), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollbackPaymentImportBatch", reflect.TypeOf((*MockQuerier)(nil).RollbackPaymentImportBatch), ctx, arg)
 }
 
-// PayInstallment mocks base method.
-func (m *MockQuerier) PayInstallment(ctx context.Context, arg generated.PayInstallmentParams) (sql.Result, error) {
+// SettleLoanSettlementQuote mocks base method.
+func (m *MockQuerier) SettleLoanSettlementQuote(ctx context.Context, arg generated.SettleLoanSettlementQuoteParams) (sql.Result, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "PayInstallment", ctx, arg)
+	ret := m.ctrl.Call(m, "SettleLoanSettlementQuote", ctx, arg)
 	ret0, _ := ret[0].(sql.Result)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// PayInstallment indicates an expected call of PayInstallment.
-func (mr *MockQuerierMockRecorder) PayInstallment(ctx, arg any) *gomock.Call {
+// SettleLoanSettlementQuote indicates an expected call of SettleLoanSettlementQuote.
+func (mr *MockQuerierMockRecorder) SettleLoanSettlementQuote(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PayInstallment", reflect.TypeOf((*MockQuerier)(nil).PayInstallment), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SettleLoanSettlementQuote", reflect.TypeOf((*MockQuerier)(nil).SettleLoanSettlementQuote), ctx, arg)
+}
+
+// SoftDeleteNonPosted mocks base method.
+func (m *MockQuerier) SoftDeleteNonPosted(ctx context.Context, arg generated.SoftDeleteNonPostedParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SoftDeleteNonPosted", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SoftDeleteNonPosted indicates an expected call of SoftDeleteNonPosted.
+func (mr *MockQuerierMockRecorder) SoftDeleteNonPosted(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SoftDeleteNonPosted", reflect.TypeOf((*MockQuerier)(nil).SoftDeleteNonPosted), ctx, arg)
 }
 
 // TransferLoan mocks base method.
@@ -1523,6 +3424,21 @@ func (mr *MockQuerierMockRecorder) TransferLoan(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferLoan", reflect.TypeOf((*MockQuerier)(nil).TransferLoan), ctx, arg)
 }
 
+// UpdateAssignmentRule mocks base method.
+func (m *MockQuerier) UpdateAssignmentRule(ctx context.Context, arg generated.UpdateAssignmentRuleParams) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAssignmentRule", ctx, arg)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAssignmentRule indicates an expected call of UpdateAssignmentRule.
+func (mr *MockQuerierMockRecorder) UpdateAssignmentRule(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAssignmentRule", reflect.TypeOf((*MockQuerier)(nil).UpdateAssignmentRule), ctx, arg)
+}
+
 // UpdateBranch mocks base method.
 func (m *MockQuerier) UpdateBranch(ctx context.Context, arg generated.UpdateBranchParams) (sql.Result, error) {
 	m.ctrl.T.Helper()
@@ -1568,21 +3484,6 @@ func (mr *MockQuerierMockRecorder) UpdateClientOverpayment(ctx, arg any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateClientOverpayment", reflect.TypeOf((*MockQuerier)(nil).UpdateClientOverpayment), ctx, arg)
 }
 
-// UpdateClientOverpaymentGeneral mocks base method.
-func (m *MockQuerier) UpdateClientOverpaymentGeneral(ctx context.Context, arg generated.UpdateClientOverpaymentGeneralParams) (sql.Result, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateClientOverpaymentGeneral", ctx, arg)
-	ret0, _ := ret[0].(sql.Result)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// UpdateClientOverpaymentGeneral indicates an expected call of UpdateClientOverpaymentGeneral.
-func (mr *MockQuerierMockRecorder) UpdateClientOverpaymentGeneral(ctx, arg any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateClientOverpaymentGeneral", reflect.TypeOf((*MockQuerier)(nil).UpdateClientOverpaymentGeneral), ctx, arg)
-}
-
 // UpdateInstallment mocks base method.
 func (m *MockQuerier) UpdateInstallment(ctx context.Context, arg generated.UpdateInstallmentParams) (sql.Result, error) {
 	m.ctrl.T.Helper()
@@ -1613,6 +3514,21 @@ func (mr *MockQuerierMockRecorder) UpdateLoan(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLoan", reflect.TypeOf((*MockQuerier)(nil).UpdateLoan), ctx, arg)
 }
 
+// UpdateLoanDisbursementResult mocks base method.
+func (m *MockQuerier) UpdateLoanDisbursementResult(ctx context.Context, arg generated.UpdateLoanDisbursementResultParams) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLoanDisbursementResult", ctx, arg)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateLoanDisbursementResult indicates an expected call of UpdateLoanDisbursementResult.
+func (mr *MockQuerierMockRecorder) UpdateLoanDisbursementResult(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLoanDisbursementResult", reflect.TypeOf((*MockQuerier)(nil).UpdateLoanDisbursementResult), ctx, arg)
+}
+
 // UpdateLoanProcessingFeeStatus mocks base method.
 func (m *MockQuerier) UpdateLoanProcessingFeeStatus(ctx context.Context, arg generated.UpdateLoanProcessingFeeStatusParams) (sql.Result, error) {
 	m.ctrl.T.Helper()
@@ -1628,6 +3544,21 @@ func (mr *MockQuerierMockRecorder) UpdateLoanProcessingFeeStatus(ctx, arg any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLoanProcessingFeeStatus", reflect.TypeOf((*MockQuerier)(nil).UpdateLoanProcessingFeeStatus), ctx, arg)
 }
 
+// UpdateLoanSchedule mocks base method.
+func (m *MockQuerier) UpdateLoanSchedule(ctx context.Context, arg generated.UpdateLoanScheduleParams) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLoanSchedule", ctx, arg)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateLoanSchedule indicates an expected call of UpdateLoanSchedule.
+func (mr *MockQuerierMockRecorder) UpdateLoanSchedule(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLoanSchedule", reflect.TypeOf((*MockQuerier)(nil).UpdateLoanSchedule), ctx, arg)
+}
+
 // UpdateLoanStatus mocks base method.
 func (m *MockQuerier) UpdateLoanStatus(ctx context.Context, arg generated.UpdateLoanStatusParams) (sql.Result, error) {
 	m.ctrl.T.Helper()
@@ -1643,6 +3574,81 @@ func (mr *MockQuerierMockRecorder) UpdateLoanStatus(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLoanStatus", reflect.TypeOf((*MockQuerier)(nil).UpdateLoanStatus), ctx, arg)
 }
 
+// UpdateNonPosted mocks base method.
+func (m *MockQuerier) UpdateNonPosted(ctx context.Context, arg generated.UpdateNonPostedParams) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNonPosted", ctx, arg)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateNonPosted indicates an expected call of UpdateNonPosted.
+func (mr *MockQuerierMockRecorder) UpdateNonPosted(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNonPosted", reflect.TypeOf((*MockQuerier)(nil).UpdateNonPosted), ctx, arg)
+}
+
+// UpdateOverpaymentRefundPayout mocks base method.
+func (m *MockQuerier) UpdateOverpaymentRefundPayout(ctx context.Context, arg generated.UpdateOverpaymentRefundPayoutParams) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOverpaymentRefundPayout", ctx, arg)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateOverpaymentRefundPayout indicates an expected call of UpdateOverpaymentRefundPayout.
+func (mr *MockQuerierMockRecorder) UpdateOverpaymentRefundPayout(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOverpaymentRefundPayout", reflect.TypeOf((*MockQuerier)(nil).UpdateOverpaymentRefundPayout), ctx, arg)
+}
+
+// UpdatePaymentImportRowResult mocks base method.
+func (m *MockQuerier) UpdatePaymentImportRowResult(ctx context.Context, arg generated.UpdatePaymentImportRowResultParams) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePaymentImportRowResult", ctx, arg)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePaymentImportRowResult indicates an expected call of UpdatePaymentImportRowResult.
+func (mr *MockQuerierMockRecorder) UpdatePaymentImportRowResult(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePaymentImportRowResult", reflect.TypeOf((*MockQuerier)(nil).UpdatePaymentImportRowResult), ctx, arg)
+}
+
+// UpdateProcessedCallbackTransaction mocks base method.
+func (m *MockQuerier) UpdateProcessedCallbackTransaction(ctx context.Context, arg generated.UpdateProcessedCallbackTransactionParams) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProcessedCallbackTransaction", ctx, arg)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateProcessedCallbackTransaction indicates an expected call of UpdateProcessedCallbackTransaction.
+func (mr *MockQuerierMockRecorder) UpdateProcessedCallbackTransaction(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProcessedCallbackTransaction", reflect.TypeOf((*MockQuerier)(nil).UpdateProcessedCallbackTransaction), ctx, arg)
+}
+
+// UpdateStkPushRequestResult mocks base method.
+func (m *MockQuerier) UpdateStkPushRequestResult(ctx context.Context, arg generated.UpdateStkPushRequestResultParams) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStkPushRequestResult", ctx, arg)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateStkPushRequestResult indicates an expected call of UpdateStkPushRequestResult.
+func (mr *MockQuerierMockRecorder) UpdateStkPushRequestResult(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStkPushRequestResult", reflect.TypeOf((*MockQuerier)(nil).UpdateStkPushRequestResult), ctx, arg)
+}
+
 // UpdateUser mocks base method.
 func (m *MockQuerier) UpdateUser(ctx context.Context, arg generated.UpdateUserParams) (sql.Result, error) {
 	m.ctrl.T.Helper()
@@ -1672,3 +3678,48 @@ func (mr *MockQuerierMockRecorder) UpdateUserPassword(ctx, arg any) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserPassword", reflect.TypeOf((*MockQuerier)(nil).UpdateUserPassword), ctx, arg)
 }
+
+// UpsertProductAllocationStrategy mocks base method.
+func (m *MockQuerier) UpsertProductAllocationStrategy(ctx context.Context, arg generated.UpsertProductAllocationStrategyParams) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertProductAllocationStrategy", ctx, arg)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertProductAllocationStrategy indicates an expected call of UpsertProductAllocationStrategy.
+func (mr *MockQuerierMockRecorder) UpsertProductAllocationStrategy(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertProductAllocationStrategy", reflect.TypeOf((*MockQuerier)(nil).UpsertProductAllocationStrategy), ctx, arg)
+}
+
+// UpsertProductDefaultThreshold mocks base method.
+func (m *MockQuerier) UpsertProductDefaultThreshold(ctx context.Context, arg generated.UpsertProductDefaultThresholdParams) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertProductDefaultThreshold", ctx, arg)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertProductDefaultThreshold indicates an expected call of UpsertProductDefaultThreshold.
+func (mr *MockQuerierMockRecorder) UpsertProductDefaultThreshold(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertProductDefaultThreshold", reflect.TypeOf((*MockQuerier)(nil).UpsertProductDefaultThreshold), ctx, arg)
+}
+
+// UpsertProductPenaltyRule mocks base method.
+func (m *MockQuerier) UpsertProductPenaltyRule(ctx context.Context, arg generated.UpsertProductPenaltyRuleParams) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertProductPenaltyRule", ctx, arg)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertProductPenaltyRule indicates an expected call of UpsertProductPenaltyRule.
+func (mr *MockQuerierMockRecorder) UpsertProductPenaltyRule(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertProductPenaltyRule", reflect.TypeOf((*MockQuerier)(nil).UpsertProductPenaltyRule), ctx, arg)
+}
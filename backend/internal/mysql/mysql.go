@@ -3,12 +3,17 @@ package mysql
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
 	"log"
+	"math/rand"
+	"strings"
+	"time"
 
 	"github.com/EmilioCliff/kokomed-fin/backend/internal/mysql/generated"
 	"github.com/EmilioCliff/kokomed-fin/backend/internal/repository"
 	"github.com/EmilioCliff/kokomed-fin/backend/pkg"
-	_ "github.com/go-sql-driver/mysql"
+	mysqldriver "github.com/go-sql-driver/mysql"
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/golang-migrate/migrate/v4/database"
 	"github.com/golang-migrate/migrate/v4/database/mysql"
@@ -16,6 +21,15 @@ import (
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 )
 
+const (
+	errDeadlock        = 1213
+	errLockWaitTimeout = 1205
+	errDuplicateEntry  = 1062
+
+	maxTxAttempts  = 3
+	txRetryBackoff = 50 * time.Millisecond
+)
+
 type Store struct {
 	db           *sql.DB
 	config       pkg.Config
@@ -39,6 +53,8 @@ type MySQLRepo struct {
 	Clients   repository.ClientRepository
 	NonPosted repository.NonPostedRepository
 	Helpers   repository.HelperRepository
+	Ledger    repository.LedgerRepository
+	CashBooks repository.CashBookRepository
 }
 
 func NewMySQLRepo(db *Store) *MySQLRepo {
@@ -50,6 +66,8 @@ func NewMySQLRepo(db *Store) *MySQLRepo {
 		Clients:   NewClientRepository(db),
 		NonPosted: NewNonPostedRepository(db),
 		Helpers:   NewHelperRepository(db),
+		Ledger:    NewLedgerRepository(db),
+		CashBooks: NewCashBookRepository(db),
 	}
 }
 
@@ -112,8 +130,37 @@ func (s *Store) runMigration(driver database.Driver) error {
 	return nil
 }
 
-// executes transaction.
+// Queries runs queries straight on the database, for reads that do not need a transaction.
+func (s *Store) Queries() generated.Querier {
+	return generated.New(s.db)
+}
+
+// executes transaction. Transactions that lose a deadlock or time out waiting for a row lock
+// are rolled back by mysql and are run again, fn must therefore only keep state it sets
+// afresh on every run.
 func (s *Store) ExecTx(ctx context.Context, fn func(q generated.Querier) error) error {
+	var err error
+
+	for attempt := 1; ; attempt++ {
+		err = s.execTx(ctx, fn)
+		if err == nil || attempt == maxTxAttempts || !isLockError(err) {
+			return err
+		}
+
+		backoff := txRetryBackoff << (attempt - 1)
+		backoff += time.Duration(rand.Int63n(int64(backoff)))
+
+		log.Printf("retrying transaction after lock error (attempt %d): %v", attempt, err)
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+	}
+}
+
+func (s *Store) execTx(ctx context.Context, fn func(q generated.Querier) error) error {
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
 		return pkg.Errorf(pkg.INTERNAL_ERROR, "couldn't being transaction: %v", err)
@@ -132,3 +179,47 @@ func (s *Store) ExecTx(ctx context.Context, fn func(q generated.Querier) error)
 
 	return tx.Commit()
 }
+
+// DryRunTx runs fn in a transaction that is always rolled back, so the real write path can be
+// exercised without changing anything. fn's error is returned as is.
+func (s *Store) DryRunTx(ctx context.Context, fn func(q generated.Querier) error) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return pkg.Errorf(pkg.INTERNAL_ERROR, "couldn't being transaction: %v", err)
+	}
+
+	q := s.NewQuerierFn(tx)
+
+	err = fn(q)
+
+	if rbErr := tx.Rollback(); rbErr != nil {
+		return pkg.Errorf(pkg.INTERNAL_ERROR, "dry run rb err: %v", rbErr)
+	}
+
+	return err
+}
+
+// isLockError reports whether the transaction failed on a deadlock or a lock wait timeout.
+// Query errors are usually flattened into a pkg.Error message so the driver error number is
+// also looked for in the text.
+func isLockError(err error) bool {
+	var mysqlErr *mysqldriver.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return mysqlErr.Number == errDeadlock || mysqlErr.Number == errLockWaitTimeout
+	}
+
+	message := err.Error()
+
+	return strings.Contains(message, fmt.Sprintf("Error %d", errDeadlock)) ||
+		strings.Contains(message, fmt.Sprintf("Error %d", errLockWaitTimeout))
+}
+
+// isDuplicateEntry reports whether the statement failed on a unique key.
+func isDuplicateEntry(err error) bool {
+	var mysqlErr *mysqldriver.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return mysqlErr.Number == errDuplicateEntry
+	}
+
+	return false
+}
@@ -3,6 +3,7 @@ package mysql
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"strings"
 
 	"github.com/EmilioCliff/kokomed-fin/backend/internal/mysql/generated"
@@ -35,9 +36,10 @@ func (r *NonPostedRepository) CreateNonPosted(
 		AccountNumber:     nonPosted.AccountNumber,
 		PhoneNumber:       nonPosted.PhoneNumber,
 		PayingName:        nonPosted.PayingName,
-		Amount:            nonPosted.Amount,
+		Amount:            nonPosted.Amount.Float64(),
 		PaidDate:          nonPosted.PaidDate,
 		AssignedBy:        nonPosted.AssignedBy,
+		CashReceipt:       nonPosted.CashReceipt,
 	}
 
 	if nonPosted.AssignedTo != nil {
@@ -47,32 +49,52 @@ func (r *NonPostedRepository) CreateNonPosted(
 		}
 	}
 
-	execResult, err := r.queries.CreateNonPosted(ctx, params)
-	if err != nil {
-		return repository.NonPosted{}, pkg.Errorf(
-			pkg.INTERNAL_ERROR,
-			"failed to create non posted: %s",
-			err.Error(),
-		)
-	}
+	err := r.db.ExecTx(ctx, func(q generated.Querier) error {
+		execResult, err := q.CreateNonPosted(ctx, params)
+		if err != nil {
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to create non posted: %s", err.Error())
+		}
+
+		id, err := execResult.LastInsertId()
+		if err != nil {
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to get last insert id: %s", err.Error())
+		}
 
-	id, err := execResult.LastInsertId()
+		nonPosted.ID = uint32(id)
+
+		return PostJournalEntry(ctx, q, repository.JournalEntry{
+			EntryType:   repository.JournalPaymentReceived,
+			Description: fmt.Sprintf("PAYMENT %s RECEIVED", nonPosted.TransactionNumber),
+			NonPostedID: &nonPosted.ID,
+			CreatedBy:   nonPosted.AssignedBy,
+			Lines: repository.Transfer(
+				repository.PaymentFundingAccount(nonPosted.TransactionNumber),
+				repository.AccountUnallocatedPayments,
+				nonPosted.Amount,
+			),
+		})
+	})
 	if err != nil {
-		return repository.NonPosted{}, pkg.Errorf(
-			pkg.INTERNAL_ERROR,
-			"failed to get last insert id: %s",
-			err.Error(),
-		)
+		return repository.NonPosted{}, err
 	}
 
-	nonPosted.ID = uint32(id)
-
 	return *nonPosted, nil
 }
 
 func (r *NonPostedRepository) UpdateNonPosted(
 	ctx context.Context,
 	nonPosted *repository.NonPosted,
+) error {
+	return r.db.ExecTx(ctx, func(q generated.Querier) error {
+		return UpdateNonPostedTx(ctx, q, nonPosted)
+	})
+}
+
+// UpdateNonPostedTx updates a payment within the caller's transaction.
+func UpdateNonPostedTx(
+	ctx context.Context,
+	q generated.Querier,
+	nonPosted *repository.NonPosted,
 ) error {
 	params := generated.UpdateNonPostedParams{
 		ID:                nonPosted.ID,
@@ -81,7 +103,7 @@ func (r *NonPostedRepository) UpdateNonPosted(
 		AccountNumber:     nonPosted.AccountNumber,
 		PhoneNumber:       nonPosted.PhoneNumber,
 		PayingName:        nonPosted.PayingName,
-		Amount:            nonPosted.Amount,
+		Amount:            nonPosted.Amount.Float64(),
 		PaidDate:          nonPosted.PaidDate,
 		AssignedBy:        nonPosted.AssignedBy,
 		AssignTo: sql.NullInt32{
@@ -96,16 +118,52 @@ func (r *NonPostedRepository) UpdateNonPosted(
 		}
 	}
 
-	_, err := r.queries.UpdateNonPosted(ctx, params)
+	current, err := q.GetNonPosted(ctx, nonPosted.ID)
 	if err != nil {
-		return pkg.Errorf(
-			pkg.INTERNAL_ERROR,
-			"failed to create non posted: %s",
-			err.Error(),
-		)
+		if err == sql.ErrNoRows {
+			return pkg.Errorf(pkg.NOT_FOUND_ERROR, "no non posted found")
+		}
+
+		return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to get non posted: %s", err.Error())
 	}
 
-	return nil
+	// moving a till payment in or out of a day changes both days' cash
+	if err := CheckPaymentCashBookOpen(
+		ctx,
+		q,
+		current.CashReceipt,
+		current.AssignedBy,
+		current.PaidDate,
+	); err != nil {
+		return err
+	}
+
+	if err := CheckPaymentCashBookOpen(
+		ctx,
+		q,
+		current.CashReceipt,
+		nonPosted.AssignedBy,
+		nonPosted.PaidDate,
+	); err != nil {
+		return err
+	}
+
+	if _, err := q.UpdateNonPosted(ctx, params); err != nil {
+		return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to create non posted: %s", err.Error())
+	}
+
+	// a corrected amount means more or less money was received than first recorded
+	return PostJournalEntry(ctx, q, repository.JournalEntry{
+		EntryType:   repository.JournalPaymentAdjusted,
+		Description: fmt.Sprintf("PAYMENT %s AMOUNT CORRECTED", nonPosted.TransactionNumber),
+		NonPostedID: &nonPosted.ID,
+		CreatedBy:   nonPosted.AssignedBy,
+		Lines: repository.Transfer(
+			repository.PaymentFundingAccount(current.TransactionNumber),
+			repository.AccountUnallocatedPayments,
+			nonPosted.Amount-pkg.MoneyFromFloat(current.Amount),
+		),
+	})
 }
 
 func (r *NonPostedRepository) GetNonPosted(
@@ -132,9 +190,10 @@ func (r *NonPostedRepository) GetNonPosted(
 		AccountNumber:     nonPosted.AccountNumber,
 		PhoneNumber:       nonPosted.PhoneNumber,
 		PayingName:        nonPosted.PayingName,
-		Amount:            nonPosted.Amount,
+		Amount:            pkg.MoneyFromFloat(nonPosted.Amount),
 		PaidDate:          nonPosted.PaidDate,
 		AssignedBy:        nonPosted.AssignedBy,
+		CashReceipt:       nonPosted.CashReceipt,
 	}
 
 	if nonPosted.DeletedAt.Valid {
@@ -161,7 +220,7 @@ func (r *NonPostedRepository) GetNonPosted(
 			ID:          uint32(nonPosted.ClientID.Int32),
 			FullName:    nonPosted.ClientName.String,
 			PhoneNumber: nonPosted.ClientPhone.String,
-			Overpayment: overpayment,
+			Overpayment: pkg.MoneyFromFloat(overpayment),
 			BranchName:  nonPosted.ClientBranchName.String,
 		}
 	}
@@ -253,9 +312,10 @@ func (r *NonPostedRepository) ListNonPosted(
 			AccountNumber:     nonPosted.AccountNumber,
 			PhoneNumber:       nonPosted.PhoneNumber,
 			PayingName:        nonPosted.PayingName,
-			Amount:            nonPosted.Amount,
+			Amount:            pkg.MoneyFromFloat(nonPosted.Amount),
 			PaidDate:          nonPosted.PaidDate,
 			AssignedBy:        nonPosted.AssignedBy,
+			CashReceipt:       nonPosted.CashReceipt,
 		}
 
 		if nonPosted.AssignTo.Valid {
@@ -275,7 +335,7 @@ func (r *NonPostedRepository) ListNonPosted(
 				ID:          uint32(nonPosted.ClientID.Int32),
 				FullName:    nonPosted.ClientName.String,
 				PhoneNumber: nonPosted.ClientPhone.String,
-				Overpayment: overpayment,
+				Overpayment: pkg.MoneyFromFloat(overpayment),
 				BranchName:  nonPosted.ClientBranchName.String,
 			}
 		}
@@ -311,7 +371,7 @@ func (r *NonPostedRepository) ListPaymentAllocationsByNonPostedId(
 			NonPostedID:   allocation.NonPostedID,
 			LoanID:        nil,
 			InstallmentID: nil,
-			Amount:        allocation.Amount,
+			Amount:        pkg.MoneyFromFloat(allocation.Amount),
 			Description:   allocation.Description,
 			DeletedAt:     nil,
 			CreatedAt:     allocation.CreatedAt,
@@ -325,6 +385,10 @@ func (r *NonPostedRepository) ListPaymentAllocationsByNonPostedId(
 			p.InstallmentID = pkg.Uint32Ptr(uint32(allocation.InstallmentID.Int32))
 		}
 
+		if allocation.PenaltyID.Valid {
+			p.PenaltyID = pkg.Uint32Ptr(uint32(allocation.PenaltyID.Int32))
+		}
+
 		if allocation.DeletedAt.Valid {
 			p.DeletedAt = &allocation.DeletedAt.Time
 		}
@@ -404,20 +468,314 @@ func (r *NonPostedRepository) DeleteNonPosted(
 	id uint32,
 	description string,
 ) error {
-	err := r.queries.SoftDeleteNonPosted(ctx, generated.SoftDeleteNonPostedParams{
+	return r.db.ExecTx(ctx, func(q generated.Querier) error {
+		return DeleteNonPostedTx(ctx, q, id, description)
+	})
+}
+
+// DeleteNonPostedTx soft deletes an unallocated payment within the caller's transaction.
+func DeleteNonPostedTx(
+	ctx context.Context,
+	q generated.Querier,
+	id uint32,
+	description string,
+) error {
+	nonPosted, err := q.GetNonPosted(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return pkg.Errorf(pkg.NOT_FOUND_ERROR, "no non posted found")
+		}
+
+		return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to get non posted: %s", err.Error())
+	}
+
+	if nonPosted.DeletedAt.Valid {
+		return nil
+	}
+
+	if err := CheckPaymentCashBookOpen(
+		ctx,
+		q,
+		nonPosted.CashReceipt,
+		nonPosted.AssignedBy,
+		nonPosted.PaidDate,
+	); err != nil {
+		return err
+	}
+
+	if err := q.SoftDeleteNonPosted(ctx, generated.SoftDeleteNonPostedParams{
 		ID: id,
 		DeletedDescription: sql.NullString{
 			Valid:  true,
 			String: description,
 		},
-	})
-	if err != nil && err == sql.ErrNoRows {
+	}); err != nil {
 		return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to delete non posted: %s", err.Error())
 	}
 
+	// only unallocated payments are deleted here, the money goes back where it came from
+	return PostJournalEntry(ctx, q, repository.JournalEntry{
+		EntryType:   repository.JournalPaymentDeleted,
+		Description: description,
+		NonPostedID: &id,
+		Lines: repository.Transfer(
+			repository.AccountUnallocatedPayments,
+			repository.PaymentFundingAccount(nonPosted.TransactionNumber),
+			pkg.MoneyFromFloat(nonPosted.Amount),
+		),
+	})
+}
+
+func (r *NonPostedRepository) GetProcessedCallback(
+	ctx context.Context,
+	transactionNumber string,
+	transactionSource string,
+) (repository.ProcessedCallback, error) {
+	callback, err := r.queries.GetProcessedCallback(ctx, generated.GetProcessedCallbackParams{
+		TransactionNumber: transactionNumber,
+		TransactionSource: transactionSource,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return repository.ProcessedCallback{}, pkg.Errorf(
+				pkg.NOT_FOUND_ERROR,
+				"no processed callback found",
+			)
+		}
+
+		return repository.ProcessedCallback{}, pkg.Errorf(
+			pkg.INTERNAL_ERROR,
+			"failed to get processed callback: %s",
+			err.Error(),
+		)
+	}
+
+	rslt := repository.ProcessedCallback{
+		ID:                callback.ID,
+		TransactionNumber: callback.TransactionNumber,
+		TransactionSource: callback.TransactionSource,
+		NonPostedID:       callback.NonPostedID,
+		CreatedAt:         callback.CreatedAt,
+	}
+
+	if callback.LoanID.Valid {
+		value := uint32(callback.LoanID.Int32)
+		rslt.LoanID = &value
+	}
+
+	return rslt, nil
+}
+
+// DeleteProcessedCallbackTx forgets a payment's reference within the caller's transaction so the
+// reference can be posted again once the payment is gone.
+func DeleteProcessedCallbackTx(ctx context.Context, q generated.Querier, nonPostedID uint32) error {
+	if _, err := q.DeleteProcessedCallbackByNonPosted(ctx, nonPostedID); err != nil {
+		return pkg.Errorf(
+			pkg.INTERNAL_ERROR,
+			"failed to delete processed callback: %s",
+			err.Error(),
+		)
+	}
+
+	return nil
+}
+
+// UpdateProcessedCallbackTx moves a payment's processed callback to its updated reference within
+// the caller's transaction, the old reference is free to be posted again.
+func UpdateProcessedCallbackTx(
+	ctx context.Context,
+	q generated.Querier,
+	nonPostedID uint32,
+	transactionNumber string,
+	transactionSource string,
+) error {
+	if transactionNumber == "" {
+		return DeleteProcessedCallbackTx(ctx, q, nonPostedID)
+	}
+
+	_, err := q.UpdateProcessedCallbackTransaction(
+		ctx,
+		generated.UpdateProcessedCallbackTransactionParams{
+			TransactionNumber: transactionNumber,
+			TransactionSource: transactionSource,
+			NonPostedID:       nonPostedID,
+		},
+	)
+	if err != nil {
+		if isDuplicateEntry(err) {
+			return pkg.Errorf(
+				pkg.ALREADY_EXISTS_ERROR,
+				"a payment with reference %s has already been posted",
+				transactionNumber,
+			)
+		}
+
+		return pkg.Errorf(
+			pkg.INTERNAL_ERROR,
+			"failed to update processed callback: %s",
+			err.Error(),
+		)
+	}
+
 	return nil
 }
 
+func (r *NonPostedRepository) ListDuplicateNonPosted(
+	ctx context.Context,
+) ([]repository.NonPosted, error) {
+	nonPosteds, err := r.queries.ListDuplicateNonPosted(ctx)
+	if err != nil {
+		return nil, pkg.Errorf(
+			pkg.INTERNAL_ERROR,
+			"failed to list duplicate non posted: %s",
+			err.Error(),
+		)
+	}
+
+	rslt := make([]repository.NonPosted, len(nonPosteds))
+
+	for i, nonPosted := range nonPosteds {
+		rslt[i] = convertGenerateNonPosted(nonPosted)
+	}
+
+	return rslt, nil
+}
+
+func (r *NonPostedRepository) CreatePaymentValidationLog(
+	ctx context.Context,
+	log *repository.PaymentValidationLog,
+) error {
+	params := generated.CreatePaymentValidationLogParams{
+		TransactionNumber: log.TransactionNumber,
+		AccountNumber:     log.AccountNumber,
+		PhoneNumber:       log.PhoneNumber,
+		PayingName:        log.PayingName,
+		Amount:            log.Amount.Float64(),
+		Accepted:          log.Accepted,
+		ResultCode:        log.ResultCode,
+		Reason:            log.Reason,
+	}
+
+	if log.ClientID != nil {
+		params.ClientID = sql.NullInt32{
+			Valid: true,
+			Int32: int32(*log.ClientID),
+		}
+	}
+
+	if _, err := r.queries.CreatePaymentValidationLog(ctx, params); err != nil {
+		return pkg.Errorf(
+			pkg.INTERNAL_ERROR,
+			"failed to create payment validation log: %s",
+			err.Error(),
+		)
+	}
+
+	return nil
+}
+
+func (r *NonPostedRepository) ListPaymentValidationLogs(
+	ctx context.Context,
+	pgData *pkg.PaginationMetadata,
+) ([]repository.PaymentValidationLog, pkg.PaginationMetadata, error) {
+	logs, err := r.queries.ListPaymentValidationLogs(ctx, generated.ListPaymentValidationLogsParams{
+		Limit:  int32(pgData.PageSize),
+		Offset: pkg.CalculateOffset(pgData.CurrentPage, pgData.PageSize),
+	})
+	if err != nil {
+		return nil, pkg.PaginationMetadata{}, pkg.Errorf(
+			pkg.INTERNAL_ERROR,
+			"failed to list payment validation logs: %s",
+			err.Error(),
+		)
+	}
+
+	totalLogs, err := r.queries.CountPaymentValidationLogs(ctx)
+	if err != nil {
+		return nil, pkg.PaginationMetadata{}, pkg.Errorf(
+			pkg.INTERNAL_ERROR,
+			"failed to count payment validation logs: %s",
+			err.Error(),
+		)
+	}
+
+	rslt := make([]repository.PaymentValidationLog, len(logs))
+
+	for i, log := range logs {
+		rslt[i] = repository.PaymentValidationLog{
+			ID:                log.ID,
+			TransactionNumber: log.TransactionNumber,
+			AccountNumber:     log.AccountNumber,
+			PhoneNumber:       log.PhoneNumber,
+			PayingName:        log.PayingName,
+			Amount:            pkg.MoneyFromFloat(log.Amount),
+			Accepted:          log.Accepted,
+			ResultCode:        log.ResultCode,
+			Reason:            log.Reason,
+			CreatedAt:         log.CreatedAt,
+		}
+
+		if log.ClientID.Valid {
+			value := uint32(log.ClientID.Int32)
+			rslt[i].ClientID = &value
+		}
+	}
+
+	return rslt, pkg.CreatePaginationMetadata(
+		uint32(totalLogs),
+		pgData.PageSize,
+		pgData.CurrentPage,
+	), nil
+}
+
+func (r *NonPostedRepository) CreateCallbackPayload(
+	ctx context.Context,
+	payload *repository.CallbackPayload,
+) error {
+	_, err := r.queries.CreateCallbackPayload(ctx, generated.CreateCallbackPayloadParams{
+		TransactionNumber: payload.TransactionNumber,
+		CallbackType:      payload.CallbackType,
+		Payload:           payload.Payload,
+	})
+	if err != nil {
+		return pkg.Errorf(
+			pkg.INTERNAL_ERROR,
+			"failed to create callback payload: %s",
+			err.Error(),
+		)
+	}
+
+	return nil
+}
+
+func (r *NonPostedRepository) ListCallbackPayloads(
+	ctx context.Context,
+	transactionNumber string,
+) ([]repository.CallbackPayload, error) {
+	payloads, err := r.queries.ListCallbackPayloadsByTransactionNumber(ctx, transactionNumber)
+	if err != nil {
+		return nil, pkg.Errorf(
+			pkg.INTERNAL_ERROR,
+			"failed to list callback payloads: %s",
+			err.Error(),
+		)
+	}
+
+	rslt := make([]repository.CallbackPayload, len(payloads))
+
+	for i, payload := range payloads {
+		rslt[i] = repository.CallbackPayload{
+			ID:                payload.ID,
+			TransactionNumber: payload.TransactionNumber,
+			CallbackType:      payload.CallbackType,
+			Payload:           payload.Payload,
+			CreatedAt:         payload.CreatedAt,
+		}
+	}
+
+	return rslt, nil
+}
+
 func (r *NonPostedRepository) GetReportPaymentData(
 	ctx context.Context,
 	filters services.ReportFilters,
@@ -444,7 +802,7 @@ func (r *NonPostedRepository) GetReportPaymentData(
 	rslt := make([]services.PaymentReportData, len(nonPosteds))
 
 	var totalPayments int64
-	var totalAmountReceived float64
+	var totalAmountReceived pkg.Money
 	sourceCount := make(map[string]int64) // Count of each transaction source
 	staffCount := make(map[string]int64)  // Count of each assigned staff
 
@@ -454,14 +812,14 @@ func (r *NonPostedRepository) GetReportPaymentData(
 			TransactionNumber: nonPosted.TransactionNumber,
 			AccountNumber:     nonPosted.AccountNumber,
 			PayingName:        nonPosted.PayingName,
-			Amount:            nonPosted.Amount,
+			Amount:            pkg.MoneyFromFloat(nonPosted.Amount),
 			PaidDate:          nonPosted.PaidDate,
 			AssignedTo:        nonPosted.AssignedName,
 			AssignedBy:        nonPosted.AssignedBy,
 		}
 
 		totalPayments++
-		totalAmountReceived += nonPosted.Amount
+		totalAmountReceived += rslt[i].Amount
 		sourceCount[string(nonPosted.TransactionSource)]++
 		staffCount[nonPosted.AssignedBy]++
 	}
@@ -494,6 +852,134 @@ func (r *NonPostedRepository) GetReportPaymentData(
 	return rslt, summary, nil
 }
 
+// GetPaymentReceiptData collects what a payment receipt shows: the payment, the installments it
+// paid and where the loan stands now.
+func (r *NonPostedRepository) GetPaymentReceiptData(
+	ctx context.Context,
+	id uint32,
+) (services.PaymentReceiptData, error) {
+	nonPosted, err := r.queries.GetNonPosted(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return services.PaymentReceiptData{}, pkg.Errorf(
+				pkg.NOT_FOUND_ERROR,
+				"no non posted found",
+			)
+		}
+
+		return services.PaymentReceiptData{}, pkg.Errorf(
+			pkg.INTERNAL_ERROR,
+			"failed to get non posted: %s",
+			err.Error(),
+		)
+	}
+
+	if nonPosted.DeletedAt.Valid {
+		return services.PaymentReceiptData{}, pkg.Errorf(
+			pkg.INVALID_ERROR,
+			"payment has been deleted",
+		)
+	}
+
+	if !nonPosted.AssignTo.Valid {
+		return services.PaymentReceiptData{}, pkg.Errorf(
+			pkg.INVALID_ERROR,
+			"payment has not been allocated to a client",
+		)
+	}
+
+	rslt := services.PaymentReceiptData{
+		ReceiptNumber:     pkg.PaymentReceiptNumber(nonPosted.ID),
+		NonPostedID:       nonPosted.ID,
+		TransactionNumber: nonPosted.TransactionNumber,
+		TransactionSource: string(nonPosted.TransactionSource),
+		PayingName:        nonPosted.PayingName,
+		Amount:            pkg.MoneyFromFloat(nonPosted.Amount),
+		PaidDate:          nonPosted.PaidDate,
+		ClientName:        nonPosted.ClientName.String,
+		ClientPhone:       nonPosted.ClientPhone.String,
+	}
+
+	allocations, err := r.queries.ListPaymentAllocationsByNonPostedId(ctx, id)
+	if err != nil {
+		return services.PaymentReceiptData{}, pkg.Errorf(
+			pkg.INTERNAL_ERROR,
+			"failed to list payment allocations: %s",
+			err.Error(),
+		)
+	}
+
+	paidInstallments := make(map[uint32]pkg.Money)
+
+	for _, allocation := range allocations {
+		if allocation.PenaltyID.Valid {
+			rslt.LoanID = pkg.Uint32Ptr(uint32(allocation.LoanID.Int32))
+			rslt.PenaltiesPaid += pkg.MoneyFromFloat(allocation.Amount)
+
+			continue
+		}
+
+		if !allocation.InstallmentID.Valid {
+			rslt.Overpayment += pkg.MoneyFromFloat(allocation.Amount)
+
+			continue
+		}
+
+		rslt.LoanID = pkg.Uint32Ptr(uint32(allocation.LoanID.Int32))
+		paidInstallments[uint32(allocation.InstallmentID.Int32)] += pkg.MoneyFromFloat(allocation.Amount)
+	}
+
+	if rslt.LoanID == nil {
+		return rslt, nil
+	}
+
+	terms, err := r.queries.GetLoanAllocationTerms(ctx, *rslt.LoanID)
+	if err != nil {
+		return services.PaymentReceiptData{}, pkg.Errorf(
+			pkg.INTERNAL_ERROR,
+			"failed to get loan allocation terms: %s",
+			err.Error(),
+		)
+	}
+
+	loan, err := r.queries.GetLoan(ctx, *rslt.LoanID)
+	if err != nil {
+		return services.PaymentReceiptData{}, pkg.Errorf(
+			pkg.INTERNAL_ERROR,
+			"failed to get loan: %s",
+			err.Error(),
+		)
+	}
+
+	rslt.LoanBalance = pkg.MoneyFromFloat(terms.RepayAmount) - pkg.MoneyFromFloat(loan.PaidAmount)
+
+	installments, err := r.queries.ListInstallmentsByLoan(ctx, *rslt.LoanID)
+	if err != nil {
+		return services.PaymentReceiptData{}, pkg.Errorf(
+			pkg.INTERNAL_ERROR,
+			"failed to list installments: %s",
+			err.Error(),
+		)
+	}
+
+	for _, installment := range installments {
+		if amount, ok := paidInstallments[installment.ID]; ok {
+			rslt.Allocations = append(rslt.Allocations, services.PaymentReceiptAllocation{
+				InstallmentNumber: installment.InstallmentNumber,
+				DueDate:           installment.DueDate,
+				Amount:            amount,
+			})
+		}
+
+		if !installment.Paid && rslt.NextDueDate == nil {
+			rslt.NextDueDate = pkg.TimePtr(installment.DueDate)
+			rslt.NextDueAmount = pkg.MoneyFromFloat(installment.RemainingAmount)
+		}
+	}
+
+	return rslt, nil
+}
+
 func (r *NonPostedRepository) GetClientNonPosted(
 	ctx context.Context,
 	id uint32,
@@ -632,7 +1118,7 @@ func (r *NonPostedRepository) GetClientNonPosted(
 		rslt.ClientDetails.ID = client.ID
 		rslt.ClientDetails.FullName = client.FullName
 		rslt.ClientDetails.PhoneNumber = client.PhoneNumber
-		rslt.ClientDetails.Overpayment = client.Overpayment
+		rslt.ClientDetails.Overpayment = pkg.MoneyFromFloat(client.Overpayment)
 		rslt.ClientDetails.BranchName = branch.Name
 		rslt.ClientDetails.BranchID = client.BranchID
 		rslt.ClientDetails.Dob = client.Dob.Time.Format("2006-01-02")
@@ -646,11 +1132,11 @@ func (r *NonPostedRepository) GetClientNonPosted(
 
 	if clientHasActiveLoan {
 		rslt.LoanDetails.ID = loan.ID
-		rslt.LoanDetails.LoanAmount = loan.LoanAmount
-		rslt.LoanDetails.RepayAmount = loan.RepayAmount
+		rslt.LoanDetails.LoanAmount = pkg.MoneyFromFloat(loan.LoanAmount)
+		rslt.LoanDetails.RepayAmount = pkg.MoneyFromFloat(loan.RepayAmount)
 		rslt.LoanDetails.DisbursedOn = loan.DisbursedOn.Time.Format("2006-01-02")
 		rslt.LoanDetails.DueDate = loan.DueDate.Time.Format("2006-01-02")
-		rslt.LoanDetails.PaidAmount = loan.PaidAmount
+		rslt.LoanDetails.PaidAmount = pkg.MoneyFromFloat(loan.PaidAmount)
 		rslt.LoanDetails.Installments = convertGeneratedInstallmentList(installments)
 	}
 
@@ -664,13 +1150,13 @@ func (r *NonPostedRepository) GetClientNonPosted(
 				AccountNumber:     nonPosted.AccountNumber,
 				PhoneNumber:       nonPosted.PhoneNumber,
 				PayingName:        nonPosted.PayingName,
-				Amount:            nonPosted.Amount,
+				Amount:            pkg.MoneyFromFloat(nonPosted.Amount),
 				PaidDate:          nonPosted.PaidDate,
 				AssignedBy:        nonPosted.AssignedBy,
 			}
 		}
 		rslt.PaymentDetails = paymentDetails
-		rslt.TotalPaid = pkg.InterfaceFloat64(totalPaid)
+		rslt.TotalPaid = pkg.InterfaceMoney(totalPaid)
 	}
 
 	return rslt, pkg.CreatePaginationMetadata(
@@ -695,9 +1181,10 @@ func convertGenerateNonPosted(nonPosted generated.NonPosted) repository.NonPoste
 		AccountNumber:     nonPosted.AccountNumber,
 		PhoneNumber:       nonPosted.PhoneNumber,
 		PayingName:        nonPosted.PayingName,
-		Amount:            nonPosted.Amount,
+		Amount:            pkg.MoneyFromFloat(nonPosted.Amount),
 		PaidDate:          nonPosted.PaidDate,
 		AssignedTo:        assignedTo,
 		AssignedBy:        nonPosted.AssignedBy,
+		CashReceipt:       nonPosted.CashReceipt,
 	}
 }
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/EmilioCliff/kokomed-fin/backend/internal/mysql/generated"
 	"github.com/EmilioCliff/kokomed-fin/backend/internal/mysql/mockdb"
@@ -28,6 +29,7 @@ func TestNonPostedRepository_TestCreateNonPosted(t *testing.T) {
 
 	mockQueries := mockdb.NewMockQuerier(ctrl)
 	r.queries = mockQueries
+	useMockTx(r.db, mockQueries)
 
 	tests := []struct {
 		name       string
@@ -103,7 +105,7 @@ func TestNonPostedRepository_GetNonPosted(t *testing.T) {
 				mockQueries.EXPECT().
 					GetNonPosted(gomock.Any(), gomock.Any()).
 					Times(1).
-					Return(generated.NonPosted{ID: 1, TransactionNumber: "test"}, nil)
+					Return(generated.GetNonPostedRow{ID: 1, TransactionNumber: "test"}, nil)
 			},
 			wantErr: false,
 			err:     nil,
@@ -118,7 +120,7 @@ func TestNonPostedRepository_GetNonPosted(t *testing.T) {
 				mockQueries.EXPECT().
 					GetNonPosted(gomock.Any(), gomock.Any()).
 					Times(1).
-					Return(generated.NonPosted{}, sql.ErrNoRows)
+					Return(generated.GetNonPostedRow{}, sql.ErrNoRows)
 			},
 			wantErr: true,
 			err:     errors.New(pkg.NOT_FOUND_ERROR),
@@ -129,7 +131,7 @@ func TestNonPostedRepository_GetNonPosted(t *testing.T) {
 				mockQueries.EXPECT().
 					GetNonPosted(gomock.Any(), gomock.Any()).
 					Times(1).
-					Return(generated.NonPosted{}, errors.New("internal error"))
+					Return(generated.GetNonPostedRow{}, errors.New("internal error"))
 			},
 			wantErr: true,
 			err:     errors.New(pkg.INTERNAL_ERROR),
@@ -162,46 +164,81 @@ func TestNonPostedRepository_ListNonPosted(t *testing.T) {
 	mockQueries := mockdb.NewMockQuerier(ctrl)
 	r.queries = mockQueries
 
+	fromDate := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	toDate := time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)
+	pgData := &pkg.PaginationMetadata{
+		CurrentPage: 1,
+		PageSize:    10,
+		FromDate:    &fromDate,
+		ToDate:      &toDate,
+	}
+
 	tests := []struct {
 		name       string
 		buildStubs func(mockQueries *mockdb.MockQuerier)
 		wantErr    bool
 		err        error
 		wantResult []repository.NonPosted
+		wantPgData pkg.PaginationMetadata
 	}{
 		{
 			name: "OK",
 			buildStubs: func(mockQueries *mockdb.MockQuerier) {
 				mockQueries.EXPECT().
-					ListAllNonPosted(gomock.Any(), gomock.Any()).
+					ListNonPostedByCategory(gomock.Any(), generated.ListNonPostedByCategoryParams{
+						Column1:      "",
+						Column5:      "",
+						Limit:        10,
+						Offset:       0,
+						FromPaidDate: fromDate,
+						ToPaidDate:   toDate,
+					}).
 					Times(1).
-					Return([]generated.NonPosted{
-						{ID: 1, TransactionNumber: "test"},
+					Return([]generated.ListNonPostedByCategoryRow{
+						{ID: 1, TransactionNumber: "test", Amount: 1500},
 					}, nil)
+				mockQueries.EXPECT().
+					CountNonPostedByCategory(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(int64(1), nil)
 			},
 			wantErr: false,
 			err:     nil,
 			wantResult: []repository.NonPosted{
-				{ID: 1, TransactionNumber: "test"},
+				{ID: 1, TransactionNumber: "test", Amount: pkg.MoneyFromFloat(1500)},
+			},
+			wantPgData: pkg.PaginationMetadata{
+				CurrentPage: 1,
+				PageSize:    10,
+				TotalData:   1,
+				TotalPages:  1,
 			},
 		},
 		{
 			name: "NonPosted not found",
 			buildStubs: func(mockQueries *mockdb.MockQuerier) {
 				mockQueries.EXPECT().
-					ListAllNonPosted(gomock.Any(), gomock.Any()).
+					ListNonPostedByCategory(gomock.Any(), gomock.Any()).
 					Times(1).
-					Return([]generated.NonPosted{}, nil)
+					Return([]generated.ListNonPostedByCategoryRow{}, nil)
+				mockQueries.EXPECT().
+					CountNonPostedByCategory(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(int64(0), nil)
 			},
 			wantErr:    false,
 			err:        nil,
 			wantResult: []repository.NonPosted{},
+			wantPgData: pkg.PaginationMetadata{
+				CurrentPage: 1,
+				PageSize:    10,
+			},
 		},
 		{
 			name: "Internal Server Error",
 			buildStubs: func(mockQueries *mockdb.MockQuerier) {
 				mockQueries.EXPECT().
-					ListAllNonPosted(gomock.Any(), gomock.Any()).
+					ListNonPostedByCategory(gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(nil, errors.New("internal error"))
 			},
@@ -214,7 +251,11 @@ func TestNonPostedRepository_ListNonPosted(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.buildStubs(mockQueries)
 
-			result, err := r.ListNonPosted(context.Background(), &pkg.PaginationMetadata{})
+			result, metadata, err := r.ListNonPosted(
+				context.Background(),
+				&repository.NonPostedCategory{},
+				pgData,
+			)
 
 			if tc.wantErr {
 				require.Error(t, err)
@@ -222,6 +263,7 @@ func TestNonPostedRepository_ListNonPosted(t *testing.T) {
 			} else {
 				require.NoError(t, err)
 				require.Equal(t, tc.wantResult, result)
+				require.Equal(t, tc.wantPgData, metadata)
 			}
 		})
 	}
@@ -301,14 +343,16 @@ func TestNonPostedRepository_ListUnassignedNonPosted(t *testing.T) {
 	}
 }
 
-func TestNonPostedRepository_DeleteNonPosted(t *testing.T) {
-	r := NewTestNonPostedRepository()
-
+func TestDeleteNonPostedTx(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockQueries := mockdb.NewMockQuerier(ctrl)
-	r.queries = mockQueries
+	nonPosted := generated.GetNonPostedRow{
+		ID:                1,
+		TransactionNumber: "test",
+		TransactionSource: generated.NonPostedTransactionSourceINTERNAL,
+		Amount:            1500,
+	}
 
 	tests := []struct {
 		name       string
@@ -320,18 +364,63 @@ func TestNonPostedRepository_DeleteNonPosted(t *testing.T) {
 			name: "OK",
 			buildStubs: func(mockQueries *mockdb.MockQuerier) {
 				mockQueries.EXPECT().
-					DeleteNonPosted(gomock.Any(), gomock.Any()).
+					GetNonPosted(gomock.Any(), nonPosted.ID).
+					Times(1).
+					Return(nonPosted, nil)
+				mockQueries.EXPECT().
+					SoftDeleteNonPosted(gomock.Any(), generated.SoftDeleteNonPostedParams{
+						ID:                 nonPosted.ID,
+						DeletedDescription: sql.NullString{Valid: true, String: "test"},
+					}).
 					Times(1).
 					Return(nil)
+				mockQueries.EXPECT().
+					CreateJournalEntry(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(&mockSQLResult{lastInsertID: 1, rowsAffected: 1}, nil)
+				mockQueries.EXPECT().
+					CreateJournalLine(gomock.Any(), gomock.Any()).
+					Times(2).
+					Return(&mockSQLResult{rowsAffected: 1}, nil)
 			},
 			wantErr: false,
 			err:     nil,
 		},
+		{
+			name: "Already Deleted",
+			buildStubs: func(mockQueries *mockdb.MockQuerier) {
+				deleted := nonPosted
+				deleted.DeletedAt = sql.NullTime{Valid: true, Time: time.Now()}
+
+				mockQueries.EXPECT().
+					GetNonPosted(gomock.Any(), nonPosted.ID).
+					Times(1).
+					Return(deleted, nil)
+				mockQueries.EXPECT().SoftDeleteNonPosted(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: false,
+			err:     nil,
+		},
+		{
+			name: "Not Found",
+			buildStubs: func(mockQueries *mockdb.MockQuerier) {
+				mockQueries.EXPECT().
+					GetNonPosted(gomock.Any(), nonPosted.ID).
+					Times(1).
+					Return(generated.GetNonPostedRow{}, sql.ErrNoRows)
+			},
+			wantErr: true,
+			err:     errors.New(pkg.NOT_FOUND_ERROR),
+		},
 		{
 			name: "Internal Server Error",
 			buildStubs: func(mockQueries *mockdb.MockQuerier) {
 				mockQueries.EXPECT().
-					DeleteNonPosted(gomock.Any(), gomock.Any()).
+					GetNonPosted(gomock.Any(), nonPosted.ID).
+					Times(1).
+					Return(nonPosted, nil)
+				mockQueries.EXPECT().
+					SoftDeleteNonPosted(gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(errors.New("internal error"))
 			},
@@ -342,9 +431,10 @@ func TestNonPostedRepository_DeleteNonPosted(t *testing.T) {
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
+			mockQueries := mockdb.NewMockQuerier(ctrl)
 			tc.buildStubs(mockQueries)
 
-			err := r.DeleteNonPosted(context.Background(), 1)
+			err := DeleteNonPostedTx(context.Background(), mockQueries, nonPosted.ID, "test")
 
 			if tc.wantErr {
 				require.Error(t, err)
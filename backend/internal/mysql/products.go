@@ -3,6 +3,7 @@ package mysql
 import (
 	"context"
 	"database/sql"
+	"strings"
 
 	"github.com/EmilioCliff/kokomed-fin/backend/internal/mysql/generated"
 	"github.com/EmilioCliff/kokomed-fin/backend/internal/repository"
@@ -81,9 +82,9 @@ func (r *ProductRepository) GetAllProducts(
 			ID:             product.ID,
 			BranchID:       product.BranchID,
 			BranchName:     &product.BranchName,
-			LoanAmount:     product.LoanAmount,
-			RepayAmount:    product.RepayAmount,
-			InterestAmount: product.InterestAmount,
+			LoanAmount:     pkg.MoneyFromFloat(product.LoanAmount),
+			RepayAmount:    pkg.MoneyFromFloat(product.RepayAmount),
+			InterestAmount: pkg.MoneyFromFloat(product.InterestAmount),
 			UpdatedBy:      product.UpdatedBy,
 			UpdatedAt:      product.UpdatedAt,
 			CreatedAt:      product.CreatedAt,
@@ -114,16 +115,34 @@ func (r *ProductRepository) GetProductByID(
 		)
 	}
 
+	strategy, err := r.getProductAllocationStrategy(ctx, id)
+	if err != nil {
+		return repository.Product{}, err
+	}
+
+	graceDays, err := r.getProductDefaultGraceDays(ctx, id)
+	if err != nil {
+		return repository.Product{}, err
+	}
+
+	penaltyRule, err := r.getProductPenaltyRule(ctx, id)
+	if err != nil {
+		return repository.Product{}, err
+	}
+
 	return repository.Product{
-		ID:             product.ID,
-		BranchID:       product.BranchID,
-		LoanAmount:     product.LoanAmount,
-		RepayAmount:    product.RepayAmount,
-		InterestAmount: product.InterestAmount,
-		UpdatedBy:      product.UpdatedBy,
-		UpdatedAt:      product.UpdatedAt,
-		CreatedAt:      product.CreatedAt,
-		BranchName:     &product.BranchName,
+		ID:                 product.ID,
+		BranchID:           product.BranchID,
+		LoanAmount:         pkg.MoneyFromFloat(product.LoanAmount),
+		RepayAmount:        pkg.MoneyFromFloat(product.RepayAmount),
+		InterestAmount:     pkg.MoneyFromFloat(product.InterestAmount),
+		AllocationStrategy: strategy,
+		DefaultGraceDays:   graceDays,
+		PenaltyRule:        penaltyRule,
+		UpdatedBy:          product.UpdatedBy,
+		UpdatedAt:          product.UpdatedAt,
+		CreatedAt:          product.CreatedAt,
+		BranchName:         &product.BranchName,
 	}, nil
 }
 
@@ -160,9 +179,9 @@ func (r *ProductRepository) CreateProduct(
 ) (repository.Product, error) {
 	execRslt, err := r.queries.CreateProduct(ctx, generated.CreateProductParams{
 		BranchID:       product.BranchID,
-		LoanAmount:     product.LoanAmount,
-		RepayAmount:    product.RepayAmount,
-		InterestAmount: product.InterestAmount,
+		LoanAmount:     product.LoanAmount.Float64(),
+		RepayAmount:    product.RepayAmount.Float64(),
+		InterestAmount: product.InterestAmount.Float64(),
 		UpdatedBy:      product.UpdatedBy,
 	})
 	if err != nil {
@@ -194,9 +213,282 @@ func (r *ProductRepository) CreateProduct(
 	product.ID = uint32(id)
 	product.BranchName = &branch.Name
 
+	if product.AllocationStrategy != "" {
+		if err := r.upsertProductAllocationStrategy(
+			ctx,
+			product.ID,
+			product.AllocationStrategy,
+			product.UpdatedBy,
+		); err != nil {
+			return repository.Product{}, err
+		}
+	} else {
+		product.AllocationStrategy = string(generated.ProductAllocationStrategiesStrategyOLDESTFIRST)
+	}
+
 	return *product, nil
 }
 
+func (r *ProductRepository) UpdateProductAllocationStrategy(
+	ctx context.Context,
+	id uint32,
+	strategy string,
+	updatedBy uint32,
+) (repository.Product, error) {
+	if _, err := r.GetProductByID(ctx, id); err != nil {
+		return repository.Product{}, err
+	}
+
+	if err := r.upsertProductAllocationStrategy(ctx, id, strategy, updatedBy); err != nil {
+		return repository.Product{}, err
+	}
+
+	return r.GetProductByID(ctx, id)
+}
+
+func (r *ProductRepository) upsertProductAllocationStrategy(
+	ctx context.Context,
+	id uint32,
+	strategy string,
+	updatedBy uint32,
+) error {
+	allocationStrategy := generated.ProductAllocationStrategiesStrategy(strings.ToUpper(strategy))
+
+	switch allocationStrategy {
+	case generated.ProductAllocationStrategiesStrategyOLDESTFIRST,
+		generated.ProductAllocationStrategiesStrategyCURRENTFIRST,
+		generated.ProductAllocationStrategiesStrategySPREADOVERDUE,
+		generated.ProductAllocationStrategiesStrategyWATERFALL:
+	default:
+		return pkg.Errorf(pkg.INVALID_ERROR, "invalid allocation strategy: %s", strategy)
+	}
+
+	_, err := r.queries.UpsertProductAllocationStrategy(
+		ctx,
+		generated.UpsertProductAllocationStrategyParams{
+			ProductID: id,
+			Strategy:  allocationStrategy,
+			UpdatedBy: updatedBy,
+		},
+	)
+	if err != nil {
+		return pkg.Errorf(
+			pkg.INTERNAL_ERROR,
+			"failed to update product allocation strategy: %s",
+			err.Error(),
+		)
+	}
+
+	return nil
+}
+
+// products without a strategy keep paying the oldest installment first.
+func (r *ProductRepository) getProductAllocationStrategy(
+	ctx context.Context,
+	id uint32,
+) (string, error) {
+	strategy, err := r.queries.GetProductAllocationStrategy(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return string(generated.ProductAllocationStrategiesStrategyOLDESTFIRST), nil
+		}
+
+		return "", pkg.Errorf(
+			pkg.INTERNAL_ERROR,
+			"failed to get product allocation strategy: %s",
+			err.Error(),
+		)
+	}
+
+	return string(strategy.Strategy), nil
+}
+
+// UpdateProductDefaultThreshold sets the days after the due date before the product's loans
+// default. A nil graceDays drops the threshold so the system default applies again.
+func (r *ProductRepository) UpdateProductDefaultThreshold(
+	ctx context.Context,
+	id uint32,
+	graceDays *uint32,
+	updatedBy uint32,
+) (repository.Product, error) {
+	if _, err := r.GetProductByID(ctx, id); err != nil {
+		return repository.Product{}, err
+	}
+
+	if graceDays == nil {
+		if err := r.queries.DeleteProductDefaultThreshold(ctx, id); err != nil {
+			return repository.Product{}, pkg.Errorf(
+				pkg.INTERNAL_ERROR,
+				"failed to delete product default threshold: %s",
+				err.Error(),
+			)
+		}
+
+		return r.GetProductByID(ctx, id)
+	}
+
+	_, err := r.queries.UpsertProductDefaultThreshold(
+		ctx,
+		generated.UpsertProductDefaultThresholdParams{
+			ProductID: id,
+			GraceDays: *graceDays,
+			UpdatedBy: updatedBy,
+		},
+	)
+	if err != nil {
+		return repository.Product{}, pkg.Errorf(
+			pkg.INTERNAL_ERROR,
+			"failed to update product default threshold: %s",
+			err.Error(),
+		)
+	}
+
+	return r.GetProductByID(ctx, id)
+}
+
+// products without a threshold use the system default grace days.
+func (r *ProductRepository) getProductDefaultGraceDays(
+	ctx context.Context,
+	id uint32,
+) (*uint32, error) {
+	threshold, err := r.queries.GetProductDefaultThreshold(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		return nil, pkg.Errorf(
+			pkg.INTERNAL_ERROR,
+			"failed to get product default threshold: %s",
+			err.Error(),
+		)
+	}
+
+	return &threshold.GraceDays, nil
+}
+
+// UpdateProductPenaltyRule sets how the product's overdue installments are penalised. A nil rule
+// stops new penalties, those already accrued stay owed.
+func (r *ProductRepository) UpdateProductPenaltyRule(
+	ctx context.Context,
+	id uint32,
+	rule *repository.PenaltyRule,
+	updatedBy uint32,
+) (repository.Product, error) {
+	if _, err := r.GetProductByID(ctx, id); err != nil {
+		return repository.Product{}, err
+	}
+
+	if rule == nil {
+		if err := r.queries.DeleteProductPenaltyRule(ctx, id); err != nil {
+			return repository.Product{}, pkg.Errorf(
+				pkg.INTERNAL_ERROR,
+				"failed to delete product penalty rule: %s",
+				err.Error(),
+			)
+		}
+
+		return r.GetProductByID(ctx, id)
+	}
+
+	penaltyType := generated.ProductPenaltyRulesPenaltyType(strings.ToUpper(rule.PenaltyType))
+	switch penaltyType {
+	case generated.ProductPenaltyRulesPenaltyTypeFLAT:
+	case generated.ProductPenaltyRulesPenaltyTypePERCENTAGE:
+		if rule.Rate > 100 {
+			return repository.Product{}, pkg.Errorf(
+				pkg.INVALID_ERROR,
+				"penalty percentage cannot be more than 100",
+			)
+		}
+	default:
+		return repository.Product{}, pkg.Errorf(
+			pkg.INVALID_ERROR,
+			"invalid penalty type: %s",
+			rule.PenaltyType,
+		)
+	}
+
+	frequency := generated.ProductPenaltyRulesFrequency(strings.ToUpper(rule.Frequency))
+	switch frequency {
+	case generated.ProductPenaltyRulesFrequencyDAILY, generated.ProductPenaltyRulesFrequencyWEEKLY:
+	default:
+		return repository.Product{}, pkg.Errorf(
+			pkg.INVALID_ERROR,
+			"invalid penalty frequency: %s",
+			rule.Frequency,
+		)
+	}
+
+	if rule.Rate <= 0 {
+		return repository.Product{}, pkg.Errorf(pkg.INVALID_ERROR, "penalty rate must be positive")
+	}
+
+	params := generated.UpsertProductPenaltyRuleParams{
+		ProductID:   id,
+		PenaltyType: penaltyType,
+		Rate:        rule.Rate,
+		Frequency:   frequency,
+		UpdatedBy:   updatedBy,
+	}
+
+	if rule.Cap != nil {
+		if *rule.Cap <= 0 {
+			return repository.Product{}, pkg.Errorf(
+				pkg.INVALID_ERROR,
+				"penalty cap must be positive",
+			)
+		}
+
+		params.CapAmount = sql.NullFloat64{
+			Valid:   true,
+			Float64: rule.Cap.Float64(),
+		}
+	}
+
+	if _, err := r.queries.UpsertProductPenaltyRule(ctx, params); err != nil {
+		return repository.Product{}, pkg.Errorf(
+			pkg.INTERNAL_ERROR,
+			"failed to update product penalty rule: %s",
+			err.Error(),
+		)
+	}
+
+	return r.GetProductByID(ctx, id)
+}
+
+// products without a rule do not penalise overdue installments.
+func (r *ProductRepository) getProductPenaltyRule(
+	ctx context.Context,
+	id uint32,
+) (*repository.PenaltyRule, error) {
+	rule, err := r.queries.GetProductPenaltyRule(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		return nil, pkg.Errorf(
+			pkg.INTERNAL_ERROR,
+			"failed to get product penalty rule: %s",
+			err.Error(),
+		)
+	}
+
+	penaltyRule := &repository.PenaltyRule{
+		PenaltyType: string(rule.PenaltyType),
+		Rate:        rule.Rate,
+		Frequency:   string(rule.Frequency),
+	}
+
+	if rule.CapAmount.Valid {
+		capAmount := pkg.MoneyFromFloat(rule.CapAmount.Float64)
+		penaltyRule.Cap = &capAmount
+	}
+
+	return penaltyRule, nil
+}
+
 func (r *ProductRepository) DeleteProduct(ctx context.Context, id uint32) error {
 	err := r.queries.DeleteProduct(ctx, id)
 	if err != nil {
@@ -252,9 +544,9 @@ func (r *ProductRepository) GetReportProductData(
 			ActiveLoans:       product.ActiveLoans,
 			CompletedLoans:    product.CompletedLoans,
 			DefaultedLoans:    product.DefaultedLoans,
-			AmountDisbursed:   pkg.InterfaceFloat64(product.TotalAmountDisbursed),
-			AmountRepaid:      pkg.InterfaceFloat64(product.TotalAmountRepaid),
-			OutstandingAmount: pkg.InterfaceFloat64(product.TotalOutstandingAmount),
+			AmountDisbursed:   pkg.InterfaceMoney(product.TotalAmountDisbursed).Float64(),
+			AmountRepaid:      pkg.InterfaceMoney(product.TotalAmountRepaid).Float64(),
+			OutstandingAmount: pkg.InterfaceMoney(product.TotalOutstandingAmount).Float64(),
 			DefaultRate:       pkg.InterfaceFloat64(product.DefaultRate),
 		}
 	}
@@ -273,9 +565,9 @@ func convertGeneratedProducts(product generated.Product) repository.Product {
 	return repository.Product{
 		ID:             product.ID,
 		BranchID:       product.BranchID,
-		LoanAmount:     product.LoanAmount,
-		RepayAmount:    product.RepayAmount,
-		InterestAmount: product.InterestAmount,
+		LoanAmount:     pkg.MoneyFromFloat(product.LoanAmount),
+		RepayAmount:    pkg.MoneyFromFloat(product.RepayAmount),
+		InterestAmount: pkg.MoneyFromFloat(product.InterestAmount),
 		UpdatedBy:      product.UpdatedBy,
 		UpdatedAt:      product.UpdatedAt,
 		CreatedAt:      product.CreatedAt,
@@ -3,6 +3,7 @@ package mysql
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"errors"
 	"testing"
 
@@ -29,32 +30,54 @@ func TestProductRepository_GetAllProducts(t *testing.T) {
 	mockQueries := mockdb.NewMockQuerier(ctrl)
 	r.queries = mockQueries
 
+	pgData := &pkg.PaginationMetadata{CurrentPage: 1, PageSize: 10}
+
 	tests := []struct {
 		name       string
 		buildStubs func(mockQueries *mockdb.MockQuerier)
 		wantErr    bool
 		err        error
 		wantResult []repository.Product
+		wantPgData pkg.PaginationMetadata
 	}{
 		{
 			name: "OK",
 			buildStubs: func(mockQueries *mockdb.MockQuerier) {
 				mockQueries.EXPECT().
-					ListProducts(gomock.Any(), gomock.Any()).
+					ListProductsByCategory(gomock.Any(), generated.ListProductsByCategoryParams{
+						Column1: "",
+						Limit:   10,
+						Offset:  0,
+					}).
 					Times(1).
-					Return([]generated.Product{
-						{ID: 42, BranchID: 1, LoanAmount: 1000},
+					Return([]generated.ListProductsByCategoryRow{
+						{ID: 42, BranchID: 1, BranchName: "test", LoanAmount: 1000},
 					}, nil)
+				mockQueries.EXPECT().
+					CountLoansByCategory(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(int64(1), nil)
+			},
+			wantErr: false,
+			err:     nil,
+			wantResult: []repository.Product{{
+				ID:         42,
+				BranchID:   1,
+				BranchName: pkg.StringPtr("test"),
+				LoanAmount: pkg.MoneyFromFloat(1000),
+			}},
+			wantPgData: pkg.PaginationMetadata{
+				CurrentPage: 1,
+				PageSize:    10,
+				TotalData:   1,
+				TotalPages:  1,
 			},
-			wantErr:    false,
-			err:        nil,
-			wantResult: []repository.Product{{ID: 42, BranchID: 1, LoanAmount: 1000}},
 		},
 		{
 			name: "Not Found",
 			buildStubs: func(mockQueries *mockdb.MockQuerier) {
 				mockQueries.EXPECT().
-					ListProducts(gomock.Any(), gomock.Any()).
+					ListProductsByCategory(gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(nil, sql.ErrNoRows)
 			},
@@ -65,7 +88,7 @@ func TestProductRepository_GetAllProducts(t *testing.T) {
 			name: "Internal Error",
 			buildStubs: func(mockQueries *mockdb.MockQuerier) {
 				mockQueries.EXPECT().
-					ListProducts(gomock.Any(), gomock.Any()).
+					ListProductsByCategory(gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(nil, errors.New("internal error"))
 			},
@@ -78,7 +101,7 @@ func TestProductRepository_GetAllProducts(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.buildStubs(mockQueries)
 
-			result, err := r.GetAllProducts(context.Background(), &pkg.PaginationMetadata{})
+			result, metadata, err := r.GetAllProducts(context.Background(), nil, pgData)
 
 			if tc.wantErr {
 				require.Error(t, err)
@@ -86,6 +109,7 @@ func TestProductRepository_GetAllProducts(t *testing.T) {
 			} else {
 				require.NoError(t, err)
 				require.Equal(t, tc.wantResult, result)
+				require.Equal(t, tc.wantPgData, metadata)
 			}
 		})
 	}
@@ -113,11 +137,29 @@ func TestProductRepository_GetProductByID(t *testing.T) {
 				mockQueries.EXPECT().
 					GetProduct(gomock.Any(), gomock.Any()).
 					Times(1).
-					Return(generated.Product{ID: 42, BranchID: 1, LoanAmount: 1000}, nil)
+					Return(generated.GetProductRow{ID: 42, BranchID: 1, BranchName: "test", LoanAmount: 1000}, nil)
+				mockQueries.EXPECT().
+					GetProductAllocationStrategy(gomock.Any(), uint32(42)).
+					Times(1).
+					Return(generated.ProductAllocationStrategy{}, sql.ErrNoRows)
+				mockQueries.EXPECT().
+					GetProductDefaultThreshold(gomock.Any(), uint32(42)).
+					Times(1).
+					Return(generated.ProductDefaultThreshold{}, sql.ErrNoRows)
+				mockQueries.EXPECT().
+					GetProductPenaltyRule(gomock.Any(), uint32(42)).
+					Times(1).
+					Return(generated.ProductPenaltyRule{}, sql.ErrNoRows)
+			},
+			wantErr: false,
+			err:     nil,
+			wantResult: repository.Product{
+				ID:                 42,
+				BranchID:           1,
+				BranchName:         pkg.StringPtr("test"),
+				LoanAmount:         pkg.MoneyFromFloat(1000),
+				AllocationStrategy: string(generated.ProductAllocationStrategiesStrategyOLDESTFIRST),
 			},
-			wantErr:    false,
-			err:        nil,
-			wantResult: repository.Product{ID: 42, BranchID: 1, LoanAmount: 1000},
 		},
 		{
 			name: "Not Found",
@@ -125,7 +167,7 @@ func TestProductRepository_GetProductByID(t *testing.T) {
 				mockQueries.EXPECT().
 					GetProduct(gomock.Any(), gomock.Any()).
 					Times(1).
-					Return(generated.Product{}, sql.ErrNoRows)
+					Return(generated.GetProductRow{}, sql.ErrNoRows)
 			},
 			wantErr: true,
 			err:     errors.New(pkg.NOT_FOUND_ERROR),
@@ -136,7 +178,7 @@ func TestProductRepository_GetProductByID(t *testing.T) {
 				mockQueries.EXPECT().
 					GetProduct(gomock.Any(), gomock.Any()).
 					Times(1).
-					Return(generated.Product{}, errors.New("internal error"))
+					Return(generated.GetProductRow{}, errors.New("internal error"))
 			},
 			wantErr: true,
 			err:     errors.New(pkg.INTERNAL_ERROR),
@@ -188,7 +230,7 @@ func TestProductRepository_ListProductByBranch(t *testing.T) {
 			},
 			wantErr:    false,
 			err:        nil,
-			wantResult: []repository.Product{{ID: 42, BranchID: 1, LoanAmount: 1000}},
+			wantResult: []repository.Product{{ID: 42, BranchID: 1, LoanAmount: pkg.MoneyFromFloat(1000)}},
 		},
 		{
 			name: "Not Found",
@@ -254,13 +296,19 @@ func TestProductRepository_CreateProduct(t *testing.T) {
 					CreateProduct(gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(&mockSQLResult{lastInsertID: 1, rowsAffected: 1}, nil)
+				mockQueries.EXPECT().
+					GetBranch(gomock.Any(), uint32(1)).
+					Times(1).
+					Return(generated.Branch{ID: 1, Name: "test"}, nil)
 			},
 			wantErr: false,
 			err:     nil,
 			wantResult: repository.Product{
-				ID:         1,
-				BranchID:   1,
-				LoanAmount: 1000,
+				ID:                 1,
+				BranchID:           1,
+				BranchName:         pkg.StringPtr("test"),
+				LoanAmount:         pkg.MoneyFromFloat(1000),
+				AllocationStrategy: string(generated.ProductAllocationStrategiesStrategyOLDESTFIRST),
 			},
 		},
 		{
@@ -282,7 +330,7 @@ func TestProductRepository_CreateProduct(t *testing.T) {
 
 			product, err := r.CreateProduct(context.Background(), &repository.Product{
 				BranchID:   1,
-				LoanAmount: 1000,
+				LoanAmount: pkg.MoneyFromFloat(1000),
 			})
 
 			if tc.wantErr {
@@ -374,3 +422,45 @@ func (m *mockSQLResult) LastInsertId() (int64, error) {
 func (m *mockSQLResult) RowsAffected() (int64, error) {
 	return m.rowsAffected, nil
 }
+
+// mockTxConnector opens connections whose transactions do nothing, so repository methods that
+// run in a transaction can be tested with the mock querier.
+type mockTxConnector struct{}
+
+func (mockTxConnector) Connect(context.Context) (driver.Conn, error) {
+	return mockTxConn{}, nil
+}
+
+func (mockTxConnector) Driver() driver.Driver {
+	return nil
+}
+
+type mockTxConn struct{}
+
+func (mockTxConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("statements go through the mock querier")
+}
+
+func (mockTxConn) Close() error {
+	return nil
+}
+
+func (mockTxConn) Begin() (driver.Tx, error) {
+	return mockTxConn{}, nil
+}
+
+func (mockTxConn) Commit() error {
+	return nil
+}
+
+func (mockTxConn) Rollback() error {
+	return nil
+}
+
+// useMockTx makes the store's transactions run their queries on mockQueries.
+func useMockTx(store *Store, mockQueries generated.Querier) {
+	store.db = sql.OpenDB(mockTxConnector{})
+	store.NewQuerierFn = func(*sql.Tx) generated.Querier {
+		return mockQueries
+	}
+}
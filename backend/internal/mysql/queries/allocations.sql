@@ -1,6 +1,6 @@
 -- name: CreatePaymentAllocation :execresult
-INSERT INTO payment_allocations (non_posted_id, loan_id, installment_id, amount, description)
-VALUES (sqlc.arg("non_posted_id"), sqlc.narg("loan_id"), sqlc.narg("installment_id"), sqlc.arg("amount"), sqlc.arg("description"));
+INSERT INTO payment_allocations (non_posted_id, loan_id, installment_id, penalty_id, amount, description)
+VALUES (sqlc.arg("non_posted_id"), sqlc.narg("loan_id"), sqlc.narg("installment_id"), sqlc.narg("penalty_id"), sqlc.arg("amount"), sqlc.arg("description"));
 
 -- name: ListPaymentAllocationsByNonPostedId :many
 SELECT * FROM payment_allocations WHERE non_posted_id = sqlc.arg("non_posted_id") AND deleted_at IS NULL;
@@ -0,0 +1,25 @@
+-- name: CreateAssignmentRule :execresult
+INSERT INTO assignment_rules (name, rule_type, priority, active, prefix, days_window, min_name_similarity, created_by)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: GetAssignmentRule :one
+SELECT * FROM assignment_rules WHERE id = ? LIMIT 1;
+
+-- name: ListAssignmentRules :many
+SELECT * FROM assignment_rules ORDER BY priority, id;
+
+-- name: ListActiveAssignmentRules :many
+SELECT * FROM assignment_rules WHERE active = TRUE ORDER BY priority, id;
+
+-- name: UpdateAssignmentRule :execresult
+UPDATE assignment_rules
+    SET name = ?,
+    priority = ?,
+    active = ?,
+    prefix = ?,
+    days_window = ?,
+    min_name_similarity = ?
+WHERE id = ?;
+
+-- name: DeleteAssignmentRule :exec
+DELETE FROM assignment_rules WHERE id = ?;
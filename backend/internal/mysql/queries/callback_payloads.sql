@@ -0,0 +1,5 @@
+-- name: CreateCallbackPayload :execresult
+INSERT INTO callback_payloads (transaction_number, callback_type, payload) VALUES (?, ?, ?);
+
+-- name: ListCallbackPayloadsByTransactionNumber :many
+SELECT * FROM callback_payloads WHERE transaction_number = ? ORDER BY created_at ASC;
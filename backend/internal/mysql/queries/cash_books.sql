@@ -0,0 +1,83 @@
+-- name: CreateBranchExpense :execresult
+INSERT INTO branch_expenses (branch_id, expense_date, amount, description, created_by)
+VALUES (?, ?, ?, ?, ?);
+
+-- name: ListBranchExpenses :many
+SELECT id, branch_id, expense_date, amount, description, created_by, created_at FROM branch_expenses
+WHERE branch_id = ? AND expense_date >= ? AND expense_date < ?
+ORDER BY expense_date, id;
+
+-- name: ListBranchCashReceipts :many
+SELECT 
+    np.id, 
+    np.transaction_number, 
+    np.paying_name, 
+    np.amount, 
+    np.paid_date, 
+    np.assigned_by
+FROM non_posted np
+JOIN users u ON np.assigned_by = u.email
+WHERE u.branch_id = ?
+    AND np.cash_receipt = TRUE
+    AND np.deleted_at IS NULL
+    AND np.paid_date >= ?
+    AND np.paid_date < ?
+ORDER BY np.paid_date, np.id;
+
+-- name: ListBranchCashDisbursements :many
+SELECT 
+    l.id, 
+    c.full_name AS client_name, 
+    COALESCE(lt.net_disbursed, p.loan_amount) - IF(l.fee_paid, l.processing_fee, 0) AS cash_paid, 
+    l.disbursed_on, 
+    u.full_name AS disbursed_by_name
+FROM loans l
+JOIN users u ON l.disbursed_by = u.id
+JOIN clients c ON l.client_id = c.id
+JOIN products p ON l.product_id = p.id
+LEFT JOIN loan_top_ups lt ON lt.loan_id = l.id
+WHERE u.branch_id = ?
+    AND l.disbursed_on >= ?
+    AND l.disbursed_on < ?
+    AND NOT EXISTS (SELECT 1 FROM loan_disbursements ld WHERE ld.loan_id = l.id)
+ORDER BY l.disbursed_on, l.id;
+
+-- name: GetLatestCashBookClose :one
+SELECT id, branch_id, business_date, opening_balance, cash_received, cash_disbursed, expenses, expected_closing, counted_cash, closed_by, closed_at FROM cash_book_closes
+WHERE branch_id = ? AND business_date < ?
+ORDER BY business_date DESC
+LIMIT 1;
+
+-- name: GetCashBookClose :one
+SELECT id, branch_id, business_date, opening_balance, cash_received, cash_disbursed, expenses, expected_closing, counted_cash, closed_by, closed_at FROM cash_book_closes WHERE branch_id = ? AND business_date = ? LIMIT 1;
+
+-- name: CheckCashBookClosed :one
+SELECT EXISTS (
+    SELECT 1 FROM cash_book_closes WHERE branch_id = ? AND business_date >= ?
+) AS closed;
+
+-- name: CreateCashBookClose :execresult
+INSERT INTO cash_book_closes (
+    branch_id, 
+    business_date, 
+    opening_balance, 
+    cash_received, 
+    cash_disbursed, 
+    expenses, 
+    expected_closing, 
+    counted_cash, 
+    closed_by
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: CreateCashVariance :execresult
+INSERT INTO cash_variances (close_id, branch_id, business_date, expected_cash, counted_cash, variance, note, created_by)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: ListCashVariances :many
+SELECT id, close_id, branch_id, business_date, expected_cash, counted_cash, variance, note, created_by, created_at FROM cash_variances
+WHERE branch_id = ?
+ORDER BY business_date DESC
+LIMIT ? OFFSET ?;
+
+-- name: CountCashVariances :one
+SELECT COUNT(*) AS total_variances FROM cash_variances WHERE branch_id = ?;
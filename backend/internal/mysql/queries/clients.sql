@@ -183,4 +183,21 @@ WHERE
     )
     AND (
         sqlc.narg('active') IS NULL OR c.active = sqlc.narg('active')
-    );
\ No newline at end of file
+    );
+-- name: ListClientsByPhoneNumbers :many
+SELECT * FROM clients
+WHERE phone_number IN (
+    sqlc.arg("local_phone"),
+    sqlc.arg("international_phone"),
+    sqlc.arg("plus_phone"),
+    sqlc.arg("short_phone")
+);
+
+-- name: GetClientByIDNumber :one
+SELECT * FROM clients WHERE id_number = ? LIMIT 1;
+
+-- name: ListClientNames :many
+SELECT id, full_name FROM clients;
+
+-- name: LockClient :one
+SELECT id FROM clients WHERE id = ? LIMIT 1 FOR UPDATE;
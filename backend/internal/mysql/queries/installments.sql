@@ -11,7 +11,7 @@ SELECT * FROM installments WHERE id = ? LIMIT 1;
 SELECT * FROM installments WHERE loan_id = ? ORDER BY due_date ASC;
 
 -- name: ListUnpaidInstallmentsByLoan :many
-SELECT * FROM installments WHERE loan_id = ? AND remaining_amount > 0 ORDER BY due_date ASC;
+SELECT * FROM installments WHERE loan_id = ? AND remaining_amount > 0 AND closed_at IS NULL ORDER BY due_date ASC;
 
 -- name: UpdateInstallment :execresult
 UPDATE installments 
@@ -61,6 +61,7 @@ JOIN branches b ON p.branch_id = b.id
 
 WHERE 
     (i.paid = FALSE OR i.remaining_amount > 0) 
+    AND i.closed_at IS NULL
     AND i.due_date <= CURDATE()
     AND (
         COALESCE(?, '') = '' 
@@ -79,9 +80,27 @@ JOIN clients c ON l.client_id = c.id
 
 WHERE 
     (i.paid = FALSE OR i.remaining_amount > 0) 
+    AND i.closed_at IS NULL
     AND i.due_date <= CURDATE()
     AND (
         COALESCE(?, '') = '' 
         OR LOWER(c.full_name) LIKE ?
         OR LOWER(c.phone_number) LIKE ?
-    );
\ No newline at end of file
+    );
+-- name: ListDueInstallmentsByAmount :many
+SELECT i.id, i.loan_id, l.client_id, i.remaining_amount, i.due_date
+FROM installments i
+JOIN loans l ON i.loan_id = l.id
+WHERE l.status = 'ACTIVE'
+    AND i.paid = FALSE
+    AND i.closed_at IS NULL
+    AND i.remaining_amount BETWEEN sqlc.arg("min_amount") AND sqlc.arg("max_amount")
+    AND i.due_date BETWEEN sqlc.arg("from_date") AND sqlc.arg("to_date")
+ORDER BY i.due_date;
+
+-- name: LockLoanInstallments :many
+SELECT id FROM installments WHERE loan_id = ? ORDER BY id FOR UPDATE;
+
+-- name: CountOverdueInstallments :one
+SELECT COUNT(*) AS overdue_installments FROM installments 
+WHERE loan_id = ? AND paid = FALSE AND closed_at IS NULL AND due_date < ?;
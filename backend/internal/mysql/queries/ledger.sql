@@ -0,0 +1,74 @@
+-- name: CreateJournalEntry :execresult
+INSERT INTO journal_entries (entry_type, description, loan_id, client_id, non_posted_id, created_by)
+VALUES (?, ?, ?, ?, ?, ?);
+
+-- name: CreateJournalLine :execresult
+INSERT INTO journal_lines (entry_id, account_id, debit, credit)
+SELECT sqlc.arg("entry_id"), id, sqlc.arg("debit"), sqlc.arg("credit") FROM ledger_accounts WHERE code = sqlc.arg("code");
+
+-- name: ListLedgerAccounts :many
+SELECT * FROM ledger_accounts ORDER BY code;
+
+-- name: GetLedgerAccountByCode :one
+SELECT * FROM ledger_accounts WHERE code = ? LIMIT 1;
+
+-- name: GetTrialBalance :many
+SELECT 
+    a.code, 
+    a.name, 
+    a.account_type, 
+    COALESCE(SUM(l.debit), 0) AS total_debit, 
+    COALESCE(SUM(l.credit), 0) AS total_credit
+FROM ledger_accounts a
+LEFT JOIN (
+    journal_lines l 
+    JOIN journal_entries e ON l.entry_id = e.id AND e.created_at < sqlc.arg("as_of")
+) ON l.account_id = a.id
+GROUP BY a.id, a.code, a.name, a.account_type
+ORDER BY a.code;
+
+-- name: ListAccountLedger :many
+SELECT 
+    t.line_id, 
+    t.entry_id, 
+    t.entry_type, 
+    t.description, 
+    t.loan_id, 
+    t.client_id, 
+    t.non_posted_id, 
+    t.created_by, 
+    t.created_at, 
+    t.debit, 
+    t.credit, 
+    t.running_balance
+FROM (
+    SELECT 
+        l.id AS line_id, 
+        e.id AS entry_id, 
+        e.entry_type, 
+        e.description, 
+        e.loan_id, 
+        e.client_id, 
+        e.non_posted_id, 
+        e.created_by, 
+        e.created_at, 
+        l.debit, 
+        l.credit,
+        SUM(l.debit - l.credit) OVER (ORDER BY e.created_at, l.id) AS running_balance
+    FROM journal_lines l
+    JOIN journal_entries e ON l.entry_id = e.id
+    JOIN ledger_accounts a ON l.account_id = a.id
+    WHERE a.code = sqlc.arg("code") AND e.created_at < sqlc.arg("to_date")
+) t
+WHERE t.created_at >= sqlc.arg("from_date")
+ORDER BY t.created_at, t.line_id
+LIMIT ? OFFSET ?;
+
+-- name: CountAccountLedger :one
+SELECT COUNT(*) AS total_lines
+FROM journal_lines l
+JOIN journal_entries e ON l.entry_id = e.id
+JOIN ledger_accounts a ON l.account_id = a.id
+WHERE a.code = sqlc.arg("code") 
+    AND e.created_at >= sqlc.arg("from_date") 
+    AND e.created_at < sqlc.arg("to_date");
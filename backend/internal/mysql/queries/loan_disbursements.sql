@@ -0,0 +1,21 @@
+-- name: CreateLoanDisbursement :execresult
+INSERT INTO loan_disbursements (loan_id, phone_number, amount, fee_deducted, conversation_id, originator_conversation_id, initiated_by)
+VALUES (?, ?, ?, ?, ?, ?, ?);
+
+-- name: GetLoanDisbursementByConversationID :one
+SELECT * FROM loan_disbursements WHERE conversation_id = ? LIMIT 1;
+
+-- name: UpdateLoanDisbursementResult :execresult
+UPDATE loan_disbursements
+    SET status = sqlc.arg("status"),
+    result_code = sqlc.arg("result_code"),
+    result_desc = sqlc.arg("result_desc"),
+    transaction_id = sqlc.narg("transaction_id"),
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = sqlc.arg("id");
+
+-- name: ListLoanDisbursementsByLoan :many
+SELECT * FROM loan_disbursements WHERE loan_id = ? ORDER BY created_at DESC;
+
+-- name: LockLoanDisbursementByConversationID :one
+SELECT * FROM loan_disbursements WHERE conversation_id = ? LIMIT 1 FOR UPDATE;
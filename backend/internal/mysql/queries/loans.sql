@@ -35,7 +35,7 @@ WHERE id = sqlc.arg("id");
 -- name: ReduceLoan :execresult
 UPDATE loans 
     SET paid_amount = paid_amount - sqlc.arg("paid_amount"),
-    status = 'ACTIVE',
+    status = IF(status = 'DEFAULTED', 'DEFAULTED', 'ACTIVE'),
     updated_by = sqlc.arg("updated_by")
 WHERE id = sqlc.arg("id");
 
@@ -398,4 +398,15 @@ FROM loans l
 JOIN products p ON l.product_id = p.id
 WHERE 
     l.id = ?
-LIMIT 1;
\ No newline at end of file
+LIMIT 1;
+-- name: LockLoan :one
+SELECT id FROM loans WHERE id = ? LIMIT 1 FOR UPDATE;
+
+-- name: MarkLoanDefaulted :execresult
+UPDATE loans SET status = 'DEFAULTED' WHERE id = ? AND status = 'ACTIVE';
+
+-- name: GetClientPayableLoan :one
+SELECT id FROM loans 
+WHERE client_id = ? AND status IN ('ACTIVE', 'DEFAULTED')
+ORDER BY status = 'DEFAULTED' DESC, id
+LIMIT 1;
@@ -1,5 +1,5 @@
 -- name: CreateNonPosted :execresult
-INSERT INTO non_posted (transaction_source, transaction_number, account_number, phone_number, paying_name, amount, paid_date, assign_to, assigned_by) 
+INSERT INTO non_posted (transaction_source, transaction_number, account_number, phone_number, paying_name, amount, paid_date, assign_to, assigned_by, cash_receipt) 
 VALUES (
     sqlc.arg("transaction_source"),
     sqlc.arg("transaction_number"),
@@ -9,7 +9,8 @@ VALUES (
     sqlc.arg("amount"),
     sqlc.arg("paid_date"),
     sqlc.narg("assign_to"),
-    sqlc.arg("assigned_by")
+    sqlc.arg("assigned_by"),
+    sqlc.arg("cash_receipt")
 );
 
 -- name: UpdateNonPosted :execresult
@@ -149,4 +150,33 @@ WHERE
         COALESCE(?, '') = '' 
         OR FIND_IN_SET(transaction_source, ?) > 0
     )
-    AND paid_date BETWEEN ? AND ?;
\ No newline at end of file
+    AND paid_date BETWEEN ? AND ?;
+
+-- name: ListDuplicateNonPosted :many
+SELECT np.* FROM non_posted np
+JOIN (
+    SELECT transaction_number, transaction_source
+    FROM non_posted
+    WHERE deleted_at IS NULL
+    GROUP BY transaction_number, transaction_source
+    HAVING COUNT(*) > 1
+) d ON np.transaction_number = d.transaction_number AND np.transaction_source = d.transaction_source
+WHERE np.deleted_at IS NULL
+ORDER BY np.transaction_number, np.id;
+
+-- name: ListMpesaNonPostedByPaidDate :many
+SELECT * FROM non_posted
+WHERE transaction_source = 'MPESA'
+    AND deleted_at IS NULL
+    AND paid_date BETWEEN sqlc.arg("from_date") AND sqlc.arg("to_date")
+ORDER BY paid_date;
+
+-- name: ListUnassignedNonPostedForRules :many
+SELECT * FROM non_posted
+WHERE assign_to IS NULL
+    AND deleted_at IS NULL
+ORDER BY paid_date DESC
+LIMIT ?;
+
+-- name: LockNonPosted :one
+SELECT * FROM non_posted WHERE id = ? LIMIT 1 FOR UPDATE;
@@ -0,0 +1,42 @@
+-- name: CreateOverpaymentRefund :execresult
+INSERT INTO overpayment_refunds (client_id, amount, reason, payout_method, payout_phone, requested_by)
+VALUES (?, ?, ?, ?, ?, ?);
+
+-- name: GetOverpaymentRefund :one
+SELECT * FROM overpayment_refunds WHERE id = ? LIMIT 1;
+
+-- name: GetOverpaymentRefundByConversationID :one
+SELECT * FROM overpayment_refunds WHERE conversation_id = ? LIMIT 1;
+
+-- name: ListOverpaymentRefunds :many
+SELECT * FROM overpayment_refunds ORDER BY created_at DESC LIMIT ? OFFSET ?;
+
+-- name: CountOverpaymentRefunds :one
+SELECT COUNT(*) AS total_refunds FROM overpayment_refunds;
+
+-- name: ListOverpaymentRefundsByStatus :many
+SELECT * FROM overpayment_refunds WHERE status = ? ORDER BY created_at DESC LIMIT ? OFFSET ?;
+
+-- name: CountOverpaymentRefundsByStatus :one
+SELECT COUNT(*) AS total_refunds FROM overpayment_refunds WHERE status = ?;
+
+-- name: ReviewOverpaymentRefund :execresult
+UPDATE overpayment_refunds
+    SET status = ?,
+    reviewed_by = ?,
+    review_note = ?,
+    reviewed_at = CURRENT_TIMESTAMP
+WHERE id = ?;
+
+-- name: UpdateOverpaymentRefundPayout :execresult
+UPDATE overpayment_refunds
+    SET status = ?,
+    payout_reference = ?,
+    conversation_id = ?
+WHERE id = ?;
+
+-- name: LockOverpaymentRefund :one
+SELECT * FROM overpayment_refunds WHERE id = ? LIMIT 1 FOR UPDATE;
+
+-- name: LockOverpaymentRefundByConversationID :one
+SELECT * FROM overpayment_refunds WHERE conversation_id = ? LIMIT 1 FOR UPDATE;
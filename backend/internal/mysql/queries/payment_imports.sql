@@ -0,0 +1,47 @@
+-- name: CreatePaymentImportBatch :execresult
+INSERT INTO payment_import_batches (file_name, total_rows, valid_rows, uploaded_by)
+VALUES (?, ?, ?, ?);
+
+-- name: GetPaymentImportBatch :one
+SELECT * FROM payment_import_batches WHERE id = ? LIMIT 1;
+
+-- name: ListPaymentImportBatches :many
+SELECT * FROM payment_import_batches ORDER BY created_at DESC, id DESC LIMIT ? OFFSET ?;
+
+-- name: CountPaymentImportBatches :one
+SELECT COUNT(*) AS total_batches FROM payment_import_batches;
+
+-- name: ClaimPaymentImportBatch :execresult
+UPDATE payment_import_batches SET status = 'POSTING' WHERE id = ? AND status IN ('PREVIEW', 'FAILED');
+
+-- name: MarkPaymentImportBatchPosted :execresult
+UPDATE payment_import_batches
+    SET status = 'POSTED',
+    posted_rows = ?,
+    posted_by = ?,
+    posted_at = CURRENT_TIMESTAMP
+WHERE id = ?;
+
+-- name: MarkPaymentImportBatchFailed :execresult
+UPDATE payment_import_batches SET status = 'FAILED' WHERE id = ? AND status = 'POSTING';
+
+-- name: RollbackPaymentImportBatch :execresult
+UPDATE payment_import_batches
+    SET status = 'ROLLED_BACK',
+    rolled_back_by = ?,
+    rollback_reason = ?,
+    rolled_back_at = CURRENT_TIMESTAMP
+WHERE id = ?;
+
+-- name: CreatePaymentImportRow :execresult
+INSERT INTO payment_import_rows (batch_id, line_number, identifier, client_id, amount, paid_date, reference, error)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: ListPaymentImportRows :many
+SELECT * FROM payment_import_rows WHERE batch_id = ? ORDER BY line_number;
+
+-- name: UpdatePaymentImportRowResult :execresult
+UPDATE payment_import_rows SET non_posted_id = ?, error = ? WHERE id = ?;
+
+-- name: MarkPaymentImportRowRolledBack :execresult
+UPDATE payment_import_rows SET rolled_back = TRUE, error = '' WHERE id = ?;
@@ -0,0 +1,9 @@
+-- name: CreatePaymentReassignment :execresult
+INSERT INTO payment_reassignments (non_posted_id, from_client_id, to_client_id, from_loan_id, to_loan_id, amount, reason, reassigned_by)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: GetPaymentReassignment :one
+SELECT * FROM payment_reassignments WHERE id = ? LIMIT 1;
+
+-- name: ListPaymentReassignmentsByNonPosted :many
+SELECT * FROM payment_reassignments WHERE non_posted_id = ? ORDER BY created_at DESC, id DESC;
@@ -0,0 +1,24 @@
+-- name: CreatePaymentSplit :execresult
+INSERT INTO payment_splits (non_posted_id, created_by)
+VALUES (?, ?);
+
+-- name: GetPaymentSplit :one
+SELECT * FROM payment_splits WHERE id = ? LIMIT 1;
+
+-- name: GetActivePaymentSplitByNonPosted :one
+SELECT * FROM payment_splits WHERE non_posted_id = ? AND status = 'ACTIVE' LIMIT 1;
+
+-- name: ReversePaymentSplit :execresult
+UPDATE payment_splits
+    SET status = 'REVERSED',
+    reversed_by = ?,
+    reversal_reason = ?,
+    reversed_at = CURRENT_TIMESTAMP
+WHERE id = ?;
+
+-- name: CreatePaymentSplitPortion :execresult
+INSERT INTO payment_split_portions (split_id, client_id, loan_id, amount)
+VALUES (?, ?, ?, ?);
+
+-- name: ListPaymentSplitPortions :many
+SELECT * FROM payment_split_portions WHERE split_id = ? ORDER BY id;
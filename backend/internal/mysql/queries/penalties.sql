@@ -0,0 +1,67 @@
+-- name: UpsertProductPenaltyRule :execresult
+INSERT INTO product_penalty_rules (product_id, penalty_type, rate, frequency, cap_amount, updated_by)
+VALUES (sqlc.arg("product_id"), sqlc.arg("penalty_type"), sqlc.arg("rate"), sqlc.arg("frequency"), sqlc.narg("cap_amount"), sqlc.arg("updated_by"))
+ON DUPLICATE KEY UPDATE
+    penalty_type = VALUES(penalty_type),
+    rate = VALUES(rate),
+    frequency = VALUES(frequency),
+    cap_amount = VALUES(cap_amount),
+    updated_by = VALUES(updated_by);
+
+-- name: GetProductPenaltyRule :one
+SELECT * FROM product_penalty_rules WHERE product_id = ? LIMIT 1;
+
+-- name: DeleteProductPenaltyRule :exec
+DELETE FROM product_penalty_rules WHERE product_id = ?;
+
+-- name: ListInstallmentsForPenaltyAccrual :many
+SELECT 
+    i.id AS installment_id,
+    i.loan_id,
+    l.client_id,
+    i.due_date,
+    i.remaining_amount,
+    r.penalty_type,
+    r.rate,
+    r.frequency,
+    r.cap_amount,
+    CAST(COALESCE(SUM(p.periods), 0) AS SIGNED) AS charged_periods,
+    COALESCE(SUM(p.amount), 0) AS charged_amount
+FROM installments i
+JOIN loans l ON i.loan_id = l.id
+JOIN product_penalty_rules r ON r.product_id = l.product_id
+LEFT JOIN installment_penalties p ON p.installment_id = i.id
+WHERE i.paid = FALSE
+    AND i.remaining_amount > 0
+    AND i.closed_at IS NULL
+    AND i.due_date < sqlc.arg("as_of")
+    AND l.status IN ('ACTIVE', 'DEFAULTED')
+GROUP BY i.id, i.loan_id, l.client_id, i.due_date, i.remaining_amount, r.penalty_type, r.rate, r.frequency, r.cap_amount
+ORDER BY i.loan_id, i.due_date;
+
+-- name: CreateInstallmentPenalty :execresult
+INSERT INTO installment_penalties (loan_id, installment_id, accrued_on, periods, amount, remaining_amount)
+VALUES (sqlc.arg("loan_id"), sqlc.arg("installment_id"), sqlc.arg("accrued_on"), sqlc.arg("periods"), sqlc.arg("amount"), sqlc.arg("amount"));
+
+-- name: ListPenaltiesByLoan :many
+SELECT * FROM installment_penalties WHERE loan_id = ? ORDER BY accrued_on, id;
+
+-- name: ListUnpaidPenaltiesByLoan :many
+SELECT * FROM installment_penalties WHERE loan_id = ? AND remaining_amount > 0 ORDER BY accrued_on, id;
+
+-- name: CountUnpaidPenaltiesByLoan :one
+SELECT COUNT(*) AS unpaid_penalties FROM installment_penalties WHERE loan_id = ? AND remaining_amount > 0;
+
+-- name: PayInstallmentPenalty :execresult
+UPDATE installment_penalties 
+    SET remaining_amount = sqlc.arg("remaining_amount"),
+    paid = coalesce(sqlc.narg("paid"), paid),
+    paid_at = coalesce(sqlc.narg("paid_at"), paid_at)
+WHERE id = sqlc.arg("id");
+
+-- name: RevertInstallmentPenalty :execresult
+UPDATE installment_penalties 
+    SET remaining_amount = remaining_amount + sqlc.arg("remaining_amount"),
+    paid = FALSE,
+    paid_at = NULL
+WHERE id = sqlc.arg("id");
@@ -0,0 +1,17 @@
+-- name: CreateProcessedCallback :execresult
+INSERT INTO processed_callbacks (transaction_number, transaction_source, non_posted_id, loan_id)
+VALUES (
+    sqlc.arg("transaction_number"),
+    sqlc.arg("transaction_source"),
+    sqlc.arg("non_posted_id"),
+    sqlc.narg("loan_id")
+);
+
+-- name: GetProcessedCallback :one
+SELECT * FROM processed_callbacks WHERE transaction_number = ? AND transaction_source = ? LIMIT 1;
+
+-- name: DeleteProcessedCallbackByNonPosted :execresult
+DELETE FROM processed_callbacks WHERE non_posted_id = ?;
+
+-- name: UpdateProcessedCallbackTransaction :execresult
+UPDATE processed_callbacks SET transaction_number = ?, transaction_source = ? WHERE non_posted_id = ?;
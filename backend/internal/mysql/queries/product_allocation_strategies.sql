@@ -0,0 +1,18 @@
+-- name: UpsertProductAllocationStrategy :execresult
+INSERT INTO product_allocation_strategies (product_id, strategy, updated_by)
+VALUES (?, ?, ?)
+ON DUPLICATE KEY UPDATE strategy = VALUES(strategy), updated_by = VALUES(updated_by);
+
+-- name: GetProductAllocationStrategy :one
+SELECT * FROM product_allocation_strategies WHERE product_id = ? LIMIT 1;
+
+-- name: GetLoanAllocationTerms :one
+SELECT 
+    p.repay_amount,
+    p.interest_amount,
+    CAST(COALESCE(s.strategy, 'OLDEST_FIRST') AS CHAR) AS strategy
+FROM loans l
+JOIN products p ON l.product_id = p.id
+LEFT JOIN product_allocation_strategies s ON s.product_id = p.id
+WHERE l.id = ?
+LIMIT 1;
@@ -0,0 +1,31 @@
+-- name: UpsertProductDefaultThreshold :execresult
+INSERT INTO product_default_thresholds (product_id, grace_days, updated_by)
+VALUES (?, ?, ?)
+ON DUPLICATE KEY UPDATE grace_days = VALUES(grace_days), updated_by = VALUES(updated_by);
+
+-- name: GetProductDefaultThreshold :one
+SELECT * FROM product_default_thresholds WHERE product_id = ? LIMIT 1;
+
+-- name: DeleteProductDefaultThreshold :exec
+DELETE FROM product_default_thresholds WHERE product_id = ?;
+
+-- name: ListLoansPastDefaultThreshold :many
+SELECT 
+    l.id,
+    l.client_id,
+    c.full_name AS client_name,
+    l.loan_officer,
+    u.full_name AS officer_name,
+    u.email AS officer_email,
+    l.due_date,
+    COALESCE(SUM(i.remaining_amount), 0) AS arrears
+FROM loans l
+JOIN clients c ON l.client_id = c.id
+JOIN users u ON l.loan_officer = u.id
+JOIN installments i ON i.loan_id = l.id AND i.paid = FALSE AND i.closed_at IS NULL
+LEFT JOIN product_default_thresholds t ON t.product_id = l.product_id
+WHERE l.status = 'ACTIVE'
+    AND l.due_date IS NOT NULL
+    AND DATE_ADD(l.due_date, INTERVAL COALESCE(t.grace_days, sqlc.arg("default_grace_days")) DAY) < sqlc.arg("as_of")
+GROUP BY l.id, l.client_id, c.full_name, l.loan_officer, u.full_name, u.email, l.due_date
+ORDER BY l.due_date, l.id;
@@ -0,0 +1,25 @@
+-- name: CreateStatementReconciliation :execresult
+INSERT INTO statement_reconciliations (statement_date, file_name, total_lines, matched, missing_callbacks, amount_mismatches, not_on_statement, uploaded_by)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: GetStatementReconciliation :one
+SELECT * FROM statement_reconciliations WHERE id = ? LIMIT 1;
+
+-- name: ListStatementReconciliations :many
+SELECT * FROM statement_reconciliations ORDER BY created_at DESC LIMIT ? OFFSET ?;
+
+-- name: CountStatementReconciliations :one
+SELECT COUNT(*) AS total_reconciliations FROM statement_reconciliations;
+
+-- name: CreateStatementReconciliationItem :execresult
+INSERT INTO statement_reconciliation_items (reconciliation_id, item_type, transaction_number, statement_amount, recorded_amount, non_posted_id, account_number, phone_number, paying_name, paid_date)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: ListStatementReconciliationItems :many
+SELECT * FROM statement_reconciliation_items WHERE reconciliation_id = ? ORDER BY id;
+
+-- name: MarkStatementReconciliationItemImported :execresult
+UPDATE statement_reconciliation_items
+    SET imported_non_posted_id = sqlc.arg("imported_non_posted_id"),
+    imported_at = CURRENT_TIMESTAMP
+WHERE id = sqlc.arg("id");
@@ -0,0 +1,26 @@
+-- name: CreateLoanRestructure :execresult
+INSERT INTO loan_restructures (loan_id, outstanding_amount, previous_total_installments, previous_installments_period, previous_due_date, total_installments, installments_period, reason, approved_by)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: ListLoanRestructures :many
+SELECT * FROM loan_restructures WHERE loan_id = ? ORDER BY created_at, id;
+
+-- name: CloseUnpaidInstallments :execresult
+UPDATE installments 
+    SET closed_at = CURRENT_TIMESTAMP,
+    restructure_id = sqlc.arg("restructure_id")
+WHERE loan_id = sqlc.arg("loan_id") AND remaining_amount > 0 AND closed_at IS NULL;
+
+-- name: GetLoanInstallmentCounts :one
+SELECT 
+    CAST(COALESCE(MAX(installment_number), 0) AS SIGNED) AS last_installment_number,
+    CAST(COUNT(CASE WHEN paid = TRUE THEN 1 END) AS SIGNED) AS paid_installments
+FROM installments WHERE loan_id = ?;
+
+-- name: UpdateLoanSchedule :execresult
+UPDATE loans 
+    SET total_installments = sqlc.arg("total_installments"),
+    installments_period = sqlc.arg("installments_period"),
+    due_date = sqlc.arg("due_date"),
+    updated_by = sqlc.arg("updated_by")
+WHERE id = sqlc.arg("id");
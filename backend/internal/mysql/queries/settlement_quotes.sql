@@ -0,0 +1,17 @@
+-- name: CreateLoanSettlementQuote :execresult
+INSERT INTO loan_settlement_quotes (loan_id, outstanding_amount, penalties_amount, unearned_interest, rebate_percent, rebate_amount, quoted_amount, expires_at, quoted_by)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: GetLoanSettlementQuote :one
+SELECT * FROM loan_settlement_quotes WHERE id = ? LIMIT 1;
+
+-- name: ListLoanSettlementQuotes :many
+SELECT * FROM loan_settlement_quotes WHERE loan_id = ? ORDER BY created_at DESC, id DESC;
+
+-- name: SettleLoanSettlementQuote :execresult
+UPDATE loan_settlement_quotes
+    SET status = 'SETTLED',
+    non_posted_id = ?,
+    settled_by = ?,
+    settled_at = CURRENT_TIMESTAMP
+WHERE id = ? AND status = 'PENDING';
@@ -0,0 +1,18 @@
+-- name: CreateStkPushRequest :execresult
+INSERT INTO stk_push_requests (loan_id, client_id, phone_number, amount, merchant_request_id, checkout_request_id, initiated_by)
+VALUES (?, ?, ?, ?, ?, ?, ?);
+
+-- name: GetStkPushRequestByCheckoutID :one
+SELECT * FROM stk_push_requests WHERE checkout_request_id = ? LIMIT 1;
+
+-- name: UpdateStkPushRequestResult :execresult
+UPDATE stk_push_requests
+    SET status = sqlc.arg("status"),
+    result_code = sqlc.arg("result_code"),
+    result_desc = sqlc.arg("result_desc"),
+    mpesa_receipt_number = sqlc.narg("mpesa_receipt_number"),
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = sqlc.arg("id");
+
+-- name: ListStkPushRequestsByLoan :many
+SELECT * FROM stk_push_requests WHERE loan_id = ? ORDER BY created_at DESC;
@@ -0,0 +1,9 @@
+-- name: CreateLoanTopUp :execresult
+INSERT INTO loan_top_ups (loan_id, refinanced_loan_id, non_posted_id, settled_amount, penalties_settled, net_disbursed, created_by)
+VALUES (?, ?, ?, ?, ?, ?, ?);
+
+-- name: GetLoanTopUpByLoan :one
+SELECT * FROM loan_top_ups WHERE loan_id = ? LIMIT 1;
+
+-- name: GetLoanTopUpByRefinancedLoan :one
+SELECT * FROM loan_top_ups WHERE refinanced_loan_id = ? LIMIT 1;
@@ -0,0 +1,28 @@
+-- name: CreatePaymentValidationLog :execresult
+INSERT INTO payment_validation_logs (transaction_number, account_number, phone_number, paying_name, amount, client_id, accepted, result_code, reason)
+VALUES (
+    sqlc.arg("transaction_number"),
+    sqlc.arg("account_number"),
+    sqlc.arg("phone_number"),
+    sqlc.arg("paying_name"),
+    sqlc.arg("amount"),
+    sqlc.narg("client_id"),
+    sqlc.arg("accepted"),
+    sqlc.arg("result_code"),
+    sqlc.arg("reason")
+);
+
+-- name: ListPaymentValidationLogs :many
+SELECT * FROM payment_validation_logs ORDER BY created_at DESC LIMIT ? OFFSET ?;
+
+-- name: CountPaymentValidationLogs :one
+SELECT COUNT(*) AS total_logs FROM payment_validation_logs;
+
+-- name: CreateBlacklistedClient :execresult
+INSERT INTO blacklisted_clients (client_id, reason, created_by) VALUES (?, ?, ?);
+
+-- name: GetBlacklistedClient :one
+SELECT * FROM blacklisted_clients WHERE client_id = ? LIMIT 1;
+
+-- name: DeleteBlacklistedClient :exec
+DELETE FROM blacklisted_clients WHERE client_id = ?;
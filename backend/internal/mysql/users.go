@@ -464,8 +464,8 @@ func convertUserReportData(row GetUserUsersReportDataRow) (services.UserUsersRep
 		Branch:                 row.Branch.String,
 		TotalClientsHandled:    row.TotalClientsHandled,
 		LoansApproved:          row.LoansApproved,
-		TotalLoanAmountManaged: pkg.InterfaceFloat64(row.TotalLoanAmountManaged),
-		TotalCollectedAmount:   pkg.InterfaceFloat64(row.TotalCollectedAmount),
+		TotalLoanAmountManaged: pkg.InterfaceMoney(row.TotalLoanAmountManaged).Float64(),
+		TotalCollectedAmount:   pkg.InterfaceMoney(row.TotalCollectedAmount).Float64(),
 		DefaultRate:            pkg.InterfaceFloat64(row.DefaultRate),
 		AssignedPayments:       row.AssignedPayments,
 		AssignedLoans:          assignedLoans,
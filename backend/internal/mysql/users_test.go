@@ -44,13 +44,18 @@ func TestUserRepository_CreateUser(t *testing.T) {
 					CreateUser(gomock.Any(), gomock.Eq(generated.CreateUserParams{FullName: "test", Password: "test"})).
 					Times(1).
 					Return(&mockSQLResult{lastInsertID: 1, rowsAffected: 1}, nil)
+				mockQueries.EXPECT().
+					GetBranch(gomock.Any(), uint32(0)).
+					Times(1).
+					Return(generated.Branch{Name: "test"}, nil)
 			},
 			wantErr: false,
 			err:     nil,
 			wantResult: repository.User{
-				ID:       1,
-				FullName: "test",
-				Password: "test",
+				ID:         1,
+				FullName:   "test",
+				Password:   "test",
+				BranchName: pkg.StringPtr("test"),
 			},
 		},
 		{
@@ -105,14 +110,15 @@ func TestUserRepository_GetUserByID(t *testing.T) {
 				mockQueries.EXPECT().
 					GetUser(gomock.Any(), gomock.Any()).
 					Times(1).
-					Return(generated.User{ID: 1, FullName: "test", Password: "test"}, nil)
+					Return(generated.GetUserRow{ID: 1, FullName: "test", Password: "test", BranchName: "test"}, nil)
 			},
 			wantErr: false,
 			err:     nil,
 			wantResult: repository.User{
-				ID:       1,
-				FullName: "test",
-				Password: "test",
+				ID:         1,
+				FullName:   "test",
+				Password:   "test",
+				BranchName: pkg.StringPtr("test"),
 			},
 		},
 		{
@@ -121,7 +127,7 @@ func TestUserRepository_GetUserByID(t *testing.T) {
 				mockQueries.EXPECT().
 					GetUser(gomock.Any(), gomock.Any()).
 					Times(1).
-					Return(generated.User{}, sql.ErrNoRows)
+					Return(generated.GetUserRow{}, sql.ErrNoRows)
 			},
 			wantErr: true,
 			err:     errors.New(pkg.NOT_FOUND_ERROR),
@@ -132,7 +138,7 @@ func TestUserRepository_GetUserByID(t *testing.T) {
 				mockQueries.EXPECT().
 					GetUser(gomock.Any(), gomock.Any()).
 					Times(1).
-					Return(generated.User{}, errors.New("error"))
+					Return(generated.GetUserRow{}, errors.New("error"))
 			},
 			wantErr:    true,
 			err:        errors.New(pkg.INTERNAL_ERROR),
@@ -179,14 +185,15 @@ func TestUserRepository_GetUserByEmail(t *testing.T) {
 				mockQueries.EXPECT().
 					GetUserByEmail(gomock.Any(), gomock.Eq("email")).
 					Times(1).
-					Return(generated.User{ID: 1, FullName: "test", Password: "test"}, nil)
+					Return(generated.GetUserByEmailRow{ID: 1, FullName: "test", Password: "test", BranchName: "test"}, nil)
 			},
 			wantErr: false,
 			err:     nil,
 			wantResult: repository.User{
-				ID:       1,
-				FullName: "test",
-				Password: "test",
+				ID:         1,
+				FullName:   "test",
+				Password:   "test",
+				BranchName: pkg.StringPtr("test"),
 			},
 		},
 		{
@@ -195,7 +202,7 @@ func TestUserRepository_GetUserByEmail(t *testing.T) {
 				mockQueries.EXPECT().
 					GetUserByEmail(gomock.Any(), gomock.Eq("email")).
 					Times(1).
-					Return(generated.User{}, sql.ErrNoRows)
+					Return(generated.GetUserByEmailRow{}, sql.ErrNoRows)
 			},
 			wantErr: true,
 			err:     errors.New(pkg.NOT_FOUND_ERROR),
@@ -294,32 +301,52 @@ func TestUserRepository_ListUsers(t *testing.T) {
 	mockQueries := mockdb.NewMockQuerier(ctrl)
 	r.queries = mockQueries
 
+	pgData := &pkg.PaginationMetadata{CurrentPage: 1, PageSize: 10}
+
 	tests := []struct {
 		name       string
 		buildStubs func(mockQueries *mockdb.MockQuerier)
 		wantErr    bool
 		err        error
 		wantResult []repository.User
+		wantPgData pkg.PaginationMetadata
 	}{
 		{
 			name: "OK",
 			buildStubs: func(mockQueries *mockdb.MockQuerier) {
 				mockQueries.EXPECT().
-					ListUsers(gomock.Any(), gomock.Any()).
+					ListUsersByCategory(gomock.Any(), generated.ListUsersByCategoryParams{
+						Column1: "",
+						Column4: "",
+						Limit:   10,
+						Offset:  0,
+					}).
 					Times(1).
-					Return([]generated.User{
-						{ID: 1, FullName: "test", Password: "test"},
+					Return([]generated.ListUsersByCategoryRow{
+						{ID: 1, FullName: "test", BranchName: "test"},
 					}, nil)
+				mockQueries.EXPECT().
+					CountUsersByCategory(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(int64(1), nil)
+			},
+			wantErr: false,
+			err:     nil,
+			wantResult: []repository.User{
+				{ID: 1, FullName: "test", BranchName: pkg.StringPtr("test")},
+			},
+			wantPgData: pkg.PaginationMetadata{
+				CurrentPage: 1,
+				PageSize:    10,
+				TotalData:   1,
+				TotalPages:  1,
 			},
-			wantErr:    false,
-			err:        nil,
-			wantResult: []repository.User{{ID: 1, FullName: "test", Password: "test"}},
 		},
 		{
 			name: "Not Found",
 			buildStubs: func(mockQueries *mockdb.MockQuerier) {
 				mockQueries.EXPECT().
-					ListUsers(gomock.Any(), gomock.Any()).
+					ListUsersByCategory(gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(nil, sql.ErrNoRows)
 			},
@@ -332,7 +359,11 @@ func TestUserRepository_ListUsers(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.buildStubs(mockQueries)
 
-			result, err := r.ListUsers(context.Background(), &pkg.PaginationMetadata{})
+			result, metadata, err := r.ListUsers(
+				context.Background(),
+				&repository.CategorySearch{},
+				pgData,
+			)
 
 			if tc.wantErr {
 				require.Error(t, err)
@@ -340,6 +371,7 @@ func TestUserRepository_ListUsers(t *testing.T) {
 			} else {
 				require.NoError(t, err)
 				require.Equal(t, tc.wantResult, result)
+				require.Equal(t, tc.wantPgData, metadata)
 			}
 		})
 	}
@@ -377,11 +409,16 @@ func TestUserRepository_UpdateUser(t *testing.T) {
 				// Mock GetUser call
 				mockQueries.EXPECT().
 					GetUser(gomock.Any(), gomock.Eq(uint32(1))).
-					Return(generated.User{ID: 1, Role: "ADMIN", Password: "testpassword"}, nil).
+					Return(generated.GetUserRow{ID: 1, Role: "ADMIN", Password: "testpassword", BranchName: "test"}, nil).
 					Times(1)
 			},
-			wantErr:    false,
-			wantResult: repository.User{ID: 1, Role: "ADMIN", Password: "testpassword"},
+			wantErr: false,
+			wantResult: repository.User{
+				ID:         1,
+				Role:       "ADMIN",
+				Password:   "testpassword",
+				BranchName: pkg.StringPtr("test"),
+			},
 		},
 	}
 
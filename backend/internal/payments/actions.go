@@ -4,246 +4,618 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
-	"strings"
 
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/mysql/generated"
 	"github.com/EmilioCliff/kokomed-fin/backend/internal/services"
 	"github.com/EmilioCliff/kokomed-fin/backend/pkg"
 )
 
+// Simulations run the real update and delete in a transaction that is always rolled back, and
+// report what the rows looked like before and after. They cannot drift from what executing the
+// action would do.
+
 func (p *PaymentService) SimulateUpdatePayment(
 	ctx context.Context,
 	paymentID uint32,
 	userID uint32,
 	paymentData *services.MpesaCallbackData,
 ) (*services.SimulationResult, error) {
-	var result services.SimulationResult
-	result.PaymentID = paymentID
+	nonPosted, err := p.getUpdatablePayment(ctx, paymentID)
+	if err != nil {
+		return nil, err
+	}
+
+	clientIDs := []uint32{}
+	if nonPosted.AssignedTo != nil {
+		clientIDs = append(clientIDs, *nonPosted.AssignedTo)
+	}
+
+	if paymentData.AssignedTo != nil &&
+		(nonPosted.AssignedTo == nil || *paymentData.AssignedTo != *nonPosted.AssignedTo) {
+		clientIDs = append(clientIDs, *paymentData.AssignedTo)
+	}
+
+	result, err := p.simulate(ctx, paymentID, clientIDs, func(q generated.Querier) error {
+		return updatePayment(ctx, q, paymentID, "SIMULATION", paymentData)
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	result.UserID = userID
 
-	nonPosted, err := p.mySQL.NonPosted.GetNonPosted(ctx, paymentID)
+	if !blocked(result) {
+		result.Actions = append(result.Actions, services.SimulatedAction{
+			ActionType:  "update_payment",
+			Description: "Would update non-posted",
+			Amount:      paymentData.Amount,
+			Severity:    "info",
+		})
+	}
+
+	return result, nil
+}
+
+func (p *PaymentService) SimulateDeletePayment(
+	ctx context.Context,
+	paymentID uint32,
+	userID uint32,
+) (*services.SimulationResult, error) {
+	paymentData, err := p.getDeletablePayment(ctx, paymentID)
 	if err != nil {
 		return nil, err
 	}
 
-	if nonPosted.TransactionSource != "INTERNAL" {
-		return nil, pkg.Errorf(
-			pkg.INVALID_ERROR,
-			"transaction source is not 'INTERNAL'. only 'INTERNAL' transactions can be updated",
-		)
+	clientIDs := []uint32{}
+	if paymentData.AssignedTo != nil {
+		clientIDs = append(clientIDs, *paymentData.AssignedTo)
 	}
 
-	if paymentData.AssignedTo == nil {
+	result, err := p.simulate(ctx, paymentID, clientIDs, func(q generated.Querier) error {
+		return deletePayment(ctx, q, paymentData, userID, "SIMULATION")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result.UserID = userID
+
+	if !blocked(result) {
 		result.Actions = append(result.Actions, services.SimulatedAction{
-			ActionType:    "update_payment",
-			Description:   "Non-posted payment is not assigned to any client. No accounts, loans, or overpayments will be affected.",
-			Amount:        paymentData.Amount,
-			LoanID:        nil,
-			InstallmentID: nil,
-			Severity:      "info",
+			ActionType:  "delete_non_posted",
+			Description: "Would delete non-posted",
+			Amount:      paymentData.Amount.Float64(),
+			Severity:    "success",
 		})
-		return &result, nil
 	}
 
-	allocations, err := p.mySQL.NonPosted.ListPaymentAllocationsByNonPostedId(ctx, paymentID)
+	return result, nil
+}
+
+// simulate runs action in a dry run transaction and diffs the payment's allocations, the loans it
+// paid and the clients' overpayments from before to after. An action the real path refuses is
+// reported as blocked.
+func (p *PaymentService) simulate(
+	ctx context.Context,
+	paymentID uint32,
+	clientIDs []uint32,
+	action func(q generated.Querier) error,
+) (*services.SimulationResult, error) {
+	result := &services.SimulationResult{PaymentID: paymentID}
+
+	var actionErr error
+
+	err := p.db.DryRunTx(ctx, func(q generated.Querier) error {
+		before, err := takePaymentSnapshot(ctx, q, paymentID, clientIDs, nil)
+		if err != nil {
+			return err
+		}
+
+		if actionErr = action(q); actionErr != nil {
+			return nil
+		}
+
+		after, err := takePaymentSnapshot(ctx, q, paymentID, clientIDs, before.loanIDs())
+		if err != nil {
+			return err
+		}
+
+		result.Changes = before.diff(after)
+
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	if len(allocations) == 0 {
-		result.Actions = append(result.Actions, services.SimulatedAction{
-			ActionType:  "not_enough_data",
-			Description: "payment has no allocations. needs extra steps",
+	if actionErr != nil {
+		if pkg.ErrorCode(actionErr) == pkg.INTERNAL_ERROR {
+			return nil, actionErr
+		}
+
+		result.Actions = []services.SimulatedAction{{
+			ActionType:  "blocked",
+			Description: pkg.ErrorMessage(actionErr),
 			Severity:    "danger",
-		})
-		return &result, nil
+		}}
+
+		return result, nil
 	}
 
-	revertedAmount := 0.0
-	var loanID uint32
+	result.Actions = changeActions(result.Changes)
+
+	return result, nil
+}
+
+func blocked(result *services.SimulationResult) bool {
+	return len(result.Actions) == 1 && result.Actions[0].ActionType == "blocked"
+}
+
+type loanState struct {
+	ID         uint32    `json:"id"`
+	Status     string    `json:"status"`
+	PaidAmount pkg.Money `json:"paidAmount"`
+}
+
+type installmentState struct {
+	ID                uint32    `json:"id"`
+	LoanID            uint32    `json:"loanId"`
+	InstallmentNumber uint32    `json:"installmentNumber"`
+	RemainingAmount   pkg.Money `json:"remainingAmount"`
+	Paid              bool      `json:"paid"`
+}
+
+type penaltyState struct {
+	ID              uint32    `json:"id"`
+	LoanID          uint32    `json:"loanId"`
+	InstallmentID   uint32    `json:"installmentId"`
+	RemainingAmount pkg.Money `json:"remainingAmount"`
+	Paid            bool      `json:"paid"`
+}
+
+type overpaymentState struct {
+	ClientID    uint32    `json:"clientId"`
+	Overpayment pkg.Money `json:"overpayment"`
+}
+
+type allocationState struct {
+	ID            uint32    `json:"id"`
+	LoanID        *uint32   `json:"loanId"`
+	InstallmentID *uint32   `json:"installmentId"`
+	PenaltyID     *uint32   `json:"penaltyId"`
+	Amount        pkg.Money `json:"amount"`
+	Description   string    `json:"description"`
+}
+
+type paymentSnapshot struct {
+	loans        []loanState
+	installments []installmentState
+	penalties    []penaltyState
+	overpayments []overpaymentState
+	allocations  []allocationState
+}
+
+// takePaymentSnapshot reads the rows a payment action can change. Without loanIDs the loans are
+// the ones the payment is allocated to, which reverting touches, and the payable loans of the
+// clients, which paying again touches when the payment moves to another client.
+func takePaymentSnapshot(
+	ctx context.Context,
+	q generated.Querier,
+	paymentID uint32,
+	clientIDs []uint32,
+	loanIDs []uint32,
+) (paymentSnapshot, error) {
+	var snapshot paymentSnapshot
+
+	allocations, err := q.ListPaymentAllocationsByNonPostedId(ctx, paymentID)
+	if err != nil {
+		return paymentSnapshot{}, pkg.Errorf(
+			pkg.INTERNAL_ERROR,
+			"failed to list payment allocations: %s",
+			err.Error(),
+		)
+	}
 
 	for _, allocation := range allocations {
-		if allocation.InstallmentID != nil {
-			revertedAmount += allocation.Amount
-			loanID = *allocation.LoanID
-			result.Actions = append(result.Actions, services.SimulatedAction{
-				ActionType:    "revert_installments",
-				Amount:        allocation.Amount,
-				InstallmentID: allocation.InstallmentID,
-				LoanID:        pkg.Uint32Ptr(loanID),
-				Description:   "Would revert installment",
-				Severity:      "warning",
+		state := allocationState{
+			ID:          allocation.ID,
+			Amount:      pkg.MoneyFromFloat(allocation.Amount),
+			Description: allocation.Description,
+		}
+
+		if allocation.LoanID.Valid {
+			state.LoanID = pkg.Uint32Ptr(uint32(allocation.LoanID.Int32))
+		}
+
+		if allocation.InstallmentID.Valid {
+			state.InstallmentID = pkg.Uint32Ptr(uint32(allocation.InstallmentID.Int32))
+		}
+
+		if allocation.PenaltyID.Valid {
+			state.PenaltyID = pkg.Uint32Ptr(uint32(allocation.PenaltyID.Int32))
+		}
+
+		snapshot.allocations = append(snapshot.allocations, state)
+	}
+
+	if loanIDs == nil {
+		loanIDs, err = snapshot.affectedLoanIDs(ctx, q, clientIDs)
+		if err != nil {
+			return paymentSnapshot{}, err
+		}
+	}
+
+	for _, loanID := range loanIDs {
+		loan, err := q.GetLoan(ctx, loanID)
+		if err != nil {
+			return paymentSnapshot{}, pkg.Errorf(
+				pkg.INTERNAL_ERROR,
+				"failed to get loan: %s",
+				err.Error(),
+			)
+		}
+
+		snapshot.loans = append(snapshot.loans, loanState{
+			ID:         loan.ID,
+			Status:     string(loan.Status),
+			PaidAmount: pkg.MoneyFromFloat(loan.PaidAmount),
+		})
+
+		installments, err := q.ListInstallmentsByLoan(ctx, loanID)
+		if err != nil {
+			return paymentSnapshot{}, pkg.Errorf(
+				pkg.INTERNAL_ERROR,
+				"failed to list installments: %s",
+				err.Error(),
+			)
+		}
+
+		for _, i := range installments {
+			snapshot.installments = append(snapshot.installments, installmentState{
+				ID:                i.ID,
+				LoanID:            i.LoanID,
+				InstallmentNumber: i.InstallmentNumber,
+				RemainingAmount:   pkg.MoneyFromFloat(i.RemainingAmount),
+				Paid:              i.Paid,
 			})
-		} else {
-			result.Actions = append(result.Actions, services.SimulatedAction{
-				ActionType: "reduce_overpayment",
-				Amount:     allocation.Amount,
-				LoanID:     nil,
-				Description: fmt.Sprintf(
-					"Would deduct overpayment for client with id %d",
-					*paymentData.AssignedTo,
-				),
-				Severity: "warning",
+		}
+
+		penalties, err := q.ListPenaltiesByLoan(ctx, loanID)
+		if err != nil {
+			return paymentSnapshot{}, pkg.Errorf(
+				pkg.INTERNAL_ERROR,
+				"failed to list penalties: %s",
+				err.Error(),
+			)
+		}
+
+		for _, penalty := range penalties {
+			snapshot.penalties = append(snapshot.penalties, penaltyState{
+				ID:              penalty.ID,
+				LoanID:          penalty.LoanID,
+				InstallmentID:   penalty.InstallmentID,
+				RemainingAmount: pkg.MoneyFromFloat(penalty.RemainingAmount),
+				Paid:            penalty.Paid,
 			})
 		}
 	}
 
-	// Loan status check
-	if revertedAmount > 0 {
-		loanStatus, err := p.mySQL.Loans.GetLoanStatus(ctx, loanID)
-		if err != nil && err != sql.ErrNoRows {
-			return nil, err
+	for _, clientID := range clientIDs {
+		overpayment, err := q.GetClientOverpayment(ctx, clientID)
+		if err != nil {
+			return paymentSnapshot{}, pkg.Errorf(
+				pkg.INTERNAL_ERROR,
+				"failed to get client overpayment: %s",
+				err.Error(),
+			)
 		}
 
-		if strings.ToLower(loanStatus) != "active" {
-			hasActiveLoan, err := p.mySQL.Loans.GetClientActiceLoan(ctx, *paymentData.AssignedTo)
-			if err != nil {
-				return nil, err
-			}
+		snapshot.overpayments = append(snapshot.overpayments, overpaymentState{
+			ClientID:    clientID,
+			Overpayment: pkg.MoneyFromFloat(overpayment),
+		})
+	}
 
-			if hasActiveLoan != 0 {
-				result.Actions = append(result.Actions, services.SimulatedAction{
-					ActionType:  "loan_status_blocked",
-					Description: "Loan status change would be blocked due to existing active loan",
-					Severity:    "warning",
-				})
-			} else {
-				result.Actions = append(result.Actions, services.SimulatedAction{
-					ActionType:  "loan_status_change",
-					Description: "Loan status would change to ACTIVE",
-					LoanID:      pkg.Uint32Ptr(loanID),
-					Severity:    "warning",
-				})
+	return snapshot, nil
+}
+
+func (s paymentSnapshot) affectedLoanIDs(
+	ctx context.Context,
+	q generated.Querier,
+	clientIDs []uint32,
+) ([]uint32, error) {
+	loanIDs := []uint32{}
+	seen := make(map[uint32]bool)
+
+	for _, allocation := range s.allocations {
+		if allocation.LoanID != nil && !seen[*allocation.LoanID] {
+			seen[*allocation.LoanID] = true
+			loanIDs = append(loanIDs, *allocation.LoanID)
+		}
+	}
+
+	for _, clientID := range clientIDs {
+		loanID, err := q.GetClientPayableLoan(ctx, clientID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				continue
 			}
+
+			return nil, pkg.Errorf(
+				pkg.INTERNAL_ERROR,
+				"failed to get client payable loan: %s",
+				err.Error(),
+			)
+		}
+
+		if !seen[loanID] {
+			seen[loanID] = true
+			loanIDs = append(loanIDs, loanID)
 		}
 	}
 
-	// Summary of update
-	result.Actions = append(result.Actions, services.SimulatedAction{
-		ActionType:  "update_payment",
-		Description: "Would update non-posted ",
-		Severity:    "info",
-	})
-	result.Actions = append(result.Actions, services.SimulatedAction{
-		ActionType:  "process_loan_payment",
-		Amount:      paymentData.Amount,
-		Description: "Would apply new payment for non-posted",
-		LoanID:      pkg.Uint32Ptr(loanID),
-		Severity:    "success",
-	})
+	return loanIDs, nil
+}
+
+func (s paymentSnapshot) loanIDs() []uint32 {
+	loanIDs := make([]uint32, len(s.loans))
+	for i, loan := range s.loans {
+		loanIDs[i] = loan.ID
+	}
 
-	return &result, nil
+	return loanIDs
 }
 
-func (p *PaymentService) SimulateDeletePayment(
-	ctx context.Context,
-	paymentID uint32,
-	userID uint32,
-) (*services.SimulationResult, error) {
-	var result services.SimulationResult
+// diff lists the rows that differ between the snapshots, loans first then installments,
+// penalties, overpayments and allocations.
+func (s paymentSnapshot) diff(after paymentSnapshot) []services.SimulatedChange {
+	changes := []services.SimulatedChange{}
 
-	paymentData, err := p.mySQL.NonPosted.GetNonPosted(ctx, paymentID)
-	if err != nil {
-		return nil, err
+	afterLoans := make(map[uint32]loanState, len(after.loans))
+	for _, loan := range after.loans {
+		afterLoans[loan.ID] = loan
 	}
 
-	if paymentData.TransactionSource != "INTERNAL" {
-		return nil, pkg.Errorf(
-			pkg.INVALID_ERROR,
-			"transaction source is not 'INTERNAL'. only 'INTERNAL' transactions can be deleted",
-		)
+	for _, loan := range s.loans {
+		if changed, ok := afterLoans[loan.ID]; ok && changed != loan {
+			changes = append(changes, services.SimulatedChange{
+				Entity:   "loan",
+				EntityID: loan.ID,
+				Before:   loan,
+				After:    changed,
+			})
+		}
 	}
 
-	if paymentData.AssignedTo == nil {
-		result.Actions = append(result.Actions, services.SimulatedAction{
-			ActionType:    "delete_non_posted",
-			Description:   "Non-posted payment is not assigned to any client. No accounts, loans, or overpayments will be affected.",
-			Amount:        paymentData.Amount,
-			LoanID:        nil,
-			InstallmentID: nil,
-			Severity:      "success",
-		})
-		return &result, nil
+	afterInstallments := make(map[uint32]installmentState, len(after.installments))
+	for _, i := range after.installments {
+		afterInstallments[i.ID] = i
 	}
 
-	allocations, err := p.mySQL.NonPosted.ListPaymentAllocationsByNonPostedId(ctx, paymentID)
-	if err != nil {
-		return nil, err
+	for _, i := range s.installments {
+		if changed, ok := afterInstallments[i.ID]; ok && changed != i {
+			changes = append(changes, services.SimulatedChange{
+				Entity:   "installment",
+				EntityID: i.ID,
+				Before:   i,
+				After:    changed,
+			})
+		}
 	}
 
-	if len(allocations) == 0 {
-		result.Actions = append(result.Actions, services.SimulatedAction{
-			ActionType:  "not_enough_data",
-			Description: "payment has no allocations. needs extra steps",
-			Severity:    "danger",
-		})
-		return &result, nil
+	afterPenalties := make(map[uint32]penaltyState, len(after.penalties))
+	for _, penalty := range after.penalties {
+		afterPenalties[penalty.ID] = penalty
 	}
 
-	revertedAmount := 0.0
-	var loanID uint32
+	for _, penalty := range s.penalties {
+		if changed, ok := afterPenalties[penalty.ID]; ok && changed != penalty {
+			changes = append(changes, services.SimulatedChange{
+				Entity:   "penalty",
+				EntityID: penalty.ID,
+				Before:   penalty,
+				After:    changed,
+			})
+		}
+	}
 
-	for _, allocation := range allocations {
-		if allocation.InstallmentID != nil {
-			revertedAmount += allocation.Amount
-			loanID = *allocation.LoanID
-			result.Actions = append(result.Actions, services.SimulatedAction{
-				ActionType:    "revert_installments",
-				Amount:        allocation.Amount,
-				InstallmentID: allocation.InstallmentID,
-				LoanID:        pkg.Uint32Ptr(loanID),
-				Description:   "Would revert installment",
-				Severity:      "warning",
+	afterOverpayments := make(map[uint32]overpaymentState, len(after.overpayments))
+	for _, overpayment := range after.overpayments {
+		afterOverpayments[overpayment.ClientID] = overpayment
+	}
+
+	for _, overpayment := range s.overpayments {
+		if changed, ok := afterOverpayments[overpayment.ClientID]; ok && changed != overpayment {
+			changes = append(changes, services.SimulatedChange{
+				Entity:   "overpayment",
+				EntityID: overpayment.ClientID,
+				Before:   overpayment,
+				After:    changed,
 			})
-		} else {
-			result.Actions = append(result.Actions, services.SimulatedAction{
-				ActionType: "reduce_overpayment",
-				Amount:     allocation.Amount,
-				LoanID:     nil,
-				Description: fmt.Sprintf(
-					"Would deduct overpayment for client with id %d",
-					*paymentData.AssignedTo,
-				),
-				Severity: "warning",
+		}
+	}
+
+	beforeAllocations := make(map[uint32]bool, len(s.allocations))
+	for _, allocation := range s.allocations {
+		beforeAllocations[allocation.ID] = true
+	}
+
+	afterAllocations := make(map[uint32]bool, len(after.allocations))
+	for _, allocation := range after.allocations {
+		afterAllocations[allocation.ID] = true
+	}
+
+	for _, allocation := range s.allocations {
+		if !afterAllocations[allocation.ID] {
+			changes = append(changes, services.SimulatedChange{
+				Entity:   "allocation",
+				EntityID: allocation.ID,
+				Before:   allocation,
 			})
 		}
 	}
 
-	// Check loan status only if we reverted installment(s)
-	if revertedAmount > 0 {
-		loanStatus, err := p.mySQL.Loans.GetLoanStatus(ctx, loanID)
-		if err != nil {
-			return nil, err
+	for _, allocation := range after.allocations {
+		if !beforeAllocations[allocation.ID] {
+			changes = append(changes, services.SimulatedChange{
+				Entity:   "allocation",
+				EntityID: allocation.ID,
+				After:    allocation,
+			})
 		}
+	}
 
-		if strings.ToLower(loanStatus) != "active" {
-			hasActiveLoan, err := p.mySQL.Loans.GetClientActiceLoan(ctx, *paymentData.AssignedTo)
-			if err != nil && err != sql.ErrNoRows {
-				return nil, err
+	return changes
+}
+
+// changeActions describes each change as the action that made it.
+func changeActions(changes []services.SimulatedChange) []services.SimulatedAction {
+	actions := []services.SimulatedAction{}
+
+	for _, change := range changes {
+		switch change.Entity {
+		case "loan":
+			before := change.Before.(loanState)
+			after := change.After.(loanState)
+
+			if after.PaidAmount != before.PaidAmount {
+				actions = append(actions, services.SimulatedAction{
+					ActionType: "update_loan",
+					Description: fmt.Sprintf(
+						"Would change loan paid amount from %s to %s",
+						before.PaidAmount,
+						after.PaidAmount,
+					),
+					Amount:   (after.PaidAmount - before.PaidAmount).Float64(),
+					LoanID:   pkg.Uint32Ptr(before.ID),
+					Severity: "warning",
+				})
 			}
 
-			if hasActiveLoan != 0 {
-				result.Actions = append(result.Actions, services.SimulatedAction{
-					ActionType:  "loan_status_blocked",
-					Description: "Loan status change would be blocked due to existing active loan",
-					Severity:    "warning",
+			if after.Status != before.Status {
+				actions = append(actions, services.SimulatedAction{
+					ActionType: "loan_status_change",
+					Description: fmt.Sprintf(
+						"Loan status would change from %s to %s",
+						before.Status,
+						after.Status,
+					),
+					LoanID:   pkg.Uint32Ptr(before.ID),
+					Severity: "warning",
+				})
+			}
+		case "installment":
+			before := change.Before.(installmentState)
+			after := change.After.(installmentState)
+
+			paid := before.RemainingAmount - after.RemainingAmount
+			action := services.SimulatedAction{
+				ActionType: "pay_installment",
+				Description: fmt.Sprintf(
+					"Would pay installment %d, remaining %s to %s",
+					before.InstallmentNumber,
+					before.RemainingAmount,
+					after.RemainingAmount,
+				),
+				Amount:        paid.Float64(),
+				LoanID:        pkg.Uint32Ptr(before.LoanID),
+				InstallmentID: pkg.Uint32Ptr(before.ID),
+				Severity:      "success",
+			}
+
+			if paid < 0 {
+				action.ActionType = "revert_installments"
+				action.Description = fmt.Sprintf(
+					"Would revert installment %d, remaining %s to %s",
+					before.InstallmentNumber,
+					before.RemainingAmount,
+					after.RemainingAmount,
+				)
+				action.Amount = (-paid).Float64()
+				action.Severity = "warning"
+			}
+
+			actions = append(actions, action)
+		case "penalty":
+			before := change.Before.(penaltyState)
+			after := change.After.(penaltyState)
+
+			paid := before.RemainingAmount - after.RemainingAmount
+			action := services.SimulatedAction{
+				ActionType: "pay_penalty",
+				Description: fmt.Sprintf(
+					"Would pay penalty %d, remaining %s to %s",
+					before.ID,
+					before.RemainingAmount,
+					after.RemainingAmount,
+				),
+				Amount:        paid.Float64(),
+				LoanID:        pkg.Uint32Ptr(before.LoanID),
+				InstallmentID: pkg.Uint32Ptr(before.InstallmentID),
+				Severity:      "success",
+			}
+
+			if paid < 0 {
+				action.ActionType = "revert_penalty"
+				action.Description = fmt.Sprintf(
+					"Would revert penalty %d, remaining %s to %s",
+					before.ID,
+					before.RemainingAmount,
+					after.RemainingAmount,
+				)
+				action.Amount = (-paid).Float64()
+				action.Severity = "warning"
+			}
+
+			actions = append(actions, action)
+		case "overpayment":
+			before := change.Before.(overpaymentState)
+			after := change.After.(overpaymentState)
+
+			added := after.Overpayment - before.Overpayment
+			action := services.SimulatedAction{
+				ActionType: "add_overpayment",
+				Description: fmt.Sprintf(
+					"Would change overpayment for client with id %d from %s to %s",
+					before.ClientID,
+					before.Overpayment,
+					after.Overpayment,
+				),
+				Amount:   added.Float64(),
+				Severity: "info",
+			}
+
+			if added < 0 {
+				action.ActionType = "reduce_overpayment"
+				action.Amount = (-added).Float64()
+				action.Severity = "warning"
+			}
+
+			actions = append(actions, action)
+		case "allocation":
+			if change.After == nil {
+				allocation := change.Before.(allocationState)
+				actions = append(actions, services.SimulatedAction{
+					ActionType:    "remove_allocation",
+					Description:   fmt.Sprintf("Would remove allocation: %s", allocation.Description),
+					Amount:        allocation.Amount.Float64(),
+					LoanID:        allocation.LoanID,
+					InstallmentID: allocation.InstallmentID,
+					Severity:      "warning",
 				})
 			} else {
-				result.Actions = append(result.Actions, services.SimulatedAction{
-					ActionType:  "loan_status_change",
-					Description: "Loan status would change to ACTIVE",
-					LoanID:      pkg.Uint32Ptr(loanID),
-					Severity:    "warning",
+				allocation := change.After.(allocationState)
+				actions = append(actions, services.SimulatedAction{
+					ActionType:    "create_allocation",
+					Description:   fmt.Sprintf("Would allocate: %s", allocation.Description),
+					Amount:        allocation.Amount.Float64(),
+					LoanID:        allocation.LoanID,
+					InstallmentID: allocation.InstallmentID,
+					Severity:      "success",
 				})
 			}
 		}
 	}
 
-	// Summary of update
-	result.Actions = append(result.Actions, services.SimulatedAction{
-		ActionType:  "delete_non_posted",
-		Description: "Would delete non-posted ",
-		Severity:    "success",
-	})
-
-	return &result, nil
+	return actions
 }
@@ -0,0 +1,306 @@
+package payments
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/mysql/generated"
+	"github.com/EmilioCliff/kokomed-fin/backend/pkg"
+)
+
+const (
+	allocationComponentInterest  = "INTEREST"
+	allocationComponentPrincipal = "PRINCIPAL"
+)
+
+// allocationStrategy decides how a repayment is spread over a loan's unpaid installments.
+// Installments are passed oldest first and only hold what is still owed on them.
+type allocationStrategy interface {
+	allocate(
+		amount pkg.Money,
+		installments []generated.Installment,
+		terms allocationTerms,
+	) []installmentAllocation
+}
+
+type allocationTerms struct {
+	RepayAmount    pkg.Money
+	InterestAmount pkg.Money
+	Today          time.Time
+}
+
+type installmentAllocation struct {
+	Installment generated.Installment
+	Amount      pkg.Money
+	// Component is set by strategies that pay parts of an installment separately
+	Component string
+}
+
+var allocationStrategies = map[generated.ProductAllocationStrategiesStrategy]allocationStrategy{
+	generated.ProductAllocationStrategiesStrategyOLDESTFIRST:   oldestFirstStrategy{},
+	generated.ProductAllocationStrategiesStrategyCURRENTFIRST:  currentFirstStrategy{},
+	generated.ProductAllocationStrategiesStrategySPREADOVERDUE: spreadOverdueStrategy{},
+	generated.ProductAllocationStrategiesStrategyWATERFALL:     waterfallStrategy{},
+}
+
+func allocationStrategyFor(name string) allocationStrategy {
+	if strategy, ok := allocationStrategies[generated.ProductAllocationStrategiesStrategy(name)]; ok {
+		return strategy
+	}
+
+	return oldestFirstStrategy{}
+}
+
+// oldestFirstStrategy clears installments in the order they fall due.
+type oldestFirstStrategy struct{}
+
+func (oldestFirstStrategy) allocate(
+	amount pkg.Money,
+	installments []generated.Installment,
+	_ allocationTerms,
+) []installmentAllocation {
+	allocations, _ := payInOrder(amount, installments, "")
+
+	return allocations
+}
+
+// currentFirstStrategy pays the running installment before going back to arrears.
+type currentFirstStrategy struct{}
+
+func (currentFirstStrategy) allocate(
+	amount pkg.Money,
+	installments []generated.Installment,
+	terms allocationTerms,
+) []installmentAllocation {
+	current := -1
+	for idx, i := range installments {
+		if !isOverdue(i, terms.Today) {
+			current = idx
+			break
+		}
+	}
+
+	if current <= 0 {
+		allocations, _ := payInOrder(amount, installments, "")
+
+		return allocations
+	}
+
+	ordered := make([]generated.Installment, 0, len(installments))
+	ordered = append(ordered, installments[current])
+	ordered = append(ordered, installments[:current]...)
+	ordered = append(ordered, installments[current+1:]...)
+
+	allocations, _ := payInOrder(amount, ordered, "")
+
+	return allocations
+}
+
+// spreadOverdueStrategy shares the payment across all overdue installments in proportion to what
+// is owed on each, anything left after the arrears are cleared goes to the next installments.
+type spreadOverdueStrategy struct{}
+
+func (spreadOverdueStrategy) allocate(
+	amount pkg.Money,
+	installments []generated.Installment,
+	terms allocationTerms,
+) []installmentAllocation {
+	overdue := 0
+	totalOverdue := pkg.Money(0)
+
+	for _, i := range installments {
+		if !isOverdue(i, terms.Today) {
+			break
+		}
+
+		overdue++
+		totalOverdue += owed(i)
+	}
+
+	if overdue == 0 || amount >= totalOverdue {
+		allocations, _ := payInOrder(amount, installments, "")
+
+		return allocations
+	}
+
+	var allocations []installmentAllocation
+	left := amount
+
+	for idx, i := range installments[:overdue] {
+		share := amount.Share(owed(i), totalOverdue)
+		// the last installment takes the rounding difference
+		if idx == overdue-1 || share > left {
+			share = left
+		}
+
+		share = pkg.MinMoney(share, owed(i))
+		if share <= 0 {
+			continue
+		}
+
+		left -= share
+		allocations = append(allocations, installmentAllocation{Installment: i, Amount: share})
+	}
+
+	return allocations
+}
+
+// waterfallStrategy settles the interest on every unpaid installment before any principal.
+// The interest on an installment is its share of the product interest and is taken as paid first.
+// With the penalties every plan settles first this is penalties, interest then principal. There
+// is no fee step, the processing fee is never part of the installments: it is collected at
+// disbursement and tracked by the loan's fee_paid flag, so a repayment has no fees to pay.
+type waterfallStrategy struct{}
+
+func (waterfallStrategy) allocate(
+	amount pkg.Money,
+	installments []generated.Installment,
+	terms allocationTerms,
+) []installmentAllocation {
+	if terms.RepayAmount <= 0 {
+		allocations, _ := payInOrder(amount, installments, "")
+
+		return allocations
+	}
+
+	interest := make([]generated.Installment, len(installments))
+	principal := make([]generated.Installment, len(installments))
+
+	for idx, i := range installments {
+		due := pkg.MoneyFromFloat(i.AmountDue)
+		interestShare := terms.InterestAmount.Share(due, terms.RepayAmount)
+		interestOwed := pkg.MinMoney(max(interestShare-(due-owed(i)), 0), owed(i))
+
+		interest[idx] = i
+		interest[idx].RemainingAmount = interestOwed.Float64()
+
+		principal[idx] = i
+		principal[idx].RemainingAmount = (owed(i) - interestOwed).Float64()
+	}
+
+	allocations, left := payInOrder(amount, interest, allocationComponentInterest)
+	principalAllocations, _ := payInOrder(left, principal, allocationComponentPrincipal)
+
+	return append(allocations, principalAllocations...)
+}
+
+// payInOrder pays the installments one after the other and returns what could not be allocated.
+func payInOrder(
+	amount pkg.Money,
+	installments []generated.Installment,
+	component string,
+) ([]installmentAllocation, pkg.Money) {
+	var allocations []installmentAllocation
+
+	for _, i := range installments {
+		if amount <= 0 {
+			break
+		}
+
+		if owed(i) <= 0 {
+			continue
+		}
+
+		pay := pkg.MinMoney(amount, owed(i))
+		amount -= pay
+
+		allocations = append(allocations, installmentAllocation{
+			Installment: i,
+			Amount:      pay,
+			Component:   component,
+		})
+	}
+
+	return allocations, amount
+}
+
+// owed is what is still to be paid on an installment in whole cents.
+func owed(installment generated.Installment) pkg.Money {
+	return pkg.MoneyFromFloat(installment.RemainingAmount)
+}
+
+func isOverdue(installment generated.Installment, today time.Time) bool {
+	y, m, d := today.Date()
+
+	return installment.DueDate.Before(time.Date(y, m, d, 0, 0, 0, 0, installment.DueDate.Location()))
+}
+
+// loanAllocationPlan holds what is needed to allocate a payment to a loan with its product's strategy.
+type loanAllocationPlan struct {
+	strategy     allocationStrategy
+	terms        allocationTerms
+	installments []generated.Installment
+	// penalties are settled before any installment whatever the strategy
+	penalties []generated.InstallmentPenalty
+}
+
+func getLoanAllocationPlan(
+	ctx context.Context,
+	q generated.Querier,
+	loanID uint32,
+) (loanAllocationPlan, error) {
+	terms, err := q.GetLoanAllocationTerms(ctx, loanID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return loanAllocationPlan{}, pkg.Errorf(pkg.NOT_FOUND_ERROR, "loan not found")
+		}
+
+		return loanAllocationPlan{}, pkg.Errorf(
+			pkg.INTERNAL_ERROR,
+			"failed to get loan allocation terms: %s",
+			err.Error(),
+		)
+	}
+
+	installments, err := q.ListUnpaidInstallmentsByLoan(ctx, loanID)
+	if err != nil {
+		return loanAllocationPlan{}, pkg.Errorf(
+			pkg.INTERNAL_ERROR,
+			"failed to list unpaid installments: %s",
+			err.Error(),
+		)
+	}
+
+	penalties, err := q.ListUnpaidPenaltiesByLoan(ctx, loanID)
+	if err != nil {
+		return loanAllocationPlan{}, pkg.Errorf(
+			pkg.INTERNAL_ERROR,
+			"failed to list unpaid penalties: %s",
+			err.Error(),
+		)
+	}
+
+	return loanAllocationPlan{
+		strategy: allocationStrategyFor(terms.Strategy),
+		terms: allocationTerms{
+			RepayAmount:    pkg.MoneyFromFloat(terms.RepayAmount),
+			InterestAmount: pkg.MoneyFromFloat(terms.InterestAmount),
+			Today:          time.Now().In(pkg.NairobiLocation()),
+		},
+		installments: installments,
+		penalties:    penalties,
+	}, nil
+}
+
+// outstanding is what clears the loan, its unpaid penalties and installments.
+func (lp loanAllocationPlan) outstanding() pkg.Money {
+	total := pkg.Money(0)
+	for _, penalty := range lp.penalties {
+		total += pkg.MoneyFromFloat(penalty.RemainingAmount)
+	}
+
+	for _, installment := range lp.installments {
+		total += owed(installment)
+	}
+
+	return total
+}
+
+func (lp loanAllocationPlan) allocate(amount pkg.Money) []installmentAllocation {
+	if amount <= 0 || len(lp.installments) == 0 {
+		return nil
+	}
+
+	return lp.strategy.allocate(amount, lp.installments, lp.terms)
+}
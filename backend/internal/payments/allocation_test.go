@@ -0,0 +1,216 @@
+package payments
+
+import (
+	"testing"
+	"time"
+
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/mysql/generated"
+	"github.com/EmilioCliff/kokomed-fin/backend/pkg"
+	"github.com/stretchr/testify/require"
+)
+
+type plannedPayment struct {
+	InstallmentID uint32
+	Amount        pkg.Money
+	Component     string
+}
+
+// allocationInstallments are four weekly installments of 1300 from a loan repaying 5200 with 1200
+// interest. The first one has 300 left on it and, as of allocationToday, the first two are overdue.
+func allocationInstallments() []generated.Installment {
+	firstDue := time.Date(2025, 2, 24, 0, 0, 0, 0, time.UTC)
+
+	installments := make([]generated.Installment, 4)
+	for idx := range installments {
+		installments[idx] = generated.Installment{
+			ID:                uint32(idx + 1),
+			LoanID:            1,
+			InstallmentNumber: uint32(idx + 1),
+			AmountDue:         1300,
+			RemainingAmount:   1300,
+			DueDate:           firstDue.AddDate(0, 0, 7*idx),
+		}
+	}
+
+	installments[0].RemainingAmount = 300
+
+	return installments
+}
+
+var allocationToday = time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+
+func TestAllocationStrategies(t *testing.T) {
+	terms := allocationTerms{
+		RepayAmount:    pkg.MoneyFromFloat(5200),
+		InterestAmount: pkg.MoneyFromFloat(1200),
+		Today:          allocationToday,
+	}
+
+	beforeAnyDue := terms
+	beforeAnyDue.Today = time.Date(2025, 2, 20, 9, 0, 0, 0, time.UTC)
+
+	withoutTerms := terms
+	withoutTerms.RepayAmount = 0
+	withoutTerms.InterestAmount = 0
+
+	tests := []struct {
+		name     string
+		strategy string
+		amount   pkg.Money
+		terms    allocationTerms
+		want     []plannedPayment
+	}{
+		{
+			name:     "oldest first",
+			strategy: string(generated.ProductAllocationStrategiesStrategyOLDESTFIRST),
+			amount:   pkg.MoneyFromFloat(2000),
+			terms:    terms,
+			want: []plannedPayment{
+				{InstallmentID: 1, Amount: pkg.MoneyFromFloat(300)},
+				{InstallmentID: 2, Amount: pkg.MoneyFromFloat(1300)},
+				{InstallmentID: 3, Amount: pkg.MoneyFromFloat(400)},
+			},
+		},
+		{
+			name:     "oldest first more than is owed",
+			strategy: string(generated.ProductAllocationStrategiesStrategyOLDESTFIRST),
+			amount:   pkg.MoneyFromFloat(5000),
+			terms:    terms,
+			want: []plannedPayment{
+				{InstallmentID: 1, Amount: pkg.MoneyFromFloat(300)},
+				{InstallmentID: 2, Amount: pkg.MoneyFromFloat(1300)},
+				{InstallmentID: 3, Amount: pkg.MoneyFromFloat(1300)},
+				{InstallmentID: 4, Amount: pkg.MoneyFromFloat(1300)},
+			},
+		},
+		{
+			name:     "unknown strategy pays oldest first",
+			strategy: "UNKNOWN",
+			amount:   pkg.MoneyFromFloat(500),
+			terms:    terms,
+			want: []plannedPayment{
+				{InstallmentID: 1, Amount: pkg.MoneyFromFloat(300)},
+				{InstallmentID: 2, Amount: pkg.MoneyFromFloat(200)},
+			},
+		},
+		{
+			name:     "current first",
+			strategy: string(generated.ProductAllocationStrategiesStrategyCURRENTFIRST),
+			amount:   pkg.MoneyFromFloat(2000),
+			terms:    terms,
+			want: []plannedPayment{
+				{InstallmentID: 3, Amount: pkg.MoneyFromFloat(1300)},
+				{InstallmentID: 1, Amount: pkg.MoneyFromFloat(300)},
+				{InstallmentID: 2, Amount: pkg.MoneyFromFloat(400)},
+			},
+		},
+		{
+			name:     "current first without arrears",
+			strategy: string(generated.ProductAllocationStrategiesStrategyCURRENTFIRST),
+			amount:   pkg.MoneyFromFloat(500),
+			terms:    beforeAnyDue,
+			want: []plannedPayment{
+				{InstallmentID: 1, Amount: pkg.MoneyFromFloat(300)},
+				{InstallmentID: 2, Amount: pkg.MoneyFromFloat(200)},
+			},
+		},
+		{
+			name:     "spread overdue",
+			strategy: string(generated.ProductAllocationStrategiesStrategySPREADOVERDUE),
+			amount:   pkg.MoneyFromFloat(800),
+			terms:    terms,
+			want: []plannedPayment{
+				{InstallmentID: 1, Amount: pkg.MoneyFromFloat(150)},
+				{InstallmentID: 2, Amount: pkg.MoneyFromFloat(650)},
+			},
+		},
+		{
+			name:     "spread overdue rounding goes to the last overdue installment",
+			strategy: string(generated.ProductAllocationStrategiesStrategySPREADOVERDUE),
+			amount:   pkg.MoneyFromFloat(100),
+			terms:    terms,
+			want: []plannedPayment{
+				{InstallmentID: 1, Amount: pkg.MoneyFromFloat(18.75)},
+				{InstallmentID: 2, Amount: pkg.MoneyFromFloat(81.25)},
+			},
+		},
+		{
+			name:     "spread overdue clears the arrears then pays in order",
+			strategy: string(generated.ProductAllocationStrategiesStrategySPREADOVERDUE),
+			amount:   pkg.MoneyFromFloat(2000),
+			terms:    terms,
+			want: []plannedPayment{
+				{InstallmentID: 1, Amount: pkg.MoneyFromFloat(300)},
+				{InstallmentID: 2, Amount: pkg.MoneyFromFloat(1300)},
+				{InstallmentID: 3, Amount: pkg.MoneyFromFloat(400)},
+			},
+		},
+		{
+			name:     "spread overdue without arrears",
+			strategy: string(generated.ProductAllocationStrategiesStrategySPREADOVERDUE),
+			amount:   pkg.MoneyFromFloat(500),
+			terms:    beforeAnyDue,
+			want: []plannedPayment{
+				{InstallmentID: 1, Amount: pkg.MoneyFromFloat(300)},
+				{InstallmentID: 2, Amount: pkg.MoneyFromFloat(200)},
+			},
+		},
+		{
+			name:     "waterfall pays interest before principal",
+			strategy: string(generated.ProductAllocationStrategiesStrategyWATERFALL),
+			amount:   pkg.MoneyFromFloat(1000),
+			terms:    terms,
+			want: []plannedPayment{
+				{InstallmentID: 2, Amount: pkg.MoneyFromFloat(300), Component: allocationComponentInterest},
+				{InstallmentID: 3, Amount: pkg.MoneyFromFloat(300), Component: allocationComponentInterest},
+				{InstallmentID: 4, Amount: pkg.MoneyFromFloat(300), Component: allocationComponentInterest},
+				{InstallmentID: 1, Amount: pkg.MoneyFromFloat(100), Component: allocationComponentPrincipal},
+			},
+		},
+		{
+			name:     "waterfall short of the interest",
+			strategy: string(generated.ProductAllocationStrategiesStrategyWATERFALL),
+			amount:   pkg.MoneyFromFloat(400),
+			terms:    terms,
+			want: []plannedPayment{
+				{InstallmentID: 2, Amount: pkg.MoneyFromFloat(300), Component: allocationComponentInterest},
+				{InstallmentID: 3, Amount: pkg.MoneyFromFloat(100), Component: allocationComponentInterest},
+			},
+		},
+		{
+			name:     "waterfall without product terms pays in order",
+			strategy: string(generated.ProductAllocationStrategiesStrategyWATERFALL),
+			amount:   pkg.MoneyFromFloat(500),
+			terms:    withoutTerms,
+			want: []plannedPayment{
+				{InstallmentID: 1, Amount: pkg.MoneyFromFloat(300)},
+				{InstallmentID: 2, Amount: pkg.MoneyFromFloat(200)},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			allocations := allocationStrategyFor(tc.strategy).allocate(
+				tc.amount,
+				allocationInstallments(),
+				tc.terms,
+			)
+
+			got := make([]plannedPayment, len(allocations))
+			total := pkg.Money(0)
+
+			for idx, allocation := range allocations {
+				got[idx] = plannedPayment{
+					InstallmentID: allocation.Installment.ID,
+					Amount:        allocation.Amount,
+					Component:     allocation.Component,
+				}
+				total += allocation.Amount
+			}
+
+			require.Equal(t, tc.want, got)
+			require.LessOrEqual(t, total, tc.amount)
+		})
+	}
+}
@@ -0,0 +1,279 @@
+package payments
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/mysql"
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/mysql/generated"
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/repository"
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/services"
+	"github.com/EmilioCliff/kokomed-fin/backend/pkg"
+)
+
+func (p *PaymentService) InitiateB2CDisbursement(
+	ctx context.Context,
+	disburseData *services.B2CDisbursementData,
+) (services.B2CDisbursementResult, error) {
+	if !p.config.MPESA_B2C_ENABLED {
+		return services.B2CDisbursementResult{}, pkg.Errorf(
+			pkg.INVALID_ERROR,
+			"mpesa b2c disbursement is not enabled",
+		)
+	}
+
+	loan, err := p.mySQL.Loans.GetLoanByID(ctx, disburseData.LoanID)
+	if err != nil {
+		return services.B2CDisbursementResult{}, err
+	}
+
+	product, err := p.mySQL.Products.GetProductByID(ctx, loan.ProductID)
+	if err != nil {
+		return services.B2CDisbursementResult{}, err
+	}
+
+	amount := product.LoanAmount
+	feeDeducted := false
+
+	if p.config.MPESA_B2C_DEDUCT_PROCESSING_FEE && !loan.FeePaid {
+		amount -= loan.ProcessingFee
+		feeDeducted = true
+	}
+
+	if amount <= 0 {
+		return services.B2CDisbursementResult{}, pkg.Errorf(
+			pkg.INVALID_ERROR,
+			"nothing to disburse after the processing fee",
+		)
+	}
+
+	// daraja only accepts whole shillings, flooring would pay the client less than the loan
+	if !amount.Whole() {
+		return services.B2CDisbursementResult{}, pkg.Errorf(
+			pkg.INVALID_ERROR,
+			"mpesa disbursements must be in whole shillings, %s has cents",
+			amount,
+		)
+	}
+
+	client, err := p.mySQL.Clients.GetClientFullData(ctx, loan.ClientID)
+	if err != nil {
+		return services.B2CDisbursementResult{}, err
+	}
+
+	// the loan is moved to DISBURSING before the payout so it cannot be paid out twice
+	err = p.db.ExecTx(ctx, func(q generated.Querier) error {
+		return claimLoanForDisbursement(ctx, q, loan.ClientID, loan.ID)
+	})
+	if err != nil {
+		return services.B2CDisbursementResult{}, err
+	}
+
+	rsp, err := p.mpesa.B2CPayment(pkg.B2CRequest{
+		PhoneNumber: client.PhoneNumber,
+		Amount:      int64(amount / 100),
+		Remarks:     fmt.Sprintf("Loan %d disbursement", loan.ID),
+		Occasion:    fmt.Sprintf("LN%d", loan.ID),
+		ResultURL:   p.config.MPESA_B2C_RESULT_URL,
+		TimeoutURL:  p.config.MPESA_B2C_TIMEOUT_URL,
+	})
+	if err != nil {
+		if txErr := p.db.ExecTx(ctx, func(q generated.Querier) error {
+			return updateLoanStatus(ctx, q, loan.ID, generated.LoansStatusINACTIVE)
+		}); txErr != nil {
+			return services.B2CDisbursementResult{}, txErr
+		}
+
+		return services.B2CDisbursementResult{}, err
+	}
+
+	err = p.db.ExecTx(ctx, func(q generated.Querier) error {
+		_, err := q.CreateLoanDisbursement(ctx, generated.CreateLoanDisbursementParams{
+			LoanID:                   loan.ID,
+			PhoneNumber:              client.PhoneNumber,
+			Amount:                   amount.Float64(),
+			FeeDeducted:              feeDeducted,
+			ConversationID:           rsp.ConversationID,
+			OriginatorConversationID: rsp.OriginatorConversationID,
+			InitiatedBy:              disburseData.InitiatedBy,
+		})
+		if err != nil {
+			return pkg.Errorf(
+				pkg.INTERNAL_ERROR,
+				"failed to create loan disbursement: %s",
+				err.Error(),
+			)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return services.B2CDisbursementResult{}, err
+	}
+
+	return services.B2CDisbursementResult{
+		ConversationID:           rsp.ConversationID,
+		OriginatorConversationID: rsp.OriginatorConversationID,
+		PhoneNumber:              client.PhoneNumber,
+		Amount:                   amount.Float64(),
+		FeeDeducted:              feeDeducted,
+	}, nil
+}
+
+// claimLoanForDisbursement moves an inactive loan to DISBURSING. The client and the loan are
+// locked first so a second disbursement waits and then finds the loan already claimed.
+func claimLoanForDisbursement(ctx context.Context, q generated.Querier, clientID, loanID uint32) error {
+	if err := mysql.LockLoanForPayment(ctx, q, clientID, loanID); err != nil {
+		return err
+	}
+
+	status, err := q.GetLoanStatus(ctx, loanID)
+	if err != nil {
+		return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to get loan status: %s", err.Error())
+	}
+
+	if status != generated.LoansStatusINACTIVE {
+		return pkg.Errorf(pkg.INVALID_ERROR, "only inactive loans can be disbursed")
+	}
+
+	busy := []struct {
+		status  generated.LoansStatus
+		message string
+	}{
+		{generated.LoansStatusACTIVE, "client already has an active loan"},
+		{generated.LoansStatusDISBURSING, "client already has a loan being disbursed"},
+	}
+
+	for _, b := range busy {
+		_, err := q.GetClientActiveLoan(ctx, generated.GetClientActiveLoanParams{
+			ClientID: clientID,
+			Status:   b.status,
+		})
+		if err == nil {
+			return pkg.Errorf(pkg.ALREADY_EXISTS_ERROR, b.message)
+		}
+
+		if err != sql.ErrNoRows {
+			return pkg.Errorf(
+				pkg.INTERNAL_ERROR,
+				"failed to get client active loan: %s",
+				err.Error(),
+			)
+		}
+	}
+
+	return updateLoanStatus(ctx, q, loanID, generated.LoansStatusDISBURSING)
+}
+
+func (p *PaymentService) ProcessB2CResult(
+	ctx context.Context,
+	resultData *services.B2CResultData,
+) (uint32, error) {
+	var disbursement generated.LoanDisbursement
+	disbursementFound := true
+
+	// the disbursement row is locked so a redelivered result waits and then sees it settled,
+	// the loan is activated in the same transaction the result is recorded in
+	err := p.db.ExecTx(ctx, func(q generated.Querier) error {
+		var err error
+
+		disbursementFound = true
+
+		disbursement, err = q.LockLoanDisbursementByConversationID(ctx, resultData.ConversationID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				disbursementFound = false
+
+				return nil
+			}
+
+			return pkg.Errorf(
+				pkg.INTERNAL_ERROR,
+				"failed to get loan disbursement: %s",
+				err.Error(),
+			)
+		}
+
+		// daraja can deliver the same result more than once
+		if disbursement.Status != generated.LoanDisbursementsStatusPENDING {
+			return nil
+		}
+
+		params := generated.UpdateLoanDisbursementResultParams{
+			ID:     disbursement.ID,
+			Status: generated.LoanDisbursementsStatusFAILED,
+			ResultCode: sql.NullInt32{
+				Valid: true,
+				Int32: int32(resultData.ResultCode),
+			},
+			ResultDesc: sql.NullString{
+				Valid:  true,
+				String: resultData.ResultDesc,
+			},
+		}
+
+		if resultData.ResultCode == 0 {
+			disbursedOn := time.Now().In(pkg.NairobiLocation())
+			if resultData.CompletedAt != nil {
+				disbursedOn = *resultData.CompletedAt
+			}
+
+			// activating like a manual disbursement creates the installments
+			disburseLoan := repository.DisburseLoan{
+				ID:          disbursement.LoanID,
+				DisbursedBy: disbursement.InitiatedBy,
+				Status:      pkg.StringPtr(string(generated.LoansStatusACTIVE)),
+				DisbursedOn: pkg.TimePtr(disbursedOn),
+			}
+
+			if disbursement.FeeDeducted {
+				disburseLoan.FeePaid = pkg.BoolPtr(true)
+			}
+
+			if err := mysql.DisburseB2CLoan(ctx, q, &disburseLoan); err != nil {
+				return err
+			}
+
+			params.Status = generated.LoanDisbursementsStatusCOMPLETED
+			params.TransactionID = sql.NullString{
+				Valid:  true,
+				String: resultData.TransactionID,
+			}
+		} else {
+			if err := updateLoanStatus(ctx, q, disbursement.LoanID, generated.LoansStatusINACTIVE); err != nil {
+				return err
+			}
+		}
+
+		if _, err := q.UpdateLoanDisbursementResult(ctx, params); err != nil {
+			return pkg.Errorf(
+				pkg.INTERNAL_ERROR,
+				"failed to update loan disbursement: %s",
+				err.Error(),
+			)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if !disbursementFound {
+		// b2c is also used to pay out overpayment refunds
+		found, err := p.processRefundPayoutResult(ctx, resultData)
+		if err != nil {
+			return 0, err
+		}
+
+		if !found {
+			return 0, pkg.Errorf(pkg.NOT_FOUND_ERROR, "no loan disbursement found")
+		}
+
+		return 0, nil
+	}
+
+	return disbursement.LoanID, nil
+}
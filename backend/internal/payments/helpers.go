@@ -4,8 +4,10 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/mysql"
 	"github.com/EmilioCliff/kokomed-fin/backend/internal/mysql/generated"
 	"github.com/EmilioCliff/kokomed-fin/backend/internal/repository"
 	"github.com/EmilioCliff/kokomed-fin/backend/pkg"
@@ -17,103 +19,74 @@ func processLoanPayment(
 	loan *repository.UpdateLoan,
 	paymentID uint32,
 	clientID uint32,
-	offsetAmount float64,
+	offsetAmount pkg.Money,
 	allocationMessage string,
 ) error {
-	totalPaid := 0.0
+	// a client without a payable loan has no loan to lock, the payment is kept as overpayment
+	if loan.ID == 0 {
+		if err := mysql.LockClientForUpdate(ctx, q, clientID); err != nil {
+			return err
+		}
 
-	installments, err := q.ListUnpaidInstallmentsByLoan(ctx, loan.ID)
-	if err != nil {
-		return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to list unpaid installments: %s", err.Error())
+		return creditOverpayment(
+			ctx,
+			q,
+			clientID,
+			paymentID,
+			loan.PaidAmount,
+			fmt.Sprintf("%s: no active loan adding to overpayment", allocationMessage),
+		)
 	}
 
-	var allocations []repository.PaymentAllocation
-	for _, i := range installments {
-		if loan.PaidAmount >= i.RemainingAmount {
-			_, err := q.PayInstallment(ctx, generated.PayInstallmentParams{
-				ID:              i.ID,
-				RemainingAmount: 0,
-				Paid: sql.NullBool{
-					Valid: true,
-					Bool:  true,
-				},
-				PaidAt: sql.NullTime{
-					Valid: true,
-					Time:  time.Now(),
-				},
-			})
-			if err != nil {
-				return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to pay installment: %s", err.Error())
-			}
-
-			oldPaidAmount := loan.PaidAmount
-			remainingAmount := i.RemainingAmount
-			expectedNewAmount := oldPaidAmount - remainingAmount
-			loan.PaidAmount = expectedNewAmount
-
-			if loan.PaidAmount != expectedNewAmount {
-				loan.PaidAmount = expectedNewAmount
-			}
-
-			totalPaid += remainingAmount
-			allocations = append(allocations, repository.PaymentAllocation{
-				NonPostedID:   paymentID,
-				LoanID:        &loan.ID,
-				InstallmentID: &i.ID,
-				Amount:        remainingAmount,
-				Description:   fmt.Sprintf("%s: installment paid fully", allocationMessage),
-			})
-		} else {
-			if loan.PaidAmount == 0 {
-				break
-			}
-
-			partialPayment := loan.PaidAmount
-			newRemainingAmount := i.RemainingAmount - partialPayment
-
-			_, err := q.PayInstallment(ctx, generated.PayInstallmentParams{
-				ID:              i.ID,
-				RemainingAmount: newRemainingAmount,
-			})
-			if err != nil {
-				return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to pay installment: %s", err.Error())
-			}
+	if err := mysql.LockLoanForPayment(ctx, q, clientID, loan.ID); err != nil {
+		return err
+	}
 
-			oldTotalPaid := totalPaid
-			totalPaid += partialPayment
+	plan, err := getLoanAllocationPlan(ctx, q, loan.ID)
+	if err != nil {
+		return err
+	}
 
-			if totalPaid != oldTotalPaid+partialPayment {
-				totalPaid = oldTotalPaid + partialPayment
-			}
+	penaltiesPaid, err := payLoanPenalties(
+		ctx,
+		q,
+		loan.PaidAmount,
+		loan.ID,
+		paymentID,
+		plan.penalties,
+		allocationMessage,
+	)
+	if err != nil {
+		return err
+	}
 
-			loan.PaidAmount = 0
+	// penalties are not part of the loan's paid amount, only what reaches the installments is
+	loan.PaidAmount -= penaltiesPaid
 
-			allocations = append(allocations, repository.PaymentAllocation{
-				NonPostedID:   paymentID,
-				LoanID:        &loan.ID,
-				InstallmentID: &i.ID,
-				Amount:        partialPayment,
-				Description:   fmt.Sprintf("%s: installment paid partially", allocationMessage),
-			})
+	installments := plan.installments
 
-			break
-		}
+	totalPaid, err := payInstallments(
+		ctx,
+		q,
+		loan.ID,
+		paymentID,
+		plan.allocate(loan.PaidAmount),
+		allocationMessage,
+	)
+	if err != nil {
+		return err
 	}
 
-	for _, allocation := range allocations {
-		if err := createAllocation(ctx, q, allocation); err != nil {
-			return err
-		}
-	}
+	loan.PaidAmount -= totalPaid
 
 	if len(installments) > 0 {
 		params := generated.UpdateLoanParams{
 			ID:         loan.ID,
-			PaidAmount: totalPaid,
+			PaidAmount: totalPaid.Float64(),
 		}
 
 		if offsetAmount > 0 {
-			params.PaidAmount = totalPaid - offsetAmount
+			params.PaidAmount = (totalPaid - offsetAmount).Float64()
 		}
 
 		if loan.UpdatedBy != nil {
@@ -130,22 +103,14 @@ func processLoanPayment(
 	}
 
 	if loan.PaidAmount > 0 {
-		if err := updateOverpayment(ctx, q, repository.Overpayment{
-			ClientID:    clientID,
-			Amount:      loan.PaidAmount,
-			PaymentID:   &paymentID,
-			Description: fmt.Sprintf("%s: loan is paid fully adding to overpayment", allocationMessage),
-		}); err != nil {
-			return err
-		}
-
-		if err = createAllocation(ctx, q, repository.PaymentAllocation{
-			NonPostedID:   paymentID,
-			Amount:        loan.PaidAmount,
-			LoanID:        nil,
-			InstallmentID: nil,
-			Description:   fmt.Sprintf("%s: loan is paid fully adding to overpayment", allocationMessage),
-		}); err != nil {
+		if err := creditOverpayment(
+			ctx,
+			q,
+			clientID,
+			paymentID,
+			loan.PaidAmount,
+			fmt.Sprintf("%s: loan is paid fully adding to overpayment", allocationMessage),
+		); err != nil {
 			return err
 		}
 	}
@@ -155,7 +120,12 @@ func processLoanPayment(
 		return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to list unpaid installments: %s", err.Error())
 	}
 
-	if len(installmentsLeft) == 0 {
+	penaltiesLeft, err := q.CountUnpaidPenaltiesByLoan(ctx, loan.ID)
+	if err != nil {
+		return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to count unpaid penalties: %s", err.Error())
+	}
+
+	if len(installmentsLeft) == 0 && penaltiesLeft == 0 {
 		_, err = q.UpdateLoanStatus(ctx, generated.UpdateLoanStatusParams{
 			ID:     loan.ID,
 			Status: generated.LoansStatusCOMPLETED,
@@ -163,9 +133,149 @@ func processLoanPayment(
 		if err != nil {
 			return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to change loans status: %s", err.Error())
 		}
+
+		return nil
 	}
 
-	return nil
+	return mysql.RestoreDefaultedLoan(ctx, q, loan.ID)
+}
+
+// creditOverpayment adds what the payment could not pay on a loan to the client's overpayment and
+// allocates it there.
+func creditOverpayment(
+	ctx context.Context,
+	q generated.Querier,
+	clientID uint32,
+	paymentID uint32,
+	amount pkg.Money,
+	description string,
+) error {
+	if amount <= 0 {
+		return nil
+	}
+
+	if err := updateOverpayment(ctx, q, repository.Overpayment{
+		ClientID:    clientID,
+		Amount:      amount,
+		PaymentID:   &paymentID,
+		Description: description,
+	}); err != nil {
+		return err
+	}
+
+	return createAllocation(ctx, q, repository.PaymentAllocation{
+		NonPostedID:   paymentID,
+		Amount:        amount,
+		LoanID:        nil,
+		InstallmentID: nil,
+		Description:   description,
+	})
+}
+
+// payInstallments pays the planned allocations on the installments, allocates the payment to
+// them and returns what was paid.
+func payInstallments(
+	ctx context.Context,
+	q generated.Querier,
+	loanID uint32,
+	paymentID uint32,
+	planned []installmentAllocation,
+	allocationMessage string,
+) (pkg.Money, error) {
+	// a strategy can pay parts of the same installment separately
+	paidByInstallment := make(map[uint32]pkg.Money, len(planned))
+	var installments []generated.Installment
+
+	for _, a := range planned {
+		if _, ok := paidByInstallment[a.Installment.ID]; !ok {
+			installments = append(installments, a.Installment)
+		}
+
+		paidByInstallment[a.Installment.ID] += a.Amount
+	}
+
+	for _, i := range installments {
+		paid := paidByInstallment[i.ID]
+
+		params := generated.PayInstallmentParams{
+			ID:              i.ID,
+			RemainingAmount: (owed(i) - paid).Float64(),
+		}
+
+		if owed(i)-paid <= 0 {
+			params.RemainingAmount = 0
+			params.Paid = sql.NullBool{
+				Valid: true,
+				Bool:  true,
+			}
+			params.PaidAt = sql.NullTime{
+				Valid: true,
+				Time:  time.Now(),
+			}
+		}
+
+		if _, err := q.PayInstallment(ctx, params); err != nil {
+			return 0, pkg.Errorf(pkg.INTERNAL_ERROR, "failed to pay installment: %s", err.Error())
+		}
+	}
+
+	totalPaid := pkg.Money(0)
+
+	for _, a := range planned {
+		description := fmt.Sprintf("%s: installment paid partially", allocationMessage)
+		if owed(a.Installment)-paidByInstallment[a.Installment.ID] <= 0 {
+			description = fmt.Sprintf("%s: installment paid fully", allocationMessage)
+		}
+
+		if a.Component != "" {
+			description = fmt.Sprintf("%s (%s)", description, strings.ToLower(a.Component))
+		}
+
+		if err := createAllocation(ctx, q, repository.PaymentAllocation{
+			NonPostedID:   paymentID,
+			LoanID:        &loanID,
+			InstallmentID: pkg.Uint32Ptr(a.Installment.ID),
+			Amount:        a.Amount,
+			Description:   description,
+		}); err != nil {
+			return 0, err
+		}
+
+		totalPaid += a.Amount
+	}
+
+	return totalPaid, nil
+}
+
+// lockForRevert locks the clients and loans the allocations paid, with the clients and loans
+// the caller pays after reverting them, before any of them is changed.
+func lockForRevert(
+	ctx context.Context,
+	q generated.Querier,
+	allocations []generated.PaymentAllocation,
+	clientIDs []uint32,
+	loanIDs []uint32,
+) error {
+	for _, allocation := range allocations {
+		if allocation.LoanID.Valid {
+			loanIDs = append(loanIDs, uint32(allocation.LoanID.Int32))
+		}
+	}
+
+	for _, loanID := range loanIDs {
+		loan, err := q.GetLoan(ctx, loanID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return pkg.Errorf(pkg.NOT_FOUND_ERROR, "loan %d not found", loanID)
+			}
+
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to get loan: %s", err.Error())
+		}
+
+		clientIDs = append(clientIDs, loan.ClientID)
+	}
+
+	return mysql.LockClientsAndLoans(ctx, q, clientIDs, loanIDs)
 }
 
 func revertInstallment(
@@ -174,7 +284,22 @@ func revertInstallment(
 	installmentID uint32,
 	paidAmount float64,
 ) error {
-	_, err := q.RevertInstallment(ctx, generated.RevertInstallmentParams{
+	installment, err := q.GetInstallment(ctx, installmentID)
+	if err != nil {
+		return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to get installment: %s", err.Error())
+	}
+
+	// what was owed on a closed installment was carried into a restructured schedule or
+	// rebated when the loan was settled early
+	if installment.ClosedAt.Valid {
+		return pkg.Errorf(
+			pkg.INVALID_ERROR,
+			"payment was allocated to installment %d before it was closed",
+			installment.InstallmentNumber,
+		)
+	}
+
+	_, err = q.RevertInstallment(ctx, generated.RevertInstallmentParams{
 		ID:              installmentID,
 		RemainingAmount: paidAmount,
 	})
@@ -194,6 +319,10 @@ func deductOverpayment(
 	q generated.Querier,
 	data repository.Overpayment,
 ) error {
+	if err := mysql.LockClientForUpdate(ctx, q, data.ClientID); err != nil {
+		return err
+	}
+
 	overpayment, err := q.GetClientOverpayment(ctx, data.ClientID)
 	if err != nil {
 		return pkg.Errorf(
@@ -203,7 +332,7 @@ func deductOverpayment(
 		)
 	}
 
-	if overpayment < data.Amount {
+	if pkg.MoneyFromFloat(overpayment) < data.Amount {
 		return pkg.Errorf(
 			pkg.INVALID_ERROR,
 			"client overpayment is less than the amount")
@@ -211,7 +340,7 @@ func deductOverpayment(
 
 	_, err = q.DeductClientOverpayment(ctx, generated.DeductClientOverpaymentParams{
 		ID:          data.ClientID,
-		Overpayment: data.Amount,
+		Overpayment: data.Amount.Float64(),
 	})
 	if err != nil {
 		return pkg.Errorf(
@@ -223,11 +352,18 @@ func deductOverpayment(
 
 	params := generated.CreateClientOverpaymentTransactionParams{
 		ClientID:    data.ClientID,
-		Amount:      data.Amount,
+		Amount:      data.Amount.Float64(),
 		CreatedBy:   data.CreatedBy,
 		Description: data.Description,
 	}
 
+	if data.PaymentID != nil {
+		params.PaymentID = sql.NullInt32{
+			Valid: true,
+			Int32: int32(*data.PaymentID),
+		}
+	}
+
 	_, err = q.CreateClientOverpaymentTransaction(ctx, params)
 	if err != nil {
 		return pkg.Errorf(
@@ -247,7 +383,7 @@ func updateOverpayment(
 ) error {
 	_, err := q.UpdateClientOverpayment(ctx, generated.UpdateClientOverpaymentParams{
 		ClientID:    overpaymentParams.ClientID,
-		Overpayment: overpaymentParams.Amount,
+		Overpayment: overpaymentParams.Amount.Float64(),
 	})
 	if err != nil {
 		return pkg.Errorf(
@@ -258,16 +394,20 @@ func updateOverpayment(
 	}
 
 	params := generated.CreateClientOverpaymentTransactionParams{
-		ClientID: overpaymentParams.ClientID,
-		Amount:   overpaymentParams.Amount,
-		PaymentID: sql.NullInt32{
-			Valid: true,
-			Int32: int32(*overpaymentParams.PaymentID),
-		},
+		ClientID:    overpaymentParams.ClientID,
+		Amount:      overpaymentParams.Amount.Float64(),
 		CreatedBy:   "SYSTEM",
 		Description: overpaymentParams.Description,
 	}
 
+	// balances restored without a payment, e.g. a failed refund, have no payment id
+	if overpaymentParams.PaymentID != nil {
+		params.PaymentID = sql.NullInt32{
+			Valid: true,
+			Int32: int32(*overpaymentParams.PaymentID),
+		}
+	}
+
 	_, err = q.CreateClientOverpaymentTransaction(ctx, params)
 	if err != nil {
 		return pkg.Errorf(
@@ -291,9 +431,10 @@ func createNonPosted(
 		AccountNumber:     params.AccountNumber,
 		PhoneNumber:       params.PhoneNumber,
 		PayingName:        params.PayingName,
-		Amount:            params.Amount,
+		Amount:            params.Amount.Float64(),
 		PaidDate:          params.PaidDate,
 		AssignedBy:        params.AssignedBy,
+		CashReceipt:       params.CashReceipt,
 	}
 
 	if params.AssignedTo != nil {
@@ -303,6 +444,16 @@ func createNonPosted(
 		}
 	}
 
+	if err := mysql.CheckPaymentCashBookOpen(
+		ctx,
+		q,
+		params.CashReceipt,
+		params.AssignedBy,
+		params.PaidDate,
+	); err != nil {
+		return 0, err
+	}
+
 	nonPostedExecResult, err := q.CreateNonPosted(ctx, nonPostedParams)
 	if err != nil {
 		return 0, pkg.Errorf(
@@ -321,9 +472,48 @@ func createNonPosted(
 		)
 	}
 
+	if err := postPaymentReceived(ctx, q, uint32(nonPostedID), params); err != nil {
+		return 0, err
+	}
+
 	return uint32(nonPostedID), nil
 }
 
+func recordProcessedCallback(
+	ctx context.Context,
+	q generated.Querier,
+	params *repository.NonPosted,
+	nonPostedID uint32,
+	loanID *uint32,
+) error {
+	if params.TransactionNumber == "" {
+		return nil
+	}
+
+	processedParams := generated.CreateProcessedCallbackParams{
+		TransactionNumber: params.TransactionNumber,
+		TransactionSource: params.TransactionSource,
+		NonPostedID:       nonPostedID,
+	}
+
+	if loanID != nil {
+		processedParams.LoanID = sql.NullInt32{
+			Valid: true,
+			Int32: int32(*loanID),
+		}
+	}
+
+	if _, err := q.CreateProcessedCallback(ctx, processedParams); err != nil {
+		return pkg.Errorf(
+			pkg.INTERNAL_ERROR,
+			"failed to record processed callback: %s",
+			err.Error(),
+		)
+	}
+
+	return nil
+}
+
 func createAllocation(
 	ctx context.Context,
 	q generated.Querier,
@@ -331,7 +521,7 @@ func createAllocation(
 ) error {
 	allocation := generated.CreatePaymentAllocationParams{
 		NonPostedID: allocationParams.NonPostedID,
-		Amount:      allocationParams.Amount,
+		Amount:      allocationParams.Amount.Float64(),
 		Description: allocationParams.Description,
 	}
 
@@ -349,6 +539,13 @@ func createAllocation(
 		}
 	}
 
+	if allocationParams.PenaltyID != nil {
+		allocation.PenaltyID = sql.NullInt32{
+			Valid: true,
+			Int32: int32(*allocationParams.PenaltyID),
+		}
+	}
+
 	_, err := q.CreatePaymentAllocation(ctx, allocation)
 	if err != nil {
 		return pkg.Errorf(
@@ -358,5 +555,21 @@ func createAllocation(
 		)
 	}
 
+	return postAllocation(ctx, q, allocationParams)
+}
+
+func updateLoanStatus(
+	ctx context.Context,
+	q generated.Querier,
+	loanID uint32,
+	status generated.LoansStatus,
+) error {
+	if _, err := q.UpdateLoanStatus(ctx, generated.UpdateLoanStatusParams{
+		ID:     loanID,
+		Status: status,
+	}); err != nil {
+		return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to update loan status: %s", err.Error())
+	}
+
 	return nil
 }
@@ -0,0 +1,701 @@
+package payments
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/mysql/generated"
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/services"
+	"github.com/EmilioCliff/kokomed-fin/backend/pkg"
+)
+
+// PreviewPaymentImport validates every line of an uploaded file and stores it as a batch that
+// can be reviewed before any payment is posted.
+func (p *PaymentService) PreviewPaymentImport(
+	ctx context.Context,
+	importData *services.PaymentImportData,
+) (services.PaymentImportBatch, error) {
+	if len(importData.Lines) == 0 {
+		return services.PaymentImportBatch{}, pkg.Errorf(pkg.INVALID_ERROR, "file has no payments")
+	}
+
+	var batchID uint32
+
+	err := p.db.ExecTx(ctx, func(q generated.Querier) error {
+		rows := make([]generated.CreatePaymentImportRowParams, len(importData.Lines))
+		references := make(map[string]uint32, len(importData.Lines))
+		validRows := uint32(0)
+
+		for i, line := range importData.Lines {
+			clientID, lineErr, err := validatePaymentImportLine(ctx, q, line, references)
+			if err != nil {
+				return err
+			}
+
+			rows[i] = generated.CreatePaymentImportRowParams{
+				LineNumber: line.LineNumber,
+				Identifier: line.Identifier,
+				Amount:     line.Amount,
+				Reference:  line.Reference,
+				Error:      lineErr,
+			}
+
+			if clientID != 0 {
+				rows[i].ClientID = sql.NullInt32{Valid: true, Int32: int32(clientID)}
+			}
+
+			if line.PaidDate != nil {
+				rows[i].PaidDate = sql.NullTime{Valid: true, Time: *line.PaidDate}
+			}
+
+			if lineErr == "" {
+				validRows++
+			}
+		}
+
+		execResult, err := q.CreatePaymentImportBatch(ctx, generated.CreatePaymentImportBatchParams{
+			FileName:   importData.FileName,
+			TotalRows:  uint32(len(rows)),
+			ValidRows:  validRows,
+			UploadedBy: importData.UploadedBy,
+		})
+		if err != nil {
+			return pkg.Errorf(
+				pkg.INTERNAL_ERROR,
+				"failed to create payment import batch: %s",
+				err.Error(),
+			)
+		}
+
+		id, err := execResult.LastInsertId()
+		if err != nil {
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to get last insert id: %s", err.Error())
+		}
+
+		batchID = uint32(id)
+
+		for _, row := range rows {
+			row.BatchID = batchID
+
+			if _, err := q.CreatePaymentImportRow(ctx, row); err != nil {
+				return pkg.Errorf(
+					pkg.INTERNAL_ERROR,
+					"failed to create payment import row: %s",
+					err.Error(),
+				)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return services.PaymentImportBatch{}, err
+	}
+
+	return p.GetPaymentImport(ctx, batchID)
+}
+
+// validatePaymentImportLine resolves the client a line is paying for. Problems with the line are
+// returned as a message so the rest of the file can still be previewed.
+func validatePaymentImportLine(
+	ctx context.Context,
+	q generated.Querier,
+	line pkg.PaymentImportLine,
+	references map[string]uint32,
+) (uint32, string, error) {
+	var errs []string
+	if line.Error != "" {
+		errs = append(errs, line.Error)
+	}
+
+	if line.Reference != "" {
+		reference := strings.ToUpper(line.Reference)
+		if firstLine, ok := references[reference]; ok {
+			errs = append(errs, fmt.Sprintf("reference already used on line %d", firstLine))
+		} else {
+			references[reference] = line.LineNumber
+
+			_, err := q.GetProcessedCallback(ctx, generated.GetProcessedCallbackParams{
+				TransactionNumber: line.Reference,
+				TransactionSource: string(generated.NonPostedTransactionSourceINTERNAL),
+			})
+			if err == nil {
+				errs = append(errs, "reference has already been posted")
+			} else if err != sql.ErrNoRows {
+				return 0, "", pkg.Errorf(
+					pkg.INTERNAL_ERROR,
+					"failed to get processed callback: %s",
+					err.Error(),
+				)
+			}
+		}
+	}
+
+	if line.PaidDate != nil && line.PaidDate.After(time.Now()) {
+		errs = append(errs, "date is in the future")
+	}
+
+	clientID := uint32(0)
+
+	if line.Identifier != "" {
+		client, found, err := matchClientByIDNumber(ctx, q, line.Identifier)
+		if err != nil {
+			return 0, "", err
+		}
+
+		if found {
+			clientID = client.ID
+		} else {
+			clients, err := matchClientsByPhone(ctx, q, line.Identifier)
+			if err != nil {
+				return 0, "", err
+			}
+
+			switch len(clients) {
+			case 0:
+				errs = append(errs, "no client found with the phone or id number")
+			case 1:
+				clientID = clients[0].ID
+			default:
+				errs = append(errs, "phone number matches more than one client")
+			}
+		}
+	}
+
+	return clientID, strings.Join(errs, "; "), nil
+}
+
+func (p *PaymentService) GetPaymentImport(
+	ctx context.Context,
+	id uint32,
+) (services.PaymentImportBatch, error) {
+	var batch generated.PaymentImportBatch
+	var rows []generated.PaymentImportRow
+
+	err := p.db.ExecTx(ctx, func(q generated.Querier) error {
+		var err error
+
+		batch, err = q.GetPaymentImportBatch(ctx, id)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return pkg.Errorf(pkg.NOT_FOUND_ERROR, "payment import not found")
+			}
+
+			return pkg.Errorf(
+				pkg.INTERNAL_ERROR,
+				"failed to get payment import batch: %s",
+				err.Error(),
+			)
+		}
+
+		rows, err = q.ListPaymentImportRows(ctx, id)
+		if err != nil {
+			return pkg.Errorf(
+				pkg.INTERNAL_ERROR,
+				"failed to list payment import rows: %s",
+				err.Error(),
+			)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return services.PaymentImportBatch{}, err
+	}
+
+	rsp := convertPaymentImportBatch(batch)
+
+	rsp.Rows = make([]services.PaymentImportRow, len(rows))
+	for i, row := range rows {
+		rsp.Rows[i] = convertPaymentImportRow(row)
+	}
+
+	return rsp, nil
+}
+
+func (p *PaymentService) ListPaymentImports(
+	ctx context.Context,
+	pgData *pkg.PaginationMetadata,
+) ([]services.PaymentImportBatch, pkg.PaginationMetadata, error) {
+	var batches []generated.PaymentImportBatch
+	var total int64
+
+	err := p.db.ExecTx(ctx, func(q generated.Querier) error {
+		var err error
+
+		batches, err = q.ListPaymentImportBatches(ctx, generated.ListPaymentImportBatchesParams{
+			Limit:  int32(pgData.PageSize),
+			Offset: pkg.CalculateOffset(pgData.CurrentPage, pgData.PageSize),
+		})
+		if err != nil {
+			return pkg.Errorf(
+				pkg.INTERNAL_ERROR,
+				"failed to list payment import batches: %s",
+				err.Error(),
+			)
+		}
+
+		total, err = q.CountPaymentImportBatches(ctx)
+		if err != nil {
+			return pkg.Errorf(
+				pkg.INTERNAL_ERROR,
+				"failed to count payment import batches: %s",
+				err.Error(),
+			)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, pkg.PaginationMetadata{}, err
+	}
+
+	rsp := make([]services.PaymentImportBatch, len(batches))
+	for i, batch := range batches {
+		rsp[i] = convertPaymentImportBatch(batch)
+	}
+
+	return rsp, pkg.CreatePaginationMetadata(uint32(total), pgData.PageSize, pgData.CurrentPage), nil
+}
+
+// PostPaymentImport posts every valid row of a previewed batch as an 'INTERNAL' payment. A row
+// that fails keeps its error and does not stop the rest of the batch. A batch that cannot be
+// finished is marked failed, posting it again resumes with the rows that were not posted and
+// rolling it back reverses the ones that were.
+func (p *PaymentService) PostPaymentImport(
+	ctx context.Context,
+	id uint32,
+	postedBy uint32,
+	assignedBy string,
+) (rsp services.PaymentImportBatch, err error) {
+	var rows []generated.PaymentImportRow
+
+	err = p.db.ExecTx(ctx, func(q generated.Querier) error {
+		if _, err := q.GetPaymentImportBatch(ctx, id); err != nil {
+			if err == sql.ErrNoRows {
+				return pkg.Errorf(pkg.NOT_FOUND_ERROR, "payment import not found")
+			}
+
+			return pkg.Errorf(
+				pkg.INTERNAL_ERROR,
+				"failed to get payment import batch: %s",
+				err.Error(),
+			)
+		}
+
+		// only one request gets to move the batch into posting
+		execResult, err := q.ClaimPaymentImportBatch(ctx, id)
+		if err != nil {
+			return pkg.Errorf(
+				pkg.INTERNAL_ERROR,
+				"failed to claim payment import batch: %s",
+				err.Error(),
+			)
+		}
+
+		claimed, err := execResult.RowsAffected()
+		if err != nil {
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to get rows affected: %s", err.Error())
+		}
+
+		if claimed == 0 {
+			return pkg.Errorf(
+				pkg.INVALID_ERROR,
+				"payment import is being posted or has already been posted",
+			)
+		}
+
+		rows, err = q.ListPaymentImportRows(ctx, id)
+		if err != nil {
+			return pkg.Errorf(
+				pkg.INTERNAL_ERROR,
+				"failed to list payment import rows: %s",
+				err.Error(),
+			)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return services.PaymentImportBatch{}, err
+	}
+
+	// the batch is claimed, it must not be left posting whatever happens to this request
+	defer func() {
+		if r := recover(); r != nil {
+			p.failPaymentImport(ctx, id)
+			panic(r)
+		}
+
+		if err != nil {
+			p.failPaymentImport(ctx, id)
+		}
+	}()
+
+	posted := uint32(0)
+
+	for _, row := range rows {
+		// posted before the batch failed
+		if row.NonPostedID.Valid {
+			posted++
+
+			continue
+		}
+
+		if row.Error != "" || !row.ClientID.Valid {
+			continue
+		}
+
+		nonPostedID, postErr := p.postPaymentImportRow(ctx, id, row, assignedBy)
+
+		result := generated.UpdatePaymentImportRowResultParams{ID: row.ID}
+		if postErr != nil {
+			result.Error = truncatePaymentImportError(postErr.Error())
+		} else {
+			result.NonPostedID = sql.NullInt32{Valid: true, Int32: int32(nonPostedID)}
+		}
+
+		err = p.db.ExecTx(ctx, func(q generated.Querier) error {
+			if _, err := q.UpdatePaymentImportRowResult(ctx, result); err != nil {
+				return pkg.Errorf(
+					pkg.INTERNAL_ERROR,
+					"failed to update payment import row: %s",
+					err.Error(),
+				)
+			}
+
+			return nil
+		})
+		if err != nil {
+			return services.PaymentImportBatch{}, err
+		}
+
+		if postErr == nil {
+			posted++
+		}
+	}
+
+	err = p.db.ExecTx(ctx, func(q generated.Querier) error {
+		_, err := q.MarkPaymentImportBatchPosted(ctx, generated.MarkPaymentImportBatchPostedParams{
+			ID:         id,
+			PostedRows: posted,
+			PostedBy:   sql.NullInt32{Valid: true, Int32: int32(postedBy)},
+		})
+		if err != nil {
+			return pkg.Errorf(
+				pkg.INTERNAL_ERROR,
+				"failed to mark payment import as posted: %s",
+				err.Error(),
+			)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return services.PaymentImportBatch{}, err
+	}
+
+	return p.GetPaymentImport(ctx, id)
+}
+
+// failPaymentImport moves a batch that could not be finished out of posting. It runs after the
+// request may have been cancelled so it does not use the request's cancellation.
+func (p *PaymentService) failPaymentImport(ctx context.Context, id uint32) {
+	ctx = context.WithoutCancel(ctx)
+
+	err := p.db.ExecTx(ctx, func(q generated.Querier) error {
+		if _, err := q.MarkPaymentImportBatchFailed(ctx, id); err != nil {
+			return pkg.Errorf(
+				pkg.INTERNAL_ERROR,
+				"failed to mark payment import as failed: %s",
+				err.Error(),
+			)
+		}
+
+		return nil
+	})
+	if err != nil {
+		log.Printf("payment import %d is left posting: %v", id, err)
+	}
+}
+
+func (p *PaymentService) postPaymentImportRow(
+	ctx context.Context,
+	batchID uint32,
+	row generated.PaymentImportRow,
+	assignedBy string,
+) (uint32, error) {
+	assignedBy = fmt.Sprintf("IMPORT %d: %s", batchID, assignedBy)
+
+	// the reference could have been posted since the batch was previewed, or by this batch
+	// before it failed
+	if processed, err := p.mySQL.NonPosted.GetProcessedCallback(
+		ctx,
+		row.Reference,
+		string(generated.NonPostedTransactionSourceINTERNAL),
+	); err == nil {
+		payment, err := p.mySQL.NonPosted.GetNonPosted(ctx, processed.NonPostedID)
+		if err != nil {
+			return 0, err
+		}
+
+		if strings.HasPrefix(payment.AssignedBy, fmt.Sprintf("IMPORT %d: ", batchID)) {
+			return processed.NonPostedID, nil
+		}
+
+		return 0, pkg.Errorf(pkg.INVALID_ERROR, "reference has already been posted")
+	} else if pkg.ErrorCode(err) != pkg.NOT_FOUND_ERROR {
+		return 0, err
+	}
+
+	var client generated.Client
+
+	err := p.db.ExecTx(ctx, func(q generated.Querier) error {
+		var err error
+
+		client, err = q.GetClient(ctx, uint32(row.ClientID.Int32))
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return pkg.Errorf(pkg.NOT_FOUND_ERROR, "client not found")
+			}
+
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to get client: %s", err.Error())
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	callbackData := &services.MpesaCallbackData{
+		TransactionSource: string(generated.NonPostedTransactionSourceINTERNAL),
+		TransactionID:     row.Reference,
+		AccountNumber:     row.Identifier,
+		PhoneNumber:       client.PhoneNumber,
+		PayingName:        client.FullName,
+		Amount:            row.Amount,
+		AssignedBy:        assignedBy,
+		AssignedTo:        &client.ID,
+	}
+
+	if row.PaidDate.Valid {
+		callbackData.PaidDate = &row.PaidDate.Time
+	}
+
+	if _, err := p.ProcessCallback(ctx, callbackData); err != nil {
+		return 0, err
+	}
+
+	processed, err := p.mySQL.NonPosted.GetProcessedCallback(
+		ctx,
+		row.Reference,
+		string(generated.NonPostedTransactionSourceINTERNAL),
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	return processed.NonPostedID, nil
+}
+
+// rollbackPaymentImportRow deletes the payment an import row posted and marks the row rolled back
+// in one transaction.
+func (p *PaymentService) rollbackPaymentImportRow(
+	ctx context.Context,
+	row services.PaymentImportRow,
+	userID uint32,
+	description string,
+) error {
+	paymentData, err := p.getDeletablePayment(ctx, *row.NonPostedID)
+	if err != nil {
+		return err
+	}
+
+	return p.db.ExecTx(ctx, func(q generated.Querier) error {
+		if err := deletePayment(ctx, q, paymentData, userID, description); err != nil {
+			return err
+		}
+
+		if _, err := q.MarkPaymentImportRowRolledBack(ctx, row.ID); err != nil {
+			return pkg.Errorf(
+				pkg.INTERNAL_ERROR,
+				"failed to mark payment import row as rolled back: %s",
+				err.Error(),
+			)
+		}
+
+		return nil
+	})
+}
+
+// RollbackPaymentImport deletes every payment a batch posted. Rows that cannot be reversed keep
+// their error and the batch stays posted so the rollback can be retried.
+func (p *PaymentService) RollbackPaymentImport(
+	ctx context.Context,
+	id uint32,
+	rollbackData *services.RollbackPaymentImportData,
+) (services.PaymentImportBatch, error) {
+	reason := strings.TrimSpace(rollbackData.Reason)
+	if reason == "" {
+		return services.PaymentImportBatch{}, pkg.Errorf(
+			pkg.INVALID_ERROR,
+			"a rollback reason is required",
+		)
+	}
+
+	batch, err := p.GetPaymentImport(ctx, id)
+	if err != nil {
+		return services.PaymentImportBatch{}, err
+	}
+
+	if batch.Status != string(generated.PaymentImportBatchesStatusPOSTED) &&
+		batch.Status != string(generated.PaymentImportBatchesStatusFAILED) {
+		return services.PaymentImportBatch{}, pkg.Errorf(
+			pkg.INVALID_ERROR,
+			"only posted or failed imports can be rolled back",
+		)
+	}
+
+	failed := 0
+
+	for _, row := range batch.Rows {
+		if row.NonPostedID == nil || row.RolledBack {
+			continue
+		}
+
+		// the payment, its processed callback and the row are changed together, the reference
+		// can be imported again once its payment is gone
+		rollbackErr := p.rollbackPaymentImportRow(
+			ctx,
+			row,
+			rollbackData.RolledBackBy,
+			fmt.Sprintf("IMPORT %d ROLLBACK: %s", id, reason),
+		)
+		if rollbackErr == nil {
+			continue
+		}
+
+		failed++
+
+		err := p.db.ExecTx(ctx, func(q generated.Querier) error {
+			_, err := q.UpdatePaymentImportRowResult(
+				ctx,
+				generated.UpdatePaymentImportRowResultParams{
+					ID:          row.ID,
+					NonPostedID: sql.NullInt32{Valid: true, Int32: int32(*row.NonPostedID)},
+					Error:       truncatePaymentImportError(rollbackErr.Error()),
+				},
+			)
+			if err != nil {
+				return pkg.Errorf(
+					pkg.INTERNAL_ERROR,
+					"failed to update payment import row: %s",
+					err.Error(),
+				)
+			}
+
+			return nil
+		})
+		if err != nil {
+			return services.PaymentImportBatch{}, err
+		}
+	}
+
+	if failed == 0 {
+		err = p.db.ExecTx(ctx, func(q generated.Querier) error {
+			_, err := q.RollbackPaymentImportBatch(ctx, generated.RollbackPaymentImportBatchParams{
+				ID:             id,
+				RolledBackBy:   sql.NullInt32{Valid: true, Int32: int32(rollbackData.RolledBackBy)},
+				RollbackReason: sql.NullString{Valid: true, String: reason},
+			})
+			if err != nil {
+				return pkg.Errorf(
+					pkg.INTERNAL_ERROR,
+					"failed to roll back payment import: %s",
+					err.Error(),
+				)
+			}
+
+			return nil
+		})
+		if err != nil {
+			return services.PaymentImportBatch{}, err
+		}
+	}
+
+	return p.GetPaymentImport(ctx, id)
+}
+
+func truncatePaymentImportError(message string) string {
+	if len(message) > 255 {
+		return message[:255]
+	}
+
+	return message
+}
+
+func convertPaymentImportBatch(batch generated.PaymentImportBatch) services.PaymentImportBatch {
+	rsp := services.PaymentImportBatch{
+		ID:         batch.ID,
+		FileName:   batch.FileName,
+		Status:     string(batch.Status),
+		TotalRows:  batch.TotalRows,
+		ValidRows:  batch.ValidRows,
+		PostedRows: batch.PostedRows,
+		UploadedBy: batch.UploadedBy,
+		CreatedAt:  batch.CreatedAt,
+	}
+
+	if batch.PostedBy.Valid {
+		rsp.PostedBy = pkg.Uint32Ptr(uint32(batch.PostedBy.Int32))
+	}
+
+	if batch.PostedAt.Valid {
+		rsp.PostedAt = &batch.PostedAt.Time
+	}
+
+	if batch.RolledBackBy.Valid {
+		rsp.RolledBackBy = pkg.Uint32Ptr(uint32(batch.RolledBackBy.Int32))
+	}
+
+	if batch.RolledBackAt.Valid {
+		rsp.RolledBackAt = &batch.RolledBackAt.Time
+	}
+
+	if batch.RollbackReason.Valid {
+		rsp.RollbackReason = batch.RollbackReason.String
+	}
+
+	return rsp
+}
+
+func convertPaymentImportRow(row generated.PaymentImportRow) services.PaymentImportRow {
+	rsp := services.PaymentImportRow{
+		ID:         row.ID,
+		LineNumber: row.LineNumber,
+		Identifier: row.Identifier,
+		Amount:     row.Amount,
+		Reference:  row.Reference,
+		Error:      row.Error,
+		RolledBack: row.RolledBack,
+	}
+
+	if row.ClientID.Valid {
+		rsp.ClientID = pkg.Uint32Ptr(uint32(row.ClientID.Int32))
+	}
+
+	if row.PaidDate.Valid {
+		rsp.PaidDate = &row.PaidDate.Time
+	}
+
+	if row.NonPostedID.Valid {
+		rsp.NonPostedID = pkg.Uint32Ptr(uint32(row.NonPostedID.Int32))
+	}
+
+	return rsp
+}
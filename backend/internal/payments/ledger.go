@@ -0,0 +1,184 @@
+package payments
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/mysql"
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/mysql/generated"
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/repository"
+	"github.com/EmilioCliff/kokomed-fin/backend/pkg"
+)
+
+// Money received for a payment sits in unallocated payments until it is allocated to a loan's
+// installments or to the client's overpayment, reversing an allocation puts it back there.
+
+func postPaymentReceived(
+	ctx context.Context,
+	q generated.Querier,
+	paymentID uint32,
+	payment *repository.NonPosted,
+) error {
+	entryType := repository.JournalPaymentReceived
+	description := fmt.Sprintf("PAYMENT %s RECEIVED", payment.TransactionNumber)
+
+	// an applied overpayment was never received, it is drawn down from the client's balance
+	if strings.HasPrefix(payment.TransactionNumber, repository.OverpaymentApplicationPrefix) {
+		entryType = repository.JournalOverpaymentApplied
+		description = fmt.Sprintf(
+			"OVERPAYMENT APPLIED: drawn down into loan %s",
+			strings.TrimPrefix(payment.TransactionNumber, repository.OverpaymentApplicationPrefix),
+		)
+	}
+
+	// the top up loan paid out its whole principal, the part kept back to settle the refinanced
+	// loan comes back into cash
+	if strings.HasPrefix(payment.TransactionNumber, repository.TopUpSettlementPrefix) {
+		entryType = repository.JournalLoanRefinanced
+		description = fmt.Sprintf(
+			"LOAN REFINANCED: settled from loan %s",
+			strings.TrimPrefix(payment.TransactionNumber, repository.TopUpSettlementPrefix),
+		)
+	}
+
+	return mysql.PostJournalEntry(ctx, q, repository.JournalEntry{
+		EntryType:   entryType,
+		Description: description,
+		ClientID:    payment.AssignedTo,
+		NonPostedID: &paymentID,
+		CreatedBy:   payment.AssignedBy,
+		Lines: repository.Transfer(
+			repository.PaymentFundingAccount(payment.TransactionNumber),
+			repository.AccountUnallocatedPayments,
+			payment.Amount,
+		),
+	})
+}
+
+func postAllocation(
+	ctx context.Context,
+	q generated.Querier,
+	allocation repository.PaymentAllocation,
+) error {
+	account := repository.AccountClientOverpayments
+	if allocation.InstallmentID != nil {
+		account = repository.AccountLoansReceivable
+	}
+
+	if allocation.PenaltyID != nil {
+		account = repository.AccountPenaltiesReceivable
+	}
+
+	return mysql.PostJournalEntry(ctx, q, repository.JournalEntry{
+		EntryType:   repository.JournalPaymentAllocated,
+		Description: allocation.Description,
+		LoanID:      allocation.LoanID,
+		NonPostedID: &allocation.NonPostedID,
+		Lines: repository.Transfer(
+			repository.AccountUnallocatedPayments,
+			account,
+			allocation.Amount,
+		),
+	})
+}
+
+// postAllocationReversal books the payment's allocations going back to unallocated payments.
+// It is posted with the allocations that are about to be deleted.
+func postAllocationReversal(
+	ctx context.Context,
+	q generated.Querier,
+	paymentID uint32,
+	allocations []generated.PaymentAllocation,
+	createdBy string,
+	description string,
+) error {
+	toLoans := pkg.Money(0)
+	toPenalties := pkg.Money(0)
+	toOverpayment := pkg.Money(0)
+
+	var loanID *uint32
+
+	for _, allocation := range allocations {
+		if allocation.PenaltyID.Valid {
+			toPenalties += pkg.MoneyFromFloat(allocation.Amount)
+			loanID = pkg.Uint32Ptr(uint32(allocation.LoanID.Int32))
+		} else if allocation.InstallmentID.Valid {
+			toLoans += pkg.MoneyFromFloat(allocation.Amount)
+			loanID = pkg.Uint32Ptr(uint32(allocation.LoanID.Int32))
+		} else {
+			toOverpayment += pkg.MoneyFromFloat(allocation.Amount)
+		}
+	}
+
+	return mysql.PostJournalEntry(ctx, q, repository.JournalEntry{
+		EntryType:   repository.JournalPaymentReversed,
+		Description: description,
+		LoanID:      loanID,
+		NonPostedID: &paymentID,
+		CreatedBy:   createdBy,
+		Lines: []repository.JournalLine{
+			{AccountCode: repository.AccountLoansReceivable, Debit: toLoans},
+			{AccountCode: repository.AccountPenaltiesReceivable, Debit: toPenalties},
+			{AccountCode: repository.AccountClientOverpayments, Debit: toOverpayment},
+			{
+				AccountCode: repository.AccountUnallocatedPayments,
+				Credit:      toLoans + toPenalties + toOverpayment,
+			},
+		},
+	})
+}
+
+func postPaymentDeleted(
+	ctx context.Context,
+	q generated.Querier,
+	paymentID uint32,
+	payment repository.NonPosted,
+	description string,
+) error {
+	return mysql.PostJournalEntry(ctx, q, repository.JournalEntry{
+		EntryType:   repository.JournalPaymentDeleted,
+		Description: description,
+		ClientID:    payment.AssignedTo,
+		NonPostedID: &paymentID,
+		Lines: repository.Transfer(
+			repository.AccountUnallocatedPayments,
+			repository.PaymentFundingAccount(payment.TransactionNumber),
+			payment.Amount,
+		),
+	})
+}
+
+// postOverpaymentRefund books a refund paid out of the client's overpayment, a failed payout
+// puts the balance back.
+func postOverpaymentRefund(
+	ctx context.Context,
+	q generated.Querier,
+	refund generated.OverpaymentRefund,
+	createdBy string,
+	failed bool,
+) error {
+	entry := repository.JournalEntry{
+		EntryType:   repository.JournalOverpaymentRefund,
+		Description: fmt.Sprintf("REFUND %s: %s", refundVoucherNumber(refund.ID), refund.Reason),
+		ClientID:    &refund.ClientID,
+		CreatedBy:   createdBy,
+		Lines: repository.Transfer(
+			repository.AccountClientOverpayments,
+			repository.AccountCash,
+			pkg.MoneyFromFloat(refund.Amount),
+		),
+	}
+
+	if failed {
+		entry.EntryType = repository.JournalOverpaymentRefundFailed
+		entry.Description = fmt.Sprintf("REFUND %s FAILED", refundVoucherNumber(refund.ID))
+		entry.Lines = repository.Transfer(
+			repository.AccountCash,
+			repository.AccountClientOverpayments,
+			pkg.MoneyFromFloat(refund.Amount),
+		)
+	}
+
+	return mysql.PostJournalEntry(ctx, q, entry)
+}
@@ -0,0 +1,124 @@
+package payments
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/mysql"
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/mysql/generated"
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/repository"
+	"github.com/EmilioCliff/kokomed-fin/backend/pkg"
+)
+
+var _ mysql.LoanPayer = loanPayer{}
+
+// loanPayer lets the loan repository pay loans with the same allocation as every other payment.
+type loanPayer struct{}
+
+// ApplyOverpayment records the client's overpayment, up to what the loan owes, as an internal
+// payment funded by the overpayment and pays the loan with it. Deleting that payment restores
+// the overpayment.
+func (loanPayer) ApplyOverpayment(
+	ctx context.Context,
+	q generated.Querier,
+	loanID, clientID, appliedBy uint32,
+) error {
+	if err := mysql.LockLoanForPayment(ctx, q, clientID, loanID); err != nil {
+		return err
+	}
+
+	overpayment, err := q.GetClientOverpayment(ctx, clientID)
+	if err != nil {
+		return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to get client overpayment: %s", err.Error())
+	}
+
+	plan, err := getLoanAllocationPlan(ctx, q, loanID)
+	if err != nil {
+		return err
+	}
+
+	amount := pkg.MinMoney(pkg.MoneyFromFloat(overpayment), plan.outstanding())
+	if amount <= 0 {
+		return nil
+	}
+
+	client, err := q.GetClient(ctx, clientID)
+	if err != nil {
+		return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to get client: %s", err.Error())
+	}
+
+	paymentID, err := createNonPosted(ctx, q, &repository.NonPosted{
+		TransactionSource: string(generated.NonPostedTransactionSourceINTERNAL),
+		TransactionNumber: fmt.Sprintf("%s%d", repository.OverpaymentApplicationPrefix, loanID),
+		AccountNumber:     fmt.Sprintf("%d", loanID),
+		PhoneNumber:       client.PhoneNumber,
+		PayingName:        client.FullName,
+		Amount:            amount,
+		PaidDate:          time.Now(),
+		AssignedTo:        &clientID,
+		AssignedBy:        "OVERPAYMENT",
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := deductOverpayment(ctx, q, repository.Overpayment{
+		ClientID:  clientID,
+		Amount:    amount,
+		PaymentID: &paymentID,
+		CreatedBy: "SYSTEM",
+		Description: fmt.Sprintf(
+			"OVERPAYMENT APPLIED: drawn down into loan %d by user %d",
+			loanID,
+			appliedBy,
+		),
+	}); err != nil {
+		return err
+	}
+
+	return processLoanPayment(ctx, q, &repository.UpdateLoan{
+		ID:         loanID,
+		PaidAmount: amount,
+		UpdatedBy:  &appliedBy,
+	}, paymentID, clientID, 0, "OVERPAYMENT APPLIED")
+}
+
+// SettleRefinancedLoan records the part of the top up loan's principal kept back as an internal
+// payment by the disbursing user and pays the refinanced loan off with it.
+func (loanPayer) SettleRefinancedLoan(
+	ctx context.Context,
+	q generated.Querier,
+	loanID, clientID, newLoanID, settledBy uint32,
+	amount pkg.Money,
+) (uint32, error) {
+	client, err := q.GetClient(ctx, clientID)
+	if err != nil {
+		return 0, pkg.Errorf(pkg.INTERNAL_ERROR, "failed to get client: %s", err.Error())
+	}
+
+	paymentID, err := createNonPosted(ctx, q, &repository.NonPosted{
+		TransactionSource: string(generated.NonPostedTransactionSourceINTERNAL),
+		TransactionNumber: fmt.Sprintf("%s%d", repository.TopUpSettlementPrefix, newLoanID),
+		AccountNumber:     fmt.Sprintf("%d", loanID),
+		PhoneNumber:       client.PhoneNumber,
+		PayingName:        client.FullName,
+		Amount:            amount,
+		PaidDate:          time.Now(),
+		AssignedTo:        &clientID,
+		AssignedBy:        fmt.Sprintf("USER %d", settledBy),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if err := processLoanPayment(ctx, q, &repository.UpdateLoan{
+		ID:         loanID,
+		PaidAmount: amount,
+		UpdatedBy:  &settledBy,
+	}, paymentID, clientID, 0, "LOAN REFINANCED"); err != nil {
+		return 0, err
+	}
+
+	return paymentID, nil
+}
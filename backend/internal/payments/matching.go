@@ -0,0 +1,241 @@
+package payments
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/mysql/generated"
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/services"
+	"github.com/EmilioCliff/kokomed-fin/backend/pkg"
+)
+
+// suggestion weights, a score is capped at 100.
+const (
+	accountPhoneScore      = 50
+	payerPhoneScore        = 35
+	idNumberScore          = 45
+	nameScore              = 30
+	installmentAmountScore = 20
+
+	minNameSimilarity = 0.6
+)
+
+func (p *PaymentService) SuggestAssignments(
+	ctx context.Context,
+	nonPostedID uint32,
+	limit int,
+) ([]services.AssignmentSuggestion, error) {
+	payment, err := p.mySQL.NonPosted.GetNonPosted(ctx, nonPostedID)
+	if err != nil {
+		return nil, err
+	}
+
+	if payment.AssignedTo != nil {
+		return nil, pkg.Errorf(pkg.INVALID_ERROR, "payment is already assigned")
+	}
+
+	candidates := map[uint32]*services.AssignmentSuggestion{}
+	addReason := func(clientID uint32, score float64, reason string) {
+		candidate, ok := candidates[clientID]
+		if !ok {
+			candidate = &services.AssignmentSuggestion{ClientID: clientID}
+			candidates[clientID] = candidate
+		}
+
+		candidate.Score += score
+		candidate.Reasons = append(candidate.Reasons, reason)
+	}
+
+	err = p.db.ExecTx(ctx, func(q generated.Querier) error {
+		clients, err := matchClientsByPhone(ctx, q, payment.AccountNumber)
+		if err != nil {
+			return err
+		}
+
+		for _, client := range clients {
+			addReason(client.ID, accountPhoneScore, "account number matches client phone number")
+		}
+
+		clients, err = matchClientsByPhone(ctx, q, payment.PhoneNumber)
+		if err != nil {
+			return err
+		}
+
+		for _, client := range clients {
+			addReason(client.ID, payerPhoneScore, "paying phone number matches client phone number")
+		}
+
+		client, found, err := matchClientByIDNumber(ctx, q, payment.AccountNumber)
+		if err != nil {
+			return err
+		}
+
+		if found {
+			addReason(client.ID, idNumberScore, "account number matches client id number")
+		}
+
+		names, err := q.ListClientNames(ctx)
+		if err != nil {
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to list client names: %s", err.Error())
+		}
+
+		for _, name := range names {
+			similarity := pkg.NameSimilarity(payment.PayingName, name.FullName)
+			if similarity < minNameSimilarity {
+				continue
+			}
+
+			addReason(
+				name.ID,
+				math.Round(similarity*nameScore),
+				fmt.Sprintf("paying name is %.0f%% similar to client name", similarity*100),
+			)
+		}
+
+		// installments due in the month before the payment up to a week after it
+		installments, err := dueInstallmentsByAmount(
+			ctx,
+			q,
+			payment.Amount,
+			payment.PaidDate.AddDate(0, -1, 0),
+			payment.PaidDate.AddDate(0, 0, 7),
+		)
+		if err != nil {
+			return err
+		}
+
+		for _, installment := range installments {
+			addReason(installment.ClientID, installmentAmountScore, fmt.Sprintf(
+				"loan %d has an installment of %.2f due on %s",
+				installment.LoanID,
+				installment.RemainingAmount,
+				installment.DueDate.Format("2006-01-02"),
+			))
+		}
+
+		for clientID, candidate := range candidates {
+			client, err := q.GetClient(ctx, clientID)
+			if err != nil {
+				return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to get client: %s", err.Error())
+			}
+
+			candidate.FullName = client.FullName
+			candidate.PhoneNumber = client.PhoneNumber
+			candidate.IDNumber = client.IDNumber.String
+			candidate.Score = min(candidate.Score, 100)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	suggestions := make([]services.AssignmentSuggestion, 0, len(candidates))
+	for _, candidate := range candidates {
+		suggestions = append(suggestions, *candidate)
+	}
+
+	slices.SortFunc(suggestions, func(a, b services.AssignmentSuggestion) int {
+		if a.Score != b.Score {
+			return cmp.Compare(b.Score, a.Score)
+		}
+
+		return cmp.Compare(a.ClientID, b.ClientID)
+	})
+
+	if limit > 0 && len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+
+	return suggestions, nil
+}
+
+func matchClientsByPhone(
+	ctx context.Context,
+	q generated.Querier,
+	phoneNumber string,
+) ([]generated.Client, error) {
+	variants, ok := pkg.PhoneNumberVariants(phoneNumber)
+	if !ok {
+		return nil, nil
+	}
+
+	clients, err := q.ListClientsByPhoneNumbers(ctx, generated.ListClientsByPhoneNumbersParams{
+		LocalPhone:         variants[0],
+		InternationalPhone: variants[1],
+		PlusPhone:          variants[2],
+		ShortPhone:         variants[3],
+	})
+	if err != nil {
+		return nil, pkg.Errorf(
+			pkg.INTERNAL_ERROR,
+			"failed to list clients by phone number: %s",
+			err.Error(),
+		)
+	}
+
+	return clients, nil
+}
+
+func matchClientByIDNumber(
+	ctx context.Context,
+	q generated.Querier,
+	accountNumber string,
+) (generated.Client, bool, error) {
+	accountNumber = strings.TrimSpace(accountNumber)
+	if accountNumber == "" {
+		return generated.Client{}, false, nil
+	}
+
+	client, err := q.GetClientByIDNumber(ctx, sql.NullString{
+		Valid:  true,
+		String: accountNumber,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return generated.Client{}, false, nil
+		}
+
+		return generated.Client{}, false, pkg.Errorf(
+			pkg.INTERNAL_ERROR,
+			"failed to get client by id number: %s",
+			err.Error(),
+		)
+	}
+
+	return client, true, nil
+}
+
+func dueInstallmentsByAmount(
+	ctx context.Context,
+	q generated.Querier,
+	amount pkg.Money,
+	fromDate, toDate time.Time,
+) ([]generated.ListDueInstallmentsByAmountRow, error) {
+	installments, err := q.ListDueInstallmentsByAmount(
+		ctx,
+		generated.ListDueInstallmentsByAmountParams{
+			// the installments are stored as decimals, half a cent either side matches the amount
+			MinAmount: amount.Float64() - 0.005,
+			MaxAmount: amount.Float64() + 0.005,
+			FromDate:  fromDate,
+			ToDate:    toDate,
+		},
+	)
+	if err != nil {
+		return nil, pkg.Errorf(
+			pkg.INTERNAL_ERROR,
+			"failed to list due installments: %s",
+			err.Error(),
+		)
+	}
+
+	return installments, nil
+}
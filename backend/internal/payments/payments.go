@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/EmilioCliff/kokomed-fin/backend/internal/mysql"
@@ -16,20 +17,121 @@ import (
 var _ services.PaymentService = (*PaymentService)(nil)
 
 type PaymentService struct {
-	mySQL *mysql.MySQLRepo
-	db    *mysql.Store
+	mySQL   *mysql.MySQLRepo
+	db      *mysql.Store
+	queries generated.Querier
+	config  pkg.Config
+	mpesa   *pkg.MpesaClient
+	payouts map[string]services.PayoutProvider
 }
 
-func NewPaymentService(mySQL *mysql.MySQLRepo, store *mysql.Store) *PaymentService {
-	return &PaymentService{
-		mySQL: mySQL,
-		db:    store,
+func NewPaymentService(
+	mySQL *mysql.MySQLRepo,
+	store *mysql.Store,
+	config pkg.Config,
+) *PaymentService {
+	p := &PaymentService{
+		mySQL:   mySQL,
+		db:      store,
+		queries: store.Queries(),
+		config:  config,
+		mpesa:   pkg.NewMpesaClient(config),
+		payouts: map[string]services.PayoutProvider{
+			PayoutMethodManual: manualPayoutProvider{},
+		},
 	}
+
+	if config.MPESA_B2C_ENABLED {
+		p.RegisterPayoutProvider(PayoutMethodMpesa, mpesaPayoutProvider{mpesa: p.mpesa, config: config})
+	}
+
+	// loans disbursed against an overpayment are paid like any other payment
+	if loans, ok := mySQL.Loans.(*mysql.LoanRepository); ok {
+		loans.SetLoanPayer(loanPayer{})
+	}
+
+	return p
 }
 
 func (p *PaymentService) ProcessCallback(
 	ctx context.Context,
 	callbackData *services.MpesaCallbackData,
+) (uint32, error) {
+	callbackData.TransactionSource = strings.ToUpper(strings.TrimSpace(callbackData.TransactionSource))
+
+	// mpesa retries callbacks it thinks were not delivered, a transaction is only processed once
+	loanID, processed, err := p.getProcessedCallback(ctx, callbackData)
+	if err != nil {
+		return 0, err
+	}
+
+	if processed {
+		return p.replayProcessedCallback(callbackData, loanID)
+	}
+
+	loanID, err = p.processCallback(ctx, callbackData)
+	if err != nil {
+		// a concurrent delivery of the same transaction could have been recorded first
+		originalLoanID, processed, lookupErr := p.getProcessedCallback(ctx, callbackData)
+		if lookupErr == nil && processed {
+			return p.replayProcessedCallback(callbackData, originalLoanID)
+		}
+
+		return 0, err
+	}
+
+	return loanID, nil
+}
+
+// replayProcessedCallback answers a transaction that was already processed. A redelivered mpesa
+// callback gets the original result, an internal payment reusing a reference is a mistake the
+// user has to hear about.
+func (p *PaymentService) replayProcessedCallback(
+	callbackData *services.MpesaCallbackData,
+	loanID uint32,
+) (uint32, error) {
+	if callbackData.TransactionSource == string(generated.NonPostedTransactionSourceMPESA) {
+		return loanID, nil
+	}
+
+	return 0, pkg.Errorf(
+		pkg.ALREADY_EXISTS_ERROR,
+		"a payment with reference %s has already been posted",
+		callbackData.TransactionID,
+	)
+}
+
+func (p *PaymentService) getProcessedCallback(
+	ctx context.Context,
+	callbackData *services.MpesaCallbackData,
+) (uint32, bool, error) {
+	if callbackData.TransactionID == "" {
+		return 0, false, nil
+	}
+
+	processed, err := p.mySQL.NonPosted.GetProcessedCallback(
+		ctx,
+		callbackData.TransactionID,
+		callbackData.TransactionSource,
+	)
+	if err != nil {
+		if pkg.ErrorCode(err) == pkg.NOT_FOUND_ERROR {
+			return 0, false, nil
+		}
+
+		return 0, false, err
+	}
+
+	if processed.LoanID != nil {
+		return *processed.LoanID, true, nil
+	}
+
+	return 0, true, nil
+}
+
+func (p *PaymentService) processCallback(
+	ctx context.Context,
+	callbackData *services.MpesaCallbackData,
 ) (uint32, error) {
 	params := &repository.NonPosted{
 		TransactionSource: callbackData.TransactionSource,
@@ -37,9 +139,10 @@ func (p *PaymentService) ProcessCallback(
 		AccountNumber:     callbackData.AccountNumber,
 		PhoneNumber:       callbackData.PhoneNumber,
 		PayingName:        callbackData.PayingName,
-		Amount:            callbackData.Amount,
+		Amount:            pkg.MoneyFromFloat(callbackData.Amount),
 		AssignedTo:        callbackData.AssignedTo,
 		AssignedBy:        callbackData.AssignedBy,
+		CashReceipt:       callbackData.CashReceipt,
 		PaidDate:          time.Now(),
 	}
 
@@ -47,49 +150,36 @@ func (p *PaymentService) ProcessCallback(
 		params.PaidDate = *callbackData.PaidDate
 	}
 
+	// admin defined rules get a chance to assign the payment before it is left unassigned
+	if params.AssignedTo == nil {
+		clientID, rule, err := p.applyAssignmentRules(ctx, rulePayment{
+			AccountNumber: params.AccountNumber,
+			PayingName:    params.PayingName,
+			Amount:        params.Amount,
+			PaidDate:      params.PaidDate,
+		})
+		if err != nil {
+			return 0, err
+		}
+
+		if rule != nil {
+			params.AssignedTo = &clientID
+			params.AssignedBy = ruleAssignedBy(rule)
+		}
+	}
+
 	loanID := uint32(0)
 	if params.AssignedTo != nil {
 		var err error
-		loanID, err = p.mySQL.Loans.GetClientActiceLoan(ctx, *params.AssignedTo)
+		loanID, err = p.mySQL.Loans.GetClientPayableLoan(ctx, *params.AssignedTo)
 		if err != nil {
-			if pkg.ErrorCode(err) == pkg.NOT_FOUND_ERROR {
-				err = p.db.ExecTx(ctx, func(q generated.Querier) error {
-					nonPostedID, err := createNonPosted(ctx, q, params)
-					if err != nil {
-						return err
-					}
-
-					if err = updateOverpayment(ctx, q, repository.Overpayment{
-						ClientID:    *params.AssignedTo,
-						Amount:      params.Amount,
-						PaymentID:   &nonPostedID,
-						Description: "SYSTEM LOAN PAYMENT: no active loan adding to overpayment",
-					}); err != nil {
-						return err
-					}
-
-					if err = createAllocation(ctx, q, repository.PaymentAllocation{
-						NonPostedID:   nonPostedID,
-						Amount:        params.Amount,
-						LoanID:        nil,
-						InstallmentID: nil,
-						Description:   "SYSTEM LOAN PAYMENT: no active loan adding to overpayment",
-					}); err != nil {
-						return err
-					}
-
-					return nil
-				})
-				return 0, err
-			}
-
 			return 0, err
 		}
 
 		if err = p.db.ExecTx(ctx, func(q generated.Querier) error {
 			loan := &repository.UpdateLoan{
 				ID:         loanID,
-				PaidAmount: callbackData.Amount,
+				PaidAmount: pkg.MoneyFromFloat(callbackData.Amount),
 			}
 
 			nonPostedID, err := createNonPosted(ctx, q, params)
@@ -97,13 +187,29 @@ func (p *PaymentService) ProcessCallback(
 				return err
 			}
 
+			var paidLoanID *uint32
+			if loanID != 0 {
+				paidLoanID = &loanID
+			}
+
+			if err = recordProcessedCallback(ctx, q, params, nonPostedID, paidLoanID); err != nil {
+				return err
+			}
+
+			// without a payable loan processLoanPayment keeps the payment as overpayment
 			return processLoanPayment(ctx, q, loan, nonPostedID, *params.AssignedTo, 0, "SYSTEM LOAN PAYMENT")
 		}); err != nil {
 			return 0, err
 		}
 	} else {
-		_, err := p.mySQL.NonPosted.CreateNonPosted(ctx, params)
-		if err != nil {
+		if err := p.db.ExecTx(ctx, func(q generated.Querier) error {
+			nonPostedID, err := createNonPosted(ctx, q, params)
+			if err != nil {
+				return err
+			}
+
+			return recordProcessedCallback(ctx, q, params, nonPostedID, nil)
+		}); err != nil {
 			return 0, err
 		}
 	}
@@ -122,7 +228,12 @@ func (p *PaymentService) TriggerManualPayment(
 	// 	return 0, err
 	// }
 	err := p.db.ExecTx(ctx, func(q generated.Querier) error {
-		_, err := q.AssignNonPosted(ctx, generated.AssignNonPostedParams{
+		nonPosted, err := lockUnassignedPayment(ctx, q, paymentData.NonPostedID)
+		if err != nil {
+			return err
+		}
+
+		_, err = q.AssignNonPosted(ctx, generated.AssignNonPostedParams{
 			ID: paymentData.NonPostedID,
 			AssignTo: sql.NullInt32{
 				Valid: true,
@@ -138,25 +249,13 @@ func (p *PaymentService) TriggerManualPayment(
 			return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to assign non posted: %s", err.Error())
 		}
 
-		nonPosted, err := q.GetNonPosted(ctx, paymentData.NonPostedID)
-		if err != nil {
-			if err == sql.ErrNoRows {
-				return pkg.Errorf(pkg.NOT_FOUND_ERROR, "no non posted found")
-			}
-
-			return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to get non posted: %s", err.Error())
-		}
-
 		// get clients active loan
-		loanID, err = q.GetClientActiveLoan(ctx, generated.GetClientActiveLoanParams{
-			ClientID: paymentData.ClientID,
-			Status:   generated.LoansStatusACTIVE,
-		})
+		loanID, err = q.GetClientPayableLoan(ctx, paymentData.ClientID)
 		if err != nil {
 			if err == sql.ErrNoRows {
 				if err := updateOverpayment(ctx, q, repository.Overpayment{
 					ClientID:    paymentData.ClientID,
-					Amount:      nonPosted.Amount,
+					Amount:      pkg.MoneyFromFloat(nonPosted.Amount),
 					PaymentID:   &paymentData.NonPostedID,
 					Description: "MANUAL LOAN PAYMENT: no active loan adding to overpayment",
 				}); err != nil {
@@ -165,7 +264,7 @@ func (p *PaymentService) TriggerManualPayment(
 
 				if err := createAllocation(ctx, q, repository.PaymentAllocation{
 					NonPostedID:   paymentData.NonPostedID,
-					Amount:        nonPosted.Amount,
+					Amount:        pkg.MoneyFromFloat(nonPosted.Amount),
 					LoanID:        nil,
 					InstallmentID: nil,
 					Description:   "MANUAL LOAN PAYMENT: no active loan adding to overpayment",
@@ -181,7 +280,7 @@ func (p *PaymentService) TriggerManualPayment(
 
 		if err = processLoanPayment(ctx, q, &repository.UpdateLoan{
 			ID:         loanID,
-			PaidAmount: nonPosted.Amount,
+			PaidAmount: pkg.MoneyFromFloat(nonPosted.Amount),
 			UpdatedBy:  &paymentData.AdminUserID,
 		}, paymentData.NonPostedID, paymentData.ClientID, 0, "MANUAL LOAN PAYMENT"); err != nil {
 			return err
@@ -200,18 +299,67 @@ func (p *PaymentService) UpdatePayment(
 	description string,
 	paymentData *services.MpesaCallbackData,
 ) error {
+	if _, err := p.getUpdatablePayment(ctx, paymentID); err != nil {
+		return err
+	}
+
+	return p.db.ExecTx(ctx, func(q generated.Querier) error {
+		return updatePayment(ctx, q, paymentID, description, paymentData)
+	})
+}
+
+func (p *PaymentService) getUpdatablePayment(
+	ctx context.Context,
+	paymentID uint32,
+) (repository.NonPosted, error) {
 	nonPosted, err := p.mySQL.NonPosted.GetNonPosted(ctx, paymentID)
 	if err != nil {
-		return err
+		return repository.NonPosted{}, err
 	}
 
 	if nonPosted.TransactionSource != "INTERNAL" {
-		return pkg.Errorf(
+		return repository.NonPosted{}, pkg.Errorf(
 			pkg.INVALID_ERROR,
 			"transaction source is not 'INTERNAL'. only 'INTERNAL' transactions can be updated",
 		)
 	}
 
+	if strings.HasPrefix(nonPosted.TransactionNumber, repository.OverpaymentApplicationPrefix) {
+		return repository.NonPosted{}, pkg.Errorf(
+			pkg.INVALID_ERROR,
+			"applied overpayments cannot be updated, delete the payment to reverse it",
+		)
+	}
+
+	if strings.HasPrefix(nonPosted.TransactionNumber, repository.TopUpSettlementPrefix) {
+		return repository.NonPosted{}, pkg.Errorf(
+			pkg.INVALID_ERROR,
+			"top up settlements cannot be updated",
+		)
+	}
+
+	if strings.HasPrefix(nonPosted.TransactionNumber, repository.EarlySettlementPrefix) {
+		return repository.NonPosted{}, pkg.Errorf(
+			pkg.INVALID_ERROR,
+			"early settlements cannot be updated",
+		)
+	}
+
+	if err := checkPaymentNotSplit(ctx, p.queries, paymentID); err != nil {
+		return repository.NonPosted{}, err
+	}
+
+	return nonPosted, nil
+}
+
+// updatePayment reverts what the payment paid and pays again with the updated details.
+func updatePayment(
+	ctx context.Context,
+	q generated.Querier,
+	paymentID uint32,
+	description string,
+	paymentData *services.MpesaCallbackData,
+) error {
 	descriptionUpdate := fmt.Sprintf("UPDATE LOAN PAYMENT: %s", description)
 	nonPostedParams := &repository.NonPosted{
 		ID:                 paymentID,
@@ -220,138 +368,169 @@ func (p *PaymentService) UpdatePayment(
 		AccountNumber:      paymentData.AccountNumber,
 		PhoneNumber:        paymentData.PhoneNumber,
 		PayingName:         paymentData.PayingName,
-		Amount:             paymentData.Amount,
+		Amount:             pkg.MoneyFromFloat(paymentData.Amount),
 		AssignedBy:         paymentData.AssignedBy,
 		PaidDate:           time.Now(),
 		DeletedDescription: &descriptionUpdate,
 	}
+
+	if err := lockUnsplitPayment(ctx, q, paymentID); err != nil {
+		return err
+	}
+
+	if err := mysql.UpdateProcessedCallbackTx(
+		ctx,
+		q,
+		paymentID,
+		paymentData.TransactionID,
+		paymentData.TransactionSource,
+	); err != nil {
+		return err
+	}
+
 	if paymentData.AssignedTo == nil {
 		nonPostedParams.AssignedTo = nil
-		if err := p.mySQL.NonPosted.UpdateNonPosted(ctx, nonPostedParams); err != nil {
-			return err
-		}
+		return mysql.UpdateNonPostedTx(ctx, q, nonPostedParams)
+	}
 
-		return nil
+	allocations, err := q.ListPaymentAllocationsByNonPostedId(ctx, paymentID)
+	if err != nil {
+		return err
 	}
 
-	err = p.db.ExecTx(ctx, func(q generated.Querier) error {
-		allocations, err := q.ListPaymentAllocationsByNonPostedId(ctx, paymentID)
-		if err != nil {
-			return err
-		}
+	if len(allocations) == 0 {
+		return pkg.Errorf(
+			pkg.INVALID_ERROR,
+			"action cannot be performed. payment lacks enough data to reverse payments",
+		)
+	}
 
-		if len(allocations) == 0 {
-			return pkg.Errorf(
-				pkg.INVALID_ERROR,
-				"action cannot be performed. payment lacks enough data to reverse payments",
-			)
-		}
+	// the loan is paid again below, nothing is reverted before it is locked
+	if err := lockForRevert(ctx, q, allocations, []uint32{*paymentData.AssignedTo}, nil); err != nil {
+		return err
+	}
 
-		revertedAmount := 0.0
-		loanID := uint32(0)
-		for _, allocation := range allocations {
-			if allocation.InstallmentID.Valid {
-				loanID = uint32(allocation.LoanID.Int32)
-				revertedAmount += allocation.Amount
-				if err := revertInstallment(ctx, q, uint32(allocation.InstallmentID.Int32), allocation.Amount); err != nil {
-					return err
-				}
-			} else {
-				if err := deductOverpayment(ctx, q, repository.Overpayment{
-					ClientID:  *paymentData.AssignedTo,
-					Amount:    allocation.Amount,
-					PaymentID: &paymentID,
-					CreatedBy: paymentData.AssignedBy,
-					Description: fmt.Sprintf(
-						"UPDATE LOAN PAYMENT: REDUCING OVERPAYMENT: %s",
-						description,
-					),
-				}); err != nil {
-					return err
-				}
+	revertedAmount := pkg.Money(0)
+	penaltiesReverted := pkg.Money(0)
+	loanID := uint32(0)
+	for _, allocation := range allocations {
+		if allocation.PenaltyID.Valid {
+			loanID = uint32(allocation.LoanID.Int32)
+			penaltiesReverted += pkg.MoneyFromFloat(allocation.Amount)
+			if err := revertPenalty(ctx, q, uint32(allocation.PenaltyID.Int32), allocation.Amount); err != nil {
+				return err
+			}
+		} else if allocation.InstallmentID.Valid {
+			loanID = uint32(allocation.LoanID.Int32)
+			revertedAmount += pkg.MoneyFromFloat(allocation.Amount)
+			if err := revertInstallment(ctx, q, uint32(allocation.InstallmentID.Int32), allocation.Amount); err != nil {
+				return err
+			}
+		} else {
+			if err := deductOverpayment(ctx, q, repository.Overpayment{
+				ClientID:  *paymentData.AssignedTo,
+				Amount:    pkg.MoneyFromFloat(allocation.Amount),
+				PaymentID: &paymentID,
+				CreatedBy: paymentData.AssignedBy,
+				Description: fmt.Sprintf(
+					"UPDATE LOAN PAYMENT: REDUCING OVERPAYMENT: %s",
+					description,
+				),
+			}); err != nil {
+				return err
 			}
 		}
+	}
 
-		_, err = q.DeletePaymentAllocationsByNonPostedId(
-			ctx,
-			generated.DeletePaymentAllocationsByNonPostedIdParams{
-				NonPostedID: paymentID,
-				DeletedDescription: sql.NullString{
-					Valid: true,
-					String: fmt.Sprintf(
-						"UPDATE LOAN PAYMENT: DELETING ALLOCATION: %s",
-						description,
-					),
-				},
+	if err := postAllocationReversal(
+		ctx,
+		q,
+		paymentID,
+		allocations,
+		paymentData.AssignedBy,
+		fmt.Sprintf("UPDATE LOAN PAYMENT: REVERSING ALLOCATIONS: %s", description),
+	); err != nil {
+		return err
+	}
+
+	_, err = q.DeletePaymentAllocationsByNonPostedId(
+		ctx,
+		generated.DeletePaymentAllocationsByNonPostedIdParams{
+			NonPostedID: paymentID,
+			DeletedDescription: sql.NullString{
+				Valid: true,
+				String: fmt.Sprintf(
+					"UPDATE LOAN PAYMENT: DELETING ALLOCATION: %s",
+					description,
+				),
 			},
+		},
+	)
+	if err != nil {
+		return pkg.Errorf(
+			pkg.INTERNAL_ERROR,
+			"failed to delete payment allocations: %s",
+			err.Error(),
 		)
-		if err != nil {
-			return pkg.Errorf(
-				pkg.INTERNAL_ERROR,
-				"failed to delete payment allocations: %s",
-				err.Error(),
-			)
+	}
+
+	if revertedAmount+penaltiesReverted > 0 {
+		loanStatus, err := q.GetLoanStatus(ctx, loanID)
+		if err != nil && err != sql.ErrNoRows {
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to get loan status: %s", err.Error())
 		}
 
-		if revertedAmount > 0 {
-			loanStatus, err := q.GetLoanStatus(ctx, loanID)
-			if err != nil && err != sql.ErrNoRows {
-				return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to get loan status: %s", err.Error())
+		if loanStatus == generated.LoansStatusCOMPLETED {
+			hasActiveLoan, err := q.CheckActiveLoanForClient(ctx, *paymentData.AssignedTo)
+			if err != nil {
+				return pkg.Errorf(
+					pkg.INTERNAL_ERROR,
+					"failed to check if client has an active loan: %s",
+					err.Error(),
+				)
 			}
 
-			if loanStatus != generated.LoansStatusACTIVE {
-				hasActiveLoan, err := q.CheckActiveLoanForClient(ctx, *paymentData.AssignedTo)
-				if err != nil {
-					return pkg.Errorf(
-						pkg.INTERNAL_ERROR,
-						"failed to check if client has an active loan: %s",
-						err.Error(),
-					)
-				}
-
-				if hasActiveLoan {
-					return pkg.Errorf(
-						pkg.INVALID_ERROR,
-						"loan is status will change to active and client has another active loan",
-					)
-				}
-
-				_, err = q.UpdateLoanStatus(ctx, generated.UpdateLoanStatusParams{
-					ID:     loanID,
-					Status: generated.LoansStatusACTIVE,
-				})
-				if err != nil {
-					return pkg.Errorf(
-						pkg.INTERNAL_ERROR,
-						"failed to change loans status: %s",
-						err.Error(),
-					)
-				}
+			if hasActiveLoan {
+				return pkg.Errorf(
+					pkg.INVALID_ERROR,
+					"loan is status will change to active and client has another active loan",
+				)
 			}
-		}
 
-		nonPostedParams.AssignedTo = paymentData.AssignedTo
-		if err := p.mySQL.NonPosted.UpdateNonPosted(ctx, nonPostedParams); err != nil {
-			return err
+			_, err = q.UpdateLoanStatus(ctx, generated.UpdateLoanStatusParams{
+				ID:     loanID,
+				Status: generated.LoansStatusACTIVE,
+			})
+			if err != nil {
+				return pkg.Errorf(
+					pkg.INTERNAL_ERROR,
+					"failed to change loans status: %s",
+					err.Error(),
+				)
+			}
 		}
+	}
 
-		loan := &repository.UpdateLoan{
-			ID:         loanID,
-			PaidAmount: paymentData.Amount,
-		}
+	nonPostedParams.AssignedTo = paymentData.AssignedTo
+	if err := mysql.UpdateNonPostedTx(ctx, q, nonPostedParams); err != nil {
+		return err
+	}
 
-		return processLoanPayment(
-			ctx,
-			q,
-			loan,
-			paymentID,
-			*paymentData.AssignedTo,
-			revertedAmount,
-			fmt.Sprintf("UPDATE LOAN PAYMENT: %s", description),
-		)
-	})
+	// a payment that only went to overpayment has no loan and goes back to overpayment
+	loan := &repository.UpdateLoan{
+		ID:         loanID,
+		PaidAmount: pkg.MoneyFromFloat(paymentData.Amount),
+	}
 
-	return err
+	return processLoanPayment(
+		ctx,
+		q,
+		loan,
+		paymentID,
+		*paymentData.AssignedTo,
+		revertedAmount,
+		fmt.Sprintf("UPDATE LOAN PAYMENT: %s", description),
+	)
 }
 
 func (p *PaymentService) DeletePayment(
@@ -360,141 +539,243 @@ func (p *PaymentService) DeletePayment(
 	userID uint32,
 	description string,
 ) error {
-	paymentData, err := p.mySQL.NonPosted.GetNonPosted(ctx, paymentID)
+	paymentData, err := p.getDeletablePayment(ctx, paymentID)
 	if err != nil {
 		return err
 	}
 
+	return p.db.ExecTx(ctx, func(q generated.Querier) error {
+		return deletePayment(ctx, q, paymentData, userID, description)
+	})
+}
+
+func (p *PaymentService) getDeletablePayment(
+	ctx context.Context,
+	paymentID uint32,
+) (repository.NonPosted, error) {
+	paymentData, err := p.mySQL.NonPosted.GetNonPosted(ctx, paymentID)
+	if err != nil {
+		return repository.NonPosted{}, err
+	}
+
 	if paymentData.TransactionSource != "INTERNAL" {
-		return pkg.Errorf(
+		return repository.NonPosted{}, pkg.Errorf(
 			pkg.INVALID_ERROR,
 			"transaction source is not 'INTERNAL'. only 'INTERNAL' transactions can be deleted",
 		)
 	}
 
+	// the refinanced loan was settled out of the top up loan's principal, it cannot be undone
+	// without undoing the top up loan
+	if strings.HasPrefix(paymentData.TransactionNumber, repository.TopUpSettlementPrefix) {
+		return repository.NonPosted{}, pkg.Errorf(
+			pkg.INVALID_ERROR,
+			"top up settlements cannot be deleted",
+		)
+	}
+
+	// the rebated installments were closed with the loan, the settlement cannot be taken back
+	if strings.HasPrefix(paymentData.TransactionNumber, repository.EarlySettlementPrefix) {
+		return repository.NonPosted{}, pkg.Errorf(
+			pkg.INVALID_ERROR,
+			"early settlements cannot be deleted",
+		)
+	}
+
+	if err := checkPaymentNotSplit(ctx, p.queries, paymentID); err != nil {
+		return repository.NonPosted{}, err
+	}
+
+	return paymentData, nil
+}
+
+// deletePayment reverts what the payment paid and deletes it.
+func deletePayment(
+	ctx context.Context,
+	q generated.Querier,
+	paymentData repository.NonPosted,
+	userID uint32,
+	description string,
+) error {
+	paymentID := paymentData.ID
+
+	if err := lockUnsplitPayment(ctx, q, paymentID); err != nil {
+		return err
+	}
+
+	if err := mysql.DeleteProcessedCallbackTx(ctx, q, paymentID); err != nil {
+		return err
+	}
+
 	if paymentData.AssignedTo == nil {
-		if err = p.mySQL.NonPosted.DeleteNonPosted(ctx, paymentID, fmt.Sprintf(
+		return mysql.DeleteNonPostedTx(ctx, q, paymentID, fmt.Sprintf(
 			"DELETE PAYMENT: DELETING PAYMENT: %s",
 			description,
-		)); err != nil {
-			return err
-		}
+		))
+	}
 
-		return nil
+	if err := mysql.CheckPaymentCashBookOpen(
+		ctx,
+		q,
+		paymentData.CashReceipt,
+		paymentData.AssignedBy,
+		paymentData.PaidDate,
+	); err != nil {
+		return err
 	}
 
-	err = p.db.ExecTx(ctx, func(q generated.Querier) error {
-		allocations, err := q.ListPaymentAllocationsByNonPostedId(ctx, paymentID)
-		if err != nil {
-			return err
-		}
+	allocations, err := q.ListPaymentAllocationsByNonPostedId(ctx, paymentID)
+	if err != nil {
+		return err
+	}
 
-		if len(allocations) == 0 {
-			return pkg.Errorf(
-				pkg.INVALID_ERROR,
-				"action cannot be performed. payment lacks enough data to reverse payments",
-			)
-		}
+	if len(allocations) == 0 {
+		return pkg.Errorf(
+			pkg.INVALID_ERROR,
+			"action cannot be performed. payment lacks enough data to reverse payments",
+		)
+	}
 
-		revertedAmount := 0.0
-		loanID := uint32(0)
-		for _, allocation := range allocations {
-			if allocation.InstallmentID.Valid {
-				loanID = uint32(allocation.LoanID.Int32)
-				revertedAmount += allocation.Amount
-				if err := revertInstallment(ctx, q, uint32(allocation.InstallmentID.Int32), allocation.Amount); err != nil {
-					return err
-				}
-			} else {
-				if err := deductOverpayment(ctx, q, repository.Overpayment{
-					ClientID:  *paymentData.AssignedTo,
-					Amount:    allocation.Amount,
-					PaymentID: &paymentID,
-					CreatedBy: paymentData.AssignedBy,
-					Description: fmt.Sprintf(
-						"DELETE PAYMENT: REDUCING OVERPAYMENT: %s",
-						description,
-					),
-				}); err != nil {
-					return err
-				}
+	if err := lockForRevert(ctx, q, allocations, []uint32{*paymentData.AssignedTo}, nil); err != nil {
+		return err
+	}
+
+	revertedAmount := pkg.Money(0)
+	penaltiesReverted := pkg.Money(0)
+	loanID := uint32(0)
+	for _, allocation := range allocations {
+		if allocation.PenaltyID.Valid {
+			loanID = uint32(allocation.LoanID.Int32)
+			penaltiesReverted += pkg.MoneyFromFloat(allocation.Amount)
+			if err := revertPenalty(ctx, q, uint32(allocation.PenaltyID.Int32), allocation.Amount); err != nil {
+				return err
+			}
+		} else if allocation.InstallmentID.Valid {
+			loanID = uint32(allocation.LoanID.Int32)
+			revertedAmount += pkg.MoneyFromFloat(allocation.Amount)
+			if err := revertInstallment(ctx, q, uint32(allocation.InstallmentID.Int32), allocation.Amount); err != nil {
+				return err
+			}
+		} else {
+			if err := deductOverpayment(ctx, q, repository.Overpayment{
+				ClientID:  *paymentData.AssignedTo,
+				Amount:    pkg.MoneyFromFloat(allocation.Amount),
+				PaymentID: &paymentID,
+				CreatedBy: paymentData.AssignedBy,
+				Description: fmt.Sprintf(
+					"DELETE PAYMENT: REDUCING OVERPAYMENT: %s",
+					description,
+				),
+			}); err != nil {
+				return err
 			}
 		}
+	}
 
-		_, err = q.DeletePaymentAllocationsByNonPostedId(
-			ctx,
-			generated.DeletePaymentAllocationsByNonPostedIdParams{
-				NonPostedID: paymentID,
-				DeletedDescription: sql.NullString{
-					Valid: true,
-					String: fmt.Sprintf(
-						"DELETE PAYMENT: DELETING ALLOCATIONS: %s",
-						description,
-					),
-				},
-			},
-		)
-		if err != nil {
-			return pkg.Errorf(
-				pkg.INTERNAL_ERROR,
-				"failed to delete payment allocations: %s",
-				err.Error(),
-			)
-		}
+	if err := postAllocationReversal(
+		ctx,
+		q,
+		paymentID,
+		allocations,
+		paymentData.AssignedBy,
+		fmt.Sprintf("DELETE PAYMENT: REVERSING ALLOCATIONS: %s", description),
+	); err != nil {
+		return err
+	}
 
-		err = q.SoftDeleteNonPosted(ctx, generated.SoftDeleteNonPostedParams{
-			ID: paymentID,
+	_, err = q.DeletePaymentAllocationsByNonPostedId(
+		ctx,
+		generated.DeletePaymentAllocationsByNonPostedIdParams{
+			NonPostedID: paymentID,
 			DeletedDescription: sql.NullString{
 				Valid: true,
 				String: fmt.Sprintf(
-					"DELETE PAYMENT: DELETING PAYMENT: %s",
+					"DELETE PAYMENT: DELETING ALLOCATIONS: %s",
 					description,
 				),
 			},
-		})
-		if err != nil {
-			return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to delete non posted: %s", err.Error())
-		}
+		},
+	)
+	if err != nil {
+		return pkg.Errorf(
+			pkg.INTERNAL_ERROR,
+			"failed to delete payment allocations: %s",
+			err.Error(),
+		)
+	}
 
-		if revertedAmount > 0 {
-			loanStatus, err := q.GetLoanStatus(ctx, loanID)
-			if err != nil && err != sql.ErrNoRows {
-				return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to get loan status: %s", err.Error())
-			}
+	err = q.SoftDeleteNonPosted(ctx, generated.SoftDeleteNonPostedParams{
+		ID: paymentID,
+		DeletedDescription: sql.NullString{
+			Valid: true,
+			String: fmt.Sprintf(
+				"DELETE PAYMENT: DELETING PAYMENT: %s",
+				description,
+			),
+		},
+	})
+	if err != nil {
+		return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to delete non posted: %s", err.Error())
+	}
 
-			if loanStatus != generated.LoansStatusACTIVE {
-				hasActiveLoan, err := q.CheckActiveLoanForClient(ctx, *paymentData.AssignedTo)
-				if err != nil {
-					return pkg.Errorf(
-						pkg.INTERNAL_ERROR,
-						"failed to check if client has an active loan: %s",
-						err.Error(),
-					)
-				}
+	if err := postPaymentDeleted(ctx, q, paymentID, paymentData, fmt.Sprintf(
+		"DELETE PAYMENT: DELETING PAYMENT: %s",
+		description,
+	)); err != nil {
+		return err
+	}
 
-				if hasActiveLoan {
-					return pkg.Errorf(
-						pkg.INVALID_ERROR,
-						"loan status will change to active and client has another active loan",
-					)
-				}
+	if revertedAmount+penaltiesReverted > 0 {
+		loanStatus, err := q.GetLoanStatus(ctx, loanID)
+		if err != nil && err != sql.ErrNoRows {
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to get loan status: %s", err.Error())
+		}
 
-			}
-			_, err = q.ReduceLoan(ctx, generated.ReduceLoanParams{
-				ID:         loanID,
-				PaidAmount: revertedAmount,
-				UpdatedBy: sql.NullInt32{
-					Valid: true,
-					Int32: int32(userID),
-				},
-			})
+		if loanStatus == generated.LoansStatusCOMPLETED {
+			hasActiveLoan, err := q.CheckActiveLoanForClient(ctx, *paymentData.AssignedTo)
 			if err != nil {
-				return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to update loan: %s", err.Error())
+				return pkg.Errorf(
+					pkg.INTERNAL_ERROR,
+					"failed to check if client has an active loan: %s",
+					err.Error(),
+				)
 			}
+
+			if hasActiveLoan {
+				return pkg.Errorf(
+					pkg.INVALID_ERROR,
+					"loan status will change to active and client has another active loan",
+				)
+			}
+
+		}
+		_, err = q.ReduceLoan(ctx, generated.ReduceLoanParams{
+			ID:         loanID,
+			PaidAmount: revertedAmount.Float64(),
+			UpdatedBy: sql.NullInt32{
+				Valid: true,
+				Int32: int32(userID),
+			},
+		})
+		if err != nil {
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to update loan: %s", err.Error())
 		}
 
-		return nil
-	})
+		// an applied overpayment goes back to the client's overpayment balance
+		if strings.HasPrefix(paymentData.TransactionNumber, repository.OverpaymentApplicationPrefix) {
+			if err := updateOverpayment(ctx, q, repository.Overpayment{
+				ClientID:  *paymentData.AssignedTo,
+				Amount:    revertedAmount + penaltiesReverted,
+				PaymentID: &paymentID,
+				Description: fmt.Sprintf(
+					"DELETE PAYMENT: RESTORING APPLIED OVERPAYMENT: %s",
+					description,
+				),
+			}); err != nil {
+				return err
+			}
+		}
+	}
 
-	return err
+	return nil
 }
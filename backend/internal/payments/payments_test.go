@@ -3,64 +3,350 @@ package payments
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"os"
+	"sync"
 	"testing"
+	"time"
 
-	"github.com/EmilioCliff/kokomed-fin/backend/internal/mock"
 	"github.com/EmilioCliff/kokomed-fin/backend/internal/mysql"
 	"github.com/EmilioCliff/kokomed-fin/backend/internal/mysql/generated"
-	"github.com/EmilioCliff/kokomed-fin/backend/internal/mysql/mockdb"
 	"github.com/EmilioCliff/kokomed-fin/backend/internal/repository"
 	"github.com/EmilioCliff/kokomed-fin/backend/internal/services"
 	"github.com/EmilioCliff/kokomed-fin/backend/pkg"
-	"go.uber.org/mock/gomock"
+	"github.com/stretchr/testify/require"
 )
 
-type TestGRPCServer struct {
-	*PaymentService
-	LoansRepository mock.MockLoanRepository
-}
+// the payment tests run against a real database, row locks and retried transactions cannot be
+// exercised on a mocked querier. They are skipped unless TEST_DB_DSN points at a test database,
+// e.g. TEST_DB_DSN="root:secret@tcp(localhost:3306)/kokomed_test?parseTime=true".
+const testDSNEnv = "TEST_DB_DSN"
 
-func mockCreateLoan(ctx context.Context, loan *repository.Loan) (repository.LoanFullData, error) {
-	return repository.LoanFullData{}, nil
+type testPayments struct {
+	payments *PaymentService
+	mySQL    *mysql.MySQLRepo
+	db       *sql.DB
 }
 
-func TestProcessCallback(t *testing.T) {
-	server := &TestGRPCServer{}
+type testLoan struct {
+	ClientID uint32
+	LoanID   uint32
+}
 
-	server.mySQL.Loans = &server.LoansRepository
+func newTestPayments(t *testing.T) *testPayments {
+	t.Helper()
 
-	server.LoansRepository.MockCreateLoan = mockCreateLoan
+	dsn := os.Getenv(testDSNEnv)
+	if dsn == "" {
+		t.Skipf("%s is not set", testDSNEnv)
+	}
 
-	store := mysql.NewStore(pkg.Config{})
-	db := mysql.NewMySQLRepo(store)
+	config := pkg.Config{
+		DB_DSN:         dsn,
+		MIGRATION_PATH: "file://../mysql/migrations",
+	}
 
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
+	store := mysql.NewStore(config)
+	require.NoError(t, store.OpenDB())
+	t.Cleanup(func() { _ = store.CloseDB() })
 
-	// store.
+	db, err := sql.Open("mysql", dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
 
-	mockQueries := mockdb.NewMockQuerier(ctrl)
+	repo := mysql.NewMySQLRepo(store)
 
-	store.NewQuerierFn = func(tx *sql.Tx) generated.Querier {
-		return mockQueries
+	return &testPayments{
+		payments: NewPaymentService(repo, store, config),
+		mySQL:    repo,
+		db:       db,
 	}
+}
+
+type testClient struct {
+	BranchID uint32
+	UserID   uint32
+	ClientID uint32
+}
+
+// seedClient creates a branch, a user and a client without a loan.
+func (tp *testPayments) seedClient(t *testing.T) testClient {
+	t.Helper()
+
+	ctx := context.Background()
+	suffix := fmt.Sprintf("%d", time.Now().UnixNano())
+
+	branch, err := tp.mySQL.Branches.CreateBranch(ctx, &repository.Branch{Name: "branch-" + suffix})
+	require.NoError(t, err)
 
-	payments := NewPaymentService(db, store)
+	userID := tp.seedUser(t, branch.ID, suffix)
 
-	callbackData := &services.MpesaCallbackData{
+	client, err := tp.mySQL.Clients.CreateClient(ctx, &repository.Client{
+		FullName:      "client " + suffix,
+		PhoneNumber:   "07" + suffix,
+		Gender:        "MALE",
+		BranchID:      branch.ID,
+		AssignedStaff: userID,
+		UpdatedBy:     userID,
+		CreatedBy:     userID,
+	})
+	require.NoError(t, err)
+
+	return testClient{BranchID: branch.ID, UserID: userID, ClientID: client.ID}
+}
+
+// seedLoan creates a client with a product and disburses the client a loan repaying 5200 over 4
+// weekly installments of 1300.
+func (tp *testPayments) seedLoan(t *testing.T) testLoan {
+	t.Helper()
+
+	ctx := context.Background()
+
+	client := tp.seedClient(t)
+	userID := client.UserID
+
+	product, err := tp.mySQL.Products.CreateProduct(ctx, &repository.Product{
+		BranchID:       client.BranchID,
+		LoanAmount:     pkg.MoneyFromFloat(4000),
+		RepayAmount:    pkg.MoneyFromFloat(5200),
+		InterestAmount: pkg.MoneyFromFloat(1200),
+		UpdatedBy:      userID,
+	})
+	require.NoError(t, err)
+
+	disbursedOn := time.Now()
+	dueDate := disbursedOn.AddDate(0, 0, 28)
+
+	loan, err := tp.mySQL.Loans.CreateLoan(ctx, &repository.Loan{
+		ProductID:          product.ID,
+		ClientID:           client.ClientID,
+		LoanOfficerID:      userID,
+		ApprovedBy:         userID,
+		DisbursedOn:        &disbursedOn,
+		DisbursedBy:        &userID,
+		DueDate:            &dueDate,
+		TotalInstallments:  4,
+		InstallmentsPeriod: 7,
+		ProcessingFee:      pkg.MoneyFromFloat(400),
+		CreatedBy:          userID,
+	})
+	require.NoError(t, err)
+
+	return testLoan{ClientID: client.ClientID, LoanID: loan.ID}
+}
+
+// seedUser inserts a user directly, a user's created_by references the users table so the
+// first user of an empty database cannot be created through the repository.
+func (tp *testPayments) seedUser(t *testing.T, branchID uint32, suffix string) uint32 {
+	t.Helper()
+
+	ctx := context.Background()
+
+	conn, err := tp.db.Conn(ctx)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	_, err = conn.ExecContext(ctx, "SET FOREIGN_KEY_CHECKS = 0")
+	require.NoError(t, err)
+
+	result, err := conn.ExecContext(ctx, `INSERT INTO users
+		(full_name, phone_number, email, password, refresh_token, role, branch_id, updated_by, updated_at, created_by)
+		VALUES (?, ?, ?, '', '', 'ADMIN', ?, 0, NOW(), 0)`,
+		"user "+suffix, "01"+suffix, suffix+"@example.com", branchID,
+	)
+	require.NoError(t, err)
+
+	id, err := result.LastInsertId()
+	require.NoError(t, err)
+
+	_, err = conn.ExecContext(ctx, "UPDATE users SET updated_by = id, created_by = id WHERE id = ?", id)
+	require.NoError(t, err)
+
+	_, err = conn.ExecContext(ctx, "SET FOREIGN_KEY_CHECKS = 1")
+	require.NoError(t, err)
+
+	return uint32(id)
+}
+
+func (tp *testPayments) callback(clientID uint32, transactionID string, amount float64) *services.MpesaCallbackData {
+	return &services.MpesaCallbackData{
 		TransactionSource: "MPESA",
-		TransactionID:     "1234567890",
-		AccountNumber:     "1234567890",
-		PhoneNumber:       "1234567890",
+		TransactionID:     transactionID,
+		AccountNumber:     "account",
+		PhoneNumber:       "0700000000",
 		PayingName:        "John Doe",
-		Amount:            1000,
-		AssignedTo:        &[]uint32{1}[0],
+		Amount:            amount,
+		AssignedTo:        &clientID,
+	}
+}
+
+// requireLoanPaid checks the loan, its installments and the allocations of its payments all
+// agree on paid.
+func (tp *testPayments) requireLoanPaid(
+	t *testing.T,
+	loan testLoan,
+	paid pkg.Money,
+	remaining []pkg.Money,
+	payments map[uint32]pkg.Money,
+) {
+	t.Helper()
+
+	ctx := context.Background()
+	q := generated.New(tp.db)
+
+	dbLoan, err := q.GetLoan(ctx, loan.LoanID)
+	require.NoError(t, err)
+	require.Equal(t, paid, pkg.MoneyFromFloat(dbLoan.PaidAmount))
+
+	installments, err := q.ListInstallmentsByLoan(ctx, loan.LoanID)
+	require.NoError(t, err)
+	require.Len(t, installments, len(remaining))
+
+	for i, installment := range installments {
+		require.Equal(t, remaining[i], pkg.MoneyFromFloat(installment.RemainingAmount), "installment %d", i+1)
+		require.Equal(t, remaining[i] == 0, installment.Paid, "installment %d", i+1)
 	}
 
-	loanId, err := payments.ProcessCallback(context.Background(), callbackData)
-	if err != nil {
-		t.Fatalf("failed to process callback: %v", err)
+	allocations, err := q.ListPaymentAllocationsByLoanId(ctx, sql.NullInt32{Int32: int32(loan.LoanID), Valid: true})
+	require.NoError(t, err)
+
+	allocated := pkg.Money(0)
+	byPayment := make(map[uint32]pkg.Money)
+	for _, allocation := range allocations {
+		allocated += pkg.MoneyFromFloat(allocation.Amount)
+		byPayment[allocation.NonPostedID] += pkg.MoneyFromFloat(allocation.Amount)
 	}
 
-	t.Logf("loanId: %d", loanId)
+	require.Equal(t, paid, allocated)
+	require.Equal(t, payments, byPayment)
+
+	overpayment, err := q.GetClientOverpayment(ctx, loan.ClientID)
+	require.NoError(t, err)
+	require.Zero(t, overpayment)
+}
+
+func (tp *testPayments) paymentID(t *testing.T, transactionID string) uint32 {
+	t.Helper()
+
+	processed, err := tp.mySQL.NonPosted.GetProcessedCallback(context.Background(), transactionID, "MPESA")
+	require.NoError(t, err)
+
+	return processed.NonPostedID
+}
+
+func TestProcessCallback(t *testing.T) {
+	tp := newTestPayments(t)
+	loan := tp.seedLoan(t)
+
+	transactionID := fmt.Sprintf("TX%d", time.Now().UnixNano())
+
+	loanID, err := tp.payments.ProcessCallback(context.Background(), tp.callback(loan.ClientID, transactionID, 1500))
+	require.NoError(t, err)
+	require.Equal(t, loan.LoanID, loanID)
+
+	// a redelivered callback is not paid twice
+	loanID, err = tp.payments.ProcessCallback(context.Background(), tp.callback(loan.ClientID, transactionID, 1500))
+	require.NoError(t, err)
+	require.Equal(t, loan.LoanID, loanID)
+
+	tp.requireLoanPaid(
+		t,
+		loan,
+		pkg.MoneyFromFloat(1500),
+		[]pkg.Money{0, pkg.MoneyFromFloat(1100), pkg.MoneyFromFloat(1300), pkg.MoneyFromFloat(1300)},
+		map[uint32]pkg.Money{tp.paymentID(t, transactionID): pkg.MoneyFromFloat(1500)},
+	)
+}
+
+func TestProcessCallback_ConcurrentPayments(t *testing.T) {
+	tp := newTestPayments(t)
+	loan := tp.seedLoan(t)
+
+	const n = 8
+	amount := 500.0
+
+	prefix := fmt.Sprintf("TX%d-", time.Now().UnixNano())
+
+	var wg sync.WaitGroup
+	errs := make(chan error, n)
+
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			_, err := tp.payments.ProcessCallback(
+				context.Background(),
+				tp.callback(loan.ClientID, fmt.Sprintf("%s%d", prefix, i), amount),
+			)
+			errs <- err
+		}(i)
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	payments := make(map[uint32]pkg.Money, n)
+	for i := 0; i < n; i++ {
+		payments[tp.paymentID(t, fmt.Sprintf("%s%d", prefix, i))] = pkg.MoneyFromFloat(amount)
+	}
+
+	// 8 x 500 pays the first three installments of 1300 and 100 of the last
+	tp.requireLoanPaid(
+		t,
+		loan,
+		pkg.MoneyFromFloat(n*amount),
+		[]pkg.Money{0, 0, 0, pkg.MoneyFromFloat(1200)},
+		payments,
+	)
+}
+
+func TestProcessCallback_NoPayableLoan(t *testing.T) {
+	tp := newTestPayments(t)
+	client := tp.seedClient(t)
+
+	ctx := context.Background()
+	q := generated.New(tp.db)
+
+	transactionID := fmt.Sprintf("TX%d", time.Now().UnixNano())
+
+	callback := tp.callback(client.ClientID, transactionID, 1500)
+	callback.TransactionSource = "INTERNAL"
+	callback.AssignedBy = "USER 1"
+
+	loanID, err := tp.payments.ProcessCallback(ctx, callback)
+	require.NoError(t, err)
+	require.Zero(t, loanID)
+
+	processed, err := tp.mySQL.NonPosted.GetProcessedCallback(ctx, transactionID, "INTERNAL")
+	require.NoError(t, err)
+
+	requireOverpaid := func(amount pkg.Money) {
+		t.Helper()
+
+		overpayment, err := q.GetClientOverpayment(ctx, client.ClientID)
+		require.NoError(t, err)
+		require.Equal(t, amount, pkg.MoneyFromFloat(overpayment))
+
+		allocations, err := q.ListPaymentAllocationsByNonPostedId(ctx, processed.NonPostedID)
+		require.NoError(t, err)
+		require.Len(t, allocations, 1)
+		require.False(t, allocations[0].LoanID.Valid)
+		require.Equal(t, amount, pkg.MoneyFromFloat(allocations[0].Amount))
+	}
+
+	// the whole payment is kept as overpayment
+	requireOverpaid(pkg.MoneyFromFloat(1500))
+
+	// updating it moves the overpayment instead of failing on a missing loan
+	callback.Amount = 700
+	err = tp.payments.UpdatePayment(ctx, processed.NonPostedID, client.UserID, "wrong amount", callback)
+	require.NoError(t, err)
+
+	requireOverpaid(pkg.MoneyFromFloat(700))
 }
@@ -0,0 +1,97 @@
+package payments
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/mysql/generated"
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/repository"
+	"github.com/EmilioCliff/kokomed-fin/backend/pkg"
+)
+
+// payLoanPenalties settles the loan's unpaid penalties oldest first and returns what was used.
+func payLoanPenalties(
+	ctx context.Context,
+	q generated.Querier,
+	amount pkg.Money,
+	loanID uint32,
+	paymentID uint32,
+	penalties []generated.InstallmentPenalty,
+	allocationMessage string,
+) (pkg.Money, error) {
+	totalPaid := pkg.Money(0)
+
+	for _, penalty := range penalties {
+		if amount <= 0 {
+			break
+		}
+
+		remaining := pkg.MoneyFromFloat(penalty.RemainingAmount)
+		if remaining <= 0 {
+			continue
+		}
+
+		pay := pkg.MinMoney(amount, remaining)
+		amount -= pay
+		totalPaid += pay
+
+		params := generated.PayInstallmentPenaltyParams{
+			ID:              penalty.ID,
+			RemainingAmount: (remaining - pay).Float64(),
+		}
+
+		description := fmt.Sprintf("%s: penalty paid partially", allocationMessage)
+
+		if remaining-pay <= 0 {
+			params.RemainingAmount = 0
+			params.Paid = sql.NullBool{
+				Valid: true,
+				Bool:  true,
+			}
+			params.PaidAt = sql.NullTime{
+				Valid: true,
+				Time:  time.Now(),
+			}
+			description = fmt.Sprintf("%s: penalty paid fully", allocationMessage)
+		}
+
+		if _, err := q.PayInstallmentPenalty(ctx, params); err != nil {
+			return 0, pkg.Errorf(pkg.INTERNAL_ERROR, "failed to pay penalty: %s", err.Error())
+		}
+
+		if err := createAllocation(ctx, q, repository.PaymentAllocation{
+			NonPostedID: paymentID,
+			LoanID:      &loanID,
+			PenaltyID:   pkg.Uint32Ptr(penalty.ID),
+			Amount:      pay,
+			Description: description,
+		}); err != nil {
+			return 0, err
+		}
+	}
+
+	return totalPaid, nil
+}
+
+func revertPenalty(
+	ctx context.Context,
+	q generated.Querier,
+	penaltyID uint32,
+	paidAmount float64,
+) error {
+	_, err := q.RevertInstallmentPenalty(ctx, generated.RevertInstallmentPenaltyParams{
+		ID:              penaltyID,
+		RemainingAmount: paidAmount,
+	})
+	if err != nil {
+		return pkg.Errorf(
+			pkg.INTERNAL_ERROR,
+			"failed to update penalty: %s",
+			err.Error(),
+		)
+	}
+
+	return nil
+}
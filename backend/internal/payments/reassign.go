@@ -0,0 +1,420 @@
+package payments
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/mysql/generated"
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/repository"
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/services"
+	"github.com/EmilioCliff/kokomed-fin/backend/pkg"
+)
+
+// ReassignPayment moves an MPESA payment that was assigned to the wrong client. Everything the
+// payment did for the old client is undone before it is allocated to the new client.
+func (p *PaymentService) ReassignPayment(
+	ctx context.Context,
+	paymentID uint32,
+	reassignData *services.ReassignPaymentData,
+) (services.PaymentReassignment, error) {
+	reason := strings.TrimSpace(reassignData.Reason)
+	if reason == "" {
+		return services.PaymentReassignment{}, pkg.Errorf(
+			pkg.INVALID_ERROR,
+			"a reassignment reason is required",
+		)
+	}
+
+	var reassignmentID uint32
+
+	err := p.db.ExecTx(ctx, func(q generated.Querier) error {
+		nonPosted, err := q.GetNonPosted(ctx, paymentID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return pkg.Errorf(pkg.NOT_FOUND_ERROR, "no non posted found")
+			}
+
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to get non posted: %s", err.Error())
+		}
+
+		if nonPosted.DeletedAt.Valid {
+			return pkg.Errorf(pkg.INVALID_ERROR, "payment has been deleted")
+		}
+
+		if nonPosted.TransactionSource != generated.NonPostedTransactionSourceMPESA {
+			return pkg.Errorf(
+				pkg.INVALID_ERROR,
+				"only 'MPESA' payments can be reassigned, update 'INTERNAL' payments instead",
+			)
+		}
+
+		if !nonPosted.AssignTo.Valid {
+			return pkg.Errorf(
+				pkg.INVALID_ERROR,
+				"payment is not assigned, assign it to a client instead",
+			)
+		}
+
+		fromClientID := uint32(nonPosted.AssignTo.Int32)
+		if fromClientID == reassignData.ClientID {
+			return pkg.Errorf(pkg.INVALID_ERROR, "payment is already assigned to the client")
+		}
+
+		if err := checkPaymentNotSplit(ctx, q, paymentID); err != nil {
+			return err
+		}
+
+		if _, err := q.GetClient(ctx, reassignData.ClientID); err != nil {
+			if err == sql.ErrNoRows {
+				return pkg.Errorf(pkg.NOT_FOUND_ERROR, "client %d not found", reassignData.ClientID)
+			}
+
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to get client: %s", err.Error())
+		}
+
+		toLoanID, err := q.GetClientPayableLoan(ctx, reassignData.ClientID)
+		if err != nil && err != sql.ErrNoRows {
+			return pkg.Errorf(
+				pkg.INTERNAL_ERROR,
+				"failed to get client active loan: %s",
+				err.Error(),
+			)
+		}
+
+		hasLoan := err == nil
+
+		lock := paymentAllocationsUnwind{
+			PaymentID:     paymentID,
+			ClientID:      fromClientID,
+			UpdatedBy:     reassignData.ReassignedBy,
+			CreatedBy:     reassignData.AssignedBy,
+			Description:   fmt.Sprintf("REASSIGN PAYMENT: %s", reason),
+			LockClientIDs: []uint32{reassignData.ClientID},
+		}
+		if hasLoan {
+			lock.LockLoanIDs = []uint32{toLoanID}
+		}
+
+		fromLoanID, err := unwindPaymentAllocations(ctx, q, lock)
+		if err != nil {
+			return err
+		}
+
+		if hasLoan {
+			if err := processLoanPayment(ctx, q, &repository.UpdateLoan{
+				ID:         toLoanID,
+				PaidAmount: pkg.MoneyFromFloat(nonPosted.Amount),
+				UpdatedBy:  &reassignData.ReassignedBy,
+			}, paymentID, reassignData.ClientID, 0, "REASSIGNED LOAN PAYMENT"); err != nil {
+				return err
+			}
+		} else {
+			if err := updateOverpayment(ctx, q, repository.Overpayment{
+				ClientID:    reassignData.ClientID,
+				Amount:      pkg.MoneyFromFloat(nonPosted.Amount),
+				PaymentID:   &paymentID,
+				Description: "REASSIGNED LOAN PAYMENT: no active loan adding to overpayment",
+			}); err != nil {
+				return err
+			}
+
+			if err := createAllocation(ctx, q, repository.PaymentAllocation{
+				NonPostedID: paymentID,
+				Amount:      pkg.MoneyFromFloat(nonPosted.Amount),
+				Description: "REASSIGNED LOAN PAYMENT: no active loan adding to overpayment",
+			}); err != nil {
+				return err
+			}
+		}
+
+		_, err = q.AssignNonPosted(ctx, generated.AssignNonPostedParams{
+			ID: paymentID,
+			AssignTo: sql.NullInt32{
+				Valid: true,
+				Int32: int32(reassignData.ClientID),
+			},
+			TransactionSource: nonPosted.TransactionSource,
+			AssignedBy: sql.NullString{
+				Valid:  true,
+				String: fmt.Sprintf("REASSIGNED: %s", reassignData.AssignedBy),
+			},
+		})
+		if err != nil {
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to assign non posted: %s", err.Error())
+		}
+
+		params := generated.CreatePaymentReassignmentParams{
+			NonPostedID:  paymentID,
+			FromClientID: fromClientID,
+			ToClientID:   reassignData.ClientID,
+			Amount:       nonPosted.Amount,
+			Reason:       reason,
+			ReassignedBy: reassignData.ReassignedBy,
+		}
+
+		if fromLoanID != nil {
+			params.FromLoanID = sql.NullInt32{Valid: true, Int32: int32(*fromLoanID)}
+		}
+
+		if hasLoan {
+			params.ToLoanID = sql.NullInt32{Valid: true, Int32: int32(toLoanID)}
+		}
+
+		execResult, err := q.CreatePaymentReassignment(ctx, params)
+		if err != nil {
+			return pkg.Errorf(
+				pkg.INTERNAL_ERROR,
+				"failed to create payment reassignment: %s",
+				err.Error(),
+			)
+		}
+
+		id, err := execResult.LastInsertId()
+		if err != nil {
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to get last insert id: %s", err.Error())
+		}
+
+		reassignmentID = uint32(id)
+
+		return nil
+	})
+	if err != nil {
+		return services.PaymentReassignment{}, err
+	}
+
+	var reassignment generated.PaymentReassignment
+
+	err = p.db.ExecTx(ctx, func(q generated.Querier) error {
+		var err error
+
+		reassignment, err = q.GetPaymentReassignment(ctx, reassignmentID)
+		if err != nil {
+			return pkg.Errorf(
+				pkg.INTERNAL_ERROR,
+				"failed to get payment reassignment: %s",
+				err.Error(),
+			)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return services.PaymentReassignment{}, err
+	}
+
+	return convertPaymentReassignment(reassignment), nil
+}
+
+func (p *PaymentService) ListPaymentReassignments(
+	ctx context.Context,
+	paymentID uint32,
+) ([]services.PaymentReassignment, error) {
+	var reassignments []generated.PaymentReassignment
+
+	err := p.db.ExecTx(ctx, func(q generated.Querier) error {
+		var err error
+
+		reassignments, err = q.ListPaymentReassignmentsByNonPosted(ctx, paymentID)
+		if err != nil {
+			return pkg.Errorf(
+				pkg.INTERNAL_ERROR,
+				"failed to list payment reassignments: %s",
+				err.Error(),
+			)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rsp := make([]services.PaymentReassignment, len(reassignments))
+	for i, reassignment := range reassignments {
+		rsp[i] = convertPaymentReassignment(reassignment)
+	}
+
+	return rsp, nil
+}
+
+type paymentAllocationsUnwind struct {
+	PaymentID   uint32
+	ClientID    uint32
+	UpdatedBy   uint32
+	CreatedBy   string
+	Description string
+	// LockClientIDs and LockLoanIDs are paid after the unwind and are locked with the payment's
+	LockClientIDs []uint32
+	LockLoanIDs   []uint32
+}
+
+// unwindPaymentAllocations reverts the installments a payment paid, takes back what it added to
+// the client's overpayment and soft deletes its allocations. It returns the loan that was paid.
+func unwindPaymentAllocations(
+	ctx context.Context,
+	q generated.Querier,
+	data paymentAllocationsUnwind,
+) (*uint32, error) {
+	allocations, err := q.ListPaymentAllocationsByNonPostedId(ctx, data.PaymentID)
+	if err != nil {
+		return nil, pkg.Errorf(
+			pkg.INTERNAL_ERROR,
+			"failed to list payment allocations: %s",
+			err.Error(),
+		)
+	}
+
+	if len(allocations) == 0 {
+		return nil, pkg.Errorf(
+			pkg.INVALID_ERROR,
+			"action cannot be performed. payment lacks enough data to reverse payments",
+		)
+	}
+
+	if err := lockForRevert(
+		ctx,
+		q,
+		allocations,
+		append([]uint32{data.ClientID}, data.LockClientIDs...),
+		data.LockLoanIDs,
+	); err != nil {
+		return nil, err
+	}
+
+	var loanID *uint32
+	revertedAmount := pkg.Money(0)
+	overpaid := pkg.Money(0)
+
+	for _, allocation := range allocations {
+		if allocation.PenaltyID.Valid {
+			if err := revertPenalty(ctx, q, uint32(allocation.PenaltyID.Int32), allocation.Amount); err != nil {
+				return nil, err
+			}
+
+			loanID = pkg.Uint32Ptr(uint32(allocation.LoanID.Int32))
+
+			continue
+		}
+
+		if !allocation.InstallmentID.Valid {
+			overpaid += pkg.MoneyFromFloat(allocation.Amount)
+
+			continue
+		}
+
+		if err := revertInstallment(ctx, q, uint32(allocation.InstallmentID.Int32), allocation.Amount); err != nil {
+			return nil, err
+		}
+
+		loanID = pkg.Uint32Ptr(uint32(allocation.LoanID.Int32))
+		revertedAmount += pkg.MoneyFromFloat(allocation.Amount)
+	}
+
+	if loanID != nil {
+		loanStatus, err := q.GetLoanStatus(ctx, *loanID)
+		if err != nil {
+			return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "failed to get loan status: %s", err.Error())
+		}
+
+		if loanStatus == generated.LoansStatusCOMPLETED {
+			hasActiveLoan, err := q.CheckActiveLoanForClient(ctx, data.ClientID)
+			if err != nil {
+				return nil, pkg.Errorf(
+					pkg.INTERNAL_ERROR,
+					"failed to check if client has an active loan: %s",
+					err.Error(),
+				)
+			}
+
+			if hasActiveLoan {
+				return nil, pkg.Errorf(
+					pkg.INVALID_ERROR,
+					"loan %d status will change to active and client has another active loan",
+					*loanID,
+				)
+			}
+		}
+
+		_, err = q.ReduceLoan(ctx, generated.ReduceLoanParams{
+			ID:         *loanID,
+			PaidAmount: revertedAmount.Float64(),
+			UpdatedBy: sql.NullInt32{
+				Valid: true,
+				Int32: int32(data.UpdatedBy),
+			},
+		})
+		if err != nil {
+			return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "failed to update loan: %s", err.Error())
+		}
+	}
+
+	if overpaid > 0 {
+		if err := deductOverpayment(ctx, q, repository.Overpayment{
+			ClientID:    data.ClientID,
+			Amount:      overpaid,
+			PaymentID:   &data.PaymentID,
+			CreatedBy:   data.CreatedBy,
+			Description: fmt.Sprintf("%s: REDUCING OVERPAYMENT", data.Description),
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := postAllocationReversal(
+		ctx,
+		q,
+		data.PaymentID,
+		allocations,
+		data.CreatedBy,
+		fmt.Sprintf("%s: REVERSING ALLOCATIONS", data.Description),
+	); err != nil {
+		return nil, err
+	}
+
+	_, err = q.DeletePaymentAllocationsByNonPostedId(
+		ctx,
+		generated.DeletePaymentAllocationsByNonPostedIdParams{
+			NonPostedID: data.PaymentID,
+			DeletedDescription: sql.NullString{
+				Valid:  true,
+				String: fmt.Sprintf("%s: DELETING ALLOCATIONS", data.Description),
+			},
+		},
+	)
+	if err != nil {
+		return nil, pkg.Errorf(
+			pkg.INTERNAL_ERROR,
+			"failed to delete payment allocations: %s",
+			err.Error(),
+		)
+	}
+
+	return loanID, nil
+}
+
+func convertPaymentReassignment(
+	reassignment generated.PaymentReassignment,
+) services.PaymentReassignment {
+	rsp := services.PaymentReassignment{
+		ID:           reassignment.ID,
+		NonPostedID:  reassignment.NonPostedID,
+		FromClientID: reassignment.FromClientID,
+		ToClientID:   reassignment.ToClientID,
+		Amount:       reassignment.Amount,
+		Reason:       reassignment.Reason,
+		ReassignedBy: reassignment.ReassignedBy,
+		CreatedAt:    reassignment.CreatedAt,
+	}
+
+	if reassignment.FromLoanID.Valid {
+		rsp.FromLoanID = pkg.Uint32Ptr(uint32(reassignment.FromLoanID.Int32))
+	}
+
+	if reassignment.ToLoanID.Valid {
+		rsp.ToLoanID = pkg.Uint32Ptr(uint32(reassignment.ToLoanID.Int32))
+	}
+
+	return rsp
+}
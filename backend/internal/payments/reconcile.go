@@ -0,0 +1,412 @@
+package payments
+
+import (
+	"context"
+	"database/sql"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/mysql/generated"
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/services"
+	"github.com/EmilioCliff/kokomed-fin/backend/pkg"
+)
+
+func (p *PaymentService) ReconcileStatement(
+	ctx context.Context,
+	statementData *services.StatementData,
+) (services.ReconciliationReport, error) {
+	if len(statementData.Lines) == 0 {
+		return services.ReconciliationReport{}, pkg.Errorf(
+			pkg.INVALID_ERROR,
+			"statement has no paid in lines",
+		)
+	}
+
+	statementDate := statementData.Lines[0].PaidDate
+	if statementData.StatementDate != nil {
+		statementDate = *statementData.StatementDate
+	}
+
+	statementDate = statementDate.In(pkg.NairobiLocation())
+	fromDate := time.Date(
+		statementDate.Year(),
+		statementDate.Month(),
+		statementDate.Day(),
+		0,
+		0,
+		0,
+		0,
+		pkg.NairobiLocation(),
+	)
+	toDate := fromDate.AddDate(0, 0, 1).Add(-time.Second)
+
+	var recorded []generated.NonPosted
+
+	err := p.db.ExecTx(ctx, func(q generated.Querier) error {
+		var err error
+
+		recorded, err = q.ListMpesaNonPostedByPaidDate(ctx, generated.ListMpesaNonPostedByPaidDateParams{
+			FromDate: fromDate,
+			ToDate:   toDate,
+		})
+		if err != nil {
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to list non posted: %s", err.Error())
+		}
+
+		return nil
+	})
+	if err != nil {
+		return services.ReconciliationReport{}, err
+	}
+
+	recordedByNumber := make(map[string]generated.NonPosted, len(recorded))
+	for _, nonPosted := range recorded {
+		recordedByNumber[nonPosted.TransactionNumber] = nonPosted
+	}
+
+	reconciliation := generated.CreateStatementReconciliationParams{
+		StatementDate: fromDate,
+		FileName:      statementData.FileName,
+		UploadedBy:    statementData.UploadedBy,
+	}
+	items := []generated.CreateStatementReconciliationItemParams{}
+	seen := map[string]bool{}
+
+	for _, line := range statementData.Lines {
+		if seen[line.TransactionNumber] {
+			continue
+		}
+
+		seen[line.TransactionNumber] = true
+		reconciliation.TotalLines++
+
+		nonPosted, ok := recordedByNumber[line.TransactionNumber]
+		if !ok {
+			// the callback may have landed on another day so look it up by transaction number
+			processed, err := p.mySQL.NonPosted.GetProcessedCallback(
+				ctx,
+				line.TransactionNumber,
+				string(generated.NonPostedTransactionSourceMPESA),
+			)
+			if err != nil && pkg.ErrorCode(err) != pkg.NOT_FOUND_ERROR {
+				return services.ReconciliationReport{}, err
+			}
+
+			if err != nil {
+				reconciliation.MissingCallbacks++
+				items = append(items, generated.CreateStatementReconciliationItemParams{
+					ItemType:          generated.StatementReconciliationItemsItemTypeMISSINGCALLBACK,
+					TransactionNumber: line.TransactionNumber,
+					StatementAmount:   line.Amount,
+					AccountNumber:     line.AccountNumber,
+					PhoneNumber:       line.PhoneNumber,
+					PayingName:        line.PayingName,
+					PaidDate:          line.PaidDate,
+				})
+
+				continue
+			}
+
+			existing, err := p.mySQL.NonPosted.GetNonPosted(ctx, processed.NonPostedID)
+			if err != nil {
+				return services.ReconciliationReport{}, err
+			}
+
+			nonPosted = generated.NonPosted{
+				ID:                existing.ID,
+				TransactionNumber: existing.TransactionNumber,
+				Amount:            existing.Amount.Float64(),
+			}
+		}
+
+		delete(recordedByNumber, line.TransactionNumber)
+
+		if math.Abs(nonPosted.Amount-line.Amount) < 0.01 {
+			reconciliation.Matched++
+
+			continue
+		}
+
+		reconciliation.AmountMismatches++
+		items = append(items, generated.CreateStatementReconciliationItemParams{
+			ItemType:          generated.StatementReconciliationItemsItemTypeAMOUNTMISMATCH,
+			TransactionNumber: line.TransactionNumber,
+			StatementAmount:   line.Amount,
+			RecordedAmount:    nonPosted.Amount,
+			NonPostedID: sql.NullInt32{
+				Valid: true,
+				Int32: int32(nonPosted.ID),
+			},
+			AccountNumber: line.AccountNumber,
+			PhoneNumber:   line.PhoneNumber,
+			PayingName:    line.PayingName,
+			PaidDate:      line.PaidDate,
+		})
+	}
+
+	// whatever is left was recorded that day but safaricom has no record of it
+	for _, nonPosted := range recorded {
+		if _, ok := recordedByNumber[nonPosted.TransactionNumber]; !ok {
+			continue
+		}
+
+		delete(recordedByNumber, nonPosted.TransactionNumber)
+
+		reconciliation.NotOnStatement++
+		items = append(items, generated.CreateStatementReconciliationItemParams{
+			ItemType:          generated.StatementReconciliationItemsItemTypeNOTONSTATEMENT,
+			TransactionNumber: nonPosted.TransactionNumber,
+			RecordedAmount:    nonPosted.Amount,
+			NonPostedID: sql.NullInt32{
+				Valid: true,
+				Int32: int32(nonPosted.ID),
+			},
+			AccountNumber: nonPosted.AccountNumber,
+			PhoneNumber:   nonPosted.PhoneNumber,
+			PayingName:    nonPosted.PayingName,
+			PaidDate:      nonPosted.PaidDate,
+		})
+	}
+
+	var reconciliationID int64
+
+	err = p.db.ExecTx(ctx, func(q generated.Querier) error {
+		execResult, err := q.CreateStatementReconciliation(ctx, reconciliation)
+		if err != nil {
+			return pkg.Errorf(
+				pkg.INTERNAL_ERROR,
+				"failed to create statement reconciliation: %s",
+				err.Error(),
+			)
+		}
+
+		reconciliationID, err = execResult.LastInsertId()
+		if err != nil {
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to get last insert id: %s", err.Error())
+		}
+
+		for _, item := range items {
+			item.ReconciliationID = uint32(reconciliationID)
+
+			if _, err := q.CreateStatementReconciliationItem(ctx, item); err != nil {
+				return pkg.Errorf(
+					pkg.INTERNAL_ERROR,
+					"failed to create statement reconciliation item: %s",
+					err.Error(),
+				)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return services.ReconciliationReport{}, err
+	}
+
+	return p.GetReconciliation(ctx, uint32(reconciliationID))
+}
+
+func (p *PaymentService) GetReconciliation(
+	ctx context.Context,
+	id uint32,
+) (services.ReconciliationReport, error) {
+	var reconciliation generated.StatementReconciliation
+	var items []generated.StatementReconciliationItem
+
+	err := p.db.ExecTx(ctx, func(q generated.Querier) error {
+		var err error
+
+		reconciliation, err = q.GetStatementReconciliation(ctx, id)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return pkg.Errorf(pkg.NOT_FOUND_ERROR, "no statement reconciliation found")
+			}
+
+			return pkg.Errorf(
+				pkg.INTERNAL_ERROR,
+				"failed to get statement reconciliation: %s",
+				err.Error(),
+			)
+		}
+
+		items, err = q.ListStatementReconciliationItems(ctx, id)
+		if err != nil {
+			return pkg.Errorf(
+				pkg.INTERNAL_ERROR,
+				"failed to list statement reconciliation items: %s",
+				err.Error(),
+			)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return services.ReconciliationReport{}, err
+	}
+
+	report := services.ReconciliationReport{
+		ID:               reconciliation.ID,
+		StatementDate:    reconciliation.StatementDate,
+		FileName:         reconciliation.FileName,
+		TotalLines:       reconciliation.TotalLines,
+		Matched:          reconciliation.Matched,
+		MissingCallbacks: reconciliation.MissingCallbacks,
+		AmountMismatches: reconciliation.AmountMismatches,
+		NotOnStatement:   reconciliation.NotOnStatement,
+		UploadedBy:       reconciliation.UploadedBy,
+		CreatedAt:        reconciliation.CreatedAt,
+		Items:            make([]services.ReconciliationItem, len(items)),
+	}
+
+	for i, item := range items {
+		report.Items[i] = services.ReconciliationItem{
+			ID:                item.ID,
+			ItemType:          string(item.ItemType),
+			TransactionNumber: item.TransactionNumber,
+			StatementAmount:   item.StatementAmount,
+			RecordedAmount:    item.RecordedAmount,
+			AccountNumber:     item.AccountNumber,
+			PhoneNumber:       item.PhoneNumber,
+			PayingName:        item.PayingName,
+			PaidDate:          item.PaidDate,
+		}
+
+		if item.NonPostedID.Valid {
+			report.Items[i].NonPostedID = pkg.Uint32Ptr(uint32(item.NonPostedID.Int32))
+		}
+
+		if item.ImportedNonPostedID.Valid {
+			report.Items[i].ImportedNonPostedID = pkg.Uint32Ptr(
+				uint32(item.ImportedNonPostedID.Int32),
+			)
+		}
+
+		if item.ImportedAt.Valid {
+			report.Items[i].ImportedAt = pkg.TimePtr(item.ImportedAt.Time)
+		}
+	}
+
+	return report, nil
+}
+
+// ImportMissingCallbacks posts the missing callbacks of a reconciliation as if safaricom had sent them,
+// when itemIDs is empty every item not yet imported is posted.
+func (p *PaymentService) ImportMissingCallbacks(
+	ctx context.Context,
+	reconciliationID uint32,
+	itemIDs []uint32,
+	importedBy string,
+) ([]services.ReconciliationImportResult, error) {
+	report, err := p.GetReconciliation(ctx, reconciliationID)
+	if err != nil {
+		return nil, err
+	}
+
+	results := []services.ReconciliationImportResult{}
+
+	for _, item := range report.Items {
+		if item.ItemType != string(generated.StatementReconciliationItemsItemTypeMISSINGCALLBACK) ||
+			item.ImportedNonPostedID != nil {
+			continue
+		}
+
+		if len(itemIDs) > 0 && !slices.Contains(itemIDs, item.ID) {
+			continue
+		}
+
+		result := services.ReconciliationImportResult{
+			ItemID:            item.ID,
+			TransactionNumber: item.TransactionNumber,
+		}
+
+		nonPostedID, loanID, err := p.importStatementItem(ctx, item, importedBy)
+		if err != nil {
+			result.Error = err.Error()
+			results = append(results, result)
+
+			continue
+		}
+
+		result.LoanID = loanID
+
+		err = p.db.ExecTx(ctx, func(q generated.Querier) error {
+			if _, err := q.MarkStatementReconciliationItemImported(
+				ctx,
+				generated.MarkStatementReconciliationItemImportedParams{
+					ID: item.ID,
+					ImportedNonPostedID: sql.NullInt32{
+						Valid: true,
+						Int32: int32(nonPostedID),
+					},
+				},
+			); err != nil {
+				return pkg.Errorf(
+					pkg.INTERNAL_ERROR,
+					"failed to mark statement item imported: %s",
+					err.Error(),
+				)
+			}
+
+			return nil
+		})
+		if err != nil {
+			result.Error = err.Error()
+		}
+
+		results = append(results, result)
+	}
+
+	if len(results) == 0 {
+		return nil, pkg.Errorf(pkg.INVALID_ERROR, "no missing callbacks to import")
+	}
+
+	return results, nil
+}
+
+func (p *PaymentService) importStatementItem(
+	ctx context.Context,
+	item services.ReconciliationItem,
+	importedBy string,
+) (uint32, uint32, error) {
+	phoneNumber := item.PhoneNumber
+	if phoneNumber == "" {
+		phoneNumber = "***" // safaricom hidden number
+	}
+
+	callbackData := services.MpesaCallbackData{
+		TransactionSource: string(generated.NonPostedTransactionSourceMPESA),
+		TransactionID:     item.TransactionNumber,
+		AccountNumber:     item.AccountNumber,
+		PhoneNumber:       phoneNumber,
+		PayingName:        item.PayingName,
+		Amount:            item.StatementAmount,
+		AssignedBy:        importedBy,
+		PaidDate:          pkg.TimePtr(item.PaidDate),
+	}
+
+	clientID, err := p.mySQL.Clients.GetClientIDByPhoneNumber(ctx, item.AccountNumber)
+	if err != nil && pkg.ErrorCode(err) != pkg.NOT_FOUND_ERROR {
+		return 0, 0, err
+	}
+
+	if clientID != 0 {
+		callbackData.AssignedTo = pkg.Uint32Ptr(clientID)
+	}
+
+	loanID, err := p.ProcessCallback(ctx, &callbackData)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	processed, err := p.mySQL.NonPosted.GetProcessedCallback(
+		ctx,
+		item.TransactionNumber,
+		string(generated.NonPostedTransactionSourceMPESA),
+	)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return processed.NonPostedID, loanID, nil
+}
@@ -0,0 +1,629 @@
+package payments
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/mysql/generated"
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/repository"
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/services"
+	"github.com/EmilioCliff/kokomed-fin/backend/pkg"
+)
+
+const (
+	PayoutMethodManual = "MANUAL"
+	PayoutMethodMpesa  = "MPESA"
+)
+
+// manualPayoutProvider is used when the refund is paid out of the system, e.g. cash at the branch.
+type manualPayoutProvider struct{}
+
+func (manualPayoutProvider) Payout(
+	_ context.Context,
+	req services.PayoutRequest,
+) (services.PayoutResult, error) {
+	return services.PayoutResult{Completed: true, Reference: req.Reference}, nil
+}
+
+// mpesaPayoutProvider sends the refund over b2c, the result is confirmed by the b2c result callback.
+type mpesaPayoutProvider struct {
+	mpesa  *pkg.MpesaClient
+	config pkg.Config
+}
+
+func (m mpesaPayoutProvider) Payout(
+	_ context.Context,
+	req services.PayoutRequest,
+) (services.PayoutResult, error) {
+	amount := pkg.MoneyFromFloat(req.Amount)
+	if err := checkPayoutAmount(PayoutMethodMpesa, amount); err != nil {
+		return services.PayoutResult{}, err
+	}
+
+	rsp, err := m.mpesa.B2CPayment(pkg.B2CRequest{
+		PhoneNumber: req.PhoneNumber,
+		Amount:      int64(amount / 100),
+		Remarks:     req.Remarks,
+		Occasion:    req.Reference,
+		ResultURL:   m.config.MPESA_B2C_RESULT_URL,
+		TimeoutURL:  m.config.MPESA_B2C_TIMEOUT_URL,
+	})
+	if err != nil {
+		return services.PayoutResult{}, err
+	}
+
+	return services.PayoutResult{Completed: false, Reference: rsp.ConversationID}, nil
+}
+
+// checkPayoutAmount rejects an amount the payout method cannot send in full. Daraja only sends
+// whole shillings, the refund is booked for exactly what is sent.
+func checkPayoutAmount(method string, amount pkg.Money) error {
+	if method == PayoutMethodMpesa && !amount.Whole() {
+		return pkg.Errorf(
+			pkg.INVALID_ERROR,
+			"mpesa refunds must be in whole shillings, %s has cents",
+			amount,
+		)
+	}
+
+	return nil
+}
+
+// RegisterPayoutProvider adds or replaces the provider used for a refund payout method.
+func (p *PaymentService) RegisterPayoutProvider(method string, provider services.PayoutProvider) {
+	p.payouts[strings.ToUpper(method)] = provider
+}
+
+func (p *PaymentService) RequestOverpaymentRefund(
+	ctx context.Context,
+	refundData *services.OverpaymentRefundData,
+) (services.OverpaymentRefund, error) {
+	if refundData.Amount <= 0 {
+		return services.OverpaymentRefund{}, pkg.Errorf(
+			pkg.INVALID_ERROR,
+			"refund amount must be greater than 0",
+		)
+	}
+
+	if strings.TrimSpace(refundData.Reason) == "" {
+		return services.OverpaymentRefund{}, pkg.Errorf(pkg.INVALID_ERROR, "reason is required")
+	}
+
+	method := strings.ToUpper(strings.TrimSpace(refundData.PayoutMethod))
+	if method == "" {
+		method = PayoutMethodManual
+	}
+
+	if _, ok := p.payouts[method]; !ok {
+		return services.OverpaymentRefund{}, pkg.Errorf(
+			pkg.INVALID_ERROR,
+			"unsupported payout method: %s",
+			method,
+		)
+	}
+
+	if err := checkPayoutAmount(method, refundData.Amount); err != nil {
+		return services.OverpaymentRefund{}, err
+	}
+
+	client, err := p.mySQL.Clients.GetClientFullData(ctx, refundData.ClientID)
+	if err != nil {
+		return services.OverpaymentRefund{}, err
+	}
+
+	// the balance is only deducted on approval, this just stops obviously invalid requests
+	if client.Overpayment < refundData.Amount {
+		return services.OverpaymentRefund{}, pkg.Errorf(
+			pkg.INVALID_ERROR,
+			"client overpayment is less than the amount",
+		)
+	}
+
+	phoneNumber := strings.TrimSpace(refundData.PhoneNumber)
+	if phoneNumber == "" && method == PayoutMethodMpesa {
+		phoneNumber = client.PhoneNumber
+	}
+
+	var refund generated.OverpaymentRefund
+
+	err = p.db.ExecTx(ctx, func(q generated.Querier) error {
+		result, err := q.CreateOverpaymentRefund(ctx, generated.CreateOverpaymentRefundParams{
+			ClientID:     refundData.ClientID,
+			Amount:       refundData.Amount.Float64(),
+			Reason:       strings.TrimSpace(refundData.Reason),
+			PayoutMethod: method,
+			PayoutPhone:  phoneNumber,
+			RequestedBy:  refundData.RequestedBy,
+		})
+		if err != nil {
+			return pkg.Errorf(
+				pkg.INTERNAL_ERROR,
+				"failed to create overpayment refund: %s",
+				err.Error(),
+			)
+		}
+
+		id, err := result.LastInsertId()
+		if err != nil {
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to get last insert id: %s", err.Error())
+		}
+
+		refund, err = getOverpaymentRefund(ctx, q, uint32(id))
+
+		return err
+	})
+	if err != nil {
+		return services.OverpaymentRefund{}, err
+	}
+
+	return convertOverpaymentRefund(refund), nil
+}
+
+func (p *PaymentService) ApproveOverpaymentRefund(
+	ctx context.Context,
+	id uint32,
+	reviewData *services.ReviewOverpaymentRefundData,
+) (services.OverpaymentRefund, error) {
+	var refund generated.OverpaymentRefund
+
+	// the refund is locked and the balance taken before paying out so a second approval waits,
+	// finds it approved and cannot refund it twice
+	err := p.db.ExecTx(ctx, func(q generated.Querier) error {
+		var err error
+
+		refund, err = lockOverpaymentRefund(ctx, q, id)
+		if err != nil {
+			return err
+		}
+
+		if refund.Status != generated.OverpaymentRefundsStatusPENDING {
+			return pkg.Errorf(pkg.INVALID_ERROR, "only pending refunds can be approved")
+		}
+
+		if _, ok := p.payouts[refund.PayoutMethod]; !ok {
+			return pkg.Errorf(
+				pkg.INVALID_ERROR,
+				"unsupported payout method: %s",
+				refund.PayoutMethod,
+			)
+		}
+
+		if err := checkPayoutAmount(
+			refund.PayoutMethod,
+			pkg.MoneyFromFloat(refund.Amount),
+		); err != nil {
+			return err
+		}
+
+		if err := deductOverpayment(ctx, q, repository.Overpayment{
+			ClientID:    refund.ClientID,
+			Amount:      pkg.MoneyFromFloat(refund.Amount),
+			CreatedBy:   reviewData.ReviewerEmail,
+			Description: fmt.Sprintf("REFUND %s: %s", refundVoucherNumber(refund.ID), refund.Reason),
+		}); err != nil {
+			return err
+		}
+
+		if err := postOverpaymentRefund(ctx, q, refund, reviewData.ReviewerEmail, false); err != nil {
+			return err
+		}
+
+		return reviewOverpaymentRefund(
+			ctx,
+			q,
+			refund.ID,
+			generated.OverpaymentRefundsStatusAPPROVED,
+			reviewData,
+		)
+	})
+	if err != nil {
+		return services.OverpaymentRefund{}, err
+	}
+
+	payout, payoutErr := p.payouts[refund.PayoutMethod].Payout(ctx, services.PayoutRequest{
+		Reference:   refundVoucherNumber(refund.ID),
+		PhoneNumber: refund.PayoutPhone,
+		Amount:      refund.Amount,
+		Remarks:     fmt.Sprintf("Overpayment refund %s", refundVoucherNumber(refund.ID)),
+	})
+
+	err = p.db.ExecTx(ctx, func(q generated.Querier) error {
+		var err error
+
+		if payoutErr != nil {
+			if err = failOverpaymentRefund(ctx, q, refund, ""); err != nil {
+				return err
+			}
+
+			refund, err = getOverpaymentRefund(ctx, q, refund.ID)
+
+			return err
+		}
+
+		params := generated.UpdateOverpaymentRefundPayoutParams{
+			ID:              refund.ID,
+			Status:          generated.OverpaymentRefundsStatusPAID,
+			PayoutReference: payout.Reference,
+		}
+
+		if !payout.Completed {
+			params.Status = generated.OverpaymentRefundsStatusPROCESSING
+			params.ConversationID = sql.NullString{Valid: true, String: payout.Reference}
+		}
+
+		if _, err = q.UpdateOverpaymentRefundPayout(ctx, params); err != nil {
+			return pkg.Errorf(
+				pkg.INTERNAL_ERROR,
+				"failed to update overpayment refund payout: %s",
+				err.Error(),
+			)
+		}
+
+		refund, err = getOverpaymentRefund(ctx, q, refund.ID)
+
+		return err
+	})
+	if err != nil {
+		return services.OverpaymentRefund{}, err
+	}
+
+	if payoutErr != nil {
+		return services.OverpaymentRefund{}, payoutErr
+	}
+
+	return convertOverpaymentRefund(refund), nil
+}
+
+func (p *PaymentService) RejectOverpaymentRefund(
+	ctx context.Context,
+	id uint32,
+	reviewData *services.ReviewOverpaymentRefundData,
+) (services.OverpaymentRefund, error) {
+	if strings.TrimSpace(reviewData.Note) == "" {
+		return services.OverpaymentRefund{}, pkg.Errorf(
+			pkg.INVALID_ERROR,
+			"a note is required to reject a refund",
+		)
+	}
+
+	var refund generated.OverpaymentRefund
+
+	err := p.db.ExecTx(ctx, func(q generated.Querier) error {
+		var err error
+
+		refund, err = lockOverpaymentRefund(ctx, q, id)
+		if err != nil {
+			return err
+		}
+
+		if refund.Status != generated.OverpaymentRefundsStatusPENDING {
+			return pkg.Errorf(pkg.INVALID_ERROR, "only pending refunds can be rejected")
+		}
+
+		if err := reviewOverpaymentRefund(
+			ctx,
+			q,
+			refund.ID,
+			generated.OverpaymentRefundsStatusREJECTED,
+			reviewData,
+		); err != nil {
+			return err
+		}
+
+		refund, err = getOverpaymentRefund(ctx, q, refund.ID)
+
+		return err
+	})
+	if err != nil {
+		return services.OverpaymentRefund{}, err
+	}
+
+	return convertOverpaymentRefund(refund), nil
+}
+
+func (p *PaymentService) GetOverpaymentRefund(
+	ctx context.Context,
+	id uint32,
+) (services.OverpaymentRefund, error) {
+	var refund generated.OverpaymentRefund
+
+	err := p.db.ExecTx(ctx, func(q generated.Querier) error {
+		var err error
+
+		refund, err = getOverpaymentRefund(ctx, q, id)
+
+		return err
+	})
+	if err != nil {
+		return services.OverpaymentRefund{}, err
+	}
+
+	return convertOverpaymentRefund(refund), nil
+}
+
+func (p *PaymentService) ListOverpaymentRefunds(
+	ctx context.Context,
+	status *string,
+	pgData *pkg.PaginationMetadata,
+) ([]services.OverpaymentRefund, pkg.PaginationMetadata, error) {
+	var refunds []generated.OverpaymentRefund
+	var total int64
+
+	limit := int32(pgData.PageSize)
+	offset := pkg.CalculateOffset(pgData.CurrentPage, pgData.PageSize)
+
+	err := p.db.ExecTx(ctx, func(q generated.Querier) error {
+		var err error
+
+		if status != nil {
+			refundStatus := generated.OverpaymentRefundsStatus(strings.ToUpper(*status))
+			switch refundStatus {
+			case generated.OverpaymentRefundsStatusPENDING,
+				generated.OverpaymentRefundsStatusAPPROVED,
+				generated.OverpaymentRefundsStatusPROCESSING,
+				generated.OverpaymentRefundsStatusPAID,
+				generated.OverpaymentRefundsStatusREJECTED,
+				generated.OverpaymentRefundsStatusFAILED:
+			default:
+				return pkg.Errorf(pkg.INVALID_ERROR, "invalid refund status: %s", *status)
+			}
+
+			refunds, err = q.ListOverpaymentRefundsByStatus(
+				ctx,
+				generated.ListOverpaymentRefundsByStatusParams{
+					Status: refundStatus,
+					Limit:  limit,
+					Offset: offset,
+				},
+			)
+			if err != nil {
+				return pkg.Errorf(
+					pkg.INTERNAL_ERROR,
+					"failed to list overpayment refunds: %s",
+					err.Error(),
+				)
+			}
+
+			total, err = q.CountOverpaymentRefundsByStatus(ctx, refundStatus)
+		} else {
+			refunds, err = q.ListOverpaymentRefunds(ctx, generated.ListOverpaymentRefundsParams{
+				Limit:  limit,
+				Offset: offset,
+			})
+			if err != nil {
+				return pkg.Errorf(
+					pkg.INTERNAL_ERROR,
+					"failed to list overpayment refunds: %s",
+					err.Error(),
+				)
+			}
+
+			total, err = q.CountOverpaymentRefunds(ctx)
+		}
+
+		if err != nil {
+			return pkg.Errorf(
+				pkg.INTERNAL_ERROR,
+				"failed to count overpayment refunds: %s",
+				err.Error(),
+			)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, pkg.PaginationMetadata{}, err
+	}
+
+	rsp := make([]services.OverpaymentRefund, len(refunds))
+	for i, refund := range refunds {
+		rsp[i] = convertOverpaymentRefund(refund)
+	}
+
+	return rsp, pkg.CreatePaginationMetadata(uint32(total), pgData.PageSize, pgData.CurrentPage), nil
+}
+
+// processRefundPayoutResult settles a refund paid out over b2c. It reports false when the
+// conversation does not belong to a refund.
+func (p *PaymentService) processRefundPayoutResult(
+	ctx context.Context,
+	resultData *services.B2CResultData,
+) (bool, error) {
+	found := false
+
+	err := p.db.ExecTx(ctx, func(q generated.Querier) error {
+		// locked so a redelivered result waits and then sees the refund settled
+		refund, err := q.LockOverpaymentRefundByConversationID(ctx, sql.NullString{
+			Valid:  true,
+			String: resultData.ConversationID,
+		})
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return nil
+			}
+
+			return pkg.Errorf(
+				pkg.INTERNAL_ERROR,
+				"failed to get overpayment refund: %s",
+				err.Error(),
+			)
+		}
+
+		found = true
+
+		// daraja can deliver the same result more than once
+		if refund.Status != generated.OverpaymentRefundsStatusPROCESSING {
+			return nil
+		}
+
+		if resultData.ResultCode != 0 {
+			return failOverpaymentRefund(ctx, q, refund, resultData.ConversationID)
+		}
+
+		if _, err := q.UpdateOverpaymentRefundPayout(ctx, generated.UpdateOverpaymentRefundPayoutParams{
+			ID:              refund.ID,
+			Status:          generated.OverpaymentRefundsStatusPAID,
+			PayoutReference: resultData.TransactionID,
+			ConversationID:  refund.ConversationID,
+		}); err != nil {
+			return pkg.Errorf(
+				pkg.INTERNAL_ERROR,
+				"failed to update overpayment refund payout: %s",
+				err.Error(),
+			)
+		}
+
+		return nil
+	})
+
+	return found, err
+}
+
+// failOverpaymentRefund marks an approved refund as failed and gives the client back the balance.
+func failOverpaymentRefund(
+	ctx context.Context,
+	q generated.Querier,
+	refund generated.OverpaymentRefund,
+	conversationID string,
+) error {
+	if err := updateOverpayment(ctx, q, repository.Overpayment{
+		ClientID:    refund.ClientID,
+		Amount:      pkg.MoneyFromFloat(refund.Amount),
+		Description: fmt.Sprintf("REFUND %s FAILED", refundVoucherNumber(refund.ID)),
+	}); err != nil {
+		return err
+	}
+
+	if err := postOverpaymentRefund(ctx, q, refund, "SYSTEM", true); err != nil {
+		return err
+	}
+
+	params := generated.UpdateOverpaymentRefundPayoutParams{
+		ID:              refund.ID,
+		Status:          generated.OverpaymentRefundsStatusFAILED,
+		PayoutReference: refund.PayoutReference,
+	}
+
+	if conversationID != "" {
+		params.ConversationID = sql.NullString{Valid: true, String: conversationID}
+	}
+
+	if _, err := q.UpdateOverpaymentRefundPayout(ctx, params); err != nil {
+		return pkg.Errorf(
+			pkg.INTERNAL_ERROR,
+			"failed to update overpayment refund payout: %s",
+			err.Error(),
+		)
+	}
+
+	return nil
+}
+
+func reviewOverpaymentRefund(
+	ctx context.Context,
+	q generated.Querier,
+	id uint32,
+	status generated.OverpaymentRefundsStatus,
+	reviewData *services.ReviewOverpaymentRefundData,
+) error {
+	_, err := q.ReviewOverpaymentRefund(ctx, generated.ReviewOverpaymentRefundParams{
+		ID:     id,
+		Status: status,
+		ReviewedBy: sql.NullInt32{
+			Valid: true,
+			Int32: int32(reviewData.ReviewedBy),
+		},
+		ReviewNote: strings.TrimSpace(reviewData.Note),
+	})
+	if err != nil {
+		return pkg.Errorf(
+			pkg.INTERNAL_ERROR,
+			"failed to review overpayment refund: %s",
+			err.Error(),
+		)
+	}
+
+	return nil
+}
+
+func getOverpaymentRefund(
+	ctx context.Context,
+	q generated.Querier,
+	id uint32,
+) (generated.OverpaymentRefund, error) {
+	refund, err := q.GetOverpaymentRefund(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return generated.OverpaymentRefund{}, pkg.Errorf(
+				pkg.NOT_FOUND_ERROR,
+				"no overpayment refund found",
+			)
+		}
+
+		return generated.OverpaymentRefund{}, pkg.Errorf(
+			pkg.INTERNAL_ERROR,
+			"failed to get overpayment refund: %s",
+			err.Error(),
+		)
+	}
+
+	return refund, nil
+}
+
+// lockOverpaymentRefund reads the refund for update so its status can be checked and changed
+// without another review changing it in between.
+func lockOverpaymentRefund(
+	ctx context.Context,
+	q generated.Querier,
+	id uint32,
+) (generated.OverpaymentRefund, error) {
+	refund, err := q.LockOverpaymentRefund(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return generated.OverpaymentRefund{}, pkg.Errorf(
+				pkg.NOT_FOUND_ERROR,
+				"no overpayment refund found",
+			)
+		}
+
+		return generated.OverpaymentRefund{}, pkg.Errorf(
+			pkg.INTERNAL_ERROR,
+			"failed to lock overpayment refund: %s",
+			err.Error(),
+		)
+	}
+
+	return refund, nil
+}
+
+func refundVoucherNumber(id uint32) string {
+	return fmt.Sprintf("RF-%06d", id)
+}
+
+func convertOverpaymentRefund(refund generated.OverpaymentRefund) services.OverpaymentRefund {
+	rsp := services.OverpaymentRefund{
+		ID:              refund.ID,
+		ClientID:        refund.ClientID,
+		Amount:          pkg.MoneyFromFloat(refund.Amount),
+		Reason:          refund.Reason,
+		Status:          string(refund.Status),
+		PayoutMethod:    refund.PayoutMethod,
+		PayoutPhone:     refund.PayoutPhone,
+		PayoutReference: refund.PayoutReference,
+		RequestedBy:     refund.RequestedBy,
+		ReviewNote:      refund.ReviewNote,
+		CreatedAt:       refund.CreatedAt,
+	}
+
+	if refund.ReviewedBy.Valid {
+		rsp.ReviewedBy = pkg.Uint32Ptr(uint32(refund.ReviewedBy.Int32))
+	}
+
+	if refund.ReviewedAt.Valid {
+		rsp.ReviewedAt = pkg.TimePtr(refund.ReviewedAt.Time)
+	}
+
+	return rsp
+}
@@ -0,0 +1,507 @@
+package payments
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/mysql/generated"
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/services"
+	"github.com/EmilioCliff/kokomed-fin/backend/pkg"
+)
+
+const (
+	defaultRulePrefix            = "LN"
+	defaultRuleDaysWindow        = 3
+	defaultRuleMinNameSimilarity = 0.8
+
+	defaultDryRunLimit = 500
+)
+
+// rulePayment is the part of a payment the assignment rules look at.
+type rulePayment struct {
+	AccountNumber string
+	PayingName    string
+	Amount        pkg.Money
+	PaidDate      time.Time
+}
+
+func (p *PaymentService) CreateAssignmentRule(
+	ctx context.Context,
+	ruleData *services.AssignmentRuleData,
+) (services.AssignmentRule, error) {
+	params := generated.CreateAssignmentRuleParams{
+		Name:              strings.TrimSpace(ruleData.Name),
+		RuleType:          generated.AssignmentRulesRuleType(ruleData.RuleType),
+		Priority:          ruleData.Priority,
+		Active:            ruleData.Active,
+		Prefix:            strings.ToUpper(strings.TrimSpace(ruleData.Prefix)),
+		DaysWindow:        ruleData.DaysWindow,
+		MinNameSimilarity: ruleData.MinNameSimilarity,
+		CreatedBy:         ruleData.CreatedBy,
+	}
+
+	if params.Prefix == "" {
+		params.Prefix = defaultRulePrefix
+	}
+
+	if params.DaysWindow == 0 {
+		params.DaysWindow = defaultRuleDaysWindow
+	}
+
+	if params.MinNameSimilarity == 0 {
+		params.MinNameSimilarity = defaultRuleMinNameSimilarity
+	}
+
+	if err := validateAssignmentRule(
+		params.Name,
+		params.RuleType,
+		params.MinNameSimilarity,
+	); err != nil {
+		return services.AssignmentRule{}, err
+	}
+
+	var rule generated.AssignmentRule
+
+	err := p.db.ExecTx(ctx, func(q generated.Querier) error {
+		execResult, err := q.CreateAssignmentRule(ctx, params)
+		if err != nil {
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to create assignment rule: %s", err.Error())
+		}
+
+		id, err := execResult.LastInsertId()
+		if err != nil {
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to get last insert id: %s", err.Error())
+		}
+
+		rule, err = q.GetAssignmentRule(ctx, uint32(id))
+		if err != nil {
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to get assignment rule: %s", err.Error())
+		}
+
+		return nil
+	})
+	if err != nil {
+		return services.AssignmentRule{}, err
+	}
+
+	return convertAssignmentRule(rule), nil
+}
+
+func (p *PaymentService) UpdateAssignmentRule(
+	ctx context.Context,
+	id uint32,
+	ruleData *services.UpdateAssignmentRuleData,
+) (services.AssignmentRule, error) {
+	var rule generated.AssignmentRule
+
+	err := p.db.ExecTx(ctx, func(q generated.Querier) error {
+		var err error
+
+		rule, err = q.GetAssignmentRule(ctx, id)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return pkg.Errorf(pkg.NOT_FOUND_ERROR, "no assignment rule found")
+			}
+
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to get assignment rule: %s", err.Error())
+		}
+
+		params := generated.UpdateAssignmentRuleParams{
+			ID:                rule.ID,
+			Name:              rule.Name,
+			Priority:          rule.Priority,
+			Active:            rule.Active,
+			Prefix:            rule.Prefix,
+			DaysWindow:        rule.DaysWindow,
+			MinNameSimilarity: rule.MinNameSimilarity,
+		}
+
+		if ruleData.Name != nil {
+			params.Name = strings.TrimSpace(*ruleData.Name)
+		}
+
+		if ruleData.Priority != nil {
+			params.Priority = *ruleData.Priority
+		}
+
+		if ruleData.Active != nil {
+			params.Active = *ruleData.Active
+		}
+
+		if ruleData.Prefix != nil && strings.TrimSpace(*ruleData.Prefix) != "" {
+			params.Prefix = strings.ToUpper(strings.TrimSpace(*ruleData.Prefix))
+		}
+
+		if ruleData.DaysWindow != nil && *ruleData.DaysWindow > 0 {
+			params.DaysWindow = *ruleData.DaysWindow
+		}
+
+		if ruleData.MinNameSimilarity != nil {
+			params.MinNameSimilarity = *ruleData.MinNameSimilarity
+		}
+
+		if err := validateAssignmentRule(
+			params.Name,
+			rule.RuleType,
+			params.MinNameSimilarity,
+		); err != nil {
+			return err
+		}
+
+		if _, err := q.UpdateAssignmentRule(ctx, params); err != nil {
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to update assignment rule: %s", err.Error())
+		}
+
+		rule, err = q.GetAssignmentRule(ctx, id)
+		if err != nil {
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to get assignment rule: %s", err.Error())
+		}
+
+		return nil
+	})
+	if err != nil {
+		return services.AssignmentRule{}, err
+	}
+
+	return convertAssignmentRule(rule), nil
+}
+
+func (p *PaymentService) ListAssignmentRules(
+	ctx context.Context,
+) ([]services.AssignmentRule, error) {
+	var rules []generated.AssignmentRule
+
+	err := p.db.ExecTx(ctx, func(q generated.Querier) error {
+		var err error
+
+		rules, err = q.ListAssignmentRules(ctx)
+		if err != nil {
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to list assignment rules: %s", err.Error())
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rslt := make([]services.AssignmentRule, len(rules))
+	for i, rule := range rules {
+		rslt[i] = convertAssignmentRule(rule)
+	}
+
+	return rslt, nil
+}
+
+func (p *PaymentService) DeleteAssignmentRule(ctx context.Context, id uint32) error {
+	return p.db.ExecTx(ctx, func(q generated.Querier) error {
+		if err := q.DeleteAssignmentRule(ctx, id); err != nil {
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to delete assignment rule: %s", err.Error())
+		}
+
+		return nil
+	})
+}
+
+// DryRunAssignmentRules evaluates rules against payments that are still unassigned without
+// assigning them. Without rule ids every active rule is evaluated. A payment matched by a
+// higher priority rule is reported under the lower priority rules as claimed by that rule.
+func (p *PaymentService) DryRunAssignmentRules(
+	ctx context.Context,
+	ruleIDs []uint32,
+	limit int,
+) ([]services.AssignmentRuleDryRun, error) {
+	if limit <= 0 {
+		limit = defaultDryRunLimit
+	}
+
+	var rslt []services.AssignmentRuleDryRun
+
+	err := p.db.ExecTx(ctx, func(q generated.Querier) error {
+		rules, err := q.ListAssignmentRules(ctx)
+		if err != nil {
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to list assignment rules: %s", err.Error())
+		}
+
+		selected := []generated.AssignmentRule{}
+
+		for _, rule := range rules {
+			if len(ruleIDs) == 0 && rule.Active || slices.Contains(ruleIDs, rule.ID) {
+				selected = append(selected, rule)
+			}
+		}
+
+		payments, err := q.ListUnassignedNonPostedForRules(ctx, int32(limit))
+		if err != nil {
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to list unassigned non posted: %s", err.Error())
+		}
+
+		rslt = make([]services.AssignmentRuleDryRun, len(selected))
+		for i, rule := range selected {
+			rslt[i] = services.AssignmentRuleDryRun{
+				RuleID:   rule.ID,
+				Name:     rule.Name,
+				RuleType: string(rule.RuleType),
+				Priority: rule.Priority,
+				Matches:  []services.AssignmentRuleMatch{},
+			}
+		}
+
+		clientNames := map[uint32]string{}
+
+		for _, payment := range payments {
+			var claimedBy *uint32
+
+			for i, rule := range selected {
+				clientID, matched, err := matchAssignmentRule(ctx, q, rule, rulePayment{
+					AccountNumber: payment.AccountNumber,
+					PayingName:    payment.PayingName,
+					Amount:        pkg.MoneyFromFloat(payment.Amount),
+					PaidDate:      payment.PaidDate,
+				})
+				if err != nil {
+					return err
+				}
+
+				if !matched {
+					continue
+				}
+
+				fullName, ok := clientNames[clientID]
+				if !ok {
+					client, err := q.GetClient(ctx, clientID)
+					if err != nil {
+						return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to get client: %s", err.Error())
+					}
+
+					fullName = client.FullName
+					clientNames[clientID] = fullName
+				}
+
+				rslt[i].Matches = append(rslt[i].Matches, services.AssignmentRuleMatch{
+					NonPostedID:       payment.ID,
+					TransactionNumber: payment.TransactionNumber,
+					AccountNumber:     payment.AccountNumber,
+					PayingName:        payment.PayingName,
+					Amount:            payment.Amount,
+					PaidDate:          payment.PaidDate,
+					ClientID:          clientID,
+					FullName:          fullName,
+					ClaimedByRuleID:   claimedBy,
+				})
+
+				if claimedBy == nil {
+					claimedBy = pkg.Uint32Ptr(rule.ID)
+				}
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rslt, nil
+}
+
+// applyAssignmentRules returns the client the first matching active rule assigns the payment to.
+func (p *PaymentService) applyAssignmentRules(
+	ctx context.Context,
+	payment rulePayment,
+) (uint32, *generated.AssignmentRule, error) {
+	var clientID uint32
+	var matchedRule *generated.AssignmentRule
+
+	err := p.db.ExecTx(ctx, func(q generated.Querier) error {
+		rules, err := q.ListActiveAssignmentRules(ctx)
+		if err != nil {
+			return pkg.Errorf(
+				pkg.INTERNAL_ERROR,
+				"failed to list active assignment rules: %s",
+				err.Error(),
+			)
+		}
+
+		for _, rule := range rules {
+			id, matched, err := matchAssignmentRule(ctx, q, rule, payment)
+			if err != nil {
+				return err
+			}
+
+			if matched {
+				clientID = id
+				matchedRule = &rule
+
+				return nil
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return clientID, matchedRule, nil
+}
+
+func matchAssignmentRule(
+	ctx context.Context,
+	q generated.Querier,
+	rule generated.AssignmentRule,
+	payment rulePayment,
+) (uint32, bool, error) {
+	switch rule.RuleType {
+	case generated.AssignmentRulesRuleTypeIDNUMBER:
+		client, found, err := matchClientByIDNumber(ctx, q, payment.AccountNumber)
+
+		return client.ID, found, err
+
+	case generated.AssignmentRulesRuleTypeLOANPREFIX:
+		return matchLoanPrefix(ctx, q, rule.Prefix, payment.AccountNumber)
+
+	case generated.AssignmentRulesRuleTypeNAMEAMOUNT:
+		return matchNameAndAmount(ctx, q, rule, payment)
+	}
+
+	return 0, false, nil
+}
+
+// matchLoanPrefix matches account numbers such as "LN123" or "ln 123" to the client of loan 123.
+func matchLoanPrefix(
+	ctx context.Context,
+	q generated.Querier,
+	prefix string,
+	accountNumber string,
+) (uint32, bool, error) {
+	account := strings.ToUpper(strings.Join(strings.Fields(accountNumber), ""))
+	prefix = strings.ToUpper(strings.TrimSpace(prefix))
+
+	if prefix == "" || !strings.HasPrefix(account, prefix) {
+		return 0, false, nil
+	}
+
+	loanID, err := strconv.ParseUint(strings.TrimLeft(account[len(prefix):], "-#"), 10, 32)
+	if err != nil {
+		return 0, false, nil
+	}
+
+	clientID, err := q.GetLoanClientID(ctx, uint32(loanID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, false, nil
+		}
+
+		return 0, false, pkg.Errorf(pkg.INTERNAL_ERROR, "failed to get loan client id: %s", err.Error())
+	}
+
+	return clientID, true, nil
+}
+
+// matchNameAndAmount matches a payment whose amount equals an installment due within the rule's
+// window around the paid date and whose paying name is similar to that installment's client.
+// The payment is left unassigned when more than one client fits.
+func matchNameAndAmount(
+	ctx context.Context,
+	q generated.Querier,
+	rule generated.AssignmentRule,
+	payment rulePayment,
+) (uint32, bool, error) {
+	if strings.TrimSpace(payment.PayingName) == "" {
+		return 0, false, nil
+	}
+
+	days := int(rule.DaysWindow)
+
+	installments, err := dueInstallmentsByAmount(
+		ctx,
+		q,
+		payment.Amount,
+		payment.PaidDate.AddDate(0, 0, -days),
+		payment.PaidDate.AddDate(0, 0, days),
+	)
+	if err != nil {
+		return 0, false, err
+	}
+
+	matches := map[uint32]bool{}
+
+	for _, installment := range installments {
+		if _, ok := matches[installment.ClientID]; ok {
+			continue
+		}
+
+		client, err := q.GetClient(ctx, installment.ClientID)
+		if err != nil {
+			return 0, false, pkg.Errorf(pkg.INTERNAL_ERROR, "failed to get client: %s", err.Error())
+		}
+
+		matches[client.ID] = pkg.NameSimilarity(payment.PayingName, client.FullName) >= rule.MinNameSimilarity
+	}
+
+	var clientID uint32
+	count := 0
+
+	for id, matched := range matches {
+		if matched {
+			clientID = id
+			count++
+		}
+	}
+
+	if count != 1 {
+		return 0, false, nil
+	}
+
+	return clientID, true, nil
+}
+
+func validateAssignmentRule(
+	name string,
+	ruleType generated.AssignmentRulesRuleType,
+	minNameSimilarity float64,
+) error {
+	if name == "" {
+		return pkg.Errorf(pkg.INVALID_ERROR, "rule name is required")
+	}
+
+	switch ruleType {
+	case generated.AssignmentRulesRuleTypeIDNUMBER,
+		generated.AssignmentRulesRuleTypeLOANPREFIX,
+		generated.AssignmentRulesRuleTypeNAMEAMOUNT:
+	default:
+		return pkg.Errorf(pkg.INVALID_ERROR, "unknown rule type: %s", ruleType)
+	}
+
+	if minNameSimilarity <= 0 || minNameSimilarity > 1 {
+		return pkg.Errorf(pkg.INVALID_ERROR, "min name similarity must be between 0 and 1")
+	}
+
+	return nil
+}
+
+func convertAssignmentRule(rule generated.AssignmentRule) services.AssignmentRule {
+	return services.AssignmentRule{
+		ID:                rule.ID,
+		Name:              rule.Name,
+		RuleType:          string(rule.RuleType),
+		Priority:          rule.Priority,
+		Active:            rule.Active,
+		Prefix:            rule.Prefix,
+		DaysWindow:        rule.DaysWindow,
+		MinNameSimilarity: rule.MinNameSimilarity,
+		CreatedBy:         rule.CreatedBy,
+		CreatedAt:         rule.CreatedAt,
+		UpdatedAt:         rule.UpdatedAt,
+	}
+}
+
+func ruleAssignedBy(rule *generated.AssignmentRule) string {
+	return fmt.Sprintf("RULE: %s", rule.Name)
+}
@@ -0,0 +1,432 @@
+package payments
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/mysql"
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/mysql/generated"
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/repository"
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/services"
+	"github.com/EmilioCliff/kokomed-fin/backend/pkg"
+)
+
+// settlementQuoteExpired is reported for a pending quote past its expiry, it is never stored.
+const settlementQuoteExpired = "EXPIRED"
+
+const defaultSettlementQuoteValidity = 24 * time.Hour
+
+// loanPayoff is what is left on a loan today and how much of it is interest not yet earned.
+type loanPayoff struct {
+	installments     []generated.Installment
+	penalties        []generated.InstallmentPenalty
+	outstanding      pkg.Money
+	penaltiesOwed    pkg.Money
+	unearnedInterest pkg.Money
+}
+
+func (p *PaymentService) CreateSettlementQuote(
+	ctx context.Context,
+	quoteData *services.SettlementQuoteData,
+) (services.SettlementQuote, error) {
+	var quote generated.LoanSettlementQuote
+
+	err := p.db.ExecTx(ctx, func(q generated.Querier) error {
+		if _, err := getSettlableLoan(ctx, q, quoteData.LoanID); err != nil {
+			return err
+		}
+
+		payoff, err := getLoanPayoff(ctx, q, quoteData.LoanID)
+		if err != nil {
+			return err
+		}
+
+		if payoff.outstanding+payoff.penaltiesOwed <= 0 {
+			return pkg.Errorf(pkg.INVALID_ERROR, "loan has nothing left to settle")
+		}
+
+		percent := p.settlementRebatePercent()
+		rebate := pkg.Money(math.Round(float64(payoff.unearnedInterest) * percent / 100))
+
+		validity := p.config.LOAN_SETTLEMENT_QUOTE_VALIDITY
+		if validity <= 0 {
+			validity = defaultSettlementQuoteValidity
+		}
+
+		result, err := q.CreateLoanSettlementQuote(ctx, generated.CreateLoanSettlementQuoteParams{
+			LoanID:            quoteData.LoanID,
+			OutstandingAmount: payoff.outstanding.Float64(),
+			PenaltiesAmount:   payoff.penaltiesOwed.Float64(),
+			UnearnedInterest:  payoff.unearnedInterest.Float64(),
+			RebatePercent:     percent,
+			RebateAmount:      rebate.Float64(),
+			QuotedAmount:      (payoff.outstanding + payoff.penaltiesOwed - rebate).Float64(),
+			ExpiresAt:         time.Now().Add(validity),
+			QuotedBy:          quoteData.QuotedBy,
+		})
+		if err != nil {
+			return pkg.Errorf(
+				pkg.INTERNAL_ERROR,
+				"failed to create settlement quote: %s",
+				err.Error(),
+			)
+		}
+
+		id, err := result.LastInsertId()
+		if err != nil {
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to get last insert id: %s", err.Error())
+		}
+
+		quote, err = getLoanSettlementQuote(ctx, q, uint32(id))
+
+		return err
+	})
+	if err != nil {
+		return services.SettlementQuote{}, err
+	}
+
+	return convertSettlementQuote(quote), nil
+}
+
+func (p *PaymentService) GetSettlementQuote(
+	ctx context.Context,
+	id uint32,
+) (services.SettlementQuote, error) {
+	quote, err := getLoanSettlementQuote(ctx, p.queries, id)
+	if err != nil {
+		return services.SettlementQuote{}, err
+	}
+
+	return convertSettlementQuote(quote), nil
+}
+
+func (p *PaymentService) ListSettlementQuotes(
+	ctx context.Context,
+	loanID uint32,
+) ([]services.SettlementQuote, error) {
+	quotes, err := p.queries.ListLoanSettlementQuotes(ctx, loanID)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, pkg.Errorf(
+			pkg.INTERNAL_ERROR,
+			"failed to list settlement quotes: %s",
+			err.Error(),
+		)
+	}
+
+	rslt := make([]services.SettlementQuote, len(quotes))
+	for i, quote := range quotes {
+		rslt[i] = convertSettlementQuote(quote)
+	}
+
+	return rslt, nil
+}
+
+// SettleLoan takes the quoted amount as a payment and clears the loan with it. The payment
+// pays the penalties and then the installments in order, what the rebate leaves unpaid on the
+// last installments is written off against the interest and those installments are closed.
+func (p *PaymentService) SettleLoan(
+	ctx context.Context,
+	quoteID uint32,
+	settleData *services.SettleLoanData,
+) (services.SettlementQuote, error) {
+	var quote generated.LoanSettlementQuote
+
+	err := p.db.ExecTx(ctx, func(q generated.Querier) error {
+		var err error
+
+		quote, err = getLoanSettlementQuote(ctx, q, quoteID)
+		if err != nil {
+			return err
+		}
+
+		if quote.Status != generated.LoanSettlementQuotesStatusPENDING {
+			return pkg.Errorf(pkg.INVALID_ERROR, "settlement quote has already been used")
+		}
+
+		if time.Now().After(quote.ExpiresAt) {
+			return pkg.Errorf(
+				pkg.INVALID_ERROR,
+				"settlement quote expired on %s, request a new quote",
+				quote.ExpiresAt.In(pkg.NairobiLocation()).Format("2006-01-02 15:04"),
+			)
+		}
+
+		loan, err := getSettlableLoan(ctx, q, quote.LoanID)
+		if err != nil {
+			return err
+		}
+
+		if err := mysql.LockLoanForPayment(ctx, q, loan.ClientID, loan.ID); err != nil {
+			return err
+		}
+
+		// the loan may have been paid or settled while waiting for the lock
+		if _, err := getSettlableLoan(ctx, q, loan.ID); err != nil {
+			return err
+		}
+
+		payoff, err := getLoanPayoff(ctx, q, loan.ID)
+		if err != nil {
+			return err
+		}
+
+		if payoff.outstanding != pkg.MoneyFromFloat(quote.OutstandingAmount) ||
+			payoff.penaltiesOwed != pkg.MoneyFromFloat(quote.PenaltiesAmount) {
+			return pkg.Errorf(
+				pkg.INVALID_ERROR,
+				"the loan balance has changed since the quote was given, request a new quote",
+			)
+		}
+
+		client, err := q.GetClient(ctx, loan.ClientID)
+		if err != nil {
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to get client: %s", err.Error())
+		}
+
+		quoted := pkg.MoneyFromFloat(quote.QuotedAmount)
+		rebate := pkg.MoneyFromFloat(quote.RebateAmount)
+
+		paymentID, err := createNonPosted(ctx, q, &repository.NonPosted{
+			TransactionSource: string(generated.NonPostedTransactionSourceINTERNAL),
+			TransactionNumber: fmt.Sprintf("%s%d", repository.EarlySettlementPrefix, quote.ID),
+			AccountNumber:     fmt.Sprintf("%d", loan.ID),
+			PhoneNumber:       client.PhoneNumber,
+			PayingName:        client.FullName,
+			Amount:            quoted,
+			PaidDate:          time.Now(),
+			AssignedTo:        &loan.ClientID,
+			AssignedBy:        settleData.AssignedBy,
+		})
+		if err != nil {
+			return err
+		}
+
+		penaltiesPaid, err := payLoanPenalties(
+			ctx,
+			q,
+			payoff.penaltiesOwed,
+			loan.ID,
+			paymentID,
+			payoff.penalties,
+			"EARLY SETTLEMENT",
+		)
+		if err != nil {
+			return err
+		}
+
+		planned, _ := payInOrder(quoted-penaltiesPaid, payoff.installments, "")
+
+		installmentsPaid, err := payInstallments(
+			ctx,
+			q,
+			loan.ID,
+			paymentID,
+			planned,
+			"EARLY SETTLEMENT",
+		)
+		if err != nil {
+			return err
+		}
+
+		if _, err := q.UpdateLoan(ctx, generated.UpdateLoanParams{
+			ID:         loan.ID,
+			PaidAmount: installmentsPaid.Float64(),
+			UpdatedBy: sql.NullInt32{
+				Valid: true,
+				Int32: int32(settleData.SettledBy),
+			},
+		}); err != nil {
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to update loan: %s", err.Error())
+		}
+
+		// what the rebate leaves on the last installments is closed, not paid
+		if _, err := q.CloseUnpaidInstallments(ctx, generated.CloseUnpaidInstallmentsParams{
+			LoanID: loan.ID,
+		}); err != nil {
+			return pkg.Errorf(
+				pkg.INTERNAL_ERROR,
+				"failed to close unpaid installments: %s",
+				err.Error(),
+			)
+		}
+
+		if rebate > 0 {
+			if err := mysql.PostJournalEntry(ctx, q, repository.JournalEntry{
+				EntryType:   repository.JournalInterestRebate,
+				Description: fmt.Sprintf("LOAN %d SETTLED EARLY: unearned interest rebated", loan.ID),
+				LoanID:      &loan.ID,
+				ClientID:    &loan.ClientID,
+				NonPostedID: &paymentID,
+				CreatedBy:   settleData.AssignedBy,
+				Lines: repository.Transfer(
+					repository.AccountInterestIncome,
+					repository.AccountLoansReceivable,
+					rebate,
+				),
+			}); err != nil {
+				return err
+			}
+		}
+
+		if err := updateLoanStatus(ctx, q, loan.ID, generated.LoansStatusCOMPLETED); err != nil {
+			return err
+		}
+
+		result, err := q.SettleLoanSettlementQuote(ctx, generated.SettleLoanSettlementQuoteParams{
+			ID: quote.ID,
+			NonPostedID: sql.NullInt32{
+				Valid: true,
+				Int32: int32(paymentID),
+			},
+			SettledBy: sql.NullInt32{
+				Valid: true,
+				Int32: int32(settleData.SettledBy),
+			},
+		})
+		if err != nil {
+			return pkg.Errorf(
+				pkg.INTERNAL_ERROR,
+				"failed to settle settlement quote: %s",
+				err.Error(),
+			)
+		}
+
+		if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+			return pkg.Errorf(pkg.INVALID_ERROR, "settlement quote has already been used")
+		}
+
+		quote, err = getLoanSettlementQuote(ctx, q, quote.ID)
+
+		return err
+	})
+	if err != nil {
+		return services.SettlementQuote{}, err
+	}
+
+	return convertSettlementQuote(quote), nil
+}
+
+// settlementRebatePercent is the configured rebate kept between 0 and 100.
+func (p *PaymentService) settlementRebatePercent() float64 {
+	return math.Min(math.Max(p.config.LOAN_SETTLEMENT_REBATE_PERCENT, 0), 100)
+}
+
+func getSettlableLoan(ctx context.Context, q generated.Querier, loanID uint32) (generated.Loan, error) {
+	loan, err := q.GetLoan(ctx, loanID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return generated.Loan{}, pkg.Errorf(pkg.NOT_FOUND_ERROR, "loan not found")
+		}
+
+		return generated.Loan{}, pkg.Errorf(pkg.INTERNAL_ERROR, "failed to get loan: %s", err.Error())
+	}
+
+	if loan.Status != generated.LoansStatusACTIVE && loan.Status != generated.LoansStatusDEFAULTED {
+		return generated.Loan{}, pkg.Errorf(
+			pkg.INVALID_ERROR,
+			"only active or defaulted loans can be settled",
+		)
+	}
+
+	return loan, nil
+}
+
+// getLoanPayoff adds up what is owed on the loan. The interest on an installment is its share
+// of the product's interest, it is unearned while the installment is not yet due.
+func getLoanPayoff(ctx context.Context, q generated.Querier, loanID uint32) (loanPayoff, error) {
+	plan, err := getLoanAllocationPlan(ctx, q, loanID)
+	if err != nil {
+		return loanPayoff{}, err
+	}
+
+	payoff := loanPayoff{
+		installments: plan.installments,
+		penalties:    plan.penalties,
+	}
+
+	for _, installment := range plan.installments {
+		payoff.outstanding += owed(installment)
+
+		if isNotYetDue(installment, plan.terms.Today) {
+			payoff.unearnedInterest += owed(installment).Share(
+				plan.terms.InterestAmount,
+				plan.terms.RepayAmount,
+			)
+		}
+	}
+
+	for _, penalty := range plan.penalties {
+		payoff.penaltiesOwed += pkg.MoneyFromFloat(penalty.RemainingAmount)
+	}
+
+	return payoff, nil
+}
+
+func isNotYetDue(installment generated.Installment, today time.Time) bool {
+	y, m, d := today.Date()
+
+	return installment.DueDate.After(time.Date(y, m, d, 0, 0, 0, 0, installment.DueDate.Location()))
+}
+
+func getLoanSettlementQuote(
+	ctx context.Context,
+	q generated.Querier,
+	id uint32,
+) (generated.LoanSettlementQuote, error) {
+	quote, err := q.GetLoanSettlementQuote(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return generated.LoanSettlementQuote{}, pkg.Errorf(
+				pkg.NOT_FOUND_ERROR,
+				"no settlement quote found",
+			)
+		}
+
+		return generated.LoanSettlementQuote{}, pkg.Errorf(
+			pkg.INTERNAL_ERROR,
+			"failed to get settlement quote: %s",
+			err.Error(),
+		)
+	}
+
+	return quote, nil
+}
+
+func convertSettlementQuote(quote generated.LoanSettlementQuote) services.SettlementQuote {
+	rsp := services.SettlementQuote{
+		ID:                quote.ID,
+		LoanID:            quote.LoanID,
+		OutstandingAmount: quote.OutstandingAmount,
+		PenaltiesAmount:   quote.PenaltiesAmount,
+		UnearnedInterest:  quote.UnearnedInterest,
+		RebatePercent:     quote.RebatePercent,
+		RebateAmount:      quote.RebateAmount,
+		QuotedAmount:      quote.QuotedAmount,
+		Status:            string(quote.Status),
+		ExpiresAt:         quote.ExpiresAt,
+		QuotedBy:          quote.QuotedBy,
+		CreatedAt:         quote.CreatedAt,
+	}
+
+	if quote.Status == generated.LoanSettlementQuotesStatusPENDING &&
+		time.Now().After(quote.ExpiresAt) {
+		rsp.Status = settlementQuoteExpired
+	}
+
+	if quote.NonPostedID.Valid {
+		rsp.NonPostedID = pkg.Uint32Ptr(uint32(quote.NonPostedID.Int32))
+	}
+
+	if quote.SettledBy.Valid {
+		rsp.SettledBy = pkg.Uint32Ptr(uint32(quote.SettledBy.Int32))
+	}
+
+	if quote.SettledAt.Valid {
+		rsp.SettledAt = pkg.TimePtr(quote.SettledAt.Time)
+	}
+
+	return rsp
+}
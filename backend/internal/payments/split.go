@@ -0,0 +1,587 @@
+package payments
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/mysql/generated"
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/repository"
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/services"
+	"github.com/EmilioCliff/kokomed-fin/backend/pkg"
+)
+
+// SplitPayment divides an unassigned payment into portions, each paying a client's active
+// loan or adding to their overpayment when they have none. The payment is assigned to the
+// first portion's client so it no longer shows as unassigned.
+func (p *PaymentService) SplitPayment(
+	ctx context.Context,
+	splitData *services.PaymentSplitData,
+) (services.PaymentSplit, error) {
+	if len(splitData.Portions) < 2 {
+		return services.PaymentSplit{}, pkg.Errorf(
+			pkg.INVALID_ERROR,
+			"a split needs at least two portions",
+		)
+	}
+
+	splitID := uint32(0)
+
+	err := p.db.ExecTx(ctx, func(q generated.Querier) error {
+		nonPosted, err := lockUnassignedPayment(ctx, q, splitData.NonPostedID)
+		if err != nil {
+			return err
+		}
+
+		portions, err := resolveSplitPortions(ctx, q, splitData.Portions)
+		if err != nil {
+			return err
+		}
+
+		total := pkg.Money(0)
+		for _, portion := range portions {
+			total += portion.Amount
+		}
+
+		if total != pkg.MoneyFromFloat(nonPosted.Amount) {
+			return pkg.Errorf(
+				pkg.INVALID_ERROR,
+				"portions add up to %s but the payment is %.2f",
+				total,
+				nonPosted.Amount,
+			)
+		}
+
+		execResult, err := q.CreatePaymentSplit(ctx, generated.CreatePaymentSplitParams{
+			NonPostedID: nonPosted.ID,
+			CreatedBy:   splitData.SplitBy,
+		})
+		if err != nil {
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to create payment split: %s", err.Error())
+		}
+
+		id, err := execResult.LastInsertId()
+		if err != nil {
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to get last insert id: %s", err.Error())
+		}
+
+		splitID = uint32(id)
+
+		for _, portion := range portions {
+			params := generated.CreatePaymentSplitPortionParams{
+				SplitID:  splitID,
+				ClientID: portion.ClientID,
+				Amount:   portion.Amount.Float64(),
+			}
+
+			if portion.LoanID != nil {
+				params.LoanID = sql.NullInt32{
+					Valid: true,
+					Int32: int32(*portion.LoanID),
+				}
+			}
+
+			if _, err := q.CreatePaymentSplitPortion(ctx, params); err != nil {
+				return pkg.Errorf(
+					pkg.INTERNAL_ERROR,
+					"failed to create payment split portion: %s",
+					err.Error(),
+				)
+			}
+
+			if portion.LoanID == nil {
+				if err := updateOverpayment(ctx, q, repository.Overpayment{
+					ClientID:    portion.ClientID,
+					Amount:      portion.Amount,
+					PaymentID:   &nonPosted.ID,
+					Description: "SPLIT LOAN PAYMENT: no active loan adding to overpayment",
+				}); err != nil {
+					return err
+				}
+
+				if err := createAllocation(ctx, q, repository.PaymentAllocation{
+					NonPostedID: nonPosted.ID,
+					Amount:      portion.Amount,
+					Description: "SPLIT LOAN PAYMENT: no active loan adding to overpayment",
+				}); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			if err := processLoanPayment(ctx, q, &repository.UpdateLoan{
+				ID:         *portion.LoanID,
+				PaidAmount: portion.Amount,
+				UpdatedBy:  &splitData.SplitBy,
+			}, nonPosted.ID, portion.ClientID, 0, "SPLIT LOAN PAYMENT"); err != nil {
+				return err
+			}
+		}
+
+		_, err = q.AssignNonPosted(ctx, generated.AssignNonPostedParams{
+			ID: nonPosted.ID,
+			AssignTo: sql.NullInt32{
+				Valid: true,
+				Int32: int32(portions[0].ClientID),
+			},
+			TransactionSource: nonPosted.TransactionSource,
+			AssignedBy: sql.NullString{
+				Valid:  true,
+				String: fmt.Sprintf("SPLIT: %s", splitData.AssignedBy),
+			},
+		})
+		if err != nil {
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to assign non posted: %s", err.Error())
+		}
+
+		return nil
+	})
+	if err != nil {
+		return services.PaymentSplit{}, err
+	}
+
+	return p.GetPaymentSplit(ctx, splitID)
+}
+
+// lockUnassignedPayment locks the payment so it is only assigned or split once and checks it
+// has not been already.
+func lockUnassignedPayment(
+	ctx context.Context,
+	q generated.Querier,
+	id uint32,
+) (generated.NonPosted, error) {
+	nonPosted, err := q.LockNonPosted(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return generated.NonPosted{}, pkg.Errorf(pkg.NOT_FOUND_ERROR, "no non posted found")
+		}
+
+		return generated.NonPosted{}, pkg.Errorf(
+			pkg.INTERNAL_ERROR,
+			"failed to lock non posted: %s",
+			err.Error(),
+		)
+	}
+
+	if nonPosted.DeletedAt.Valid {
+		return generated.NonPosted{}, pkg.Errorf(pkg.INVALID_ERROR, "payment has been deleted")
+	}
+
+	if nonPosted.AssignTo.Valid {
+		return generated.NonPosted{}, pkg.Errorf(
+			pkg.INVALID_ERROR,
+			"payment has already been assigned",
+		)
+	}
+
+	if _, err := q.GetActivePaymentSplitByNonPosted(ctx, id); err == nil {
+		return generated.NonPosted{}, pkg.Errorf(pkg.INVALID_ERROR, "payment has already been split")
+	} else if err != sql.ErrNoRows {
+		return generated.NonPosted{}, pkg.Errorf(
+			pkg.INTERNAL_ERROR,
+			"failed to get payment split: %s",
+			err.Error(),
+		)
+	}
+
+	return nonPosted, nil
+}
+
+// checkPaymentNotSplit refuses a payment with an active split. The split paid every portion's
+// client, only reversing the split can undo it.
+func checkPaymentNotSplit(ctx context.Context, q generated.Querier, id uint32) error {
+	if _, err := q.GetActivePaymentSplitByNonPosted(ctx, id); err == nil {
+		return pkg.Errorf(
+			pkg.INVALID_ERROR,
+			"payment is split between clients, reverse the split instead",
+		)
+	} else if err != sql.ErrNoRows {
+		return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to get payment split: %s", err.Error())
+	}
+
+	return nil
+}
+
+// lockUnsplitPayment locks the payment so it cannot be split while it is changed, and refuses it
+// if it already is.
+func lockUnsplitPayment(ctx context.Context, q generated.Querier, id uint32) error {
+	if _, err := q.LockNonPosted(ctx, id); err != nil {
+		if err == sql.ErrNoRows {
+			return pkg.Errorf(pkg.NOT_FOUND_ERROR, "no non posted found")
+		}
+
+		return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to lock non posted: %s", err.Error())
+	}
+
+	return checkPaymentNotSplit(ctx, q, id)
+}
+
+func (p *PaymentService) GetPaymentSplit(
+	ctx context.Context,
+	id uint32,
+) (services.PaymentSplit, error) {
+	var split generated.PaymentSplit
+	var portions []generated.PaymentSplitPortion
+
+	err := p.db.ExecTx(ctx, func(q generated.Querier) error {
+		var err error
+
+		split, err = q.GetPaymentSplit(ctx, id)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return pkg.Errorf(pkg.NOT_FOUND_ERROR, "no payment split found")
+			}
+
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to get payment split: %s", err.Error())
+		}
+
+		portions, err = q.ListPaymentSplitPortions(ctx, id)
+		if err != nil {
+			return pkg.Errorf(
+				pkg.INTERNAL_ERROR,
+				"failed to list payment split portions: %s",
+				err.Error(),
+			)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return services.PaymentSplit{}, err
+	}
+
+	rslt := services.PaymentSplit{
+		ID:          split.ID,
+		NonPostedID: split.NonPostedID,
+		Status:      string(split.Status),
+		CreatedBy:   split.CreatedBy,
+		CreatedAt:   split.CreatedAt,
+		Portions:    make([]services.PaymentSplitPortion, len(portions)),
+	}
+
+	if split.ReversedBy.Valid {
+		rslt.ReversedBy = pkg.Uint32Ptr(uint32(split.ReversedBy.Int32))
+	}
+
+	if split.ReversalReason.Valid {
+		rslt.ReversalReason = pkg.StringPtr(split.ReversalReason.String)
+	}
+
+	if split.ReversedAt.Valid {
+		rslt.ReversedAt = pkg.TimePtr(split.ReversedAt.Time)
+	}
+
+	for i, portion := range portions {
+		rslt.Portions[i] = services.PaymentSplitPortion{
+			ID:       portion.ID,
+			ClientID: portion.ClientID,
+			Amount:   pkg.MoneyFromFloat(portion.Amount),
+		}
+
+		if portion.LoanID.Valid {
+			rslt.Portions[i].LoanID = pkg.Uint32Ptr(uint32(portion.LoanID.Int32))
+		}
+	}
+
+	return rslt, nil
+}
+
+// ReversePaymentSplit undoes every portion of a split in one transaction and leaves the
+// payment unassigned again.
+func (p *PaymentService) ReversePaymentSplit(
+	ctx context.Context,
+	id uint32,
+	reverseData *services.ReversePaymentSplitData,
+) (services.PaymentSplit, error) {
+	if reverseData.Reason == "" {
+		return services.PaymentSplit{}, pkg.Errorf(pkg.INVALID_ERROR, "a reversal reason is required")
+	}
+
+	err := p.db.ExecTx(ctx, func(q generated.Querier) error {
+		split, err := q.GetPaymentSplit(ctx, id)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return pkg.Errorf(pkg.NOT_FOUND_ERROR, "no payment split found")
+			}
+
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to get payment split: %s", err.Error())
+		}
+
+		if split.Status == generated.PaymentSplitsStatusREVERSED {
+			return pkg.Errorf(pkg.INVALID_ERROR, "payment split is already reversed")
+		}
+
+		nonPosted, err := q.GetNonPosted(ctx, split.NonPostedID)
+		if err != nil {
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to get non posted: %s", err.Error())
+		}
+
+		portions, err := q.ListPaymentSplitPortions(ctx, id)
+		if err != nil {
+			return pkg.Errorf(
+				pkg.INTERNAL_ERROR,
+				"failed to list payment split portions: %s",
+				err.Error(),
+			)
+		}
+
+		allocations, err := q.ListPaymentAllocationsByNonPostedId(ctx, split.NonPostedID)
+		if err != nil {
+			return pkg.Errorf(
+				pkg.INTERNAL_ERROR,
+				"failed to list payment allocations: %s",
+				err.Error(),
+			)
+		}
+
+		clientIDs := make([]uint32, 0, len(portions))
+		for _, portion := range portions {
+			clientIDs = append(clientIDs, portion.ClientID)
+		}
+
+		if err := lockForRevert(ctx, q, allocations, clientIDs, nil); err != nil {
+			return err
+		}
+
+		for _, portion := range portions {
+			// a client appears once in a split, so the installments paid on the portion's loan
+			// are the portion's and whatever is left of the portion went to overpayment
+			revertedAmount := pkg.Money(0)
+			penaltiesReverted := pkg.Money(0)
+
+			if portion.LoanID.Valid {
+				for _, allocation := range allocations {
+					if allocation.LoanID.Int32 != portion.LoanID.Int32 {
+						continue
+					}
+
+					if allocation.PenaltyID.Valid {
+						if err := revertPenalty(ctx, q, uint32(allocation.PenaltyID.Int32), allocation.Amount); err != nil {
+							return err
+						}
+
+						penaltiesReverted += pkg.MoneyFromFloat(allocation.Amount)
+
+						continue
+					}
+
+					if !allocation.InstallmentID.Valid {
+						continue
+					}
+
+					if err := revertInstallment(ctx, q, uint32(allocation.InstallmentID.Int32), allocation.Amount); err != nil {
+						return err
+					}
+
+					revertedAmount += pkg.MoneyFromFloat(allocation.Amount)
+				}
+			}
+
+			if revertedAmount+penaltiesReverted > 0 {
+				loanID := uint32(portion.LoanID.Int32)
+
+				loanStatus, err := q.GetLoanStatus(ctx, loanID)
+				if err != nil {
+					return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to get loan status: %s", err.Error())
+				}
+
+				if loanStatus == generated.LoansStatusCOMPLETED {
+					hasActiveLoan, err := q.CheckActiveLoanForClient(ctx, portion.ClientID)
+					if err != nil {
+						return pkg.Errorf(
+							pkg.INTERNAL_ERROR,
+							"failed to check if client has an active loan: %s",
+							err.Error(),
+						)
+					}
+
+					if hasActiveLoan {
+						return pkg.Errorf(
+							pkg.INVALID_ERROR,
+							"loan %d status will change to active and client has another active loan",
+							loanID,
+						)
+					}
+				}
+
+				_, err = q.ReduceLoan(ctx, generated.ReduceLoanParams{
+					ID:         loanID,
+					PaidAmount: revertedAmount.Float64(),
+					UpdatedBy: sql.NullInt32{
+						Valid: true,
+						Int32: int32(reverseData.ReversedBy),
+					},
+				})
+				if err != nil {
+					return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to update loan: %s", err.Error())
+				}
+			}
+
+			overpaid := pkg.MoneyFromFloat(portion.Amount) - revertedAmount - penaltiesReverted
+			if overpaid > 0 {
+				if err := deductOverpayment(ctx, q, repository.Overpayment{
+					ClientID:  portion.ClientID,
+					Amount:    overpaid,
+					PaymentID: &split.NonPostedID,
+					CreatedBy: reverseData.AssignedBy,
+					Description: fmt.Sprintf(
+						"SPLIT REVERSAL: REDUCING OVERPAYMENT: %s",
+						reverseData.Reason,
+					),
+				}); err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := postAllocationReversal(
+			ctx,
+			q,
+			split.NonPostedID,
+			allocations,
+			reverseData.AssignedBy,
+			fmt.Sprintf("SPLIT REVERSAL: REVERSING ALLOCATIONS: %s", reverseData.Reason),
+		); err != nil {
+			return err
+		}
+
+		_, err = q.DeletePaymentAllocationsByNonPostedId(
+			ctx,
+			generated.DeletePaymentAllocationsByNonPostedIdParams{
+				NonPostedID: split.NonPostedID,
+				DeletedDescription: sql.NullString{
+					Valid: true,
+					String: fmt.Sprintf(
+						"SPLIT REVERSAL: DELETING ALLOCATIONS: %s",
+						reverseData.Reason,
+					),
+				},
+			},
+		)
+		if err != nil {
+			return pkg.Errorf(
+				pkg.INTERNAL_ERROR,
+				"failed to delete payment allocations: %s",
+				err.Error(),
+			)
+		}
+
+		_, err = q.ReversePaymentSplit(ctx, generated.ReversePaymentSplitParams{
+			ID: id,
+			ReversedBy: sql.NullInt32{
+				Valid: true,
+				Int32: int32(reverseData.ReversedBy),
+			},
+			ReversalReason: sql.NullString{
+				Valid:  true,
+				String: reverseData.Reason,
+			},
+		})
+		if err != nil {
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to reverse payment split: %s", err.Error())
+		}
+
+		_, err = q.AssignNonPosted(ctx, generated.AssignNonPostedParams{
+			ID:                split.NonPostedID,
+			AssignTo:          sql.NullInt32{},
+			TransactionSource: nonPosted.TransactionSource,
+			AssignedBy: sql.NullString{
+				Valid:  true,
+				String: fmt.Sprintf("SPLIT REVERSED: %s", reverseData.AssignedBy),
+			},
+		})
+		if err != nil {
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to unassign non posted: %s", err.Error())
+		}
+
+		return nil
+	})
+	if err != nil {
+		return services.PaymentSplit{}, err
+	}
+
+	return p.GetPaymentSplit(ctx, id)
+}
+
+// resolveSplitPortions fills in the client of portions given by loan and the active loan of
+// portions given by client.
+func resolveSplitPortions(
+	ctx context.Context,
+	q generated.Querier,
+	portionsData []services.PaymentSplitPortionData,
+) ([]services.PaymentSplitPortionData, error) {
+	portions := make([]services.PaymentSplitPortionData, len(portionsData))
+	seen := map[uint32]bool{}
+
+	for i, portion := range portionsData {
+		if portion.Amount <= 0 {
+			return nil, pkg.Errorf(pkg.INVALID_ERROR, "portion %d amount must be greater than 0", i+1)
+		}
+
+		if portion.LoanID != nil {
+			clientID, err := q.GetLoanClientID(ctx, *portion.LoanID)
+			if err != nil {
+				if err == sql.ErrNoRows {
+					return nil, pkg.Errorf(pkg.NOT_FOUND_ERROR, "loan %d not found", *portion.LoanID)
+				}
+
+				return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "failed to get loan client id: %s", err.Error())
+			}
+
+			if portion.ClientID != 0 && portion.ClientID != clientID {
+				return nil, pkg.Errorf(
+					pkg.INVALID_ERROR,
+					"loan %d does not belong to client %d",
+					*portion.LoanID,
+					portion.ClientID,
+				)
+			}
+
+			status, err := q.GetLoanStatus(ctx, *portion.LoanID)
+			if err != nil {
+				return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "failed to get loan status: %s", err.Error())
+			}
+
+			if status != generated.LoansStatusACTIVE && status != generated.LoansStatusDEFAULTED {
+				return nil, pkg.Errorf(pkg.INVALID_ERROR, "loan %d is not active", *portion.LoanID)
+			}
+
+			portion.ClientID = clientID
+		} else {
+			if _, err := q.GetClient(ctx, portion.ClientID); err != nil {
+				if err == sql.ErrNoRows {
+					return nil, pkg.Errorf(pkg.NOT_FOUND_ERROR, "client %d not found", portion.ClientID)
+				}
+
+				return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "failed to get client: %s", err.Error())
+			}
+
+			loanID, err := q.GetClientPayableLoan(ctx, portion.ClientID)
+			if err != nil && err != sql.ErrNoRows {
+				return nil, pkg.Errorf(
+					pkg.INTERNAL_ERROR,
+					"failed to get client active loan: %s",
+					err.Error(),
+				)
+			}
+
+			if err == nil {
+				portion.LoanID = &loanID
+			}
+		}
+
+		if seen[portion.ClientID] {
+			return nil, pkg.Errorf(
+				pkg.INVALID_ERROR,
+				"client %d appears in more than one portion",
+				portion.ClientID,
+			)
+		}
+
+		seen[portion.ClientID] = true
+		portions[i] = portion
+	}
+
+	return portions, nil
+}
@@ -0,0 +1,196 @@
+package payments
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/mysql/generated"
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/services"
+	"github.com/EmilioCliff/kokomed-fin/backend/pkg"
+)
+
+func (p *PaymentService) InitiateSTKPush(
+	ctx context.Context,
+	pushData *services.STKPushData,
+) (services.STKPushResult, error) {
+	loan, err := p.mySQL.Loans.GetLoanByID(ctx, pushData.LoanID)
+	if err != nil {
+		return services.STKPushResult{}, err
+	}
+
+	if loan.Status != string(generated.LoansStatusACTIVE) {
+		return services.STKPushResult{}, pkg.Errorf(
+			pkg.INVALID_ERROR,
+			"loan is not active",
+		)
+	}
+
+	amount := float64(0)
+	if pushData.Amount != nil {
+		amount = *pushData.Amount
+	} else {
+		installments, err := p.mySQL.Loans.GetLoanInstallments(ctx, loan.ID)
+		if err != nil {
+			return services.STKPushResult{}, err
+		}
+
+		// installments are ordered by due date so the first unpaid is the next one due, closed
+		// installments are history and are not owed
+		for _, installment := range installments {
+			if !installment.Closed && installment.RemainingAmount > 0 {
+				amount = installment.RemainingAmount.Float64()
+
+				break
+			}
+		}
+	}
+
+	// daraja only accepts whole shillings
+	amount = math.Ceil(amount)
+	if amount <= 0 {
+		return services.STKPushResult{}, pkg.Errorf(pkg.INVALID_ERROR, "no amount due on loan")
+	}
+
+	client, err := p.mySQL.Clients.GetClientFullData(ctx, loan.ClientID)
+	if err != nil {
+		return services.STKPushResult{}, err
+	}
+
+	rsp, err := p.mpesa.STKPush(pkg.STKPushRequest{
+		PhoneNumber:      client.PhoneNumber,
+		Amount:           int64(amount),
+		AccountReference: client.PhoneNumber,
+		TransactionDesc:  fmt.Sprintf("Loan %d repayment", loan.ID),
+		CallbackURL:      p.config.MPESA_STK_CALLBACK_URL,
+	})
+	if err != nil {
+		return services.STKPushResult{}, err
+	}
+
+	err = p.db.ExecTx(ctx, func(q generated.Querier) error {
+		_, err := q.CreateStkPushRequest(ctx, generated.CreateStkPushRequestParams{
+			LoanID:            loan.ID,
+			ClientID:          loan.ClientID,
+			PhoneNumber:       client.PhoneNumber,
+			Amount:            amount,
+			MerchantRequestID: rsp.MerchantRequestID,
+			CheckoutRequestID: rsp.CheckoutRequestID,
+			InitiatedBy:       pushData.InitiatedBy,
+		})
+		if err != nil {
+			return pkg.Errorf(
+				pkg.INTERNAL_ERROR,
+				"failed to create stk push request: %s",
+				err.Error(),
+			)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return services.STKPushResult{}, err
+	}
+
+	return services.STKPushResult{
+		CheckoutRequestID: rsp.CheckoutRequestID,
+		MerchantRequestID: rsp.MerchantRequestID,
+		PhoneNumber:       client.PhoneNumber,
+		Amount:            amount,
+		CustomerMessage:   rsp.CustomerMessage,
+	}, nil
+}
+
+func (p *PaymentService) ProcessSTKCallback(
+	ctx context.Context,
+	callbackData *services.STKCallbackData,
+) (uint32, error) {
+	var request generated.StkPushRequest
+	var client generated.Client
+
+	err := p.db.ExecTx(ctx, func(q generated.Querier) error {
+		var err error
+
+		request, err = q.GetStkPushRequestByCheckoutID(ctx, callbackData.CheckoutRequestID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return pkg.Errorf(pkg.NOT_FOUND_ERROR, "no stk push request found")
+			}
+
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to get stk push request: %s", err.Error())
+		}
+
+		client, err = q.GetClient(ctx, request.ClientID)
+		if err != nil {
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to get client: %s", err.Error())
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	// daraja can deliver the same result more than once
+	if request.Status != generated.StkPushRequestsStatusPENDING {
+		return request.LoanID, nil
+	}
+
+	params := generated.UpdateStkPushRequestResultParams{
+		ID:     request.ID,
+		Status: generated.StkPushRequestsStatusFAILED,
+		ResultCode: sql.NullInt32{
+			Valid: true,
+			Int32: int32(callbackData.ResultCode),
+		},
+		ResultDesc: sql.NullString{
+			Valid:  true,
+			String: callbackData.ResultDesc,
+		},
+	}
+
+	if callbackData.ResultCode == 0 {
+		amount := callbackData.Amount
+		if amount <= 0 {
+			amount = request.Amount
+		}
+
+		if _, err := p.ProcessCallback(ctx, &services.MpesaCallbackData{
+			TransactionSource: "MPESA",
+			TransactionID:     callbackData.ReceiptNumber,
+			AccountNumber:     client.PhoneNumber,
+			PhoneNumber:       callbackData.PhoneNumber,
+			PayingName:        client.FullName,
+			Amount:            amount,
+			AssignedBy:        "APP",
+			AssignedTo:        pkg.Uint32Ptr(request.ClientID),
+			PaidDate:          callbackData.PaidDate,
+		}); err != nil {
+			return 0, err
+		}
+
+		params.Status = generated.StkPushRequestsStatusCOMPLETED
+		params.MpesaReceiptNumber = sql.NullString{
+			Valid:  true,
+			String: callbackData.ReceiptNumber,
+		}
+	}
+
+	err = p.db.ExecTx(ctx, func(q generated.Querier) error {
+		if _, err := q.UpdateStkPushRequestResult(ctx, params); err != nil {
+			return pkg.Errorf(
+				pkg.INTERNAL_ERROR,
+				"failed to update stk push request: %s",
+				err.Error(),
+			)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return request.LoanID, nil
+}
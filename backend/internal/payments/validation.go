@@ -0,0 +1,142 @@
+package payments
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/repository"
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/services"
+	"github.com/EmilioCliff/kokomed-fin/backend/pkg"
+)
+
+// Daraja C2B validation result codes.
+const (
+	ValidationAccepted       = "0"
+	ValidationInvalidAccount = "C2B00012"
+	ValidationInvalidAmount  = "C2B00013"
+	ValidationOtherError     = "C2B00016"
+)
+
+func (p *PaymentService) ValidateCallback(
+	ctx context.Context,
+	validationData *services.MpesaValidationData,
+) (services.ValidationResult, error) {
+	result, err := p.validateCallback(ctx, validationData)
+	if err != nil {
+		return services.ValidationResult{}, err
+	}
+
+	if err := p.mySQL.NonPosted.CreatePaymentValidationLog(ctx, &repository.PaymentValidationLog{
+		TransactionNumber: validationData.TransactionID,
+		AccountNumber:     validationData.AccountNumber,
+		PhoneNumber:       validationData.PhoneNumber,
+		PayingName:        validationData.PayingName,
+		Amount:            pkg.MoneyFromFloat(validationData.Amount),
+		ClientID:          result.ClientID,
+		Accepted:          result.Accepted,
+		ResultCode:        result.ResultCode,
+		Reason:            result.Reason,
+	}); err != nil {
+		// the decision stands without its log entry
+		log.Printf("failed to save payment validation log for %s: %v", validationData.TransactionID, err)
+	}
+
+	return result, nil
+}
+
+func (p *PaymentService) validateCallback(
+	ctx context.Context,
+	validationData *services.MpesaValidationData,
+) (services.ValidationResult, error) {
+	if validationData.Amount <= 0 {
+		return rejectValidation(ValidationInvalidAmount, "invalid amount", nil), nil
+	}
+
+	if p.config.MPESA_MIN_AMOUNT > 0 && validationData.Amount < p.config.MPESA_MIN_AMOUNT {
+		return rejectValidation(
+			ValidationInvalidAmount,
+			fmt.Sprintf("amount is below the minimum of %.2f", p.config.MPESA_MIN_AMOUNT),
+			nil,
+		), nil
+	}
+
+	if p.config.MPESA_MAX_AMOUNT > 0 && validationData.Amount > p.config.MPESA_MAX_AMOUNT {
+		return rejectValidation(
+			ValidationInvalidAmount,
+			fmt.Sprintf("amount is above the maximum of %.2f", p.config.MPESA_MAX_AMOUNT),
+			nil,
+		), nil
+	}
+
+	accountNumber := strings.TrimSpace(validationData.AccountNumber)
+	if accountNumber == "" {
+		return rejectValidation(ValidationInvalidAccount, "missing account number", nil), nil
+	}
+
+	if isAccountAlias(p.config.MPESA_ACCOUNT_ALIASES, accountNumber) {
+		return services.ValidationResult{
+			Accepted:   true,
+			ResultCode: ValidationAccepted,
+			Reason:     "account number is a configured alias",
+		}, nil
+	}
+
+	clientID, err := p.mySQL.Clients.GetClientIDByPhoneNumber(ctx, accountNumber)
+	if err != nil {
+		if pkg.ErrorCode(err) != pkg.NOT_FOUND_ERROR {
+			return services.ValidationResult{}, err
+		}
+
+		if p.config.MPESA_REJECT_UNKNOWN_ACCOUNTS {
+			return rejectValidation(ValidationInvalidAccount, "no client found for account number", nil), nil
+		}
+
+		return services.ValidationResult{
+			Accepted:   true,
+			ResultCode: ValidationAccepted,
+			Reason:     "unknown account number accepted as unassigned payment",
+		}, nil
+	}
+
+	blacklist, err := p.mySQL.Clients.GetBlacklistedClient(ctx, clientID)
+	if err != nil && pkg.ErrorCode(err) != pkg.NOT_FOUND_ERROR {
+		return services.ValidationResult{}, err
+	}
+
+	if err == nil {
+		return rejectValidation(
+			ValidationOtherError,
+			fmt.Sprintf("client is blacklisted: %s", blacklist.Reason),
+			&clientID,
+		), nil
+	}
+
+	return services.ValidationResult{
+		Accepted:   true,
+		ResultCode: ValidationAccepted,
+		Reason:     "account number matches client",
+		ClientID:   &clientID,
+	}, nil
+}
+
+func rejectValidation(code, reason string, clientID *uint32) services.ValidationResult {
+	return services.ValidationResult{
+		Accepted:   false,
+		ResultCode: code,
+		Reason:     reason,
+		ClientID:   clientID,
+	}
+}
+
+func isAccountAlias(aliases string, accountNumber string) bool {
+	for _, alias := range strings.Split(aliases, ",") {
+		alias = strings.TrimSpace(alias)
+		if alias != "" && strings.EqualFold(alias, accountNumber) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -64,17 +64,18 @@ func (lr *loanReport) generatePDF() ([]byte, error) {
 }
 
 func (lr *loanReport) adminReportExcel() ([]byte, error) {
-	columns := []string{"Loan ID", "Client Name", "Branch Name", "Loan Officer", "Loan Amount", "Repay Amount", "Paid Amount", "Outstanding Amount", "Status", "Due Date", "No Installments", "Paid Installments", "Disbursed Date", "Default Risk(%)"}
+	columns := []string{"Loan ID", "Client Name", "Branch Name", "Loan Officer", "Loan Amount", "Repay Amount", "Paid Amount", "Outstanding Amount", "Penalty Balance", "Status", "Restructured", "Due Date", "No Installments", "Paid Installments", "Disbursed Date", "Default Risk(%)"}
 
-	lr.file.SetColWidth(lr.currentSheet, "A", "N", 20)
+	lr.file.SetColWidth(lr.currentSheet, "A", "P", 20)
 	lr.file.SetColStyle(lr.currentSheet, "E", lr.createMoneyStyle())
 	lr.file.SetColStyle(lr.currentSheet, "F", lr.createMoneyStyle())
 	lr.file.SetColStyle(lr.currentSheet, "G", lr.createMoneyStyle())
 	lr.file.SetColStyle(lr.currentSheet, "H", lr.createMoneyStyle())
-	lr.file.SetColStyle(lr.currentSheet, "J", lr.createDateStyle())
-	lr.file.SetColStyle(lr.currentSheet, "K", lr.createQuantityStyle())
-	lr.file.SetColStyle(lr.currentSheet, "L", lr.createQuantityStyle())
-	lr.file.SetColStyle(lr.currentSheet, "M", lr.createDateStyle())
+	lr.file.SetColStyle(lr.currentSheet, "I", lr.createMoneyStyle())
+	lr.file.SetColStyle(lr.currentSheet, "L", lr.createDateStyle())
+	lr.file.SetColStyle(lr.currentSheet, "M", lr.createQuantityStyle())
+	lr.file.SetColStyle(lr.currentSheet, "N", lr.createQuantityStyle())
+	lr.file.SetColStyle(lr.currentSheet, "O", lr.createDateStyle())
 	// ur.file.SetColStyle(ur.currentSheet, "H", ur.createPercentageStyle())
 
 	lr.writeHeader(columns, lr.createHeaderStyle())
@@ -85,11 +86,13 @@ func (lr *loanReport) adminReportExcel() ([]byte, error) {
 			loan.ClientName,
 			loan.BranchName,
 			loan.LoanOfficer,
-			loan.LoanAmount,
-			loan.RepayAmount,
-			loan.PaidAmount,
-			loan.OutstandingAmount,
+			loan.LoanAmount.Float64(),
+			loan.RepayAmount.Float64(),
+			loan.PaidAmount.Float64(),
+			loan.OutstandingAmount.Float64(),
+			loan.PenaltyBalance.Float64(),
 			loan.Status,
+			formatYesNo(loan.Restructured),
 			loan.DueDate,
 			loan.TotalInstallments,
 			loan.PaidInstallments,
@@ -132,9 +135,11 @@ func (lr *loanReport) adminReportPDF() ([]byte, error) {
 		"TotalActiveLoans": formatQuantity(lr.adminSummary.TotalActiveLoans),
 		"TotalCompletedLoans": formatQuantity(lr.adminSummary.TotalCompletedLoans),
 		"TotalDefaultedLoans": formatQuantity(lr.adminSummary.TotalDefaultedLoans),
-		"TotalDisbursedAmount": formatMoney(lr.adminSummary.TotalDisbursedAmount),
-		"TotalRepaidAmount": formatMoney(lr.adminSummary.TotalRepaidAmount),
-		"TotalOutstanding": formatMoney(lr.adminSummary.TotalOutstanding),
+		"TotalRestructuredLoans": formatQuantity(lr.adminSummary.TotalRestructuredLoans),
+		"TotalDisbursedAmount": formatMoney(lr.adminSummary.TotalDisbursedAmount.Float64()),
+		"TotalRepaidAmount": formatMoney(lr.adminSummary.TotalRepaidAmount.Float64()),
+		"TotalOutstanding": formatMoney(lr.adminSummary.TotalOutstanding.Float64()),
+		"TotalPenaltyBalance": formatMoney(lr.adminSummary.TotalPenaltyBalance.Float64()),
 		"MostIssuedLoanBranch": lr.adminSummary.MostIssuedLoanBranch,
 		"MostLoansOfficer": lr.adminSummary.MostLoansOfficer,
 	}
@@ -143,14 +148,14 @@ func (lr *loanReport) adminReportPDF() ([]byte, error) {
 	lr.writeSummary(summary)
 
 	lr.pdf.Ln(lineHt*2)
-	headers := []string{"LoanID", "Client Name", "Branch Name", "Loan Officer", "Loan Amount", "Repay Amount", "Paid Amount", "Outstanding Amount", "Status", "Due Date", "No Installments", "Paid Installments", "Disbursed Date", "Default Risk(%)"}
-	colWidths := []float64{25, 30, 30, 30, 25, 32, 30, 35, 30, 25, 30, 30, 30, 28}
+	headers := []string{"LoanID", "Client Name", "Branch Name", "Loan Officer", "Loan Amount", "Repay Amount", "Paid Amount", "Outstanding Amount", "Penalty Balance", "Status", "Restructured", "Due Date", "No Installments", "Paid Installments", "Disbursed Date", "Default Risk(%)"}
+	colWidths := []float64{22, 27, 27, 27, 25, 28, 27, 31, 25, 22, 24, 25, 23, 23, 27, 25}
 
 	lr.pdf.SetFillColor(secondaryColor[0], secondaryColor[1], secondaryColor[2])
     lr.pdf.SetFont("Arial", "B", mediumFont)
     lr.pdf.SetX(marginX)
 	lr.writeTableHeaders(headers, colWidths)
-	colAlignment := []string{"CM", "L", "L", "L", "R", "R", "R", "R", "CM", "R", "CM", "CM", "R", "CM"}
+	colAlignment := []string{"CM", "L", "L", "L", "R", "R", "R", "R", "R", "CM", "CM", "R", "CM", "CM", "R", "CM"}
 
 	lr.pdf.SetFontStyle("")
     lr.pdf.SetFillColor(primaryColor[0], primaryColor[1], primaryColor[2])
@@ -160,11 +165,13 @@ func (lr *loanReport) adminReportPDF() ([]byte, error) {
 			loan.ClientName,
 			loan.BranchName,
 			loan.LoanOfficer,
-			formatMoney(loan.LoanAmount),
-			formatMoney(loan.RepayAmount),
-			formatMoney(loan.PaidAmount),
-			formatMoney(loan.OutstandingAmount),
+			formatMoney(loan.LoanAmount.Float64()),
+			formatMoney(loan.RepayAmount.Float64()),
+			formatMoney(loan.PaidAmount.Float64()),
+			formatMoney(loan.OutstandingAmount.Float64()),
+			formatMoney(loan.PenaltyBalance.Float64()),
 			loan.Status,
+			formatYesNo(loan.Restructured),
 			loan.DueDate,
 			loan.TotalInstallments,
 			loan.PaidInstallments,
@@ -204,7 +211,9 @@ func (lr *loanReport) loanReportPDF() ([]byte, error) {
 		"LoanAmount": formatMoney(lr.userData.LoanAmount),
 		"RepayAmount": formatMoney(lr.userData.RepayAmount),
 		"PaidAmount": formatMoney(lr.userData.PaidAmount),
+		"PenaltyBalance": formatMoney(lr.userData.PenaltyBalance.Float64()),
 		"Status": lr.userData.Status,
+		"Restructured": formatYesNo(lr.userData.Restructured),
 		"TotalInstallments": formatQuantity(lr.userData.TotalInstallments),
 		"PaidInstallments": formatQuantity(lr.userData.PaidInstallments),
 		"RemainingInstallments": formatQuantity(lr.userData.RemainingInstallments),
@@ -238,6 +247,9 @@ func (lr *loanReport) loanReportPDF() ([]byte, error) {
 			if installment.Paid <= 0 {
 				paid = "UNPAID"
 			}
+			if installment.Closed > 0 {
+				paid = "CLOSED"
+			}
 			row := []interface{}{
 				installment.InstallmentNumber,
 				formatMoney(installment.InstallmentAmount),
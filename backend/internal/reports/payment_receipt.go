@@ -0,0 +1,158 @@
+package reports
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/services"
+	"github.com/EmilioCliff/kokomed-fin/backend/pkg"
+)
+
+type paymentReceipt struct {
+	*PDFGenerator
+	data services.PaymentReceiptData
+}
+
+func (r *ReportServiceImpl) GeneratePaymentReceipt(
+	ctx context.Context,
+	nonPostedID uint32,
+) ([]byte, error) {
+	data, err := r.store.NonPosted.GetPaymentReceiptData(ctx, nonPostedID)
+	if err != nil {
+		return nil, err
+	}
+
+	receipt := &paymentReceipt{
+		PDFGenerator: newPDFGenerator("P", "A4"),
+		data:         data,
+	}
+
+	return receipt.generatePDF()
+}
+
+func (pr *paymentReceipt) generatePDF() ([]byte, error) {
+	if err := pr.addLogo(); err != nil {
+		return nil, err
+	}
+
+	center := pr.getCenterX()
+	title := "Payment Receipt"
+
+	pr.pdf.SetFont(fontFamily, "B", largestFont)
+	w := pr.pdf.GetStringWidth(companyName)
+	pr.pdf.SetXY(center-w/2, marginY)
+	pr.pdf.Cell(0, lineHt, companyName)
+	pr.pdf.Ln(-1)
+
+	pr.pdf.SetFont(fontFamily, "B", largeFont)
+	w = pr.pdf.GetStringWidth(title)
+	pr.pdf.SetX(center - w/2)
+	pr.pdf.Cell(0, lineHt, title)
+	pr.pdf.Ln(-1)
+
+	receiptNo := fmt.Sprintf("Receipt No: %s", pr.data.ReceiptNumber)
+	pr.pdf.SetFont(fontFamily, "", subtitleFont)
+	w = pr.pdf.GetStringWidth(receiptNo)
+	pr.pdf.SetX(center - w/2)
+	pr.pdf.Cell(0, lineHt, receiptNo)
+	pr.pdf.Ln(8)
+
+	generatedOn := fmt.Sprintf("Generated on: %s", time.Now().Format("2006-01-02"))
+	w = pr.pdf.GetStringWidth(generatedOn)
+	pr.pdf.SetX(center - w/2)
+	pr.pdf.Cell(0, lineHt, generatedOn)
+	pr.pdf.Ln(lineHt * 3)
+
+	loan := "N/A"
+	if pr.data.LoanID != nil {
+		loan = fmt.Sprintf("LN%03d", *pr.data.LoanID)
+	}
+
+	rows := [][2]string{
+		{"Client", pr.data.ClientName},
+		{"Phone Number", pr.data.ClientPhone},
+		{"Transaction No", pr.data.TransactionNumber},
+		{"Source", pr.data.TransactionSource},
+		{"Paid By", pr.data.PayingName},
+		{"Paid On", formatTime(&pr.data.PaidDate)},
+		{"Amount", formatMoney(pr.data.Amount.Float64())},
+		{"Loan", loan},
+	}
+
+	pr.writeDetails(rows)
+	pr.pdf.Ln(lineHt)
+
+	if len(pr.data.Allocations) > 0 {
+		colWidths := []float64{50, 75, 75}
+		colAlignment := []string{"C", "C", "R"}
+
+		pr.pdf.SetFont(fontFamily, "B", mediumFont)
+		pr.pdf.SetX(marginX)
+		pr.pdf.SetFillColor(secondaryColor[0], secondaryColor[1], secondaryColor[2])
+		pr.writeTableHeaders([]string{"Installment", "Due Date", "Amount Paid"}, colWidths)
+
+		pr.pdf.SetFont(fontFamily, "", mediumFont)
+		pr.pdf.SetFillColor(primaryColor[0], primaryColor[1], primaryColor[2])
+		for _, allocation := range pr.data.Allocations {
+			pr.pdf.SetX(marginX)
+			pr.writeTableRow([]interface{}{
+				allocation.InstallmentNumber,
+				formatTime(&allocation.DueDate),
+				formatMoney(allocation.Amount.Float64()),
+			}, colWidths, colAlignment)
+		}
+
+		pr.pdf.Ln(lineHt)
+	}
+
+	summary := [][2]string{
+		{"Added to Overpayment", formatMoney(pr.data.Overpayment.Float64())},
+	}
+
+	if pr.data.LoanID != nil {
+		nextDue := "N/A"
+		if pr.data.NextDueDate != nil {
+			nextDue = fmt.Sprintf(
+				"%s (%s)",
+				formatTime(pr.data.NextDueDate),
+				formatMoney(pr.data.NextDueAmount.Float64()),
+			)
+		}
+
+		summary = append(summary,
+			[2]string{"Penalties Paid", formatMoney(pr.data.PenaltiesPaid.Float64())},
+			[2]string{"Remaining Balance", formatMoney(pr.data.LoanBalance.Float64())},
+			[2]string{"Next Due", nextDue},
+		)
+	}
+
+	pr.writeDetails(summary)
+
+	var buffer bytes.Buffer
+	if err := pr.pdf.Output(&buffer); err != nil {
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "failed to generate payment receipt")
+	}
+
+	pr.closePDF()
+
+	return buffer.Bytes(), nil
+}
+
+func (pr *paymentReceipt) writeDetails(rows [][2]string) {
+	colWidths := []float64{50, 150}
+
+	pr.pdf.SetFont(fontFamily, "", mediumFont)
+	for _, row := range rows {
+		pr.pdf.SetX(marginX)
+		pr.pdf.SetFillColor(secondaryAltColor[0], secondaryAltColor[1], secondaryAltColor[2])
+		pr.pdf.SetFontStyle("B")
+		pr.pdf.CellFormat(colWidths[0], lineHt, row[0], "1", 0, "L", true, 0, "")
+
+		pr.pdf.SetFillColor(primaryColor[0], primaryColor[1], primaryColor[2])
+		pr.pdf.SetFontStyle("")
+		pr.pdf.CellFormat(colWidths[1], lineHt, row[1], "1", 0, "L", true, 0, "")
+		pr.pdf.Ln(-1)
+	}
+}
@@ -48,7 +48,7 @@ func (pr *paymentReport) generateExcel(sheetName string) ([]byte, error) {
 		row := []interface{}{
 			payment.TransactionNumber,
 			payment.PayingName,
-			payment.Amount,
+			payment.Amount.Float64(),
 			payment.AccountNumber,
 			payment.TransactionSource,
 			payment.PaidDate,
@@ -89,7 +89,7 @@ func (pr *paymentReport) generatePDF() ([]byte, error) {
 
 	summary := map[string]string {
 		"TotalPayments": formatQuantity(pr.summary.TotalPayments), 
-		"TotalAmountReceived": formatMoney(pr.summary.TotalAmountReceived), 
+		"TotalAmountReceived": formatMoney(pr.summary.TotalAmountReceived.Float64()), 
 		"MostCommonSource": pr.summary.MostCommonSource, 
 		"MostAssignedStaff": pr.summary.MostAssignedStaff, 
 	}
@@ -114,7 +114,7 @@ func (pr *paymentReport) generatePDF() ([]byte, error) {
 		row := []interface{}{
 			payment.TransactionNumber,
 			payment.PayingName,
-			formatMoney(payment.Amount),
+			formatMoney(payment.Amount.Float64()),
 			payment.AccountNumber,
 			payment.TransactionSource,
 			paidDate,
@@ -0,0 +1,133 @@
+package reports
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/services"
+	"github.com/EmilioCliff/kokomed-fin/backend/pkg"
+)
+
+type refundVoucher struct {
+	*PDFGenerator
+	refund     services.OverpaymentRefund
+	clientName string
+	phone      string
+	requester  string
+	reviewer   string
+}
+
+func (r *ReportServiceImpl) GenerateRefundVoucher(
+	ctx context.Context,
+	refund services.OverpaymentRefund,
+) ([]byte, error) {
+	client, err := r.store.Clients.GetClientFullData(ctx, refund.ClientID)
+	if err != nil {
+		return nil, err
+	}
+
+	requester, err := r.store.Users.GetUserByID(ctx, refund.RequestedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	voucher := &refundVoucher{
+		PDFGenerator: newPDFGenerator("P", "A4"),
+		refund:       refund,
+		clientName:   client.FullName,
+		phone:        client.PhoneNumber,
+		requester:    requester.FullName,
+		reviewer:     "N/A",
+	}
+
+	if refund.ReviewedBy != nil {
+		reviewer, err := r.store.Users.GetUserByID(ctx, *refund.ReviewedBy)
+		if err != nil {
+			return nil, err
+		}
+
+		voucher.reviewer = reviewer.FullName
+	}
+
+	return voucher.generatePDF()
+}
+
+func (rv *refundVoucher) generatePDF() ([]byte, error) {
+	if err := rv.addLogo(); err != nil {
+		return nil, err
+	}
+
+	center := rv.getCenterX()
+	title := "Overpayment Refund Voucher"
+
+	rv.pdf.SetFont(fontFamily, "B", largestFont)
+	w := rv.pdf.GetStringWidth(companyName)
+	rv.pdf.SetXY(center-w/2, marginY)
+	rv.pdf.Cell(0, lineHt, companyName)
+	rv.pdf.Ln(-1)
+
+	rv.pdf.SetFont(fontFamily, "B", largeFont)
+	w = rv.pdf.GetStringWidth(title)
+	rv.pdf.SetX(center - w/2)
+	rv.pdf.Cell(0, lineHt, title)
+	rv.pdf.Ln(-1)
+
+	voucherNo := fmt.Sprintf("Voucher No: RF-%06d", rv.refund.ID)
+	rv.pdf.SetFont(fontFamily, "", subtitleFont)
+	w = rv.pdf.GetStringWidth(voucherNo)
+	rv.pdf.SetX(center - w/2)
+	rv.pdf.Cell(0, lineHt, voucherNo)
+	rv.pdf.Ln(8)
+
+	generatedOn := fmt.Sprintf("Generated on: %s", time.Now().Format("2006-01-02"))
+	w = rv.pdf.GetStringWidth(generatedOn)
+	rv.pdf.SetX(center - w/2)
+	rv.pdf.Cell(0, lineHt, generatedOn)
+	rv.pdf.Ln(lineHt * 3)
+
+	payoutReference := rv.refund.PayoutReference
+	if payoutReference == "" {
+		payoutReference = "N/A"
+	}
+
+	rows := [][2]string{
+		{"Client", rv.clientName},
+		{"Phone Number", rv.phone},
+		{"Amount", formatMoney(rv.refund.Amount.Float64())},
+		{"Reason", rv.refund.Reason},
+		{"Status", rv.refund.Status},
+		{"Payout Method", rv.refund.PayoutMethod},
+		{"Payout Reference", payoutReference},
+		{"Requested By", rv.requester},
+		{"Requested On", formatTime(&rv.refund.CreatedAt)},
+		{"Approved By", rv.reviewer},
+		{"Reviewed On", formatTime(rv.refund.ReviewedAt)},
+	}
+
+	colWidths := []float64{50, 150}
+	colAlignment := []string{"L", "L"}
+
+	rv.pdf.SetFont(fontFamily, "", mediumFont)
+	for _, row := range rows {
+		rv.pdf.SetX(marginX)
+		rv.pdf.SetFillColor(secondaryAltColor[0], secondaryAltColor[1], secondaryAltColor[2])
+		rv.pdf.SetFontStyle("B")
+		rv.pdf.CellFormat(colWidths[0], lineHt, row[0], "1", 0, colAlignment[0], true, 0, "")
+
+		rv.pdf.SetFillColor(primaryColor[0], primaryColor[1], primaryColor[2])
+		rv.pdf.SetFontStyle("")
+		rv.pdf.CellFormat(colWidths[1], lineHt, row[1], "1", 0, colAlignment[1], true, 0, "")
+		rv.pdf.Ln(-1)
+	}
+
+	var buffer bytes.Buffer
+	if err := rv.pdf.Output(&buffer); err != nil {
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "failed to generate refund voucher")
+	}
+
+	rv.closePDF()
+
+	return buffer.Bytes(), nil
+}
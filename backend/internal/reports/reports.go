@@ -183,6 +183,13 @@ func formatQuantity(quantity int64) string {
 	return fmt.Sprintf("%d", quantity)
 }
 
+func formatYesNo(value bool) string {
+	if value {
+		return "Yes"
+	}
+	return "No"
+}
+
 func formatTime(t *time.Time) string {
 	if t == nil || t.IsZero() {
 		return "N/A" 
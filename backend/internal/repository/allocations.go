@@ -1,13 +1,18 @@
 package repository
 
-import "time"
+import (
+	"time"
+
+	"github.com/EmilioCliff/kokomed-fin/backend/pkg"
+)
 
 type PaymentAllocation struct {
 	ID                 uint32     `json:"id"`
 	NonPostedID        uint32     `json:"nonPostedId"`
 	LoanID             *uint32    `json:"loanId"`
 	InstallmentID      *uint32    `json:"installmentId"`
-	Amount             float64    `json:"amount"`
+	PenaltyID          *uint32    `json:"penaltyId"`
+	Amount             pkg.Money  `json:"amount"`
 	Description        string     `json:"description"`
 	DeletedAt          *time.Time `json:"deletedAt"`
 	DeletedDescription *string    `json:"deletedDescription"`
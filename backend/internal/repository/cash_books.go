@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/EmilioCliff/kokomed-fin/backend/pkg"
+)
+
+type CashReceipt struct {
+	PaymentID         uint32    `json:"paymentId"`
+	TransactionNumber string    `json:"transactionNumber"`
+	PayingName        string    `json:"payingName"`
+	Amount            pkg.Money `json:"amount"`
+	PaidDate          time.Time `json:"paidDate"`
+	ReceivedBy        string    `json:"receivedBy"`
+}
+
+type CashDisbursement struct {
+	LoanID      uint32    `json:"loanId"`
+	ClientName  string    `json:"clientName"`
+	Amount      pkg.Money `json:"amount"`
+	DisbursedOn time.Time `json:"disbursedOn"`
+	DisbursedBy string    `json:"disbursedBy"`
+}
+
+type BranchExpense struct {
+	ID          uint32    `json:"id"`
+	BranchID    uint32    `json:"branchId"`
+	ExpenseDate time.Time `json:"expenseDate"`
+	Amount      pkg.Money `json:"amount"`
+	Description string    `json:"description"`
+	CreatedBy   uint32    `json:"createdBy"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+type CashBookClose struct {
+	ID              uint32    `json:"id"`
+	BusinessDate    time.Time `json:"businessDate"`
+	ExpectedClosing pkg.Money `json:"expectedClosing"`
+	CountedCash     pkg.Money `json:"countedCash"`
+	Variance        pkg.Money `json:"variance"`
+	ClosedBy        uint32    `json:"closedBy"`
+	ClosedAt        time.Time `json:"closedAt"`
+}
+
+// CashBook is a branch's till for one business day. The opening balance is the cash counted
+// at the last close plus whatever moved on days left open since.
+type CashBook struct {
+	BranchID       uint32             `json:"branchId"`
+	Date           time.Time          `json:"date"`
+	OpeningBalance pkg.Money          `json:"openingBalance"`
+	Receipts       []CashReceipt      `json:"receipts"`
+	Disbursements  []CashDisbursement `json:"disbursements"`
+	Expenses       []BranchExpense    `json:"expenses"`
+	TotalReceived  pkg.Money          `json:"totalReceived"`
+	TotalDisbursed pkg.Money          `json:"totalDisbursed"`
+	TotalExpenses  pkg.Money          `json:"totalExpenses"`
+	ClosingBalance pkg.Money          `json:"closingBalance"`
+	Closed         bool               `json:"closed"`
+	Close          *CashBookClose     `json:"close"`
+}
+
+type CloseCashBook struct {
+	BranchID    uint32
+	Date        time.Time
+	CountedCash pkg.Money
+	Note        string
+	ClosedBy    uint32
+}
+
+type CashVariance struct {
+	ID           uint32    `json:"id"`
+	CloseID      uint32    `json:"closeId"`
+	BranchID     uint32    `json:"branchId"`
+	BusinessDate time.Time `json:"businessDate"`
+	ExpectedCash pkg.Money `json:"expectedCash"`
+	CountedCash  pkg.Money `json:"countedCash"`
+	Variance     pkg.Money `json:"variance"`
+	Note         string    `json:"note"`
+	CreatedBy    uint32    `json:"createdBy"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+type CashBookRepository interface {
+	GetCashBook(ctx context.Context, branchID uint32, date time.Time) (CashBook, error)
+	CreateExpense(ctx context.Context, expense *BranchExpense) (BranchExpense, error)
+	CloseCashBook(ctx context.Context, closeData *CloseCashBook) (CashBook, error)
+	ListCashVariances(
+		ctx context.Context,
+		branchID uint32,
+		pgData *pkg.PaginationMetadata,
+	) ([]CashVariance, pkg.PaginationMetadata, error)
+}
@@ -18,13 +18,13 @@ type Client struct {
 	Active        bool       `json:"active"`
 	BranchID      uint32     `json:"branch_id"`
 	AssignedStaff uint32     `json:"assigned_staff"`
-	Overpayment   float64    `json:"overpayment"`
+	Overpayment   pkg.Money  `json:"overpayment"`
 	UpdatedBy     uint32     `json:"updated_by"`
 	UpdatedAt     time.Time  `json:"updated_at"`
 	CreatedBy     uint32     `json:"created_by"`
 	CreatedAt     time.Time  `json:"created_at"`
 	BranchName    *string    `json:"branch_name"`
-	DueAmount     float64    `json:"due_amount"`
+	DueAmount     pkg.Money  `json:"due_amount"`
 }
 
 type UpdateClient struct {
@@ -55,10 +55,10 @@ type ClientShort struct {
 	Gender        string            `json:"gender"`
 	IdNumber      string            `json:"idNumber"`
 	AssignedStaff UserShortResponse `json:"assignedStaff"`
-	Overpayment   float64           `json:"overpayment"`
+	Overpayment   pkg.Money         `json:"overpayment"`
 	BranchName    string            `json:"branchName"`
 	BranchID      uint32            `json:"branchId"`
-	DueAmount     float64           `json:"dueAmount"`
+	DueAmount     pkg.Money         `json:"dueAmount"`
 }
 
 type ClientFullData struct {
@@ -71,18 +71,26 @@ type ClientFullData struct {
 	Active        bool              `json:"active"`
 	BranchName    string            `json:"branchName"`
 	AssignedStaff UserShortResponse `json:"assignedStaff"`
-	Overpayment   float64           `json:"overpayment"`
-	DueAmount     float64           `json:"dueAmount"`
+	Overpayment   pkg.Money         `json:"overpayment"`
+	DueAmount     pkg.Money         `json:"dueAmount"`
 	CreatedAt     time.Time         `json:"createdAt"`
 	UpdatedAt     time.Time         `json:"updatedAt"`
 	CreatedBy     UserShortResponse `json:"createdBy"`
 	UpdatedBy     UserShortResponse `json:"updatedBy,omitempty"`
 }
 
+type BlacklistedClient struct {
+	ID        uint32    `json:"id"`
+	ClientID  uint32    `json:"clientId"`
+	Reason    string    `json:"reason"`
+	CreatedBy string    `json:"createdBy"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
 type ClientRepository interface {
 	CreateClient(ctx context.Context, client *Client) (ClientFullData, error)
 	UpdateClient(ctx context.Context, client *UpdateClient) error
-	UpdateClientOverpayment(ctx context.Context, phoneNumber string, overpayment float64) error
+	UpdateClientOverpayment(ctx context.Context, phoneNumber string, overpayment pkg.Money) error
 	ListClients(
 		ctx context.Context,
 		category *ClientCategorySearch,
@@ -102,6 +110,10 @@ type ClientRepository interface {
 		pgData *pkg.PaginationMetadata,
 	) ([]Client, error)
 
+	BlacklistClient(ctx context.Context, blacklist *BlacklistedClient) (BlacklistedClient, error)
+	GetBlacklistedClient(ctx context.Context, clientID uint32) (BlacklistedClient, error)
+	RemoveBlacklistedClient(ctx context.Context, clientID uint32) error
+
 	GetReportClientAdminData(
 		ctx context.Context,
 		filters services.ReportFilters,
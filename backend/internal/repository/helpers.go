@@ -23,8 +23,8 @@ type ClientDashboardResponse struct {
 	Active        bool                  `json:"active"`
 	BranchName    string                `json:"branch_name"`
 	AssignedStaff UserDashboardResponse `json:"assigned_staff"`
-	Overpayment   float64               `json:"overpayment"`
-	DueAmount     float64               `json:"due_amount"`
+	Overpayment   pkg.Money             `json:"overpayment"`
+	DueAmount     pkg.Money             `json:"due_amount"`
 	CreatedBy     UserDashboardResponse `json:"created_by"`
 	CreatedAt     time.Time             `json:"created_at"`
 }
@@ -42,8 +42,8 @@ type UserDashboardResponse struct {
 
 type InactiveLoan struct {
 	ID             uint32                  `json:"id"`
-	Amount         float64                 `json:"amount"`
-	RepayAmount    float64                 `json:"repayAmount"`
+	Amount         pkg.Money               `json:"amount"`
+	RepayAmount    pkg.Money               `json:"repayAmount"`
 	ClientName     string                  `json:"clientName"`
 	ApprovedByName string                  `json:"approvedByName"`
 	Client         ClientDashboardResponse `json:"client"`
@@ -59,18 +59,18 @@ type InactiveLoan struct {
 type Payment struct {
 	ID         uint32    `json:"id"`
 	PayingName string    `json:"payingName"`
-	Amount     float64   `json:"amount"`
+	Amount     pkg.Money `json:"amount"`
 	PaidDate   time.Time `json:"paidDate"`
 }
 
 type Widget struct {
-	Title       string  `json:"title"`
-	MainAmount  float64 `json:"mainAmount"`
-	Active      float64 `json:"active"`
-	ActiveTitle string  `json:"activeTitle"`
-	Closed      float64 `json:"closed"`
-	ClosedTitle string  `json:"closedTitle"`
-	Currency    string  `json:"currency"`
+	Title       string    `json:"title"`
+	MainAmount  pkg.Money `json:"mainAmount"`
+	Active      pkg.Money `json:"active"`
+	ActiveTitle string    `json:"activeTitle"`
+	Closed      pkg.Money `json:"closed"`
+	ClosedTitle string    `json:"closedTitle"`
+	Currency    string    `json:"currency"`
 }
 
 type ProductData struct {
@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/EmilioCliff/kokomed-fin/backend/pkg"
+)
+
+// chart of accounts, seeded by the general ledger migration.
+const (
+	AccountCash                = "1000"
+	AccountLoansReceivable     = "1100"
+	AccountPenaltiesReceivable = "1200"
+	AccountUnallocatedPayments = "2000"
+	AccountClientOverpayments  = "2100"
+	AccountInterestIncome      = "4000"
+	AccountFeeIncome           = "4100"
+	AccountPenaltyIncome       = "4200"
+	AccountOperatingExpenses   = "5000"
+	AccountCashOverShort       = "5100"
+)
+
+const (
+	JournalDisbursement            = "DISBURSEMENT"
+	JournalProcessingFee           = "PROCESSING_FEE"
+	JournalPaymentReceived         = "PAYMENT_RECEIVED"
+	JournalPaymentAdjusted         = "PAYMENT_ADJUSTED"
+	JournalPaymentAllocated        = "PAYMENT_ALLOCATED"
+	JournalPaymentReversed         = "PAYMENT_REVERSED"
+	JournalPaymentDeleted          = "PAYMENT_DELETED"
+	JournalOverpaymentApplied      = "OVERPAYMENT_APPLIED"
+	JournalOverpaymentRefund       = "OVERPAYMENT_REFUND"
+	JournalOverpaymentRefundFailed = "OVERPAYMENT_REFUND_FAILED"
+	JournalExpense                 = "EXPENSE"
+	JournalCashVariance            = "CASH_VARIANCE"
+	JournalPenaltyAccrued          = "PENALTY_ACCRUED"
+	JournalLoanRefinanced          = "LOAN_REFINANCED"
+	JournalInterestRebate          = "INTEREST_REBATE"
+)
+
+type LedgerAccount struct {
+	ID          uint32    `json:"id"`
+	Code        string    `json:"code"`
+	Name        string    `json:"name"`
+	AccountType string    `json:"accountType"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+type JournalEntry struct {
+	ID          uint32        `json:"id"`
+	EntryType   string        `json:"entryType"`
+	Description string        `json:"description"`
+	LoanID      *uint32       `json:"loanId"`
+	ClientID    *uint32       `json:"clientId"`
+	NonPostedID *uint32       `json:"nonPostedId"`
+	CreatedBy   string        `json:"createdBy"`
+	CreatedAt   time.Time     `json:"createdAt"`
+	Lines       []JournalLine `json:"lines"`
+}
+
+type JournalLine struct {
+	AccountCode string    `json:"accountCode"`
+	Debit       pkg.Money `json:"debit"`
+	Credit      pkg.Money `json:"credit"`
+}
+
+type TrialBalanceAccount struct {
+	Code        string    `json:"code"`
+	Name        string    `json:"name"`
+	AccountType string    `json:"accountType"`
+	Debit       pkg.Money `json:"debit"`
+	Credit      pkg.Money `json:"credit"`
+	Balance     pkg.Money `json:"balance"`
+}
+
+type TrialBalance struct {
+	AsOf        time.Time             `json:"asOf"`
+	Accounts    []TrialBalanceAccount `json:"accounts"`
+	TotalDebit  pkg.Money             `json:"totalDebit"`
+	TotalCredit pkg.Money             `json:"totalCredit"`
+	Balanced    bool                  `json:"balanced"`
+}
+
+type AccountLedgerEntry struct {
+	EntryID     uint32    `json:"entryId"`
+	EntryType   string    `json:"entryType"`
+	Description string    `json:"description"`
+	LoanID      *uint32   `json:"loanId"`
+	ClientID    *uint32   `json:"clientId"`
+	NonPostedID *uint32   `json:"nonPostedId"`
+	CreatedBy   string    `json:"createdBy"`
+	Debit       pkg.Money `json:"debit"`
+	Credit      pkg.Money `json:"credit"`
+	Balance     pkg.Money `json:"balance"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// Transfer moves an amount from the credited account to the debited one. A negative amount
+// moves it the other way.
+func Transfer(debitAccount, creditAccount string, amount pkg.Money) []JournalLine {
+	if amount < 0 {
+		debitAccount, creditAccount, amount = creditAccount, debitAccount, -amount
+	}
+
+	return []JournalLine{
+		{AccountCode: debitAccount, Debit: amount},
+		{AccountCode: creditAccount, Credit: amount},
+	}
+}
+
+// PaymentFundingAccount is the account a payment's money came from. Applied overpayments are
+// drawn from the client's overpayment balance, everything else was received in cash or M-Pesa.
+func PaymentFundingAccount(transactionNumber string) string {
+	if strings.HasPrefix(transactionNumber, OverpaymentApplicationPrefix) {
+		return AccountClientOverpayments
+	}
+
+	return AccountCash
+}
+
+// DebitNormal reports whether an account type's balance grows with debits.
+func DebitNormal(accountType string) bool {
+	return accountType == "ASSET" || accountType == "EXPENSE"
+}
+
+type LedgerRepository interface {
+	ListAccounts(ctx context.Context) ([]LedgerAccount, error)
+	GetTrialBalance(ctx context.Context, asOf time.Time) (TrialBalance, error)
+	GetAccountLedger(
+		ctx context.Context,
+		code string,
+		from, to time.Time,
+		pgData *pkg.PaginationMetadata,
+	) ([]AccountLedgerEntry, pkg.PaginationMetadata, error)
+}
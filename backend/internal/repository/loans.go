@@ -21,12 +21,13 @@ type Loan struct {
 	TotalInstallments  uint32     `json:"total_installments"`
 	InstallmentsPeriod uint32     `json:"installments_period"`
 	Status             string     `json:"status"`
-	ProcessingFee      float64    `json:"processing_fee"`
+	ProcessingFee      pkg.Money  `json:"processing_fee"`
 	FeePaid            bool       `json:"fee_paid"`
-	PaidAmount         float64    `json:"paid_amount"`
+	PaidAmount         pkg.Money  `json:"paid_amount"`
 	UpdatedBy          *uint32    `json:"updated_by"`
 	CreatedBy          uint32     `json:"created_by"`
 	CreatedAt          time.Time  `json:"created_at"`
+	UseOverpayment     bool       `json:"use_overpayment"`
 }
 
 type LoanFullData struct {
@@ -37,10 +38,10 @@ type LoanFullData struct {
 	TotalInstallments  uint32            `json:"totalInstallments"`
 	InstallmentsPeriod uint32            `json:"installmentsPeriod"`
 	Status             string            `json:"status"`
-	ProcessingFee      float64           `json:"processingFee"`
+	ProcessingFee      pkg.Money         `json:"processingFee"`
 	FeePaid            bool              `json:"feePaid"`
-	PaidAmount         float64           `json:"paidAmount"`
-	RemainingAmount    float64           `json:"remainingAmount"`
+	PaidAmount         pkg.Money         `json:"paidAmount"`
+	RemainingAmount    pkg.Money         `json:"remainingAmount"`
 	CreatedAt          time.Time         `json:"createdAt"`
 	Product            ProductShort      `json:"product"`
 	Client             ClientShort       `json:"client"`
@@ -52,33 +53,38 @@ type LoanFullData struct {
 }
 
 type DisburseLoan struct {
-	ID          uint32     `json:"id"`
-	DisbursedBy uint32     `json:"disbursedBy"`
-	Status      *string    `json:"status"`
-	FeePaid     *bool      `json:"feePaid"`
-	DisbursedOn *time.Time `json:"disbursedOn"`
+	ID             uint32     `json:"id"`
+	DisbursedBy    uint32     `json:"disbursedBy"`
+	Status         *string    `json:"status"`
+	FeePaid        *bool      `json:"feePaid"`
+	DisbursedOn    *time.Time `json:"disbursedOn"`
+	UseOverpayment bool       `json:"useOverpayment"`
 }
 
 type UpdateLoan struct {
-	ID         uint32  `json:"id"`
-	PaidAmount float64 `json:"paid_amount"`
-	UpdatedBy  *uint32 `json:"updated_by"`
+	ID         uint32    `json:"id"`
+	PaidAmount pkg.Money `json:"paid_amount"`
+	UpdatedBy  *uint32   `json:"updated_by"`
 }
 
 type Installment struct {
-	ID              uint32  `json:"id"`
-	LoanID          uint32  `json:"loanId"`
-	InstallmentNo   uint32  `json:"installmentNo"`
-	Amount          float64 `json:"amount"`
-	RemainingAmount float64 `json:"remainingAmount"`
-	Paid            bool    `json:"paid"`
-	PaidAt          string  `json:"paidAt"`
-	DueDate         string  `json:"dueDate"`
+	ID              uint32    `json:"id"`
+	LoanID          uint32    `json:"loanId"`
+	InstallmentNo   uint32    `json:"installmentNo"`
+	Amount          pkg.Money `json:"amount"`
+	RemainingAmount pkg.Money `json:"remainingAmount"`
+	Paid            bool      `json:"paid"`
+	PaidAt          string    `json:"paidAt"`
+	DueDate         string    `json:"dueDate"`
+	// Closed installments were replaced by a restructure or rebated on an early settlement and
+	// are kept as history
+	Closed        bool    `json:"closed"`
+	RestructureID *uint32 `json:"restructureId"`
 }
 
 type UpdateInstallment struct {
 	ID              uint32     `json:"id"`
-	RemainingAmount float64    `json:"remaining_amount"`
+	RemainingAmount pkg.Money  `json:"remaining_amount"`
 	Paid            *bool      `json:"paid"`
 	PaidAt          *time.Time `json:"paid_at"`
 }
@@ -90,53 +96,144 @@ type Category struct {
 }
 
 type LoanEvent struct {
-	ID         string   `json:"id"`
-	LoanID     uint32   `json:"loanId"`
-	ClientName string   `json:"clientName"`
-	LoanAmount float64  `json:"loanAmount"`
-	Date       *string  `json:"date"`
-	PaymentDue *float64 `json:"paymentDue,omitempty"`
-	Type       string   `json:"type"`
-	AllDay     bool     `json:"allDay"`
-	Title      string   `json:"title"`
+	ID         string     `json:"id"`
+	LoanID     uint32     `json:"loanId"`
+	ClientName string     `json:"clientName"`
+	LoanAmount pkg.Money  `json:"loanAmount"`
+	Date       *string    `json:"date"`
+	PaymentDue *pkg.Money `json:"paymentDue,omitempty"`
+	Type       string     `json:"type"`
+	AllDay     bool       `json:"allDay"`
+	Title      string     `json:"title"`
 }
 
 type ExpectedPayment struct {
-	LoanId          uint32  `json:"loanId"`
-	BranchName      string  `json:"branchName"`
-	ClientName      string  `json:"clientName"`
-	LoanOfficerName string  `json:"loanOfficerName"`
-	LoanAmount      float64 `json:"loanAmount"`
-	RepayAmount     float64 `json:"repayAmount"`
-	TotalUnpaid     float64 `json:"totalUnpaid"`
-	DueDate         string  `json:"dueDate"`
+	LoanId          uint32    `json:"loanId"`
+	BranchName      string    `json:"branchName"`
+	ClientName      string    `json:"clientName"`
+	LoanOfficerName string    `json:"loanOfficerName"`
+	LoanAmount      pkg.Money `json:"loanAmount"`
+	RepayAmount     pkg.Money `json:"repayAmount"`
+	TotalUnpaid     pkg.Money `json:"totalUnpaid"`
+	DueDate         string    `json:"dueDate"`
 }
 
 type LoanShort struct {
 	ID            uint32              `json:"id"`
 	Status        string              `json:"status"`
-	LoanAmount    float64             `json:"loanAmount"`
-	RepayAmount   float64             `json:"repayAmount"`
+	LoanAmount    pkg.Money           `json:"loanAmount"`
+	RepayAmount   pkg.Money           `json:"repayAmount"`
 	DisbursedOn   string              `json:"disbursedOn"`
 	DueDate       string              `json:"dueDate"`
-	PaidAmount    float64             `json:"paidAmount"`
+	PaidAmount    pkg.Money           `json:"paidAmount"`
 	ClientDetails ClientShort         `json:"clientDetails"`
 	Installments  []Installment       `json:"installments"`
 	Payments      []PaymentAllocation `json:"paymentAllocations"`
 	NonPosted     []NonPostedShort    `json:"nonPosted"`
+	Penalties     []Penalty           `json:"penalties"`
+	// PenaltyBalance is what is still owed on the loan's penalties
+	PenaltyBalance pkg.Money         `json:"penaltyBalance"`
+	Restructures   []LoanRestructure `json:"restructures"`
+	// TopUp is set on a loan that refinanced an older one, RefinancedBy on the loan it settled
+	TopUp        *LoanTopUp `json:"topUp"`
+	RefinancedBy *LoanTopUp `json:"refinancedBy"`
+}
+
+// RestructureLoan spreads what is left on an active loan over a new schedule.
+type RestructureLoan struct {
+	LoanID             uint32 `json:"loanId"`
+	TotalInstallments  uint32 `json:"totalInstallments"`
+	InstallmentsPeriod uint32 `json:"installmentsPeriod"`
+	Reason             string `json:"reason"`
+	ApprovedBy         uint32 `json:"approvedBy"`
+}
+
+// LoanRestructure records a restructure with the schedule it replaced.
+type LoanRestructure struct {
+	ID                         uint32    `json:"id"`
+	LoanID                     uint32    `json:"loanId"`
+	OutstandingAmount          pkg.Money `json:"outstandingAmount"`
+	PreviousTotalInstallments  uint32    `json:"previousTotalInstallments"`
+	PreviousInstallmentsPeriod uint32    `json:"previousInstallmentsPeriod"`
+	PreviousDueDate            string    `json:"previousDueDate"`
+	TotalInstallments          uint32    `json:"totalInstallments"`
+	InstallmentsPeriod         uint32    `json:"installmentsPeriod"`
+	Reason                     string    `json:"reason"`
+	ApprovedBy                 uint32    `json:"approvedBy"`
+	CreatedAt                  time.Time `json:"createdAt"`
+}
+
+// TopUpSettlementPrefix starts the transaction number of the internal payment that settles a
+// refinanced loan out of the principal of the loan that topped it up.
+const TopUpSettlementPrefix = "TOPUP-"
+
+// EarlySettlementPrefix starts the transaction number of the internal payment taken when a loan
+// is settled early on a quote.
+const EarlySettlementPrefix = "SETTLEMENT-"
+
+// TopUpLoan replaces an active loan with a new disbursed loan for the same client. The new
+// loan's principal settles what is left on the old one and only the rest is paid out.
+type TopUpLoan struct {
+	LoanID  uint32 `json:"loanId"`
+	NewLoan Loan   `json:"newLoan"`
+}
+
+// LoanTopUp records a top-up with what it settled on the refinanced loan.
+type LoanTopUp struct {
+	ID               uint32        `json:"id"`
+	LoanID           uint32        `json:"loanId"`
+	RefinancedLoanID uint32        `json:"refinancedLoanId"`
+	NonPostedID      uint32        `json:"nonPostedId"`
+	SettledAmount    pkg.Money     `json:"settledAmount"`
+	PenaltiesSettled pkg.Money     `json:"penaltiesSettled"`
+	NetDisbursed     pkg.Money     `json:"netDisbursed"`
+	CreatedBy        uint32        `json:"createdBy"`
+	CreatedAt        time.Time     `json:"createdAt"`
+	Loan             *LoanFullData `json:"loan,omitempty"`
+}
+
+// Penalty is a late payment charge accrued on an overdue installment.
+type Penalty struct {
+	ID              uint32    `json:"id"`
+	LoanID          uint32    `json:"loanId"`
+	InstallmentID   uint32    `json:"installmentId"`
+	AccruedOn       string    `json:"accruedOn"`
+	Periods         uint32    `json:"periods"`
+	Amount          pkg.Money `json:"amount"`
+	RemainingAmount pkg.Money `json:"remainingAmount"`
+	Paid            bool      `json:"paid"`
+	PaidAt          string    `json:"paidAt"`
+}
+
+// PenaltyAccrual sums up a penalty accrual run.
+type PenaltyAccrual struct {
+	Installments int       `json:"installments"`
+	Amount       pkg.Money `json:"amount"`
 }
 type UnpaidInstallmentData struct {
-	InstallmentNumber uint32  `json:"installmentNumber"`
-	RemainingAmount   float64 `json:"remainingAmount"`
-	DueDate           string  `json:"dueDate"`
-	LoanOfficer       string  `json:"loanOfficer"`
-	LoanId            uint32  `json:"loanId"`
-	ProductName       string  `json:"productName"`
-	ClientId          uint32  `json:"clientId"`
-	FullName          string  `json:"fullName"`
-	PhoneNumber       string  `json:"phoneNumber"`
-	ClientBranch      string  `json:"clientBranch"`
-	TotalDueAmount    float64 `json:"totalDueAmount"`
+	InstallmentNumber uint32    `json:"installmentNumber"`
+	RemainingAmount   pkg.Money `json:"remainingAmount"`
+	DueDate           string    `json:"dueDate"`
+	LoanOfficer       string    `json:"loanOfficer"`
+	LoanId            uint32    `json:"loanId"`
+	ProductName       string    `json:"productName"`
+	ClientId          uint32    `json:"clientId"`
+	FullName          string    `json:"fullName"`
+	PhoneNumber       string    `json:"phoneNumber"`
+	ClientBranch      string    `json:"clientBranch"`
+	TotalDueAmount    pkg.Money `json:"totalDueAmount"`
+}
+
+// DefaultedLoan is a loan the overdue job moved to DEFAULTED, with what its officer is told.
+type DefaultedLoan struct {
+	LoanID       uint32    `json:"loanId"`
+	ClientID     uint32    `json:"clientId"`
+	ClientName   string    `json:"clientName"`
+	LoanOfficer  uint32    `json:"loanOfficer"`
+	OfficerName  string    `json:"officerName"`
+	OfficerEmail string    `json:"officerEmail"`
+	DueDate      time.Time `json:"dueDate"`
+	Arrears      pkg.Money `json:"arrears"`
 }
 
 type LoansRepository interface {
@@ -146,6 +243,15 @@ type LoansRepository interface {
 	TransferLoan(ctx context.Context, officerId uint32, loanId uint32, adminId uint32) error
 	GetLoanByID(ctx context.Context, id uint32) (Loan, error)
 	GetClientActiceLoan(ctx context.Context, clientID uint32) (uint32, error)
+	GetClientPayableLoan(ctx context.Context, clientID uint32) (uint32, error)
+	MarkDefaultedLoans(
+		ctx context.Context,
+		asOf time.Time,
+		defaultGraceDays uint32,
+	) ([]DefaultedLoan, error)
+	AccrueLoanPenalties(ctx context.Context, asOf time.Time) (PenaltyAccrual, error)
+	RestructureLoan(ctx context.Context, restructure *RestructureLoan) (LoanRestructure, error)
+	TopUpLoan(ctx context.Context, topUp *TopUpLoan) (LoanTopUp, error)
 	GetLoanStatus(ctx context.Context, id uint32) (string, error)
 	GetClientLoans(
 		ctx context.Context,
@@ -165,8 +271,6 @@ type LoansRepository interface {
 		pgData *pkg.PaginationMetadata,
 	) ([]ExpectedPayment, pkg.PaginationMetadata, error)
 
-	// use client overpayment to pay loan
-
 	GetLoanInstallments(ctx context.Context, id uint32) ([]Installment, error)
 	GetInstallment(ctx context.Context, id uint32) (Installment, error)
 	UpdateInstallment(ctx context.Context, installment *UpdateInstallment) (Installment, error)
@@ -9,21 +9,58 @@ import (
 )
 
 type NonPosted struct {
-	ID                 uint32      `json:"id"`
-	TransactionSource  string      `json:"transactionSource"`
-	TransactionNumber  string      `json:"transactionNumber"`
-	AccountNumber      string      `json:"accountNumber"`
-	PhoneNumber        string      `json:"phoneNumber"`
-	PayingName         string      `json:"payingName"`
-	Amount             float64     `json:"amount"`
-	PaidDate           time.Time   `json:"paidDate"`
-	AssignedTo         *uint32     `json:"assignedClient,omitempty"`
-	AssignedBy         string      `json:"assignedBy"`
+	ID                uint32    `json:"id"`
+	TransactionSource string    `json:"transactionSource"`
+	TransactionNumber string    `json:"transactionNumber"`
+	AccountNumber     string    `json:"accountNumber"`
+	PhoneNumber       string    `json:"phoneNumber"`
+	PayingName        string    `json:"payingName"`
+	Amount            pkg.Money `json:"amount"`
+	PaidDate          time.Time `json:"paidDate"`
+	AssignedTo        *uint32   `json:"assignedClient,omitempty"`
+	AssignedBy        string    `json:"assignedBy"`
+	// CashReceipt is set on payments taken over a branch counter, they are the branch's cash
+	CashReceipt        bool        `json:"cashReceipt"`
 	DeletedAt          *time.Time  `json:"deletedAt,omitempty"`
 	DeletedDescription *string     `json:"deletedDescription,omitempty"`
 	AssignedClient     ClientShort `json:"assignedTo,omitempty"`
 }
 
+// ProcessedCallback records the first time a transaction was received so that
+// replayed callbacks can be answered without processing the payment again.
+type ProcessedCallback struct {
+	ID                uint32    `json:"id"`
+	TransactionNumber string    `json:"transactionNumber"`
+	TransactionSource string    `json:"transactionSource"`
+	NonPostedID       uint32    `json:"nonPostedId"`
+	LoanID            *uint32   `json:"loanId,omitempty"`
+	CreatedAt         time.Time `json:"createdAt"`
+}
+
+// PaymentValidationLog is the decision taken on a Daraja validation request.
+type PaymentValidationLog struct {
+	ID                uint32    `json:"id"`
+	TransactionNumber string    `json:"transactionNumber"`
+	AccountNumber     string    `json:"accountNumber"`
+	PhoneNumber       string    `json:"phoneNumber"`
+	PayingName        string    `json:"payingName"`
+	Amount            pkg.Money `json:"amount"`
+	ClientID          *uint32   `json:"clientId,omitempty"`
+	Accepted          bool      `json:"accepted"`
+	ResultCode        string    `json:"resultCode"`
+	Reason            string    `json:"reason"`
+	CreatedAt         time.Time `json:"createdAt"`
+}
+
+// CallbackPayload is the raw body of a payment callback kept for audit.
+type CallbackPayload struct {
+	ID                uint32    `json:"id"`
+	TransactionNumber string    `json:"transactionNumber"`
+	CallbackType      string    `json:"callbackType"`
+	Payload           string    `json:"payload"`
+	CreatedAt         time.Time `json:"createdAt"`
+}
+
 type NonPostedCategory struct {
 	Search  *string `json:"search"`
 	Sources *string `json:"sources"`
@@ -33,7 +70,7 @@ type ClientNonPosted struct {
 	ClientDetails  ClientShort      `json:"clientDetails"`
 	PaymentDetails []NonPostedShort `json:"paymentDetails"`
 	LoanDetails    LoanShort        `json:"loanShort"`
-	TotalPaid      float64          `json:"totalPaid"`
+	TotalPaid      pkg.Money        `json:"totalPaid"`
 }
 
 type NonPostedRepository interface {
@@ -56,6 +93,19 @@ type NonPostedRepository interface {
 		pgData *pkg.PaginationMetadata,
 	) ([]NonPosted, error)
 	DeleteNonPosted(ctx context.Context, id uint32, description string) error
+	GetProcessedCallback(
+		ctx context.Context,
+		transactionNumber string,
+		transactionSource string,
+	) (ProcessedCallback, error)
+	ListDuplicateNonPosted(ctx context.Context) ([]NonPosted, error)
+	CreatePaymentValidationLog(ctx context.Context, log *PaymentValidationLog) error
+	CreateCallbackPayload(ctx context.Context, payload *CallbackPayload) error
+	ListCallbackPayloads(ctx context.Context, transactionNumber string) ([]CallbackPayload, error)
+	ListPaymentValidationLogs(
+		ctx context.Context,
+		pgData *pkg.PaginationMetadata,
+	) ([]PaymentValidationLog, pkg.PaginationMetadata, error)
 	GetClientNonPosted(
 		ctx context.Context,
 		id uint32,
@@ -67,6 +117,7 @@ type NonPostedRepository interface {
 		ctx context.Context,
 		filters services.ReportFilters,
 	) ([]services.PaymentReportData, services.PaymentSummary, error)
+	GetPaymentReceiptData(ctx context.Context, id uint32) (services.PaymentReceiptData, error)
 }
 
 type NonPostedShort struct {
@@ -76,7 +127,7 @@ type NonPostedShort struct {
 	AccountNumber     string    `json:"accountNumber"`
 	PhoneNumber       string    `json:"phoneNumber"`
 	PayingName        string    `json:"payingName"`
-	Amount            float64   `json:"amount"`
+	Amount            pkg.Money `json:"amount"`
 	PaidDate          time.Time `json:"paidDate"`
 	AssignedBy        string    `json:"assignedBy"`
 }
@@ -1,12 +1,20 @@
 package repository
 
-import "time"
+import (
+	"time"
+
+	"github.com/EmilioCliff/kokomed-fin/backend/pkg"
+)
+
+// OverpaymentApplicationPrefix starts the transaction number of the internal payment created
+// when a client's overpayment is drawn down into a new loan.
+const OverpaymentApplicationPrefix = "OVERPAYMENT-"
 
 type Overpayment struct {
 	ID          uint32    `json:"id"`
 	ClientID    uint32    `json:"client_id"`
 	PaymentID   *uint32   `json:"payment_id"`
-	Amount      float64   `json:"amount"`
+	Amount      pkg.Money `json:"amount"`
 	Description string    `json:"description"`
 	CreatedBy   string    `json:"created_by"`
 	CreatedAt   time.Time `json:"created_at"`
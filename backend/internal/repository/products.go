@@ -12,28 +12,53 @@ type Product struct {
 	ID             uint32    `json:"id"`
 	BranchID       uint32    `json:"branch_id"`
 	BranchName     *string   `json:"branchName"`
-	LoanAmount     float64   `json:"loanAmount"`
-	RepayAmount    float64   `json:"repayAmount"`
-	InterestAmount float64   `json:"interestAmount"`
-	UpdatedBy      uint32    `json:"updated_by"`
-	UpdatedAt      time.Time `json:"updated_at"`
-	CreatedAt      time.Time `json:"created_at"`
+	LoanAmount     pkg.Money `json:"loanAmount"`
+	RepayAmount    pkg.Money `json:"repayAmount"`
+	InterestAmount pkg.Money `json:"interestAmount"`
+	// AllocationStrategy decides how repayments are spread over installments
+	AllocationStrategy string `json:"allocationStrategy,omitempty"`
+	// DefaultGraceDays is how long after the due date an unpaid loan defaults, nil uses the
+	// system default
+	DefaultGraceDays *uint32 `json:"defaultGraceDays"`
+	// PenaltyRule is nil when the product's overdue installments carry no penalty
+	PenaltyRule *PenaltyRule `json:"penaltyRule"`
+	UpdatedBy   uint32       `json:"updated_by"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+	CreatedAt   time.Time    `json:"created_at"`
+}
+
+const (
+	PenaltyTypeFlat       = "FLAT"
+	PenaltyTypePercentage = "PERCENTAGE"
+
+	PenaltyFrequencyDaily  = "DAILY"
+	PenaltyFrequencyWeekly = "WEEKLY"
+)
+
+// PenaltyRule charges overdue installments a flat amount or a percentage of what is still owed
+// on them for every day or week they stay overdue. Cap limits the total charged on an
+// installment.
+type PenaltyRule struct {
+	PenaltyType string     `json:"penaltyType"`
+	Rate        float64    `json:"rate"`
+	Frequency   string     `json:"frequency"`
+	Cap         *pkg.Money `json:"cap"`
 }
 
 type ProductShort struct {
-	ID             uint32  `json:"id"`
-	BranchName     string  `json:"branchName"`
-	LoanAmount     float64 `json:"loanAmount"`
-	RepayAmount    float64 `json:"repayAmount"`
-	InterestAmount float64 `json:"interestAmount"`
+	ID             uint32    `json:"id"`
+	BranchName     string    `json:"branchName"`
+	LoanAmount     pkg.Money `json:"loanAmount"`
+	RepayAmount    pkg.Money `json:"repayAmount"`
+	InterestAmount pkg.Money `json:"interestAmount"`
 }
 
 type UpdateProduct struct {
-	ID             uint32   `json:"id"`
-	LoanAmount     *float64 `json:"loan_amount"`
-	RepayAmount    *float64 `json:"repay_amount"`
-	InterestAmount *float64 `json:"interest_amount"`
-	UpdatedBy      uint32   `json:"updated_by"`
+	ID             uint32     `json:"id"`
+	LoanAmount     *pkg.Money `json:"loan_amount"`
+	RepayAmount    *pkg.Money `json:"repay_amount"`
+	InterestAmount *pkg.Money `json:"interest_amount"`
+	UpdatedBy      uint32     `json:"updated_by"`
 }
 
 type ProductRepository interface {
@@ -49,6 +74,24 @@ type ProductRepository interface {
 		pgData *pkg.PaginationMetadata,
 	) ([]Product, error)
 	CreateProduct(ctx context.Context, product *Product) (Product, error)
+	UpdateProductAllocationStrategy(
+		ctx context.Context,
+		id uint32,
+		strategy string,
+		updatedBy uint32,
+	) (Product, error)
+	UpdateProductDefaultThreshold(
+		ctx context.Context,
+		id uint32,
+		graceDays *uint32,
+		updatedBy uint32,
+	) (Product, error)
+	UpdateProductPenaltyRule(
+		ctx context.Context,
+		id uint32,
+		rule *PenaltyRule,
+		updatedBy uint32,
+	) (Product, error)
 	DeleteProduct(ctx context.Context, id uint32) error
 
 	GetReportProductData(
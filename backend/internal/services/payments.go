@@ -3,6 +3,8 @@ package services
 import (
 	"context"
 	"time"
+
+	"github.com/EmilioCliff/kokomed-fin/backend/pkg"
 )
 
 type MpesaCallbackData struct {
@@ -15,6 +17,361 @@ type MpesaCallbackData struct {
 	AssignedBy        string     `json:"assigned_by"`
 	AssignedTo        *uint32    `json:"assigned_to"`
 	PaidDate          *time.Time `json:"paid_date"`
+	CashReceipt       bool       `json:"cash_receipt"`
+}
+
+type MpesaValidationData struct {
+	TransactionID string  `json:"transaction_id"`
+	AccountNumber string  `json:"account_number"`
+	PhoneNumber   string  `json:"phone_number"`
+	PayingName    string  `json:"paying_name"`
+	Amount        float64 `json:"amount"`
+}
+
+type ValidationResult struct {
+	Accepted   bool    `json:"accepted"`
+	ResultCode string  `json:"resultCode"`
+	Reason     string  `json:"reason"`
+	ClientID   *uint32 `json:"clientId"`
+}
+
+type STKPushData struct {
+	LoanID      uint32   `json:"loan_id"`
+	Amount      *float64 `json:"amount"`
+	InitiatedBy uint32   `json:"initiated_by"`
+}
+
+type STKPushResult struct {
+	CheckoutRequestID string  `json:"checkoutRequestId"`
+	MerchantRequestID string  `json:"merchantRequestId"`
+	PhoneNumber       string  `json:"phoneNumber"`
+	Amount            float64 `json:"amount"`
+	CustomerMessage   string  `json:"customerMessage"`
+}
+
+type STKCallbackData struct {
+	CheckoutRequestID string     `json:"checkout_request_id"`
+	ResultCode        int        `json:"result_code"`
+	ResultDesc        string     `json:"result_desc"`
+	ReceiptNumber     string     `json:"receipt_number"`
+	Amount            float64    `json:"amount"`
+	PhoneNumber       string     `json:"phone_number"`
+	PaidDate          *time.Time `json:"paid_date"`
+}
+
+type B2CDisbursementData struct {
+	LoanID      uint32 `json:"loan_id"`
+	InitiatedBy uint32 `json:"initiated_by"`
+}
+
+type B2CDisbursementResult struct {
+	ConversationID           string  `json:"conversationId"`
+	OriginatorConversationID string  `json:"originatorConversationId"`
+	PhoneNumber              string  `json:"phoneNumber"`
+	Amount                   float64 `json:"amount"`
+	FeeDeducted              bool    `json:"feeDeducted"`
+}
+
+type B2CResultData struct {
+	ConversationID string     `json:"conversation_id"`
+	ResultCode     int        `json:"result_code"`
+	ResultDesc     string     `json:"result_desc"`
+	TransactionID  string     `json:"transaction_id"`
+	CompletedAt    *time.Time `json:"completed_at"`
+}
+
+type StatementLine struct {
+	TransactionNumber string    `json:"transaction_number"`
+	AccountNumber     string    `json:"account_number"`
+	PhoneNumber       string    `json:"phone_number"`
+	PayingName        string    `json:"paying_name"`
+	Amount            float64   `json:"amount"`
+	PaidDate          time.Time `json:"paid_date"`
+}
+
+type StatementData struct {
+	FileName      string          `json:"file_name"`
+	StatementDate *time.Time      `json:"statement_date"`
+	UploadedBy    uint32          `json:"uploaded_by"`
+	Lines         []StatementLine `json:"lines"`
+}
+
+type ReconciliationItem struct {
+	ID                  uint32     `json:"id"`
+	ItemType            string     `json:"itemType"`
+	TransactionNumber   string     `json:"transactionNumber"`
+	StatementAmount     float64    `json:"statementAmount"`
+	RecordedAmount      float64    `json:"recordedAmount"`
+	NonPostedID         *uint32    `json:"nonPostedId,omitempty"`
+	AccountNumber       string     `json:"accountNumber"`
+	PhoneNumber         string     `json:"phoneNumber"`
+	PayingName          string     `json:"payingName"`
+	PaidDate            time.Time  `json:"paidDate"`
+	ImportedNonPostedID *uint32    `json:"importedNonPostedId,omitempty"`
+	ImportedAt          *time.Time `json:"importedAt,omitempty"`
+}
+
+type ReconciliationReport struct {
+	ID               uint32               `json:"id"`
+	StatementDate    time.Time            `json:"statementDate"`
+	FileName         string               `json:"fileName"`
+	TotalLines       uint32               `json:"totalLines"`
+	Matched          uint32               `json:"matched"`
+	MissingCallbacks uint32               `json:"missingCallbacks"`
+	AmountMismatches uint32               `json:"amountMismatches"`
+	NotOnStatement   uint32               `json:"notOnStatement"`
+	UploadedBy       uint32               `json:"uploadedBy"`
+	CreatedAt        time.Time            `json:"createdAt"`
+	Items            []ReconciliationItem `json:"items"`
+}
+
+type ReconciliationImportResult struct {
+	ItemID            uint32 `json:"itemId"`
+	TransactionNumber string `json:"transactionNumber"`
+	LoanID            uint32 `json:"loanId,omitempty"`
+	Error             string `json:"error,omitempty"`
+}
+
+type AssignmentSuggestion struct {
+	ClientID    uint32   `json:"clientId"`
+	FullName    string   `json:"fullName"`
+	PhoneNumber string   `json:"phoneNumber"`
+	IDNumber    string   `json:"idNumber"`
+	Score       float64  `json:"score"`
+	Reasons     []string `json:"reasons"`
+}
+
+type AssignmentRule struct {
+	ID                uint32    `json:"id"`
+	Name              string    `json:"name"`
+	RuleType          string    `json:"ruleType"`
+	Priority          uint32    `json:"priority"`
+	Active            bool      `json:"active"`
+	Prefix            string    `json:"prefix"`
+	DaysWindow        uint32    `json:"daysWindow"`
+	MinNameSimilarity float64   `json:"minNameSimilarity"`
+	CreatedBy         uint32    `json:"createdBy"`
+	CreatedAt         time.Time `json:"createdAt"`
+	UpdatedAt         time.Time `json:"updatedAt"`
+}
+
+type AssignmentRuleData struct {
+	Name              string  `json:"name"`
+	RuleType          string  `json:"rule_type"`
+	Priority          uint32  `json:"priority"`
+	Active            bool    `json:"active"`
+	Prefix            string  `json:"prefix"`
+	DaysWindow        uint32  `json:"days_window"`
+	MinNameSimilarity float64 `json:"min_name_similarity"`
+	CreatedBy         uint32  `json:"created_by"`
+}
+
+type UpdateAssignmentRuleData struct {
+	Name              *string  `json:"name"`
+	Priority          *uint32  `json:"priority"`
+	Active            *bool    `json:"active"`
+	Prefix            *string  `json:"prefix"`
+	DaysWindow        *uint32  `json:"days_window"`
+	MinNameSimilarity *float64 `json:"min_name_similarity"`
+}
+
+type AssignmentRuleMatch struct {
+	NonPostedID       uint32    `json:"nonPostedId"`
+	TransactionNumber string    `json:"transactionNumber"`
+	AccountNumber     string    `json:"accountNumber"`
+	PayingName        string    `json:"payingName"`
+	Amount            float64   `json:"amount"`
+	PaidDate          time.Time `json:"paidDate"`
+	ClientID          uint32    `json:"clientId"`
+	FullName          string    `json:"fullName"`
+	ClaimedByRuleID   *uint32   `json:"claimedByRuleId,omitempty"`
+}
+
+type AssignmentRuleDryRun struct {
+	RuleID   uint32                `json:"ruleId"`
+	Name     string                `json:"name"`
+	RuleType string                `json:"ruleType"`
+	Priority uint32                `json:"priority"`
+	Matches  []AssignmentRuleMatch `json:"matches"`
+}
+
+type PaymentSplitPortionData struct {
+	ClientID uint32    `json:"client_id"`
+	LoanID   *uint32   `json:"loan_id"`
+	Amount   pkg.Money `json:"amount"`
+}
+
+type PaymentSplitData struct {
+	NonPostedID uint32                    `json:"non_posted_id"`
+	SplitBy     uint32                    `json:"split_by"`
+	AssignedBy  string                    `json:"assigned_by"`
+	Portions    []PaymentSplitPortionData `json:"portions"`
+}
+
+type ReversePaymentSplitData struct {
+	ReversedBy uint32 `json:"reversed_by"`
+	AssignedBy string `json:"assigned_by"`
+	Reason     string `json:"reason"`
+}
+
+type PaymentSplitPortion struct {
+	ID       uint32    `json:"id"`
+	ClientID uint32    `json:"clientId"`
+	LoanID   *uint32   `json:"loanId,omitempty"`
+	Amount   pkg.Money `json:"amount"`
+}
+
+type PaymentSplit struct {
+	ID             uint32                `json:"id"`
+	NonPostedID    uint32                `json:"nonPostedId"`
+	Status         string                `json:"status"`
+	CreatedBy      uint32                `json:"createdBy"`
+	ReversedBy     *uint32               `json:"reversedBy,omitempty"`
+	ReversalReason *string               `json:"reversalReason,omitempty"`
+	ReversedAt     *time.Time            `json:"reversedAt,omitempty"`
+	CreatedAt      time.Time             `json:"createdAt"`
+	Portions       []PaymentSplitPortion `json:"portions"`
+}
+
+type ReassignPaymentData struct {
+	ClientID     uint32 `json:"client_id"`
+	ReassignedBy uint32 `json:"reassigned_by"`
+	AssignedBy   string `json:"assigned_by"`
+	Reason       string `json:"reason"`
+}
+
+type PaymentReassignment struct {
+	ID           uint32    `json:"id"`
+	NonPostedID  uint32    `json:"nonPostedId"`
+	FromClientID uint32    `json:"fromClientId"`
+	ToClientID   uint32    `json:"toClientId"`
+	FromLoanID   *uint32   `json:"fromLoanId,omitempty"`
+	ToLoanID     *uint32   `json:"toLoanId,omitempty"`
+	Amount       float64   `json:"amount"`
+	Reason       string    `json:"reason"`
+	ReassignedBy uint32    `json:"reassignedBy"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+type PaymentImportData struct {
+	FileName   string                  `json:"file_name"`
+	UploadedBy uint32                  `json:"uploaded_by"`
+	Lines      []pkg.PaymentImportLine `json:"lines"`
+}
+
+type RollbackPaymentImportData struct {
+	RolledBackBy uint32 `json:"rolled_back_by"`
+	AssignedBy   string `json:"assigned_by"`
+	Reason       string `json:"reason"`
+}
+
+type PaymentImportRow struct {
+	ID          uint32     `json:"id"`
+	LineNumber  uint32     `json:"lineNumber"`
+	Identifier  string     `json:"identifier"`
+	ClientID    *uint32    `json:"clientId,omitempty"`
+	Amount      float64    `json:"amount"`
+	PaidDate    *time.Time `json:"paidDate,omitempty"`
+	Reference   string     `json:"reference"`
+	Error       string     `json:"error,omitempty"`
+	NonPostedID *uint32    `json:"nonPostedId,omitempty"`
+	RolledBack  bool       `json:"rolledBack"`
+}
+
+type PaymentImportBatch struct {
+	ID             uint32             `json:"id"`
+	FileName       string             `json:"fileName"`
+	Status         string             `json:"status"`
+	TotalRows      uint32             `json:"totalRows"`
+	ValidRows      uint32             `json:"validRows"`
+	PostedRows     uint32             `json:"postedRows"`
+	UploadedBy     uint32             `json:"uploadedBy"`
+	PostedBy       *uint32            `json:"postedBy,omitempty"`
+	PostedAt       *time.Time         `json:"postedAt,omitempty"`
+	RolledBackBy   *uint32            `json:"rolledBackBy,omitempty"`
+	RolledBackAt   *time.Time         `json:"rolledBackAt,omitempty"`
+	RollbackReason string             `json:"rollbackReason,omitempty"`
+	CreatedAt      time.Time          `json:"createdAt"`
+	Rows           []PaymentImportRow `json:"rows,omitempty"`
+}
+
+type OverpaymentRefundData struct {
+	ClientID     uint32    `json:"client_id"`
+	Amount       pkg.Money `json:"amount"`
+	Reason       string    `json:"reason"`
+	PayoutMethod string    `json:"payout_method"`
+	PhoneNumber  string    `json:"phone_number"`
+	RequestedBy  uint32    `json:"requested_by"`
+}
+
+type OverpaymentRefund struct {
+	ID              uint32     `json:"id"`
+	ClientID        uint32     `json:"clientId"`
+	Amount          pkg.Money  `json:"amount"`
+	Reason          string     `json:"reason"`
+	Status          string     `json:"status"`
+	PayoutMethod    string     `json:"payoutMethod"`
+	PayoutPhone     string     `json:"payoutPhone"`
+	PayoutReference string     `json:"payoutReference"`
+	RequestedBy     uint32     `json:"requestedBy"`
+	ReviewedBy      *uint32    `json:"reviewedBy,omitempty"`
+	ReviewNote      string     `json:"reviewNote"`
+	ReviewedAt      *time.Time `json:"reviewedAt,omitempty"`
+	CreatedAt       time.Time  `json:"createdAt"`
+}
+
+type ReviewOverpaymentRefundData struct {
+	ReviewedBy    uint32 `json:"reviewed_by"`
+	ReviewerEmail string `json:"reviewer_email"`
+	Note          string `json:"note"`
+}
+
+type SettlementQuoteData struct {
+	LoanID   uint32 `json:"loan_id"`
+	QuotedBy uint32 `json:"quoted_by"`
+}
+
+type SettleLoanData struct {
+	SettledBy  uint32 `json:"settled_by"`
+	AssignedBy string `json:"assigned_by"`
+}
+
+// SettlementQuote is what a client pays to clear a loan early. Status is EXPIRED for a pending
+// quote past its expiry.
+type SettlementQuote struct {
+	ID                uint32     `json:"id"`
+	LoanID            uint32     `json:"loanId"`
+	OutstandingAmount float64    `json:"outstandingAmount"`
+	PenaltiesAmount   float64    `json:"penaltiesAmount"`
+	UnearnedInterest  float64    `json:"unearnedInterest"`
+	RebatePercent     float64    `json:"rebatePercent"`
+	RebateAmount      float64    `json:"rebateAmount"`
+	QuotedAmount      float64    `json:"quotedAmount"`
+	Status            string     `json:"status"`
+	ExpiresAt         time.Time  `json:"expiresAt"`
+	QuotedBy          uint32     `json:"quotedBy"`
+	NonPostedID       *uint32    `json:"nonPostedId,omitempty"`
+	SettledBy         *uint32    `json:"settledBy,omitempty"`
+	SettledAt         *time.Time `json:"settledAt,omitempty"`
+	CreatedAt         time.Time  `json:"createdAt"`
+}
+
+type PayoutRequest struct {
+	Reference   string  `json:"reference"`
+	PhoneNumber string  `json:"phone_number"`
+	Amount      float64 `json:"amount"`
+	Remarks     string  `json:"remarks"`
+}
+
+type PayoutResult struct {
+	// Completed is false when the provider confirms the payout asynchronously
+	Completed bool   `json:"completed"`
+	Reference string `json:"reference"`
+}
+
+// PayoutProvider sends money back to a client, e.g. an mpesa b2c payment.
+type PayoutProvider interface {
+	Payout(ctx context.Context, req PayoutRequest) (PayoutResult, error)
 }
 
 type ManualPaymentData struct {
@@ -32,19 +389,126 @@ type SimulatedAction struct {
 	Severity      string  `json:"severity"`
 }
 
+// SimulatedChange is a row the simulated action changed, as it was before and after. Before is
+// nil for a created row and After for a removed one.
+type SimulatedChange struct {
+	Entity   string      `json:"entity"`
+	EntityID uint32      `json:"entityId"`
+	Before   interface{} `json:"before"`
+	After    interface{} `json:"after"`
+}
+
 type SimulationResult struct {
 	PaymentID uint32            `json:"paymentId"`
 	UserID    uint32            `json:"userId"`
 	Actions   []SimulatedAction `json:"actions"`
+	Changes   []SimulatedChange `json:"changes"`
 }
 
 type PaymentService interface {
 	ProcessCallback(ctx context.Context, callbackData *MpesaCallbackData) (uint32, error)
+	ValidateCallback(
+		ctx context.Context,
+		validationData *MpesaValidationData,
+	) (ValidationResult, error)
+	InitiateSTKPush(ctx context.Context, pushData *STKPushData) (STKPushResult, error)
+	ProcessSTKCallback(ctx context.Context, callbackData *STKCallbackData) (uint32, error)
+	InitiateB2CDisbursement(
+		ctx context.Context,
+		disburseData *B2CDisbursementData,
+	) (B2CDisbursementResult, error)
+	ProcessB2CResult(ctx context.Context, resultData *B2CResultData) (uint32, error)
+	ReconcileStatement(
+		ctx context.Context,
+		statementData *StatementData,
+	) (ReconciliationReport, error)
+	GetReconciliation(ctx context.Context, id uint32) (ReconciliationReport, error)
+	ImportMissingCallbacks(
+		ctx context.Context,
+		reconciliationID uint32,
+		itemIDs []uint32,
+		importedBy string,
+	) ([]ReconciliationImportResult, error)
+	SuggestAssignments(
+		ctx context.Context,
+		nonPostedID uint32,
+		limit int,
+	) ([]AssignmentSuggestion, error)
+	CreateAssignmentRule(ctx context.Context, ruleData *AssignmentRuleData) (AssignmentRule, error)
+	UpdateAssignmentRule(
+		ctx context.Context,
+		id uint32,
+		ruleData *UpdateAssignmentRuleData,
+	) (AssignmentRule, error)
+	ListAssignmentRules(ctx context.Context) ([]AssignmentRule, error)
+	DeleteAssignmentRule(ctx context.Context, id uint32) error
+	DryRunAssignmentRules(
+		ctx context.Context,
+		ruleIDs []uint32,
+		limit int,
+	) ([]AssignmentRuleDryRun, error)
 	TriggerManualPayment(
 		ctx context.Context,
 		paymentData ManualPaymentData,
 		assignedBy string,
 	) (uint32, error)
+	SplitPayment(ctx context.Context, splitData *PaymentSplitData) (PaymentSplit, error)
+	GetPaymentSplit(ctx context.Context, id uint32) (PaymentSplit, error)
+	ReversePaymentSplit(
+		ctx context.Context,
+		id uint32,
+		reverseData *ReversePaymentSplitData,
+	) (PaymentSplit, error)
+	ReassignPayment(
+		ctx context.Context,
+		paymentID uint32,
+		reassignData *ReassignPaymentData,
+	) (PaymentReassignment, error)
+	ListPaymentReassignments(ctx context.Context, paymentID uint32) ([]PaymentReassignment, error)
+	PreviewPaymentImport(ctx context.Context, importData *PaymentImportData) (PaymentImportBatch, error)
+	GetPaymentImport(ctx context.Context, id uint32) (PaymentImportBatch, error)
+	ListPaymentImports(
+		ctx context.Context,
+		pgData *pkg.PaginationMetadata,
+	) ([]PaymentImportBatch, pkg.PaginationMetadata, error)
+	PostPaymentImport(
+		ctx context.Context,
+		id uint32,
+		postedBy uint32,
+		assignedBy string,
+	) (PaymentImportBatch, error)
+	RollbackPaymentImport(
+		ctx context.Context,
+		id uint32,
+		rollbackData *RollbackPaymentImportData,
+	) (PaymentImportBatch, error)
+	RequestOverpaymentRefund(
+		ctx context.Context,
+		refundData *OverpaymentRefundData,
+	) (OverpaymentRefund, error)
+	ApproveOverpaymentRefund(
+		ctx context.Context,
+		id uint32,
+		reviewData *ReviewOverpaymentRefundData,
+	) (OverpaymentRefund, error)
+	RejectOverpaymentRefund(
+		ctx context.Context,
+		id uint32,
+		reviewData *ReviewOverpaymentRefundData,
+	) (OverpaymentRefund, error)
+	GetOverpaymentRefund(ctx context.Context, id uint32) (OverpaymentRefund, error)
+	CreateSettlementQuote(
+		ctx context.Context,
+		quoteData *SettlementQuoteData,
+	) (SettlementQuote, error)
+	GetSettlementQuote(ctx context.Context, id uint32) (SettlementQuote, error)
+	ListSettlementQuotes(ctx context.Context, loanID uint32) ([]SettlementQuote, error)
+	SettleLoan(ctx context.Context, quoteID uint32, settleData *SettleLoanData) (SettlementQuote, error)
+	ListOverpaymentRefunds(
+		ctx context.Context,
+		status *string,
+		pgData *pkg.PaginationMetadata,
+	) ([]OverpaymentRefund, pkg.PaginationMetadata, error)
 	UpdatePayment(
 		ctx context.Context,
 		paymentID uint32,
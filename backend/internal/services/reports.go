@@ -3,6 +3,8 @@ package services
 import (
 	"context"
 	"time"
+
+	"github.com/EmilioCliff/kokomed-fin/backend/pkg"
 )
 
 type ReportService interface {
@@ -12,6 +14,8 @@ type ReportService interface {
 	GenerateUsersReport(ctx context.Context, format string, filters ReportFilters) ([]byte, error)
 	GenerateClientsReport(ctx context.Context, format string, filters ReportFilters) ([]byte, error)
 	GenerateProductsReport(ctx context.Context, format string, filters ReportFilters) ([]byte, error)
+	GenerateRefundVoucher(ctx context.Context, refund OverpaymentRefund) ([]byte, error)
+	GeneratePaymentReceipt(ctx context.Context, nonPostedID uint32) ([]byte, error)
 }
 
 type ReportFilters struct {
@@ -155,7 +159,7 @@ type BranchSummary struct {
 type PaymentReportData struct {
 	TransactionNumber string
 	PayingName        string
-	Amount            float64
+	Amount            pkg.Money
 	AccountNumber     string
 	TransactionSource string
 	PaidDate          time.Time
@@ -163,9 +167,34 @@ type PaymentReportData struct {
 	AssignedBy        string
 }
 
+type PaymentReceiptData struct {
+	ReceiptNumber     string
+	NonPostedID       uint32
+	TransactionNumber string
+	TransactionSource string
+	PayingName        string
+	Amount            pkg.Money
+	PaidDate          time.Time
+	ClientName        string
+	ClientPhone       string
+	LoanID            *uint32
+	Allocations       []PaymentReceiptAllocation
+	PenaltiesPaid     pkg.Money
+	Overpayment       pkg.Money
+	LoanBalance       pkg.Money
+	NextDueDate       *time.Time
+	NextDueAmount     pkg.Money
+}
+
+type PaymentReceiptAllocation struct {
+	InstallmentNumber uint32
+	DueDate           time.Time
+	Amount            pkg.Money
+}
+
 type PaymentSummary struct {
 	TotalPayments       int64
-	TotalAmountReceived float64
+	TotalAmountReceived pkg.Money
 	MostCommonSource    string
 	MostAssignedStaff   string
 }
@@ -194,11 +223,13 @@ type LoanReportData struct {
 	ClientName        string
 	BranchName        string
 	LoanOfficer       string
-	LoanAmount        float64
-	RepayAmount       float64
-	PaidAmount        float64
-	OutstandingAmount float64
+	LoanAmount        pkg.Money
+	RepayAmount       pkg.Money
+	PaidAmount        pkg.Money
+	OutstandingAmount pkg.Money
+	PenaltyBalance    pkg.Money
 	Status            string
+	Restructured      bool
 	DueDate           string
 	TotalInstallments uint32
 	PaidInstallments  int64
@@ -207,15 +238,17 @@ type LoanReportData struct {
 }
 
 type LoanSummary struct {
-	TotalLoans           int64
-	TotalActiveLoans     int64
-	TotalCompletedLoans  int64
-	TotalDefaultedLoans  int64
-	TotalDisbursedAmount float64
-	TotalRepaidAmount    float64
-	TotalOutstanding     float64
-	MostIssuedLoanBranch string
-	MostLoansOfficer     string
+	TotalLoans             int64
+	TotalActiveLoans       int64
+	TotalCompletedLoans    int64
+	TotalDefaultedLoans    int64
+	TotalRestructuredLoans int64
+	TotalDisbursedAmount   pkg.Money
+	TotalRepaidAmount      pkg.Money
+	TotalOutstanding       pkg.Money
+	TotalPenaltyBalance    pkg.Money
+	MostIssuedLoanBranch   string
+	MostLoansOfficer       string
 }
 
 type LoanReportDataById struct {
@@ -224,7 +257,9 @@ type LoanReportDataById struct {
 	LoanAmount            float64                                `json:"loan_amount"`
 	RepayAmount           float64                                `json:"repay_amount"`
 	PaidAmount            float64                                `json:"paid_amount"`
+	PenaltyBalance        pkg.Money                              `json:"penalty_balance"`
 	Status                string                                 `json:"status"`
+	Restructured          bool                                   `json:"restructured"`
 	TotalInstallments     int64                                  `json:"total_installments"`
 	PaidInstallments      int64                                  `json:"paid_installments"`
 	RemainingInstallments int64                                  `json:"remaining_installments"`
@@ -238,4 +273,5 @@ type LoanReportDataByIdInstallmentDetails struct {
 	DueDate           string  `json:"due_date"`
 	Paid              uint32  `json:"paid"`
 	PaidAt            string  `json:"paid_at"`
+	Closed            uint32  `json:"closed"`
 }
@@ -18,6 +18,13 @@ type RedisConfig struct {
 	DB int
 }
 
+// ScheduleConfig holds the settings of the periodic tasks.
+type ScheduleConfig struct {
+	LoanDefaultCron      string
+	LoanDefaultGraceDays uint32
+	LoanPenaltyCron      string
+}
+
 type WorkerService interface {
 	// processes
 	StartProcessor() error
@@ -25,10 +32,36 @@ type WorkerService interface {
 
 
 	ProcessSendResetPassword(ctx context.Context, task *asynq.Task) error
+	ProcessSendPaymentReceipt(ctx context.Context, task *asynq.Task) error
+	ProcessMarkDefaultedLoans(ctx context.Context, task *asynq.Task) error
+	ProcessAccrueLoanPenalties(ctx context.Context, task *asynq.Task) error
 
 	DistributeTaskSendResetPassword(ctx context.Context, payload SendResetPasswordPayload, opt ...asynq.Option, ) error
+	DistributeTaskSendPaymentReceipt(ctx context.Context, payload SendPaymentReceiptPayload, opt ...asynq.Option) error
+	DistributeTaskMarkDefaultedLoans(ctx context.Context, payload MarkDefaultedLoansPayload, opt ...asynq.Option) error
+	DistributeTaskAccrueLoanPenalties(ctx context.Context, payload AccrueLoanPenaltiesPayload, opt ...asynq.Option) error
 }
 
 type SendResetPasswordPayload struct {
 	Email string `json:"email"`
-}
\ No newline at end of file
+}
+
+const (
+	ReceiptChannelEmail = "email"
+	ReceiptChannelSMS   = "sms"
+)
+
+type SendPaymentReceiptPayload struct {
+	NonPostedID uint32 `json:"non_posted_id"`
+	Channel     string `json:"channel"`
+	// Recipient is the email address or phone number, sms goes to the client's phone when empty
+	Recipient string `json:"recipient"`
+}
+
+type MarkDefaultedLoansPayload struct {
+	// DefaultGraceDays applies to products without their own default threshold
+	DefaultGraceDays uint32 `json:"default_grace_days"`
+}
+
+// AccrueLoanPenaltiesPayload is empty, the penalty rules are read from the products.
+type AccrueLoanPenaltiesPayload struct{}
@@ -0,0 +1,63 @@
+package workers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/services"
+	"github.com/EmilioCliff/kokomed-fin/backend/pkg"
+	"github.com/hibiken/asynq"
+)
+
+const AccrueLoanPenaltiesTask = "task:accrue_loan_penalties"
+
+func (distributor TaskDistributor) DistributeTaskAccrueLoanPenalties(
+	ctx context.Context,
+	payload services.AccrueLoanPenaltiesPayload,
+	opt ...asynq.Option,
+) error {
+	task, err := newAccrueLoanPenaltiesTask(payload, opt...)
+	if err != nil {
+		return err
+	}
+
+	_, err = distributor.client.EnqueueContext(ctx, task)
+	if err != nil {
+		return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to enqueue task: %s", err.Error())
+	}
+
+	return nil
+}
+
+func newAccrueLoanPenaltiesTask(
+	payload services.AccrueLoanPenaltiesPayload,
+	opt ...asynq.Option,
+) (*asynq.Task, error) {
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "failed to marshal payload: %s", err.Error())
+	}
+
+	return asynq.NewTask(AccrueLoanPenaltiesTask, jsonPayload, opt...), nil
+}
+
+// ProcessAccrueLoanPenalties charges the overdue installments their penalties up to today. A retry
+// after a partial run only charges what the first run did not get to.
+func (processor *TaskProcessor) ProcessAccrueLoanPenalties(ctx context.Context, task *asynq.Task) error {
+	var payload services.AccrueLoanPenaltiesPayload
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	accrual, err := processor.repo.Loans.AccrueLoanPenalties(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("accrued %s penalties on %d installments", accrual.Amount, accrual.Installments)
+
+	return nil
+}
@@ -0,0 +1,108 @@
+package workers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/repository"
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/services"
+	"github.com/EmilioCliff/kokomed-fin/backend/pkg"
+	"github.com/hibiken/asynq"
+)
+
+const MarkDefaultedLoansTask = "task:mark_defaulted_loans"
+
+func (distributor TaskDistributor) DistributeTaskMarkDefaultedLoans(
+	ctx context.Context,
+	payload services.MarkDefaultedLoansPayload,
+	opt ...asynq.Option,
+) error {
+	task, err := newMarkDefaultedLoansTask(payload, opt...)
+	if err != nil {
+		return err
+	}
+
+	_, err = distributor.client.EnqueueContext(ctx, task)
+	if err != nil {
+		return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to enqueue task: %s", err.Error())
+	}
+
+	return nil
+}
+
+func newMarkDefaultedLoansTask(
+	payload services.MarkDefaultedLoansPayload,
+	opt ...asynq.Option,
+) (*asynq.Task, error) {
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "failed to marshal payload: %s", err.Error())
+	}
+
+	return asynq.NewTask(MarkDefaultedLoansTask, jsonPayload, opt...), nil
+}
+
+// ProcessMarkDefaultedLoans moves overdue loans to DEFAULTED and lets each loan officer know
+// which of their loans defaulted. A failed email does not fail the task, the loans are already
+// marked.
+func (processor *TaskProcessor) ProcessMarkDefaultedLoans(ctx context.Context, task *asynq.Task) error {
+	var payload services.MarkDefaultedLoansPayload
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	defaulted, err := processor.repo.Loans.MarkDefaultedLoans(ctx, time.Now(), payload.DefaultGraceDays)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("marked %d loans defaulted", len(defaulted))
+
+	byOfficer := map[uint32][]repository.DefaultedLoan{}
+	for _, loan := range defaulted {
+		byOfficer[loan.LoanOfficer] = append(byOfficer[loan.LoanOfficer], loan)
+	}
+
+	for _, loans := range byOfficer {
+		if err := processor.notifyDefaultedLoans(loans); err != nil {
+			log.Printf("failed to notify %s of defaulted loans: %v", loans[0].OfficerEmail, err)
+		}
+	}
+
+	return nil
+}
+
+func (processor *TaskProcessor) notifyDefaultedLoans(loans []repository.DefaultedLoan) error {
+	rows := ""
+	for _, loan := range loans {
+		rows += fmt.Sprintf(`
+		<tr><td>%d</td><td>%s</td><td>%s</td><td>%s</td></tr>`,
+			loan.LoanID,
+			loan.ClientName,
+			loan.DueDate.Format("2006-01-02"),
+			loan.Arrears,
+		)
+	}
+
+	emailBody := fmt.Sprintf(`
+	<h1>Hello %s</h1>
+	<p>The following loans have passed their default threshold and are now marked as defaulted:</p>
+	<table>
+		<tr><th>Loan</th><th>Client</th><th>Due Date</th><th>Arrears (KES)</th></tr>%s
+	</table>
+`, loans[0].OfficerName, rows)
+
+	return processor.sender.SendMail(
+		"Defaulted Loans",
+		emailBody,
+		"text/html",
+		[]string{loans[0].OfficerEmail},
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+}
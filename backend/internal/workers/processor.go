@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/EmilioCliff/kokomed-fin/backend/internal/mysql"
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/reports"
 	"github.com/EmilioCliff/kokomed-fin/backend/internal/services"
 	"github.com/EmilioCliff/kokomed-fin/backend/pkg"
 	"github.com/hibiken/asynq"
@@ -17,6 +18,9 @@ type TaskProcessor struct {
 	sender pkg.EmailSender
 	repo   *mysql.MySQLRepo
 	maker  pkg.JWTMaker
+	report services.ReportService
+	// sms is nil until an sms gateway is configured
+	sms pkg.SMSSender
 }
 
 func NewTaskProcessor(
@@ -41,6 +45,7 @@ func NewTaskProcessor(
 		sender: sender,
 		repo:   repo,
 		maker:  maker,
+		report: reports.NewReportService(repo),
 	}
 }
 
@@ -48,6 +53,9 @@ func (processor *TaskProcessor) Start() error {
 	mux := asynq.NewServeMux()
 
 	mux.HandleFunc(SendResetPasswordTask, processor.ProcessSendResetPassword)
+	mux.HandleFunc(SendPaymentReceiptTask, processor.ProcessSendPaymentReceipt)
+	mux.HandleFunc(MarkDefaultedLoansTask, processor.ProcessMarkDefaultedLoans)
+	mux.HandleFunc(AccrueLoanPenaltiesTask, processor.ProcessAccrueLoanPenalties)
 
 	return processor.server.Start(mux)
 }
@@ -0,0 +1,78 @@
+package workers
+
+import (
+	"log"
+	"time"
+
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/services"
+	"github.com/EmilioCliff/kokomed-fin/backend/pkg"
+	"github.com/hibiken/asynq"
+)
+
+// TaskScheduler enqueues the periodic tasks. Every instance runs one so tasks are enqueued as
+// unique, keeping a single run per period when several instances are up.
+type TaskScheduler struct {
+	scheduler *asynq.Scheduler
+	config    services.ScheduleConfig
+}
+
+func NewTaskScheduler(redisOpt asynq.RedisClientOpt, config services.ScheduleConfig) *TaskScheduler {
+	scheduler := asynq.NewScheduler(redisOpt, &asynq.SchedulerOpts{
+		Location: pkg.NairobiLocation(),
+		LogLevel: asynq.WarnLevel,
+	})
+
+	return &TaskScheduler{
+		scheduler: scheduler,
+		config:    config,
+	}
+}
+
+func (s *TaskScheduler) Start() error {
+	if s.config.LoanDefaultCron != "" {
+		task, err := newMarkDefaultedLoansTask(
+			services.MarkDefaultedLoansPayload{DefaultGraceDays: s.config.LoanDefaultGraceDays},
+			asynq.Queue(services.QueueLow),
+			asynq.Unique(time.Hour),
+		)
+		if err != nil {
+			return err
+		}
+
+		if _, err := s.scheduler.Register(s.config.LoanDefaultCron, task); err != nil {
+			return pkg.Errorf(
+				pkg.INTERNAL_ERROR,
+				"failed to schedule %s: %s",
+				MarkDefaultedLoansTask,
+				err.Error(),
+			)
+		}
+	}
+
+	if s.config.LoanPenaltyCron != "" {
+		task, err := newAccrueLoanPenaltiesTask(
+			services.AccrueLoanPenaltiesPayload{},
+			asynq.Queue(services.QueueLow),
+			asynq.Unique(time.Hour),
+		)
+		if err != nil {
+			return err
+		}
+
+		if _, err := s.scheduler.Register(s.config.LoanPenaltyCron, task); err != nil {
+			return pkg.Errorf(
+				pkg.INTERNAL_ERROR,
+				"failed to schedule %s: %s",
+				AccrueLoanPenaltiesTask,
+				err.Error(),
+			)
+		}
+	}
+
+	return s.scheduler.Start()
+}
+
+func (s *TaskScheduler) Stop() {
+	s.scheduler.Shutdown()
+	log.Println("Task scheduler stopped successfully.")
+}
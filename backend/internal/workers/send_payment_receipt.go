@@ -0,0 +1,124 @@
+package workers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/EmilioCliff/kokomed-fin/backend/internal/services"
+	"github.com/EmilioCliff/kokomed-fin/backend/pkg"
+	"github.com/hibiken/asynq"
+)
+
+const SendPaymentReceiptTask = "task:send_payment_receipt"
+
+func (distributor TaskDistributor) DistributeTaskSendPaymentReceipt(
+	ctx context.Context,
+	payload services.SendPaymentReceiptPayload,
+	opt ...asynq.Option,
+) error {
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to marshal payload: %s", err.Error())
+	}
+
+	task := asynq.NewTask(SendPaymentReceiptTask, jsonPayload, opt...)
+	_, err = distributor.client.EnqueueContext(ctx, task)
+	if err != nil {
+		return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to enqueue task: %s", err.Error())
+	}
+
+	return nil
+}
+
+func (processor *TaskProcessor) ProcessSendPaymentReceipt(ctx context.Context, task *asynq.Task) error {
+	var payload services.SendPaymentReceiptPayload
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	data, err := processor.repo.NonPosted.GetPaymentReceiptData(ctx, payload.NonPostedID)
+	if err != nil {
+		if pkg.ErrorCode(err) != pkg.INTERNAL_ERROR {
+			return fmt.Errorf("%s: %w", err.Error(), asynq.SkipRetry)
+		}
+
+		return err
+	}
+
+	switch payload.Channel {
+	case services.ReceiptChannelEmail:
+		receipt, err := processor.report.GeneratePaymentReceipt(ctx, payload.NonPostedID)
+		if err != nil {
+			return err
+		}
+
+		emailBody := fmt.Sprintf(`
+	<h1>Hello %s</h1>
+	<p>We have received your payment of KES %s. Your receipt %s is attached.</p>
+`, data.ClientName, data.Amount, data.ReceiptNumber)
+
+		err = processor.sender.SendMail(
+			fmt.Sprintf("Payment Receipt %s", data.ReceiptNumber),
+			emailBody,
+			"application/pdf",
+			[]string{payload.Recipient},
+			nil,
+			nil,
+			[]string{fmt.Sprintf("receipt_%s.pdf", data.ReceiptNumber)},
+			[][]byte{receipt},
+		)
+		if err != nil {
+			return fmt.Errorf("failed to send email: %w", err)
+		}
+	case services.ReceiptChannelSMS:
+		if processor.sms == nil {
+			return fmt.Errorf("sms delivery is not configured: %w", asynq.SkipRetry)
+		}
+
+		phoneNumber := payload.Recipient
+		if phoneNumber == "" {
+			phoneNumber = data.ClientPhone
+		}
+
+		if err := processor.sms.SendSMS(phoneNumber, paymentReceiptSMS(data)); err != nil {
+			return fmt.Errorf("failed to send sms: %w", err)
+		}
+	default:
+		return fmt.Errorf("unknown receipt channel %q: %w", payload.Channel, asynq.SkipRetry)
+	}
+
+	return nil
+}
+
+func paymentReceiptSMS(data services.PaymentReceiptData) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(
+		&sb,
+		"%s: payment of KES %s received on %s.",
+		data.ReceiptNumber,
+		data.Amount,
+		data.PaidDate.Format("2006-01-02"),
+	)
+
+	if data.LoanID != nil {
+		fmt.Fprintf(&sb, " Loan balance KES %s.", data.LoanBalance)
+
+		if data.NextDueDate != nil {
+			fmt.Fprintf(
+				&sb,
+				" Next installment KES %s due %s.",
+				data.NextDueAmount,
+				data.NextDueDate.Format("2006-01-02"),
+			)
+		}
+	}
+
+	if data.Overpayment > 0 {
+		fmt.Fprintf(&sb, " KES %s added to your overpayment.", data.Overpayment)
+	}
+
+	return sb.String()
+}
@@ -14,9 +14,10 @@ var _ services.WorkerService = (*WorkerServiceImpl)(nil)
 type WorkerServiceImpl struct {
 	distributor *TaskDistributor
 	processor   *TaskProcessor
+	scheduler   *TaskScheduler
 }
 
-func NewWorkerService(redisConfig services.RedisConfig, emailSender pkg.EmailSender, repo *mysql.MySQLRepo, maker pkg.JWTMaker) services.WorkerService {
+func NewWorkerService(redisConfig services.RedisConfig, scheduleConfig services.ScheduleConfig, emailSender pkg.EmailSender, repo *mysql.MySQLRepo, maker pkg.JWTMaker) services.WorkerService {
 	redisOpt := asynq.RedisClientOpt{
 		Addr: redisConfig.Address,
 		DB: redisConfig.DB,
@@ -26,14 +27,20 @@ func NewWorkerService(redisConfig services.RedisConfig, emailSender pkg.EmailSen
 	return &WorkerServiceImpl{
 		distributor: NewTaskDistributor(redisOpt),
 		processor:   NewTaskProcessor(redisOpt, emailSender, repo, maker),
+		scheduler:   NewTaskScheduler(redisOpt, scheduleConfig),
 	}
 }
 
 func (w *WorkerServiceImpl) StartProcessor() error {
-	return w.processor.Start()
+	if err := w.processor.Start(); err != nil {
+		return err
+	}
+
+	return w.scheduler.Start()
 }
 
 func (w *WorkerServiceImpl) StopProcessor() {
+	w.scheduler.Stop()
 	w.processor.Stop()
 }
 
@@ -43,4 +50,28 @@ func (w *WorkerServiceImpl) ProcessSendResetPassword(ctx context.Context, task *
 
 func (w *WorkerServiceImpl) DistributeTaskSendResetPassword(ctx context.Context, payload services.SendResetPasswordPayload, opt ...asynq.Option, ) error {
 	return w.distributor.DistributeTaskSendResetPassword(ctx, payload, opt...)
-}
\ No newline at end of file
+}
+
+func (w *WorkerServiceImpl) ProcessSendPaymentReceipt(ctx context.Context, task *asynq.Task) error {
+	return w.processor.ProcessSendPaymentReceipt(ctx, task)
+}
+
+func (w *WorkerServiceImpl) DistributeTaskSendPaymentReceipt(ctx context.Context, payload services.SendPaymentReceiptPayload, opt ...asynq.Option) error {
+	return w.distributor.DistributeTaskSendPaymentReceipt(ctx, payload, opt...)
+}
+
+func (w *WorkerServiceImpl) ProcessMarkDefaultedLoans(ctx context.Context, task *asynq.Task) error {
+	return w.processor.ProcessMarkDefaultedLoans(ctx, task)
+}
+
+func (w *WorkerServiceImpl) DistributeTaskMarkDefaultedLoans(ctx context.Context, payload services.MarkDefaultedLoansPayload, opt ...asynq.Option) error {
+	return w.distributor.DistributeTaskMarkDefaultedLoans(ctx, payload, opt...)
+}
+
+func (w *WorkerServiceImpl) ProcessAccrueLoanPenalties(ctx context.Context, task *asynq.Task) error {
+	return w.processor.ProcessAccrueLoanPenalties(ctx, task)
+}
+
+func (w *WorkerServiceImpl) DistributeTaskAccrueLoanPenalties(ctx context.Context, payload services.AccrueLoanPenaltiesPayload, opt ...asynq.Option) error {
+	return w.distributor.DistributeTaskAccrueLoanPenalties(ctx, payload, opt...)
+}
@@ -32,6 +32,30 @@ type Config struct {
 	MPESA_CONSUMER_SECRET   string        `mapstructure:"MPESA_CONSUMER_SECRET"`
 	MPESA_SHORT_CODE        string        `mapstructure:"MPESA_SHORT_CODE"`
 	MPESA_PASSKEY           string        `mapstructure:"MPESA_PASSKEY"`
+
+	MPESA_ACCOUNT_ALIASES         string  `mapstructure:"MPESA_ACCOUNT_ALIASES"`
+	MPESA_MIN_AMOUNT              float64 `mapstructure:"MPESA_MIN_AMOUNT"`
+	MPESA_MAX_AMOUNT              float64 `mapstructure:"MPESA_MAX_AMOUNT"`
+	MPESA_REJECT_UNKNOWN_ACCOUNTS bool    `mapstructure:"MPESA_REJECT_UNKNOWN_ACCOUNTS"`
+	MPESA_BASE_URL                string  `mapstructure:"MPESA_BASE_URL"`
+	MPESA_STK_CALLBACK_URL        string  `mapstructure:"MPESA_STK_CALLBACK_URL"`
+
+	MPESA_B2C_ENABLED               bool   `mapstructure:"MPESA_B2C_ENABLED"`
+	MPESA_B2C_SHORT_CODE            string `mapstructure:"MPESA_B2C_SHORT_CODE"`
+	MPESA_B2C_INITIATOR_NAME        string `mapstructure:"MPESA_B2C_INITIATOR_NAME"`
+	MPESA_B2C_SECURITY_CREDENTIAL   string `mapstructure:"MPESA_B2C_SECURITY_CREDENTIAL"`
+	MPESA_B2C_RESULT_URL            string `mapstructure:"MPESA_B2C_RESULT_URL"`
+	MPESA_B2C_TIMEOUT_URL           string `mapstructure:"MPESA_B2C_TIMEOUT_URL"`
+	MPESA_B2C_DEDUCT_PROCESSING_FEE bool   `mapstructure:"MPESA_B2C_DEDUCT_PROCESSING_FEE"`
+
+	// loans default this many days after the due date unless their product sets its own threshold
+	LOAN_DEFAULT_GRACE_DAYS uint32 `mapstructure:"LOAN_DEFAULT_GRACE_DAYS"`
+	LOAN_DEFAULT_CRON       string `mapstructure:"LOAN_DEFAULT_CRON"`
+	LOAN_PENALTY_CRON       string `mapstructure:"LOAN_PENALTY_CRON"`
+
+	// share of the unearned interest given back when a loan is settled early, 0 to 100
+	LOAN_SETTLEMENT_REBATE_PERCENT float64       `mapstructure:"LOAN_SETTLEMENT_REBATE_PERCENT"`
+	LOAN_SETTLEMENT_QUOTE_VALIDITY time.Duration `mapstructure:"LOAN_SETTLEMENT_QUOTE_VALIDITY"`
 }
 
 // Loads app configuration from .env file.
@@ -77,4 +101,22 @@ func setDefaults() {
 	viper.SetDefault("EMAIL_SENDER_ADDRESS", "")
 	viper.SetDefault("REDIS_ADDRESS", "")
 	viper.SetDefault("REDIS_PASSWORD", "")
+	viper.SetDefault("MPESA_ACCOUNT_ALIASES", "")
+	viper.SetDefault("MPESA_MIN_AMOUNT", 0)
+	viper.SetDefault("MPESA_MAX_AMOUNT", 0)
+	viper.SetDefault("MPESA_REJECT_UNKNOWN_ACCOUNTS", false)
+	viper.SetDefault("MPESA_BASE_URL", "https://api.safaricom.co.ke")
+	viper.SetDefault("MPESA_STK_CALLBACK_URL", "")
+	viper.SetDefault("MPESA_B2C_ENABLED", false)
+	viper.SetDefault("MPESA_B2C_SHORT_CODE", "")
+	viper.SetDefault("MPESA_B2C_INITIATOR_NAME", "")
+	viper.SetDefault("MPESA_B2C_SECURITY_CREDENTIAL", "")
+	viper.SetDefault("MPESA_B2C_RESULT_URL", "")
+	viper.SetDefault("MPESA_B2C_TIMEOUT_URL", "")
+	viper.SetDefault("MPESA_B2C_DEDUCT_PROCESSING_FEE", false)
+	viper.SetDefault("LOAN_DEFAULT_GRACE_DAYS", 30)
+	viper.SetDefault("LOAN_DEFAULT_CRON", "0 1 * * *")
+	viper.SetDefault("LOAN_PENALTY_CRON", "30 0 * * *")
+	viper.SetDefault("LOAN_SETTLEMENT_REBATE_PERCENT", 0)
+	viper.SetDefault("LOAN_SETTLEMENT_QUOTE_VALIDITY", "24h")
 }
@@ -1,6 +1,7 @@
 package pkg
 
 import (
+	"fmt"
 	"strconv"
 	"strings"
 	"time"
@@ -37,6 +38,12 @@ func PtrToStr(s *string) string { return *s }
 // StringPtr returns a pointer to the given string.
 func StringPtr(s string) *string { return &s }
 
+// PaymentReceiptNumber returns the receipt number of a payment. It only depends on the payment id
+// so a receipt keeps its number however many times it is generated.
+func PaymentReceiptNumber(nonPostedID uint32) string {
+	return fmt.Sprintf("RC-%08d", nonPostedID)
+}
+
 // Uint32Ptr returns a pointer to the given uint32.
 func Uint32Ptr(i uint32) *uint32 { return &i }
 
@@ -0,0 +1,103 @@
+package pkg
+
+import (
+	"strings"
+	"unicode"
+)
+
+// PhoneNumberVariants returns the ways a kenyan phone number may have been typed,
+// local (07...), international (2547...), with a plus (+2547...) and without the leading zero.
+func PhoneNumberVariants(phoneNumber string) ([]string, bool) {
+	digits := strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) {
+			return r
+		}
+
+		return -1
+	}, phoneNumber)
+
+	international, err := FormatMpesaPhoneNumber(digits)
+	if err != nil {
+		return nil, false
+	}
+
+	return []string{
+		"0" + international[3:],
+		international,
+		"+" + international,
+		international[3:],
+	}, true
+}
+
+// NameSimilarity scores how alike two names are between 0 and 1.
+// Names are compared word by word so that the order the names were typed in does not matter.
+func NameSimilarity(a, b string) float64 {
+	aWords := strings.Fields(strings.ToLower(a))
+	bWords := strings.Fields(strings.ToLower(b))
+
+	if len(aWords) == 0 || len(bWords) == 0 {
+		return 0
+	}
+
+	// compare from the side with fewer names, paybill names are often just the first two names
+	if len(aWords) > len(bWords) {
+		aWords, bWords = bWords, aWords
+	}
+
+	total := 0.0
+
+	for _, aWord := range aWords {
+		best := 0.0
+
+		for _, bWord := range bWords {
+			if score := wordSimilarity(aWord, bWord); score > best {
+				best = score
+			}
+		}
+
+		total += best
+	}
+
+	return total / float64(len(aWords))
+}
+
+func wordSimilarity(a, b string) float64 {
+	if a == b {
+		return 1
+	}
+
+	longest := max(len([]rune(a)), len([]rune(b)))
+	if longest == 0 {
+		return 0
+	}
+
+	return 1 - float64(levenshtein(a, b))/float64(longest)
+}
+
+func levenshtein(a, b string) int {
+	ar, br := []rune(a), []rune(b)
+
+	prev := make([]int, len(br)+1)
+	curr := make([]int, len(br)+1)
+
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ar); i++ {
+		curr[0] = i
+
+		for j := 1; j <= len(br); j++ {
+			cost := 1
+			if ar[i-1] == br[j-1] {
+				cost = 0
+			}
+
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+
+		prev, curr = curr, prev
+	}
+
+	return prev[len(br)]
+}
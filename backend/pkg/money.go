@@ -0,0 +1,180 @@
+package pkg
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Money is an amount in cents. Amounts are added, compared and split as whole cents so
+// payments and installments always balance to the cent.
+type Money int64
+
+// MoneyFromFloat converts a float amount to the nearest cent.
+func MoneyFromFloat(f float64) Money {
+	return Money(math.Round(f * 100))
+}
+
+// ParseMoney reads a decimal amount such as "1250.5" without going through a float.
+func ParseMoney(s string) (Money, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, Errorf(INVALID_ERROR, "empty amount")
+	}
+
+	negative := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "-"), "+")
+
+	whole, fraction, _ := strings.Cut(s, ".")
+	if whole == "" {
+		whole = "0"
+	}
+
+	units, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil {
+		return 0, Errorf(INVALID_ERROR, "invalid amount %q", s)
+	}
+
+	cents := int64(0)
+	if fraction != "" {
+		if _, err := strconv.ParseUint(fraction, 10, 64); err != nil {
+			return 0, Errorf(INVALID_ERROR, "invalid amount %q", s)
+		}
+
+		// round half up on the third decimal
+		padded := (fraction + "000")[:3]
+		thousandths, _ := strconv.ParseInt(padded, 10, 64)
+		cents = (thousandths + 5) / 10
+	}
+
+	m := Money(units*100 + cents)
+	if negative {
+		m = -m
+	}
+
+	return m, nil
+}
+
+// Float64 returns the amount in shillings for the database and for float based callers.
+func (m Money) Float64() float64 {
+	return float64(m) / 100
+}
+
+func (m Money) String() string {
+	sign := ""
+	cents := int64(m)
+
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+
+	return fmt.Sprintf("%s%d.%02d", sign, cents/100, cents%100)
+}
+
+// Whole reports whether the amount is in whole shillings.
+func (m Money) Whole() bool {
+	return m%100 == 0
+}
+
+// Split divides the amount into n parts that add up to it exactly. Every part gets the same
+// whole cents and the last part takes the remainder.
+func (m Money) Split(n int) []Money {
+	if n <= 0 {
+		return nil
+	}
+
+	parts := make([]Money, n)
+	share := m / Money(n)
+
+	for i := range parts {
+		parts[i] = share
+	}
+
+	parts[n-1] += m - share*Money(n)
+
+	return parts
+}
+
+// Share returns the part of the amount proportional to part/total, rounded to the nearest cent.
+func (m Money) Share(part, total Money) Money {
+	if total == 0 {
+		return 0
+	}
+
+	return Money(math.Round(float64(m) * float64(part) / float64(total)))
+}
+
+// MinMoney returns the smaller of two amounts.
+func MinMoney(a, b Money) Money {
+	if a < b {
+		return a
+	}
+
+	return b
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+func (m *Money) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	value, err := ParseMoney(strings.Trim(string(data), `"`))
+	if err != nil {
+		return err
+	}
+
+	*m = value
+
+	return nil
+}
+
+// Scan reads DECIMAL columns, which the mysql driver returns as bytes.
+func (m *Money) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*m = 0
+	case []byte:
+		value, err := ParseMoney(string(v))
+		if err != nil {
+			return err
+		}
+
+		*m = value
+	case string:
+		value, err := ParseMoney(v)
+		if err != nil {
+			return err
+		}
+
+		*m = value
+	case float64:
+		*m = MoneyFromFloat(v)
+	case int64:
+		*m = Money(v * 100)
+	default:
+		return fmt.Errorf("cannot scan %T into Money", src)
+	}
+
+	return nil
+}
+
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
+
+// InterfaceMoney reads a DECIMAL aggregate returned as bytes.
+func InterfaceMoney(i interface{}) Money {
+	var m Money
+	if err := m.Scan(i); err != nil {
+		return 0
+	}
+
+	return m
+}
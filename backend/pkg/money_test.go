@@ -0,0 +1,103 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    Money
+		wantErr bool
+	}{
+		{name: "whole", input: "1250", want: 125000},
+		{name: "one decimal", input: "1250.5", want: 125050},
+		{name: "two decimals", input: "1250.55", want: 125055},
+		{name: "surrounding spaces", input: " 10.10 ", want: 1010},
+		{name: "leading dot", input: ".75", want: 75},
+		{name: "plus sign", input: "+3", want: 300},
+		{name: "negative", input: "-3", want: -300},
+		{name: "negative fraction", input: "-0.25", want: -25},
+		{name: "rounds half up", input: "0.005", want: 1},
+		{name: "rounds down", input: "0.004", want: 0},
+		{name: "rounds into units", input: "0.995", want: 100},
+		{name: "ignores past thousandths", input: "1.2349", want: 123},
+		{name: "empty", input: "", wantErr: true},
+		{name: "letters", input: "abc", wantErr: true},
+		{name: "bad fraction", input: "1.2x", wantErr: true},
+		{name: "signed fraction", input: "1.-2", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseMoney(tc.input)
+			if tc.wantErr {
+				require.Error(t, err)
+				require.Equal(t, INVALID_ERROR, ErrorCode(err))
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestMoneySplit(t *testing.T) {
+	tests := []struct {
+		name  string
+		money Money
+		n     int
+		want  []Money
+	}{
+		{name: "even", money: 520000, n: 4, want: []Money{130000, 130000, 130000, 130000}},
+		{name: "remainder to last part", money: 100000, n: 3, want: []Money{33333, 33333, 33334}},
+		{name: "fewer cents than parts", money: 2, n: 3, want: []Money{0, 0, 2}},
+		{name: "single part", money: 1234, n: 1, want: []Money{1234}},
+		{name: "zero parts", money: 1234, n: 0, want: nil},
+		{name: "negative parts", money: 1234, n: -1, want: nil},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.money.Split(tc.n)
+			require.Equal(t, tc.want, got)
+
+			if tc.n > 0 {
+				sum := Money(0)
+				for _, part := range got {
+					sum += part
+				}
+
+				require.Equal(t, tc.money, sum)
+			}
+		})
+	}
+}
+
+func TestMoneyShare(t *testing.T) {
+	tests := []struct {
+		name  string
+		money Money
+		part  Money
+		total Money
+		want  Money
+	}{
+		{name: "half", money: 1000, part: 50, total: 100, want: 500},
+		{name: "whole", money: 1000, part: 100, total: 100, want: 1000},
+		{name: "rounds up", money: 100, part: 2, total: 3, want: 67},
+		{name: "rounds down", money: 100, part: 1, total: 3, want: 33},
+		{name: "zero part", money: 1000, part: 0, total: 100, want: 0},
+		{name: "zero total", money: 1000, part: 50, total: 0, want: 0},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, tc.money.Share(tc.part, tc.total))
+		})
+	}
+}
@@ -1,17 +1,128 @@
 package pkg
 
 import (
+	"bytes"
 	"encoding/base64"
 	"encoding/json"
 	"io"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 )
 
-func GenerateAccessToken(consumerKey string, consumerSecret string) (string, error) {
+// MpesaTimeFormat is the layout Daraja uses for TransTime.
+const MpesaTimeFormat = "20060102150405"
+
+// MpesaB2CTimeFormat is the layout Daraja uses for TransactionCompletedDateTime.
+const MpesaB2CTimeFormat = "02.01.2006 15:04:05"
+
+// C2BCallback is the payload Daraja sends to both the C2B validation and confirmation urls.
+type C2BCallback struct {
+	TransactionType   string `json:"TransactionType"`
+	TransID           string `json:"TransID"`
+	TransTime         string `json:"TransTime"`
+	TransAmount       string `json:"TransAmount"`
+	BusinessShortCode string `json:"BusinessShortCode"`
+	BillRefNumber     string `json:"BillRefNumber"`
+	InvoiceNumber     string `json:"InvoiceNumber"`
+	OrgAccountBalance string `json:"OrgAccountBalance"`
+	ThirdPartyTransID string `json:"ThirdPartyTransID"`
+	MSISDN            string `json:"MSISDN"`
+	FirstName         string `json:"FirstName"`
+	MiddleName        string `json:"MiddleName"`
+	LastName          string `json:"LastName"`
+}
+
+// Validate checks that the fields needed to post a payment are present and well formed.
+func (c *C2BCallback) Validate() error {
+	if strings.TrimSpace(c.TransID) == "" {
+		return Errorf(INVALID_ERROR, "missing TransID")
+	}
+
+	amount, err := c.Amount()
+	if err != nil {
+		return err
+	}
+
+	if amount <= 0 {
+		return Errorf(INVALID_ERROR, "invalid TransAmount: %s", c.TransAmount)
+	}
+
+	if _, err := c.PaidDate(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Amount parses TransAmount.
+func (c *C2BCallback) Amount() (float64, error) {
+	amount, err := strconv.ParseFloat(strings.TrimSpace(c.TransAmount), 64)
+	if err != nil {
+		return 0, Errorf(INVALID_ERROR, "invalid TransAmount: %s", c.TransAmount)
+	}
+
+	return amount, nil
+}
+
+// PaidDate parses TransTime in Nairobi time, an empty TransTime returns the current time.
+func (c *C2BCallback) PaidDate() (time.Time, error) {
+	if strings.TrimSpace(c.TransTime) == "" {
+		return time.Now().In(NairobiLocation()), nil
+	}
+
+	paidDate, err := time.ParseInLocation(MpesaTimeFormat, strings.TrimSpace(c.TransTime), NairobiLocation())
+	if err != nil {
+		return time.Time{}, Errorf(INVALID_ERROR, "invalid TransTime: %s", c.TransTime)
+	}
+
+	return paidDate, nil
+}
+
+// PayingName joins the payer names that were sent.
+func (c *C2BCallback) PayingName() string {
+	names := []string{}
+
+	for _, name := range []string{c.FirstName, c.MiddleName, c.LastName} {
+		if strings.TrimSpace(name) != "" {
+			names = append(names, strings.TrimSpace(name))
+		}
+	}
+
+	return strings.Join(names, " ")
+}
+
+var nairobiLocation = loadNairobiLocation()
+
+// NairobiLocation returns the Africa/Nairobi timezone, falling back to a fixed EAT offset.
+func NairobiLocation() *time.Location {
+	return nairobiLocation
+}
+
+func loadNairobiLocation() *time.Location {
+	loc, err := time.LoadLocation("Africa/Nairobi")
+	if err != nil {
+		return time.FixedZone("EAT", 3*60*60)
+	}
+
+	return loc
+}
+
+// MpesaDefaultBaseURL is the Daraja production api.
+const MpesaDefaultBaseURL = "https://api.safaricom.co.ke"
+
+// GenerateAccessToken gets a Daraja access token from the api at baseURL, the production api
+// when it is empty.
+func GenerateAccessToken(baseURL string, consumerKey string, consumerSecret string) (string, error) {
+	if baseURL == "" {
+		baseURL = MpesaDefaultBaseURL
+	}
+
 	authString := consumerKey + ":" + consumerSecret
 	encodedAuthString := base64.StdEncoding.EncodeToString([]byte(authString))
 
-	url := "https://api.safaricom.co.ke/oauth/v2/generate?grant_type=client_credentials"
+	url := strings.TrimRight(baseURL, "/") + "/oauth/v2/generate?grant_type=client_credentials"
 
 	req, err := http.NewRequest(http.MethodGet, url, nil)
 	if err != nil {
@@ -51,4 +162,284 @@ func GenerateAccessToken(consumerKey string, consumerSecret string) (string, err
 	}
 
 	return accessToken, nil
-}
\ No newline at end of file
+}
+
+// MpesaClient calls the Daraja api on behalf of the configured shortcode.
+type MpesaClient struct {
+	baseURL        string
+	consumerKey    string
+	consumerSecret string
+	shortCode      string
+	passkey        string
+	b2cShortCode   string
+	initiatorName  string
+	credential     string
+	client         *http.Client
+}
+
+func NewMpesaClient(config Config) *MpesaClient {
+	baseURL := config.MPESA_BASE_URL
+	if baseURL == "" {
+		baseURL = MpesaDefaultBaseURL
+	}
+
+	return &MpesaClient{
+		baseURL:        strings.TrimRight(baseURL, "/"),
+		consumerKey:    config.MPESA_CONSUMER_KEY,
+		consumerSecret: config.MPESA_CONSUMER_SECRET,
+		shortCode:      config.MPESA_SHORT_CODE,
+		passkey:        config.MPESA_PASSKEY,
+		b2cShortCode:   config.MPESA_B2C_SHORT_CODE,
+		initiatorName:  config.MPESA_B2C_INITIATOR_NAME,
+		credential:     config.MPESA_B2C_SECURITY_CREDENTIAL,
+		client:         &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+type STKPushRequest struct {
+	PhoneNumber      string
+	Amount           int64
+	AccountReference string
+	TransactionDesc  string
+	CallbackURL      string
+}
+
+type STKPushResponse struct {
+	MerchantRequestID   string `json:"MerchantRequestID"`
+	CheckoutRequestID   string `json:"CheckoutRequestID"`
+	ResponseCode        string `json:"ResponseCode"`
+	ResponseDescription string `json:"ResponseDescription"`
+	CustomerMessage     string `json:"CustomerMessage"`
+}
+
+// STKPush sends a Lipa na M-Pesa Online payment prompt to the customers phone.
+func (c *MpesaClient) STKPush(req STKPushRequest) (STKPushResponse, error) {
+	phoneNumber, err := FormatMpesaPhoneNumber(req.PhoneNumber)
+	if err != nil {
+		return STKPushResponse{}, err
+	}
+
+	timestamp := time.Now().In(NairobiLocation()).Format(MpesaTimeFormat)
+	password := base64.StdEncoding.EncodeToString([]byte(c.shortCode + c.passkey + timestamp))
+
+	body := map[string]any{
+		"BusinessShortCode": c.shortCode,
+		"Password":          password,
+		"Timestamp":         timestamp,
+		"TransactionType":   "CustomerPayBillOnline",
+		"Amount":            req.Amount,
+		"PartyA":            phoneNumber,
+		"PartyB":            c.shortCode,
+		"PhoneNumber":       phoneNumber,
+		"CallBackURL":       req.CallbackURL,
+		"AccountReference":  req.AccountReference,
+		"TransactionDesc":   req.TransactionDesc,
+	}
+
+	var rsp STKPushResponse
+	if err := c.post("/mpesa/stkpush/v1/processrequest", body, &rsp); err != nil {
+		return STKPushResponse{}, err
+	}
+
+	if rsp.ResponseCode != "0" {
+		return STKPushResponse{}, Errorf(
+			INTERNAL_ERROR,
+			"stk push rejected: %s",
+			rsp.ResponseDescription,
+		)
+	}
+
+	return rsp, nil
+}
+
+type B2CRequest struct {
+	PhoneNumber string
+	Amount      int64
+	Remarks     string
+	Occasion    string
+	ResultURL   string
+	TimeoutURL  string
+}
+
+type B2CResponse struct {
+	ConversationID           string `json:"ConversationID"`
+	OriginatorConversationID string `json:"OriginatorConversationID"`
+	ResponseCode             string `json:"ResponseCode"`
+	ResponseDescription      string `json:"ResponseDescription"`
+}
+
+// B2CPayment sends money from the b2c shortcode to the customers phone.
+func (c *MpesaClient) B2CPayment(req B2CRequest) (B2CResponse, error) {
+	phoneNumber, err := FormatMpesaPhoneNumber(req.PhoneNumber)
+	if err != nil {
+		return B2CResponse{}, err
+	}
+
+	body := map[string]any{
+		"InitiatorName":      c.initiatorName,
+		"SecurityCredential": c.credential,
+		"CommandID":          "BusinessPayment",
+		"Amount":             req.Amount,
+		"PartyA":             c.b2cShortCode,
+		"PartyB":             phoneNumber,
+		"Remarks":            req.Remarks,
+		"QueueTimeOutURL":    req.TimeoutURL,
+		"ResultURL":          req.ResultURL,
+		"Occassion":          req.Occasion,
+	}
+
+	var rsp B2CResponse
+	if err := c.post("/mpesa/b2c/v1/paymentrequest", body, &rsp); err != nil {
+		return B2CResponse{}, err
+	}
+
+	if rsp.ResponseCode != "0" {
+		return B2CResponse{}, Errorf(
+			INTERNAL_ERROR,
+			"b2c payment rejected: %s",
+			rsp.ResponseDescription,
+		)
+	}
+
+	return rsp, nil
+}
+
+func (c *MpesaClient) post(path string, body any, out any) error {
+	accessToken, err := GenerateAccessToken(c.baseURL, c.consumerKey, c.consumerSecret)
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return Errorf(INTERNAL_ERROR, "error marshaling body: %v", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, c.baseURL+path, bytes.NewReader(payload))
+	if err != nil {
+		return Errorf(INTERNAL_ERROR, "error creating request: %v", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Add("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return Errorf(INTERNAL_ERROR, "error sending request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return Errorf(INTERNAL_ERROR, "error reading body: %v", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return Errorf(
+			INTERNAL_ERROR,
+			"unexpected response status code: %d: %s",
+			resp.StatusCode,
+			string(respBody),
+		)
+	}
+
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return Errorf(INTERNAL_ERROR, "error unmarshaling body: %v", err)
+	}
+
+	return nil
+}
+
+// STKCallback is the result Daraja posts to the CallBackURL of an stk push.
+type STKCallback struct {
+	Body struct {
+		StkCallback STKCallbackResult `json:"stkCallback"`
+	} `json:"Body"`
+}
+
+type STKCallbackResult struct {
+	MerchantRequestID string `json:"MerchantRequestID"`
+	CheckoutRequestID string `json:"CheckoutRequestID"`
+	ResultCode        int    `json:"ResultCode"`
+	ResultDesc        string `json:"ResultDesc"`
+	CallbackMetadata  struct {
+		Item []STKCallbackItem `json:"Item"`
+	} `json:"CallbackMetadata"`
+}
+
+type STKCallbackItem struct {
+	Name  string `json:"Name"`
+	Value any    `json:"Value"`
+}
+
+// Item returns the metadata value with the given name as a string.
+func (r *STKCallbackResult) Item(name string) string {
+	for _, item := range r.CallbackMetadata.Item {
+		if item.Name != name {
+			continue
+		}
+
+		switch v := item.Value.(type) {
+		case string:
+			return v
+		case float64:
+			return strconv.FormatFloat(v, 'f', -1, 64)
+		}
+	}
+
+	return ""
+}
+
+// B2CResult is the result Daraja posts to the ResultURL of a b2c payment.
+type B2CResult struct {
+	Result struct {
+		ResultType               int    `json:"ResultType"`
+		ResultCode               int    `json:"ResultCode"`
+		ResultDesc               string `json:"ResultDesc"`
+		OriginatorConversationID string `json:"OriginatorConversationID"`
+		ConversationID           string `json:"ConversationID"`
+		TransactionID            string `json:"TransactionID"`
+		ResultParameters         struct {
+			ResultParameter []B2CResultParameter `json:"ResultParameter"`
+		} `json:"ResultParameters"`
+	} `json:"Result"`
+}
+
+type B2CResultParameter struct {
+	Key   string `json:"Key"`
+	Value any    `json:"Value"`
+}
+
+// Parameter returns the result parameter with the given key as a string.
+func (r *B2CResult) Parameter(key string) string {
+	for _, param := range r.Result.ResultParameters.ResultParameter {
+		if param.Key != key {
+			continue
+		}
+
+		switch v := param.Value.(type) {
+		case string:
+			return v
+		case float64:
+			return strconv.FormatFloat(v, 'f', -1, 64)
+		}
+	}
+
+	return ""
+}
+
+// FormatMpesaPhoneNumber converts a local phone number to the 2547XXXXXXXX format daraja expects.
+func FormatMpesaPhoneNumber(phoneNumber string) (string, error) {
+	phoneNumber = strings.TrimPrefix(strings.ReplaceAll(phoneNumber, " ", ""), "+")
+
+	switch {
+	case len(phoneNumber) == 10 && strings.HasPrefix(phoneNumber, "0"):
+		return "254" + phoneNumber[1:], nil
+	case len(phoneNumber) == 9:
+		return "254" + phoneNumber, nil
+	case len(phoneNumber) == 12 && strings.HasPrefix(phoneNumber, "254"):
+		return phoneNumber, nil
+	}
+
+	return "", Errorf(INVALID_ERROR, "invalid phone number: %s", phoneNumber)
+}
@@ -0,0 +1,135 @@
+package pkg
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// PaymentImportLine is a row of a manual payment upload. Rows that cannot be read keep their
+// error so the whole file can be previewed at once.
+type PaymentImportLine struct {
+	LineNumber uint32
+	Identifier string
+	Amount     float64
+	PaidDate   *time.Time
+	Reference  string
+	Error      string
+}
+
+var paymentImportColumns = map[string][]string{
+	"identifier": {"phone", "phone number", "id", "id number", "national id", "client"},
+	"amount":     {"amount"},
+	"date":       {"date", "paid date", "date paid"},
+	"reference":  {"reference", "ref", "receipt", "transaction id"},
+}
+
+var paymentImportDateLayouts = []string{
+	"2006-01-02",
+	"2006/01/02",
+	"02/01/2006",
+	"02-01-2006",
+	"2006-01-02 15:04:05",
+}
+
+// ParsePaymentImport reads a csv or xlsx of manual payments. The first non empty row is the header
+// and needs a phone or id number, amount, date and reference column.
+func ParsePaymentImport(fileName string, r io.Reader) ([]PaymentImportLine, error) {
+	rows, err := ReadSpreadsheet(fileName, r)
+	if err != nil {
+		return nil, err
+	}
+
+	headerRow := -1
+	for i, row := range rows {
+		if strings.TrimSpace(strings.Join(row, "")) != "" {
+			headerRow = i
+			break
+		}
+	}
+
+	if headerRow == -1 {
+		return nil, Errorf(INVALID_ERROR, "file has no rows")
+	}
+
+	columns := map[string]int{}
+	for j, cell := range rows[headerRow] {
+		header := normalizeStatementHeader(cell)
+
+		for column, aliases := range paymentImportColumns {
+			for _, alias := range aliases {
+				if _, ok := columns[column]; !ok && header == alias {
+					columns[column] = j
+				}
+			}
+		}
+	}
+
+	for column := range paymentImportColumns {
+		if _, ok := columns[column]; !ok {
+			return nil, Errorf(INVALID_ERROR, "file is missing the %s column", column)
+		}
+	}
+
+	var lines []PaymentImportLine
+
+	for i, row := range rows[headerRow+1:] {
+		if strings.TrimSpace(strings.Join(row, "")) == "" {
+			continue
+		}
+
+		line := PaymentImportLine{
+			LineNumber: uint32(headerRow + i + 2),
+			Identifier: statementCell(row, columns, "identifier"),
+			Reference:  statementCell(row, columns, "reference"),
+		}
+
+		var errs []string
+
+		if line.Identifier == "" {
+			errs = append(errs, "phone or id number is required")
+		}
+
+		if line.Reference == "" {
+			errs = append(errs, "reference is required")
+		}
+
+		amount, err := parseStatementAmount(statementCell(row, columns, "amount"))
+		if err != nil || amount <= 0 {
+			errs = append(errs, "amount must be a number greater than 0")
+		}
+
+		line.Amount = amount
+
+		paidDate, err := parsePaymentImportDate(statementCell(row, columns, "date"))
+		if err != nil {
+			errs = append(errs, err.Error())
+		} else {
+			line.PaidDate = &paidDate
+		}
+
+		line.Error = strings.Join(errs, "; ")
+		lines = append(lines, line)
+	}
+
+	if len(lines) == 0 {
+		return nil, Errorf(INVALID_ERROR, "file has no payments")
+	}
+
+	return lines, nil
+}
+
+func parsePaymentImportDate(date string) (time.Time, error) {
+	if date == "" {
+		return time.Time{}, fmt.Errorf("date is required")
+	}
+
+	for _, layout := range paymentImportDateLayouts {
+		if t, err := time.ParseInLocation(layout, date, NairobiLocation()); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid date %q, use YYYY-MM-DD", date)
+}
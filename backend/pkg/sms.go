@@ -0,0 +1,6 @@
+package pkg
+
+// SMSSender delivers text messages to a phone number.
+type SMSSender interface {
+	SendSMS(phoneNumber, message string) error
+}
@@ -0,0 +1,48 @@
+package pkg
+
+import (
+	"encoding/csv"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// ReadSpreadsheet returns the rows of an uploaded csv or xlsx file, for xlsx only the first sheet is read.
+func ReadSpreadsheet(fileName string, r io.Reader) ([][]string, error) {
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".csv":
+		reader := csv.NewReader(r)
+		reader.FieldsPerRecord = -1
+		reader.LazyQuotes = true
+		reader.TrimLeadingSpace = true
+
+		rows, err := reader.ReadAll()
+		if err != nil {
+			return nil, Errorf(INVALID_ERROR, "failed to read csv: %v", err)
+		}
+
+		return rows, nil
+	case ".xlsx":
+		file, err := excelize.OpenReader(r)
+		if err != nil {
+			return nil, Errorf(INVALID_ERROR, "failed to open xlsx: %v", err)
+		}
+		defer file.Close()
+
+		sheets := file.GetSheetList()
+		if len(sheets) == 0 {
+			return nil, Errorf(INVALID_ERROR, "xlsx has no sheets")
+		}
+
+		rows, err := file.GetRows(sheets[0])
+		if err != nil {
+			return nil, Errorf(INVALID_ERROR, "failed to read xlsx: %v", err)
+		}
+
+		return rows, nil
+	}
+
+	return nil, Errorf(INVALID_ERROR, "unsupported file type: %s", filepath.Ext(fileName))
+}
@@ -0,0 +1,158 @@
+package pkg
+
+import (
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MpesaStatementLine is a completed paid in line of an M-Pesa paybill statement export.
+type MpesaStatementLine struct {
+	ReceiptNo      string
+	CompletionTime time.Time
+	Details        string
+	PaidIn         float64
+	OtherPartyInfo string
+	AccountNumber  string
+}
+
+// statement exports from the org portal use different date layouts depending on the format chosen.
+var statementTimeFormats = []string{
+	"2006-01-02 15:04:05",
+	"02-01-2006 15:04:05",
+	"02/01/2006 15:04:05",
+	"02-01-2006 15:04",
+	"02/01/2006 15:04",
+	"01-02-06 15:04",
+	"2006-01-02T15:04:05",
+}
+
+// ParseMpesaStatement reads a csv or xlsx paybill statement and returns the completed paid in lines.
+// The header row is searched for since exports start with a few rows of account details.
+func ParseMpesaStatement(fileName string, r io.Reader) ([]MpesaStatementLine, error) {
+	rows, err := ReadSpreadsheet(fileName, r)
+	if err != nil {
+		return nil, err
+	}
+
+	headerRow := -1
+	columns := map[string]int{}
+
+	for i, row := range rows {
+		for _, cell := range row {
+			if normalizeStatementHeader(cell) == "receipt no" {
+				headerRow = i
+
+				break
+			}
+		}
+
+		if headerRow != -1 {
+			for j, cell := range row {
+				columns[normalizeStatementHeader(cell)] = j
+			}
+
+			break
+		}
+	}
+
+	if headerRow == -1 {
+		return nil, Errorf(INVALID_ERROR, "statement has no Receipt No. column")
+	}
+
+	for _, required := range []string{"completion time", "paid in"} {
+		if _, ok := columns[required]; !ok {
+			return nil, Errorf(INVALID_ERROR, "statement has no %s column", required)
+		}
+	}
+
+	lines := []MpesaStatementLine{}
+
+	for i, row := range rows[headerRow+1:] {
+		receiptNo := statementCell(row, columns, "receipt no")
+		if receiptNo == "" {
+			continue
+		}
+
+		if status := statementCell(row, columns, "transaction status"); status != "" &&
+			!strings.EqualFold(status, "completed") {
+			continue
+		}
+
+		paidIn, err := parseStatementAmount(statementCell(row, columns, "paid in"))
+		if err != nil {
+			return nil, Errorf(INVALID_ERROR, "row %d: invalid paid in amount", headerRow+i+2)
+		}
+
+		if paidIn <= 0 {
+			continue
+		}
+
+		completionTime, err := parseStatementTime(statementCell(row, columns, "completion time"))
+		if err != nil {
+			return nil, Errorf(INVALID_ERROR, "row %d: %s", headerRow+i+2, ErrorMessage(err))
+		}
+
+		lines = append(lines, MpesaStatementLine{
+			ReceiptNo:      receiptNo,
+			CompletionTime: completionTime,
+			Details:        statementCell(row, columns, "details"),
+			PaidIn:         paidIn,
+			OtherPartyInfo: statementCell(row, columns, "other party info"),
+			AccountNumber:  statementCell(row, columns, "a/c no"),
+		})
+	}
+
+	return lines, nil
+}
+
+// PhoneNumber returns the phone part of "2547XXXXXXXX - JOHN DOE".
+func (l *MpesaStatementLine) PhoneNumber() string {
+	phoneNumber, _, _ := strings.Cut(l.OtherPartyInfo, " - ")
+
+	return strings.TrimSpace(phoneNumber)
+}
+
+// PayingName returns the name part of "2547XXXXXXXX - JOHN DOE".
+func (l *MpesaStatementLine) PayingName() string {
+	_, name, found := strings.Cut(l.OtherPartyInfo, " - ")
+	if !found {
+		return strings.TrimSpace(l.OtherPartyInfo)
+	}
+
+	return strings.TrimSpace(name)
+}
+
+func normalizeStatementHeader(header string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(header)), ".")
+}
+
+func statementCell(row []string, columns map[string]int, name string) string {
+	idx, ok := columns[name]
+	if !ok || idx >= len(row) {
+		return ""
+	}
+
+	return strings.TrimSpace(row[idx])
+}
+
+func parseStatementAmount(amount string) (float64, error) {
+	amount = strings.ReplaceAll(strings.TrimSpace(amount), ",", "")
+	if amount == "" {
+		return 0, nil
+	}
+
+	return strconv.ParseFloat(amount, 64)
+}
+
+func parseStatementTime(value string) (time.Time, error) {
+	for _, layout := range statementTimeFormats {
+		t, err := time.ParseInLocation(layout, value, NairobiLocation())
+		if err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, Errorf(INVALID_ERROR, "invalid completion time: %s", value)
+}
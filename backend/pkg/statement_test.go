@@ -0,0 +1,127 @@
+package pkg
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMpesaStatement(t *testing.T) {
+	file, err := os.Open("testdata/mpesa_statement.csv")
+	require.NoError(t, err)
+	defer file.Close()
+
+	lines, err := ParseMpesaStatement("mpesa_statement.csv", file)
+	require.NoError(t, err)
+
+	want := []MpesaStatementLine{
+		{
+			ReceiptNo:      "SC1A2B3C4D",
+			CompletionTime: time.Date(2025, 3, 1, 8, 15, 30, 0, NairobiLocation()),
+			Details:        "Pay Bill from 254712345678 - JOHN DOE Acc. 0712345678",
+			PaidIn:         1500,
+			OtherPartyInfo: "254712345678 - JOHN DOE",
+			AccountNumber:  "0712345678",
+		},
+		{
+			ReceiptNo:      "SC2B3C4D5E",
+			CompletionTime: time.Date(2025, 3, 2, 9, 0, 0, 0, NairobiLocation()),
+			Details:        "Pay Bill from 254722000111 - JANE WANJIKU Acc. LOAN",
+			PaidIn:         800,
+			OtherPartyInfo: "254722000111 - JANE WANJIKU",
+			AccountNumber:  "LOAN",
+		},
+		{
+			ReceiptNo:      "SC3C4D5E6F",
+			CompletionTime: time.Date(2025, 3, 3, 10, 30, 0, 0, NairobiLocation()),
+			Details:        "Pay Bill from 254733000222 - PETER OTIENO Acc. 0733000222",
+			PaidIn:         250.5,
+			OtherPartyInfo: "254733000222 - PETER OTIENO",
+			AccountNumber:  "0733000222",
+		},
+		{
+			ReceiptNo:      "SC6F7G8H9I",
+			CompletionTime: time.Date(2025, 3, 4, 11, 45, 0, 0, NairobiLocation()),
+			Details:        "Pay Bill from 254766000555 - GRACE Acc. 0766000555",
+			PaidIn:         100,
+			OtherPartyInfo: "GRACE",
+			AccountNumber:  "0766000555",
+		},
+	}
+
+	require.Len(t, lines, len(want))
+
+	for i := range want {
+		require.Equal(t, want[i].ReceiptNo, lines[i].ReceiptNo)
+		require.True(
+			t,
+			want[i].CompletionTime.Equal(lines[i].CompletionTime),
+			"%s completion time %s",
+			want[i].ReceiptNo,
+			lines[i].CompletionTime,
+		)
+		require.Equal(t, want[i].Details, lines[i].Details)
+		require.Equal(t, want[i].PaidIn, lines[i].PaidIn)
+		require.Equal(t, want[i].OtherPartyInfo, lines[i].OtherPartyInfo)
+		require.Equal(t, want[i].AccountNumber, lines[i].AccountNumber)
+	}
+
+	require.Equal(t, "254712345678", lines[0].PhoneNumber())
+	require.Equal(t, "JOHN DOE", lines[0].PayingName())
+	require.Equal(t, "GRACE", lines[3].PhoneNumber())
+	require.Equal(t, "GRACE", lines[3].PayingName())
+}
+
+func TestParseMpesaStatement_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		fileName string
+		content  string
+		err      string
+	}{
+		{
+			name:     "No Header",
+			fileName: "statement.csv",
+			content:  "Organization Name:,KOKOMED FINANCE\nSC1A2B3C4D,2025-03-01 08:15:30,1500\n",
+			err:      "statement has no Receipt No. column",
+		},
+		{
+			name:     "No Paid In Column",
+			fileName: "statement.csv",
+			content:  "Receipt No.,Completion Time,Withdrawn\nSC1A2B3C4D,2025-03-01 08:15:30,1500\n",
+			err:      "statement has no paid in column",
+		},
+		{
+			name:     "Invalid Amount",
+			fileName: "statement.csv",
+			content:  "Receipt No.,Completion Time,Paid In\nSC1A2B3C4D,2025-03-01 08:15:30,abc\n",
+			err:      "row 2: invalid paid in amount",
+		},
+		{
+			name:     "Invalid Completion Time",
+			fileName: "statement.csv",
+			content:  "Receipt No.,Completion Time,Paid In\nSC1A2B3C4D,1st March 2025,1500\n",
+			err:      "row 2: invalid completion time: 1st March 2025",
+		},
+		{
+			name:     "Unsupported File",
+			fileName: "statement.pdf",
+			content:  "Receipt No.,Completion Time,Paid In\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseMpesaStatement(tc.fileName, strings.NewReader(tc.content))
+			require.Error(t, err)
+			require.Equal(t, INVALID_ERROR, ErrorCode(err))
+
+			if tc.err != "" {
+				require.Equal(t, tc.err, ErrorMessage(err))
+			}
+		})
+	}
+}
@@ -16,7 +16,7 @@ export const loanSchema = z.object({
 	disbursedBy: userSchema.optional(),
 	noOfInstallments: z.number(),
 	installmentsPeriod: z.number(),
-	status: z.enum(['INACTIVE', 'ACTIVE', 'COMPLETED', 'DEFAULTED']),
+	status: z.enum(['INACTIVE', 'DISBURSING', 'ACTIVE', 'COMPLETED', 'DEFAULTED']),
 	processingFee: z.number(),
 	feePaid: z.boolean(),
 	paidAmount: z.number(),
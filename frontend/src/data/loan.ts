@@ -3,6 +3,10 @@ export const statuses = [
 		value: 'inactive',
 		label: 'INACTIVE',
 	},
+	{
+		value: 'disbursing',
+		label: 'DISBURSING',
+	},
 	{
 		value: 'active',
 		label: 'ACTIVE',
@@ -21,6 +21,7 @@ export enum role {
 export enum loanStatus {
 	ACTIVE = 'ACTIVE',
 	INACTIVE = 'INACTIVE',
+	DISBURSING = 'DISBURSING',
 	COMPLETED = 'COMPLETED',
 	DEFAULTED = 'DEFAULTED',
 }
@@ -217,6 +218,8 @@ export interface updateLoanType extends Omit<commonresponse, 'data'> {
 	status?: loanStatus;
 	disburseDate?: string;
 	feePaid?: boolean;
+	mpesaPayout?: boolean;
+	useOverpayment?: boolean;
 }
 
 export interface updateUserType extends Omit<commonresponse, 'data'> {
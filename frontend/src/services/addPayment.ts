@@ -11,7 +11,7 @@ const addPayment = async (data: PaymentFormType) => {
 		}
 
 		const response = await api
-			.post<commonresponse>('/payment/callback', {
+			.post<commonresponse>('/payment/internal', {
 				TransAmount: String(data.TransAmount),
 				TransID: data.TransID,
 				BillRefNumber: data.BillRefNumber,